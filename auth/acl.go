@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata with the token of the caller ("Bearer <token>")
+const authorizationKey = "authorization"
+
+// Allow or deny identities to call methods on keys.
+// Patterns are exact or end with * to match a prefix ("*" matches anything).
+type Rule struct {
+	Name       string   `json:"name"`
+	Identities []string `json:"identities"`
+	// Short method names (e.g. Get, Split)
+	Methods []string `json:"methods"`
+	// Keys of the request (requests without key only match rules without keys)
+	Keys []string `json:"keys,omitempty"`
+	Deny bool     `json:"deny,omitempty"`
+}
+
+type ACLConfig struct {
+	// Identities by bearer token
+	Tokens map[string]string `json:"tokens"`
+	// The first matching rule decides (denied if none matches)
+	Rules []Rule `json:"rules"`
+}
+
+func match(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == value || pattern == "*" {
+			return true
+		}
+		if strings.HasSuffix(pattern, "*") && strings.HasPrefix(value, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the rule applies to the request (key is empty for requests without key)
+func (r *Rule) Matches(identity, method, key string, hasKey bool) bool {
+	if !match(r.Identities, identity) || !match(r.Methods, method) {
+		return false
+	}
+	if len(r.Keys) == 0 {
+		return true
+	}
+	return hasKey && match(r.Keys, key)
+}
+
+// Enforces an ACL config (allows everything while unset).
+// Requests signed with the cluster secret bypass it.
+type ACL struct {
+	// Checks signatures of internal requests (none bypass the ACL if nil)
+	Verifier *Verifier
+	// Key of the node at a location (false for nodes without key),
+	// to check requests by location against rules with keys.
+	// Requests by location only match rules without keys if nil.
+	KeyOf func(ctx context.Context, location uint64) (string, bool, error)
+
+	lock   sync.RWMutex
+	config *ACLConfig
+
+	// Accessed atomically
+	Allowed int64
+	Denied  int64
+}
+
+func (a *ACL) Set(config *ACLConfig) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.config = config
+}
+
+func (a *ACL) Get() *ACLConfig {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.config
+}
+
+func ParseACL(data []byte) (*ACLConfig, error) {
+	var config ACLConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("Invalid ACL: %v", err)
+	}
+	for i, rule := range config.Rules {
+		if len(rule.Identities) == 0 || len(rule.Methods) == 0 {
+			return nil, fmt.Errorf("Rule %d (%s) needs identities and methods", i, rule.Name)
+		}
+	}
+	return &config, nil
+}
+
+// Whether the request is signed with the cluster secret.
+// Protected methods are verified by the Verifier interceptor before the ACL;
+// others are verified here (so replayed signatures are rejected too).
+func (a *ACL) internal(ctx context.Context, method string) bool {
+	if a.Verifier == nil {
+		return false
+	}
+	if Verified(ctx) {
+		return true
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(signatureKey)) == 0 {
+		return false
+	}
+	return a.Verifier.verify(ctx, method) == nil
+}
+
+// Identity of the caller: the CN of its client certificate, or the identity of its token
+func (a *ACL) identity(ctx context.Context, config *ACLConfig) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.PeerCertificates) > 0 {
+			return info.State.PeerCertificates[0].Subject.CommonName
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(authorizationKey); len(values) > 0 {
+		token := strings.TrimPrefix(values[0], "Bearer ")
+		if identity, ok := config.Tokens[token]; ok {
+			return identity
+		}
+	}
+	return ""
+}
+
+// Identity of the caller of the request (empty if it has none, e.g. signed requests without ACLs)
+func (a *ACL) Identity(ctx context.Context) string {
+	config := a.Get()
+	if config == nil {
+		config = &ACLConfig{}
+	}
+	return a.identity(ctx, config)
+}
+
+// Method and key a request is checked for
+type access struct {
+	method string
+	key    string
+	hasKey bool
+}
+
+// Accesses of a request: its key (or the key of the node at its location),
+// the key of each entry of a batch and the keys it resolves (as Get)
+func (a *ACL) accesses(ctx context.Context, method string, req interface{}) ([]access, error) {
+	var accesses []access
+	resolved := func(r interface{}) {
+		if r, ok := r.(interface{ GetResolveKeys() []string }); ok {
+			for _, key := range r.GetResolveKeys() {
+				accesses = append(accesses, access{"Get", key, true})
+			}
+		}
+	}
+
+	switch r := req.(type) {
+	case interface{ GetRequests() []*db.SetRequest }:
+		for _, entry := range r.GetRequests() {
+			accesses = append(accesses, access{method, entry.Key, true})
+			resolved(entry)
+		}
+		if len(accesses) == 0 {
+			accesses = append(accesses, access{method, "", false})
+		}
+	case interface{ GetKey() string }:
+		accesses = append(accesses, access{method, r.GetKey(), true})
+	case interface{ GetLocation() uint64 }:
+		if a.KeyOf == nil {
+			accesses = append(accesses, access{method, "", false})
+			break
+		}
+		key, hasKey, err := a.KeyOf(ctx, r.GetLocation())
+		if err != nil {
+			return nil, err
+		}
+		accesses = append(accesses, access{method, key, hasKey})
+	default:
+		accesses = append(accesses, access{method, "", false})
+	}
+	resolved(req)
+	return accesses, nil
+}
+
+// The first matching rule decides (denied if none matches)
+func (config *ACLConfig) decide(identity string, access access) error {
+	for _, rule := range config.Rules {
+		if !rule.Matches(identity, access.method, access.key, access.hasKey) {
+			continue
+		}
+		if rule.Deny {
+			return status.Errorf(codes.PermissionDenied, "%s denied to %q by rule %s", access.method, identity, rule.Name)
+		}
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%s denied to %q: no rule matched", access.method, identity)
+}
+
+// Decide on a request (returns nil if allowed).
+// Every access of the request must be allowed.
+func (a *ACL) Authorize(ctx context.Context, fullMethod string, req interface{}) error {
+	config := a.Get()
+	if config == nil || a.internal(ctx, fullMethod) {
+		return nil
+	}
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	identity := a.identity(ctx, config)
+	accesses, err := a.accesses(ctx, method, req)
+	if err != nil {
+		atomic.AddInt64(&a.Denied, 1)
+		return status.Errorf(codes.PermissionDenied, "%s denied to %q: %v", method, identity, err)
+	}
+	for _, access := range accesses {
+		if err := config.decide(identity, access); err != nil {
+			atomic.AddInt64(&a.Denied, 1)
+			return err
+		}
+	}
+	atomic.AddInt64(&a.Allowed, 1)
+	return nil
+}
+
+func (a *ACL) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.Authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *ACL) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.Authorize(ss.Context(), info.FullMethod, nil); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// Attach a bearer token to outgoing requests
+type Token string
+
+func (t Token) UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+string(t))
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var testSecret = []byte("secret")
+
+// Incoming context of a request signed for the method
+func signedContext(secret []byte, method string) context.Context {
+	md, _ := metadata.FromOutgoingContext(Signer{Secret: secret}.signContext(context.Background(), method))
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func tokenContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationKey, "Bearer "+token))
+}
+
+func newTestACL(t *testing.T) *ACL {
+	t.Helper()
+	config, err := ParseACL([]byte(`{
+		"tokens": {"t1": "alice"},
+		"rules": [
+			{"name": "public", "identities": ["*"], "methods": ["Get", "GetNode", "Set", "BatchSet"], "keys": ["public/*"]},
+			{"name": "stats", "identities": ["alice"], "methods": ["GetStats"]}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl := &ACL{
+		Verifier: NewVerifier(testSecret, time.Minute),
+		KeyOf: func(ctx context.Context, location uint64) (string, bool, error) {
+			switch location {
+			case 1:
+				return "public/a", true, nil
+			case 2:
+				return "private/a", true, nil
+			case 3:
+				return "", false, nil
+			}
+			return "", false, errors.New("unreachable owner")
+		},
+	}
+	acl.Set(config)
+	return acl
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		req     interface{}
+		allowed bool
+	}{
+		{"key allowed", "Get", &db.GetRequest{Key: "public/a"}, true},
+		{"key denied", "Get", &db.GetRequest{Key: "private/a"}, false},
+		{"location of allowed key", "GetNode", &db.GetNodeRequest{Location: 1}, true},
+		{"location of denied key", "GetNode", &db.GetNodeRequest{Location: 2}, false},
+		{"location without key", "GetNode", &db.GetNodeRequest{Location: 3}, false},
+		{"request without key", "GetStats", &db.Empty{}, true},
+		{"location not resolved", "GetNode", &db.GetNodeRequest{Location: 4}, false},
+		{"batch allowed", "BatchSet", &db.BatchSetRequest{Requests: []*db.SetRequest{{Key: "public/a"}, {Key: "public/b"}}}, true},
+		{"batch with denied entry", "BatchSet", &db.BatchSetRequest{Requests: []*db.SetRequest{{Key: "public/a"}, {Key: "private/b"}}}, false},
+		{"empty batch", "BatchSet", &db.BatchSetRequest{}, false},
+		{"resolve allowed key", "Set", &db.SetRequest{Key: "public/a", ResolveKeys: []string{"public/b"}}, true},
+		{"resolve denied key", "Set", &db.SetRequest{Key: "public/a", ResolveKeys: []string{"private/b"}}, false},
+		{"resolve denied key from node", "GetNode", &db.GetNodeRequest{Location: 1, ResolveKeys: []string{"private/b"}}, false},
+		{"batch resolving denied key", "BatchSet", &db.BatchSetRequest{Requests: []*db.SetRequest{{Key: "public/a", ResolveKeys: []string{"private/b"}}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := newTestACL(t)
+			err := acl.Authorize(tokenContext("t1"), "/db.DbService/"+tt.method, tt.req)
+			if (err == nil) != tt.allowed {
+				t.Errorf("Authorize returned %v, expected allowed: %v", err, tt.allowed)
+			}
+			allowed, denied := int64(0), int64(1)
+			if tt.allowed {
+				allowed, denied = 1, 0
+			}
+			if acl.Allowed != allowed || acl.Denied != denied {
+				t.Errorf("Counted %d allowed and %d denied", acl.Allowed, acl.Denied)
+			}
+		})
+	}
+}
+
+func TestAuthorizeInternal(t *testing.T) {
+	const method = "/db.DbService/Get"
+	denied := &db.GetRequest{Key: "private/a"}
+
+	acl := newTestACL(t)
+	ctx := signedContext(testSecret, method)
+	if err := acl.Authorize(ctx, method, denied); err != nil {
+		t.Fatalf("Signed request denied: %v", err)
+	}
+	if err := acl.Authorize(ctx, method, denied); err == nil {
+		t.Errorf("Replayed signature bypassed the ACL")
+	}
+	if err := acl.Authorize(signedContext([]byte("other"), method), method, denied); err == nil {
+		t.Errorf("Request signed with another secret bypassed the ACL")
+	}
+	if err := acl.Authorize(signedContext(testSecret, "/db.DbService/Set"), method, denied); err == nil {
+		t.Errorf("Signature of another method bypassed the ACL")
+	}
+
+	// Protected methods are verified once by the Verifier interceptor
+	acl = newTestACL(t)
+	ctx = signedContext(testSecret, "/db.DbService/AddChild")
+	if err := acl.Verifier.verify(ctx, "/db.DbService/AddChild"); err != nil {
+		t.Fatal(err)
+	}
+	verified := context.WithValue(ctx, verifiedKey{}, true)
+	if err := acl.Authorize(verified, "/db.DbService/AddChild", &db.AddChildRequest{Location: 2}); err != nil {
+		t.Errorf("Verified request denied: %v", err)
+	}
+	if err := acl.Authorize(ctx, "/db.DbService/AddChild", &db.AddChildRequest{Location: 2}); err == nil {
+		t.Errorf("Signature verified by the interceptor was accepted again")
+	}
+
+	// Without a verifier nothing bypasses the ACL
+	acl = newTestACL(t)
+	acl.Verifier = nil
+	if err := acl.Authorize(signedContext(testSecret, method), method, denied); err == nil {
+		t.Errorf("Signed request bypassed the ACL without a verifier")
+	}
+}
+
+func TestVerifierInterceptor(t *testing.T) {
+	v := NewVerifier(testSecret, time.Minute)
+	call := func(ctx context.Context, method string) (bool, error) {
+		verified := false
+		_, err := v.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			verified = Verified(ctx)
+			return nil, nil
+		})
+		return verified, err
+	}
+
+	const protected, public = "/db.DbService/AddChild", "/db.DbService/Set"
+	if _, err := call(context.Background(), protected); err == nil {
+		t.Errorf("Unsigned request of a protected method accepted")
+	}
+	if verified, err := call(signedContext(testSecret, protected), protected); err != nil || !verified {
+		t.Errorf("Signed request of a protected method not verified: %v", err)
+	}
+	// Other methods are verified only if signed
+	if verified, err := call(context.Background(), public); err != nil || verified {
+		t.Errorf("Unsigned request verified: %v", err)
+	}
+	if verified, err := call(signedContext(testSecret, public), public); err != nil || !verified {
+		t.Errorf("Signed request not verified: %v", err)
+	}
+	if verified, err := call(signedContext([]byte("other"), public), public); err != nil || verified {
+		t.Errorf("Request signed with another secret verified: %v", err)
+	}
+}
+
+func TestVerifierSkew(t *testing.T) {
+	const method = "/db.DbService/Split"
+	signedAt := func(at time.Time) context.Context {
+		timestamp := strconv.FormatInt(at.UnixNano(), 10)
+		nonce := strconv.FormatInt(at.UnixNano(), 16)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			timestampKey, timestamp,
+			nonceKey, nonce,
+			signatureKey, sign(testSecret, method, timestamp, nonce),
+		))
+	}
+
+	v := NewVerifier(testSecret, time.Minute)
+	for _, offset := range []time.Duration{-30 * time.Second, 30 * time.Second} {
+		if err := v.verify(signedAt(time.Now().Add(offset)), method); err != nil {
+			t.Errorf("Signature %v away within the skew rejected: %v", offset, err)
+		}
+	}
+	for _, offset := range []time.Duration{-2 * time.Minute, 2 * time.Minute} {
+		if err := v.verify(signedAt(time.Now().Add(offset)), method); err == nil {
+			t.Errorf("Signature %v away outside the skew accepted", offset)
+		}
+	}
+	// Tolerated by a larger skew
+	v = NewVerifier(testSecret, 5*time.Minute)
+	if err := v.verify(signedAt(time.Now().Add(-2*time.Minute)), method); err != nil {
+		t.Errorf("Signature within the configured skew rejected: %v", err)
+	}
+}
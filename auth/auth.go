@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata of signed requests
+const (
+	timestampKey = "x-auth-timestamp"
+	nonceKey     = "x-auth-nonce"
+	signatureKey = "x-auth-signature"
+)
+
+// Internal and admin methods that must be signed with the cluster secret
+var ProtectedMethods = map[string]bool{
+	"/db.DbService/AddNode":            true,
+	"/db.DbService/AddNodes":           true,
+	"/db.DbService/PrepareReceive":     true,
+	"/db.DbService/AddChild":           true,
+	"/db.DbService/UnlinkChild":        true,
+	"/db.DbService/ExpireNode":         true,
+	"/db.DbService/ResolveBucket":      true,
+	"/db.DbService/RemoveNode":         true,
+	"/db.DbService/RemoveNodes":        true,
+	"/db.DbService/AddAliases":         true,
+	"/db.DbService/RemoveChildren":     true,
+	"/db.DbService/ReplaceChildren":    true,
+	"/db.DbService/Split":              true,
+	"/db.DbService/SetIndexingLock":    true,
+	"/db.DbService/Invalidate":         true,
+	"/db.DbService/Register":           true,
+	"/db.DbService/UpdateMembership":   true,
+	"/db.DbService/ClaimRanges":        true,
+	"/db.DbService/TriggerSplit":       true,
+	"/db.DbService/SetMode":            true,
+	"/db.DbService/Compact":            true,
+	"/db.DbService/ReloadConfig":       true,
+	"/db.DbService/RetryMerge":         true,
+	"/db.DbService/CheckIntegrity":     true,
+	"/db.DbService/GetConfig":          true,
+	"/db.DbService/SetPlacementRules":  true,
+	"/db.DbService/GetSlowLog":         true,
+	"/db.DbService/GetJournal":         true,
+	"/db.DbService/GetHeatmap":         true,
+	"/db.DbService/Decommission":       true,
+	"/db.DbService/CreateSnapshot":     true,
+	"/db.DbService/DeleteSnapshot":     true,
+	"/db.DbService/GetHeads":           true,
+	"/db.DbService/GetACL":             true,
+	"/db.DbService/SetACL":             true,
+	"/db.DbService/SetValidationRules": true,
+	"/db.DbService/RunTask":            true,
+	"/db.DbService/PauseMaintenance":   true,
+	"/db.DbService/CompressChain":      true,
+	"/db.DbService/RedactValue":        true,
+	"/db.DbService/AllocateServer":     true,
+	"/db.DbService/ReleaseServer":      true,
+	"/db.DbService/FollowWAL":          true,
+	"/db.DbService/Promote":            true,
+	"/db.DbService/CopySubtree":        true,
+	// Registrations run actions on every server
+	"/db.DbService/SetMergeFunction":       true,
+	"/db.DbService/SetGlobalMergeFunction": true,
+	"/db.DbService/SetScopedMergeFunction": true,
+}
+
+func sign(secret []byte, method, timestamp, nonce string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + timestamp + "\n" + nonce))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign outgoing requests with the cluster secret
+type Signer struct {
+	Secret []byte
+}
+
+func (s Signer) signContext(ctx context.Context, method string) context.Context {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+	timestamp := strconv.FormatInt(time.Now().UnixNano(), 10)
+	nonceHex := hex.EncodeToString(nonce)
+	return metadata.AppendToOutgoingContext(ctx,
+		timestampKey, timestamp,
+		nonceKey, nonceHex,
+		signatureKey, sign(s.Secret, method, timestamp, nonceHex),
+	)
+}
+
+func (s Signer) UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(s.signContext(ctx, method), method, req, reply, cc, opts...)
+}
+
+// Streams are signed once when opened
+func (s Signer) StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(s.signContext(ctx, method), desc, cc, method, opts...)
+}
+
+// Verify signatures of protected methods
+type Verifier struct {
+	Secret []byte
+	// Max difference between the clocks of servers.
+	// Signatures are remembered for twice as long to reject replays.
+	Skew time.Duration
+
+	lock      sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewVerifier(secret []byte, skew time.Duration) *Verifier {
+	return &Verifier{
+		Secret: secret,
+		Skew:   skew,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+func (v *Verifier) verify(ctx context.Context, method string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	get := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	timestamp, nonce, signature := get(timestampKey), get(nonceKey), get(signatureKey)
+	if len(signature) == 0 {
+		return status.Errorf(codes.PermissionDenied, "%s requires internal authentication", method)
+	}
+	if !hmac.Equal([]byte(signature), []byte(sign(v.Secret, method, timestamp, nonce))) {
+		return status.Errorf(codes.PermissionDenied, "Invalid signature for %s", method)
+	}
+
+	nanos, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "Invalid timestamp %s", timestamp)
+	}
+	now := time.Now()
+	diff := now.Sub(time.Unix(0, nanos))
+	if diff > v.Skew || diff < -v.Skew {
+		return status.Errorf(codes.PermissionDenied, "Signature of %s is outside the allowed skew", method)
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if now.Sub(v.lastSweep) > v.Skew {
+		for s, expiry := range v.seen {
+			if now.After(expiry) {
+				delete(v.seen, s)
+			}
+		}
+		v.lastSweep = now
+	}
+	if _, ok := v.seen[signature]; ok {
+		return status.Errorf(codes.PermissionDenied, "Replayed request for %s", method)
+	}
+	v.seen[signature] = now.Add(2 * v.Skew)
+	return nil
+}
+
+// Marks the context of requests whose signature was verified
+type verifiedKey struct{}
+
+// Whether the signature of the request was verified by a Verifier interceptor
+func Verified(ctx context.Context) bool {
+	verified, _ := ctx.Value(verifiedKey{}).(bool)
+	return verified
+}
+
+// Stream with the context marked as verified
+type verifiedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *verifiedStream) Context() context.Context {
+	return s.ctx
+}
+
+// Whether the request must be marked as verified.
+// Protected methods must be signed; others are verified if they are
+// (e.g. Sets forwarded by peers with fields only peers may use).
+func (v *Verifier) check(ctx context.Context, method string) (bool, error) {
+	if ProtectedMethods[method] {
+		if err := v.verify(ctx, method); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(signatureKey)) == 0 {
+		return false, nil
+	}
+	return v.verify(ctx, method) == nil, nil
+}
+
+func (v *Verifier) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	verified, err := v.check(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		ctx = context.WithValue(ctx, verifiedKey{}, true)
+	}
+	return handler(ctx, req)
+}
+
+func (v *Verifier) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	verified, err := v.check(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if verified {
+		ss = &verifiedStream{ss, context.WithValue(ss.Context(), verifiedKey{}, true)}
+	}
+	return handler(srv, ss)
+}
@@ -30,12 +30,19 @@ type Result struct {
 	GetLatency    float64 `json:"GetLatency"`
 	SetThroughput float64 `json:"SetThroughput"`
 	SetLatency    float64 `json:"SetLatency"`
+	// Kind resolve: a Set followed by a Get of the key,
+	// and a Set resolving the key in the same request
+	SetGetLatency      float64 `json:"SetGetLatency,omitempty"`
+	ResolvedSetLatency float64 `json:"ResolvedSetLatency,omitempty"`
 }
 
 func callWorker(channel chan Result, args *Args) {
-	resp := utils.CallAction("benchmark", []byte(utils.ToString(args)))
+	resp, err := utils.CallAction("benchmark", []byte(utils.ToString(args)))
+	if err != nil {
+		log.Fatalln(err)
+	}
 	var result Result
-	json.Unmarshal(resp, &result)
+	json.Unmarshal(resp.Body, &result)
 	channel <- result
 }
 
@@ -86,6 +93,39 @@ func workerThread(channel chan Result, args *Args) {
 			})
 		}
 		getDur = time.Since(start).Milliseconds()
+	} else if args.Kind == "resolve" {
+		conn, err := grpc.Dial(args.Server, grpc.WithInsecure())
+		if err != nil {
+			log.Fatalf("Cannot connect: %v", err)
+		}
+		defer conn.Close()
+
+		client := db.NewDbServiceClient(conn)
+		// Small values fit in maxResolveBytes
+		data := []byte(strings.Repeat("t", 1024))
+		key := args.Key + randomWords(8)
+		var loc uint64
+
+		start := time.Now()
+		for i := 0; i < 10; i += 1 {
+			resp, _ := client.Set(ctx, &db.SetRequest{Dep: loc, Key: key, Value: data})
+			loc = resp.GetLocation()
+			client.Get(ctx, &db.GetRequest{Location: loc, Key: key})
+		}
+		setGetDur := time.Since(start).Milliseconds()
+
+		start = time.Now()
+		for i := 0; i < 10; i += 1 {
+			resp, _ := client.Set(ctx, &db.SetRequest{Dep: loc, Key: key, Value: data, ResolveKeys: []string{key}})
+			loc = resp.GetLocation()
+		}
+		resolvedDur := time.Since(start).Milliseconds()
+
+		channel <- Result{
+			SetGetLatency:      float64(setGetDur) / 10,
+			ResolvedSetLatency: float64(resolvedDur) / 10,
+		}
+		return
 	} else {
 		// Randomly choose one server
 		address := args.Server
@@ -98,6 +138,7 @@ func workerThread(channel chan Result, args *Args) {
 
 		client := db.NewDbServiceClient(conn)
 
+		data := []byte(value)
 		var keys []string
 		var locs []uint64
 		start := time.Now()
@@ -107,7 +148,7 @@ func workerThread(channel chan Result, args *Args) {
 			resp, _ := client.Set(ctx, &db.SetRequest{
 				Dep:   0,
 				Key:   key,
-				Value: value,
+				Value: data,
 			})
 			locs = append(locs, resp.Location)
 		}
@@ -160,9 +201,13 @@ func main() {
 			result.GetThroughput += r.GetThroughput
 			result.SetLatency += r.SetLatency
 			result.SetThroughput += r.SetThroughput
+			result.SetGetLatency += r.SetGetLatency
+			result.ResolvedSetLatency += r.ResolvedSetLatency
 		}
 		result.GetLatency /= batchSize
 		result.SetLatency /= batchSize
+		result.SetGetLatency /= batchSize
+		result.ResolvedSetLatency /= batchSize
 
 		utils.Print(result)
 	} else {
@@ -183,9 +228,13 @@ func main() {
 			result.GetThroughput += r.GetThroughput
 			result.SetLatency += r.SetLatency
 			result.SetThroughput += r.SetThroughput
+			result.SetGetLatency += r.SetGetLatency
+			result.ResolvedSetLatency += r.ResolvedSetLatency
 		}
 		result.GetLatency /= 10
 		result.SetLatency /= 10
+		result.SetGetLatency /= 10
+		result.ResolvedSetLatency /= 10
 
 		utils.Print(result)
 	}
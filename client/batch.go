@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Whether the entry was not written but may be if sent again
+func Retryable(result *db.BatchSetResult) bool {
+	entry := result.GetStatus()
+	if entry == nil {
+		return false
+	}
+	switch codes.Code(entry.Code) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// Write several keys. Each result has the location of the node or why it was not written
+// (an error means nothing was written).
+// Requests get a RequestId if they have none, so that RetryFailed doesn't write them twice.
+func (c *Client) BatchSet(ctx context.Context, in *db.BatchSetRequest, opts ...grpc.CallOption) (*db.BatchSetResponse, error) {
+	for _, request := range in.Requests {
+		if len(request.RequestId) == 0 {
+			request.RequestId = db.NewRequestId()
+		}
+	}
+	resp, err := c.DbServiceClient.BatchSet(ctx, in, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	// Servers without the batch-results feature answer with Responses
+	resp.Results = resp.AllResults()
+	return resp, nil
+}
+
+// Send the retryable failed requests of the batch again with their RequestIds,
+// and return the results of the whole batch.
+// With Chain, requests are sent again from the first failed one if it is retryable.
+func (c *Client) RetryFailed(ctx context.Context, in *db.BatchSetRequest, resp *db.BatchSetResponse, opts ...grpc.CallOption) (*db.BatchSetResponse, error) {
+	var indices []int
+	for i, result := range resp.Results {
+		if result.GetStatus() == nil {
+			continue
+		}
+		if in.Chain {
+			if Retryable(result) {
+				for j := i; j < len(resp.Results); j++ {
+					indices = append(indices, j)
+				}
+			}
+			break
+		}
+		if Retryable(result) {
+			indices = append(indices, i)
+		}
+	}
+	results := append([]*db.BatchSetResult{}, resp.Results...)
+	if len(indices) == 0 {
+		return &db.BatchSetResponse{Results: results}, nil
+	}
+
+	retry := &db.BatchSetRequest{Chain: in.Chain}
+	for _, i := range indices {
+		retry.Requests = append(retry.Requests, in.Requests[i])
+	}
+	if in.Chain && indices[0] > 0 {
+		// The server sets the deps of the others
+		in.Requests[indices[0]].Dep = results[indices[0]-1].GetLocation()
+	}
+	retried, err := c.BatchSet(ctx, retry, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range indices {
+		results[i] = retried.Results[j]
+	}
+	return &db.BatchSetResponse{Results: results}, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+)
+
+var ErrBranchBusy = errors.New("Branch is used concurrently (use Fork for concurrent writers)")
+
+// Sequence of writes where each one depends on the previous one.
+// The head is the location of the last write.
+// A branch must not be used concurrently.
+type Branch struct {
+	client *Client
+	head   uint64
+	busy   int32
+}
+
+// Branch whose first write depends on the head (e.g. a root)
+func NewBranch(c *Client, head uint64) *Branch {
+	return &Branch{client: c, head: head}
+}
+
+// Parse a branch serialized with String (e.g. passed between actions)
+func ParseBranch(c *Client, value string) (*Branch, error) {
+	head, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid branch %s: %v", value, err)
+	}
+	return NewBranch(c, head), nil
+}
+
+func (b *Branch) String() string {
+	return strconv.FormatUint(b.Head(), 16)
+}
+
+func (b *Branch) Head() uint64 {
+	return atomic.LoadUint64(&b.head)
+}
+
+// New branch from the same head.
+// Writes of both branches become siblings resolved by the merge function.
+func (b *Branch) Fork() *Branch {
+	return NewBranch(b.client, b.Head())
+}
+
+func (b *Branch) acquire() error {
+	if !atomic.CompareAndSwapInt32(&b.busy, 0, 1) {
+		return ErrBranchBusy
+	}
+	return nil
+}
+
+func (b *Branch) release() {
+	atomic.StoreInt32(&b.busy, 0)
+}
+
+// Write the key depending on the head and move the head to the new node
+func (b *Branch) Set(ctx context.Context, key string, value []byte, opts ...grpc.CallOption) (*WriteResult, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+	defer b.release()
+
+	result, err := b.client.Write(ctx, key, value, b.head, opts...)
+	if err != nil {
+		return nil, err
+	}
+	atomic.StoreUint64(&b.head, result.Location)
+	return result, nil
+}
+
+// Read the key as seen from the head
+func (b *Branch) Get(ctx context.Context, key string, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+	defer b.release()
+
+	return b.client.Get(ctx, key, b.head, opts...)
+}
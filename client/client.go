@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/placement"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	db.DbServiceClient
+	// Timeout of each attempt of a Get (0 uses the deadline of the caller)
+	AttemptTimeout time.Duration
+	// Max attempts of a Get stopped by its deadline
+	MaxAttempts int
+	// Remote hops allowed to each walk (0 uses maxRemoteHops of the server, negative for no limit)
+	MaxRemoteHops int32
+	// Check values served from the read cache of a server against the owner before returning them
+	SyncRepair bool
+
+	// Servers that can serve reads besides the primary
+	Replicas   []*Replica
+	Preference ReadPreference
+	// Next replica for round robin
+	next uint32
+
+	mapping   mappingCache
+	placement placement.Rules
+	// Features of the server set by Handshake
+	features map[string]bool
+}
+
+func New(conn db.DbServiceClient) *Client {
+	return &Client{
+		DbServiceClient: conn,
+		MaxAttempts:     3,
+	}
+}
+
+// Get the key from the location, resuming the walk where
+// the previous attempt stopped if it hit its deadline.
+// Pass grpc.Header to see the server that served it (served-by).
+func (c *Client) Get(ctx context.Context, key string, location uint64, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	return c.get(ctx, &db.GetRequest{Key: key, Location: location}, opts...)
+}
+
+// Get the key, accepting a value cached by the receiving server up to maxStaleness old
+// (FromCache and CachedAt are set in that case)
+func (c *Client) GetStale(ctx context.Context, key string, location uint64, maxStaleness time.Duration, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	return c.get(ctx, &db.GetRequest{
+		Key:                key,
+		Location:           location,
+		MaxStalenessMillis: maxStaleness.Milliseconds(),
+	}, opts...)
+}
+
+// Get the newest value of the key at or before the time.
+// List versions with GetKeyNodes to pick the time.
+func (c *Client) GetAsOf(ctx context.Context, key string, location uint64, asOf time.Time, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	return c.get(ctx, &db.GetRequest{
+		Key:      key,
+		Location: location,
+		AsOf:     asOf.UnixNano(),
+	}, opts...)
+}
+
+// Get the key as of the snapshot pinned by CreateSnapshot
+func (c *Client) GetSnapshot(ctx context.Context, key string, location uint64, snapshot string, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	return c.get(ctx, &db.GetRequest{
+		Key:      key,
+		Location: location,
+		Snapshot: snapshot,
+	}, opts...)
+}
+
+func (c *Client) get(ctx context.Context, request *db.GetRequest, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	key, location := request.Key, request.Location
+	hops := request.MaxRemoteHops
+	if hops == 0 {
+		hops = c.MaxRemoteHops
+	}
+	for attempt := 0; attempt < c.MaxAttempts; attempt++ {
+		attemptCtx := ctx
+		cancel := func() {}
+		if c.AttemptTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, c.AttemptTimeout)
+		}
+		resp, err := c.read(attemptCtx, &db.GetRequest{
+			Key:                key,
+			Location:           location,
+			AsOf:               request.AsOf,
+			MaxStalenessMillis: request.MaxStalenessMillis,
+			Snapshot:           request.Snapshot,
+			MaxRemoteHops:      hops,
+			SyncRepair:         request.SyncRepair || c.SyncRepair,
+		}, opts...)
+		cancel()
+		if err != nil {
+			return resp, dberrors.FromStatus(err)
+		}
+		if !resp.DeadlineExceeded {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		// Locations stay valid across splits
+		location = resp.ResumeFrom
+	}
+	return nil, status.Errorf(codes.DeadlineExceeded, "Walk for %s stopped at %x", key, location)
+}
+
+// Outcome of a write and of the merge it triggered
+type WriteResult struct {
+	// Location of the created node
+	Location uint64
+	Merge    db.MergeStatus
+	// Set if Merge is MERGE_FAILED (the merge is retried by the server)
+	MergeError error
+	// Level the write reached
+	Durability db.Durability
+	// Successor the write was linked to if its dep was replaced by a merge (0 otherwise)
+	RedirectedDep uint64
+	// Keys read from the new node (see WriteResolving)
+	Resolved []*db.ResolvedKey
+}
+
+// Set the key with the dep.
+// An error means the node was not created; a failed merge is only reported in the result.
+func (c *Client) Write(ctx context.Context, key string, value []byte, dep uint64, opts ...grpc.CallOption) (*WriteResult, error) {
+	return c.WriteResolving(ctx, key, value, dep, nil, opts...)
+}
+
+// Write the key and read the keys needed next from the new node in the same request.
+// Resolved has an entry per key, with a Status if it was not read (e.g. NOT_FOUND).
+func (c *Client) WriteResolving(ctx context.Context, key string, value []byte, dep uint64, resolveKeys []string, opts ...grpc.CallOption) (*WriteResult, error) {
+	resp, err := c.Set(ctx, &db.SetRequest{
+		Key:         key,
+		Value:       value,
+		Dep:         dep,
+		ResolveKeys: resolveKeys,
+	}, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	result := &WriteResult{
+		Location:      resp.Location,
+		Merge:         resp.MergeStatus,
+		Durability:    resp.Durability,
+		RedirectedDep: resp.RedirectedDep,
+		Resolved:      resp.Resolved,
+	}
+	if resp.MergeStatus == db.MergeStatus_MERGE_FAILED {
+		result.MergeError = errors.New(resp.MergeError)
+	}
+	return result, nil
+}
+
+// Get the key from dep, initializing it to the default if it is unset.
+// Concurrent initializers of the key from the same dep get the same node,
+// and only one of them sees Created.
+func (c *Client) GetOrSet(ctx context.Context, key string, dep uint64, value []byte, opts ...grpc.CallOption) (*db.GetOrSetResponse, error) {
+	resp, err := c.DbServiceClient.GetOrSet(ctx, &db.GetOrSetRequest{
+		Key:     key,
+		Dep:     dep,
+		Default: value,
+	}, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	return resp, nil
+}
+
+// Wait until merges under the root are done on all servers (0 for all roots),
+// or until the deadline of ctx. Check Converged of the report.
+func (c *Client) WaitConverged(ctx context.Context, root uint64, opts ...grpc.CallOption) (*db.ConvergenceReport, error) {
+	resp, err := c.ConvergenceStatus(ctx, &db.ConvergenceStatusRequest{
+		Root:  root,
+		Block: true,
+	}, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	return resp, nil
+}
+
+// Read the key from several branch heads (the children of parent if heads is empty).
+// Returns a DivergedError with the response listing the values of the heads
+// if they disagree.
+func (c *Client) GetMerged(ctx context.Context, key string, heads []uint64, parent uint64, opts ...grpc.CallOption) (*db.GetMergedResponse, error) {
+	resp, err := c.DbServiceClient.GetMerged(ctx, &db.GetMergedRequest{
+		Key:       key,
+		Locations: heads,
+		Parent:    parent,
+	}, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	if resp.Conflict {
+		return resp, &dberrors.DivergedError{Key: key, Heads: int64(len(resp.Heads))}
+	}
+	return resp, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc"
+)
+
+// Add delta to the counter with the dep.
+// Concurrent increments from the same dep all count and never invoke a merge function.
+func (c *Client) Increment(ctx context.Context, key string, delta int64, dep uint64, opts ...grpc.CallOption) (*WriteResult, error) {
+	resp, err := c.Set(ctx, &db.SetRequest{
+		Key:   key,
+		Value: []byte(strconv.FormatInt(delta, 10)),
+		Dep:   dep,
+		Type:  db.NodeType_COUNTER,
+	}, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	return &WriteResult{
+		Location:   resp.Location,
+		Merge:      resp.MergeStatus,
+		Durability: resp.Durability,
+	}, nil
+}
+
+// Value of the counter seen from the location: the sum of its deltas
+// before the location and after it (on all branches under it)
+func (c *Client) Counter(ctx context.Context, key string, location uint64, opts ...grpc.CallOption) (int64, error) {
+	resp, err := c.Get(ctx, key, location, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Type != db.NodeType_COUNTER {
+		return 0, fmt.Errorf("Key %s is not a counter", key)
+	}
+	return strconv.ParseInt(string(resp.Value), 10, 64)
+}
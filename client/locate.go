@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/placement"
+)
+
+// Cached mapping of ranges to servers
+type mappingCache struct {
+	lock    sync.RWMutex
+	mapping *db.Mapping
+}
+
+// Fetch the mapping of ranges and the placement rules to cache them
+func (c *Client) RefreshMapping(ctx context.Context) error {
+	mapping, err := c.DbServiceClient.GetMapping(ctx, &db.Empty{})
+	if err != nil {
+		return err
+	}
+	rules, err := c.DbServiceClient.GetPlacementRules(ctx, &db.Empty{})
+	if err != nil {
+		return err
+	}
+	var placementRules []placement.Rule
+	for _, rule := range rules.Rules {
+		placementRules = append(placementRules, placement.Rule{
+			Prefix:    rule.Prefix,
+			Delimiter: rule.Delimiter,
+		})
+	}
+	c.placement.Set(placementRules, rules.Epoch)
+	c.mapping.lock.Lock()
+	c.mapping.mapping = mapping
+	c.mapping.lock.Unlock()
+	return nil
+}
+
+// Drop the cached mapping (e.g. after a request reached a wrong server)
+func (c *Client) InvalidateMapping() {
+	c.mapping.lock.Lock()
+	c.mapping.mapping = nil
+	c.mapping.lock.Unlock()
+}
+
+// Owners of the keys by the cached mapping,
+// falling back to LocateKeys if it's missing or stale
+func (c *Client) Locate(ctx context.Context, keys []string) (map[string]string, error) {
+	owners := make(map[string]string)
+
+	c.mapping.lock.RLock()
+	mapping := c.mapping.mapping
+	c.mapping.lock.RUnlock()
+	if mapping != nil {
+		for _, key := range keys {
+			hash := c.placement.Hash(key)
+			for _, r := range mapping.Ranges {
+				if hash >= r.Left && hash <= r.Right {
+					owners[key] = r.Server
+					break
+				}
+			}
+		}
+		if len(owners) == len(keys) {
+			return owners, nil
+		}
+	}
+
+	resp, err := c.DbServiceClient.LocateKeys(ctx, &db.LocateKeysRequest{Keys: keys})
+	if err != nil {
+		return nil, err
+	}
+	for _, owner := range resp.Owners {
+		owners[owner.Key] = owner.Address
+	}
+	// The cached mapping is older than the server's
+	if mapping != nil && mapping.Epoch != resp.Epoch {
+		c.InvalidateMapping()
+	}
+	return owners, nil
+}
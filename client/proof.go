@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc"
+)
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// A proof failing verification at a step (-1 for the proof as a whole)
+type ProofError struct {
+	Link     int
+	Location uint64
+	Reason   string
+}
+
+func (e *ProofError) Error() string {
+	if e.Link < 0 {
+		return fmt.Sprintf("Invalid proof: %s", e.Reason)
+	}
+	return fmt.Sprintf("Invalid proof at link %d (%x): %s", e.Link, e.Location, e.Reason)
+}
+
+func (e *ProofError) Is(target error) bool {
+	return target == ErrInvalidProof
+}
+
+// Get the chain from the location to its root proving the value of the key (see VerifyProof)
+func (c *Client) GetProof(ctx context.Context, key string, location uint64, opts ...grpc.CallOption) (*db.Proof, error) {
+	proof, err := c.DbServiceClient.GetProof(ctx, &db.GetProofRequest{Key: key, Location: location}, opts...)
+	if err != nil {
+		return nil, dberrors.FromStatus(err)
+	}
+	return proof, nil
+}
+
+// Check offline that the proof links the value of the key to the root (any root if 0):
+// the content of every node matches its checksum (which covers the dep),
+// each node depends on the next one and the last one is the root.
+// Returns the node defining the key.
+// Checksums are CRC32C, so this catches corrupted and spliced chains, not forged ones.
+func VerifyProof(proof *db.Proof, key string, root uint64) (*db.ProofStep, error) {
+	if proof.Key != key {
+		return nil, &ProofError{Link: -1, Reason: fmt.Sprintf("proof of key %s instead of %s", proof.Key, key)}
+	}
+	if len(proof.Steps) == 0 {
+		return nil, &ProofError{Link: -1, Reason: "no steps"}
+	}
+	if proof.Steps[0].Location != proof.Location {
+		return nil, &ProofError{Link: 0, Location: proof.Steps[0].Location, Reason: fmt.Sprintf("chain starts at %x instead of %x", proof.Steps[0].Location, proof.Location)}
+	}
+	for i, step := range proof.Steps {
+		if step.Checksum == 0 {
+			return nil, &ProofError{Link: i, Location: step.Location, Reason: "no checksum"}
+		}
+		if actual := storage.Checksum(step.Key, step.Value, step.Dep, step.Type, step.Metadata); actual != step.Checksum {
+			return nil, &ProofError{Link: i, Location: step.Location, Reason: fmt.Sprintf("checksum is %08x, expected %08x (from %s)", actual, step.Checksum, step.Server)}
+		}
+		if i+1 < len(proof.Steps) && step.Dep != proof.Steps[i+1].Location {
+			return nil, &ProofError{Link: i, Location: step.Location, Reason: fmt.Sprintf("dep is %x but the next node is %x (from %s)", step.Dep, proof.Steps[i+1].Location, proof.Steps[i+1].Server)}
+		}
+	}
+	last := len(proof.Steps) - 1
+	if end := proof.Steps[last]; end.Dep != math.MaxUint64 {
+		return nil, &ProofError{Link: last, Location: end.Location, Reason: fmt.Sprintf("chain stops at dep %x before a root", end.Dep)}
+	}
+	expected := root
+	if expected == 0 {
+		expected = proof.Root
+	}
+	if proof.Steps[last].Location != expected {
+		return nil, &ProofError{Link: last, Location: proof.Steps[last].Location, Reason: fmt.Sprintf("chain ends at root %x, expected %x", proof.Steps[last].Location, expected)}
+	}
+	if proof.Defining < 0 || int(proof.Defining) > last || proof.Steps[proof.Defining].Key != key {
+		return nil, &ProofError{Link: int(proof.Defining), Reason: fmt.Sprintf("no node of the chain defines %s", key)}
+	}
+	return proof.Steps[proof.Defining], nil
+}
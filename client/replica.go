@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Where reads are sent (writes always go to the primary)
+type ReadPreference int
+
+const (
+	Primary ReadPreference = iota
+	RoundRobin
+	// Lowest latency measured by Ping
+	Nearest
+)
+
+type Replica struct {
+	Address string
+	Conn    db.DbServiceClient
+	// Last measured round trip in nanoseconds (accessed atomically)
+	latency int64
+}
+
+func (r *Replica) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&r.latency))
+}
+
+// Add a server that can serve reads
+func (c *Client) AddReplica(address string, conn db.DbServiceClient) {
+	c.Replicas = append(c.Replicas, &Replica{Address: address, Conn: conn})
+}
+
+// Add the standbys the server lists as replicas of its ranges in the mapping
+// (connected with dial), skipping those already added
+func (c *Client) ConnectReplicas(ctx context.Context, dial func(address string) (db.DbServiceClient, error)) error {
+	mapping, err := c.DbServiceClient.GetMapping(ctx, &db.Empty{})
+	if err != nil {
+		return err
+	}
+	added := make(map[string]bool)
+	for _, replica := range c.Replicas {
+		added[replica.Address] = true
+	}
+	for _, r := range mapping.Ranges {
+		// The first replica is the primary
+		for _, address := range r.Replicas[1:] {
+			if added[address] {
+				continue
+			}
+			conn, err := dial(address)
+			if err != nil {
+				return err
+			}
+			c.AddReplica(address, conn)
+			added[address] = true
+		}
+	}
+	return nil
+}
+
+// Measure the latency of all replicas
+func (c *Client) Ping(ctx context.Context) {
+	for _, replica := range c.Replicas {
+		start := time.Now()
+		latency := int64(math.MaxInt64)
+		if _, err := replica.Conn.ListServers(ctx, &db.Empty{}); err == nil {
+			latency = int64(time.Since(start))
+		}
+		atomic.StoreInt64(&replica.latency, latency)
+	}
+}
+
+// Ping replicas periodically until stop is called
+func (c *Client) StartPinging(interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			c.Ping(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return cancel
+}
+
+// Connections to try for a read, in order
+func (c *Client) readOrder() []db.DbServiceClient {
+	conns := []db.DbServiceClient{c.DbServiceClient}
+	if len(c.Replicas) == 0 || c.Preference == Primary {
+		for _, replica := range c.Replicas {
+			conns = append(conns, replica.Conn)
+		}
+		return conns
+	}
+
+	if c.Preference == RoundRobin {
+		// The primary takes its turn like the replicas
+		for _, replica := range c.Replicas {
+			conns = append(conns, replica.Conn)
+		}
+		n := int(atomic.AddUint32(&c.next, 1)) % len(conns)
+		return append(conns[n:], conns[:n]...)
+	}
+
+	replicas := append([]*Replica(nil), c.Replicas...)
+	sort.SliceStable(replicas, func(i, j int) bool {
+		return replicas[i].Latency() < replicas[j].Latency()
+	})
+	conns = conns[:0]
+	for _, replica := range replicas {
+		conns = append(conns, replica.Conn)
+	}
+	// Fall back to the primary
+	return append(conns, c.DbServiceClient)
+}
+
+// Send the read to the preferred replica and fail over on Unavailable
+func (c *Client) read(ctx context.Context, in *db.GetRequest, opts ...grpc.CallOption) (*db.GetResponse, error) {
+	var err error
+	for _, conn := range c.readOrder() {
+		var resp *db.GetResponse
+		resp, err = conn.Get(ctx, in, opts...)
+		if status.Code(err) != codes.Unavailable {
+			return resp, err
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The server cannot serve this client
+type IncompatibleServerError struct {
+	// APIVersion of the server (0 if it predates GetVersion)
+	ApiVersion    int32
+	MinApiVersion int32
+	// Required features the server lacks
+	Missing []string
+}
+
+func (e *IncompatibleServerError) Error() string {
+	if e.MinApiVersion > db.APIVersion {
+		return fmt.Sprintf("Server requires API version %d or newer (client has %d)", e.MinApiVersion, db.APIVersion)
+	}
+	return fmt.Sprintf("Server (API version %d) lacks features %s", e.ApiVersion, strings.Join(e.Missing, ", "))
+}
+
+// Check the version of the server and remember its features (see Supports).
+// Returns an IncompatibleServerError if it lacks a required feature
+// or no longer accepts this version of the client.
+func (c *Client) Handshake(ctx context.Context, required ...string) (*db.VersionResponse, error) {
+	version, err := c.GetVersion(ctx, &db.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		// Servers before versioning support no optional feature
+		version, err = &db.VersionResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	features := make(map[string]bool)
+	for _, feature := range version.Features {
+		features[feature] = true
+	}
+	c.features = features
+
+	if version.MinApiVersion > db.APIVersion {
+		return version, &IncompatibleServerError{ApiVersion: version.ApiVersion, MinApiVersion: version.MinApiVersion}
+	}
+	var missing []string
+	for _, feature := range required {
+		if !features[feature] {
+			missing = append(missing, feature)
+		}
+	}
+	if len(missing) > 0 {
+		return version, &IncompatibleServerError{ApiVersion: version.ApiVersion, MinApiVersion: version.MinApiVersion, Missing: missing}
+	}
+	if version.ApiVersion < db.APIVersion {
+		log.Printf("Server has API version %d (client has %d): newer features are unavailable", version.ApiVersion, db.APIVersion)
+	}
+	return version, nil
+}
+
+// Whether the server supports the feature (false before Handshake)
+func (c *Client) Supports(feature string) bool {
+	return c.features[feature]
+}
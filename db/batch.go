@@ -0,0 +1,18 @@
+package db
+
+// Result of each request of the batch, from Responses
+// if the server answered without the batch-results feature
+func (r *BatchSetResponse) AllResults() []*BatchSetResult {
+	if len(r.GetResults()) > 0 || len(r.GetResponses()) == 0 {
+		return r.GetResults()
+	}
+	results := make([]*BatchSetResult, len(r.Responses))
+	for i, resp := range r.Responses {
+		results[i] = &BatchSetResult{
+			Result:      &BatchSetResult_Location{Location: resp.Location},
+			MergeStatus: resp.MergeStatus,
+			MergeError:  resp.MergeError,
+		}
+	}
+	return results
+}
@@ -0,0 +1,26 @@
+package db
+
+import "testing"
+
+func TestAllResults(t *testing.T) {
+	results := (&BatchSetResponse{Responses: []*SetResponse{
+		{Location: 1},
+		{Location: 2, MergeStatus: MergeStatus_MERGE_PENDING, MergeError: "slow"},
+	}}).AllResults()
+	if len(results) != 2 || results[0].GetLocation() != 1 || results[1].GetLocation() != 2 ||
+		results[1].MergeStatus != MergeStatus_MERGE_PENDING || results[1].MergeError != "slow" {
+		t.Errorf("Results converted from Responses: %v", results)
+	}
+
+	// Results win over Responses
+	resp := &BatchSetResponse{
+		Responses: []*SetResponse{{Location: 1}},
+		Results:   []*BatchSetResult{{Result: &BatchSetResult_Location{Location: 3}}},
+	}
+	if results := resp.AllResults(); len(results) != 1 || results[0].GetLocation() != 3 {
+		t.Errorf("Results ignored: %v", results)
+	}
+	if results := (&BatchSetResponse{}).AllResults(); len(results) != 0 {
+		t.Errorf("Results of an empty response: %v", results)
+	}
+}
@@ -29,6 +29,219 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type MergeStatus int32
+
+const (
+	// No merge was needed
+	MergeStatus_MERGE_NONE      MergeStatus = 0
+	MergeStatus_MERGE_COMPLETED MergeStatus = 1
+	// Merged later by the background worker
+	MergeStatus_MERGE_PENDING MergeStatus = 2
+	MergeStatus_MERGE_FAILED  MergeStatus = 3
+)
+
+// Enum value maps for MergeStatus.
+var (
+	MergeStatus_name = map[int32]string{
+		0: "MERGE_NONE",
+		1: "MERGE_COMPLETED",
+		2: "MERGE_PENDING",
+		3: "MERGE_FAILED",
+	}
+	MergeStatus_value = map[string]int32{
+		"MERGE_NONE":      0,
+		"MERGE_COMPLETED": 1,
+		"MERGE_PENDING":   2,
+		"MERGE_FAILED":    3,
+	}
+)
+
+func (x MergeStatus) Enum() *MergeStatus {
+	p := new(MergeStatus)
+	*p = x
+	return p
+}
+
+func (x MergeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_db_proto_enumTypes[0].Descriptor()
+}
+
+func (MergeStatus) Type() protoreflect.EnumType {
+	return &file_db_proto_enumTypes[0]
+}
+
+func (x MergeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeStatus.Descriptor instead.
+func (MergeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{0}
+}
+
+// When a write is acknowledged
+type Durability int32
+
+const (
+	// The default of the server (durability in server.json)
+	Durability_DURABILITY_DEFAULT Durability = 0
+	// After the write in memory
+	Durability_ACK_MEMORY Durability = 1
+	// After the log of the disk backend is flushed
+	Durability_ACK_WAL Durability = 2
+	// After the required replicas acknowledge it
+	Durability_ACK_REPLICATED Durability = 3
+)
+
+// Enum value maps for Durability.
+var (
+	Durability_name = map[int32]string{
+		0: "DURABILITY_DEFAULT",
+		1: "ACK_MEMORY",
+		2: "ACK_WAL",
+		3: "ACK_REPLICATED",
+	}
+	Durability_value = map[string]int32{
+		"DURABILITY_DEFAULT": 0,
+		"ACK_MEMORY":         1,
+		"ACK_WAL":            2,
+		"ACK_REPLICATED":     3,
+	}
+)
+
+func (x Durability) Enum() *Durability {
+	p := new(Durability)
+	*p = x
+	return p
+}
+
+func (x Durability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Durability) Descriptor() protoreflect.EnumDescriptor {
+	return file_db_proto_enumTypes[1].Descriptor()
+}
+
+func (Durability) Type() protoreflect.EnumType {
+	return &file_db_proto_enumTypes[1]
+}
+
+func (x Durability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Durability.Descriptor instead.
+func (Durability) EnumDescriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{1}
+}
+
+type NodeType int32
+
+const (
+	NodeType_REGULAR NodeType = 0
+	// Delta of a counter: reads sum the deltas of the key and concurrent deltas are never merged
+	NodeType_COUNTER NodeType = 1
+)
+
+// Enum value maps for NodeType.
+var (
+	NodeType_name = map[int32]string{
+		0: "REGULAR",
+		1: "COUNTER",
+	}
+	NodeType_value = map[string]int32{
+		"REGULAR": 0,
+		"COUNTER": 1,
+	}
+)
+
+func (x NodeType) Enum() *NodeType {
+	p := new(NodeType)
+	*p = x
+	return p
+}
+
+func (x NodeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NodeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_db_proto_enumTypes[2].Descriptor()
+}
+
+func (NodeType) Type() protoreflect.EnumType {
+	return &file_db_proto_enumTypes[2]
+}
+
+func (x NodeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NodeType.Descriptor instead.
+func (NodeType) EnumDescriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{2}
+}
+
+type Mode int32
+
+const (
+	Mode_NORMAL Mode = 0
+	// Reject mutations but keep serving reads and forwards
+	Mode_READONLY Mode = 1
+	// Readonly and refuse to be a split target
+	Mode_DRAINING Mode = 2
+	// Follow the log of a primary and serve no client traffic until promoted
+	Mode_STANDBY Mode = 3
+)
+
+// Enum value maps for Mode.
+var (
+	Mode_name = map[int32]string{
+		0: "NORMAL",
+		1: "READONLY",
+		2: "DRAINING",
+		3: "STANDBY",
+	}
+	Mode_value = map[string]int32{
+		"NORMAL":   0,
+		"READONLY": 1,
+		"DRAINING": 2,
+		"STANDBY":  3,
+	}
+)
+
+func (x Mode) Enum() *Mode {
+	p := new(Mode)
+	*p = x
+	return p
+}
+
+func (x Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_db_proto_enumTypes[3].Descriptor()
+}
+
+func (Mode) Type() protoreflect.EnumType {
+	return &file_db_proto_enumTypes[3]
+}
+
+func (x Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Mode.Descriptor instead.
+func (Mode) EnumDescriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{3}
+}
+
 type GetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -37,6 +250,29 @@ type GetRequest struct {
 	// Empty to get key at location
 	Key      string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
 	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Server caching the result (set by forwarding servers)
+	Subscriber string `protobuf:"bytes,3,opt,name=Subscriber,proto3" json:"Subscriber,omitempty"`
+	// Continue a chain walk on the owner of Location (set by servers)
+	Continuation bool `protobuf:"varint,4,opt,name=Continuation,proto3" json:"Continuation,omitempty"`
+	// Skip nodes created after this time (unix nanoseconds, 0 means now).
+	// Best effort since clocks of servers can be skewed.
+	AsOf int64 `protobuf:"varint,5,opt,name=AsOf,proto3" json:"AsOf,omitempty"`
+	// Accept a value cached by the receiving server up to this old
+	// instead of forwarding to the owner (0 uses the cacheable rules only)
+	MaxStalenessMillis int64 `protobuf:"varint,6,opt,name=MaxStalenessMillis,proto3" json:"MaxStalenessMillis,omitempty"`
+	// Read as of a snapshot (see CreateSnapshot)
+	Snapshot string `protobuf:"bytes,7,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+	// Max servers the chain walk continues on after the first one
+	// (0 uses maxRemoteHops of the server, negative for no limit)
+	MaxRemoteHops int32 `protobuf:"varint,8,opt,name=MaxRemoteHops,proto3" json:"MaxRemoteHops,omitempty"`
+	// Servers walked before (set by servers with Continuation)
+	Path []*WalkStep `protobuf:"bytes,9,rep,name=Path,proto3" json:"Path,omitempty"`
+	// Return the node defining the key without its value
+	// (used by read repair to compare a cached value with the owner)
+	MetadataOnly bool `protobuf:"varint,10,opt,name=MetadataOnly,proto3" json:"MetadataOnly,omitempty"`
+	// Check a value served from the read cache of a non-owner against the owner
+	// before returning it (the cache is repaired if stale)
+	SyncRepair bool `protobuf:"varint,11,opt,name=SyncRepair,proto3" json:"SyncRepair,omitempty"`
 }
 
 func (x *GetRequest) Reset() {
@@ -85,16 +321,82 @@ func (x *GetRequest) GetLocation() uint64 {
 	return 0
 }
 
-type GetResponse struct {
+func (x *GetRequest) GetSubscriber() string {
+	if x != nil {
+		return x.Subscriber
+	}
+	return ""
+}
+
+func (x *GetRequest) GetContinuation() bool {
+	if x != nil {
+		return x.Continuation
+	}
+	return false
+}
+
+func (x *GetRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *GetRequest) GetMaxStalenessMillis() int64 {
+	if x != nil {
+		return x.MaxStalenessMillis
+	}
+	return 0
+}
+
+func (x *GetRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *GetRequest) GetMaxRemoteHops() int32 {
+	if x != nil {
+		return x.MaxRemoteHops
+	}
+	return 0
+}
+
+func (x *GetRequest) GetPath() []*WalkStep {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *GetRequest) GetMetadataOnly() bool {
+	if x != nil {
+		return x.MetadataOnly
+	}
+	return false
+}
+
+func (x *GetRequest) GetSyncRepair() bool {
+	if x != nil {
+		return x.SyncRepair
+	}
+	return false
+}
+
+// Part of a chain walk done by a server
+type WalkStep struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value string `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Where the walk started or continued on the server
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Server   string `protobuf:"bytes,2,opt,name=Server,proto3" json:"Server,omitempty"`
 }
 
-func (x *GetResponse) Reset() {
-	*x = GetResponse{}
+func (x *WalkStep) Reset() {
+	*x = WalkStep{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_db_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -102,13 +404,13 @@ func (x *GetResponse) Reset() {
 	}
 }
 
-func (x *GetResponse) String() string {
+func (x *WalkStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResponse) ProtoMessage() {}
+func (*WalkStep) ProtoMessage() {}
 
-func (x *GetResponse) ProtoReflect() protoreflect.Message {
+func (x *WalkStep) ProtoReflect() protoreflect.Message {
 	mi := &file_db_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -120,31 +422,57 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
-func (*GetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WalkStep.ProtoReflect.Descriptor instead.
+func (*WalkStep) Descriptor() ([]byte, []int) {
 	return file_db_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetResponse) GetValue() string {
+func (x *WalkStep) GetLocation() uint64 {
 	if x != nil {
-		return x.Value
+		return x.Location
+	}
+	return 0
+}
+
+func (x *WalkStep) GetServer() string {
+	if x != nil {
+		return x.Server
 	}
 	return ""
 }
 
-type SetRequest struct {
+type GetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
-	// Dependent location
-	Dep uint64 `protobuf:"varint,3,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	Value []byte `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Served by a non-owner from its read cache
+	FromCache bool `protobuf:"varint,2,opt,name=FromCache,proto3" json:"FromCache,omitempty"`
+	// Creation time of the node (unix nanoseconds)
+	CreatedAt int64 `protobuf:"varint,3,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// The walk stopped before the deadline without finding the key.
+	// Retry from ResumeFrom to continue it.
+	DeadlineExceeded bool   `protobuf:"varint,4,opt,name=DeadlineExceeded,proto3" json:"DeadlineExceeded,omitempty"`
+	ResumeFrom       uint64 `protobuf:"varint,5,opt,name=ResumeFrom,proto3" json:"ResumeFrom,omitempty"`
+	// Node defining the key
+	Location uint64 `protobuf:"varint,6,opt,name=Location,proto3" json:"Location,omitempty"`
+	// When the cached value was fetched from the owner (unix nanoseconds, set if FromCache)
+	CachedAt int64 `protobuf:"varint,7,opt,name=CachedAt,proto3" json:"CachedAt,omitempty"`
+	// Content type given when the value was set
+	ContentType string `protobuf:"bytes,8,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	// COUNTER if Value is the sum of the deltas of the counter
+	Type NodeType `protobuf:"varint,9,opt,name=Type,proto3,enum=db.NodeType" json:"Type,omitempty"`
+	// Servers the walk continued on after the first one
+	RemoteHops int32 `protobuf:"varint,10,opt,name=RemoteHops,proto3" json:"RemoteHops,omitempty"`
+	// The walk continued from the successor of a node replaced by a merge or compression (see ResolveAlias)
+	Redirected bool `protobuf:"varint,11,opt,name=Redirected,proto3" json:"Redirected,omitempty"`
+	// Checksum of the node defining the key (0 if unknown or from an older server)
+	Checksum uint32 `protobuf:"varint,12,opt,name=Checksum,proto3" json:"Checksum,omitempty"`
 }
 
-func (x *SetRequest) Reset() {
-	*x = SetRequest{}
+func (x *GetResponse) Reset() {
+	*x = GetResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_db_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -152,13 +480,13 @@ func (x *SetRequest) Reset() {
 	}
 }
 
-func (x *SetRequest) String() string {
+func (x *GetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetRequest) ProtoMessage() {}
+func (*GetResponse) ProtoMessage() {}
 
-func (x *SetRequest) ProtoReflect() protoreflect.Message {
+func (x *GetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_db_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -170,110 +498,131 @@ func (x *SetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
-func (*SetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
+func (*GetResponse) Descriptor() ([]byte, []int) {
 	return file_db_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SetRequest) GetKey() string {
+func (x *GetResponse) GetValue() []byte {
 	if x != nil {
-		return x.Key
+		return x.Value
 	}
-	return ""
+	return nil
 }
 
-func (x *SetRequest) GetValue() string {
+func (x *GetResponse) GetFromCache() bool {
 	if x != nil {
-		return x.Value
+		return x.FromCache
 	}
-	return ""
+	return false
 }
 
-func (x *SetRequest) GetDep() uint64 {
+func (x *GetResponse) GetCreatedAt() int64 {
 	if x != nil {
-		return x.Dep
+		return x.CreatedAt
 	}
 	return 0
 }
 
-type SetResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GetResponse) GetDeadlineExceeded() bool {
+	if x != nil {
+		return x.DeadlineExceeded
+	}
+	return false
+}
 
-	// Location of the changes
-	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+func (x *GetResponse) GetResumeFrom() uint64 {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return 0
 }
 
-func (x *SetResponse) Reset() {
-	*x = SetResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *GetResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
 	}
+	return 0
 }
 
-func (x *SetResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetResponse) GetCachedAt() int64 {
+	if x != nil {
+		return x.CachedAt
+	}
+	return 0
 }
 
-func (*SetResponse) ProtoMessage() {}
+func (x *GetResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
 
-func (x *SetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GetResponse) GetType() NodeType {
+	if x != nil {
+		return x.Type
 	}
-	return mi.MessageOf(x)
+	return NodeType_REGULAR
 }
 
-// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
-func (*SetResponse) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{3}
+func (x *GetResponse) GetRemoteHops() int32 {
+	if x != nil {
+		return x.RemoteHops
+	}
+	return 0
 }
 
-func (x *SetResponse) GetLocation() uint64 {
+func (x *GetResponse) GetRedirected() bool {
 	if x != nil {
-		return x.Location
+		return x.Redirected
+	}
+	return false
+}
+
+func (x *GetResponse) GetChecksum() uint32 {
+	if x != nil {
+		return x.Checksum
 	}
 	return 0
 }
 
-type Node struct {
+type Event struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Parent location
-	Location uint64   `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
-	Dep      uint64   `protobuf:"varint,2,opt,name=Dep,proto3" json:"Dep,omitempty"`
-	Key      string   `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
-	Value    string   `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
-	Children []uint64 `protobuf:"varint,5,rep,packed,name=Children,proto3" json:"Children,omitempty"`
+	// Increasing per server
+	Sequence uint64 `protobuf:"varint,1,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	// Unix nanoseconds
+	Time int64 `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	// Originating server
+	Server string `protobuf:"bytes,3,opt,name=Server,proto3" json:"Server,omitempty"`
+	// e.g. split.committed, merge.dead_lettered, mode.changed
+	Type       string            `protobuf:"bytes,4,opt,name=Type,proto3" json:"Type,omitempty"`
+	Message    string            `protobuf:"bytes,5,opt,name=Message,proto3" json:"Message,omitempty"`
+	Attributes map[string]string `protobuf:"bytes,6,rep,name=Attributes,proto3" json:"Attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// "critical" for conditions needing an operator (empty otherwise)
+	Severity string `protobuf:"bytes,7,opt,name=Severity,proto3" json:"Severity,omitempty"`
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+func (x *Event) Reset() {
+	*x = Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[4]
+		mi := &file_db_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Node) String() string {
+func (x *Event) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*Event) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[4]
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,71 +633,90 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Node) GetLocation() uint64 {
+func (x *Event) GetSequence() uint64 {
 	if x != nil {
-		return x.Location
+		return x.Sequence
 	}
 	return 0
 }
 
-func (x *Node) GetDep() uint64 {
+func (x *Event) GetTime() int64 {
 	if x != nil {
-		return x.Dep
+		return x.Time
 	}
 	return 0
 }
 
-func (x *Node) GetKey() string {
+func (x *Event) GetServer() string {
 	if x != nil {
-		return x.Key
+		return x.Server
 	}
 	return ""
 }
 
-func (x *Node) GetValue() string {
+func (x *Event) GetType() string {
 	if x != nil {
-		return x.Value
+		return x.Type
 	}
 	return ""
 }
 
-func (x *Node) GetChildren() []uint64 {
+func (x *Event) GetMessage() string {
 	if x != nil {
-		return x.Children
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Event) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
 	}
 	return nil
 }
 
-type AddNodeRequest struct {
+func (x *Event) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+type WatchEventsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Node *Node `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	// Only events of these types (all if empty)
+	Types []string `protobuf:"bytes,1,rep,name=Types,proto3" json:"Types,omitempty"`
+	// Number of past events to send first (at most 256)
+	Replay int32 `protobuf:"varint,2,opt,name=Replay,proto3" json:"Replay,omitempty"`
+	// End the stream after the past events
+	NoFollow bool `protobuf:"varint,3,opt,name=NoFollow,proto3" json:"NoFollow,omitempty"`
 }
 
-func (x *AddNodeRequest) Reset() {
-	*x = AddNodeRequest{}
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[5]
+		mi := &file_db_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddNodeRequest) String() string {
+func (x *WatchEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddNodeRequest) ProtoMessage() {}
+func (*WatchEventsRequest) ProtoMessage() {}
 
-func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[5]
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,48 +727,70 @@ func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddNodeRequest.ProtoReflect.Descriptor instead.
-func (*AddNodeRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AddNodeRequest) GetNode() *Node {
+func (x *WatchEventsRequest) GetTypes() []string {
 	if x != nil {
-		return x.Node
+		return x.Types
 	}
 	return nil
 }
 
-type SplitRequest struct {
+func (x *WatchEventsRequest) GetReplay() int32 {
+	if x != nil {
+		return x.Replay
+	}
+	return 0
+}
+
+func (x *WatchEventsRequest) GetNoFollow() bool {
+	if x != nil {
+		return x.NoFollow
+	}
+	return false
+}
+
+type SlowRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// [L, Mid], [Mid + 1, R]
-	Left        uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
-	Right       uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
-	Mid         uint32 `protobuf:"varint,3,opt,name=Mid,proto3" json:"Mid,omitempty"`
-	LeftServer  string `protobuf:"bytes,4,opt,name=LeftServer,proto3" json:"LeftServer,omitempty"`
-	RightServer string `protobuf:"bytes,5,opt,name=RightServer,proto3" json:"RightServer,omitempty"`
+	// Full method name
+	Method   string `protobuf:"bytes,1,opt,name=Method,proto3" json:"Method,omitempty"`
+	Key      string `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	Location uint64 `protobuf:"varint,3,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Start time (unix nanoseconds)
+	Time           int64 `protobuf:"varint,4,opt,name=Time,proto3" json:"Time,omitempty"`
+	DurationMicros int64 `protobuf:"varint,5,opt,name=DurationMicros,proto3" json:"DurationMicros,omitempty"`
+	// Servers the request went through before this one
+	Hops int32 `protobuf:"varint,6,opt,name=Hops,proto3" json:"Hops,omitempty"`
+	// Time by phase (lockWait, store, walk, link, merge, forward)
+	PhaseMicros map[string]int64 `protobuf:"bytes,7,rep,name=PhaseMicros,proto3" json:"PhaseMicros,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Error       string           `protobuf:"bytes,8,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Trace of the request (empty if untraced)
+	TraceId string `protobuf:"bytes,9,opt,name=TraceId,proto3" json:"TraceId,omitempty"`
 }
 
-func (x *SplitRequest) Reset() {
-	*x = SplitRequest{}
+func (x *SlowRequest) Reset() {
+	*x = SlowRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[6]
+		mi := &file_db_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SplitRequest) String() string {
+func (x *SlowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SplitRequest) ProtoMessage() {}
+func (*SlowRequest) ProtoMessage() {}
 
-func (x *SplitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[6]
+func (x *SlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,73 +801,99 @@ func (x *SplitRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SplitRequest.ProtoReflect.Descriptor instead.
-func (*SplitRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SlowRequest.ProtoReflect.Descriptor instead.
+func (*SlowRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SplitRequest) GetLeft() uint32 {
+func (x *SlowRequest) GetMethod() string {
 	if x != nil {
-		return x.Left
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SlowRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SlowRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
 	}
 	return 0
 }
 
-func (x *SplitRequest) GetRight() uint32 {
+func (x *SlowRequest) GetTime() int64 {
 	if x != nil {
-		return x.Right
+		return x.Time
 	}
 	return 0
 }
 
-func (x *SplitRequest) GetMid() uint32 {
+func (x *SlowRequest) GetDurationMicros() int64 {
 	if x != nil {
-		return x.Mid
+		return x.DurationMicros
 	}
 	return 0
 }
 
-func (x *SplitRequest) GetLeftServer() string {
+func (x *SlowRequest) GetHops() int32 {
 	if x != nil {
-		return x.LeftServer
+		return x.Hops
+	}
+	return 0
+}
+
+func (x *SlowRequest) GetPhaseMicros() map[string]int64 {
+	if x != nil {
+		return x.PhaseMicros
+	}
+	return nil
+}
+
+func (x *SlowRequest) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *SplitRequest) GetRightServer() string {
+func (x *SlowRequest) GetTraceId() string {
 	if x != nil {
-		return x.RightServer
+		return x.TraceId
 	}
 	return ""
 }
 
-type SetMergeFunctionRequest struct {
+type SlowLog struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Location uint64 `protobuf:"varint,1,opt,name=location,proto3" json:"location,omitempty"`
-	// Action name
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Requests []*SlowRequest `protobuf:"bytes,1,rep,name=Requests,proto3" json:"Requests,omitempty"`
 }
 
-func (x *SetMergeFunctionRequest) Reset() {
-	*x = SetMergeFunctionRequest{}
+func (x *SlowLog) Reset() {
+	*x = SlowLog{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[7]
+		mi := &file_db_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetMergeFunctionRequest) String() string {
+func (x *SlowLog) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetMergeFunctionRequest) ProtoMessage() {}
+func (*SlowLog) ProtoMessage() {}
 
-func (x *SetMergeFunctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[7]
+func (x *SlowLog) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -488,51 +904,69 @@ func (x *SetMergeFunctionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetMergeFunctionRequest.ProtoReflect.Descriptor instead.
-func (*SetMergeFunctionRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *SetMergeFunctionRequest) GetLocation() uint64 {
-	if x != nil {
-		return x.Location
-	}
-	return 0
+// Deprecated: Use SlowLog.ProtoReflect.Descriptor instead.
+func (*SlowLog) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SetMergeFunctionRequest) GetName() string {
+func (x *SlowLog) GetRequests() []*SlowRequest {
 	if x != nil {
-		return x.Name
+		return x.Requests
 	}
-	return ""
+	return nil
 }
 
-type SetGlobalMergeFunctionRequest struct {
+// Mutating request received by a server (successful or not)
+type JournalEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Action name
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Full method name
+	Method string `protobuf:"bytes,1,opt,name=Method,proto3" json:"Method,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Location in the request (e.g. the node or the parent)
+	Location uint64 `protobuf:"varint,3,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Location of the node created (0 if none)
+	Created uint64 `protobuf:"varint,4,opt,name=Created,proto3" json:"Created,omitempty"`
+	Dep     uint64 `protobuf:"varint,5,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	// Identity of the caller (empty if it has none) and its address
+	Identity string `protobuf:"bytes,6,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	Peer     string `protobuf:"bytes,7,opt,name=Peer,proto3" json:"Peer,omitempty"`
+	// client, or server for requests forwarded by other servers
+	Origin string `protobuf:"bytes,8,opt,name=Origin,proto3" json:"Origin,omitempty"`
+	// Start time (unix nanoseconds)
+	Time           int64  `protobuf:"varint,9,opt,name=Time,proto3" json:"Time,omitempty"`
+	DurationMicros int64  `protobuf:"varint,10,opt,name=DurationMicros,proto3" json:"DurationMicros,omitempty"`
+	RequestId      string `protobuf:"bytes,11,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+	// Status code of the outcome
+	Code  string `protobuf:"bytes,12,opt,name=Code,proto3" json:"Code,omitempty"`
+	Error string `protobuf:"bytes,13,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Trace of the request (empty if untraced)
+	TraceId string `protobuf:"bytes,14,opt,name=TraceId,proto3" json:"TraceId,omitempty"`
+	// Slower than the slow log threshold of the method (see GetSlowLog)
+	Slow bool `protobuf:"varint,15,opt,name=Slow,proto3" json:"Slow,omitempty"`
+	// Requests of the key not kept since its previous entry (see journalMaxPerKey)
+	Skipped int64 `protobuf:"varint,16,opt,name=Skipped,proto3" json:"Skipped,omitempty"`
 }
 
-func (x *SetGlobalMergeFunctionRequest) Reset() {
-	*x = SetGlobalMergeFunctionRequest{}
+func (x *JournalEntry) Reset() {
+	*x = JournalEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[8]
+		mi := &file_db_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetGlobalMergeFunctionRequest) String() string {
+func (x *JournalEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetGlobalMergeFunctionRequest) ProtoMessage() {}
+func (*JournalEntry) ProtoMessage() {}
 
-func (x *SetGlobalMergeFunctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[8]
+func (x *JournalEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,145 +977,156 @@ func (x *SetGlobalMergeFunctionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetGlobalMergeFunctionRequest.ProtoReflect.Descriptor instead.
-func (*SetGlobalMergeFunctionRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use JournalEntry.ProtoReflect.Descriptor instead.
+func (*JournalEntry) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SetGlobalMergeFunctionRequest) GetName() string {
+func (x *JournalEntry) GetMethod() string {
 	if x != nil {
-		return x.Name
+		return x.Method
 	}
 	return ""
 }
 
-type AddChildRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *JournalEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
 
-	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
-	Child    uint64 `protobuf:"varint,2,opt,name=Child,proto3" json:"Child,omitempty"`
+func (x *JournalEntry) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
 }
 
-func (x *AddChildRequest) Reset() {
-	*x = AddChildRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *JournalEntry) GetCreated() uint64 {
+	if x != nil {
+		return x.Created
 	}
+	return 0
 }
 
-func (x *AddChildRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *JournalEntry) GetDep() uint64 {
+	if x != nil {
+		return x.Dep
+	}
+	return 0
 }
 
-func (*AddChildRequest) ProtoMessage() {}
+func (x *JournalEntry) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
 
-func (x *AddChildRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *JournalEntry) GetPeer() string {
+	if x != nil {
+		return x.Peer
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use AddChildRequest.ProtoReflect.Descriptor instead.
-func (*AddChildRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{9}
+func (x *JournalEntry) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
 }
 
-func (x *AddChildRequest) GetLocation() uint64 {
+func (x *JournalEntry) GetTime() int64 {
 	if x != nil {
-		return x.Location
+		return x.Time
 	}
 	return 0
 }
 
-func (x *AddChildRequest) GetChild() uint64 {
+func (x *JournalEntry) GetDurationMicros() int64 {
 	if x != nil {
-		return x.Child
+		return x.DurationMicros
 	}
 	return 0
 }
 
-type RemoveChildrenRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+func (x *JournalEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
 }
 
-func (x *RemoveChildrenRequest) Reset() {
-	*x = RemoveChildrenRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *JournalEntry) GetCode() string {
+	if x != nil {
+		return x.Code
 	}
+	return ""
 }
 
-func (x *RemoveChildrenRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *JournalEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (*RemoveChildrenRequest) ProtoMessage() {}
-
-func (x *RemoveChildrenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *JournalEntry) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RemoveChildrenRequest.ProtoReflect.Descriptor instead.
-func (*RemoveChildrenRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{10}
+func (x *JournalEntry) GetSlow() bool {
+	if x != nil {
+		return x.Slow
+	}
+	return false
 }
 
-func (x *RemoveChildrenRequest) GetLocation() uint64 {
+func (x *JournalEntry) GetSkipped() int64 {
 	if x != nil {
-		return x.Location
+		return x.Skipped
 	}
 	return 0
 }
 
-type GetNodeRequest struct {
+type GetJournalRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Filters (all entries if unset)
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Matches the location, created node or dep of entries
+	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Time range (unix nanoseconds)
+	Since int64 `protobuf:"varint,3,opt,name=Since,proto3" json:"Since,omitempty"`
+	Until int64 `protobuf:"varint,4,opt,name=Until,proto3" json:"Until,omitempty"`
+	// Most recent entries returned (0 for all)
+	Limit int32 `protobuf:"varint,5,opt,name=Limit,proto3" json:"Limit,omitempty"`
 }
 
-func (x *GetNodeRequest) Reset() {
-	*x = GetNodeRequest{}
+func (x *GetJournalRequest) Reset() {
+	*x = GetJournalRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[11]
+		mi := &file_db_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetNodeRequest) String() string {
+func (x *GetJournalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNodeRequest) ProtoMessage() {}
+func (*GetJournalRequest) ProtoMessage() {}
 
-func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[11]
+func (x *GetJournalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,43 +1137,74 @@ func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNodeRequest.ProtoReflect.Descriptor instead.
-func (*GetNodeRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetJournalRequest.ProtoReflect.Descriptor instead.
+func (*GetJournalRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetNodeRequest) GetLocation() uint64 {
+func (x *GetJournalRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetJournalRequest) GetLocation() uint64 {
 	if x != nil {
 		return x.Location
 	}
 	return 0
 }
 
-type Nodes struct {
+func (x *GetJournalRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetJournalRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *GetJournalRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Journal struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Nodes []*Node `protobuf:"bytes,1,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	// Oldest first
+	Entries []*JournalEntry `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	// Max entries kept by the server
+	Capacity int32 `protobuf:"varint,2,opt,name=Capacity,proto3" json:"Capacity,omitempty"`
 }
 
-func (x *Nodes) Reset() {
-	*x = Nodes{}
+func (x *Journal) Reset() {
+	*x = Journal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[12]
+		mi := &file_db_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Nodes) String() string {
+func (x *Journal) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Nodes) ProtoMessage() {}
+func (*Journal) ProtoMessage() {}
 
-func (x *Nodes) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[12]
+func (x *Journal) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,41 +1215,56 @@ func (x *Nodes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Nodes.ProtoReflect.Descriptor instead.
-func (*Nodes) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use Journal.ProtoReflect.Descriptor instead.
+func (*Journal) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Nodes) GetNodes() []*Node {
+func (x *Journal) GetEntries() []*JournalEntry {
 	if x != nil {
-		return x.Nodes
+		return x.Entries
 	}
 	return nil
 }
 
-type Empty struct {
+func (x *Journal) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type GetMergedRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Branch heads to read from
+	Locations []uint64 `protobuf:"varint,2,rep,packed,name=Locations,proto3" json:"Locations,omitempty"`
+	// Read from the children of the parent if Locations is empty
+	Parent uint64 `protobuf:"varint,3,opt,name=Parent,proto3" json:"Parent,omitempty"`
+	// Remote hops of the walk from each head (see GetRequest)
+	MaxRemoteHops int32 `protobuf:"varint,4,opt,name=MaxRemoteHops,proto3" json:"MaxRemoteHops,omitempty"`
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+func (x *GetMergedRequest) Reset() {
+	*x = GetMergedRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[13]
+		mi := &file_db_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Empty) String() string {
+func (x *GetMergedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*GetMergedRequest) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[13]
+func (x *GetMergedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -784,36 +1275,69 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetMergedRequest.ProtoReflect.Descriptor instead.
+func (*GetMergedRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{10}
 }
 
-type SetIndexingLockRequest struct {
+func (x *GetMergedRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetMergedRequest) GetLocations() []uint64 {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *GetMergedRequest) GetParent() uint64 {
+	if x != nil {
+		return x.Parent
+	}
+	return 0
+}
+
+func (x *GetMergedRequest) GetMaxRemoteHops() int32 {
+	if x != nil {
+		return x.MaxRemoteHops
+	}
+	return 0
+}
+
+type HeadValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lock bool `protobuf:"varint,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	Head uint64 `protobuf:"varint,1,opt,name=Head,proto3" json:"Head,omitempty"`
+	// Unset if the key is not found from the head
+	Found bool `protobuf:"varint,2,opt,name=Found,proto3" json:"Found,omitempty"`
+	// Node defining the key
+	Location uint64 `protobuf:"varint,3,opt,name=Location,proto3" json:"Location,omitempty"`
+	Value    []byte `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
 }
 
-func (x *SetIndexingLockRequest) Reset() {
-	*x = SetIndexingLockRequest{}
+func (x *HeadValue) Reset() {
+	*x = HeadValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[14]
+		mi := &file_db_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetIndexingLockRequest) String() string {
+func (x *HeadValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetIndexingLockRequest) ProtoMessage() {}
+func (*HeadValue) ProtoMessage() {}
 
-func (x *SetIndexingLockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[14]
+func (x *HeadValue) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -824,43 +1348,70 @@ func (x *SetIndexingLockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetIndexingLockRequest.ProtoReflect.Descriptor instead.
-func (*SetIndexingLockRequest) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use HeadValue.ProtoReflect.Descriptor instead.
+func (*HeadValue) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SetIndexingLockRequest) GetLock() bool {
+func (x *HeadValue) GetHead() uint64 {
 	if x != nil {
-		return x.Lock
+		return x.Head
+	}
+	return 0
+}
+
+func (x *HeadValue) GetFound() bool {
+	if x != nil {
+		return x.Found
 	}
 	return false
 }
 
-type SetIndexingLockResponse struct {
+func (x *HeadValue) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *HeadValue) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type GetMergedResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Value all heads agree on
+	Value []byte `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Node defining the key if all heads resolve to the same node
+	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Heads disagree (Value is unset)
+	Conflict bool         `protobuf:"varint,3,opt,name=Conflict,proto3" json:"Conflict,omitempty"`
+	Heads    []*HeadValue `protobuf:"bytes,4,rep,name=Heads,proto3" json:"Heads,omitempty"`
 }
 
-func (x *SetIndexingLockResponse) Reset() {
-	*x = SetIndexingLockResponse{}
+func (x *GetMergedResponse) Reset() {
+	*x = GetMergedResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_db_proto_msgTypes[15]
+		mi := &file_db_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetIndexingLockResponse) String() string {
+func (x *GetMergedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetIndexingLockResponse) ProtoMessage() {}
+func (*GetMergedResponse) ProtoMessage() {}
 
-func (x *SetIndexingLockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_db_proto_msgTypes[15]
+func (x *GetMergedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,750 +1422,16208 @@ func (x *SetIndexingLockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetIndexingLockResponse.ProtoReflect.Descriptor instead.
-func (*SetIndexingLockResponse) Descriptor() ([]byte, []int) {
-	return file_db_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GetMergedResponse.ProtoReflect.Descriptor instead.
+func (*GetMergedResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SetIndexingLockResponse) GetSuccess() bool {
+func (x *GetMergedResponse) GetValue() []byte {
 	if x != nil {
-		return x.Success
+		return x.Value
+	}
+	return nil
+}
+
+func (x *GetMergedResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *GetMergedResponse) GetConflict() bool {
+	if x != nil {
+		return x.Conflict
 	}
 	return false
 }
 
-var File_db_proto protoreflect.FileDescriptor
+func (x *GetMergedResponse) GetHeads() []*HeadValue {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
 
-var file_db_proto_rawDesc = []byte{
-	0x0a, 0x08, 0x64, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x64, 0x62, 0x22, 0x3a,
-	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a,
-	0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x23, 0x0a, 0x0b, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22,
-	0x46, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70, 0x22, 0x29, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x78, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x65, 0x70, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x04, 0x52, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0x2e, 0x0a, 0x0e,
-	0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
-	0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x64,
-	0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x8c, 0x01, 0x0a,
-	0x0c, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x4c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x4d, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x65, 0x66,
-	0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4c,
-	0x65, 0x66, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x69, 0x67,
-	0x68, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x49, 0x0a, 0x17, 0x53,
-	0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x33, 0x0a, 0x1d, 0x53, 0x65, 0x74, 0x47, 0x6c, 0x6f,
-	0x62, 0x61, 0x6c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x41,
-	0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x68,
-	0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x43, 0x68, 0x69, 0x6c, 0x64,
-	0x22, 0x33, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72,
-	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x27, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x05,
-	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x64, 0x62,
-	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x07, 0x0a, 0x05,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2c, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c,
-	0x6f, 0x63, 0x6b, 0x22, 0x33, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x69,
-	0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0x9b, 0x04, 0x0a, 0x09, 0x44, 0x62, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x64, 0x62, 0x2e, 0x53,
-	0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68,
-	0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2b,
-	0x0a, 0x08, 0x41, 0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x13, 0x2e, 0x64, 0x62, 0x2e,
-	0x41, 0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x07, 0x47,
-	0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4e,
-	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x64, 0x62, 0x2e,
-	0x4e, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x0e, 0x2e,
-	0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
-	0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x28, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x07, 0x41, 0x64,
-	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x05, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x12,
-	0x10, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3c,
-	0x0a, 0x10, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65,
-	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x16,
-	0x53, 0x65, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75,
-	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x47,
-	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type SetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Dependent location (required, use CreateRoot for a new DAG).
+	// 18446744073709551614 (db.DepAuto) chains onto the latest node of the key (serialized like SerializeWrites)
+	Dep uint64 `protobuf:"varint,3,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	// Create the node even if Dep does not exist (it is left unlinked)
+	AllowDanglingDep bool `protobuf:"varint,4,opt,name=AllowDanglingDep,proto3" json:"AllowDanglingDep,omitempty"`
+	// Expire the value after this many seconds (0 for never)
+	TtlSeconds int64 `protobuf:"varint,5,opt,name=TtlSeconds,proto3" json:"TtlSeconds,omitempty"`
+	// Write the node at this location without linking it (set by the server
+	// receiving the Set, which links the node to Dep concurrently)
+	Location uint64 `protobuf:"varint,6,opt,name=Location,proto3" json:"Location,omitempty"`
+	// e.g. application/json, text/plain (stored with the node)
+	ContentType string     `protobuf:"bytes,7,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	Durability  Durability `protobuf:"varint,8,opt,name=Durability,proto3,enum=db.Durability" json:"Durability,omitempty"`
+	// Set again with the same id (e.g. a retry) returns the node written by the first one
+	// instead of writing another, for 10 minutes
+	RequestId string `protobuf:"bytes,9,opt,name=RequestId,proto3" json:"RequestId,omitempty"`
+	// COUNTER for a delta of a counter (Value is a decimal integer)
+	Type NodeType `protobuf:"varint,10,opt,name=Type,proto3,enum=db.NodeType" json:"Type,omitempty"`
+	// Order the write after the other writes of the key on its owner (see serializeWrites in server.json)
+	SerializeWrites bool `protobuf:"varint,11,opt,name=SerializeWrites,proto3" json:"SerializeWrites,omitempty"`
+	// Keys read from the new node after the write, returned in Resolved
+	// (at most maxResolveKeys, see ResolvedKey)
+	ResolveKeys []string `protobuf:"bytes,12,rep,name=ResolveKeys,proto3" json:"ResolveKeys,omitempty"`
 }
 
-var (
-	file_db_proto_rawDescOnce sync.Once
-	file_db_proto_rawDescData = file_db_proto_rawDesc
-)
+func (x *SetRequest) Reset() {
+	*x = SetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_db_proto_rawDescGZIP() []byte {
-	file_db_proto_rawDescOnce.Do(func() {
-		file_db_proto_rawDescData = protoimpl.X.CompressGZIP(file_db_proto_rawDescData)
-	})
-	return file_db_proto_rawDescData
+func (x *SetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_db_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
-var file_db_proto_goTypes = []interface{}{
-	(*GetRequest)(nil),                    // 0: db.GetRequest
-	(*GetResponse)(nil),                   // 1: db.GetResponse
-	(*SetRequest)(nil),                    // 2: db.SetRequest
-	(*SetResponse)(nil),                   // 3: db.SetResponse
-	(*Node)(nil),                          // 4: db.Node
-	(*AddNodeRequest)(nil),                // 5: db.AddNodeRequest
-	(*SplitRequest)(nil),                  // 6: db.SplitRequest
-	(*SetMergeFunctionRequest)(nil),       // 7: db.SetMergeFunctionRequest
-	(*SetGlobalMergeFunctionRequest)(nil), // 8: db.SetGlobalMergeFunctionRequest
-	(*AddChildRequest)(nil),               // 9: db.AddChildRequest
-	(*RemoveChildrenRequest)(nil),         // 10: db.RemoveChildrenRequest
-	(*GetNodeRequest)(nil),                // 11: db.GetNodeRequest
-	(*Nodes)(nil),                         // 12: db.Nodes
-	(*Empty)(nil),                         // 13: db.Empty
-	(*SetIndexingLockRequest)(nil),        // 14: db.SetIndexingLockRequest
-	(*SetIndexingLockResponse)(nil),       // 15: db.SetIndexingLockResponse
+func (*SetRequest) ProtoMessage() {}
+
+func (x *SetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_db_proto_depIdxs = []int32{
-	4,  // 0: db.AddNodeRequest.Node:type_name -> db.Node
-	4,  // 1: db.Nodes.Nodes:type_name -> db.Node
-	14, // 2: db.DbService.SetIndexingLock:input_type -> db.SetIndexingLockRequest
-	10, // 3: db.DbService.RemoveChildren:input_type -> db.RemoveChildrenRequest
-	9,  // 4: db.DbService.AddChild:input_type -> db.AddChildRequest
-	11, // 5: db.DbService.GetNode:input_type -> db.GetNodeRequest
-	0,  // 6: db.DbService.Get:input_type -> db.GetRequest
-	2,  // 7: db.DbService.Set:input_type -> db.SetRequest
-	5,  // 8: db.DbService.AddNode:input_type -> db.AddNodeRequest
-	6,  // 9: db.DbService.Split:input_type -> db.SplitRequest
-	7,  // 10: db.DbService.SetMergeFunction:input_type -> db.SetMergeFunctionRequest
-	8,  // 11: db.DbService.SetGlobalMergeFunction:input_type -> db.SetGlobalMergeFunctionRequest
-	15, // 12: db.DbService.SetIndexingLock:output_type -> db.SetIndexingLockResponse
-	13, // 13: db.DbService.RemoveChildren:output_type -> db.Empty
-	4,  // 14: db.DbService.AddChild:output_type -> db.Node
-	4,  // 15: db.DbService.GetNode:output_type -> db.Node
-	1,  // 16: db.DbService.Get:output_type -> db.GetResponse
-	3,  // 17: db.DbService.Set:output_type -> db.SetResponse
-	13, // 18: db.DbService.AddNode:output_type -> db.Empty
-	13, // 19: db.DbService.Split:output_type -> db.Empty
-	13, // 20: db.DbService.SetMergeFunction:output_type -> db.Empty
-	13, // 21: db.DbService.SetGlobalMergeFunction:output_type -> db.Empty
-	12, // [12:22] is the sub-list for method output_type
-	2,  // [2:12] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+
+// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{13}
 }
 
-func init() { file_db_proto_init() }
-func file_db_proto_init() {
-	if File_db_proto != nil {
-		return
+func (x *SetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_db_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddNodeRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *SetRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SetRequest) GetDep() uint64 {
+	if x != nil {
+		return x.Dep
+	}
+	return 0
+}
+
+func (x *SetRequest) GetAllowDanglingDep() bool {
+	if x != nil {
+		return x.AllowDanglingDep
+	}
+	return false
+}
+
+func (x *SetRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *SetRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *SetRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *SetRequest) GetDurability() Durability {
+	if x != nil {
+		return x.Durability
+	}
+	return Durability_DURABILITY_DEFAULT
+}
+
+func (x *SetRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SetRequest) GetType() NodeType {
+	if x != nil {
+		return x.Type
+	}
+	return NodeType_REGULAR
+}
+
+func (x *SetRequest) GetSerializeWrites() bool {
+	if x != nil {
+		return x.SerializeWrites
+	}
+	return false
+}
+
+func (x *SetRequest) GetResolveKeys() []string {
+	if x != nil {
+		return x.ResolveKeys
+	}
+	return nil
+}
+
+type SetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Location of the changes (the node exists whatever the merge status)
+	Location    uint64      `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	MergeStatus MergeStatus `protobuf:"varint,2,opt,name=MergeStatus,proto3,enum=db.MergeStatus" json:"MergeStatus,omitempty"`
+	// Why the merge failed (it is retried in the background)
+	MergeError string `protobuf:"bytes,3,opt,name=MergeError,proto3" json:"MergeError,omitempty"`
+	// Level reached (lower than requested if it was not available)
+	Durability Durability `protobuf:"varint,4,opt,name=Durability,proto3,enum=db.Durability" json:"Durability,omitempty"`
+	// Successor the node was linked to instead of a Dep replaced by a merge or compression (0 if not redirected)
+	RedirectedDep uint64 `protobuf:"varint,5,opt,name=RedirectedDep,proto3" json:"RedirectedDep,omitempty"`
+	// In the order of ResolveKeys
+	Resolved []*ResolvedKey `protobuf:"bytes,6,rep,name=Resolved,proto3" json:"Resolved,omitempty"`
+}
+
+func (x *SetResponse) Reset() {
+	*x = SetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetResponse) ProtoMessage() {}
+
+func (x *SetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_db_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SplitRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *SetResponse) GetMergeStatus() MergeStatus {
+	if x != nil {
+		return x.MergeStatus
+	}
+	return MergeStatus_MERGE_NONE
+}
+
+func (x *SetResponse) GetMergeError() string {
+	if x != nil {
+		return x.MergeError
+	}
+	return ""
+}
+
+func (x *SetResponse) GetDurability() Durability {
+	if x != nil {
+		return x.Durability
+	}
+	return Durability_DURABILITY_DEFAULT
+}
+
+func (x *SetResponse) GetRedirectedDep() uint64 {
+	if x != nil {
+		return x.RedirectedDep
+	}
+	return 0
+}
+
+func (x *SetResponse) GetResolved() []*ResolvedKey {
+	if x != nil {
+		return x.Resolved
+	}
+	return nil
+}
+
+// Value of a key read from a node for ResolveKeys of a Set or GetNode
+type ResolvedKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Node defining the key
+	Location    uint64 `protobuf:"varint,3,opt,name=Location,proto3" json:"Location,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,4,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	ContentType string `protobuf:"bytes,5,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	// Set if the key was not resolved (e.g. NOT_FOUND, or RESOURCE_EXHAUSTED
+	// beyond maxResolveBytes: read it with Get)
+	Status *EntryStatus `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
+}
+
+func (x *ResolvedKey) Reset() {
+	*x = ResolvedKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolvedKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedKey) ProtoMessage() {}
+
+func (x *ResolvedKey) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_db_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetMergeFunctionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedKey.ProtoReflect.Descriptor instead.
+func (*ResolvedKey) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResolvedKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ResolvedKey) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ResolvedKey) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ResolvedKey) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ResolvedKey) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ResolvedKey) GetStatus() *EntryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type BatchSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*SetRequest `protobuf:"bytes,1,rep,name=Requests,proto3" json:"Requests,omitempty"`
+	// Each request depends on the node written by the previous one (Dep of the first is used)
+	Chain bool `protobuf:"varint,2,opt,name=Chain,proto3" json:"Chain,omitempty"`
+}
+
+func (x *BatchSetRequest) Reset() {
+	*x = BatchSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetRequest) ProtoMessage() {}
+
+func (x *BatchSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_db_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetGlobalMergeFunctionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddChildRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveChildrenRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodeRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Nodes); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_db_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetRequest.ProtoReflect.Descriptor instead.
+func (*BatchSetRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchSetRequest) GetRequests() []*SetRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchSetRequest) GetChain() bool {
+	if x != nil {
+		return x.Chain
+	}
+	return false
+}
+
+type BatchSetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// In the order of the requests, only if all of them were written
+	// (the response of servers without the batch-results feature)
+	Responses []*SetResponse `protobuf:"bytes,1,rep,name=Responses,proto3" json:"Responses,omitempty"`
+	// In the order of the requests
+	Results []*BatchSetResult `protobuf:"bytes,2,rep,name=Results,proto3" json:"Results,omitempty"`
+}
+
+func (x *BatchSetResponse) Reset() {
+	*x = BatchSetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetResponse) ProtoMessage() {}
+
+func (x *BatchSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_db_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetIndexingLockRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetResponse.ProtoReflect.Descriptor instead.
+func (*BatchSetResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchSetResponse) GetResponses() []*SetResponse {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+func (x *BatchSetResponse) GetResults() []*BatchSetResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BatchSetResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*BatchSetResult_Location
+	//	*BatchSetResult_Status
+	Result      isBatchSetResult_Result `protobuf_oneof:"Result"`
+	MergeStatus MergeStatus             `protobuf:"varint,3,opt,name=MergeStatus,proto3,enum=db.MergeStatus" json:"MergeStatus,omitempty"`
+	MergeError  string                  `protobuf:"bytes,4,opt,name=MergeError,proto3" json:"MergeError,omitempty"`
+}
+
+func (x *BatchSetResult) Reset() {
+	*x = BatchSetResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchSetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSetResult) ProtoMessage() {}
+
+func (x *BatchSetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_db_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetIndexingLockResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSetResult.ProtoReflect.Descriptor instead.
+func (*BatchSetResult) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{18}
+}
+
+func (m *BatchSetResult) GetResult() isBatchSetResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *BatchSetResult) GetLocation() uint64 {
+	if x, ok := x.GetResult().(*BatchSetResult_Location); ok {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *BatchSetResult) GetStatus() *EntryStatus {
+	if x, ok := x.GetResult().(*BatchSetResult_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *BatchSetResult) GetMergeStatus() MergeStatus {
+	if x != nil {
+		return x.MergeStatus
+	}
+	return MergeStatus_MERGE_NONE
+}
+
+func (x *BatchSetResult) GetMergeError() string {
+	if x != nil {
+		return x.MergeError
+	}
+	return ""
+}
+
+type isBatchSetResult_Result interface {
+	isBatchSetResult_Result()
+}
+
+type BatchSetResult_Location struct {
+	// Written
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3,oneof"`
+}
+
+type BatchSetResult_Status struct {
+	// Not written (retryable if the code is UNAVAILABLE, ABORTED or DEADLINE_EXCEEDED)
+	Status *EntryStatus `protobuf:"bytes,2,opt,name=Status,proto3,oneof"`
+}
+
+func (*BatchSetResult_Location) isBatchSetResult_Result() {}
+
+func (*BatchSetResult_Status) isBatchSetResult_Result() {}
+
+// Status of an entry of a batch (codes of grpc)
+type EntryStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    uint32 `protobuf:"varint,1,opt,name=Code,proto3" json:"Code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+}
+
+func (x *EntryStatus) Reset() {
+	*x = EntryStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntryStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryStatus) ProtoMessage() {}
+
+func (x *EntryStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_db_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   16,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_db_proto_goTypes,
-		DependencyIndexes: file_db_proto_depIdxs,
-		MessageInfos:      file_db_proto_msgTypes,
-	}.Build()
-	File_db_proto = out.File
-	file_db_proto_rawDesc = nil
-	file_db_proto_goTypes = nil
-	file_db_proto_depIdxs = nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryStatus.ProtoReflect.Descriptor instead.
+func (*EntryStatus) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EntryStatus) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *EntryStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Creation time (unix nanoseconds)
+	Time int64 `protobuf:"varint,2,opt,name=Time,proto3" json:"Time,omitempty"`
+	// Nodes without children on all servers at that time
+	Heads []uint64 `protobuf:"varint,3,rep,packed,name=Heads,proto3" json:"Heads,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Snapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snapshot) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Snapshot) GetHeads() []uint64 {
+	if x != nil {
+		return x.Heads
+	}
+	return nil
+}
+
+type Snapshots struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=Snapshots,proto3" json:"Snapshots,omitempty"`
+}
+
+func (x *Snapshots) Reset() {
+	*x = Snapshots{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshots) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshots) ProtoMessage() {}
+
+func (x *Snapshots) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshots.ProtoReflect.Descriptor instead.
+func (*Snapshots) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Snapshots) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type Heads struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locations []uint64 `protobuf:"varint,1,rep,packed,name=Locations,proto3" json:"Locations,omitempty"`
+}
+
+func (x *Heads) Reset() {
+	*x = Heads{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Heads) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heads) ProtoMessage() {}
+
+func (x *Heads) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heads.ProtoReflect.Descriptor instead.
+func (*Heads) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Heads) GetLocations() []uint64 {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Pushed by the seed
+	Snapshot *Snapshot `protobuf:"bytes,2,opt,name=Snapshot,proto3" json:"Snapshot,omitempty"`
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type DeleteSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Pushed by the seed
+	Replicated bool `protobuf:"varint,2,opt,name=Replicated,proto3" json:"Replicated,omitempty"`
+}
+
+func (x *DeleteSnapshotRequest) Reset() {
+	*x = DeleteSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSnapshotRequest) ProtoMessage() {}
+
+func (x *DeleteSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteSnapshotRequest) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+type ApplyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Location to read the current value from and to depend on
+	Dep uint64 `protobuf:"varint,2,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	// Builtin (increment, append, max) or the name of an action
+	Transform string `protobuf:"bytes,3,opt,name=Transform,proto3" json:"Transform,omitempty"`
+	// Argument of the transform (e.g. the delta of increment)
+	Arg []byte `protobuf:"bytes,4,opt,name=Arg,proto3" json:"Arg,omitempty"`
+	// Other keys read by an action (must have the same owner as Key)
+	ReadKeys []string `protobuf:"bytes,5,rep,name=ReadKeys,proto3" json:"ReadKeys,omitempty"`
+}
+
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRequest) ProtoMessage() {}
+
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ApplyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetDep() uint64 {
+	if x != nil {
+		return x.Dep
+	}
+	return 0
+}
+
+func (x *ApplyRequest) GetTransform() string {
+	if x != nil {
+		return x.Transform
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetArg() []byte {
+	if x != nil {
+		return x.Arg
+	}
+	return nil
+}
+
+func (x *ApplyRequest) GetReadKeys() []string {
+	if x != nil {
+		return x.ReadKeys
+	}
+	return nil
+}
+
+type ApplyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Location of the written node
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Value    []byte `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *ApplyResponse) Reset() {
+	*x = ApplyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyResponse) ProtoMessage() {}
+
+func (x *ApplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyResponse.ProtoReflect.Descriptor instead.
+func (*ApplyResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ApplyResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ApplyResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type GetOrSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Location to read from and to depend on if the key is unset
+	Dep uint64 `protobuf:"varint,2,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	// Written if the key is unset
+	Default []byte `protobuf:"bytes,3,opt,name=Default,proto3" json:"Default,omitempty"`
+	// Expire the default after this many seconds (0 for never)
+	TtlSeconds int64 `protobuf:"varint,4,opt,name=TtlSeconds,proto3" json:"TtlSeconds,omitempty"`
+}
+
+func (x *GetOrSetRequest) Reset() {
+	*x = GetOrSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrSetRequest) ProtoMessage() {}
+
+func (x *GetOrSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrSetRequest.ProtoReflect.Descriptor instead.
+func (*GetOrSetRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetOrSetRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetOrSetRequest) GetDep() uint64 {
+	if x != nil {
+		return x.Dep
+	}
+	return 0
+}
+
+func (x *GetOrSetRequest) GetDefault() []byte {
+	if x != nil {
+		return x.Default
+	}
+	return nil
+}
+
+func (x *GetOrSetRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type GetOrSetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value []byte `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Node defining the key
+	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	// The default was written by this call
+	Created bool `protobuf:"varint,3,opt,name=Created,proto3" json:"Created,omitempty"`
+}
+
+func (x *GetOrSetResponse) Reset() {
+	*x = GetOrSetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrSetResponse) ProtoMessage() {}
+
+func (x *GetOrSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrSetResponse.ProtoReflect.Descriptor instead.
+func (*GetOrSetResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetOrSetResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *GetOrSetResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *GetOrSetResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Parent location
+	Location uint64   `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Dep      uint64   `protobuf:"varint,2,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	Key      string   `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value    []byte   `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Children []uint64 `protobuf:"varint,5,rep,packed,name=Children,proto3" json:"Children,omitempty"`
+	// Creation time (unix nanoseconds)
+	CreatedAt int64 `protobuf:"varint,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// Only set when requested
+	Stats *NodeStats `protobuf:"bytes,7,opt,name=Stats,proto3" json:"Stats,omitempty"`
+	// e.g. activation-id of the action creating the node
+	Metadata map[string]string `protobuf:"bytes,8,rep,name=Metadata,proto3" json:"Metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Total number of children (Children can be a page of them)
+	ChildCount int64 `protobuf:"varint,9,opt,name=ChildCount,proto3" json:"ChildCount,omitempty"`
+	// Cursor of the next page of children (0 if none)
+	NextChildrenCursor uint64 `protobuf:"varint,10,opt,name=NextChildrenCursor,proto3" json:"NextChildrenCursor,omitempty"`
+	// Incremented whenever the children change
+	ChildrenVersion uint64 `protobuf:"varint,11,opt,name=ChildrenVersion,proto3" json:"ChildrenVersion,omitempty"`
+	// Set in AddChild responses when the node has reached its merge threshold
+	// and no merge of it is in flight (one AddChild at a time runs the merge)
+	ConflictDetected bool `protobuf:"varint,12,opt,name=ConflictDetected,proto3" json:"ConflictDetected,omitempty"`
+	// When the value expires (unix nanoseconds, 0 for never)
+	ExpiresAt int64 `protobuf:"varint,13,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	// Merges of the node were given up on, so its children diverge
+	UnresolvedConflict bool `protobuf:"varint,14,opt,name=UnresolvedConflict,proto3" json:"UnresolvedConflict,omitempty"`
+	// Roots and buckets have no key
+	Keyless     bool     `protobuf:"varint,15,opt,name=Keyless,proto3" json:"Keyless,omitempty"`
+	ContentType string   `protobuf:"bytes,16,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	Type        NodeType `protobuf:"varint,17,opt,name=Type,proto3,enum=db.NodeType" json:"Type,omitempty"`
+	// CRC32C of key, value, dep, type and metadata set when the node is created
+	// (0 if unknown, e.g. nodes of older servers)
+	Checksum uint32 `protobuf:"varint,18,opt,name=Checksum,proto3" json:"Checksum,omitempty"`
+	// Location requested from GetNode if it was replaced by this node (0 if not redirected)
+	RedirectedFrom uint64 `protobuf:"varint,19,opt,name=RedirectedFrom,proto3" json:"RedirectedFrom,omitempty"`
+	// Keys requested by GetNodeRequest.ResolveKeys
+	Resolved []*ResolvedKey `protobuf:"bytes,20,rep,name=Resolved,proto3" json:"Resolved,omitempty"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Node) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *Node) GetDep() uint64 {
+	if x != nil {
+		return x.Dep
+	}
+	return 0
+}
+
+func (x *Node) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Node) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Node) GetChildren() []uint64 {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Node) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Node) GetStats() *NodeStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Node) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Node) GetChildCount() int64 {
+	if x != nil {
+		return x.ChildCount
+	}
+	return 0
+}
+
+func (x *Node) GetNextChildrenCursor() uint64 {
+	if x != nil {
+		return x.NextChildrenCursor
+	}
+	return 0
+}
+
+func (x *Node) GetChildrenVersion() uint64 {
+	if x != nil {
+		return x.ChildrenVersion
+	}
+	return 0
+}
+
+func (x *Node) GetConflictDetected() bool {
+	if x != nil {
+		return x.ConflictDetected
+	}
+	return false
+}
+
+func (x *Node) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Node) GetUnresolvedConflict() bool {
+	if x != nil {
+		return x.UnresolvedConflict
+	}
+	return false
+}
+
+func (x *Node) GetKeyless() bool {
+	if x != nil {
+		return x.Keyless
+	}
+	return false
+}
+
+func (x *Node) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Node) GetType() NodeType {
+	if x != nil {
+		return x.Type
+	}
+	return NodeType_REGULAR
+}
+
+func (x *Node) GetChecksum() uint32 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *Node) GetRedirectedFrom() uint64 {
+	if x != nil {
+		return x.RedirectedFrom
+	}
+	return 0
+}
+
+func (x *Node) GetResolved() []*ResolvedKey {
+	if x != nil {
+		return x.Resolved
+	}
+	return nil
+}
+
+type NodeStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Distance from the root following Dep
+	Depth int64 `protobuf:"varint,1,opt,name=Depth,proto3" json:"Depth,omitempty"`
+	// Cross-server hops while computing the depth
+	Hops     int64 `protobuf:"varint,2,opt,name=Hops,proto3" json:"Hops,omitempty"`
+	Children int64 `protobuf:"varint,3,opt,name=Children,proto3" json:"Children,omitempty"`
+	// Reachable descendants (up to the traversal budget)
+	Descendants int64 `protobuf:"varint,4,opt,name=Descendants,proto3" json:"Descendants,omitempty"`
+	// Whether the traversal budget was exhausted
+	Truncated bool `protobuf:"varint,5,opt,name=Truncated,proto3" json:"Truncated,omitempty"`
+}
+
+func (x *NodeStats) Reset() {
+	*x = NodeStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeStats) ProtoMessage() {}
+
+func (x *NodeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeStats.ProtoReflect.Descriptor instead.
+func (*NodeStats) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *NodeStats) GetDepth() int64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *NodeStats) GetHops() int64 {
+	if x != nil {
+		return x.Hops
+	}
+	return 0
+}
+
+func (x *NodeStats) GetChildren() int64 {
+	if x != nil {
+		return x.Children
+	}
+	return 0
+}
+
+func (x *NodeStats) GetDescendants() int64 {
+	if x != nil {
+		return x.Descendants
+	}
+	return 0
+}
+
+func (x *NodeStats) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type AddNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node *Node `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	// Accept a location not matching the hash of the key
+	// (for nodes moved between servers or migration tools)
+	SkipLocationCheck bool `protobuf:"varint,2,opt,name=SkipLocationCheck,proto3" json:"SkipLocationCheck,omitempty"`
+	// Token of PrepareReceive for nodes transferred by a split (rejected if unknown or expired)
+	TransferToken string `protobuf:"bytes,3,opt,name=TransferToken,proto3" json:"TransferToken,omitempty"`
+}
+
+func (x *AddNodeRequest) Reset() {
+	*x = AddNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNodeRequest) ProtoMessage() {}
+
+func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNodeRequest.ProtoReflect.Descriptor instead.
+func (*AddNodeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddNodeRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *AddNodeRequest) GetSkipLocationCheck() bool {
+	if x != nil {
+		return x.SkipLocationCheck
+	}
+	return false
+}
+
+func (x *AddNodeRequest) GetTransferToken() string {
+	if x != nil {
+		return x.TransferToken
+	}
+	return ""
+}
+
+// Announce a transfer of nodes to the receiver
+type PrepareReceiveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Key hash range of the nodes
+	Left   uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right  uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+	Nodes  int64  `protobuf:"varint,3,opt,name=Nodes,proto3" json:"Nodes,omitempty"`
+	Bytes  int64  `protobuf:"varint,4,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	Sender string `protobuf:"bytes,5,opt,name=Sender,proto3" json:"Sender,omitempty"`
+}
+
+func (x *PrepareReceiveRequest) Reset() {
+	*x = PrepareReceiveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareReceiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareReceiveRequest) ProtoMessage() {}
+
+func (x *PrepareReceiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareReceiveRequest.ProtoReflect.Descriptor instead.
+func (*PrepareReceiveRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PrepareReceiveRequest) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *PrepareReceiveRequest) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *PrepareReceiveRequest) GetNodes() int64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *PrepareReceiveRequest) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *PrepareReceiveRequest) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+type PrepareReceiveResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Passed with every AddNode of the transfer
+	Token string `protobuf:"bytes,1,opt,name=Token,proto3" json:"Token,omitempty"`
+	// Unix nanoseconds, extended by each AddNode with the token
+	ExpiresAt int64 `protobuf:"varint,2,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	// Nodes already stored in the range (e.g. left by a failed attempt), up to 1000
+	Conflicting []uint64 `protobuf:"varint,3,rep,packed,name=Conflicting,proto3" json:"Conflicting,omitempty"`
+	// More nodes than listed are in the range
+	ConflictingTruncated bool `protobuf:"varint,4,opt,name=ConflictingTruncated,proto3" json:"ConflictingTruncated,omitempty"`
+}
+
+func (x *PrepareReceiveResponse) Reset() {
+	*x = PrepareReceiveResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareReceiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareReceiveResponse) ProtoMessage() {}
+
+func (x *PrepareReceiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareReceiveResponse.ProtoReflect.Descriptor instead.
+func (*PrepareReceiveResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PrepareReceiveResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PrepareReceiveResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *PrepareReceiveResponse) GetConflicting() []uint64 {
+	if x != nil {
+		return x.Conflicting
+	}
+	return nil
+}
+
+func (x *PrepareReceiveResponse) GetConflictingTruncated() bool {
+	if x != nil {
+		return x.ConflictingTruncated
+	}
+	return false
+}
+
+type AddNodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The same node was already stored (e.g. the request is a retry)
+	Skipped bool `protobuf:"varint,1,opt,name=Skipped,proto3" json:"Skipped,omitempty"`
+}
+
+func (x *AddNodeResponse) Reset() {
+	*x = AddNodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNodeResponse) ProtoMessage() {}
+
+func (x *AddNodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNodeResponse.ProtoReflect.Descriptor instead.
+func (*AddNodeResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddNodeResponse) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+// Nodes transferred by a split in one call (the sender sizes batches from the feedback)
+type AddNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*AddNodeRequest `protobuf:"bytes,1,rep,name=Requests,proto3" json:"Requests,omitempty"`
+}
+
+func (x *AddNodesRequest) Reset() {
+	*x = AddNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNodesRequest) ProtoMessage() {}
+
+func (x *AddNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNodesRequest.ProtoReflect.Descriptor instead.
+func (*AddNodesRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddNodesRequest) GetRequests() []*AddNodeRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type AddNodesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// In the order of the requests, up to the failed one
+	Results []*AddNodeResponse `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+	// Time the receiver took to add the batch
+	ApplyMicros int64 `protobuf:"varint,2,opt,name=ApplyMicros,proto3" json:"ApplyMicros,omitempty"`
+	// Requests in flight on the receiver when the batch was added
+	QueueDepth int64 `protobuf:"varint,3,opt,name=QueueDepth,proto3" json:"QueueDepth,omitempty"`
+	// Why the node after Results was not added (the rest of the batch was not sent to the store)
+	Failure *EntryStatus `protobuf:"bytes,4,opt,name=Failure,proto3" json:"Failure,omitempty"`
+}
+
+func (x *AddNodesResponse) Reset() {
+	*x = AddNodesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNodesResponse) ProtoMessage() {}
+
+func (x *AddNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNodesResponse.ProtoReflect.Descriptor instead.
+func (*AddNodesResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddNodesResponse) GetResults() []*AddNodeResponse {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *AddNodesResponse) GetApplyMicros() int64 {
+	if x != nil {
+		return x.ApplyMicros
+	}
+	return 0
+}
+
+func (x *AddNodesResponse) GetQueueDepth() int64 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *AddNodesResponse) GetFailure() *EntryStatus {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type SplitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// [L, Mid], [Mid + 1, R] (or [L, R] if both servers are the same)
+	Left        uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right       uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+	Mid         uint32 `protobuf:"varint,3,opt,name=Mid,proto3" json:"Mid,omitempty"`
+	LeftServer  string `protobuf:"bytes,4,opt,name=LeftServer,proto3" json:"LeftServer,omitempty"`
+	RightServer string `protobuf:"bytes,5,opt,name=RightServer,proto3" json:"RightServer,omitempty"`
+	// New epoch of the mapping (0 with Holder for legacy splits without locks)
+	Epoch uint64 `protobuf:"varint,6,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	// Server holding the indexing locks
+	Holder string `protobuf:"bytes,7,opt,name=Holder,proto3" json:"Holder,omitempty"`
+}
+
+func (x *SplitRequest) Reset() {
+	*x = SplitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SplitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitRequest) ProtoMessage() {}
+
+func (x *SplitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitRequest.ProtoReflect.Descriptor instead.
+func (*SplitRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SplitRequest) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *SplitRequest) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *SplitRequest) GetMid() uint32 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *SplitRequest) GetLeftServer() string {
+	if x != nil {
+		return x.LeftServer
+	}
+	return ""
+}
+
+func (x *SplitRequest) GetRightServer() string {
+	if x != nil {
+		return x.RightServer
+	}
+	return ""
+}
+
+func (x *SplitRequest) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SplitRequest) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+type DecommissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Server to decommission (empty for the receiving one)
+	Server string `protobuf:"bytes,1,opt,name=Server,proto3" json:"Server,omitempty"`
+	// Peer absorbing its ranges (empty for the least loaded one)
+	Target string `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target,omitempty"`
+}
+
+func (x *DecommissionRequest) Reset() {
+	*x = DecommissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecommissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecommissionRequest) ProtoMessage() {}
+
+func (x *DecommissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecommissionRequest.ProtoReflect.Descriptor instead.
+func (*DecommissionRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DecommissionRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *DecommissionRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type DecommissionStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty if never started, then draining, transferring, committing, done or failed
+	Phase      string `protobuf:"bytes,1,opt,name=Phase,proto3" json:"Phase,omitempty"`
+	Target     string `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target,omitempty"`
+	NodesMoved int64  `protobuf:"varint,3,opt,name=NodesMoved,proto3" json:"NodesMoved,omitempty"`
+	BytesMoved int64  `protobuf:"varint,4,opt,name=BytesMoved,proto3" json:"BytesMoved,omitempty"`
+	TotalNodes int64  `protobuf:"varint,5,opt,name=TotalNodes,proto3" json:"TotalNodes,omitempty"`
+	TotalBytes int64  `protobuf:"varint,6,opt,name=TotalBytes,proto3" json:"TotalBytes,omitempty"`
+	// Estimated time left for the transfer
+	EstimatedMillis int64  `protobuf:"varint,7,opt,name=EstimatedMillis,proto3" json:"EstimatedMillis,omitempty"`
+	Error           string `protobuf:"bytes,8,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Unix nanoseconds
+	Started int64 `protobuf:"varint,9,opt,name=Started,proto3" json:"Started,omitempty"`
+}
+
+func (x *DecommissionStatus) Reset() {
+	*x = DecommissionStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecommissionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecommissionStatus) ProtoMessage() {}
+
+func (x *DecommissionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecommissionStatus.ProtoReflect.Descriptor instead.
+func (*DecommissionStatus) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DecommissionStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *DecommissionStatus) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *DecommissionStatus) GetNodesMoved() int64 {
+	if x != nil {
+		return x.NodesMoved
+	}
+	return 0
+}
+
+func (x *DecommissionStatus) GetBytesMoved() int64 {
+	if x != nil {
+		return x.BytesMoved
+	}
+	return 0
+}
+
+func (x *DecommissionStatus) GetTotalNodes() int64 {
+	if x != nil {
+		return x.TotalNodes
+	}
+	return 0
+}
+
+func (x *DecommissionStatus) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *DecommissionStatus) GetEstimatedMillis() int64 {
+	if x != nil {
+		return x.EstimatedMillis
+	}
+	return 0
+}
+
+func (x *DecommissionStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DecommissionStatus) GetStarted() int64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+type TriggerSplitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty for a random available server
+	Target string `protobuf:"bytes,1,opt,name=Target,proto3" json:"Target,omitempty"`
+	// Override the midpoint of the range
+	HasMid bool   `protobuf:"varint,2,opt,name=HasMid,proto3" json:"HasMid,omitempty"`
+	Mid    uint32 `protobuf:"varint,3,opt,name=Mid,proto3" json:"Mid,omitempty"`
+	// Only compute the plan
+	DryRun bool `protobuf:"varint,4,opt,name=DryRun,proto3" json:"DryRun,omitempty"`
+}
+
+func (x *TriggerSplitRequest) Reset() {
+	*x = TriggerSplitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TriggerSplitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerSplitRequest) ProtoMessage() {}
+
+func (x *TriggerSplitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerSplitRequest.ProtoReflect.Descriptor instead.
+func (*TriggerSplitRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *TriggerSplitRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TriggerSplitRequest) GetHasMid() bool {
+	if x != nil {
+		return x.HasMid
+	}
+	return false
+}
+
+func (x *TriggerSplitRequest) GetMid() uint32 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *TriggerSplitRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type SplitPlan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left          uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right         uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+	Mid           uint32 `protobuf:"varint,3,opt,name=Mid,proto3" json:"Mid,omitempty"`
+	Target        string `protobuf:"bytes,4,opt,name=Target,proto3" json:"Target,omitempty"`
+	LeftServer    string `protobuf:"bytes,5,opt,name=LeftServer,proto3" json:"LeftServer,omitempty"`
+	RightServer   string `protobuf:"bytes,6,opt,name=RightServer,proto3" json:"RightServer,omitempty"`
+	TransferNodes int64  `protobuf:"varint,7,opt,name=TransferNodes,proto3" json:"TransferNodes,omitempty"`
+	KeepNodes     int64  `protobuf:"varint,8,opt,name=KeepNodes,proto3" json:"KeepNodes,omitempty"`
+	TransferBytes int64  `protobuf:"varint,9,opt,name=TransferBytes,proto3" json:"TransferBytes,omitempty"`
+	// Estimated from the throughput of the last transfer (0 if unknown)
+	EstimatedMillis int64 `protobuf:"varint,10,opt,name=EstimatedMillis,proto3" json:"EstimatedMillis,omitempty"`
+	Executed        bool  `protobuf:"varint,11,opt,name=Executed,proto3" json:"Executed,omitempty"`
+}
+
+func (x *SplitPlan) Reset() {
+	*x = SplitPlan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SplitPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitPlan) ProtoMessage() {}
+
+func (x *SplitPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitPlan.ProtoReflect.Descriptor instead.
+func (*SplitPlan) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SplitPlan) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetMid() uint32 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SplitPlan) GetLeftServer() string {
+	if x != nil {
+		return x.LeftServer
+	}
+	return ""
+}
+
+func (x *SplitPlan) GetRightServer() string {
+	if x != nil {
+		return x.RightServer
+	}
+	return ""
+}
+
+func (x *SplitPlan) GetTransferNodes() int64 {
+	if x != nil {
+		return x.TransferNodes
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetKeepNodes() int64 {
+	if x != nil {
+		return x.KeepNodes
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetTransferBytes() int64 {
+	if x != nil {
+		return x.TransferBytes
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetEstimatedMillis() int64 {
+	if x != nil {
+		return x.EstimatedMillis
+	}
+	return 0
+}
+
+func (x *SplitPlan) GetExecuted() bool {
+	if x != nil {
+		return x.Executed
+	}
+	return false
+}
+
+// When and how much to merge (zero values use defaults)
+type MergePolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Merge when the parent has at least this many children (default 2)
+	MinChildren int32 `protobuf:"varint,1,opt,name=minChildren,proto3" json:"minChildren,omitempty"`
+	// Wait until no child is added for this long before merging
+	DebounceMillis int64 `protobuf:"varint,2,opt,name=debounceMillis,proto3" json:"debounceMillis,omitempty"`
+	// Max children resolved per invocation (0 means all)
+	MaxBatch int32 `protobuf:"varint,3,opt,name=maxBatch,proto3" json:"maxBatch,omitempty"`
+}
+
+func (x *MergePolicy) Reset() {
+	*x = MergePolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePolicy) ProtoMessage() {}
+
+func (x *MergePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePolicy.ProtoReflect.Descriptor instead.
+func (*MergePolicy) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MergePolicy) GetMinChildren() int32 {
+	if x != nil {
+		return x.MinChildren
+	}
+	return 0
+}
+
+func (x *MergePolicy) GetDebounceMillis() int64 {
+	if x != nil {
+		return x.DebounceMillis
+	}
+	return 0
+}
+
+func (x *MergePolicy) GetMaxBatch() int32 {
+	if x != nil {
+		return x.MaxBatch
+	}
+	return 0
+}
+
+type SetMergeFunctionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=location,proto3" json:"location,omitempty"`
+	// Action name
+	Name   string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Policy *MergePolicy `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Copy of a registration that is already routed (not forwarded again)
+	Replicated bool `protobuf:"varint,4,opt,name=replicated,proto3" json:"replicated,omitempty"`
+	// Register without checking that the action exists in OpenWhisk (e.g. offline)
+	SkipValidation bool `protobuf:"varint,5,opt,name=skip_validation,json=skipValidation,proto3" json:"skip_validation,omitempty"`
+}
+
+func (x *SetMergeFunctionRequest) Reset() {
+	*x = SetMergeFunctionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetMergeFunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMergeFunctionRequest) ProtoMessage() {}
+
+func (x *SetMergeFunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMergeFunctionRequest.ProtoReflect.Descriptor instead.
+func (*SetMergeFunctionRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetMergeFunctionRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *SetMergeFunctionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetMergeFunctionRequest) GetPolicy() *MergePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SetMergeFunctionRequest) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+func (x *SetMergeFunctionRequest) GetSkipValidation() bool {
+	if x != nil {
+		return x.SkipValidation
+	}
+	return false
+}
+
+type SetGlobalMergeFunctionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Action name
+	Name   string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Policy *MergePolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Copy of a registration that is already routed (not forwarded again)
+	Replicated     bool `protobuf:"varint,3,opt,name=replicated,proto3" json:"replicated,omitempty"`
+	SkipValidation bool `protobuf:"varint,4,opt,name=skip_validation,json=skipValidation,proto3" json:"skip_validation,omitempty"`
+}
+
+func (x *SetGlobalMergeFunctionRequest) Reset() {
+	*x = SetGlobalMergeFunctionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetGlobalMergeFunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGlobalMergeFunctionRequest) ProtoMessage() {}
+
+func (x *SetGlobalMergeFunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGlobalMergeFunctionRequest.ProtoReflect.Descriptor instead.
+func (*SetGlobalMergeFunctionRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetGlobalMergeFunctionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetGlobalMergeFunctionRequest) GetPolicy() *MergePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SetGlobalMergeFunctionRequest) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+func (x *SetGlobalMergeFunctionRequest) GetSkipValidation() bool {
+	if x != nil {
+		return x.SkipValidation
+	}
+	return false
+}
+
+type MergeRegistration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64       `protobuf:"varint,1,opt,name=location,proto3" json:"location,omitempty"`
+	Name     string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Policy   *MergePolicy `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Registered by SetGlobalMergeFunction (location is ignored)
+	Global bool `protobuf:"varint,4,opt,name=global,proto3" json:"global,omitempty"`
+	// Registered by SetScopedMergeFunction for parents with the key,
+	// with keys starting with the prefix, or at locations with key hash in [left, right]
+	Key    string `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	Prefix string `protobuf:"bytes,6,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Ranged bool   `protobuf:"varint,7,opt,name=ranged,proto3" json:"ranged,omitempty"`
+	Left   uint32 `protobuf:"varint,8,opt,name=left,proto3" json:"left,omitempty"`
+	Right  uint32 `protobuf:"varint,9,opt,name=right,proto3" json:"right,omitempty"`
+	// Last check of the action on this server: "found", "skipped" or empty if not checked
+	// (e.g. registered on another server or before a restart)
+	Validation string `protobuf:"bytes,10,opt,name=validation,proto3" json:"validation,omitempty"`
+	// When the action was checked (unix nanoseconds)
+	ValidatedAt int64 `protobuf:"varint,11,opt,name=validated_at,json=validatedAt,proto3" json:"validated_at,omitempty"`
+}
+
+func (x *MergeRegistration) Reset() {
+	*x = MergeRegistration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRegistration) ProtoMessage() {}
+
+func (x *MergeRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRegistration.ProtoReflect.Descriptor instead.
+func (*MergeRegistration) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MergeRegistration) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *MergeRegistration) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MergeRegistration) GetPolicy() *MergePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *MergeRegistration) GetGlobal() bool {
+	if x != nil {
+		return x.Global
+	}
+	return false
+}
+
+func (x *MergeRegistration) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MergeRegistration) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *MergeRegistration) GetRanged() bool {
+	if x != nil {
+		return x.Ranged
+	}
+	return false
+}
+
+func (x *MergeRegistration) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *MergeRegistration) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *MergeRegistration) GetValidation() string {
+	if x != nil {
+		return x.Validation
+	}
+	return ""
+}
+
+func (x *MergeRegistration) GetValidatedAt() int64 {
+	if x != nil {
+		return x.ValidatedAt
+	}
+	return 0
+}
+
+type MergeRegistrations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Registrations []*MergeRegistration `protobuf:"bytes,1,rep,name=registrations,proto3" json:"registrations,omitempty"`
+}
+
+func (x *MergeRegistrations) Reset() {
+	*x = MergeRegistrations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeRegistrations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRegistrations) ProtoMessage() {}
+
+func (x *MergeRegistrations) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRegistrations.ProtoReflect.Descriptor instead.
+func (*MergeRegistrations) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MergeRegistrations) GetRegistrations() []*MergeRegistration {
+	if x != nil {
+		return x.Registrations
+	}
+	return nil
+}
+
+// Exactly one of key, prefix or ranged
+type SetScopedMergeFunctionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Key hash range [left, right]
+	Ranged bool   `protobuf:"varint,3,opt,name=ranged,proto3" json:"ranged,omitempty"`
+	Left   uint32 `protobuf:"varint,4,opt,name=left,proto3" json:"left,omitempty"`
+	Right  uint32 `protobuf:"varint,5,opt,name=right,proto3" json:"right,omitempty"`
+	// Action name (empty to remove the registration)
+	Name   string       `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	Policy *MergePolicy `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Copy of a registration that is already routed (not forwarded again)
+	Replicated     bool `protobuf:"varint,8,opt,name=replicated,proto3" json:"replicated,omitempty"`
+	SkipValidation bool `protobuf:"varint,9,opt,name=skip_validation,json=skipValidation,proto3" json:"skip_validation,omitempty"`
+}
+
+func (x *SetScopedMergeFunctionRequest) Reset() {
+	*x = SetScopedMergeFunctionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetScopedMergeFunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetScopedMergeFunctionRequest) ProtoMessage() {}
+
+func (x *SetScopedMergeFunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetScopedMergeFunctionRequest.ProtoReflect.Descriptor instead.
+func (*SetScopedMergeFunctionRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetScopedMergeFunctionRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetScopedMergeFunctionRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SetScopedMergeFunctionRequest) GetRanged() bool {
+	if x != nil {
+		return x.Ranged
+	}
+	return false
+}
+
+func (x *SetScopedMergeFunctionRequest) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *SetScopedMergeFunctionRequest) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *SetScopedMergeFunctionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetScopedMergeFunctionRequest) GetPolicy() *MergePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SetScopedMergeFunctionRequest) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+func (x *SetScopedMergeFunctionRequest) GetSkipValidation() bool {
+	if x != nil {
+		return x.SkipValidation
+	}
+	return false
+}
+
+// A location, or a key for the registrations applying to any node with it
+type ResolveMergeFunctionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=location,proto3" json:"location,omitempty"`
+	Key      string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *ResolveMergeFunctionRequest) Reset() {
+	*x = ResolveMergeFunctionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveMergeFunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveMergeFunctionRequest) ProtoMessage() {}
+
+func (x *ResolveMergeFunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveMergeFunctionRequest.ProtoReflect.Descriptor instead.
+func (*ResolveMergeFunctionRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ResolveMergeFunctionRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ResolveMergeFunctionRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type MergeResolution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Action that would fire (empty if none)
+	Name   string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Policy *MergePolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// location, key, prefix, range or global
+	Scope string `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	// Registrations applying, the first one fires
+	Matches []*MergeRegistration `protobuf:"bytes,4,rep,name=matches,proto3" json:"matches,omitempty"`
+}
+
+func (x *MergeResolution) Reset() {
+	*x = MergeResolution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeResolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeResolution) ProtoMessage() {}
+
+func (x *MergeResolution) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeResolution.ProtoReflect.Descriptor instead.
+func (*MergeResolution) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MergeResolution) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MergeResolution) GetPolicy() *MergePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *MergeResolution) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *MergeResolution) GetMatches() []*MergeRegistration {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// Registrations of locations in the hash range [left, right] and the global one
+type GetMergeRegistrationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left  uint32 `protobuf:"varint,1,opt,name=left,proto3" json:"left,omitempty"`
+	Right uint32 `protobuf:"varint,2,opt,name=right,proto3" json:"right,omitempty"`
+}
+
+func (x *GetMergeRegistrationsRequest) Reset() {
+	*x = GetMergeRegistrationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMergeRegistrationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMergeRegistrationsRequest) ProtoMessage() {}
+
+func (x *GetMergeRegistrationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMergeRegistrationsRequest.ProtoReflect.Descriptor instead.
+func (*GetMergeRegistrationsRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetMergeRegistrationsRequest) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *GetMergeRegistrationsRequest) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+type AddChildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Child    uint64 `protobuf:"varint,2,opt,name=Child,proto3" json:"Child,omitempty"`
+}
+
+func (x *AddChildRequest) Reset() {
+	*x = AddChildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddChildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChildRequest) ProtoMessage() {}
+
+func (x *AddChildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChildRequest.ProtoReflect.Descriptor instead.
+func (*AddChildRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AddChildRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *AddChildRequest) GetChild() uint64 {
+	if x != nil {
+		return x.Child
+	}
+	return 0
+}
+
+type ExistsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+}
+
+func (x *ExistsRequest) Reset() {
+	*x = ExistsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsRequest) ProtoMessage() {}
+
+func (x *ExistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsRequest.ProtoReflect.Descriptor instead.
+func (*ExistsRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ExistsRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+type ExistsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exists bool `protobuf:"varint,1,opt,name=Exists,proto3" json:"Exists,omitempty"`
+}
+
+func (x *ExistsResponse) Reset() {
+	*x = ExistsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExistsResponse) ProtoMessage() {}
+
+func (x *ExistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExistsResponse.ProtoReflect.Descriptor instead.
+func (*ExistsResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ExistsResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+type ResolveBucketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+}
+
+func (x *ResolveBucketRequest) Reset() {
+	*x = ResolveBucketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveBucketRequest) ProtoMessage() {}
+
+func (x *ResolveBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveBucketRequest.ProtoReflect.Descriptor instead.
+func (*ResolveBucketRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ResolveBucketRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+type ResolveBucketResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Node taking new children of the location
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+}
+
+func (x *ResolveBucketResponse) Reset() {
+	*x = ResolveBucketResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveBucketResponse) ProtoMessage() {}
+
+func (x *ResolveBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveBucketResponse.ProtoReflect.Descriptor instead.
+func (*ResolveBucketResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ResolveBucketResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+type GetHeatmapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only this server (set when fanning out)
+	Local bool `protobuf:"varint,1,opt,name=Local,proto3" json:"Local,omitempty"`
+	// Clear the counters after reading them
+	Clear bool `protobuf:"varint,2,opt,name=Clear,proto3" json:"Clear,omitempty"`
+}
+
+func (x *GetHeatmapRequest) Reset() {
+	*x = GetHeatmapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHeatmapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeatmapRequest) ProtoMessage() {}
+
+func (x *GetHeatmapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeatmapRequest.ProtoReflect.Descriptor instead.
+func (*GetHeatmapRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetHeatmapRequest) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+func (x *GetHeatmapRequest) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+type HeatmapBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// First and last key hash of the bucket
+	Left   uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right  uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+	Reads  int64  `protobuf:"varint,3,opt,name=Reads,proto3" json:"Reads,omitempty"`
+	Writes int64  `protobuf:"varint,4,opt,name=Writes,proto3" json:"Writes,omitempty"`
+	// Total latency of the requests
+	LatencyMicros int64 `protobuf:"varint,5,opt,name=LatencyMicros,proto3" json:"LatencyMicros,omitempty"`
+}
+
+func (x *HeatmapBucket) Reset() {
+	*x = HeatmapBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeatmapBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeatmapBucket) ProtoMessage() {}
+
+func (x *HeatmapBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeatmapBucket.ProtoReflect.Descriptor instead.
+func (*HeatmapBucket) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *HeatmapBucket) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetReads() int64 {
+	if x != nil {
+		return x.Reads
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetWrites() int64 {
+	if x != nil {
+		return x.Writes
+	}
+	return 0
+}
+
+func (x *HeatmapBucket) GetLatencyMicros() int64 {
+	if x != nil {
+		return x.LatencyMicros
+	}
+	return 0
+}
+
+type Heatmap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Equal buckets over the hash space
+	Buckets []*HeatmapBucket `protobuf:"bytes,1,rep,name=Buckets,proto3" json:"Buckets,omitempty"`
+	// Ranges of the servers (not set with Local)
+	Ranges []*Range `protobuf:"bytes,2,rep,name=Ranges,proto3" json:"Ranges,omitempty"`
+	// Start of the counts (unix nanoseconds, the oldest of the servers)
+	Since int64 `protobuf:"varint,3,opt,name=Since,proto3" json:"Since,omitempty"`
+}
+
+func (x *Heatmap) Reset() {
+	*x = Heatmap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Heatmap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heatmap) ProtoMessage() {}
+
+func (x *Heatmap) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heatmap.ProtoReflect.Descriptor instead.
+func (*Heatmap) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *Heatmap) GetBuckets() []*HeatmapBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *Heatmap) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *Heatmap) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type ExpireNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Unix nanoseconds (kept if the node expires earlier)
+	ExpiresAt int64 `protobuf:"varint,2,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+}
+
+func (x *ExpireNodeRequest) Reset() {
+	*x = ExpireNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpireNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpireNodeRequest) ProtoMessage() {}
+
+func (x *ExpireNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpireNodeRequest.ProtoReflect.Descriptor instead.
+func (*ExpireNodeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ExpireNodeRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ExpireNodeRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CopySubtreeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Root of the subtree (its children are copied if it is a root or bucket)
+	Source uint64 `protobuf:"varint,1,opt,name=Source,proto3" json:"Source,omitempty"`
+	// Dep of the copy of Source (under any root)
+	Destination uint64 `protobuf:"varint,2,opt,name=Destination,proto3" json:"Destination,omitempty"`
+	// Expire the source nodes once everything is copied
+	Move bool `protobuf:"varint,3,opt,name=Move,proto3" json:"Move,omitempty"`
+	// Id of an interrupted copy to resume (empty for a new one)
+	Id string `protobuf:"bytes,4,opt,name=Id,proto3" json:"Id,omitempty"`
+}
+
+func (x *CopySubtreeRequest) Reset() {
+	*x = CopySubtreeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopySubtreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopySubtreeRequest) ProtoMessage() {}
+
+func (x *CopySubtreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopySubtreeRequest.ProtoReflect.Descriptor instead.
+func (*CopySubtreeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CopySubtreeRequest) GetSource() uint64 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *CopySubtreeRequest) GetDestination() uint64 {
+	if x != nil {
+		return x.Destination
+	}
+	return 0
+}
+
+func (x *CopySubtreeRequest) GetMove() bool {
+	if x != nil {
+		return x.Move
+	}
+	return false
+}
+
+func (x *CopySubtreeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CopiedNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source uint64 `protobuf:"varint,1,opt,name=Source,proto3" json:"Source,omitempty"`
+	Copy   uint64 `protobuf:"varint,2,opt,name=Copy,proto3" json:"Copy,omitempty"`
+}
+
+func (x *CopiedNode) Reset() {
+	*x = CopiedNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopiedNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopiedNode) ProtoMessage() {}
+
+func (x *CopiedNode) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopiedNode.ProtoReflect.Descriptor instead.
+func (*CopiedNode) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CopiedNode) GetSource() uint64 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *CopiedNode) GetCopy() uint64 {
+	if x != nil {
+		return x.Copy
+	}
+	return 0
+}
+
+type CopyProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Pass in CopySubtreeRequest to resume the copy
+	Id string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Nodes copied so far
+	Copied int64 `protobuf:"varint,2,opt,name=Copied,proto3" json:"Copied,omitempty"`
+	// Of those, nodes already copied by a previous attempt
+	Skipped int64 `protobuf:"varint,3,opt,name=Skipped,proto3" json:"Skipped,omitempty"`
+	// Copies made since the previous message
+	Nodes []*CopiedNode `protobuf:"bytes,4,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	// Set in the last message (after the source is expired for a move)
+	Done bool `protobuf:"varint,5,opt,name=Done,proto3" json:"Done,omitempty"`
+}
+
+func (x *CopyProgress) Reset() {
+	*x = CopyProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyProgress) ProtoMessage() {}
+
+func (x *CopyProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyProgress.ProtoReflect.Descriptor instead.
+func (*CopyProgress) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CopyProgress) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CopyProgress) GetCopied() int64 {
+	if x != nil {
+		return x.Copied
+	}
+	return 0
+}
+
+func (x *CopyProgress) GetSkipped() int64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *CopyProgress) GetNodes() []*CopiedNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *CopyProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type ReplaceChildrenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64   `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Children []uint64 `protobuf:"varint,2,rep,packed,name=Children,proto3" json:"Children,omitempty"`
+	// Replace only if the children are still at ExpectedVersion
+	CheckVersion    bool   `protobuf:"varint,3,opt,name=CheckVersion,proto3" json:"CheckVersion,omitempty"`
+	ExpectedVersion uint64 `protobuf:"varint,4,opt,name=ExpectedVersion,proto3" json:"ExpectedVersion,omitempty"`
+}
+
+func (x *ReplaceChildrenRequest) Reset() {
+	*x = ReplaceChildrenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceChildrenRequest) ProtoMessage() {}
+
+func (x *ReplaceChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceChildrenRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ReplaceChildrenRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ReplaceChildrenRequest) GetChildren() []uint64 {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *ReplaceChildrenRequest) GetCheckVersion() bool {
+	if x != nil {
+		return x.CheckVersion
+	}
+	return false
+}
+
+func (x *ReplaceChildrenRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ReplaceChildrenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Children before replacement
+	Children []uint64 `protobuf:"varint,1,rep,packed,name=Children,proto3" json:"Children,omitempty"`
+	// Children version after replacement
+	Version uint64 `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+}
+
+func (x *ReplaceChildrenResponse) Reset() {
+	*x = ReplaceChildrenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceChildrenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceChildrenResponse) ProtoMessage() {}
+
+func (x *ReplaceChildrenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceChildrenResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceChildrenResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ReplaceChildrenResponse) GetChildren() []uint64 {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *ReplaceChildrenResponse) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RemoveChildrenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+}
+
+func (x *RemoveChildrenRequest) Reset() {
+	*x = RemoveChildrenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveChildrenRequest) ProtoMessage() {}
+
+func (x *RemoveChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveChildrenRequest.ProtoReflect.Descriptor instead.
+func (*RemoveChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RemoveChildrenRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+type RemoveNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Node replacing it (e.g. the output of a merge), resolved from the location afterwards
+	Successor uint64 `protobuf:"varint,2,opt,name=Successor,proto3" json:"Successor,omitempty"`
+}
+
+func (x *RemoveNodeRequest) Reset() {
+	*x = RemoveNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveNodeRequest) ProtoMessage() {}
+
+func (x *RemoveNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveNodeRequest.ProtoReflect.Descriptor instead.
+func (*RemoveNodeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RemoveNodeRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *RemoveNodeRequest) GetSuccessor() uint64 {
+	if x != nil {
+		return x.Successor
+	}
+	return 0
+}
+
+// Replaced location resolving to its successor
+type Alias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location  uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Successor uint64 `protobuf:"varint,2,opt,name=Successor,proto3" json:"Successor,omitempty"`
+	// When the location was replaced (unix nanoseconds)
+	Time int64 `protobuf:"varint,3,opt,name=Time,proto3" json:"Time,omitempty"`
+}
+
+func (x *Alias) Reset() {
+	*x = Alias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alias) ProtoMessage() {}
+
+func (x *Alias) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alias.ProtoReflect.Descriptor instead.
+func (*Alias) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *Alias) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *Alias) GetSuccessor() uint64 {
+	if x != nil {
+		return x.Successor
+	}
+	return 0
+}
+
+func (x *Alias) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type Aliases struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Aliases []*Alias `protobuf:"bytes,1,rep,name=Aliases,proto3" json:"Aliases,omitempty"`
+}
+
+func (x *Aliases) Reset() {
+	*x = Aliases{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Aliases) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aliases) ProtoMessage() {}
+
+func (x *Aliases) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aliases.ProtoReflect.Descriptor instead.
+func (*Aliases) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Aliases) GetAliases() []*Alias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+type ResolveAliasRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Aliases followed before (set by servers)
+	Chain int32 `protobuf:"varint,2,opt,name=Chain,proto3" json:"Chain,omitempty"`
+}
+
+func (x *ResolveAliasRequest) Reset() {
+	*x = ResolveAliasRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAliasRequest) ProtoMessage() {}
+
+func (x *ResolveAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAliasRequest.ProtoReflect.Descriptor instead.
+func (*ResolveAliasRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ResolveAliasRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ResolveAliasRequest) GetChain() int32 {
+	if x != nil {
+		return x.Chain
+	}
+	return 0
+}
+
+type ResolveAliasResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Stored location the request resolves to
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Aliases followed (0 if the location is stored)
+	Chain int32 `protobuf:"varint,2,opt,name=Chain,proto3" json:"Chain,omitempty"`
+}
+
+func (x *ResolveAliasResponse) Reset() {
+	*x = ResolveAliasResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAliasResponse) ProtoMessage() {}
+
+func (x *ResolveAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAliasResponse.ProtoReflect.Descriptor instead.
+func (*ResolveAliasResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ResolveAliasResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ResolveAliasResponse) GetChain() int32 {
+	if x != nil {
+		return x.Chain
+	}
+	return 0
+}
+
+// Hash range [Left, Right] and the servers holding it
+type Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left  uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+	// Primary server (handles writes)
+	Server string `protobuf:"bytes,3,opt,name=Server,proto3" json:"Server,omitempty"`
+	// Servers that can serve reads, primary first
+	Replicas []string `protobuf:"bytes,4,rep,name=Replicas,proto3" json:"Replicas,omitempty"`
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *Range) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *Range) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *Range) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *Range) GetReplicas() []string {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+type Mapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranges []*Range `protobuf:"bytes,1,rep,name=Ranges,proto3" json:"Ranges,omitempty"`
+	Epoch  uint64   `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+}
+
+func (x *Mapping) Reset() {
+	*x = Mapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Mapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mapping) ProtoMessage() {}
+
+func (x *Mapping) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mapping.ProtoReflect.Descriptor instead.
+func (*Mapping) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Mapping) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *Mapping) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Attached to error statuses so that typed errors survive the wire
+type ErrorDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason string            `protobuf:"bytes,1,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	Fields map[string]string `protobuf:"bytes,2,rep,name=Fields,proto3" json:"Fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// e.g. the stored and the incoming node of NODE_EXISTS
+	Nodes []*Node `protobuf:"bytes,3,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	// Chain walk of HOP_BUDGET_EXCEEDED
+	Path []*WalkStep `protobuf:"bytes,4,rep,name=Path,proto3" json:"Path,omitempty"`
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ErrorDetail) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ErrorDetail) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *ErrorDetail) GetPath() []*WalkStep {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+// Ranges a server believes it owns
+type ClaimRangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Server string   `protobuf:"bytes,1,opt,name=Server,proto3" json:"Server,omitempty"`
+	Epoch  uint64   `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Ranges []*Range `protobuf:"bytes,3,rep,name=Ranges,proto3" json:"Ranges,omitempty"`
+}
+
+func (x *ClaimRangesRequest) Reset() {
+	*x = ClaimRangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimRangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimRangesRequest) ProtoMessage() {}
+
+func (x *ClaimRangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimRangesRequest.ProtoReflect.Descriptor instead.
+func (*ClaimRangesRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ClaimRangesRequest) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *ClaimRangesRequest) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ClaimRangesRequest) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+type ClaimRangesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The receiver also owns part of the claimed ranges
+	Conflict bool `protobuf:"varint,1,opt,name=Conflict,proto3" json:"Conflict,omitempty"`
+	// Server keeping the disputed ranges
+	Winner string `protobuf:"bytes,2,opt,name=Winner,proto3" json:"Winner,omitempty"`
+	Epoch  uint64 `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	// Disputed ranges
+	Ranges []*Range `protobuf:"bytes,4,rep,name=Ranges,proto3" json:"Ranges,omitempty"`
+}
+
+func (x *ClaimRangesResponse) Reset() {
+	*x = ClaimRangesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimRangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimRangesResponse) ProtoMessage() {}
+
+func (x *ClaimRangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimRangesResponse.ProtoReflect.Descriptor instead.
+func (*ClaimRangesResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ClaimRangesResponse) GetConflict() bool {
+	if x != nil {
+		return x.Conflict
+	}
+	return false
+}
+
+func (x *ClaimRangesResponse) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *ClaimRangesResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ClaimRangesResponse) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// APIVersion of the server
+	ApiVersion int32 `protobuf:"varint,1,opt,name=ApiVersion,proto3" json:"ApiVersion,omitempty"`
+	// Oldest APIVersion of clients the server accepts
+	MinApiVersion int32 `protobuf:"varint,2,opt,name=MinApiVersion,proto3" json:"MinApiVersion,omitempty"`
+	// Build of the server (e.g. a git tag)
+	Build     string `protobuf:"bytes,3,opt,name=Build,proto3" json:"Build,omitempty"`
+	GoVersion string `protobuf:"bytes,4,opt,name=GoVersion,proto3" json:"GoVersion,omitempty"`
+	// Optional features supported by the server (see version.go)
+	Features []string `protobuf:"bytes,5,rep,name=Features,proto3" json:"Features,omitempty"`
+	// Highest level of the split protocol spoken by the server (0 if unknown)
+	ProtocolLevel int32 `protobuf:"varint,6,opt,name=ProtocolLevel,proto3" json:"ProtocolLevel,omitempty"`
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *VersionResponse) GetApiVersion() int32 {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return 0
+}
+
+func (x *VersionResponse) GetMinApiVersion() int32 {
+	if x != nil {
+		return x.MinApiVersion
+	}
+	return 0
+}
+
+func (x *VersionResponse) GetBuild() string {
+	if x != nil {
+		return x.Build
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *VersionResponse) GetProtocolLevel() int32 {
+	if x != nil {
+		return x.ProtocolLevel
+	}
+	return 0
+}
+
+type ServiceDescription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Serialized google.protobuf.FileDescriptorProto of db.proto
+	FileDescriptor []byte `protobuf:"bytes,1,opt,name=FileDescriptor,proto3" json:"FileDescriptor,omitempty"`
+	// Full names of the methods of DbService
+	Methods []string `protobuf:"bytes,2,rep,name=Methods,proto3" json:"Methods,omitempty"`
+}
+
+func (x *ServiceDescription) Reset() {
+	*x = ServiceDescription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceDescription) ProtoMessage() {}
+
+func (x *ServiceDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceDescription.ProtoReflect.Descriptor instead.
+func (*ServiceDescription) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ServiceDescription) GetFileDescriptor() []byte {
+	if x != nil {
+		return x.FileDescriptor
+	}
+	return nil
+}
+
+func (x *ServiceDescription) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+// Keys and/or precomputed key hashes to locate
+type LocateKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys   []string `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	Hashes []uint32 `protobuf:"varint,2,rep,packed,name=Hashes,proto3" json:"Hashes,omitempty"`
+}
+
+func (x *LocateKeysRequest) Reset() {
+	*x = LocateKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocateKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocateKeysRequest) ProtoMessage() {}
+
+func (x *LocateKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocateKeysRequest.ProtoReflect.Descriptor instead.
+func (*LocateKeysRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *LocateKeysRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *LocateKeysRequest) GetHashes() []uint32 {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+type KeyOwner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Empty for precomputed hashes
+	Key     string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Hash    uint32 `protobuf:"varint,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Address string `protobuf:"bytes,3,opt,name=Address,proto3" json:"Address,omitempty"`
+}
+
+func (x *KeyOwner) Reset() {
+	*x = KeyOwner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyOwner) ProtoMessage() {}
+
+func (x *KeyOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyOwner.ProtoReflect.Descriptor instead.
+func (*KeyOwner) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *KeyOwner) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyOwner) GetHash() uint32 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *KeyOwner) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type LocateKeysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys first, then hashes, in request order
+	Owners []*KeyOwner `protobuf:"bytes,1,rep,name=Owners,proto3" json:"Owners,omitempty"`
+	// Epoch of the mapping used
+	Epoch uint64 `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+}
+
+func (x *LocateKeysResponse) Reset() {
+	*x = LocateKeysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocateKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocateKeysResponse) ProtoMessage() {}
+
+func (x *LocateKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocateKeysResponse.ProtoReflect.Descriptor instead.
+func (*LocateKeysResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *LocateKeysResponse) GetOwners() []*KeyOwner {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *LocateKeysResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type RemoveNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locations []uint64 `protobuf:"varint,1,rep,packed,name=Locations,proto3" json:"Locations,omitempty"`
+	// Remove from the receiving server without routing (cleanup of aborted splits)
+	Local bool `protobuf:"varint,2,opt,name=Local,proto3" json:"Local,omitempty"`
+}
+
+func (x *RemoveNodesRequest) Reset() {
+	*x = RemoveNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveNodesRequest) ProtoMessage() {}
+
+func (x *RemoveNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveNodesRequest.ProtoReflect.Descriptor instead.
+func (*RemoveNodesRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *RemoveNodesRequest) GetLocations() []uint64 {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *RemoveNodesRequest) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+type RemoveNodesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of nodes actually removed
+	Removed int64 `protobuf:"varint,1,opt,name=Removed,proto3" json:"Removed,omitempty"`
+}
+
+func (x *RemoveNodesResponse) Reset() {
+	*x = RemoveNodesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveNodesResponse) ProtoMessage() {}
+
+func (x *RemoveNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveNodesResponse.ProtoReflect.Descriptor instead.
+func (*RemoveNodesResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *RemoveNodesResponse) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type InvalidateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+}
+
+func (x *InvalidateRequest) Reset() {
+	*x = InvalidateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateRequest) ProtoMessage() {}
+
+func (x *InvalidateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *InvalidateRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetNodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location     uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	IncludeStats bool   `protobuf:"varint,2,opt,name=IncludeStats,proto3" json:"IncludeStats,omitempty"`
+	// Max descendants to visit (0 for default, negative to skip)
+	DescendantBudget int64 `protobuf:"varint,3,opt,name=DescendantBudget,proto3" json:"DescendantBudget,omitempty"`
+	// Max children returned (0 for all)
+	ChildrenLimit int32 `protobuf:"varint,4,opt,name=ChildrenLimit,proto3" json:"ChildrenLimit,omitempty"`
+	// NextChildrenCursor of the previous page
+	ChildrenCursor uint64 `protobuf:"varint,5,opt,name=ChildrenCursor,proto3" json:"ChildrenCursor,omitempty"`
+	// Recompute the checksum of the node before returning it (DataLoss on mismatch)
+	VerifyChecksum bool `protobuf:"varint,6,opt,name=VerifyChecksum,proto3" json:"VerifyChecksum,omitempty"`
+	// Keys read from the node, returned in Node.Resolved (see SetRequest.ResolveKeys)
+	ResolveKeys []string `protobuf:"bytes,7,rep,name=ResolveKeys,proto3" json:"ResolveKeys,omitempty"`
+}
+
+func (x *GetNodeRequest) Reset() {
+	*x = GetNodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNodeRequest) ProtoMessage() {}
+
+func (x *GetNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNodeRequest.ProtoReflect.Descriptor instead.
+func (*GetNodeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GetNodeRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *GetNodeRequest) GetIncludeStats() bool {
+	if x != nil {
+		return x.IncludeStats
+	}
+	return false
+}
+
+func (x *GetNodeRequest) GetDescendantBudget() int64 {
+	if x != nil {
+		return x.DescendantBudget
+	}
+	return 0
+}
+
+func (x *GetNodeRequest) GetChildrenLimit() int32 {
+	if x != nil {
+		return x.ChildrenLimit
+	}
+	return 0
+}
+
+func (x *GetNodeRequest) GetChildrenCursor() uint64 {
+	if x != nil {
+		return x.ChildrenCursor
+	}
+	return 0
+}
+
+func (x *GetNodeRequest) GetVerifyChecksum() bool {
+	if x != nil {
+		return x.VerifyChecksum
+	}
+	return false
+}
+
+func (x *GetNodeRequest) GetResolveKeys() []string {
+	if x != nil {
+		return x.ResolveKeys
+	}
+	return nil
+}
+
+type GetProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Only the chain from Location to the root, without looking for Key
+	// (set by servers fetching the segment stored by another server)
+	Chain bool `protobuf:"varint,3,opt,name=Chain,proto3" json:"Chain,omitempty"`
+}
+
+func (x *GetProofRequest) Reset() {
+	*x = GetProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProofRequest) ProtoMessage() {}
+
+func (x *GetProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProofRequest.ProtoReflect.Descriptor instead.
+func (*GetProofRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetProofRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GetProofRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *GetProofRequest) GetChain() bool {
+	if x != nil {
+		return x.Chain
+	}
+	return false
+}
+
+// Content of a node covered by its checksum, and the server storing it
+type ProofStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64            `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Dep      uint64            `protobuf:"varint,2,opt,name=Dep,proto3" json:"Dep,omitempty"`
+	Key      string            `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value    []byte            `protobuf:"bytes,4,opt,name=Value,proto3" json:"Value,omitempty"`
+	Type     NodeType          `protobuf:"varint,5,opt,name=Type,proto3,enum=db.NodeType" json:"Type,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=Metadata,proto3" json:"Metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Checksum uint32            `protobuf:"varint,7,opt,name=Checksum,proto3" json:"Checksum,omitempty"`
+	Server   string            `protobuf:"bytes,8,opt,name=Server,proto3" json:"Server,omitempty"`
+}
+
+func (x *ProofStep) Reset() {
+	*x = ProofStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProofStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofStep) ProtoMessage() {}
+
+func (x *ProofStep) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofStep.ProtoReflect.Descriptor instead.
+func (*ProofStep) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ProofStep) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ProofStep) GetDep() uint64 {
+	if x != nil {
+		return x.Dep
+	}
+	return 0
+}
+
+func (x *ProofStep) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProofStep) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ProofStep) GetType() NodeType {
+	if x != nil {
+		return x.Type
+	}
+	return NodeType_REGULAR
+}
+
+func (x *ProofStep) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ProofStep) GetChecksum() uint32 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *ProofStep) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+// Chain of dep links from a location to its root (see client.VerifyProof)
+type Proof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	// From Location to the root, each step the dep of the previous one
+	Steps []*ProofStep `protobuf:"bytes,3,rep,name=Steps,proto3" json:"Steps,omitempty"`
+	// Index of the step defining Key (-1 for a chain)
+	Defining int32  `protobuf:"varint,4,opt,name=Defining,proto3" json:"Defining,omitempty"`
+	Root     uint64 `protobuf:"varint,5,opt,name=Root,proto3" json:"Root,omitempty"`
+}
+
+func (x *Proof) Reset() {
+	*x = Proof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proof) ProtoMessage() {}
+
+func (x *Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proof.ProtoReflect.Descriptor instead.
+func (*Proof) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *Proof) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Proof) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *Proof) GetSteps() []*ProofStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Proof) GetDefining() int32 {
+	if x != nil {
+		return x.Defining
+	}
+	return 0
+}
+
+func (x *Proof) GetRoot() uint64 {
+	if x != nil {
+		return x.Root
+	}
+	return 0
+}
+
+type GetKeyNodesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+}
+
+func (x *GetKeyNodesRequest) Reset() {
+	*x = GetKeyNodesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetKeyNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetKeyNodesRequest) ProtoMessage() {}
+
+func (x *GetKeyNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetKeyNodesRequest.ProtoReflect.Descriptor instead.
+func (*GetKeyNodesRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GetKeyNodesRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type Nodes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nodes []*Node `protobuf:"bytes,1,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+}
+
+func (x *Nodes) Reset() {
+	*x = Nodes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nodes) ProtoMessage() {}
+
+func (x *Nodes) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nodes.ProtoReflect.Descriptor instead.
+func (*Nodes) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *Nodes) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{90}
+}
+
+type MergeRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent         uint64 `protobuf:"varint,1,opt,name=Parent,proto3" json:"Parent,omitempty"`
+	Action         string `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
+	ActivationId   string `protobuf:"bytes,3,opt,name=ActivationId,proto3" json:"ActivationId,omitempty"`
+	DurationMillis int64  `protobuf:"varint,4,opt,name=DurationMillis,proto3" json:"DurationMillis,omitempty"`
+	// Empty if the merge succeeded
+	Error string `protobuf:"bytes,5,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Unix nanoseconds
+	Time int64 `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+	// Children version of the parent that was resolved
+	ChildrenVersion uint64 `protobuf:"varint,7,opt,name=ChildrenVersion,proto3" json:"ChildrenVersion,omitempty"`
+	// Times the merge was run again because children were added meanwhile
+	Reruns int32 `protobuf:"varint,8,opt,name=Reruns,proto3" json:"Reruns,omitempty"`
+}
+
+func (x *MergeRecord) Reset() {
+	*x = MergeRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeRecord) ProtoMessage() {}
+
+func (x *MergeRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeRecord.ProtoReflect.Descriptor instead.
+func (*MergeRecord) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *MergeRecord) GetParent() uint64 {
+	if x != nil {
+		return x.Parent
+	}
+	return 0
+}
+
+func (x *MergeRecord) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *MergeRecord) GetActivationId() string {
+	if x != nil {
+		return x.ActivationId
+	}
+	return ""
+}
+
+func (x *MergeRecord) GetDurationMillis() int64 {
+	if x != nil {
+		return x.DurationMillis
+	}
+	return 0
+}
+
+func (x *MergeRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MergeRecord) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MergeRecord) GetChildrenVersion() uint64 {
+	if x != nil {
+		return x.ChildrenVersion
+	}
+	return 0
+}
+
+func (x *MergeRecord) GetReruns() int32 {
+	if x != nil {
+		return x.Reruns
+	}
+	return 0
+}
+
+type FailedMerge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent   uint64 `protobuf:"varint,1,opt,name=Parent,proto3" json:"Parent,omitempty"`
+	Action   string `protobuf:"bytes,2,opt,name=Action,proto3" json:"Action,omitempty"`
+	Attempts int32  `protobuf:"varint,3,opt,name=Attempts,proto3" json:"Attempts,omitempty"`
+	// Error of the last attempt
+	Error string `protobuf:"bytes,4,opt,name=Error,proto3" json:"Error,omitempty"`
+	// Unix nanoseconds
+	FirstFailure int64 `protobuf:"varint,5,opt,name=FirstFailure,proto3" json:"FirstFailure,omitempty"`
+	LastFailure  int64 `protobuf:"varint,6,opt,name=LastFailure,proto3" json:"LastFailure,omitempty"`
+	DeadLettered bool  `protobuf:"varint,7,opt,name=DeadLettered,proto3" json:"DeadLettered,omitempty"`
+}
+
+func (x *FailedMerge) Reset() {
+	*x = FailedMerge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailedMerge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedMerge) ProtoMessage() {}
+
+func (x *FailedMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedMerge.ProtoReflect.Descriptor instead.
+func (*FailedMerge) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *FailedMerge) GetParent() uint64 {
+	if x != nil {
+		return x.Parent
+	}
+	return 0
+}
+
+func (x *FailedMerge) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *FailedMerge) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *FailedMerge) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FailedMerge) GetFirstFailure() int64 {
+	if x != nil {
+		return x.FirstFailure
+	}
+	return 0
+}
+
+func (x *FailedMerge) GetLastFailure() int64 {
+	if x != nil {
+		return x.LastFailure
+	}
+	return 0
+}
+
+func (x *FailedMerge) GetDeadLettered() bool {
+	if x != nil {
+		return x.DeadLettered
+	}
+	return false
+}
+
+type FailedMerges struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Merges []*FailedMerge `protobuf:"bytes,1,rep,name=Merges,proto3" json:"Merges,omitempty"`
+}
+
+func (x *FailedMerges) Reset() {
+	*x = FailedMerges{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailedMerges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedMerges) ProtoMessage() {}
+
+func (x *FailedMerges) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedMerges.ProtoReflect.Descriptor instead.
+func (*FailedMerges) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *FailedMerges) GetMerges() []*FailedMerge {
+	if x != nil {
+		return x.Merges
+	}
+	return nil
+}
+
+type RetryMergeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0 retries all dead-lettered merges
+	Parent uint64 `protobuf:"varint,1,opt,name=Parent,proto3" json:"Parent,omitempty"`
+}
+
+func (x *RetryMergeRequest) Reset() {
+	*x = RetryMergeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetryMergeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryMergeRequest) ProtoMessage() {}
+
+func (x *RetryMergeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryMergeRequest.ProtoReflect.Descriptor instead.
+func (*RetryMergeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RetryMergeRequest) GetParent() uint64 {
+	if x != nil {
+		return x.Parent
+	}
+	return 0
+}
+
+type ValidationRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys starting with the prefix (the rule with the longest matching prefix applies)
+	Prefix string `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	// Values must be JSON
+	RequireJson bool `protobuf:"varint,2,opt,name=RequireJson,proto3" json:"RequireJson,omitempty"`
+	// JSON Schema the values must match (implies RequireJson)
+	Schema string `protobuf:"bytes,3,opt,name=Schema,proto3" json:"Schema,omitempty"`
+}
+
+func (x *ValidationRule) Reset() {
+	*x = ValidationRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationRule) ProtoMessage() {}
+
+func (x *ValidationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationRule.ProtoReflect.Descriptor instead.
+func (*ValidationRule) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ValidationRule) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ValidationRule) GetRequireJson() bool {
+	if x != nil {
+		return x.RequireJson
+	}
+	return false
+}
+
+func (x *ValidationRule) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+type ValidationRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*ValidationRule `protobuf:"bytes,1,rep,name=Rules,proto3" json:"Rules,omitempty"`
+	// Set when pushed by another server
+	Replicated bool `protobuf:"varint,2,opt,name=Replicated,proto3" json:"Replicated,omitempty"`
+}
+
+func (x *ValidationRules) Reset() {
+	*x = ValidationRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[96]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationRules) ProtoMessage() {}
+
+func (x *ValidationRules) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[96]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationRules.ProtoReflect.Descriptor instead.
+func (*ValidationRules) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ValidationRules) GetRules() []*ValidationRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ValidationRules) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+type MaintenanceTask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Running bool   `protobuf:"varint,2,opt,name=Running,proto3" json:"Running,omitempty"`
+	// Inside one of its windows now
+	InWindow bool `protobuf:"varint,3,opt,name=InWindow,proto3" json:"InWindow,omitempty"`
+	// Unix nanoseconds (0 if never or only when triggered)
+	LastRun            int64 `protobuf:"varint,4,opt,name=LastRun,proto3" json:"LastRun,omitempty"`
+	NextRun            int64 `protobuf:"varint,5,opt,name=NextRun,proto3" json:"NextRun,omitempty"`
+	LastDurationMillis int64 `protobuf:"varint,6,opt,name=LastDurationMillis,proto3" json:"LastDurationMillis,omitempty"`
+	// Error of the last run (empty if it succeeded)
+	LastError string `protobuf:"bytes,7,opt,name=LastError,proto3" json:"LastError,omitempty"`
+}
+
+func (x *MaintenanceTask) Reset() {
+	*x = MaintenanceTask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[97]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaintenanceTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceTask) ProtoMessage() {}
+
+func (x *MaintenanceTask) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[97]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceTask.ProtoReflect.Descriptor instead.
+func (*MaintenanceTask) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *MaintenanceTask) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaintenanceTask) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *MaintenanceTask) GetInWindow() bool {
+	if x != nil {
+		return x.InWindow
+	}
+	return false
+}
+
+func (x *MaintenanceTask) GetLastRun() int64 {
+	if x != nil {
+		return x.LastRun
+	}
+	return 0
+}
+
+func (x *MaintenanceTask) GetNextRun() int64 {
+	if x != nil {
+		return x.NextRun
+	}
+	return 0
+}
+
+func (x *MaintenanceTask) GetLastDurationMillis() int64 {
+	if x != nil {
+		return x.LastDurationMillis
+	}
+	return 0
+}
+
+func (x *MaintenanceTask) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type RunTaskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// compaction, compression or sweep
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+}
+
+func (x *RunTaskRequest) Reset() {
+	*x = RunTaskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[98]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunTaskRequest) ProtoMessage() {}
+
+func (x *RunTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[98]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunTaskRequest.ProtoReflect.Descriptor instead.
+func (*RunTaskRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *RunTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PauseMaintenanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paused bool `protobuf:"varint,1,opt,name=Paused,proto3" json:"Paused,omitempty"`
+}
+
+func (x *PauseMaintenanceRequest) Reset() {
+	*x = PauseMaintenanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[99]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseMaintenanceRequest) ProtoMessage() {}
+
+func (x *PauseMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[99]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*PauseMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *PauseMaintenanceRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type ConvergenceStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only count parents under this root (0 for all)
+	Root uint64 `protobuf:"varint,1,opt,name=Root,proto3" json:"Root,omitempty"`
+	// Wait until converged or until the deadline of the call
+	Block bool `protobuf:"varint,2,opt,name=Block,proto3" json:"Block,omitempty"`
+	// Only this server (set when fanning out)
+	Local bool `protobuf:"varint,3,opt,name=Local,proto3" json:"Local,omitempty"`
+}
+
+func (x *ConvergenceStatusRequest) Reset() {
+	*x = ConvergenceStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[100]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvergenceStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvergenceStatusRequest) ProtoMessage() {}
+
+func (x *ConvergenceStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[100]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvergenceStatusRequest.ProtoReflect.Descriptor instead.
+func (*ConvergenceStatusRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ConvergenceStatusRequest) GetRoot() uint64 {
+	if x != nil {
+		return x.Root
+	}
+	return 0
+}
+
+func (x *ConvergenceStatusRequest) GetBlock() bool {
+	if x != nil {
+		return x.Block
+	}
+	return false
+}
+
+func (x *ConvergenceStatusRequest) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+type ConvergenceReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Parents with more than one child
+	DivergedParents int64 `protobuf:"varint,1,opt,name=DivergedParents,proto3" json:"DivergedParents,omitempty"`
+	// Of those, parents at the threshold of their merge function
+	Mergeable int64 `protobuf:"varint,2,opt,name=Mergeable,proto3" json:"Mergeable,omitempty"`
+	// Merges queued, debounced or waiting for a retry
+	PendingMerges int64 `protobuf:"varint,3,opt,name=PendingMerges,proto3" json:"PendingMerges,omitempty"`
+	// Mergeable parents whose merges were given up on (see GetFailedMerges)
+	DeadLettered int64 `protobuf:"varint,4,opt,name=DeadLettered,proto3" json:"DeadLettered,omitempty"`
+	// When the oldest mergeable parent diverged (unix nanoseconds, 0 if none)
+	OldestDivergence int64 `protobuf:"varint,5,opt,name=OldestDivergence,proto3" json:"OldestDivergence,omitempty"`
+	// No mergeable parent and no pending merge
+	Converged bool `protobuf:"varint,6,opt,name=Converged,proto3" json:"Converged,omitempty"`
+}
+
+func (x *ConvergenceReport) Reset() {
+	*x = ConvergenceReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[101]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvergenceReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvergenceReport) ProtoMessage() {}
+
+func (x *ConvergenceReport) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[101]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvergenceReport.ProtoReflect.Descriptor instead.
+func (*ConvergenceReport) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *ConvergenceReport) GetDivergedParents() int64 {
+	if x != nil {
+		return x.DivergedParents
+	}
+	return 0
+}
+
+func (x *ConvergenceReport) GetMergeable() int64 {
+	if x != nil {
+		return x.Mergeable
+	}
+	return 0
+}
+
+func (x *ConvergenceReport) GetPendingMerges() int64 {
+	if x != nil {
+		return x.PendingMerges
+	}
+	return 0
+}
+
+func (x *ConvergenceReport) GetDeadLettered() int64 {
+	if x != nil {
+		return x.DeadLettered
+	}
+	return 0
+}
+
+func (x *ConvergenceReport) GetOldestDivergence() int64 {
+	if x != nil {
+		return x.OldestDivergence
+	}
+	return 0
+}
+
+func (x *ConvergenceReport) GetConverged() bool {
+	if x != nil {
+		return x.Converged
+	}
+	return false
+}
+
+type MergeHistory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*MergeRecord `protobuf:"bytes,1,rep,name=Records,proto3" json:"Records,omitempty"`
+}
+
+func (x *MergeHistory) Reset() {
+	*x = MergeHistory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[102]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MergeHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeHistory) ProtoMessage() {}
+
+func (x *MergeHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[102]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeHistory.ProtoReflect.Descriptor instead.
+func (*MergeHistory) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *MergeHistory) GetRecords() []*MergeRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type CreateRootResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+}
+
+func (x *CreateRootResponse) Reset() {
+	*x = CreateRootResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[103]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRootResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRootResponse) ProtoMessage() {}
+
+func (x *CreateRootResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[103]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRootResponse.ProtoReflect.Descriptor instead.
+func (*CreateRootResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *CreateRootResponse) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+type SetIndexingLockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lock bool `protobuf:"varint,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	// Server acquiring or releasing the lock
+	Holder string `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
+	// Lock expires after the lease
+	LeaseMillis int64 `protobuf:"varint,3,opt,name=leaseMillis,proto3" json:"leaseMillis,omitempty"`
+	// Whether the server is the split target
+	Target bool `protobuf:"varint,4,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *SetIndexingLockRequest) Reset() {
+	*x = SetIndexingLockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[104]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetIndexingLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIndexingLockRequest) ProtoMessage() {}
+
+func (x *SetIndexingLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[104]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIndexingLockRequest.ProtoReflect.Descriptor instead.
+func (*SetIndexingLockRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *SetIndexingLockRequest) GetLock() bool {
+	if x != nil {
+		return x.Lock
+	}
+	return false
+}
+
+func (x *SetIndexingLockRequest) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+func (x *SetIndexingLockRequest) GetLeaseMillis() int64 {
+	if x != nil {
+		return x.LeaseMillis
+	}
+	return 0
+}
+
+func (x *SetIndexingLockRequest) GetTarget() bool {
+	if x != nil {
+		return x.Target
+	}
+	return false
+}
+
+type SetIndexingLockResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Current mapping epoch
+	Epoch uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// Current lock holder
+	Holder string `protobuf:"bytes,3,opt,name=holder,proto3" json:"holder,omitempty"`
+}
+
+func (x *SetIndexingLockResponse) Reset() {
+	*x = SetIndexingLockResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetIndexingLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIndexingLockResponse) ProtoMessage() {}
+
+func (x *SetIndexingLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIndexingLockResponse.ProtoReflect.Descriptor instead.
+func (*SetIndexingLockResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *SetIndexingLockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetIndexingLockResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SetIndexingLockResponse) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+type SetModeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode Mode `protobuf:"varint,1,opt,name=Mode,proto3,enum=db.Mode" json:"Mode,omitempty"`
+}
+
+func (x *SetModeRequest) Reset() {
+	*x = SetModeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetModeRequest) ProtoMessage() {}
+
+func (x *SetModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetModeRequest.ProtoReflect.Descriptor instead.
+func (*SetModeRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *SetModeRequest) GetMode() Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return Mode_NORMAL
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Self string `protobuf:"bytes,1,opt,name=Self,proto3" json:"Self,omitempty"`
+	// Number of valid nodes
+	Nodes            int64    `protobuf:"varint,2,opt,name=Nodes,proto3" json:"Nodes,omitempty"`
+	Mode             Mode     `protobuf:"varint,3,opt,name=Mode,proto3,enum=db.Mode" json:"Mode,omitempty"`
+	AvailableServers []string `protobuf:"bytes,4,rep,name=AvailableServers,proto3" json:"AvailableServers,omitempty"`
+	Goroutines       int64    `protobuf:"varint,5,opt,name=Goroutines,proto3" json:"Goroutines,omitempty"`
+	// Open outbound connections
+	Connections          int64  `protobuf:"varint,6,opt,name=Connections,proto3" json:"Connections,omitempty"`
+	MaxConnections       int64  `protobuf:"varint,7,opt,name=MaxConnections,proto3" json:"MaxConnections,omitempty"`
+	MaxConcurrentStreams uint32 `protobuf:"varint,8,opt,name=MaxConcurrentStreams,proto3" json:"MaxConcurrentStreams,omitempty"`
+	// Roots created by CreateRoot
+	Roots       int64 `protobuf:"varint,9,opt,name=Roots,proto3" json:"Roots,omitempty"`
+	CacheHits   int64 `protobuf:"varint,10,opt,name=CacheHits,proto3" json:"CacheHits,omitempty"`
+	CacheMisses int64 `protobuf:"varint,11,opt,name=CacheMisses,proto3" json:"CacheMisses,omitempty"`
+	// Ranges claimed by two servers
+	SplitBrainDetected int64 `protobuf:"varint,12,opt,name=SplitBrainDetected,proto3" json:"SplitBrainDetected,omitempty"`
+	SplitBrainResolved int64 `protobuf:"varint,13,opt,name=SplitBrainResolved,proto3" json:"SplitBrainResolved,omitempty"`
+	// Received nodes waiting for warm-up and nodes warmed up
+	WarmUpQueued int64 `protobuf:"varint,14,opt,name=WarmUpQueued,proto3" json:"WarmUpQueued,omitempty"`
+	WarmUpWarmed int64 `protobuf:"varint,15,opt,name=WarmUpWarmed,proto3" json:"WarmUpWarmed,omitempty"`
+	// Recent split attempts started by the server
+	SplitAttempts []*SplitAttempt `protobuf:"bytes,16,rep,name=SplitAttempts,proto3" json:"SplitAttempts,omitempty"`
+	// Spilled values
+	BlobCount int64 `protobuf:"varint,17,opt,name=BlobCount,proto3" json:"BlobCount,omitempty"`
+	BlobBytes int64 `protobuf:"varint,18,opt,name=BlobBytes,proto3" json:"BlobBytes,omitempty"`
+	// Expired nodes kept as stubs (as of the last sweep)
+	ExpiredNodes int64 `protobuf:"varint,19,opt,name=ExpiredNodes,proto3" json:"ExpiredNodes,omitempty"`
+	// Merges given up on after too many failures
+	DeadLetteredMerges int64 `protobuf:"varint,20,opt,name=DeadLetteredMerges,proto3" json:"DeadLetteredMerges,omitempty"`
+	// Memory obtained from the OS as of the last sample
+	MemoryBytes int64 `protobuf:"varint,21,opt,name=MemoryBytes,proto3" json:"MemoryBytes,omitempty"`
+	// Writes are rejected until memory usage drops
+	MemoryPressure       bool  `protobuf:"varint,22,opt,name=MemoryPressure,proto3" json:"MemoryPressure,omitempty"`
+	MemoryRejectedWrites int64 `protobuf:"varint,23,opt,name=MemoryRejectedWrites,proto3" json:"MemoryRejectedWrites,omitempty"`
+	// Events published by type
+	EventCounts map[string]int64 `protobuf:"bytes,24,rep,name=EventCounts,proto3" json:"EventCounts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Cache hits allowed by MaxStalenessMillis of the request
+	CacheStaleServed int64 `protobuf:"varint,25,opt,name=CacheStaleServed,proto3" json:"CacheStaleServed,omitempty"`
+	// Recent load reported by other servers
+	PeerLoads map[string]*PeerLoad `protobuf:"bytes,26,rep,name=PeerLoads,proto3" json:"PeerLoads,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Forwards failed fast because the peer was saturated
+	ShedRequests int64 `protobuf:"varint,27,opt,name=ShedRequests,proto3" json:"ShedRequests,omitempty"`
+	// Decisions of the ACL (internal requests are not counted)
+	AclAllowed int64 `protobuf:"varint,28,opt,name=AclAllowed,proto3" json:"AclAllowed,omitempty"`
+	AclDenied  int64 `protobuf:"varint,29,opt,name=AclDenied,proto3" json:"AclDenied,omitempty"`
+	// Runs collapsed by chain compression and nodes removed by them
+	CompressedRuns  int64 `protobuf:"varint,30,opt,name=CompressedRuns,proto3" json:"CompressedRuns,omitempty"`
+	CompressedNodes int64 `protobuf:"varint,31,opt,name=CompressedNodes,proto3" json:"CompressedNodes,omitempty"`
+	// Bytes of the values of local nodes (including spilled ones)
+	ValueBytes int64 `protobuf:"varint,32,opt,name=ValueBytes,proto3" json:"ValueBytes,omitempty"`
+	// Split protocol level negotiated by the last split or decommission (0 if none)
+	ProtocolLevel int32 `protobuf:"varint,33,opt,name=ProtocolLevel,proto3" json:"ProtocolLevel,omitempty"`
+	// Background tasks run by the scheduler
+	Maintenance []*MaintenanceTask `protobuf:"bytes,34,rep,name=Maintenance,proto3" json:"Maintenance,omitempty"`
+	// Scheduled runs are skipped
+	MaintenancePaused bool `protobuf:"varint,35,opt,name=MaintenancePaused,proto3" json:"MaintenancePaused,omitempty"`
+	// Writes acknowledged at a lower durability than requested
+	DegradedWrites  int64            `protobuf:"varint,36,opt,name=DegradedWrites,proto3" json:"DegradedWrites,omitempty"`
+	SplitGovernance *SplitGovernance `protobuf:"bytes,37,opt,name=SplitGovernance,proto3" json:"SplitGovernance,omitempty"`
+	// Nodes rejected or found with content not matching their checksum
+	ChecksumMismatches int64 `protobuf:"varint,38,opt,name=ChecksumMismatches,proto3" json:"ChecksumMismatches,omitempty"`
+	// Set on standbys (and once promoted)
+	Standby *StandbyStatus `protobuf:"bytes,39,opt,name=Standby,proto3" json:"Standby,omitempty"`
+	// Reads started on this server by the number of remote hops of their chain walk
+	RemoteHops map[int32]int64 `protobuf:"bytes,40,rep,name=RemoteHops,proto3" json:"RemoteHops,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Chain walks stopped by their remote hop budget on this server
+	HopBudgetExceeded int64 `protobuf:"varint,41,opt,name=HopBudgetExceeded,proto3" json:"HopBudgetExceeded,omitempty"`
+	// Replaced locations of this server resolving to their successors
+	// and reads or writes redirected by them
+	Aliases        int64 `protobuf:"varint,42,opt,name=Aliases,proto3" json:"Aliases,omitempty"`
+	AliasRedirects int64 `protobuf:"varint,43,opt,name=AliasRedirects,proto3" json:"AliasRedirects,omitempty"`
+	// The cluster needs more servers: none is available for the next split
+	// after one took the last (or while this server is over its threshold), and why
+	ExpansionNeeded bool   `protobuf:"varint,44,opt,name=ExpansionNeeded,proto3" json:"ExpansionNeeded,omitempty"`
+	ExpansionReason string `protobuf:"bytes,45,opt,name=ExpansionReason,proto3" json:"ExpansionReason,omitempty"`
+	// Cached values compared with their owner, found stale and repaired,
+	// and checks skipped by the rate limit of read repair
+	ReadRepairChecks   int64 `protobuf:"varint,46,opt,name=ReadRepairChecks,proto3" json:"ReadRepairChecks,omitempty"`
+	ReadRepairs        int64 `protobuf:"varint,47,opt,name=ReadRepairs,proto3" json:"ReadRepairs,omitempty"`
+	ReadRepairsSkipped int64 `protobuf:"varint,48,opt,name=ReadRepairsSkipped,proto3" json:"ReadRepairsSkipped,omitempty"`
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[107]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[107]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *GetStatsResponse) GetSelf() string {
+	if x != nil {
+		return x.Self
+	}
+	return ""
+}
+
+func (x *GetStatsResponse) GetNodes() int64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMode() Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return Mode_NORMAL
+}
+
+func (x *GetStatsResponse) GetAvailableServers() []string {
+	if x != nil {
+		return x.AvailableServers
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetGoroutines() int64 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetConnections() int64 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMaxConnections() int64 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMaxConcurrentStreams() uint32 {
+	if x != nil {
+		return x.MaxConcurrentStreams
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetRoots() int64 {
+	if x != nil {
+		return x.Roots
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCacheHits() int64 {
+	if x != nil {
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCacheMisses() int64 {
+	if x != nil {
+		return x.CacheMisses
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetSplitBrainDetected() int64 {
+	if x != nil {
+		return x.SplitBrainDetected
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetSplitBrainResolved() int64 {
+	if x != nil {
+		return x.SplitBrainResolved
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetWarmUpQueued() int64 {
+	if x != nil {
+		return x.WarmUpQueued
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetWarmUpWarmed() int64 {
+	if x != nil {
+		return x.WarmUpWarmed
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetSplitAttempts() []*SplitAttempt {
+	if x != nil {
+		return x.SplitAttempts
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetBlobCount() int64 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetBlobBytes() int64 {
+	if x != nil {
+		return x.BlobBytes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetExpiredNodes() int64 {
+	if x != nil {
+		return x.ExpiredNodes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetDeadLetteredMerges() int64 {
+	if x != nil {
+		return x.DeadLetteredMerges
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMemoryPressure() bool {
+	if x != nil {
+		return x.MemoryPressure
+	}
+	return false
+}
+
+func (x *GetStatsResponse) GetMemoryRejectedWrites() int64 {
+	if x != nil {
+		return x.MemoryRejectedWrites
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetEventCounts() map[string]int64 {
+	if x != nil {
+		return x.EventCounts
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetCacheStaleServed() int64 {
+	if x != nil {
+		return x.CacheStaleServed
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetPeerLoads() map[string]*PeerLoad {
+	if x != nil {
+		return x.PeerLoads
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetShedRequests() int64 {
+	if x != nil {
+		return x.ShedRequests
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetAclAllowed() int64 {
+	if x != nil {
+		return x.AclAllowed
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetAclDenied() int64 {
+	if x != nil {
+		return x.AclDenied
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCompressedRuns() int64 {
+	if x != nil {
+		return x.CompressedRuns
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCompressedNodes() int64 {
+	if x != nil {
+		return x.CompressedNodes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetValueBytes() int64 {
+	if x != nil {
+		return x.ValueBytes
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetProtocolLevel() int32 {
+	if x != nil {
+		return x.ProtocolLevel
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMaintenance() []*MaintenanceTask {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetMaintenancePaused() bool {
+	if x != nil {
+		return x.MaintenancePaused
+	}
+	return false
+}
+
+func (x *GetStatsResponse) GetDegradedWrites() int64 {
+	if x != nil {
+		return x.DegradedWrites
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetSplitGovernance() *SplitGovernance {
+	if x != nil {
+		return x.SplitGovernance
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetChecksumMismatches() int64 {
+	if x != nil {
+		return x.ChecksumMismatches
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetStandby() *StandbyStatus {
+	if x != nil {
+		return x.Standby
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetRemoteHops() map[int32]int64 {
+	if x != nil {
+		return x.RemoteHops
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetHopBudgetExceeded() int64 {
+	if x != nil {
+		return x.HopBudgetExceeded
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetAliases() int64 {
+	if x != nil {
+		return x.Aliases
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetAliasRedirects() int64 {
+	if x != nil {
+		return x.AliasRedirects
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetExpansionNeeded() bool {
+	if x != nil {
+		return x.ExpansionNeeded
+	}
+	return false
+}
+
+func (x *GetStatsResponse) GetExpansionReason() string {
+	if x != nil {
+		return x.ExpansionReason
+	}
+	return ""
+}
+
+func (x *GetStatsResponse) GetReadRepairChecks() int64 {
+	if x != nil {
+		return x.ReadRepairChecks
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetReadRepairs() int64 {
+	if x != nil {
+		return x.ReadRepairs
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetReadRepairsSkipped() int64 {
+	if x != nil {
+		return x.ReadRepairsSkipped
+	}
+	return 0
+}
+
+type StandbyStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Primary string `protobuf:"bytes,1,opt,name=Primary,proto3" json:"Primary,omitempty"`
+	// Offset of the next record to apply and the size of the log last reported by the primary
+	Offset         int64 `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	PrimaryLogSize int64 `protobuf:"varint,3,opt,name=PrimaryLogSize,proto3" json:"PrimaryLogSize,omitempty"`
+	LagBytes       int64 `protobuf:"varint,4,opt,name=LagBytes,proto3" json:"LagBytes,omitempty"`
+	// Time since the whole log of the primary was last applied
+	LagMillis int64 `protobuf:"varint,5,opt,name=LagMillis,proto3" json:"LagMillis,omitempty"`
+	Connected bool  `protobuf:"varint,6,opt,name=Connected,proto3" json:"Connected,omitempty"`
+	// Last error following the primary
+	Error          string `protobuf:"bytes,7,opt,name=Error,proto3" json:"Error,omitempty"`
+	AppliedRecords int64  `protobuf:"varint,8,opt,name=AppliedRecords,proto3" json:"AppliedRecords,omitempty"`
+	Promoted       bool   `protobuf:"varint,9,opt,name=Promoted,proto3" json:"Promoted,omitempty"`
+}
+
+func (x *StandbyStatus) Reset() {
+	*x = StandbyStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[108]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StandbyStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StandbyStatus) ProtoMessage() {}
+
+func (x *StandbyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[108]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StandbyStatus.ProtoReflect.Descriptor instead.
+func (*StandbyStatus) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *StandbyStatus) GetPrimary() string {
+	if x != nil {
+		return x.Primary
+	}
+	return ""
+}
+
+func (x *StandbyStatus) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StandbyStatus) GetPrimaryLogSize() int64 {
+	if x != nil {
+		return x.PrimaryLogSize
+	}
+	return 0
+}
+
+func (x *StandbyStatus) GetLagBytes() int64 {
+	if x != nil {
+		return x.LagBytes
+	}
+	return 0
+}
+
+func (x *StandbyStatus) GetLagMillis() int64 {
+	if x != nil {
+		return x.LagMillis
+	}
+	return 0
+}
+
+func (x *StandbyStatus) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *StandbyStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StandbyStatus) GetAppliedRecords() int64 {
+	if x != nil {
+		return x.AppliedRecords
+	}
+	return 0
+}
+
+func (x *StandbyStatus) GetPromoted() bool {
+	if x != nil {
+		return x.Promoted
+	}
+	return false
+}
+
+// Spacing of automatic splits
+type SplitGovernance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinIntervalMillis int64 `protobuf:"varint,1,opt,name=MinIntervalMillis,proto3" json:"MinIntervalMillis,omitempty"`
+	// Interval after adapting to recent failures and available servers
+	IntervalMillis int64 `protobuf:"varint,2,opt,name=IntervalMillis,proto3" json:"IntervalMillis,omitempty"`
+	// Last automatic split and when the next one may start (unix nanoseconds, 0 if none)
+	LastSplitAt int64 `protobuf:"varint,3,opt,name=LastSplitAt,proto3" json:"LastSplitAt,omitempty"`
+	NextSplitAt int64 `protobuf:"varint,4,opt,name=NextSplitAt,proto3" json:"NextSplitAt,omitempty"`
+	// Failed splits in the last 10 minutes
+	RecentFailures int32 `protobuf:"varint,5,opt,name=RecentFailures,proto3" json:"RecentFailures,omitempty"`
+	// Available servers are at or below the reserve
+	CapacityLow bool `protobuf:"varint,6,opt,name=CapacityLow,proto3" json:"CapacityLow,omitempty"`
+	// Splits deferred and why the last one was
+	Deferred    int64  `protobuf:"varint,7,opt,name=Deferred,proto3" json:"Deferred,omitempty"`
+	DeferReason string `protobuf:"bytes,8,opt,name=DeferReason,proto3" json:"DeferReason,omitempty"`
+}
+
+func (x *SplitGovernance) Reset() {
+	*x = SplitGovernance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[109]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SplitGovernance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitGovernance) ProtoMessage() {}
+
+func (x *SplitGovernance) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[109]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitGovernance.ProtoReflect.Descriptor instead.
+func (*SplitGovernance) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *SplitGovernance) GetMinIntervalMillis() int64 {
+	if x != nil {
+		return x.MinIntervalMillis
+	}
+	return 0
+}
+
+func (x *SplitGovernance) GetIntervalMillis() int64 {
+	if x != nil {
+		return x.IntervalMillis
+	}
+	return 0
+}
+
+func (x *SplitGovernance) GetLastSplitAt() int64 {
+	if x != nil {
+		return x.LastSplitAt
+	}
+	return 0
+}
+
+func (x *SplitGovernance) GetNextSplitAt() int64 {
+	if x != nil {
+		return x.NextSplitAt
+	}
+	return 0
+}
+
+func (x *SplitGovernance) GetRecentFailures() int32 {
+	if x != nil {
+		return x.RecentFailures
+	}
+	return 0
+}
+
+func (x *SplitGovernance) GetCapacityLow() bool {
+	if x != nil {
+		return x.CapacityLow
+	}
+	return false
+}
+
+func (x *SplitGovernance) GetDeferred() int64 {
+	if x != nil {
+		return x.Deferred
+	}
+	return 0
+}
+
+func (x *SplitGovernance) GetDeferReason() string {
+	if x != nil {
+		return x.DeferReason
+	}
+	return ""
+}
+
+type TopConflictingKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of keys (default 10)
+	Limit int32 `protobuf:"varint,1,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (x *TopConflictingKeysRequest) Reset() {
+	*x = TopConflictingKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[110]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopConflictingKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopConflictingKeysRequest) ProtoMessage() {}
+
+func (x *TopConflictingKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[110]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopConflictingKeysRequest.ProtoReflect.Descriptor instead.
+func (*TopConflictingKeysRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *TopConflictingKeysRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ConflictingKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Writes creating a sibling in the window (estimated, never under-counted)
+	Count int64 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	// Parent of the last conflict
+	Parent uint64 `protobuf:"varint,3,opt,name=Parent,proto3" json:"Parent,omitempty"`
+	// Merge function resolving conflicts under the parent (empty if none)
+	MergeFunction string `protobuf:"bytes,4,opt,name=MergeFunction,proto3" json:"MergeFunction,omitempty"`
+	LastConflict  int64  `protobuf:"varint,5,opt,name=LastConflict,proto3" json:"LastConflict,omitempty"`
+}
+
+func (x *ConflictingKey) Reset() {
+	*x = ConflictingKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[111]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConflictingKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictingKey) ProtoMessage() {}
+
+func (x *ConflictingKey) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[111]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictingKey.ProtoReflect.Descriptor instead.
+func (*ConflictingKey) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *ConflictingKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConflictingKey) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ConflictingKey) GetParent() uint64 {
+	if x != nil {
+		return x.Parent
+	}
+	return 0
+}
+
+func (x *ConflictingKey) GetMergeFunction() string {
+	if x != nil {
+		return x.MergeFunction
+	}
+	return ""
+}
+
+func (x *ConflictingKey) GetLastConflict() int64 {
+	if x != nil {
+		return x.LastConflict
+	}
+	return 0
+}
+
+type ConflictingKeys struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys          []*ConflictingKey `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	WindowSeconds int64             `protobuf:"varint,2,opt,name=WindowSeconds,proto3" json:"WindowSeconds,omitempty"`
+}
+
+func (x *ConflictingKeys) Reset() {
+	*x = ConflictingKeys{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[112]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConflictingKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictingKeys) ProtoMessage() {}
+
+func (x *ConflictingKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[112]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictingKeys.ProtoReflect.Descriptor instead.
+func (*ConflictingKeys) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ConflictingKeys) GetKeys() []*ConflictingKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *ConflictingKeys) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+type TopSpaceConsumersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of values and prefixes (default 10)
+	Limit int32 `protobuf:"varint,1,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (x *TopSpaceConsumersRequest) Reset() {
+	*x = TopSpaceConsumersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[113]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopSpaceConsumersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopSpaceConsumersRequest) ProtoMessage() {}
+
+func (x *TopSpaceConsumersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[113]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopSpaceConsumersRequest.ProtoReflect.Descriptor instead.
+func (*TopSpaceConsumersRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *TopSpaceConsumersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SpaceConsumer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Location uint64 `protobuf:"varint,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	Bytes    int64  `protobuf:"varint,3,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+}
+
+func (x *SpaceConsumer) Reset() {
+	*x = SpaceConsumer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[114]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpaceConsumer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceConsumer) ProtoMessage() {}
+
+func (x *SpaceConsumer) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[114]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceConsumer.ProtoReflect.Descriptor instead.
+func (*SpaceConsumer) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *SpaceConsumer) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SpaceConsumer) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *SpaceConsumer) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+type SpacePrefix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix string `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	Bytes  int64  `protobuf:"varint,2,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	Nodes  int64  `protobuf:"varint,3,opt,name=Nodes,proto3" json:"Nodes,omitempty"`
+}
+
+func (x *SpacePrefix) Reset() {
+	*x = SpacePrefix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[115]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpacePrefix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpacePrefix) ProtoMessage() {}
+
+func (x *SpacePrefix) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[115]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpacePrefix.ProtoReflect.Descriptor instead.
+func (*SpacePrefix) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *SpacePrefix) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SpacePrefix) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *SpacePrefix) GetNodes() int64 {
+	if x != nil {
+		return x.Nodes
+	}
+	return 0
+}
+
+type SpaceConsumers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Largest values first
+	Values []*SpaceConsumer `protobuf:"bytes,1,rep,name=Values,proto3" json:"Values,omitempty"`
+	// Key prefixes taking the most bytes first
+	Prefixes   []*SpacePrefix `protobuf:"bytes,2,rep,name=Prefixes,proto3" json:"Prefixes,omitempty"`
+	ValueBytes int64          `protobuf:"varint,3,opt,name=ValueBytes,proto3" json:"ValueBytes,omitempty"`
+}
+
+func (x *SpaceConsumers) Reset() {
+	*x = SpaceConsumers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[116]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpaceConsumers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceConsumers) ProtoMessage() {}
+
+func (x *SpaceConsumers) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[116]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceConsumers.ProtoReflect.Descriptor instead.
+func (*SpaceConsumers) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *SpaceConsumers) GetValues() []*SpaceConsumer {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SpaceConsumers) GetPrefixes() []*SpacePrefix {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+func (x *SpaceConsumers) GetValueBytes() int64 {
+	if x != nil {
+		return x.ValueBytes
+	}
+	return 0
+}
+
+type HotPathsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of keys and branches (default 10)
+	Limit int32 `protobuf:"varint,1,opt,name=Limit,proto3" json:"Limit,omitempty"`
+}
+
+func (x *HotPathsRequest) Reset() {
+	*x = HotPathsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[117]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HotPathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotPathsRequest) ProtoMessage() {}
+
+func (x *HotPathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[117]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotPathsRequest.ProtoReflect.Descriptor instead.
+func (*HotPathsRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *HotPathsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type HotKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// Estimated from samples and decayed over time
+	Reads  float64 `protobuf:"fixed64,2,opt,name=Reads,proto3" json:"Reads,omitempty"`
+	Writes float64 `protobuf:"fixed64,3,opt,name=Writes,proto3" json:"Writes,omitempty"`
+	// Nodes visited by the walks finding the key
+	AverageDepth float64 `protobuf:"fixed64,4,opt,name=AverageDepth,proto3" json:"AverageDepth,omitempty"`
+	// Node defining the key in the last sampled read
+	Node uint64 `protobuf:"varint,5,opt,name=Node,proto3" json:"Node,omitempty"`
+	// Owner of the key
+	Server string `protobuf:"bytes,6,opt,name=Server,proto3" json:"Server,omitempty"`
+}
+
+func (x *HotKey) Reset() {
+	*x = HotKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[118]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HotKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotKey) ProtoMessage() {}
+
+func (x *HotKey) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[118]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotKey.ProtoReflect.Descriptor instead.
+func (*HotKey) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *HotKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HotKey) GetReads() float64 {
+	if x != nil {
+		return x.Reads
+	}
+	return 0
+}
+
+func (x *HotKey) GetWrites() float64 {
+	if x != nil {
+		return x.Writes
+	}
+	return 0
+}
+
+func (x *HotKey) GetAverageDepth() float64 {
+	if x != nil {
+		return x.AverageDepth
+	}
+	return 0
+}
+
+func (x *HotKey) GetNode() uint64 {
+	if x != nil {
+		return x.Node
+	}
+	return 0
+}
+
+func (x *HotKey) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type HotBranch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64  `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	Reads    float64 `protobuf:"fixed64,2,opt,name=Reads,proto3" json:"Reads,omitempty"`
+	// Owner of the location
+	Server string `protobuf:"bytes,3,opt,name=Server,proto3" json:"Server,omitempty"`
+}
+
+func (x *HotBranch) Reset() {
+	*x = HotBranch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[119]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HotBranch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotBranch) ProtoMessage() {}
+
+func (x *HotBranch) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[119]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotBranch.ProtoReflect.Descriptor instead.
+func (*HotBranch) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *HotBranch) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *HotBranch) GetReads() float64 {
+	if x != nil {
+		return x.Reads
+	}
+	return 0
+}
+
+func (x *HotBranch) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+type HotPathsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []*HotKey `protobuf:"bytes,1,rep,name=Keys,proto3" json:"Keys,omitempty"`
+	// Locations reads start from (branch heads)
+	Branches []*HotBranch `protobuf:"bytes,2,rep,name=Branches,proto3" json:"Branches,omitempty"`
+	// Nodes defining the values read
+	Nodes []*HotBranch `protobuf:"bytes,3,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
+	// One in this many accesses is sampled
+	SampleEvery int64 `protobuf:"varint,4,opt,name=SampleEvery,proto3" json:"SampleEvery,omitempty"`
+	// Counts are halved after this long
+	HalfLifeSeconds int64 `protobuf:"varint,5,opt,name=HalfLifeSeconds,proto3" json:"HalfLifeSeconds,omitempty"`
+}
+
+func (x *HotPathsResponse) Reset() {
+	*x = HotPathsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[120]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HotPathsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HotPathsResponse) ProtoMessage() {}
+
+func (x *HotPathsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[120]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HotPathsResponse.ProtoReflect.Descriptor instead.
+func (*HotPathsResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *HotPathsResponse) GetKeys() []*HotKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *HotPathsResponse) GetBranches() []*HotBranch {
+	if x != nil {
+		return x.Branches
+	}
+	return nil
+}
+
+func (x *HotPathsResponse) GetNodes() []*HotBranch {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *HotPathsResponse) GetSampleEvery() int64 {
+	if x != nil {
+		return x.SampleEvery
+	}
+	return 0
+}
+
+func (x *HotPathsResponse) GetHalfLifeSeconds() int64 {
+	if x != nil {
+		return x.HalfLifeSeconds
+	}
+	return 0
+}
+
+type CompressChainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Last node of the run
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+}
+
+func (x *CompressChainRequest) Reset() {
+	*x = CompressChainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[121]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompressChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompressChainRequest) ProtoMessage() {}
+
+func (x *CompressChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[121]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompressChainRequest.ProtoReflect.Descriptor instead.
+func (*CompressChainRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *CompressChainRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+type CompressChainResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Nodes collapsed into the last one
+	Removed int64 `protobuf:"varint,1,opt,name=Removed,proto3" json:"Removed,omitempty"`
+}
+
+func (x *CompressChainResponse) Reset() {
+	*x = CompressChainResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[122]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompressChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompressChainResponse) ProtoMessage() {}
+
+func (x *CompressChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[122]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompressChainResponse.ProtoReflect.Descriptor instead.
+func (*CompressChainResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *CompressChainResponse) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type RedactValueRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location uint64 `protobuf:"varint,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Replacement of the value (empty to erase it)
+	Value []byte `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	// Why the value is redacted (required, recorded in the audit event)
+	Reason string `protobuf:"bytes,3,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	// Who asked for it (required, recorded with the authenticated identity if any)
+	Operator string `protobuf:"bytes,4,opt,name=Operator,proto3" json:"Operator,omitempty"`
+	// Authenticated identity of the caller, set by the server forwarding the request
+	Identity  string `protobuf:"bytes,5,opt,name=Identity,proto3" json:"Identity,omitempty"`
+	Forwarded bool   `protobuf:"varint,6,opt,name=Forwarded,proto3" json:"Forwarded,omitempty"`
+}
+
+func (x *RedactValueRequest) Reset() {
+	*x = RedactValueRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[123]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedactValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedactValueRequest) ProtoMessage() {}
+
+func (x *RedactValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[123]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedactValueRequest.ProtoReflect.Descriptor instead.
+func (*RedactValueRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *RedactValueRequest) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *RedactValueRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *RedactValueRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RedactValueRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *RedactValueRequest) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *RedactValueRequest) GetForwarded() bool {
+	if x != nil {
+		return x.Forwarded
+	}
+	return false
+}
+
+type RedactValueResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	// New checksum of the node
+	Checksum uint32 `protobuf:"varint,2,opt,name=Checksum,proto3" json:"Checksum,omitempty"`
+	// Whether the blob of the old value was removed
+	BlobRemoved bool `protobuf:"varint,3,opt,name=BlobRemoved,proto3" json:"BlobRemoved,omitempty"`
+}
+
+func (x *RedactValueResponse) Reset() {
+	*x = RedactValueResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[124]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedactValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedactValueResponse) ProtoMessage() {}
+
+func (x *RedactValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[124]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedactValueResponse.ProtoReflect.Descriptor instead.
+func (*RedactValueResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *RedactValueResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RedactValueResponse) GetChecksum() uint32 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *RedactValueResponse) GetBlobRemoved() bool {
+	if x != nil {
+		return x.BlobRemoved
+	}
+	return false
+}
+
+type ACL struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Json string `protobuf:"bytes,1,opt,name=Json,proto3" json:"Json,omitempty"`
+	// Pushed by another server
+	Replicated bool `protobuf:"varint,2,opt,name=Replicated,proto3" json:"Replicated,omitempty"`
+}
+
+func (x *ACL) Reset() {
+	*x = ACL{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[125]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ACL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ACL) ProtoMessage() {}
+
+func (x *ACL) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[125]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ACL.ProtoReflect.Descriptor instead.
+func (*ACL) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *ACL) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+func (x *ACL) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+type PeerLoad struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Requests being handled
+	Inflight int64 `protobuf:"varint,1,opt,name=Inflight,proto3" json:"Inflight,omitempty"`
+	// Latency of recent requests
+	P99Millis      int64 `protobuf:"varint,2,opt,name=P99Millis,proto3" json:"P99Millis,omitempty"`
+	MemoryPressure bool  `protobuf:"varint,3,opt,name=MemoryPressure,proto3" json:"MemoryPressure,omitempty"`
+	// Suggested wait before sending more requests
+	RetryAfterMillis int64 `protobuf:"varint,4,opt,name=RetryAfterMillis,proto3" json:"RetryAfterMillis,omitempty"`
+}
+
+func (x *PeerLoad) Reset() {
+	*x = PeerLoad{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[126]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerLoad) ProtoMessage() {}
+
+func (x *PeerLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[126]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerLoad.ProtoReflect.Descriptor instead.
+func (*PeerLoad) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *PeerLoad) GetInflight() int64 {
+	if x != nil {
+		return x.Inflight
+	}
+	return 0
+}
+
+func (x *PeerLoad) GetP99Millis() int64 {
+	if x != nil {
+		return x.P99Millis
+	}
+	return 0
+}
+
+func (x *PeerLoad) GetMemoryPressure() bool {
+	if x != nil {
+		return x.MemoryPressure
+	}
+	return false
+}
+
+func (x *PeerLoad) GetRetryAfterMillis() int64 {
+	if x != nil {
+		return x.RetryAfterMillis
+	}
+	return 0
+}
+
+type ReloadConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fields changed in the config file and applied
+	Applied []string `protobuf:"bytes,1,rep,name=Applied,proto3" json:"Applied,omitempty"`
+	// Fields changed in the config file that need a restart
+	Rejected []string `protobuf:"bytes,2,rep,name=Rejected,proto3" json:"Rejected,omitempty"`
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[127]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[127]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *ReloadConfigResponse) GetApplied() []string {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+func (x *ReloadConfigResponse) GetRejected() []string {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+type ConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Effective config with secrets redacted
+	Json string `protobuf:"bytes,1,opt,name=Json,proto3" json:"Json,omitempty"`
+}
+
+func (x *ConfigResponse) Reset() {
+	*x = ConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[128]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigResponse) ProtoMessage() {}
+
+func (x *ConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[128]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigResponse.ProtoReflect.Descriptor instead.
+func (*ConfigResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *ConfigResponse) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+type CompactResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemovedBlobs int64 `protobuf:"varint,1,opt,name=RemovedBlobs,proto3" json:"RemovedBlobs,omitempty"`
+	FreedBytes   int64 `protobuf:"varint,2,opt,name=FreedBytes,proto3" json:"FreedBytes,omitempty"`
+	// Expired nodes without children
+	RemovedNodes int64 `protobuf:"varint,3,opt,name=RemovedNodes,proto3" json:"RemovedNodes,omitempty"`
+}
+
+func (x *CompactResponse) Reset() {
+	*x = CompactResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[129]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactResponse) ProtoMessage() {}
+
+func (x *CompactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[129]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactResponse.ProtoReflect.Descriptor instead.
+func (*CompactResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *CompactResponse) GetRemovedBlobs() int64 {
+	if x != nil {
+		return x.RemovedBlobs
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetFreedBytes() int64 {
+	if x != nil {
+		return x.FreedBytes
+	}
+	return 0
+}
+
+func (x *CompactResponse) GetRemovedNodes() int64 {
+	if x != nil {
+		return x.RemovedNodes
+	}
+	return 0
+}
+
+type SplitAttempt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target string `protobuf:"bytes,1,opt,name=Target,proto3" json:"Target,omitempty"`
+	// locking, transferring, committing, verifying, committed, aborted or failed
+	Phase            string `protobuf:"bytes,2,opt,name=Phase,proto3" json:"Phase,omitempty"`
+	TransferredNodes int64  `protobuf:"varint,3,opt,name=TransferredNodes,proto3" json:"TransferredNodes,omitempty"`
+	Error            string `protobuf:"bytes,4,opt,name=Error,proto3" json:"Error,omitempty"`
+	StartedAt        int64  `protobuf:"varint,5,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	// Moved nodes read back from the target before the local copies are removed
+	VerifiedSamples int64 `protobuf:"varint,6,opt,name=VerifiedSamples,proto3" json:"VerifiedSamples,omitempty"`
+	FailedSamples   int64 `protobuf:"varint,7,opt,name=FailedSamples,proto3" json:"FailedSamples,omitempty"`
+	// The mapping was applied then restored since samples failed
+	RolledBack bool `protobuf:"varint,8,opt,name=RolledBack,proto3" json:"RolledBack,omitempty"`
+}
+
+func (x *SplitAttempt) Reset() {
+	*x = SplitAttempt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[130]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SplitAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitAttempt) ProtoMessage() {}
+
+func (x *SplitAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[130]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitAttempt.ProtoReflect.Descriptor instead.
+func (*SplitAttempt) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *SplitAttempt) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SplitAttempt) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *SplitAttempt) GetTransferredNodes() int64 {
+	if x != nil {
+		return x.TransferredNodes
+	}
+	return 0
+}
+
+func (x *SplitAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SplitAttempt) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *SplitAttempt) GetVerifiedSamples() int64 {
+	if x != nil {
+		return x.VerifiedSamples
+	}
+	return 0
+}
+
+func (x *SplitAttempt) GetFailedSamples() int64 {
+	if x != nil {
+		return x.FailedSamples
+	}
+	return 0
+}
+
+func (x *SplitAttempt) GetRolledBack() bool {
+	if x != nil {
+		return x.RolledBack
+	}
+	return false
+}
+
+type PlacementRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix    string `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	Delimiter string `protobuf:"bytes,2,opt,name=Delimiter,proto3" json:"Delimiter,omitempty"`
+}
+
+func (x *PlacementRule) Reset() {
+	*x = PlacementRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[131]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlacementRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementRule) ProtoMessage() {}
+
+func (x *PlacementRule) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[131]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementRule.ProtoReflect.Descriptor instead.
+func (*PlacementRule) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *PlacementRule) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *PlacementRule) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+type PlacementRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*PlacementRule `protobuf:"bytes,1,rep,name=Rules,proto3" json:"Rules,omitempty"`
+	// Assigned by the seed (0 in requests of clients)
+	Epoch uint64 `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+}
+
+func (x *PlacementRules) Reset() {
+	*x = PlacementRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[132]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlacementRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementRules) ProtoMessage() {}
+
+func (x *PlacementRules) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[132]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementRules.ProtoReflect.Descriptor instead.
+func (*PlacementRules) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *PlacementRules) GetRules() []*PlacementRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *PlacementRules) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type VerifyPlacementResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Local nodes with a key
+	Checked int64 `protobuf:"varint,1,opt,name=Checked,proto3" json:"Checked,omitempty"`
+	// Nodes whose location does not follow the current rules (at most 100 listed)
+	Misplaced int64    `protobuf:"varint,2,opt,name=Misplaced,proto3" json:"Misplaced,omitempty"`
+	Locations []uint64 `protobuf:"varint,3,rep,packed,name=Locations,proto3" json:"Locations,omitempty"`
+}
+
+func (x *VerifyPlacementResponse) Reset() {
+	*x = VerifyPlacementResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[133]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyPlacementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPlacementResponse) ProtoMessage() {}
+
+func (x *VerifyPlacementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[133]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPlacementResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPlacementResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *VerifyPlacementResponse) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *VerifyPlacementResponse) GetMisplaced() int64 {
+	if x != nil {
+		return x.Misplaced
+	}
+	return 0
+}
+
+func (x *VerifyPlacementResponse) GetLocations() []uint64 {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type CheckIntegrityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Checked int64 `protobuf:"varint,1,opt,name=Checked,proto3" json:"Checked,omitempty"`
+	// Nodes whose location does not match the hash of their key
+	InvalidLocations int64 `protobuf:"varint,2,opt,name=InvalidLocations,proto3" json:"InvalidLocations,omitempty"`
+	// Nodes in ranges owned by other servers
+	Misrouted int64 `protobuf:"varint,3,opt,name=Misrouted,proto3" json:"Misrouted,omitempty"`
+	// Invalid or misrouted nodes (at most 100 listed)
+	Locations []uint64 `protobuf:"varint,4,rep,packed,name=Locations,proto3" json:"Locations,omitempty"`
+}
+
+func (x *CheckIntegrityResponse) Reset() {
+	*x = CheckIntegrityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[134]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckIntegrityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckIntegrityResponse) ProtoMessage() {}
+
+func (x *CheckIntegrityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[134]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckIntegrityResponse.ProtoReflect.Descriptor instead.
+func (*CheckIntegrityResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *CheckIntegrityResponse) GetChecked() int64 {
+	if x != nil {
+		return x.Checked
+	}
+	return 0
+}
+
+func (x *CheckIntegrityResponse) GetInvalidLocations() int64 {
+	if x != nil {
+		return x.InvalidLocations
+	}
+	return 0
+}
+
+func (x *CheckIntegrityResponse) GetMisrouted() int64 {
+	if x != nil {
+		return x.Misrouted
+	}
+	return 0
+}
+
+func (x *CheckIntegrityResponse) GetLocations() []uint64 {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type GetRangeDigestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Inclusive range of key hashes
+	Left  uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+}
+
+func (x *GetRangeDigestRequest) Reset() {
+	*x = GetRangeDigestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[135]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRangeDigestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRangeDigestRequest) ProtoMessage() {}
+
+func (x *GetRangeDigestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[135]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRangeDigestRequest.ProtoReflect.Descriptor instead.
+func (*GetRangeDigestRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *GetRangeDigestRequest) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *GetRangeDigestRequest) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+type RangeDigest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left  uint32 `protobuf:"varint,1,opt,name=Left,proto3" json:"Left,omitempty"`
+	Right uint32 `protobuf:"varint,2,opt,name=Right,proto3" json:"Right,omitempty"`
+	// Local nodes in the range
+	Count int64 `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`
+	// Order-independent hash of the nodes
+	Digest uint64 `protobuf:"varint,4,opt,name=Digest,proto3" json:"Digest,omitempty"`
+}
+
+func (x *RangeDigest) Reset() {
+	*x = RangeDigest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[136]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeDigest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeDigest) ProtoMessage() {}
+
+func (x *RangeDigest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[136]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeDigest.ProtoReflect.Descriptor instead.
+func (*RangeDigest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *RangeDigest) GetLeft() uint32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *RangeDigest) GetRight() uint32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *RangeDigest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RangeDigest) GetDigest() uint64 {
+	if x != nil {
+		return x.Digest
+	}
+	return 0
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	// Number of nodes the server can hold
+	Capacity int64 `protobuf:"varint,2,opt,name=Capacity,proto3" json:"Capacity,omitempty"`
+	// Server removed from the membership (a dead primary replaced by its standby)
+	Replaces string `protobuf:"bytes,3,opt,name=Replaces,proto3" json:"Replaces,omitempty"`
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[137]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[137]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *RegisterRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *RegisterRequest) GetReplaces() string {
+	if x != nil {
+		return x.Replaces
+	}
+	return ""
+}
+
+// Stream the log of the disk backend from Offset on
+type FollowWALRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Start of a record (0 for the whole log)
+	Offset int64 `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// Address of the standby
+	Follower string `protobuf:"bytes,2,opt,name=Follower,proto3" json:"Follower,omitempty"`
+}
+
+func (x *FollowWALRequest) Reset() {
+	*x = FollowWALRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[138]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FollowWALRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowWALRequest) ProtoMessage() {}
+
+func (x *FollowWALRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[138]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowWALRequest.ProtoReflect.Descriptor instead.
+func (*FollowWALRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *FollowWALRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FollowWALRequest) GetFollower() string {
+	if x != nil {
+		return x.Follower
+	}
+	return ""
+}
+
+type WALRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Offset of the record in the log of the primary and of the next one
+	Offset   int64  `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Next     int64  `protobuf:"varint,2,opt,name=Next,proto3" json:"Next,omitempty"`
+	Location uint64 `protobuf:"varint,3,opt,name=Location,proto3" json:"Location,omitempty"`
+	// Stored node with its value inline (unset for a removal)
+	Node *Node `protobuf:"bytes,4,opt,name=Node,proto3" json:"Node,omitempty"`
+}
+
+func (x *WALRecord) Reset() {
+	*x = WALRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[139]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WALRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WALRecord) ProtoMessage() {}
+
+func (x *WALRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[139]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WALRecord.ProtoReflect.Descriptor instead.
+func (*WALRecord) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *WALRecord) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *WALRecord) GetNext() int64 {
+	if x != nil {
+		return x.Next
+	}
+	return 0
+}
+
+func (x *WALRecord) GetLocation() uint64 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *WALRecord) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// Records of the log, or a heartbeat without records
+type WALBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*WALRecord `protobuf:"bytes,1,rep,name=Records,proto3" json:"Records,omitempty"`
+	// Size of the log of the primary and its time (unix nanoseconds) when the batch was sent
+	LogSize int64 `protobuf:"varint,2,opt,name=LogSize,proto3" json:"LogSize,omitempty"`
+	Time    int64 `protobuf:"varint,3,opt,name=Time,proto3" json:"Time,omitempty"`
+}
+
+func (x *WALBatch) Reset() {
+	*x = WALBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[140]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WALBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WALBatch) ProtoMessage() {}
+
+func (x *WALBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[140]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WALBatch.ProtoReflect.Descriptor instead.
+func (*WALBatch) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *WALBatch) GetRecords() []*WALRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *WALBatch) GetLogSize() int64 {
+	if x != nil {
+		return x.LogSize
+	}
+	return 0
+}
+
+func (x *WALBatch) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type PromoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Max time to catch up with a reachable primary (0 for default 30s)
+	TimeoutMillis int64 `protobuf:"varint,1,opt,name=TimeoutMillis,proto3" json:"TimeoutMillis,omitempty"`
+}
+
+func (x *PromoteRequest) Reset() {
+	*x = PromoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[141]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteRequest) ProtoMessage() {}
+
+func (x *PromoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[141]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteRequest.ProtoReflect.Descriptor instead.
+func (*PromoteRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *PromoteRequest) GetTimeoutMillis() int64 {
+	if x != nil {
+		return x.TimeoutMillis
+	}
+	return 0
+}
+
+type PromoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Primary string `protobuf:"bytes,1,opt,name=Primary,proto3" json:"Primary,omitempty"`
+	// Ranges of the primary now owned by the standby
+	Ranges []*Range `protobuf:"bytes,2,rep,name=Ranges,proto3" json:"Ranges,omitempty"`
+	// Whether the primary was reachable and switched to READONLY
+	PrimaryDemoted bool `protobuf:"varint,3,opt,name=PrimaryDemoted,proto3" json:"PrimaryDemoted,omitempty"`
+	// Lag when promoted (records of the log of a dead primary not applied are lost)
+	LagBytes  int64  `protobuf:"varint,4,opt,name=LagBytes,proto3" json:"LagBytes,omitempty"`
+	LagMillis int64  `protobuf:"varint,5,opt,name=LagMillis,proto3" json:"LagMillis,omitempty"`
+	Epoch     uint64 `protobuf:"varint,6,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+}
+
+func (x *PromoteResponse) Reset() {
+	*x = PromoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[142]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteResponse) ProtoMessage() {}
+
+func (x *PromoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[142]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteResponse.ProtoReflect.Descriptor instead.
+func (*PromoteResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *PromoteResponse) GetPrimary() string {
+	if x != nil {
+		return x.Primary
+	}
+	return ""
+}
+
+func (x *PromoteResponse) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *PromoteResponse) GetPrimaryDemoted() bool {
+	if x != nil {
+		return x.PrimaryDemoted
+	}
+	return false
+}
+
+func (x *PromoteResponse) GetLagBytes() int64 {
+	if x != nil {
+		return x.LagBytes
+	}
+	return 0
+}
+
+func (x *PromoteResponse) GetLagMillis() int64 {
+	if x != nil {
+		return x.LagMillis
+	}
+	return 0
+}
+
+func (x *PromoteResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type Membership struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers          []string         `protobuf:"bytes,1,rep,name=Servers,proto3" json:"Servers,omitempty"`
+	AvailableServers []string         `protobuf:"bytes,2,rep,name=AvailableServers,proto3" json:"AvailableServers,omitempty"`
+	Epoch            uint64           `protobuf:"varint,3,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Capacity         map[string]int64 `protobuf:"bytes,4,rep,name=Capacity,proto3" json:"Capacity,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Split targets reserved by their requesters (until the split commits or aborts)
+	Reserved map[string]string `protobuf:"bytes,5,rep,name=Reserved,proto3" json:"Reserved,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[143]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[143]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *Membership) GetServers() []string {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *Membership) GetAvailableServers() []string {
+	if x != nil {
+		return x.AvailableServers
+	}
+	return nil
+}
+
+func (x *Membership) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Membership) GetCapacity() map[string]int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+func (x *Membership) GetReserved() map[string]string {
+	if x != nil {
+		return x.Reserved
+	}
+	return nil
+}
+
+type AllocateServerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requester string `protobuf:"bytes,1,opt,name=Requester,proto3" json:"Requester,omitempty"`
+	// Empty to let the seed pick the least loaded server
+	Target string `protobuf:"bytes,2,opt,name=Target,proto3" json:"Target,omitempty"`
+}
+
+func (x *AllocateServerRequest) Reset() {
+	*x = AllocateServerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[144]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllocateServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateServerRequest) ProtoMessage() {}
+
+func (x *AllocateServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[144]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateServerRequest.ProtoReflect.Descriptor instead.
+func (*AllocateServerRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *AllocateServerRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *AllocateServerRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type AllocateServerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	// Membership epoch of the reservation
+	Epoch uint64 `protobuf:"varint,2,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+}
+
+func (x *AllocateServerResponse) Reset() {
+	*x = AllocateServerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[145]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllocateServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocateServerResponse) ProtoMessage() {}
+
+func (x *AllocateServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[145]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocateServerResponse.ProtoReflect.Descriptor instead.
+func (*AllocateServerResponse) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *AllocateServerResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AllocateServerResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type ReleaseServerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	// The split to the server was rolled back after its mapping was applied,
+	// so it is no longer reserved but owns no range
+	RolledBack bool `protobuf:"varint,2,opt,name=RolledBack,proto3" json:"RolledBack,omitempty"`
+}
+
+func (x *ReleaseServerRequest) Reset() {
+	*x = ReleaseServerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_db_proto_msgTypes[146]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseServerRequest) ProtoMessage() {}
+
+func (x *ReleaseServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_proto_msgTypes[146]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseServerRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseServerRequest) Descriptor() ([]byte, []int) {
+	return file_db_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *ReleaseServerRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ReleaseServerRequest) GetRolledBack() bool {
+	if x != nil {
+		return x.RolledBack
+	}
+	return false
+}
+
+var File_db_proto protoreflect.FileDescriptor
+
+var file_db_proto_rawDesc = []byte{
+	0x0a, 0x08, 0x64, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x64, 0x62, 0x22, 0xea,
+	0x02, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x43,
+	0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x41, 0x73, 0x4f, 0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x41,
+	0x73, 0x4f, 0x66, 0x12, 0x2e, 0x0a, 0x12, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e,
+	0x65, 0x73, 0x73, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x12, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x57, 0x61, 0x6c, 0x6b, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x53,
+	0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x22, 0x3e, 0x0a, 0x08, 0x57,
+	0x61, 0x6c, 0x6b, 0x53, 0x74, 0x65, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x83, 0x03, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x46, 0x72, 0x6f, 0x6d, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2a, 0x0a,
+	0x10, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
+	0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x52,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48,
+	0x6f, 0x70, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x52, 0x65, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x22, 0x93, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x39, 0x0a, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5e, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4e,
+	0x6f, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x4e,
+	0x6f, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x22, 0xd7, 0x02, 0x0a, 0x0b, 0x53, 0x6c, 0x6f, 0x77,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x63,
+	0x72, 0x6f, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x6f, 0x70,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x42, 0x0a,
+	0x0b, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x63, 0x72, 0x6f,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65, 0x49,
+	0x64, 0x1a, 0x3e, 0x0a, 0x10, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x36, 0x0a, 0x07, 0x53, 0x6c, 0x6f, 0x77, 0x4c, 0x6f, 0x67, 0x12, 0x2b, 0x0a, 0x08,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x64, 0x62, 0x2e, 0x53, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x94, 0x03, 0x0a, 0x0c, 0x4a, 0x6f,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x65,
+	0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70, 0x12, 0x1a, 0x0a, 0x08,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x65, 0x65, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x4f, 0x72,
+	0x69, 0x67, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x49, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x6c, 0x6f, 0x77, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x53, 0x6c, 0x6f, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x22, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x55, 0x6e,
+	0x74, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x55, 0x6e, 0x74, 0x69, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x51, 0x0a, 0x07, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61,
+	0x6c, 0x12, 0x2a, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x62, 0x2e, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x80, 0x01, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79,
+	0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x61, 0x78, 0x52, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x4d,
+	0x61, 0x78, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73, 0x22, 0x67, 0x0a, 0x09,
+	0x48, 0x65, 0x61, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x65, 0x61,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x65, 0x61, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x46, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x48, 0x65, 0x61,
+	0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x48, 0x65, 0x61, 0x64, 0x73, 0x22, 0x8c,
+	0x03, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x65, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70, 0x12, 0x2a, 0x0a, 0x10, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x44, 0x61, 0x6e, 0x67, 0x6c, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x10, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x61, 0x6e, 0x67, 0x6c, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x54, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x2e, 0x0a, 0x0a, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x20, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x28, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x53, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x52,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x22, 0xff, 0x01,
+	0x0a, 0x0b, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x0b, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f,
+	0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x0a,
+	0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x52, 0x0a, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x24, 0x0a, 0x0d,
+	0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44, 0x65, 0x70, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44,
+	0x65, 0x70, 0x12, 0x2b, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x4b, 0x65, 0x79, 0x52, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x22,
+	0xba, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x53, 0x0a, 0x0f,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x22, 0x6f, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x48, 0x00, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x31,
+	0x0a, 0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x42, 0x08, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x3b, 0x0a, 0x0b, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x48, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x48, 0x65, 0x61, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x05, 0x48, 0x65, 0x61,
+	0x64, 0x73, 0x22, 0x37, 0x0a, 0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12,
+	0x2a, 0x0a, 0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x09, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x25, 0x0a, 0x05, 0x48,
+	0x65, 0x61, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x55, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x28, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x4b, 0x0a, 0x15, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x7e, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x65, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x41, 0x72, 0x67, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x41, 0x72, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65,
+	0x61, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x52, 0x65,
+	0x61, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x41, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6f, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x4f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x44, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70,
+	0x12, 0x18, 0x0a, 0x07, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x74,
+	0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x54, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x5e, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x4f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0xef, 0x05, 0x0a, 0x04, 0x4e,
+	0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x44, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x44, 0x65,
+	0x70, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x62, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0a,
+	0x43, 0x68, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x12,
+	0x4e, 0x65, 0x78, 0x74, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x68,
+	0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x0f,
+	0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69,
+	0x63, 0x74, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x12, 0x2e, 0x0a, 0x12, 0x55, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x55, 0x6e,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x6c, 0x65, 0x73, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x4b, 0x65, 0x79, 0x6c, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x26, 0x0a, 0x0e, 0x52, 0x65,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x72,
+	0x6f, 0x6d, 0x12, 0x2b, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x14,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x4b, 0x65, 0x79, 0x52, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x1a,
+	0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x91, 0x01, 0x0a,
+	0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x44, 0x65, 0x70, 0x74, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x48, 0x6f, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x48, 0x6f, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e,
+	0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x22, 0x82, 0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x2c, 0x0a, 0x11, 0x53, 0x6b, 0x69, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x53, 0x6b,
+	0x69, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12,
+	0x24, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x85, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4c,
+	0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0xa2, 0x01,
+	0x0a, 0x16, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x32,
+	0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x22, 0x2b, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x22,
+	0x41, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2e, 0x0a, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x64,
+	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x4d,
+	0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x29, 0x0a, 0x07, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x07, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x0c, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x4d, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x4d, 0x69, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x65, 0x66, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4c, 0x65, 0x66, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x20, 0x0a, 0x0b, 0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x48, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x22, 0x45, 0x0a, 0x13, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x9c, 0x02, 0x0a, 0x12, 0x44, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x42, 0x79, 0x74, 0x65, 0x73, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x13, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65,
+	0x72, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x61, 0x73, 0x4d, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x48, 0x61, 0x73, 0x4d, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x4d, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x4d, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x44, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0xd1, 0x02, 0x0a, 0x09, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x4d, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x4d, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x4c, 0x65, 0x66,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4c,
+	0x65, 0x66, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x69, 0x67,
+	0x68, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x52, 0x69, 0x67, 0x68, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4b, 0x65, 0x65, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4b, 0x65, 0x65, 0x70, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x22, 0x73, 0x0a, 0x0b, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x69,
+	0x6e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x6d, 0x69, 0x6e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x26, 0x0a, 0x0e,
+	0x64, 0x65, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x64, 0x65, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x22, 0xbb, 0x01, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64,
+	0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x73, 0x6b, 0x69, 0x70, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa5,
+	0x01, 0x0a, 0x1d, 0x53, 0x65, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a,
+	0x0f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb3, 0x02, 0x0a, 0x11, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64,
+	0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6c,
+	0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x51, 0x0a, 0x12,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x62, 0x2e, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0d, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x91, 0x02, 0x0a, 0x1d, 0x53, 0x65, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6b,
+	0x69, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x6b, 0x69, 0x70, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x1b, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x22, 0x95, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x48, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0x43, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x22, 0x2b, 0x0a, 0x0d, 0x45, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x28, 0x0a, 0x0e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x32,
+	0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x48, 0x65,
+	0x61, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x22, 0x8d, 0x01, 0x0a, 0x0d, 0x48, 0x65, 0x61,
+	0x74, 0x6d, 0x61, 0x70, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4c, 0x65,
+	0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x52,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x52, 0x65, 0x61, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x69, 0x63,
+	0x72, 0x6f, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x4c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x22, 0x6f, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x74,
+	0x6d, 0x61, 0x70, 0x12, 0x2b, 0x0a, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61, 0x74, 0x6d, 0x61,
+	0x70, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x4d, 0x0a, 0x11, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x72, 0x0a, 0x12, 0x43, 0x6f, 0x70, 0x79,
+	0x53, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x44, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x22, 0x38, 0x0a, 0x0a,
+	0x43, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x70, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x43, 0x6f, 0x70, 0x79, 0x22, 0x8a, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x70, 0x79, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x6f, 0x70, 0x69, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x43, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f,
+	0x70, 0x69, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x44,
+	0x6f, 0x6e, 0x65, 0x22, 0x9e, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68,
+	0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x08, 0x43, 0x68,
+	0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x45, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0f, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4f, 0x0a, 0x17, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x08, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x11, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x53,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x22, 0x55, 0x0a, 0x05, 0x41, 0x6c, 0x69,
+	0x61, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65,
+	0x22, 0x2e, 0x0a, 0x07, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x07, 0x41,
+	0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x64,
+	0x62, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x07, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73,
+	0x22, 0x47, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x22, 0x48, 0x0a, 0x14, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x22, 0x65, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x4c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x1a,
+	0x0a, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x22, 0x42, 0x0a, 0x07, 0x4d, 0x61,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x21, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0xd7,
+	0x01, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x64, 0x62, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x57,
+	0x61, 0x6c, 0x6b, 0x53, 0x74, 0x65, 0x70, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x1a, 0x39, 0x0a,
+	0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x12, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x06,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x64,
+	0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0x82, 0x01, 0x0a, 0x13, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x43, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x21, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x22, 0xcd, 0x01, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x41, 0x70, 0x69, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x41, 0x70,
+	0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x69, 0x6e, 0x41,
+	0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x4d, 0x69, 0x6e, 0x41, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x47, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x47, 0x6f, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x22, 0x56, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x46, 0x69,
+	0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x22, 0x3f, 0x0a, 0x11,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x4a, 0x0a,
+	0x08, 0x4b, 0x65, 0x79, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x50, 0x0a, 0x12, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x24, 0x0a, 0x06, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x52, 0x06, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x48, 0x0a, 0x12, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x22, 0x2f, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x25, 0x0a, 0x11, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x4b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x22, 0x94, 0x02,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c,
+	0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x2a, 0x0a, 0x10, 0x44, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x42, 0x75,
+	0x64, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x44, 0x65, 0x73, 0x63,
+	0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x24, 0x0a, 0x0d,
+	0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x43, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x43, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4b, 0x65, 0x79,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x4b, 0x65, 0x79, 0x73, 0x22, 0x55, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x22, 0xad, 0x02, 0x0a, 0x09,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x74, 0x65, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x44, 0x65, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x44, 0x65, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x20, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x37, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x74,
+	0x65, 0x70, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8a, 0x01, 0x0a, 0x05,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x05, 0x53, 0x74, 0x65, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x05, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x26, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4b,
+	0x65, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79,
+	0x22, 0x27, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x05, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0xf5, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x0b, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c,
+	0x46, 0x69, 0x72, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x46, 0x69, 0x72, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65,
+	0x74, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22, 0x37, 0x0a, 0x0c, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x46, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x06, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x22,
+	0x2b, 0x0a, 0x11, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x62, 0x0a, 0x0e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x52, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x22, 0x5b, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0xdd, 0x01,
+	0x0a, 0x0f, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x61, 0x73,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x1a, 0x0a, 0x08, 0x49, 0x6e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x49, 0x6e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x4c,
+	0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x4c, 0x61,
+	0x73, 0x74, 0x52, 0x75, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e, 0x12,
+	0x2e, 0x0a, 0x12, 0x4c, 0x61, 0x73, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x4c, 0x61, 0x73,
+	0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x24, 0x0a,
+	0x0e, 0x52, 0x75, 0x6e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x22, 0x31, 0x0a, 0x17, 0x50, 0x61, 0x75, 0x73, 0x65, 0x4d, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x5a, 0x0a, 0x18, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x67, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x22, 0xef, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x44, 0x69, 0x76, 0x65,
+	0x72, 0x67, 0x65, 0x64, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x64, 0x50, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65,
+	0x74, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x44, 0x65,
+	0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x4f, 0x6c,
+	0x64, 0x65, 0x73, 0x74, 0x44, 0x69, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x4f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x44, 0x69, 0x76, 0x65,
+	0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x67, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x43, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x67, 0x65, 0x64, 0x22, 0x39, 0x0a, 0x0c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22,
+	0x30, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x7e, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x69, 0x6e, 0x67,
+	0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x22, 0x61, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x69, 0x6e, 0x67,
+	0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x22, 0x2e, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x4d, 0x6f, 0x64, 0x65, 0x22, 0x96, 0x11, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x65, 0x6c,
+	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x65, 0x6c, 0x66, 0x12, 0x14, 0x0a,
+	0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x2a, 0x0a, 0x10, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x26, 0x0a, 0x0e, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x14, 0x4d, 0x61, 0x78, 0x43, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x52,
+	0x6f, 0x6f, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x52, 0x6f, 0x6f, 0x74,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x43, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x2e, 0x0a, 0x12, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x42, 0x72, 0x61, 0x69, 0x6e, 0x44,
+	0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x42, 0x72, 0x61, 0x69, 0x6e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x12, 0x2e, 0x0a, 0x12, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x42, 0x72, 0x61, 0x69, 0x6e, 0x52,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x42, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x57, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x57, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x57, 0x61, 0x72, 0x6d, 0x55, 0x70, 0x57,
+	0x61, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x57, 0x61, 0x72,
+	0x6d, 0x55, 0x70, 0x57, 0x61, 0x72, 0x6d, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0d, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x52, 0x0d, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a,
+	0x0c, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x12, 0x2e, 0x0a, 0x12, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x44,
+	0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x65, 0x64, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x50, 0x72, 0x65,
+	0x73, 0x73, 0x75, 0x72, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12,
+	0x47, 0x0a, 0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x18,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x64, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x10, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x12, 0x41, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64,
+	0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x50, 0x65,
+	0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x68, 0x65, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x53,
+	0x68, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x41,
+	0x63, 0x6c, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x41, 0x63, 0x6c, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x41,
+	0x63, 0x6c, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x41, 0x63, 0x6c, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x52, 0x75, 0x6e, 0x73, 0x18, 0x1e, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x52, 0x75, 0x6e,
+	0x73, 0x12, 0x28, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x4e,
+	0x6f, 0x64, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x43, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x21, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x12, 0x35, 0x0a, 0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x22, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x0b, 0x4d, 0x61, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x4d, 0x61, 0x69, 0x6e,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x23, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x11, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x44, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x24, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x44, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x3d,
+	0x0a, 0x0f, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0f, 0x53, 0x70,
+	0x6c, 0x69, 0x74, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a,
+	0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x18, 0x26, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x2b, 0x0a,
+	0x07, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x64, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x07, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x12, 0x44, 0x0a, 0x0a, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73, 0x18, 0x28, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73,
+	0x12, 0x2c, 0x0a, 0x11, 0x48, 0x6f, 0x70, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x45, 0x78, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x29, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x48, 0x6f, 0x70,
+	0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73,
+	0x12, 0x28, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x65,
+	0x64, 0x65, 0x64, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x45, 0x78, 0x70, 0x61, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x45, 0x78,
+	0x70, 0x61, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x2d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x10, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x61,
+	0x69, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18,
+	0x2f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x61, 0x69,
+	0x72, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72,
+	0x73, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x30, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x73, 0x53, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x1a, 0x3e, 0x0a, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x4a, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x22, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4c,
+	0x6f, 0x61, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x6f, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9b, 0x02,
+	0x0a, 0x0d, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x62, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x50, 0x72, 0x69, 0x6d, 0x61,
+	0x72, 0x79, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x67,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x4c, 0x61, 0x67,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x61, 0x67, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4c, 0x61, 0x67, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x22, 0xb3, 0x02, 0x0a, 0x0f,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x47, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x2c, 0x0a, 0x11, 0x4d, 0x69, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x4d, 0x69, 0x6e, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x26, 0x0a,
+	0x0e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d,
+	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4c, 0x61, 0x73, 0x74,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x41, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x65, 0x78, 0x74, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x4e, 0x65,
+	0x78, 0x74, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x41, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x52, 0x65, 0x63,
+	0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x4c, 0x6f, 0x77,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79,
+	0x4c, 0x6f, 0x77, 0x12, 0x1a, 0x0a, 0x08, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x44, 0x65, 0x66, 0x65, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x66, 0x65, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x31, 0x0a, 0x19, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x9a, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x50, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x4d, 0x65, 0x72, 0x67, 0x65,
+	0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a,
+	0x0c, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x22, 0x5f, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67,
+	0x4b, 0x65, 0x79, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x22, 0x30, 0x0a, 0x18, 0x54, 0x6f, 0x70, 0x53, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x53, 0x0a, 0x0d, 0x53, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x0b, 0x53, 0x70, 0x61,
+	0x63, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x12, 0x14, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a,
+	0x0e, 0x53, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x73, 0x12,
+	0x29, 0x0a, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
+	0x65, 0x72, 0x52, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64,
+	0x62, 0x2e, 0x53, 0x70, 0x61, 0x63, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x08, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x0f, 0x48, 0x6f, 0x74, 0x50, 0x61,
+	0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0x98, 0x01, 0x0a, 0x06, 0x48, 0x6f, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x4b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x52, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x52, 0x65,
+	0x61, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x57, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x41,
+	0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0c, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x4e,
+	0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x55, 0x0a, 0x09, 0x48,
+	0x6f, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x52, 0x65, 0x61, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x22, 0xce, 0x01, 0x0a, 0x10, 0x48, 0x6f, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x64, 0x62, 0x2e, 0x48, 0x6f, 0x74, 0x4b, 0x65,
+	0x79, 0x52, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x29, 0x0a, 0x08, 0x42, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x48,
+	0x6f, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x08, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x48, 0x6f, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x12, 0x28, 0x0a, 0x0f, 0x48, 0x61, 0x6c,
+	0x66, 0x4c, 0x69, 0x66, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x48, 0x61, 0x6c, 0x66, 0x4c, 0x69, 0x66, 0x65, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x22, 0x32, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x31, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0xb4, 0x01, 0x0a, 0x12, 0x52,
+	0x65, 0x64, 0x61, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65,
+	0x64, 0x22, 0x65, 0x0a, 0x13, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x39, 0x0a, 0x03, 0x41, 0x43, 0x4c, 0x12,
+	0x12, 0x0a, 0x04, 0x4a, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4a,
+	0x73, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x22, 0x98, 0x01, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x4c, 0x6f, 0x61, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x49, 0x6e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x49, 0x6e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x50, 0x39, 0x39, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x50, 0x39, 0x39, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75,
+	0x72, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x4c,
+	0x0a, 0x14, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x24, 0x0a, 0x0e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x4a, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4a, 0x73,
+	0x6f, 0x6e, 0x22, 0x79, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x65,
+	0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x46,
+	0x72, 0x65, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x8c, 0x02,
+	0x0a, 0x0c, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x50, 0x68, 0x61, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x10,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x72, 0x65, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x28, 0x0a, 0x0f,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x52, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x52, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x61, 0x63, 0x6b, 0x22, 0x45, 0x0a, 0x0d,
+	0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x44, 0x65, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x65, 0x72, 0x22, 0x4f, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x22, 0x6f, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4d, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x16, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x4d, 0x69, 0x73, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x4d, 0x69, 0x73, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x09, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x41, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4c,
+	0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x52, 0x69, 0x67, 0x68, 0x74, 0x22, 0x65, 0x0a, 0x0b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x4c, 0x65, 0x66, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x63, 0x0a, 0x0f,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x43, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x73, 0x22, 0x46, 0x0a, 0x10, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x41, 0x4c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x22, 0x71, 0x0a, 0x09, 0x57, 0x41, 0x4c,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x4e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x4e, 0x65,
+	0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x64,
+	0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x61, 0x0a, 0x08,
+	0x57, 0x41, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x27, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x57,
+	0x41, 0x4c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x36, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0xc6, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50,
+	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x72,
+	0x69, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x06, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x50, 0x72, 0x69, 0x6d,
+	0x61, 0x72, 0x79, 0x44, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x50, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x44, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x67, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x4c, 0x61, 0x67, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x4c, 0x61, 0x67, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x4c, 0x61, 0x67, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68,
+	0x22, 0xd6, 0x02, 0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12,
+	0x18, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x38, 0x0a, 0x08, 0x43,
+	0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x64, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x2e, 0x43, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x43, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x1a,
+	0x3b, 0x0a, 0x0d, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3b, 0x0a, 0x0d,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4d, 0x0a, 0x15, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x48, 0x0a, 0x16, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x22, 0x50, 0x0a, 0x14, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x6f, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x61,
+	0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x52, 0x6f, 0x6c, 0x6c, 0x65, 0x64,
+	0x42, 0x61, 0x63, 0x6b, 0x2a, 0x57, 0x0a, 0x0b, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x4e, 0x4f, 0x4e,
+	0x45, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x43, 0x4f, 0x4d,
+	0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x45, 0x52, 0x47,
+	0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
+	0x45, 0x52, 0x47, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x55, 0x0a,
+	0x0a, 0x44, 0x75, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x12, 0x44,
+	0x55, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x43, 0x4b, 0x5f, 0x4d, 0x45, 0x4d, 0x4f, 0x52,
+	0x59, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x43, 0x4b, 0x5f, 0x57, 0x41, 0x4c, 0x10, 0x02,
+	0x12, 0x12, 0x0a, 0x0e, 0x41, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54,
+	0x45, 0x44, 0x10, 0x03, 0x2a, 0x24, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x47, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10, 0x01, 0x2a, 0x3b, 0x0a, 0x04, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x45, 0x41, 0x44, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x44, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54,
+	0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x03, 0x32, 0xc3, 0x23, 0x0a, 0x09, 0x44, 0x62, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4c, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65,
+	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4c, 0x0a,
+	0x0f, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e,
+	0x12, 0x1a, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64,
+	0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0a, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x2e, 0x64, 0x62, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x40, 0x0a,
+	0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x64,
+	0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x2b, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x13, 0x2e, 0x64, 0x62,
+	0x2e, 0x41, 0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0b,
+	0x55, 0x6e, 0x6c, 0x69, 0x6e, 0x6b, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x13, 0x2e, 0x64, 0x62,
+	0x2e, 0x41, 0x64, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0a,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x2e, 0x64, 0x62, 0x2e,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x18, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x08, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x22,
+	0x00, 0x12, 0x2c, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x13, 0x2e,
+	0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x00, 0x12,
+	0x31, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x64, 0x62, 0x2e, 0x45,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x64,
+	0x62, 0x2e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6c, 0x69,
+	0x61, 0x73, 0x12, 0x17, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x41,
+	0x6c, 0x69, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x64, 0x62,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x41, 0x6c,
+	0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x0b, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x65, 0x73, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x32, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16,
+	0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f,
+	0x74, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x64,
+	0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x0e, 0x2e,
+	0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x30, 0x0a, 0x0a, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x15,
+	0x2e, 0x64, 0x62, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x00, 0x12, 0x28, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x53,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x53,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x64, 0x62, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x05, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x10,
+	0x2e, 0x64, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x53, 0x65,
+	0x74, 0x12, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4f,
+	0x72, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b,
+	0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x62,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x19, 0x2e,
+	0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x22, 0x00, 0x12, 0x22, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x73, 0x12, 0x09,
+	0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08,
+	0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x64,
+	0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x64, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x65,
+	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x26, 0x0a, 0x05, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x10, 0x2e, 0x64, 0x62, 0x2e, 0x53,
+	0x70, 0x6c, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x44, 0x65, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x64, 0x62, 0x2e, 0x44, 0x65,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x16, 0x2e, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0c, 0x54, 0x72, 0x69,
+	0x67, 0x67, 0x65, 0x72, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x17, 0x2e, 0x64, 0x62, 0x2e, 0x54,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x50, 0x6c, 0x61,
+	0x6e, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x48, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x64, 0x62,
+	0x2e, 0x53, 0x65, 0x74, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09,
+	0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x16, 0x53,
+	0x65, 0x74, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e,
+	0x64, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x46,
+	0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x46, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x64, 0x62, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0a, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x15, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09,
+	0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x11, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1c, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x09, 0x2e,
+	0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x00, 0x12,
+	0x36, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x20, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x07,
+	0x53, 0x65, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13,
+	0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x75, 0x6e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x3c, 0x0a, 0x10, 0x50, 0x61, 0x75, 0x73, 0x65, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65,
+	0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x35, 0x0a, 0x0c, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x64, 0x62, 0x2e,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12,
+	0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x64, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12,
+	0x13, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x22, 0x00, 0x12, 0x2f, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x0e, 0x2e, 0x64, 0x62, 0x2e,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x2a, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0e, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36,
+	0x0a, 0x0d, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x18, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70,
+	0x70, 0x69, 0x6e, 0x67, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x0b, 0x2e, 0x64, 0x62, 0x2e, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x22, 0x00, 0x12, 0x3d,
+	0x0a, 0x0a, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x15, 0x2e, 0x64,
+	0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x62, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x4b,
+	0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c,
+	0x65, 0x73, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e,
+	0x64, 0x62, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x1a, 0x09, 0x2e, 0x64,
+	0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0f, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x09, 0x2e, 0x64,
+	0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x64, 0x62, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x64, 0x12, 0x14, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x34, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x16, 0x2e, 0x64, 0x62, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0b, 0x43, 0x6f, 0x70, 0x79,
+	0x53, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x12, 0x16, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x70,
+	0x79, 0x53, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x74,
+	0x6d, 0x61, 0x70, 0x12, 0x15, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x74,
+	0x6d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x64, 0x62, 0x2e,
+	0x48, 0x65, 0x61, 0x74, 0x6d, 0x61, 0x70, 0x22, 0x00, 0x12, 0x26, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x53, 0x6c, 0x6f, 0x77, 0x4c, 0x6f, 0x67, 0x12, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x0b, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x6c, 0x6f, 0x77, 0x4c, 0x6f, 0x67, 0x22,
+	0x00, 0x12, 0x32, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x12,
+	0x15, 0x2e, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x64, 0x62, 0x2e, 0x4a, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x12, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1d, 0x2e, 0x64, 0x62,
+	0x2e, 0x54, 0x6f, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4b,
+	0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x62, 0x2e,
+	0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x73, 0x22,
+	0x00, 0x12, 0x47, 0x0a, 0x11, 0x54, 0x6f, 0x70, 0x53, 0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x2e, 0x64, 0x62, 0x2e, 0x54, 0x6f, 0x70, 0x53,
+	0x70, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x70, 0x61, 0x63, 0x65, 0x43,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x72, 0x73, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08, 0x48, 0x6f,
+	0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x48, 0x6f, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x64, 0x62,
+	0x2e, 0x48, 0x6f, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x1e, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x41, 0x43, 0x4c, 0x12, 0x09, 0x2e,
+	0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x07, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x43,
+	0x4c, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x12, 0x18, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0b, 0x52,
+	0x65, 0x64, 0x61, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x2e, 0x64, 0x62, 0x2e,
+	0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x64, 0x62, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x1e, 0x0a,
+	0x06, 0x53, 0x65, 0x74, 0x41, 0x43, 0x4c, 0x12, 0x07, 0x2e, 0x64, 0x62, 0x2e, 0x41, 0x43, 0x4c,
+	0x1a, 0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a,
+	0x0e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x09, 0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x64, 0x62, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0f, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x09, 0x2e, 0x64, 0x62,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x64, 0x62, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00,
+	0x12, 0x2e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x09,
+	0x2e, 0x64, 0x62, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x40, 0x0a, 0x0b, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12,
+	0x16, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x61,
+	0x69, 0x6d, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x33, 0x0a, 0x09, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x41, 0x4c, 0x12,
+	0x14, 0x2e, 0x64, 0x62, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x57, 0x41, 0x4c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x62, 0x2e, 0x57, 0x41, 0x4c, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x22, 0x00, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x12, 0x12, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_db_proto_rawDescOnce sync.Once
+	file_db_proto_rawDescData = file_db_proto_rawDesc
+)
+
+func file_db_proto_rawDescGZIP() []byte {
+	file_db_proto_rawDescOnce.Do(func() {
+		file_db_proto_rawDescData = protoimpl.X.CompressGZIP(file_db_proto_rawDescData)
+	})
+	return file_db_proto_rawDescData
+}
+
+var file_db_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_db_proto_msgTypes = make([]protoimpl.MessageInfo, 157)
+var file_db_proto_goTypes = []interface{}{
+	(MergeStatus)(0),                      // 0: db.MergeStatus
+	(Durability)(0),                       // 1: db.Durability
+	(NodeType)(0),                         // 2: db.NodeType
+	(Mode)(0),                             // 3: db.Mode
+	(*GetRequest)(nil),                    // 4: db.GetRequest
+	(*WalkStep)(nil),                      // 5: db.WalkStep
+	(*GetResponse)(nil),                   // 6: db.GetResponse
+	(*Event)(nil),                         // 7: db.Event
+	(*WatchEventsRequest)(nil),            // 8: db.WatchEventsRequest
+	(*SlowRequest)(nil),                   // 9: db.SlowRequest
+	(*SlowLog)(nil),                       // 10: db.SlowLog
+	(*JournalEntry)(nil),                  // 11: db.JournalEntry
+	(*GetJournalRequest)(nil),             // 12: db.GetJournalRequest
+	(*Journal)(nil),                       // 13: db.Journal
+	(*GetMergedRequest)(nil),              // 14: db.GetMergedRequest
+	(*HeadValue)(nil),                     // 15: db.HeadValue
+	(*GetMergedResponse)(nil),             // 16: db.GetMergedResponse
+	(*SetRequest)(nil),                    // 17: db.SetRequest
+	(*SetResponse)(nil),                   // 18: db.SetResponse
+	(*ResolvedKey)(nil),                   // 19: db.ResolvedKey
+	(*BatchSetRequest)(nil),               // 20: db.BatchSetRequest
+	(*BatchSetResponse)(nil),              // 21: db.BatchSetResponse
+	(*BatchSetResult)(nil),                // 22: db.BatchSetResult
+	(*EntryStatus)(nil),                   // 23: db.EntryStatus
+	(*Snapshot)(nil),                      // 24: db.Snapshot
+	(*Snapshots)(nil),                     // 25: db.Snapshots
+	(*Heads)(nil),                         // 26: db.Heads
+	(*CreateSnapshotRequest)(nil),         // 27: db.CreateSnapshotRequest
+	(*DeleteSnapshotRequest)(nil),         // 28: db.DeleteSnapshotRequest
+	(*ApplyRequest)(nil),                  // 29: db.ApplyRequest
+	(*ApplyResponse)(nil),                 // 30: db.ApplyResponse
+	(*GetOrSetRequest)(nil),               // 31: db.GetOrSetRequest
+	(*GetOrSetResponse)(nil),              // 32: db.GetOrSetResponse
+	(*Node)(nil),                          // 33: db.Node
+	(*NodeStats)(nil),                     // 34: db.NodeStats
+	(*AddNodeRequest)(nil),                // 35: db.AddNodeRequest
+	(*PrepareReceiveRequest)(nil),         // 36: db.PrepareReceiveRequest
+	(*PrepareReceiveResponse)(nil),        // 37: db.PrepareReceiveResponse
+	(*AddNodeResponse)(nil),               // 38: db.AddNodeResponse
+	(*AddNodesRequest)(nil),               // 39: db.AddNodesRequest
+	(*AddNodesResponse)(nil),              // 40: db.AddNodesResponse
+	(*SplitRequest)(nil),                  // 41: db.SplitRequest
+	(*DecommissionRequest)(nil),           // 42: db.DecommissionRequest
+	(*DecommissionStatus)(nil),            // 43: db.DecommissionStatus
+	(*TriggerSplitRequest)(nil),           // 44: db.TriggerSplitRequest
+	(*SplitPlan)(nil),                     // 45: db.SplitPlan
+	(*MergePolicy)(nil),                   // 46: db.MergePolicy
+	(*SetMergeFunctionRequest)(nil),       // 47: db.SetMergeFunctionRequest
+	(*SetGlobalMergeFunctionRequest)(nil), // 48: db.SetGlobalMergeFunctionRequest
+	(*MergeRegistration)(nil),             // 49: db.MergeRegistration
+	(*MergeRegistrations)(nil),            // 50: db.MergeRegistrations
+	(*SetScopedMergeFunctionRequest)(nil), // 51: db.SetScopedMergeFunctionRequest
+	(*ResolveMergeFunctionRequest)(nil),   // 52: db.ResolveMergeFunctionRequest
+	(*MergeResolution)(nil),               // 53: db.MergeResolution
+	(*GetMergeRegistrationsRequest)(nil),  // 54: db.GetMergeRegistrationsRequest
+	(*AddChildRequest)(nil),               // 55: db.AddChildRequest
+	(*ExistsRequest)(nil),                 // 56: db.ExistsRequest
+	(*ExistsResponse)(nil),                // 57: db.ExistsResponse
+	(*ResolveBucketRequest)(nil),          // 58: db.ResolveBucketRequest
+	(*ResolveBucketResponse)(nil),         // 59: db.ResolveBucketResponse
+	(*GetHeatmapRequest)(nil),             // 60: db.GetHeatmapRequest
+	(*HeatmapBucket)(nil),                 // 61: db.HeatmapBucket
+	(*Heatmap)(nil),                       // 62: db.Heatmap
+	(*ExpireNodeRequest)(nil),             // 63: db.ExpireNodeRequest
+	(*CopySubtreeRequest)(nil),            // 64: db.CopySubtreeRequest
+	(*CopiedNode)(nil),                    // 65: db.CopiedNode
+	(*CopyProgress)(nil),                  // 66: db.CopyProgress
+	(*ReplaceChildrenRequest)(nil),        // 67: db.ReplaceChildrenRequest
+	(*ReplaceChildrenResponse)(nil),       // 68: db.ReplaceChildrenResponse
+	(*RemoveChildrenRequest)(nil),         // 69: db.RemoveChildrenRequest
+	(*RemoveNodeRequest)(nil),             // 70: db.RemoveNodeRequest
+	(*Alias)(nil),                         // 71: db.Alias
+	(*Aliases)(nil),                       // 72: db.Aliases
+	(*ResolveAliasRequest)(nil),           // 73: db.ResolveAliasRequest
+	(*ResolveAliasResponse)(nil),          // 74: db.ResolveAliasResponse
+	(*Range)(nil),                         // 75: db.Range
+	(*Mapping)(nil),                       // 76: db.Mapping
+	(*ErrorDetail)(nil),                   // 77: db.ErrorDetail
+	(*ClaimRangesRequest)(nil),            // 78: db.ClaimRangesRequest
+	(*ClaimRangesResponse)(nil),           // 79: db.ClaimRangesResponse
+	(*VersionResponse)(nil),               // 80: db.VersionResponse
+	(*ServiceDescription)(nil),            // 81: db.ServiceDescription
+	(*LocateKeysRequest)(nil),             // 82: db.LocateKeysRequest
+	(*KeyOwner)(nil),                      // 83: db.KeyOwner
+	(*LocateKeysResponse)(nil),            // 84: db.LocateKeysResponse
+	(*RemoveNodesRequest)(nil),            // 85: db.RemoveNodesRequest
+	(*RemoveNodesResponse)(nil),           // 86: db.RemoveNodesResponse
+	(*InvalidateRequest)(nil),             // 87: db.InvalidateRequest
+	(*GetNodeRequest)(nil),                // 88: db.GetNodeRequest
+	(*GetProofRequest)(nil),               // 89: db.GetProofRequest
+	(*ProofStep)(nil),                     // 90: db.ProofStep
+	(*Proof)(nil),                         // 91: db.Proof
+	(*GetKeyNodesRequest)(nil),            // 92: db.GetKeyNodesRequest
+	(*Nodes)(nil),                         // 93: db.Nodes
+	(*Empty)(nil),                         // 94: db.Empty
+	(*MergeRecord)(nil),                   // 95: db.MergeRecord
+	(*FailedMerge)(nil),                   // 96: db.FailedMerge
+	(*FailedMerges)(nil),                  // 97: db.FailedMerges
+	(*RetryMergeRequest)(nil),             // 98: db.RetryMergeRequest
+	(*ValidationRule)(nil),                // 99: db.ValidationRule
+	(*ValidationRules)(nil),               // 100: db.ValidationRules
+	(*MaintenanceTask)(nil),               // 101: db.MaintenanceTask
+	(*RunTaskRequest)(nil),                // 102: db.RunTaskRequest
+	(*PauseMaintenanceRequest)(nil),       // 103: db.PauseMaintenanceRequest
+	(*ConvergenceStatusRequest)(nil),      // 104: db.ConvergenceStatusRequest
+	(*ConvergenceReport)(nil),             // 105: db.ConvergenceReport
+	(*MergeHistory)(nil),                  // 106: db.MergeHistory
+	(*CreateRootResponse)(nil),            // 107: db.CreateRootResponse
+	(*SetIndexingLockRequest)(nil),        // 108: db.SetIndexingLockRequest
+	(*SetIndexingLockResponse)(nil),       // 109: db.SetIndexingLockResponse
+	(*SetModeRequest)(nil),                // 110: db.SetModeRequest
+	(*GetStatsResponse)(nil),              // 111: db.GetStatsResponse
+	(*StandbyStatus)(nil),                 // 112: db.StandbyStatus
+	(*SplitGovernance)(nil),               // 113: db.SplitGovernance
+	(*TopConflictingKeysRequest)(nil),     // 114: db.TopConflictingKeysRequest
+	(*ConflictingKey)(nil),                // 115: db.ConflictingKey
+	(*ConflictingKeys)(nil),               // 116: db.ConflictingKeys
+	(*TopSpaceConsumersRequest)(nil),      // 117: db.TopSpaceConsumersRequest
+	(*SpaceConsumer)(nil),                 // 118: db.SpaceConsumer
+	(*SpacePrefix)(nil),                   // 119: db.SpacePrefix
+	(*SpaceConsumers)(nil),                // 120: db.SpaceConsumers
+	(*HotPathsRequest)(nil),               // 121: db.HotPathsRequest
+	(*HotKey)(nil),                        // 122: db.HotKey
+	(*HotBranch)(nil),                     // 123: db.HotBranch
+	(*HotPathsResponse)(nil),              // 124: db.HotPathsResponse
+	(*CompressChainRequest)(nil),          // 125: db.CompressChainRequest
+	(*CompressChainResponse)(nil),         // 126: db.CompressChainResponse
+	(*RedactValueRequest)(nil),            // 127: db.RedactValueRequest
+	(*RedactValueResponse)(nil),           // 128: db.RedactValueResponse
+	(*ACL)(nil),                           // 129: db.ACL
+	(*PeerLoad)(nil),                      // 130: db.PeerLoad
+	(*ReloadConfigResponse)(nil),          // 131: db.ReloadConfigResponse
+	(*ConfigResponse)(nil),                // 132: db.ConfigResponse
+	(*CompactResponse)(nil),               // 133: db.CompactResponse
+	(*SplitAttempt)(nil),                  // 134: db.SplitAttempt
+	(*PlacementRule)(nil),                 // 135: db.PlacementRule
+	(*PlacementRules)(nil),                // 136: db.PlacementRules
+	(*VerifyPlacementResponse)(nil),       // 137: db.VerifyPlacementResponse
+	(*CheckIntegrityResponse)(nil),        // 138: db.CheckIntegrityResponse
+	(*GetRangeDigestRequest)(nil),         // 139: db.GetRangeDigestRequest
+	(*RangeDigest)(nil),                   // 140: db.RangeDigest
+	(*RegisterRequest)(nil),               // 141: db.RegisterRequest
+	(*FollowWALRequest)(nil),              // 142: db.FollowWALRequest
+	(*WALRecord)(nil),                     // 143: db.WALRecord
+	(*WALBatch)(nil),                      // 144: db.WALBatch
+	(*PromoteRequest)(nil),                // 145: db.PromoteRequest
+	(*PromoteResponse)(nil),               // 146: db.PromoteResponse
+	(*Membership)(nil),                    // 147: db.Membership
+	(*AllocateServerRequest)(nil),         // 148: db.AllocateServerRequest
+	(*AllocateServerResponse)(nil),        // 149: db.AllocateServerResponse
+	(*ReleaseServerRequest)(nil),          // 150: db.ReleaseServerRequest
+	nil,                                   // 151: db.Event.AttributesEntry
+	nil,                                   // 152: db.SlowRequest.PhaseMicrosEntry
+	nil,                                   // 153: db.Node.MetadataEntry
+	nil,                                   // 154: db.ErrorDetail.FieldsEntry
+	nil,                                   // 155: db.ProofStep.MetadataEntry
+	nil,                                   // 156: db.GetStatsResponse.EventCountsEntry
+	nil,                                   // 157: db.GetStatsResponse.PeerLoadsEntry
+	nil,                                   // 158: db.GetStatsResponse.RemoteHopsEntry
+	nil,                                   // 159: db.Membership.CapacityEntry
+	nil,                                   // 160: db.Membership.ReservedEntry
+}
+var file_db_proto_depIdxs = []int32{
+	5,   // 0: db.GetRequest.Path:type_name -> db.WalkStep
+	2,   // 1: db.GetResponse.Type:type_name -> db.NodeType
+	151, // 2: db.Event.Attributes:type_name -> db.Event.AttributesEntry
+	152, // 3: db.SlowRequest.PhaseMicros:type_name -> db.SlowRequest.PhaseMicrosEntry
+	9,   // 4: db.SlowLog.Requests:type_name -> db.SlowRequest
+	11,  // 5: db.Journal.Entries:type_name -> db.JournalEntry
+	15,  // 6: db.GetMergedResponse.Heads:type_name -> db.HeadValue
+	1,   // 7: db.SetRequest.Durability:type_name -> db.Durability
+	2,   // 8: db.SetRequest.Type:type_name -> db.NodeType
+	0,   // 9: db.SetResponse.MergeStatus:type_name -> db.MergeStatus
+	1,   // 10: db.SetResponse.Durability:type_name -> db.Durability
+	19,  // 11: db.SetResponse.Resolved:type_name -> db.ResolvedKey
+	23,  // 12: db.ResolvedKey.Status:type_name -> db.EntryStatus
+	17,  // 13: db.BatchSetRequest.Requests:type_name -> db.SetRequest
+	18,  // 14: db.BatchSetResponse.Responses:type_name -> db.SetResponse
+	22,  // 15: db.BatchSetResponse.Results:type_name -> db.BatchSetResult
+	23,  // 16: db.BatchSetResult.Status:type_name -> db.EntryStatus
+	0,   // 17: db.BatchSetResult.MergeStatus:type_name -> db.MergeStatus
+	24,  // 18: db.Snapshots.Snapshots:type_name -> db.Snapshot
+	24,  // 19: db.CreateSnapshotRequest.Snapshot:type_name -> db.Snapshot
+	34,  // 20: db.Node.Stats:type_name -> db.NodeStats
+	153, // 21: db.Node.Metadata:type_name -> db.Node.MetadataEntry
+	2,   // 22: db.Node.Type:type_name -> db.NodeType
+	19,  // 23: db.Node.Resolved:type_name -> db.ResolvedKey
+	33,  // 24: db.AddNodeRequest.Node:type_name -> db.Node
+	35,  // 25: db.AddNodesRequest.Requests:type_name -> db.AddNodeRequest
+	38,  // 26: db.AddNodesResponse.Results:type_name -> db.AddNodeResponse
+	23,  // 27: db.AddNodesResponse.Failure:type_name -> db.EntryStatus
+	46,  // 28: db.SetMergeFunctionRequest.policy:type_name -> db.MergePolicy
+	46,  // 29: db.SetGlobalMergeFunctionRequest.policy:type_name -> db.MergePolicy
+	46,  // 30: db.MergeRegistration.policy:type_name -> db.MergePolicy
+	49,  // 31: db.MergeRegistrations.registrations:type_name -> db.MergeRegistration
+	46,  // 32: db.SetScopedMergeFunctionRequest.policy:type_name -> db.MergePolicy
+	46,  // 33: db.MergeResolution.policy:type_name -> db.MergePolicy
+	49,  // 34: db.MergeResolution.matches:type_name -> db.MergeRegistration
+	61,  // 35: db.Heatmap.Buckets:type_name -> db.HeatmapBucket
+	75,  // 36: db.Heatmap.Ranges:type_name -> db.Range
+	65,  // 37: db.CopyProgress.Nodes:type_name -> db.CopiedNode
+	71,  // 38: db.Aliases.Aliases:type_name -> db.Alias
+	75,  // 39: db.Mapping.Ranges:type_name -> db.Range
+	154, // 40: db.ErrorDetail.Fields:type_name -> db.ErrorDetail.FieldsEntry
+	33,  // 41: db.ErrorDetail.Nodes:type_name -> db.Node
+	5,   // 42: db.ErrorDetail.Path:type_name -> db.WalkStep
+	75,  // 43: db.ClaimRangesRequest.Ranges:type_name -> db.Range
+	75,  // 44: db.ClaimRangesResponse.Ranges:type_name -> db.Range
+	83,  // 45: db.LocateKeysResponse.Owners:type_name -> db.KeyOwner
+	2,   // 46: db.ProofStep.Type:type_name -> db.NodeType
+	155, // 47: db.ProofStep.Metadata:type_name -> db.ProofStep.MetadataEntry
+	90,  // 48: db.Proof.Steps:type_name -> db.ProofStep
+	33,  // 49: db.Nodes.Nodes:type_name -> db.Node
+	96,  // 50: db.FailedMerges.Merges:type_name -> db.FailedMerge
+	99,  // 51: db.ValidationRules.Rules:type_name -> db.ValidationRule
+	95,  // 52: db.MergeHistory.Records:type_name -> db.MergeRecord
+	3,   // 53: db.SetModeRequest.Mode:type_name -> db.Mode
+	3,   // 54: db.GetStatsResponse.Mode:type_name -> db.Mode
+	134, // 55: db.GetStatsResponse.SplitAttempts:type_name -> db.SplitAttempt
+	156, // 56: db.GetStatsResponse.EventCounts:type_name -> db.GetStatsResponse.EventCountsEntry
+	157, // 57: db.GetStatsResponse.PeerLoads:type_name -> db.GetStatsResponse.PeerLoadsEntry
+	101, // 58: db.GetStatsResponse.Maintenance:type_name -> db.MaintenanceTask
+	113, // 59: db.GetStatsResponse.SplitGovernance:type_name -> db.SplitGovernance
+	112, // 60: db.GetStatsResponse.Standby:type_name -> db.StandbyStatus
+	158, // 61: db.GetStatsResponse.RemoteHops:type_name -> db.GetStatsResponse.RemoteHopsEntry
+	115, // 62: db.ConflictingKeys.Keys:type_name -> db.ConflictingKey
+	118, // 63: db.SpaceConsumers.Values:type_name -> db.SpaceConsumer
+	119, // 64: db.SpaceConsumers.Prefixes:type_name -> db.SpacePrefix
+	122, // 65: db.HotPathsResponse.Keys:type_name -> db.HotKey
+	123, // 66: db.HotPathsResponse.Branches:type_name -> db.HotBranch
+	123, // 67: db.HotPathsResponse.Nodes:type_name -> db.HotBranch
+	135, // 68: db.PlacementRules.Rules:type_name -> db.PlacementRule
+	33,  // 69: db.WALRecord.Node:type_name -> db.Node
+	143, // 70: db.WALBatch.Records:type_name -> db.WALRecord
+	75,  // 71: db.PromoteResponse.Ranges:type_name -> db.Range
+	159, // 72: db.Membership.Capacity:type_name -> db.Membership.CapacityEntry
+	160, // 73: db.Membership.Reserved:type_name -> db.Membership.ReservedEntry
+	130, // 74: db.GetStatsResponse.PeerLoadsEntry.value:type_name -> db.PeerLoad
+	108, // 75: db.DbService.SetIndexingLock:input_type -> db.SetIndexingLockRequest
+	69,  // 76: db.DbService.RemoveChildren:input_type -> db.RemoveChildrenRequest
+	67,  // 77: db.DbService.ReplaceChildren:input_type -> db.ReplaceChildrenRequest
+	70,  // 78: db.DbService.RemoveNode:input_type -> db.RemoveNodeRequest
+	85,  // 79: db.DbService.RemoveNodes:input_type -> db.RemoveNodesRequest
+	55,  // 80: db.DbService.AddChild:input_type -> db.AddChildRequest
+	55,  // 81: db.DbService.UnlinkChild:input_type -> db.AddChildRequest
+	63,  // 82: db.DbService.ExpireNode:input_type -> db.ExpireNodeRequest
+	58,  // 83: db.DbService.ResolveBucket:input_type -> db.ResolveBucketRequest
+	88,  // 84: db.DbService.GetNode:input_type -> db.GetNodeRequest
+	89,  // 85: db.DbService.GetProof:input_type -> db.GetProofRequest
+	56,  // 86: db.DbService.Exists:input_type -> db.ExistsRequest
+	73,  // 87: db.DbService.ResolveAlias:input_type -> db.ResolveAliasRequest
+	72,  // 88: db.DbService.AddAliases:input_type -> db.Aliases
+	92,  // 89: db.DbService.GetKeyNodes:input_type -> db.GetKeyNodesRequest
+	94,  // 90: db.DbService.CreateRoot:input_type -> db.Empty
+	4,   // 91: db.DbService.Get:input_type -> db.GetRequest
+	87,  // 92: db.DbService.Invalidate:input_type -> db.InvalidateRequest
+	17,  // 93: db.DbService.Set:input_type -> db.SetRequest
+	20,  // 94: db.DbService.BatchSet:input_type -> db.BatchSetRequest
+	29,  // 95: db.DbService.Apply:input_type -> db.ApplyRequest
+	31,  // 96: db.DbService.GetOrSet:input_type -> db.GetOrSetRequest
+	27,  // 97: db.DbService.CreateSnapshot:input_type -> db.CreateSnapshotRequest
+	28,  // 98: db.DbService.DeleteSnapshot:input_type -> db.DeleteSnapshotRequest
+	94,  // 99: db.DbService.ListSnapshots:input_type -> db.Empty
+	94,  // 100: db.DbService.GetHeads:input_type -> db.Empty
+	35,  // 101: db.DbService.AddNode:input_type -> db.AddNodeRequest
+	39,  // 102: db.DbService.AddNodes:input_type -> db.AddNodesRequest
+	36,  // 103: db.DbService.PrepareReceive:input_type -> db.PrepareReceiveRequest
+	41,  // 104: db.DbService.Split:input_type -> db.SplitRequest
+	42,  // 105: db.DbService.Decommission:input_type -> db.DecommissionRequest
+	94,  // 106: db.DbService.GetDecommissionStatus:input_type -> db.Empty
+	44,  // 107: db.DbService.TriggerSplit:input_type -> db.TriggerSplitRequest
+	47,  // 108: db.DbService.SetMergeFunction:input_type -> db.SetMergeFunctionRequest
+	48,  // 109: db.DbService.SetGlobalMergeFunction:input_type -> db.SetGlobalMergeFunctionRequest
+	51,  // 110: db.DbService.SetScopedMergeFunction:input_type -> db.SetScopedMergeFunctionRequest
+	52,  // 111: db.DbService.ResolveMergeFunction:input_type -> db.ResolveMergeFunctionRequest
+	94,  // 112: db.DbService.GetMergeHistory:input_type -> db.Empty
+	94,  // 113: db.DbService.GetFailedMerges:input_type -> db.Empty
+	98,  // 114: db.DbService.RetryMerge:input_type -> db.RetryMergeRequest
+	104, // 115: db.DbService.ConvergenceStatus:input_type -> db.ConvergenceStatusRequest
+	94,  // 116: db.DbService.GetValidationRules:input_type -> db.Empty
+	100, // 117: db.DbService.SetValidationRules:input_type -> db.ValidationRules
+	54,  // 118: db.DbService.GetMergeRegistrations:input_type -> db.GetMergeRegistrationsRequest
+	110, // 119: db.DbService.SetMode:input_type -> db.SetModeRequest
+	94,  // 120: db.DbService.Compact:input_type -> db.Empty
+	102, // 121: db.DbService.RunTask:input_type -> db.RunTaskRequest
+	103, // 122: db.DbService.PauseMaintenance:input_type -> db.PauseMaintenanceRequest
+	94,  // 123: db.DbService.ReloadConfig:input_type -> db.Empty
+	94,  // 124: db.DbService.GetConfig:input_type -> db.Empty
+	94,  // 125: db.DbService.GetStats:input_type -> db.Empty
+	141, // 126: db.DbService.Register:input_type -> db.RegisterRequest
+	147, // 127: db.DbService.UpdateMembership:input_type -> db.Membership
+	94,  // 128: db.DbService.ListServers:input_type -> db.Empty
+	148, // 129: db.DbService.AllocateServer:input_type -> db.AllocateServerRequest
+	150, // 130: db.DbService.ReleaseServer:input_type -> db.ReleaseServerRequest
+	94,  // 131: db.DbService.GetMapping:input_type -> db.Empty
+	82,  // 132: db.DbService.LocateKeys:input_type -> db.LocateKeysRequest
+	94,  // 133: db.DbService.GetPlacementRules:input_type -> db.Empty
+	136, // 134: db.DbService.SetPlacementRules:input_type -> db.PlacementRules
+	94,  // 135: db.DbService.VerifyPlacement:input_type -> db.Empty
+	139, // 136: db.DbService.GetRangeDigest:input_type -> db.GetRangeDigestRequest
+	14,  // 137: db.DbService.GetMerged:input_type -> db.GetMergedRequest
+	8,   // 138: db.DbService.WatchEvents:input_type -> db.WatchEventsRequest
+	64,  // 139: db.DbService.CopySubtree:input_type -> db.CopySubtreeRequest
+	60,  // 140: db.DbService.GetHeatmap:input_type -> db.GetHeatmapRequest
+	94,  // 141: db.DbService.GetSlowLog:input_type -> db.Empty
+	12,  // 142: db.DbService.GetJournal:input_type -> db.GetJournalRequest
+	114, // 143: db.DbService.TopConflictingKeys:input_type -> db.TopConflictingKeysRequest
+	117, // 144: db.DbService.TopSpaceConsumers:input_type -> db.TopSpaceConsumersRequest
+	121, // 145: db.DbService.HotPaths:input_type -> db.HotPathsRequest
+	94,  // 146: db.DbService.GetACL:input_type -> db.Empty
+	125, // 147: db.DbService.CompressChain:input_type -> db.CompressChainRequest
+	127, // 148: db.DbService.RedactValue:input_type -> db.RedactValueRequest
+	129, // 149: db.DbService.SetACL:input_type -> db.ACL
+	94,  // 150: db.DbService.CheckIntegrity:input_type -> db.Empty
+	94,  // 151: db.DbService.DescribeService:input_type -> db.Empty
+	94,  // 152: db.DbService.GetVersion:input_type -> db.Empty
+	78,  // 153: db.DbService.ClaimRanges:input_type -> db.ClaimRangesRequest
+	142, // 154: db.DbService.FollowWAL:input_type -> db.FollowWALRequest
+	145, // 155: db.DbService.Promote:input_type -> db.PromoteRequest
+	109, // 156: db.DbService.SetIndexingLock:output_type -> db.SetIndexingLockResponse
+	94,  // 157: db.DbService.RemoveChildren:output_type -> db.Empty
+	68,  // 158: db.DbService.ReplaceChildren:output_type -> db.ReplaceChildrenResponse
+	94,  // 159: db.DbService.RemoveNode:output_type -> db.Empty
+	86,  // 160: db.DbService.RemoveNodes:output_type -> db.RemoveNodesResponse
+	33,  // 161: db.DbService.AddChild:output_type -> db.Node
+	33,  // 162: db.DbService.UnlinkChild:output_type -> db.Node
+	94,  // 163: db.DbService.ExpireNode:output_type -> db.Empty
+	59,  // 164: db.DbService.ResolveBucket:output_type -> db.ResolveBucketResponse
+	33,  // 165: db.DbService.GetNode:output_type -> db.Node
+	91,  // 166: db.DbService.GetProof:output_type -> db.Proof
+	57,  // 167: db.DbService.Exists:output_type -> db.ExistsResponse
+	74,  // 168: db.DbService.ResolveAlias:output_type -> db.ResolveAliasResponse
+	94,  // 169: db.DbService.AddAliases:output_type -> db.Empty
+	93,  // 170: db.DbService.GetKeyNodes:output_type -> db.Nodes
+	107, // 171: db.DbService.CreateRoot:output_type -> db.CreateRootResponse
+	6,   // 172: db.DbService.Get:output_type -> db.GetResponse
+	94,  // 173: db.DbService.Invalidate:output_type -> db.Empty
+	18,  // 174: db.DbService.Set:output_type -> db.SetResponse
+	21,  // 175: db.DbService.BatchSet:output_type -> db.BatchSetResponse
+	30,  // 176: db.DbService.Apply:output_type -> db.ApplyResponse
+	32,  // 177: db.DbService.GetOrSet:output_type -> db.GetOrSetResponse
+	24,  // 178: db.DbService.CreateSnapshot:output_type -> db.Snapshot
+	94,  // 179: db.DbService.DeleteSnapshot:output_type -> db.Empty
+	25,  // 180: db.DbService.ListSnapshots:output_type -> db.Snapshots
+	26,  // 181: db.DbService.GetHeads:output_type -> db.Heads
+	38,  // 182: db.DbService.AddNode:output_type -> db.AddNodeResponse
+	40,  // 183: db.DbService.AddNodes:output_type -> db.AddNodesResponse
+	37,  // 184: db.DbService.PrepareReceive:output_type -> db.PrepareReceiveResponse
+	94,  // 185: db.DbService.Split:output_type -> db.Empty
+	43,  // 186: db.DbService.Decommission:output_type -> db.DecommissionStatus
+	43,  // 187: db.DbService.GetDecommissionStatus:output_type -> db.DecommissionStatus
+	45,  // 188: db.DbService.TriggerSplit:output_type -> db.SplitPlan
+	94,  // 189: db.DbService.SetMergeFunction:output_type -> db.Empty
+	94,  // 190: db.DbService.SetGlobalMergeFunction:output_type -> db.Empty
+	94,  // 191: db.DbService.SetScopedMergeFunction:output_type -> db.Empty
+	53,  // 192: db.DbService.ResolveMergeFunction:output_type -> db.MergeResolution
+	106, // 193: db.DbService.GetMergeHistory:output_type -> db.MergeHistory
+	97,  // 194: db.DbService.GetFailedMerges:output_type -> db.FailedMerges
+	94,  // 195: db.DbService.RetryMerge:output_type -> db.Empty
+	105, // 196: db.DbService.ConvergenceStatus:output_type -> db.ConvergenceReport
+	100, // 197: db.DbService.GetValidationRules:output_type -> db.ValidationRules
+	94,  // 198: db.DbService.SetValidationRules:output_type -> db.Empty
+	50,  // 199: db.DbService.GetMergeRegistrations:output_type -> db.MergeRegistrations
+	94,  // 200: db.DbService.SetMode:output_type -> db.Empty
+	133, // 201: db.DbService.Compact:output_type -> db.CompactResponse
+	94,  // 202: db.DbService.RunTask:output_type -> db.Empty
+	94,  // 203: db.DbService.PauseMaintenance:output_type -> db.Empty
+	131, // 204: db.DbService.ReloadConfig:output_type -> db.ReloadConfigResponse
+	132, // 205: db.DbService.GetConfig:output_type -> db.ConfigResponse
+	111, // 206: db.DbService.GetStats:output_type -> db.GetStatsResponse
+	147, // 207: db.DbService.Register:output_type -> db.Membership
+	94,  // 208: db.DbService.UpdateMembership:output_type -> db.Empty
+	147, // 209: db.DbService.ListServers:output_type -> db.Membership
+	149, // 210: db.DbService.AllocateServer:output_type -> db.AllocateServerResponse
+	94,  // 211: db.DbService.ReleaseServer:output_type -> db.Empty
+	76,  // 212: db.DbService.GetMapping:output_type -> db.Mapping
+	84,  // 213: db.DbService.LocateKeys:output_type -> db.LocateKeysResponse
+	136, // 214: db.DbService.GetPlacementRules:output_type -> db.PlacementRules
+	94,  // 215: db.DbService.SetPlacementRules:output_type -> db.Empty
+	137, // 216: db.DbService.VerifyPlacement:output_type -> db.VerifyPlacementResponse
+	140, // 217: db.DbService.GetRangeDigest:output_type -> db.RangeDigest
+	16,  // 218: db.DbService.GetMerged:output_type -> db.GetMergedResponse
+	7,   // 219: db.DbService.WatchEvents:output_type -> db.Event
+	66,  // 220: db.DbService.CopySubtree:output_type -> db.CopyProgress
+	62,  // 221: db.DbService.GetHeatmap:output_type -> db.Heatmap
+	10,  // 222: db.DbService.GetSlowLog:output_type -> db.SlowLog
+	13,  // 223: db.DbService.GetJournal:output_type -> db.Journal
+	116, // 224: db.DbService.TopConflictingKeys:output_type -> db.ConflictingKeys
+	120, // 225: db.DbService.TopSpaceConsumers:output_type -> db.SpaceConsumers
+	124, // 226: db.DbService.HotPaths:output_type -> db.HotPathsResponse
+	129, // 227: db.DbService.GetACL:output_type -> db.ACL
+	126, // 228: db.DbService.CompressChain:output_type -> db.CompressChainResponse
+	128, // 229: db.DbService.RedactValue:output_type -> db.RedactValueResponse
+	94,  // 230: db.DbService.SetACL:output_type -> db.Empty
+	138, // 231: db.DbService.CheckIntegrity:output_type -> db.CheckIntegrityResponse
+	81,  // 232: db.DbService.DescribeService:output_type -> db.ServiceDescription
+	80,  // 233: db.DbService.GetVersion:output_type -> db.VersionResponse
+	79,  // 234: db.DbService.ClaimRanges:output_type -> db.ClaimRangesResponse
+	144, // 235: db.DbService.FollowWAL:output_type -> db.WALBatch
+	146, // 236: db.DbService.Promote:output_type -> db.PromoteResponse
+	156, // [156:237] is the sub-list for method output_type
+	75,  // [75:156] is the sub-list for method input_type
+	75,  // [75:75] is the sub-list for extension type_name
+	75,  // [75:75] is the sub-list for extension extendee
+	0,   // [0:75] is the sub-list for field type_name
+}
+
+func init() { file_db_proto_init() }
+func file_db_proto_init() {
+	if File_db_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_db_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WalkStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlowLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JournalEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJournalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Journal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMergedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeadValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMergedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolvedKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchSetResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntryStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshots); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Heads); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApplyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOrSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareReceiveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareReceiveResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNodeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddNodesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SplitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecommissionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecommissionStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TriggerSplitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SplitPlan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergePolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetMergeFunctionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetGlobalMergeFunctionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeRegistration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeRegistrations); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetScopedMergeFunctionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveMergeFunctionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeResolution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMergeRegistrationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddChildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExistsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExistsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveBucketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveBucketResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHeatmapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeatmapBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Heatmap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpireNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopySubtreeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopiedNode); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceChildrenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceChildrenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveChildrenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aliases); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveAliasRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveAliasResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Mapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimRangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimRangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceDescription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocateKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyOwner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocateKeysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveNodesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProofStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetKeyNodesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nodes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailedMerge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FailedMerges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryMergeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidationRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaintenanceTask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunTaskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseMaintenanceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvergenceStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConvergenceReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MergeHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetIndexingLockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetIndexingLockResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetModeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[107].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[108].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StandbyStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[109].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SplitGovernance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[110].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopConflictingKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[111].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConflictingKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[112].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConflictingKeys); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[113].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopSpaceConsumersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[114].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpaceConsumer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[115].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpacePrefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[116].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpaceConsumers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[117].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HotPathsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[118].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HotKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[119].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HotBranch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[120].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HotPathsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[121].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompressChainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[122].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompressChainResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[123].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedactValueRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[124].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedactValueResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[125].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ACL); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[126].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerLoad); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[127].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[128].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[129].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[130].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SplitAttempt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[131].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlacementRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[132].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlacementRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[133].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyPlacementResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[134].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckIntegrityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[135].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRangeDigestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[136].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RangeDigest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[137].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[138].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FollowWALRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[139].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WALRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[140].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WALBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[141].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[142].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[143].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Membership); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[144].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllocateServerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[145].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllocateServerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_db_proto_msgTypes[146].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseServerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_db_proto_msgTypes[18].OneofWrappers = []interface{}{
+		(*BatchSetResult_Location)(nil),
+		(*BatchSetResult_Status)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_db_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   157,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_db_proto_goTypes,
+		DependencyIndexes: file_db_proto_depIdxs,
+		EnumInfos:         file_db_proto_enumTypes,
+		MessageInfos:      file_db_proto_msgTypes,
+	}.Build()
+	File_db_proto = out.File
+	file_db_proto_rawDesc = nil
+	file_db_proto_goTypes = nil
+	file_db_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DbServiceClient is the client API for DbService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DbServiceClient interface {
+	SetIndexingLock(ctx context.Context, in *SetIndexingLockRequest, opts ...grpc.CallOption) (*SetIndexingLockResponse, error)
+	RemoveChildren(ctx context.Context, in *RemoveChildrenRequest, opts ...grpc.CallOption) (*Empty, error)
+	ReplaceChildren(ctx context.Context, in *ReplaceChildrenRequest, opts ...grpc.CallOption) (*ReplaceChildrenResponse, error)
+	RemoveNode(ctx context.Context, in *RemoveNodeRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveNodes(ctx context.Context, in *RemoveNodesRequest, opts ...grpc.CallOption) (*RemoveNodesResponse, error)
+	AddChild(ctx context.Context, in *AddChildRequest, opts ...grpc.CallOption) (*Node, error)
+	UnlinkChild(ctx context.Context, in *AddChildRequest, opts ...grpc.CallOption) (*Node, error)
+	ExpireNode(ctx context.Context, in *ExpireNodeRequest, opts ...grpc.CallOption) (*Empty, error)
+	ResolveBucket(ctx context.Context, in *ResolveBucketRequest, opts ...grpc.CallOption) (*ResolveBucketResponse, error)
+	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error)
+	// Nodes from the location to its root with their checksums, to verify the value of the key offline
+	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*Proof, error)
+	Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error)
+	ResolveAlias(ctx context.Context, in *ResolveAliasRequest, opts ...grpc.CallOption) (*ResolveAliasResponse, error)
+	AddAliases(ctx context.Context, in *Aliases, opts ...grpc.CallOption) (*Empty, error)
+	GetKeyNodes(ctx context.Context, in *GetKeyNodesRequest, opts ...grpc.CallOption) (*Nodes, error)
+	CreateRoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CreateRootResponse, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Invalidate(ctx context.Context, in *InvalidateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
+	BatchSet(ctx context.Context, in *BatchSetRequest, opts ...grpc.CallOption) (*BatchSetResponse, error)
+	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error)
+	GetOrSet(ctx context.Context, in *GetOrSetRequest, opts ...grpc.CallOption) (*GetOrSetResponse, error)
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListSnapshots(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Snapshots, error)
+	GetHeads(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Heads, error)
+	AddNode(ctx context.Context, in *AddNodeRequest, opts ...grpc.CallOption) (*AddNodeResponse, error)
+	AddNodes(ctx context.Context, in *AddNodesRequest, opts ...grpc.CallOption) (*AddNodesResponse, error)
+	PrepareReceive(ctx context.Context, in *PrepareReceiveRequest, opts ...grpc.CallOption) (*PrepareReceiveResponse, error)
+	Split(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*Empty, error)
+	Decommission(ctx context.Context, in *DecommissionRequest, opts ...grpc.CallOption) (*DecommissionStatus, error)
+	GetDecommissionStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DecommissionStatus, error)
+	TriggerSplit(ctx context.Context, in *TriggerSplitRequest, opts ...grpc.CallOption) (*SplitPlan, error)
+	SetMergeFunction(ctx context.Context, in *SetMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetGlobalMergeFunction(ctx context.Context, in *SetGlobalMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetScopedMergeFunction(ctx context.Context, in *SetScopedMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error)
+	ResolveMergeFunction(ctx context.Context, in *ResolveMergeFunctionRequest, opts ...grpc.CallOption) (*MergeResolution, error)
+	GetMergeHistory(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MergeHistory, error)
+	GetFailedMerges(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FailedMerges, error)
+	RetryMerge(ctx context.Context, in *RetryMergeRequest, opts ...grpc.CallOption) (*Empty, error)
+	ConvergenceStatus(ctx context.Context, in *ConvergenceStatusRequest, opts ...grpc.CallOption) (*ConvergenceReport, error)
+	GetValidationRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ValidationRules, error)
+	SetValidationRules(ctx context.Context, in *ValidationRules, opts ...grpc.CallOption) (*Empty, error)
+	GetMergeRegistrations(ctx context.Context, in *GetMergeRegistrationsRequest, opts ...grpc.CallOption) (*MergeRegistrations, error)
+	SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*Empty, error)
+	Compact(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CompactResponse, error)
+	RunTask(ctx context.Context, in *RunTaskRequest, opts ...grpc.CallOption) (*Empty, error)
+	PauseMaintenance(ctx context.Context, in *PauseMaintenanceRequest, opts ...grpc.CallOption) (*Empty, error)
+	ReloadConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	GetConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConfigResponse, error)
+	GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*Membership, error)
+	UpdateMembership(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*Empty, error)
+	ListServers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Membership, error)
+	AllocateServer(ctx context.Context, in *AllocateServerRequest, opts ...grpc.CallOption) (*AllocateServerResponse, error)
+	ReleaseServer(ctx context.Context, in *ReleaseServerRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetMapping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Mapping, error)
+	LocateKeys(ctx context.Context, in *LocateKeysRequest, opts ...grpc.CallOption) (*LocateKeysResponse, error)
+	GetPlacementRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlacementRules, error)
+	SetPlacementRules(ctx context.Context, in *PlacementRules, opts ...grpc.CallOption) (*Empty, error)
+	VerifyPlacement(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VerifyPlacementResponse, error)
+	GetRangeDigest(ctx context.Context, in *GetRangeDigestRequest, opts ...grpc.CallOption) (*RangeDigest, error)
+	GetMerged(ctx context.Context, in *GetMergedRequest, opts ...grpc.CallOption) (*GetMergedResponse, error)
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (DbService_WatchEventsClient, error)
+	CopySubtree(ctx context.Context, in *CopySubtreeRequest, opts ...grpc.CallOption) (DbService_CopySubtreeClient, error)
+	GetHeatmap(ctx context.Context, in *GetHeatmapRequest, opts ...grpc.CallOption) (*Heatmap, error)
+	GetSlowLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SlowLog, error)
+	GetJournal(ctx context.Context, in *GetJournalRequest, opts ...grpc.CallOption) (*Journal, error)
+	TopConflictingKeys(ctx context.Context, in *TopConflictingKeysRequest, opts ...grpc.CallOption) (*ConflictingKeys, error)
+	TopSpaceConsumers(ctx context.Context, in *TopSpaceConsumersRequest, opts ...grpc.CallOption) (*SpaceConsumers, error)
+	HotPaths(ctx context.Context, in *HotPathsRequest, opts ...grpc.CallOption) (*HotPathsResponse, error)
+	GetACL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ACL, error)
+	CompressChain(ctx context.Context, in *CompressChainRequest, opts ...grpc.CallOption) (*CompressChainResponse, error)
+	RedactValue(ctx context.Context, in *RedactValueRequest, opts ...grpc.CallOption) (*RedactValueResponse, error)
+	SetACL(ctx context.Context, in *ACL, opts ...grpc.CallOption) (*Empty, error)
+	CheckIntegrity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheckIntegrityResponse, error)
+	DescribeService(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceDescription, error)
+	GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionResponse, error)
+	ClaimRanges(ctx context.Context, in *ClaimRangesRequest, opts ...grpc.CallOption) (*ClaimRangesResponse, error)
+	FollowWAL(ctx context.Context, in *FollowWALRequest, opts ...grpc.CallOption) (DbService_FollowWALClient, error)
+	Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*PromoteResponse, error)
+}
+
+type dbServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDbServiceClient(cc grpc.ClientConnInterface) DbServiceClient {
+	return &dbServiceClient{cc}
+}
+
+func (c *dbServiceClient) SetIndexingLock(ctx context.Context, in *SetIndexingLockRequest, opts ...grpc.CallOption) (*SetIndexingLockResponse, error) {
+	out := new(SetIndexingLockResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetIndexingLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RemoveChildren(ctx context.Context, in *RemoveChildrenRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/RemoveChildren", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ReplaceChildren(ctx context.Context, in *ReplaceChildrenRequest, opts ...grpc.CallOption) (*ReplaceChildrenResponse, error) {
+	out := new(ReplaceChildrenResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/ReplaceChildren", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RemoveNode(ctx context.Context, in *RemoveNodeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/RemoveNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RemoveNodes(ctx context.Context, in *RemoveNodesRequest, opts ...grpc.CallOption) (*RemoveNodesResponse, error) {
+	out := new(RemoveNodesResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/RemoveNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) AddChild(ctx context.Context, in *AddChildRequest, opts ...grpc.CallOption) (*Node, error) {
+	out := new(Node)
+	err := c.cc.Invoke(ctx, "/db.DbService/AddChild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) UnlinkChild(ctx context.Context, in *AddChildRequest, opts ...grpc.CallOption) (*Node, error) {
+	out := new(Node)
+	err := c.cc.Invoke(ctx, "/db.DbService/UnlinkChild", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ExpireNode(ctx context.Context, in *ExpireNodeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/ExpireNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ResolveBucket(ctx context.Context, in *ResolveBucketRequest, opts ...grpc.CallOption) (*ResolveBucketResponse, error) {
+	out := new(ResolveBucketResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/ResolveBucket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error) {
+	out := new(Node)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*Proof, error) {
+	out := new(Proof)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Exists(ctx context.Context, in *ExistsRequest, opts ...grpc.CallOption) (*ExistsResponse, error) {
+	out := new(ExistsResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/Exists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ResolveAlias(ctx context.Context, in *ResolveAliasRequest, opts ...grpc.CallOption) (*ResolveAliasResponse, error) {
+	out := new(ResolveAliasResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/ResolveAlias", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) AddAliases(ctx context.Context, in *Aliases, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/AddAliases", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetKeyNodes(ctx context.Context, in *GetKeyNodesRequest, opts ...grpc.CallOption) (*Nodes, error) {
+	out := new(Nodes)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetKeyNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) CreateRoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CreateRootResponse, error) {
+	out := new(CreateRootResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/CreateRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Invalidate(ctx context.Context, in *InvalidateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/Invalidate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
+	out := new(SetResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) BatchSet(ctx context.Context, in *BatchSetRequest, opts ...grpc.CallOption) (*BatchSetResponse, error) {
+	out := new(BatchSetResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/BatchSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error) {
+	out := new(ApplyResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/Apply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetOrSet(ctx context.Context, in *GetOrSetRequest, opts ...grpc.CallOption) (*GetOrSetResponse, error) {
+	out := new(GetOrSetResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetOrSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, "/db.DbService/CreateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/DeleteSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ListSnapshots(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Snapshots, error) {
+	out := new(Snapshots)
+	err := c.cc.Invoke(ctx, "/db.DbService/ListSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetHeads(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Heads, error) {
+	out := new(Heads)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetHeads", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) AddNode(ctx context.Context, in *AddNodeRequest, opts ...grpc.CallOption) (*AddNodeResponse, error) {
+	out := new(AddNodeResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/AddNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) AddNodes(ctx context.Context, in *AddNodesRequest, opts ...grpc.CallOption) (*AddNodesResponse, error) {
+	out := new(AddNodesResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/AddNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) PrepareReceive(ctx context.Context, in *PrepareReceiveRequest, opts ...grpc.CallOption) (*PrepareReceiveResponse, error) {
+	out := new(PrepareReceiveResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/PrepareReceive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Split(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/Split", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Decommission(ctx context.Context, in *DecommissionRequest, opts ...grpc.CallOption) (*DecommissionStatus, error) {
+	out := new(DecommissionStatus)
+	err := c.cc.Invoke(ctx, "/db.DbService/Decommission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetDecommissionStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DecommissionStatus, error) {
+	out := new(DecommissionStatus)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetDecommissionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) TriggerSplit(ctx context.Context, in *TriggerSplitRequest, opts ...grpc.CallOption) (*SplitPlan, error) {
+	out := new(SplitPlan)
+	err := c.cc.Invoke(ctx, "/db.DbService/TriggerSplit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetMergeFunction(ctx context.Context, in *SetMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetMergeFunction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetGlobalMergeFunction(ctx context.Context, in *SetGlobalMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetGlobalMergeFunction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetScopedMergeFunction(ctx context.Context, in *SetScopedMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetScopedMergeFunction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ResolveMergeFunction(ctx context.Context, in *ResolveMergeFunctionRequest, opts ...grpc.CallOption) (*MergeResolution, error) {
+	out := new(MergeResolution)
+	err := c.cc.Invoke(ctx, "/db.DbService/ResolveMergeFunction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetMergeHistory(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MergeHistory, error) {
+	out := new(MergeHistory)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetMergeHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetFailedMerges(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FailedMerges, error) {
+	out := new(FailedMerges)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetFailedMerges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RetryMerge(ctx context.Context, in *RetryMergeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/RetryMerge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ConvergenceStatus(ctx context.Context, in *ConvergenceStatusRequest, opts ...grpc.CallOption) (*ConvergenceReport, error) {
+	out := new(ConvergenceReport)
+	err := c.cc.Invoke(ctx, "/db.DbService/ConvergenceStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetValidationRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ValidationRules, error) {
+	out := new(ValidationRules)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetValidationRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetValidationRules(ctx context.Context, in *ValidationRules, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetValidationRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetMergeRegistrations(ctx context.Context, in *GetMergeRegistrationsRequest, opts ...grpc.CallOption) (*MergeRegistrations, error) {
+	out := new(MergeRegistrations)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetMergeRegistrations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetMode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Compact(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CompactResponse, error) {
+	out := new(CompactResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/Compact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RunTask(ctx context.Context, in *RunTaskRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/RunTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) PauseMaintenance(ctx context.Context, in *PauseMaintenanceRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/PauseMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ReloadConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetConfig(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*Membership, error) {
+	out := new(Membership)
+	err := c.cc.Invoke(ctx, "/db.DbService/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) UpdateMembership(ctx context.Context, in *Membership, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/UpdateMembership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ListServers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Membership, error) {
+	out := new(Membership)
+	err := c.cc.Invoke(ctx, "/db.DbService/ListServers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) AllocateServer(ctx context.Context, in *AllocateServerRequest, opts ...grpc.CallOption) (*AllocateServerResponse, error) {
+	out := new(AllocateServerResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/AllocateServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ReleaseServer(ctx context.Context, in *ReleaseServerRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/ReleaseServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetMapping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Mapping, error) {
+	out := new(Mapping)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetMapping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) LocateKeys(ctx context.Context, in *LocateKeysRequest, opts ...grpc.CallOption) (*LocateKeysResponse, error) {
+	out := new(LocateKeysResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/LocateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetPlacementRules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlacementRules, error) {
+	out := new(PlacementRules)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetPlacementRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetPlacementRules(ctx context.Context, in *PlacementRules, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetPlacementRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) VerifyPlacement(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VerifyPlacementResponse, error) {
+	out := new(VerifyPlacementResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/VerifyPlacement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetRangeDigest(ctx context.Context, in *GetRangeDigestRequest, opts ...grpc.CallOption) (*RangeDigest, error) {
+	out := new(RangeDigest)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetRangeDigest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetMerged(ctx context.Context, in *GetMergedRequest, opts ...grpc.CallOption) (*GetMergedResponse, error) {
+	out := new(GetMergedResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetMerged", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (DbService_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DbService_serviceDesc.Streams[0], "/db.DbService/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dbServiceWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DbService_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type dbServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *dbServiceWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dbServiceClient) CopySubtree(ctx context.Context, in *CopySubtreeRequest, opts ...grpc.CallOption) (DbService_CopySubtreeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DbService_serviceDesc.Streams[1], "/db.DbService/CopySubtree", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dbServiceCopySubtreeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DbService_CopySubtreeClient interface {
+	Recv() (*CopyProgress, error)
+	grpc.ClientStream
+}
+
+type dbServiceCopySubtreeClient struct {
+	grpc.ClientStream
+}
+
+func (x *dbServiceCopySubtreeClient) Recv() (*CopyProgress, error) {
+	m := new(CopyProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dbServiceClient) GetHeatmap(ctx context.Context, in *GetHeatmapRequest, opts ...grpc.CallOption) (*Heatmap, error) {
+	out := new(Heatmap)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetHeatmap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetSlowLog(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SlowLog, error) {
+	out := new(SlowLog)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetSlowLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetJournal(ctx context.Context, in *GetJournalRequest, opts ...grpc.CallOption) (*Journal, error) {
+	out := new(Journal)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) TopConflictingKeys(ctx context.Context, in *TopConflictingKeysRequest, opts ...grpc.CallOption) (*ConflictingKeys, error) {
+	out := new(ConflictingKeys)
+	err := c.cc.Invoke(ctx, "/db.DbService/TopConflictingKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) TopSpaceConsumers(ctx context.Context, in *TopSpaceConsumersRequest, opts ...grpc.CallOption) (*SpaceConsumers, error) {
+	out := new(SpaceConsumers)
+	err := c.cc.Invoke(ctx, "/db.DbService/TopSpaceConsumers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) HotPaths(ctx context.Context, in *HotPathsRequest, opts ...grpc.CallOption) (*HotPathsResponse, error) {
+	out := new(HotPathsResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/HotPaths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetACL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ACL, error) {
+	out := new(ACL)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) CompressChain(ctx context.Context, in *CompressChainRequest, opts ...grpc.CallOption) (*CompressChainResponse, error) {
+	out := new(CompressChainResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/CompressChain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RedactValue(ctx context.Context, in *RedactValueRequest, opts ...grpc.CallOption) (*RedactValueResponse, error) {
+	out := new(RedactValueResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/RedactValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) SetACL(ctx context.Context, in *ACL, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/db.DbService/SetACL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) CheckIntegrity(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheckIntegrityResponse, error) {
+	out := new(CheckIntegrityResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/CheckIntegrity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) DescribeService(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceDescription, error) {
+	out := new(ServiceDescription)
+	err := c.cc.Invoke(ctx, "/db.DbService/DescribeService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetVersion(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/GetVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ClaimRanges(ctx context.Context, in *ClaimRangesRequest, opts ...grpc.CallOption) (*ClaimRangesResponse, error) {
+	out := new(ClaimRangesResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/ClaimRanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) FollowWAL(ctx context.Context, in *FollowWALRequest, opts ...grpc.CallOption) (DbService_FollowWALClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DbService_serviceDesc.Streams[2], "/db.DbService/FollowWAL", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dbServiceFollowWALClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DbService_FollowWALClient interface {
+	Recv() (*WALBatch, error)
+	grpc.ClientStream
+}
+
+type dbServiceFollowWALClient struct {
+	grpc.ClientStream
+}
+
+func (x *dbServiceFollowWALClient) Recv() (*WALBatch, error) {
+	m := new(WALBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dbServiceClient) Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*PromoteResponse, error) {
+	out := new(PromoteResponse)
+	err := c.cc.Invoke(ctx, "/db.DbService/Promote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DbServiceServer is the server API for DbService service.
+type DbServiceServer interface {
+	SetIndexingLock(context.Context, *SetIndexingLockRequest) (*SetIndexingLockResponse, error)
+	RemoveChildren(context.Context, *RemoveChildrenRequest) (*Empty, error)
+	ReplaceChildren(context.Context, *ReplaceChildrenRequest) (*ReplaceChildrenResponse, error)
+	RemoveNode(context.Context, *RemoveNodeRequest) (*Empty, error)
+	RemoveNodes(context.Context, *RemoveNodesRequest) (*RemoveNodesResponse, error)
+	AddChild(context.Context, *AddChildRequest) (*Node, error)
+	UnlinkChild(context.Context, *AddChildRequest) (*Node, error)
+	ExpireNode(context.Context, *ExpireNodeRequest) (*Empty, error)
+	ResolveBucket(context.Context, *ResolveBucketRequest) (*ResolveBucketResponse, error)
+	GetNode(context.Context, *GetNodeRequest) (*Node, error)
+	// Nodes from the location to its root with their checksums, to verify the value of the key offline
+	GetProof(context.Context, *GetProofRequest) (*Proof, error)
+	Exists(context.Context, *ExistsRequest) (*ExistsResponse, error)
+	ResolveAlias(context.Context, *ResolveAliasRequest) (*ResolveAliasResponse, error)
+	AddAliases(context.Context, *Aliases) (*Empty, error)
+	GetKeyNodes(context.Context, *GetKeyNodesRequest) (*Nodes, error)
+	CreateRoot(context.Context, *Empty) (*CreateRootResponse, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Invalidate(context.Context, *InvalidateRequest) (*Empty, error)
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	BatchSet(context.Context, *BatchSetRequest) (*BatchSetResponse, error)
+	Apply(context.Context, *ApplyRequest) (*ApplyResponse, error)
+	GetOrSet(context.Context, *GetOrSetRequest) (*GetOrSetResponse, error)
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error)
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*Empty, error)
+	ListSnapshots(context.Context, *Empty) (*Snapshots, error)
+	GetHeads(context.Context, *Empty) (*Heads, error)
+	AddNode(context.Context, *AddNodeRequest) (*AddNodeResponse, error)
+	AddNodes(context.Context, *AddNodesRequest) (*AddNodesResponse, error)
+	PrepareReceive(context.Context, *PrepareReceiveRequest) (*PrepareReceiveResponse, error)
+	Split(context.Context, *SplitRequest) (*Empty, error)
+	Decommission(context.Context, *DecommissionRequest) (*DecommissionStatus, error)
+	GetDecommissionStatus(context.Context, *Empty) (*DecommissionStatus, error)
+	TriggerSplit(context.Context, *TriggerSplitRequest) (*SplitPlan, error)
+	SetMergeFunction(context.Context, *SetMergeFunctionRequest) (*Empty, error)
+	SetGlobalMergeFunction(context.Context, *SetGlobalMergeFunctionRequest) (*Empty, error)
+	SetScopedMergeFunction(context.Context, *SetScopedMergeFunctionRequest) (*Empty, error)
+	ResolveMergeFunction(context.Context, *ResolveMergeFunctionRequest) (*MergeResolution, error)
+	GetMergeHistory(context.Context, *Empty) (*MergeHistory, error)
+	GetFailedMerges(context.Context, *Empty) (*FailedMerges, error)
+	RetryMerge(context.Context, *RetryMergeRequest) (*Empty, error)
+	ConvergenceStatus(context.Context, *ConvergenceStatusRequest) (*ConvergenceReport, error)
+	GetValidationRules(context.Context, *Empty) (*ValidationRules, error)
+	SetValidationRules(context.Context, *ValidationRules) (*Empty, error)
+	GetMergeRegistrations(context.Context, *GetMergeRegistrationsRequest) (*MergeRegistrations, error)
+	SetMode(context.Context, *SetModeRequest) (*Empty, error)
+	Compact(context.Context, *Empty) (*CompactResponse, error)
+	RunTask(context.Context, *RunTaskRequest) (*Empty, error)
+	PauseMaintenance(context.Context, *PauseMaintenanceRequest) (*Empty, error)
+	ReloadConfig(context.Context, *Empty) (*ReloadConfigResponse, error)
+	GetConfig(context.Context, *Empty) (*ConfigResponse, error)
+	GetStats(context.Context, *Empty) (*GetStatsResponse, error)
+	Register(context.Context, *RegisterRequest) (*Membership, error)
+	UpdateMembership(context.Context, *Membership) (*Empty, error)
+	ListServers(context.Context, *Empty) (*Membership, error)
+	AllocateServer(context.Context, *AllocateServerRequest) (*AllocateServerResponse, error)
+	ReleaseServer(context.Context, *ReleaseServerRequest) (*Empty, error)
+	GetMapping(context.Context, *Empty) (*Mapping, error)
+	LocateKeys(context.Context, *LocateKeysRequest) (*LocateKeysResponse, error)
+	GetPlacementRules(context.Context, *Empty) (*PlacementRules, error)
+	SetPlacementRules(context.Context, *PlacementRules) (*Empty, error)
+	VerifyPlacement(context.Context, *Empty) (*VerifyPlacementResponse, error)
+	GetRangeDigest(context.Context, *GetRangeDigestRequest) (*RangeDigest, error)
+	GetMerged(context.Context, *GetMergedRequest) (*GetMergedResponse, error)
+	WatchEvents(*WatchEventsRequest, DbService_WatchEventsServer) error
+	CopySubtree(*CopySubtreeRequest, DbService_CopySubtreeServer) error
+	GetHeatmap(context.Context, *GetHeatmapRequest) (*Heatmap, error)
+	GetSlowLog(context.Context, *Empty) (*SlowLog, error)
+	GetJournal(context.Context, *GetJournalRequest) (*Journal, error)
+	TopConflictingKeys(context.Context, *TopConflictingKeysRequest) (*ConflictingKeys, error)
+	TopSpaceConsumers(context.Context, *TopSpaceConsumersRequest) (*SpaceConsumers, error)
+	HotPaths(context.Context, *HotPathsRequest) (*HotPathsResponse, error)
+	GetACL(context.Context, *Empty) (*ACL, error)
+	CompressChain(context.Context, *CompressChainRequest) (*CompressChainResponse, error)
+	RedactValue(context.Context, *RedactValueRequest) (*RedactValueResponse, error)
+	SetACL(context.Context, *ACL) (*Empty, error)
+	CheckIntegrity(context.Context, *Empty) (*CheckIntegrityResponse, error)
+	DescribeService(context.Context, *Empty) (*ServiceDescription, error)
+	GetVersion(context.Context, *Empty) (*VersionResponse, error)
+	ClaimRanges(context.Context, *ClaimRangesRequest) (*ClaimRangesResponse, error)
+	FollowWAL(*FollowWALRequest, DbService_FollowWALServer) error
+	Promote(context.Context, *PromoteRequest) (*PromoteResponse, error)
+}
+
+// UnimplementedDbServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedDbServiceServer struct {
+}
+
+func (*UnimplementedDbServiceServer) SetIndexingLock(context.Context, *SetIndexingLockRequest) (*SetIndexingLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIndexingLock not implemented")
+}
+func (*UnimplementedDbServiceServer) RemoveChildren(context.Context, *RemoveChildrenRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChildren not implemented")
+}
+func (*UnimplementedDbServiceServer) ReplaceChildren(context.Context, *ReplaceChildrenRequest) (*ReplaceChildrenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceChildren not implemented")
+}
+func (*UnimplementedDbServiceServer) RemoveNode(context.Context, *RemoveNodeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveNode not implemented")
+}
+func (*UnimplementedDbServiceServer) RemoveNodes(context.Context, *RemoveNodesRequest) (*RemoveNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveNodes not implemented")
+}
+func (*UnimplementedDbServiceServer) AddChild(context.Context, *AddChildRequest) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChild not implemented")
+}
+func (*UnimplementedDbServiceServer) UnlinkChild(context.Context, *AddChildRequest) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkChild not implemented")
+}
+func (*UnimplementedDbServiceServer) ExpireNode(context.Context, *ExpireNodeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpireNode not implemented")
+}
+func (*UnimplementedDbServiceServer) ResolveBucket(context.Context, *ResolveBucketRequest) (*ResolveBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveBucket not implemented")
+}
+func (*UnimplementedDbServiceServer) GetNode(context.Context, *GetNodeRequest) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNode not implemented")
+}
+func (*UnimplementedDbServiceServer) GetProof(context.Context, *GetProofRequest) (*Proof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
+}
+func (*UnimplementedDbServiceServer) Exists(context.Context, *ExistsRequest) (*ExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exists not implemented")
+}
+func (*UnimplementedDbServiceServer) ResolveAlias(context.Context, *ResolveAliasRequest) (*ResolveAliasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveAlias not implemented")
+}
+func (*UnimplementedDbServiceServer) AddAliases(context.Context, *Aliases) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAliases not implemented")
+}
+func (*UnimplementedDbServiceServer) GetKeyNodes(context.Context, *GetKeyNodesRequest) (*Nodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyNodes not implemented")
+}
+func (*UnimplementedDbServiceServer) CreateRoot(context.Context, *Empty) (*CreateRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoot not implemented")
+}
+func (*UnimplementedDbServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedDbServiceServer) Invalidate(context.Context, *InvalidateRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Invalidate not implemented")
+}
+func (*UnimplementedDbServiceServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedDbServiceServer) BatchSet(context.Context, *BatchSetRequest) (*BatchSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSet not implemented")
+}
+func (*UnimplementedDbServiceServer) Apply(context.Context, *ApplyRequest) (*ApplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
+}
+func (*UnimplementedDbServiceServer) GetOrSet(context.Context, *GetOrSetRequest) (*GetOrSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrSet not implemented")
+}
+func (*UnimplementedDbServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (*UnimplementedDbServiceServer) DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnapshot not implemented")
+}
+func (*UnimplementedDbServiceServer) ListSnapshots(context.Context, *Empty) (*Snapshots, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (*UnimplementedDbServiceServer) GetHeads(context.Context, *Empty) (*Heads, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeads not implemented")
+}
+func (*UnimplementedDbServiceServer) AddNode(context.Context, *AddNodeRequest) (*AddNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddNode not implemented")
+}
+func (*UnimplementedDbServiceServer) AddNodes(context.Context, *AddNodesRequest) (*AddNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddNodes not implemented")
+}
+func (*UnimplementedDbServiceServer) PrepareReceive(context.Context, *PrepareReceiveRequest) (*PrepareReceiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareReceive not implemented")
+}
+func (*UnimplementedDbServiceServer) Split(context.Context, *SplitRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Split not implemented")
+}
+func (*UnimplementedDbServiceServer) Decommission(context.Context, *DecommissionRequest) (*DecommissionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decommission not implemented")
+}
+func (*UnimplementedDbServiceServer) GetDecommissionStatus(context.Context, *Empty) (*DecommissionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDecommissionStatus not implemented")
+}
+func (*UnimplementedDbServiceServer) TriggerSplit(context.Context, *TriggerSplitRequest) (*SplitPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerSplit not implemented")
+}
+func (*UnimplementedDbServiceServer) SetMergeFunction(context.Context, *SetMergeFunctionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMergeFunction not implemented")
+}
+func (*UnimplementedDbServiceServer) SetGlobalMergeFunction(context.Context, *SetGlobalMergeFunctionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGlobalMergeFunction not implemented")
+}
+func (*UnimplementedDbServiceServer) SetScopedMergeFunction(context.Context, *SetScopedMergeFunctionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScopedMergeFunction not implemented")
+}
+func (*UnimplementedDbServiceServer) ResolveMergeFunction(context.Context, *ResolveMergeFunctionRequest) (*MergeResolution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveMergeFunction not implemented")
+}
+func (*UnimplementedDbServiceServer) GetMergeHistory(context.Context, *Empty) (*MergeHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMergeHistory not implemented")
+}
+func (*UnimplementedDbServiceServer) GetFailedMerges(context.Context, *Empty) (*FailedMerges, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailedMerges not implemented")
+}
+func (*UnimplementedDbServiceServer) RetryMerge(context.Context, *RetryMergeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryMerge not implemented")
+}
+func (*UnimplementedDbServiceServer) ConvergenceStatus(context.Context, *ConvergenceStatusRequest) (*ConvergenceReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvergenceStatus not implemented")
+}
+func (*UnimplementedDbServiceServer) GetValidationRules(context.Context, *Empty) (*ValidationRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidationRules not implemented")
+}
+func (*UnimplementedDbServiceServer) SetValidationRules(context.Context, *ValidationRules) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetValidationRules not implemented")
+}
+func (*UnimplementedDbServiceServer) GetMergeRegistrations(context.Context, *GetMergeRegistrationsRequest) (*MergeRegistrations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMergeRegistrations not implemented")
+}
+func (*UnimplementedDbServiceServer) SetMode(context.Context, *SetModeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMode not implemented")
+}
+func (*UnimplementedDbServiceServer) Compact(context.Context, *Empty) (*CompactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (*UnimplementedDbServiceServer) RunTask(context.Context, *RunTaskRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunTask not implemented")
+}
+func (*UnimplementedDbServiceServer) PauseMaintenance(context.Context, *PauseMaintenanceRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseMaintenance not implemented")
+}
+func (*UnimplementedDbServiceServer) ReloadConfig(context.Context, *Empty) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (*UnimplementedDbServiceServer) GetConfig(context.Context, *Empty) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (*UnimplementedDbServiceServer) GetStats(context.Context, *Empty) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (*UnimplementedDbServiceServer) Register(context.Context, *RegisterRequest) (*Membership, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (*UnimplementedDbServiceServer) UpdateMembership(context.Context, *Membership) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMembership not implemented")
+}
+func (*UnimplementedDbServiceServer) ListServers(context.Context, *Empty) (*Membership, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServers not implemented")
+}
+func (*UnimplementedDbServiceServer) AllocateServer(context.Context, *AllocateServerRequest) (*AllocateServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllocateServer not implemented")
+}
+func (*UnimplementedDbServiceServer) ReleaseServer(context.Context, *ReleaseServerRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseServer not implemented")
+}
+func (*UnimplementedDbServiceServer) GetMapping(context.Context, *Empty) (*Mapping, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMapping not implemented")
+}
+func (*UnimplementedDbServiceServer) LocateKeys(context.Context, *LocateKeysRequest) (*LocateKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocateKeys not implemented")
+}
+func (*UnimplementedDbServiceServer) GetPlacementRules(context.Context, *Empty) (*PlacementRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlacementRules not implemented")
+}
+func (*UnimplementedDbServiceServer) SetPlacementRules(context.Context, *PlacementRules) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPlacementRules not implemented")
+}
+func (*UnimplementedDbServiceServer) VerifyPlacement(context.Context, *Empty) (*VerifyPlacementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPlacement not implemented")
+}
+func (*UnimplementedDbServiceServer) GetRangeDigest(context.Context, *GetRangeDigestRequest) (*RangeDigest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRangeDigest not implemented")
+}
+func (*UnimplementedDbServiceServer) GetMerged(context.Context, *GetMergedRequest) (*GetMergedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMerged not implemented")
+}
+func (*UnimplementedDbServiceServer) WatchEvents(*WatchEventsRequest, DbService_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (*UnimplementedDbServiceServer) CopySubtree(*CopySubtreeRequest, DbService_CopySubtreeServer) error {
+	return status.Errorf(codes.Unimplemented, "method CopySubtree not implemented")
+}
+func (*UnimplementedDbServiceServer) GetHeatmap(context.Context, *GetHeatmapRequest) (*Heatmap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeatmap not implemented")
+}
+func (*UnimplementedDbServiceServer) GetSlowLog(context.Context, *Empty) (*SlowLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlowLog not implemented")
+}
+func (*UnimplementedDbServiceServer) GetJournal(context.Context, *GetJournalRequest) (*Journal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJournal not implemented")
+}
+func (*UnimplementedDbServiceServer) TopConflictingKeys(context.Context, *TopConflictingKeysRequest) (*ConflictingKeys, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopConflictingKeys not implemented")
+}
+func (*UnimplementedDbServiceServer) TopSpaceConsumers(context.Context, *TopSpaceConsumersRequest) (*SpaceConsumers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopSpaceConsumers not implemented")
+}
+func (*UnimplementedDbServiceServer) HotPaths(context.Context, *HotPathsRequest) (*HotPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HotPaths not implemented")
+}
+func (*UnimplementedDbServiceServer) GetACL(context.Context, *Empty) (*ACL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetACL not implemented")
+}
+func (*UnimplementedDbServiceServer) CompressChain(context.Context, *CompressChainRequest) (*CompressChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompressChain not implemented")
+}
+func (*UnimplementedDbServiceServer) RedactValue(context.Context, *RedactValueRequest) (*RedactValueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedactValue not implemented")
+}
+func (*UnimplementedDbServiceServer) SetACL(context.Context, *ACL) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetACL not implemented")
+}
+func (*UnimplementedDbServiceServer) CheckIntegrity(context.Context, *Empty) (*CheckIntegrityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIntegrity not implemented")
+}
+func (*UnimplementedDbServiceServer) DescribeService(context.Context, *Empty) (*ServiceDescription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeService not implemented")
+}
+func (*UnimplementedDbServiceServer) GetVersion(context.Context, *Empty) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersion not implemented")
+}
+func (*UnimplementedDbServiceServer) ClaimRanges(context.Context, *ClaimRangesRequest) (*ClaimRangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimRanges not implemented")
+}
+func (*UnimplementedDbServiceServer) FollowWAL(*FollowWALRequest, DbService_FollowWALServer) error {
+	return status.Errorf(codes.Unimplemented, "method FollowWAL not implemented")
+}
+func (*UnimplementedDbServiceServer) Promote(context.Context, *PromoteRequest) (*PromoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Promote not implemented")
+}
+
+func RegisterDbServiceServer(s *grpc.Server, srv DbServiceServer) {
+	s.RegisterService(&_DbService_serviceDesc, srv)
+}
+
+func _DbService_SetIndexingLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIndexingLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetIndexingLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetIndexingLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetIndexingLock(ctx, req.(*SetIndexingLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_RemoveChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveChildrenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).RemoveChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/RemoveChildren",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).RemoveChildren(ctx, req.(*RemoveChildrenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ReplaceChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceChildrenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ReplaceChildren(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ReplaceChildren",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ReplaceChildren(ctx, req.(*ReplaceChildrenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_RemoveNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).RemoveNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/RemoveNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).RemoveNode(ctx, req.(*RemoveNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_RemoveNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).RemoveNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/RemoveNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).RemoveNodes(ctx, req.(*RemoveNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_AddChild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).AddChild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/AddChild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).AddChild(ctx, req.(*AddChildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_UnlinkChild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).UnlinkChild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/UnlinkChild",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).UnlinkChild(ctx, req.(*AddChildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ExpireNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpireNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ExpireNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ExpireNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ExpireNode(ctx, req.(*ExpireNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ResolveBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ResolveBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ResolveBucket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ResolveBucket(ctx, req.(*ResolveBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetProof(ctx, req.(*GetProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Exists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Exists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Exists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Exists(ctx, req.(*ExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ResolveAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ResolveAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ResolveAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ResolveAlias(ctx, req.(*ResolveAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_AddAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Aliases)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).AddAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/AddAliases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).AddAliases(ctx, req.(*Aliases))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetKeyNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetKeyNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetKeyNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetKeyNodes(ctx, req.(*GetKeyNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_CreateRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).CreateRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/CreateRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).CreateRoot(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Invalidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Invalidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Invalidate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Invalidate(ctx, req.(*InvalidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_BatchSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).BatchSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/BatchSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).BatchSet(ctx, req.(*BatchSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Apply(ctx, req.(*ApplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetOrSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetOrSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetOrSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetOrSet(ctx, req.(*GetOrSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/CreateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/DeleteSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ListSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ListSnapshots(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetHeads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetHeads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetHeads",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetHeads(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_AddNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).AddNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/AddNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).AddNode(ctx, req.(*AddNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_AddNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).AddNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/AddNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).AddNodes(ctx, req.(*AddNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_PrepareReceive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareReceiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).PrepareReceive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/PrepareReceive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).PrepareReceive(ctx, req.(*PrepareReceiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Split_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Split(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Split",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Split(ctx, req.(*SplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Decommission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecommissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Decommission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Decommission",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Decommission(ctx, req.(*DecommissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetDecommissionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetDecommissionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetDecommissionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetDecommissionStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_TriggerSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).TriggerSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/TriggerSplit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).TriggerSplit(ctx, req.(*TriggerSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_SetMergeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMergeFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetMergeFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetMergeFunction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetMergeFunction(ctx, req.(*SetMergeFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_SetGlobalMergeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGlobalMergeFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetGlobalMergeFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetGlobalMergeFunction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetGlobalMergeFunction(ctx, req.(*SetGlobalMergeFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_SetScopedMergeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScopedMergeFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetScopedMergeFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetScopedMergeFunction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetScopedMergeFunction(ctx, req.(*SetScopedMergeFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ResolveMergeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveMergeFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ResolveMergeFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ResolveMergeFunction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ResolveMergeFunction(ctx, req.(*ResolveMergeFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetMergeHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetMergeHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetMergeHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetMergeHistory(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetFailedMerges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetFailedMerges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetFailedMerges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetFailedMerges(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_RetryMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).RetryMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/RetryMerge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).RetryMerge(ctx, req.(*RetryMergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ConvergenceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvergenceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ConvergenceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ConvergenceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ConvergenceStatus(ctx, req.(*ConvergenceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetValidationRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetValidationRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetValidationRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetValidationRules(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_SetValidationRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidationRules)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetValidationRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetValidationRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetValidationRules(ctx, req.(*ValidationRules))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetMergeRegistrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMergeRegistrationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetMergeRegistrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetMergeRegistrations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetMergeRegistrations(ctx, req.(*GetMergeRegistrationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_SetMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetMode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetMode(ctx, req.(*SetModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Compact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Compact(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_RunTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).RunTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/RunTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).RunTask(ctx, req.(*RunTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_PauseMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).PauseMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/PauseMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).PauseMaintenance(ctx, req.(*PauseMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ReloadConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
-
-// DbServiceClient is the client API for DbService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DbServiceClient interface {
-	SetIndexingLock(ctx context.Context, in *SetIndexingLockRequest, opts ...grpc.CallOption) (*SetIndexingLockResponse, error)
-	RemoveChildren(ctx context.Context, in *RemoveChildrenRequest, opts ...grpc.CallOption) (*Empty, error)
-	AddChild(ctx context.Context, in *AddChildRequest, opts ...grpc.CallOption) (*Node, error)
-	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error)
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
-	AddNode(ctx context.Context, in *AddNodeRequest, opts ...grpc.CallOption) (*Empty, error)
-	Split(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*Empty, error)
-	SetMergeFunction(ctx context.Context, in *SetMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error)
-	SetGlobalMergeFunction(ctx context.Context, in *SetGlobalMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error)
+func _DbService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetConfig(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type dbServiceClient struct {
-	cc grpc.ClientConnInterface
+func _DbService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewDbServiceClient(cc grpc.ClientConnInterface) DbServiceClient {
-	return &dbServiceClient{cc}
+func _DbService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) SetIndexingLock(ctx context.Context, in *SetIndexingLockRequest, opts ...grpc.CallOption) (*SetIndexingLockResponse, error) {
-	out := new(SetIndexingLockResponse)
-	err := c.cc.Invoke(ctx, "/db.DbService/SetIndexingLock", in, out, opts...)
-	if err != nil {
+func _DbService_UpdateMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Membership)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).UpdateMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/UpdateMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).UpdateMembership(ctx, req.(*Membership))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) RemoveChildren(ctx context.Context, in *RemoveChildrenRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/db.DbService/RemoveChildren", in, out, opts...)
-	if err != nil {
+func _DbService_ListServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).ListServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ListServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ListServers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) AddChild(ctx context.Context, in *AddChildRequest, opts ...grpc.CallOption) (*Node, error) {
-	out := new(Node)
-	err := c.cc.Invoke(ctx, "/db.DbService/AddChild", in, out, opts...)
-	if err != nil {
+func _DbService_AllocateServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateServerRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).AllocateServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/AllocateServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).AllocateServer(ctx, req.(*AllocateServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error) {
-	out := new(Node)
-	err := c.cc.Invoke(ctx, "/db.DbService/GetNode", in, out, opts...)
-	if err != nil {
+func _DbService_ReleaseServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseServerRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).ReleaseServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/ReleaseServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ReleaseServer(ctx, req.(*ReleaseServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/db.DbService/Get", in, out, opts...)
-	if err != nil {
+func _DbService_GetMapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetMapping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetMapping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetMapping(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
-	out := new(SetResponse)
-	err := c.cc.Invoke(ctx, "/db.DbService/Set", in, out, opts...)
-	if err != nil {
+func _DbService_LocateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocateKeysRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).LocateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/LocateKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).LocateKeys(ctx, req.(*LocateKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) AddNode(ctx context.Context, in *AddNodeRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/db.DbService/AddNode", in, out, opts...)
-	if err != nil {
+func _DbService_GetPlacementRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetPlacementRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetPlacementRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetPlacementRules(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) Split(ctx context.Context, in *SplitRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/db.DbService/Split", in, out, opts...)
-	if err != nil {
+func _DbService_SetPlacementRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlacementRules)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).SetPlacementRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/SetPlacementRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).SetPlacementRules(ctx, req.(*PlacementRules))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) SetMergeFunction(ctx context.Context, in *SetMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/db.DbService/SetMergeFunction", in, out, opts...)
-	if err != nil {
+func _DbService_VerifyPlacement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).VerifyPlacement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/VerifyPlacement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).VerifyPlacement(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dbServiceClient) SetGlobalMergeFunction(ctx context.Context, in *SetGlobalMergeFunctionRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/db.DbService/SetGlobalMergeFunction", in, out, opts...)
-	if err != nil {
+func _DbService_GetRangeDigest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRangeDigestRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetRangeDigest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetRangeDigest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetRangeDigest(ctx, req.(*GetRangeDigestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DbServiceServer is the server API for DbService service.
-type DbServiceServer interface {
-	SetIndexingLock(context.Context, *SetIndexingLockRequest) (*SetIndexingLockResponse, error)
-	RemoveChildren(context.Context, *RemoveChildrenRequest) (*Empty, error)
-	AddChild(context.Context, *AddChildRequest) (*Node, error)
-	GetNode(context.Context, *GetNodeRequest) (*Node, error)
-	Get(context.Context, *GetRequest) (*GetResponse, error)
-	Set(context.Context, *SetRequest) (*SetResponse, error)
-	AddNode(context.Context, *AddNodeRequest) (*Empty, error)
-	Split(context.Context, *SplitRequest) (*Empty, error)
-	SetMergeFunction(context.Context, *SetMergeFunctionRequest) (*Empty, error)
-	SetGlobalMergeFunction(context.Context, *SetGlobalMergeFunctionRequest) (*Empty, error)
+func _DbService_GetMerged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMergedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetMerged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetMerged",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetMerged(ctx, req.(*GetMergedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedDbServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedDbServiceServer struct {
+func _DbService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DbServiceServer).WatchEvents(m, &dbServiceWatchEventsServer{stream})
 }
 
-func (*UnimplementedDbServiceServer) SetIndexingLock(context.Context, *SetIndexingLockRequest) (*SetIndexingLockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetIndexingLock not implemented")
+type DbService_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
 }
-func (*UnimplementedDbServiceServer) RemoveChildren(context.Context, *RemoveChildrenRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveChildren not implemented")
+
+type dbServiceWatchEventsServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedDbServiceServer) AddChild(context.Context, *AddChildRequest) (*Node, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddChild not implemented")
+
+func (x *dbServiceWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedDbServiceServer) GetNode(context.Context, *GetNodeRequest) (*Node, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNode not implemented")
+
+func _DbService_CopySubtree_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopySubtreeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DbServiceServer).CopySubtree(m, &dbServiceCopySubtreeServer{stream})
 }
-func (*UnimplementedDbServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+
+type DbService_CopySubtreeServer interface {
+	Send(*CopyProgress) error
+	grpc.ServerStream
 }
-func (*UnimplementedDbServiceServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+
+type dbServiceCopySubtreeServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedDbServiceServer) AddNode(context.Context, *AddNodeRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddNode not implemented")
+
+func (x *dbServiceCopySubtreeServer) Send(m *CopyProgress) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedDbServiceServer) Split(context.Context, *SplitRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Split not implemented")
+
+func _DbService_GetHeatmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHeatmapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetHeatmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetHeatmap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetHeatmap(ctx, req.(*GetHeatmapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedDbServiceServer) SetMergeFunction(context.Context, *SetMergeFunctionRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetMergeFunction not implemented")
+
+func _DbService_GetSlowLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetSlowLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetSlowLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetSlowLog(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedDbServiceServer) SetGlobalMergeFunction(context.Context, *SetGlobalMergeFunctionRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetGlobalMergeFunction not implemented")
+
+func _DbService_GetJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/GetJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetJournal(ctx, req.(*GetJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDbServiceServer(s *grpc.Server, srv DbServiceServer) {
-	s.RegisterService(&_DbService_serviceDesc, srv)
+func _DbService_TopConflictingKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopConflictingKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).TopConflictingKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/TopConflictingKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).TopConflictingKeys(ctx, req.(*TopConflictingKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_SetIndexingLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetIndexingLockRequest)
+func _DbService_TopSpaceConsumers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopSpaceConsumersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).SetIndexingLock(ctx, in)
+		return srv.(DbServiceServer).TopSpaceConsumers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/SetIndexingLock",
+		FullMethod: "/db.DbService/TopSpaceConsumers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).SetIndexingLock(ctx, req.(*SetIndexingLockRequest))
+		return srv.(DbServiceServer).TopSpaceConsumers(ctx, req.(*TopSpaceConsumersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_RemoveChildren_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveChildrenRequest)
+func _DbService_HotPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HotPathsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).RemoveChildren(ctx, in)
+		return srv.(DbServiceServer).HotPaths(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/RemoveChildren",
+		FullMethod: "/db.DbService/HotPaths",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).RemoveChildren(ctx, req.(*RemoveChildrenRequest))
+		return srv.(DbServiceServer).HotPaths(ctx, req.(*HotPathsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_AddChild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddChildRequest)
+func _DbService_GetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).AddChild(ctx, in)
+		return srv.(DbServiceServer).GetACL(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/AddChild",
+		FullMethod: "/db.DbService/GetACL",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).AddChild(ctx, req.(*AddChildRequest))
+		return srv.(DbServiceServer).GetACL(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodeRequest)
+func _DbService_CompressChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompressChainRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).GetNode(ctx, in)
+		return srv.(DbServiceServer).CompressChain(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/GetNode",
+		FullMethod: "/db.DbService/CompressChain",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+		return srv.(DbServiceServer).CompressChain(ctx, req.(*CompressChainRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
+func _DbService_RedactValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedactValueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).Get(ctx, in)
+		return srv.(DbServiceServer).RedactValue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/Get",
+		FullMethod: "/db.DbService/RedactValue",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).Get(ctx, req.(*GetRequest))
+		return srv.(DbServiceServer).RedactValue(ctx, req.(*RedactValueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetRequest)
+func _DbService_SetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACL)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).Set(ctx, in)
+		return srv.(DbServiceServer).SetACL(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/Set",
+		FullMethod: "/db.DbService/SetACL",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).Set(ctx, req.(*SetRequest))
+		return srv.(DbServiceServer).SetACL(ctx, req.(*ACL))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_AddNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddNodeRequest)
+func _DbService_CheckIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).AddNode(ctx, in)
+		return srv.(DbServiceServer).CheckIntegrity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/AddNode",
+		FullMethod: "/db.DbService/CheckIntegrity",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).AddNode(ctx, req.(*AddNodeRequest))
+		return srv.(DbServiceServer).CheckIntegrity(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_Split_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SplitRequest)
+func _DbService_DescribeService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).Split(ctx, in)
+		return srv.(DbServiceServer).DescribeService(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/Split",
+		FullMethod: "/db.DbService/DescribeService",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).Split(ctx, req.(*SplitRequest))
+		return srv.(DbServiceServer).DescribeService(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_SetMergeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetMergeFunctionRequest)
+func _DbService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).SetMergeFunction(ctx, in)
+		return srv.(DbServiceServer).GetVersion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/SetMergeFunction",
+		FullMethod: "/db.DbService/GetVersion",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).SetMergeFunction(ctx, req.(*SetMergeFunctionRequest))
+		return srv.(DbServiceServer).GetVersion(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DbService_SetGlobalMergeFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetGlobalMergeFunctionRequest)
+func _DbService_ClaimRanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimRangesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DbServiceServer).SetGlobalMergeFunction(ctx, in)
+		return srv.(DbServiceServer).ClaimRanges(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/db.DbService/SetGlobalMergeFunction",
+		FullMethod: "/db.DbService/ClaimRanges",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DbServiceServer).SetGlobalMergeFunction(ctx, req.(*SetGlobalMergeFunctionRequest))
+		return srv.(DbServiceServer).ClaimRanges(ctx, req.(*ClaimRangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_FollowWAL_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FollowWALRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DbServiceServer).FollowWAL(m, &dbServiceFollowWALServer{stream})
+}
+
+type DbService_FollowWALServer interface {
+	Send(*WALBatch) error
+	grpc.ServerStream
+}
+
+type dbServiceFollowWALServer struct {
+	grpc.ServerStream
+}
+
+func (x *dbServiceFollowWALServer) Send(m *WALBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DbService_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).Promote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/db.DbService/Promote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).Promote(ctx, req.(*PromoteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1631,30 +17640,130 @@ var _DbService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveChildren",
 			Handler:    _DbService_RemoveChildren_Handler,
 		},
+		{
+			MethodName: "ReplaceChildren",
+			Handler:    _DbService_ReplaceChildren_Handler,
+		},
+		{
+			MethodName: "RemoveNode",
+			Handler:    _DbService_RemoveNode_Handler,
+		},
+		{
+			MethodName: "RemoveNodes",
+			Handler:    _DbService_RemoveNodes_Handler,
+		},
 		{
 			MethodName: "AddChild",
 			Handler:    _DbService_AddChild_Handler,
 		},
+		{
+			MethodName: "UnlinkChild",
+			Handler:    _DbService_UnlinkChild_Handler,
+		},
+		{
+			MethodName: "ExpireNode",
+			Handler:    _DbService_ExpireNode_Handler,
+		},
+		{
+			MethodName: "ResolveBucket",
+			Handler:    _DbService_ResolveBucket_Handler,
+		},
 		{
 			MethodName: "GetNode",
 			Handler:    _DbService_GetNode_Handler,
 		},
+		{
+			MethodName: "GetProof",
+			Handler:    _DbService_GetProof_Handler,
+		},
+		{
+			MethodName: "Exists",
+			Handler:    _DbService_Exists_Handler,
+		},
+		{
+			MethodName: "ResolveAlias",
+			Handler:    _DbService_ResolveAlias_Handler,
+		},
+		{
+			MethodName: "AddAliases",
+			Handler:    _DbService_AddAliases_Handler,
+		},
+		{
+			MethodName: "GetKeyNodes",
+			Handler:    _DbService_GetKeyNodes_Handler,
+		},
+		{
+			MethodName: "CreateRoot",
+			Handler:    _DbService_CreateRoot_Handler,
+		},
 		{
 			MethodName: "Get",
 			Handler:    _DbService_Get_Handler,
 		},
+		{
+			MethodName: "Invalidate",
+			Handler:    _DbService_Invalidate_Handler,
+		},
 		{
 			MethodName: "Set",
 			Handler:    _DbService_Set_Handler,
 		},
+		{
+			MethodName: "BatchSet",
+			Handler:    _DbService_BatchSet_Handler,
+		},
+		{
+			MethodName: "Apply",
+			Handler:    _DbService_Apply_Handler,
+		},
+		{
+			MethodName: "GetOrSet",
+			Handler:    _DbService_GetOrSet_Handler,
+		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _DbService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _DbService_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _DbService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "GetHeads",
+			Handler:    _DbService_GetHeads_Handler,
+		},
 		{
 			MethodName: "AddNode",
 			Handler:    _DbService_AddNode_Handler,
 		},
+		{
+			MethodName: "AddNodes",
+			Handler:    _DbService_AddNodes_Handler,
+		},
+		{
+			MethodName: "PrepareReceive",
+			Handler:    _DbService_PrepareReceive_Handler,
+		},
 		{
 			MethodName: "Split",
 			Handler:    _DbService_Split_Handler,
 		},
+		{
+			MethodName: "Decommission",
+			Handler:    _DbService_Decommission_Handler,
+		},
+		{
+			MethodName: "GetDecommissionStatus",
+			Handler:    _DbService_GetDecommissionStatus_Handler,
+		},
+		{
+			MethodName: "TriggerSplit",
+			Handler:    _DbService_TriggerSplit_Handler,
+		},
 		{
 			MethodName: "SetMergeFunction",
 			Handler:    _DbService_SetMergeFunction_Handler,
@@ -1663,7 +17772,195 @@ var _DbService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetGlobalMergeFunction",
 			Handler:    _DbService_SetGlobalMergeFunction_Handler,
 		},
+		{
+			MethodName: "SetScopedMergeFunction",
+			Handler:    _DbService_SetScopedMergeFunction_Handler,
+		},
+		{
+			MethodName: "ResolveMergeFunction",
+			Handler:    _DbService_ResolveMergeFunction_Handler,
+		},
+		{
+			MethodName: "GetMergeHistory",
+			Handler:    _DbService_GetMergeHistory_Handler,
+		},
+		{
+			MethodName: "GetFailedMerges",
+			Handler:    _DbService_GetFailedMerges_Handler,
+		},
+		{
+			MethodName: "RetryMerge",
+			Handler:    _DbService_RetryMerge_Handler,
+		},
+		{
+			MethodName: "ConvergenceStatus",
+			Handler:    _DbService_ConvergenceStatus_Handler,
+		},
+		{
+			MethodName: "GetValidationRules",
+			Handler:    _DbService_GetValidationRules_Handler,
+		},
+		{
+			MethodName: "SetValidationRules",
+			Handler:    _DbService_SetValidationRules_Handler,
+		},
+		{
+			MethodName: "GetMergeRegistrations",
+			Handler:    _DbService_GetMergeRegistrations_Handler,
+		},
+		{
+			MethodName: "SetMode",
+			Handler:    _DbService_SetMode_Handler,
+		},
+		{
+			MethodName: "Compact",
+			Handler:    _DbService_Compact_Handler,
+		},
+		{
+			MethodName: "RunTask",
+			Handler:    _DbService_RunTask_Handler,
+		},
+		{
+			MethodName: "PauseMaintenance",
+			Handler:    _DbService_PauseMaintenance_Handler,
+		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _DbService_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _DbService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _DbService_GetStats_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _DbService_Register_Handler,
+		},
+		{
+			MethodName: "UpdateMembership",
+			Handler:    _DbService_UpdateMembership_Handler,
+		},
+		{
+			MethodName: "ListServers",
+			Handler:    _DbService_ListServers_Handler,
+		},
+		{
+			MethodName: "AllocateServer",
+			Handler:    _DbService_AllocateServer_Handler,
+		},
+		{
+			MethodName: "ReleaseServer",
+			Handler:    _DbService_ReleaseServer_Handler,
+		},
+		{
+			MethodName: "GetMapping",
+			Handler:    _DbService_GetMapping_Handler,
+		},
+		{
+			MethodName: "LocateKeys",
+			Handler:    _DbService_LocateKeys_Handler,
+		},
+		{
+			MethodName: "GetPlacementRules",
+			Handler:    _DbService_GetPlacementRules_Handler,
+		},
+		{
+			MethodName: "SetPlacementRules",
+			Handler:    _DbService_SetPlacementRules_Handler,
+		},
+		{
+			MethodName: "VerifyPlacement",
+			Handler:    _DbService_VerifyPlacement_Handler,
+		},
+		{
+			MethodName: "GetRangeDigest",
+			Handler:    _DbService_GetRangeDigest_Handler,
+		},
+		{
+			MethodName: "GetMerged",
+			Handler:    _DbService_GetMerged_Handler,
+		},
+		{
+			MethodName: "GetHeatmap",
+			Handler:    _DbService_GetHeatmap_Handler,
+		},
+		{
+			MethodName: "GetSlowLog",
+			Handler:    _DbService_GetSlowLog_Handler,
+		},
+		{
+			MethodName: "GetJournal",
+			Handler:    _DbService_GetJournal_Handler,
+		},
+		{
+			MethodName: "TopConflictingKeys",
+			Handler:    _DbService_TopConflictingKeys_Handler,
+		},
+		{
+			MethodName: "TopSpaceConsumers",
+			Handler:    _DbService_TopSpaceConsumers_Handler,
+		},
+		{
+			MethodName: "HotPaths",
+			Handler:    _DbService_HotPaths_Handler,
+		},
+		{
+			MethodName: "GetACL",
+			Handler:    _DbService_GetACL_Handler,
+		},
+		{
+			MethodName: "CompressChain",
+			Handler:    _DbService_CompressChain_Handler,
+		},
+		{
+			MethodName: "RedactValue",
+			Handler:    _DbService_RedactValue_Handler,
+		},
+		{
+			MethodName: "SetACL",
+			Handler:    _DbService_SetACL_Handler,
+		},
+		{
+			MethodName: "CheckIntegrity",
+			Handler:    _DbService_CheckIntegrity_Handler,
+		},
+		{
+			MethodName: "DescribeService",
+			Handler:    _DbService_DescribeService_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _DbService_GetVersion_Handler,
+		},
+		{
+			MethodName: "ClaimRanges",
+			Handler:    _DbService_ClaimRanges_Handler,
+		},
+		{
+			MethodName: "Promote",
+			Handler:    _DbService_Promote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _DbService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopySubtree",
+			Handler:       _DbService_CopySubtree_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FollowWAL",
+			Handler:       _DbService_FollowWAL_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "db.proto",
 }
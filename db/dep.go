@@ -0,0 +1,7 @@
+package db
+
+import "math"
+
+// Dep of a SetRequest chaining the write onto the latest node of the key on its owner.
+// Never used as a location.
+const DepAuto = math.MaxUint64 - 1
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OpenAPI 3 description of the service, generated from db.proto.
+// Each method is a POST of its request to /db.DbService/<Method> with the JSON
+// mapping of protobuf (64-bit integers and bytes are strings), as served by a gateway.
+// Saved in openapi.json by `go test ./db -update`.
+func OpenAPI() ([]byte, error) {
+	schemas := make(map[string]interface{})
+	addSchemas(schemas, File_db_proto.Messages())
+	enums := File_db_proto.Enums()
+	for i := 0; i < enums.Len(); i++ {
+		schemas[string(enums.Get(i).Name())] = enumSchema(enums.Get(i))
+	}
+
+	paths := make(map[string]interface{})
+	services := File_db_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		methods := service.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			method := methods.Get(j)
+			operation := map[string]interface{}{
+				"operationId": string(method.Name()),
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  jsonContent(method.Input()),
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "OK",
+						"content":     jsonContent(method.Output()),
+					},
+				},
+			}
+			if method.IsStreamingServer() {
+				// Sent as a sequence of messages
+				operation["x-server-streaming"] = true
+			}
+			paths["/"+string(service.FullName())+"/"+string(method.Name())] = map[string]interface{}{
+				"post": operation,
+			}
+		}
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "DbService",
+			"version": strconv.Itoa(APIVersion),
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+	// Maps are marshaled with sorted keys, so the output is stable
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func jsonContent(message protoreflect.MessageDescriptor) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": schemaRef(message.FullName()),
+		},
+	}
+}
+
+// Messages and enums are named by their full name without the package
+func schemaName(name protoreflect.FullName) string {
+	return string(name[len(File_db_proto.Package())+1:])
+}
+
+func schemaRef(name protoreflect.FullName) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + schemaName(name)}
+}
+
+func addSchemas(schemas map[string]interface{}, messages protoreflect.MessageDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		message := messages.Get(i)
+		// Entries of maps are inlined
+		if message.IsMapEntry() {
+			continue
+		}
+		properties := make(map[string]interface{})
+		fields := message.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			field := fields.Get(j)
+			properties[field.JSONName()] = fieldSchema(field)
+		}
+		schemas[schemaName(message.FullName())] = map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		addSchemas(schemas, message.Messages())
+		enums := message.Enums()
+		for j := 0; j < enums.Len(); j++ {
+			schemas[schemaName(enums.Get(j).FullName())] = enumSchema(enums.Get(j))
+		}
+	}
+}
+
+func enumSchema(enum protoreflect.EnumDescriptor) map[string]interface{} {
+	var names []string
+	values := enum.Values()
+	for i := 0; i < values.Len(); i++ {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	return map[string]interface{}{"type": "string", "enum": names}
+}
+
+func fieldSchema(field protoreflect.FieldDescriptor) map[string]interface{} {
+	if field.IsMap() {
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": valueSchema(field.MapValue()),
+		}
+	}
+	if field.IsList() {
+		return map[string]interface{}{
+			"type":  "array",
+			"items": valueSchema(field),
+		}
+	}
+	return valueSchema(field)
+}
+
+// Schema of a single value of the field
+func valueSchema(field protoreflect.FieldDescriptor) map[string]interface{} {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return schemaRef(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return schemaRef(field.Enum().FullName())
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "uint32"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// Strings in the JSON mapping, since they do not fit a double
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	default:
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	}
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+// openapi.json is the output of OpenAPI for the current proto
+func TestOpenAPI(t *testing.T) {
+	data, err := OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+	if *update {
+		if err := ioutil.WriteFile("openapi.json", data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	saved, err := ioutil.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(saved, data) {
+		t.Error("openapi.json is out of date (go test ./db -update)")
+	}
+
+	var document struct {
+		Paths      map[string]map[string]interface{}
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := document.Paths["/db.DbService/Get"]["post"]; !ok {
+		t.Error("Get is not described")
+	}
+	// Every method of the service, and every message they use
+	if len(document.Paths) != File_db_proto.Services().Get(0).Methods().Len() {
+		t.Errorf("%d paths for %d methods", len(document.Paths), File_db_proto.Services().Get(0).Methods().Len())
+	}
+	location := document.Components.Schemas["GetRequest"].Properties["Location"]
+	if location["type"] != "string" || location["format"] != "uint64" {
+		t.Errorf("Location of GetRequest is described as %v", location)
+	}
+}
@@ -0,0 +1,13 @@
+package db
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Random RequestId of a SetRequest
+func NewRequestId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
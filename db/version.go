@@ -0,0 +1,50 @@
+package db
+
+// Version of the API of db.proto.
+// Bump it when the meaning of existing messages changes;
+// new methods and fields are announced as features instead.
+const APIVersion = 1
+
+// Oldest APIVersion of clients still served
+const MinAPIVersion = 1
+
+// Optional features of a server, so that clients can degrade gracefully
+// against older servers during rolling upgrades
+const (
+	FeatureBatch          = "batch"
+	FeatureStreaming      = "streaming"
+	FeatureApply          = "apply"
+	FeatureGetOrSet       = "get-or-set"
+	FeatureSnapshots      = "snapshots"
+	FeatureCompression    = "compression"
+	FeatureACL            = "acl"
+	FeatureDecommission   = "decommission"
+	FeatureBatchResults   = "batch-results"
+	FeatureDurability     = "durability"
+	FeatureCounters       = "counters"
+	FeatureDepAuto        = "dep-auto"
+	FeatureProofs         = "proofs"
+	FeaturePrepareReceive = "prepare-receive"
+	FeatureAddNodes       = "add-nodes"
+	FeatureGetMerged      = "get-merged"
+)
+
+// Features supported by this version of the server
+var Features = []string{
+	FeatureBatch,
+	FeatureStreaming,
+	FeatureApply,
+	FeatureGetOrSet,
+	FeatureSnapshots,
+	FeatureCompression,
+	FeatureACL,
+	FeatureDecommission,
+	FeatureBatchResults,
+	FeatureDurability,
+	FeatureCounters,
+	FeatureDepAuto,
+	FeatureProofs,
+	FeaturePrepareReceive,
+	FeatureAddNodes,
+	FeatureGetMerged,
+}
@@ -0,0 +1,226 @@
+package db
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Add the fields and messages of the current proto to testdata
+var update = flag.Bool("update", false, "update golden files")
+
+// Kinds with the same encoding on the wire
+var wireKinds = map[string]string{
+	"string": "bytes",
+	"int32":  "varint", "int64": "varint", "uint32": "varint", "uint64": "varint", "bool": "varint", "enum": "varint",
+}
+
+func compatibleKinds(a, b string) bool {
+	if w, ok := wireKinds[a]; ok {
+		a = w
+	}
+	if w, ok := wireKinds[b]; ok {
+		b = w
+	}
+	return a == b
+}
+
+// Fields of the messages as lines of fields.golden: message, number, name, cardinality and kind
+func fieldLines(messages protoreflect.MessageDescriptors) []string {
+	var lines []string
+	for i := 0; i < messages.Len(); i++ {
+		m := messages.Get(i)
+		fields := m.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			f := fields.Get(j)
+			lines = append(lines, fmt.Sprintf("%s %d %s %s %s", m.FullName(), f.Number(), f.Name(), f.Cardinality(), f.Kind()))
+		}
+		lines = append(lines, fieldLines(m.Messages())...)
+	}
+	return lines
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); len(line) > 0 && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+// Every field ever released keeps its number, name and encoding,
+// or its number is reserved once it is removed
+func TestFieldsCompatible(t *testing.T) {
+	path := filepath.Join("testdata", "fields.golden")
+	golden := readLines(t, path)
+	current := fieldLines(File_db_proto.Messages())
+
+	for _, line := range golden {
+		parts := strings.Fields(line)
+		if len(parts) != 5 {
+			t.Fatalf("Invalid line in %s: %s", path, line)
+		}
+		name, kind := parts[2], parts[4]
+		number, err := strconv.Atoi(parts[1])
+		if err != nil {
+			t.Fatalf("Invalid line in %s: %s", path, line)
+		}
+		found, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(parts[0]))
+		desc, ok := found.(protoreflect.MessageDescriptor)
+		if err != nil || !ok {
+			t.Errorf("Message %s was removed", parts[0])
+			continue
+		}
+		field := desc.Fields().ByNumber(protoreflect.FieldNumber(number))
+		if field == nil {
+			if !desc.ReservedRanges().Has(protoreflect.FieldNumber(number)) {
+				t.Errorf("Field %d (%s) of %s was removed without reserving its number", number, name, parts[0])
+			}
+			continue
+		}
+		if string(field.Name()) != name {
+			t.Errorf("Field %d of %s was renamed from %s to %s", number, parts[0], name, field.Name())
+		}
+		if field.Cardinality().String() != parts[3] || !compatibleKinds(field.Kind().String(), kind) {
+			t.Errorf("Field %s of %s changed from %s %s to %s %s", name, parts[0], parts[3], kind, field.Cardinality(), field.Kind())
+		}
+	}
+
+	if *update {
+		lines := make(map[string]bool)
+		for _, line := range append(golden, current...) {
+			lines[line] = true
+		}
+		var sorted []string
+		for line := range lines {
+			sorted = append(sorted, line)
+		}
+		sort.Strings(sorted)
+		data := "# Fields of db.proto ever released (go test ./db -update adds new ones)\n" + strings.Join(sorted, "\n") + "\n"
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Messages as sent by the first clients and servers of each API feature
+var goldenMessages = map[string]proto.Message{
+	"set_request":  &SetRequest{Key: "k", Value: []byte("v"), Dep: 0x1234567800000001},
+	"set_response": &SetResponse{Location: 0x1234567800000002},
+	"get_request":  &GetRequest{Key: "k", Location: 0x1234567800000002},
+	"get_response": &GetResponse{Value: []byte("v")},
+	"node": &Node{
+		Location: 0x1234567800000002,
+		Dep:      0x1234567800000001,
+		Key:      "k",
+		Value:    []byte("v"),
+		Children: []uint64{3, 4},
+	},
+	"add_child_request": &AddChildRequest{Location: 0x1234567800000001, Child: 0x1234567800000002},
+	"batch_set_request": &BatchSetRequest{
+		Requests: []*SetRequest{{Key: "a", Value: []byte("1"), Dep: 1}, {Key: "b", Value: []byte("2")}},
+		Chain:    true,
+	},
+	"batch_set_response": &BatchSetResponse{Responses: []*SetResponse{{Location: 1}, {Location: 2}}},
+	"version_response": &VersionResponse{
+		ApiVersion:    1,
+		MinApiVersion: 1,
+		Build:         "v1.0.0",
+		GoVersion:     "go1.14",
+		Features:      []string{FeatureBatch, FeatureStreaming},
+	},
+}
+
+// Messages serialized by earlier versions still parse to the same values
+func TestGoldenMessages(t *testing.T) {
+	for name, expected := range goldenMessages {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join("testdata", name+".golden")
+			if *update {
+				if _, err := os.Stat(path); os.IsNotExist(err) {
+					data, err := proto.Marshal(expected)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := ioutil.WriteFile(path, data, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed := expected.ProtoReflect().New().Interface()
+			if err := proto.Unmarshal(data, parsed); err != nil {
+				t.Fatal(err)
+			}
+			if unknown := parsed.ProtoReflect().GetUnknown(); len(unknown) > 0 {
+				t.Errorf("Fields of %s are unknown: %x", name, unknown)
+			}
+			if !proto.Equal(parsed, expected) {
+				t.Errorf("Parsed %v, expected %v", parsed, expected)
+			}
+		})
+	}
+}
+
+// Every feature constant is announced by the server
+func TestFeaturesRegistered(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "version.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	announced := make(map[string]bool)
+	for _, feature := range Features {
+		if announced[feature] {
+			t.Errorf("Feature %s is announced twice", feature)
+		}
+		announced[feature] = true
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for i, name := range spec.Names {
+			if !strings.HasPrefix(name.Name, "Feature") || i >= len(spec.Values) {
+				continue
+			}
+			lit, ok := spec.Values[i].(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			if value, _ := strconv.Unquote(lit.Value); !announced[value] {
+				t.Errorf("%s is not in Features", name.Name)
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc"
+)
+
+// Keys whose placement hashes are compared across servers
+var sampleKeys = []string{"a", "user:1", "user:1/profile", "counter", "order-42", "Z"}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: dbcluster <command> [args]
+
+Commands:
+  init -nodes a:9000,b:9000,c:9000 [-threshold 10000] [-secret-file path] -out dir
+                   write a server.json per node (dir/<host>_<port>.json) with the same
+                   servers, seed and initial server
+  check -configs dir [-secret-file path] [-timeout 5s]
+                   dial the node of each config and check that they agree on membership,
+                   mapping, placement hashes and API version (exits 1 on any discrepancy)
+`)
+	flag.PrintDefaults()
+}
+
+// Fields of server.json written by init (see the Server struct of the server)
+type Config struct {
+	Servers           []string `json:"servers"`
+	AvailableServers  []string `json:"availableServers"`
+	Self              string   `json:"self"`
+	Initial           string   `json:"initial"`
+	Seed              string   `json:"seed"`
+	Threshold         int      `json:"threshold"`
+	ClusterSecretFile string   `json:"clusterSecretFile,omitempty"`
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "init":
+		flags := flag.NewFlagSet("init", flag.ExitOnError)
+		nodes := flags.String("nodes", "", "comma-separated addresses of the nodes (the first one is the seed and initial server)")
+		threshold := flags.Int("threshold", 10000, "nodes per server before a split")
+		secretFile := flags.String("secret-file", "", "path of the cluster secret on the nodes (referenced, not read)")
+		out := flags.String("out", "", "directory of the generated configs")
+		flags.Parse(args[1:])
+
+		if len(*nodes) == 0 || len(*out) == 0 {
+			usage()
+			os.Exit(2)
+		}
+		if err := initCluster(splitList(*nodes), *threshold, *secretFile, *out); err != nil {
+			log.Fatalln(err)
+		}
+
+	case "check":
+		flags := flag.NewFlagSet("check", flag.ExitOnError)
+		configs := flags.String("configs", "", "directory of the configs written by init")
+		secretFile := flags.String("secret-file", "", "file with the cluster secret")
+		timeout := flags.Duration("timeout", 5*time.Second, "timeout of the requests to each node")
+		flags.Parse(args[1:])
+
+		if len(*configs) == 0 {
+			usage()
+			os.Exit(2)
+		}
+		ok, err := checkCluster(*configs, *secretFile, *timeout)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// File name of the config of a node
+func configName(address string) string {
+	return strings.NewReplacer(":", "_", "/", "_").Replace(address) + ".json"
+}
+
+// Write consistent configs for the nodes.
+// The first node is the seed and owns the whole hash range at first;
+// the others are available for splits.
+func initCluster(nodes []string, threshold int, secretFile string, out string) error {
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		if seen[node] {
+			return fmt.Errorf("Node %s is listed twice", node)
+		}
+		seen[node] = true
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("No nodes given")
+	}
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		config := Config{
+			Servers:           nodes,
+			AvailableServers:  nodes[1:],
+			Self:              node,
+			Initial:           nodes[0],
+			Seed:              nodes[0],
+			Threshold:         threshold,
+			ClusterSecretFile: secretFile,
+		}
+		data, _ := json.MarshalIndent(&config, "", "\t")
+		path := filepath.Join(out, configName(node))
+		if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("%s\t%s\n", node, path)
+	}
+	fmt.Printf("Copy each file to server.json in the working directory of its node and start the seed (%s) first\n", nodes[0])
+	return nil
+}
+
+func loadConfigs(dir string) ([]*Config, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("No configs in %s", dir)
+	}
+	var configs []*Config
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var config Config
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if len(config.Self) == 0 {
+			return nil, fmt.Errorf("%s: self is not set", path)
+		}
+		configs = append(configs, &config)
+	}
+	return configs, nil
+}
+
+// What a node reports, summarized to compare nodes
+type nodeState struct {
+	address string
+	err     error
+	self    string
+	servers string
+	epoch   uint64
+	ranges  string
+	hashes  string
+	api     string
+}
+
+// Columns compared across nodes (the value of most nodes is the reference)
+var columns = []struct {
+	name  string
+	value func(*nodeState) string
+}{
+	{"SERVERS", func(n *nodeState) string { return n.servers }},
+	{"EPOCH", func(n *nodeState) string { return fmt.Sprint(n.epoch) }},
+	{"RANGES", func(n *nodeState) string { return n.ranges }},
+	{"HASHES", func(n *nodeState) string { return n.hashes }},
+	{"API", func(n *nodeState) string { return n.api }},
+}
+
+func dial(address string, secretFile string) (*grpc.ClientConn, error) {
+	interceptors := []grpc.UnaryClientInterceptor{dberrors.UnaryClientInterceptor}
+	if len(secretFile) > 0 {
+		data, err := ioutil.ReadFile(secretFile)
+		if err != nil {
+			return nil, err
+		}
+		secret := []byte(strings.TrimSpace(string(data)))
+		interceptors = append(interceptors, auth.Signer{Secret: secret}.UnaryClientInterceptor)
+	}
+	return grpc.Dial(address, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
+}
+
+func queryNode(address string, secretFile string, timeout time.Duration) *nodeState {
+	state := &nodeState{address: address}
+	conn, err := dial(address, secretFile)
+	if err != nil {
+		state.err = err
+		return state
+	}
+	defer conn.Close()
+	client := db.NewDbServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stats, err := client.GetStats(ctx, &db.Empty{})
+	if err != nil {
+		state.err = err
+		return state
+	}
+	state.self = stats.Self
+	version, err := client.GetVersion(ctx, &db.Empty{})
+	if err != nil {
+		state.err = err
+		return state
+	}
+	state.api = fmt.Sprintf("v%d/p%d", version.ApiVersion, version.ProtocolLevel)
+	membership, err := client.ListServers(ctx, &db.Empty{})
+	if err != nil {
+		state.err = err
+		return state
+	}
+	servers := append([]string(nil), membership.Servers...)
+	sort.Strings(servers)
+	state.servers = strings.Join(servers, ",")
+	mapping, err := client.GetMapping(ctx, &db.Empty{})
+	if err != nil {
+		state.err = err
+		return state
+	}
+	state.epoch = mapping.Epoch
+	var ranges []string
+	for _, r := range mapping.Ranges {
+		ranges = append(ranges, fmt.Sprintf("[%08x,%08x]%s", r.Left, r.Right, r.Server))
+	}
+	state.ranges = strings.Join(ranges, " ")
+	owners, err := client.LocateKeys(ctx, &db.LocateKeysRequest{Keys: sampleKeys})
+	if err != nil {
+		state.err = err
+		return state
+	}
+	var hashes []string
+	for _, owner := range owners.Owners {
+		hashes = append(hashes, fmt.Sprintf("%08x", owner.Hash))
+	}
+	state.hashes = strings.Join(hashes, ",")
+	return state
+}
+
+// Most common value (ties go to the first one to reach the count)
+func majority(values []string) string {
+	counts := make(map[string]int)
+	best, bestCount := "", 0
+	for _, value := range values {
+		counts[value] += 1
+		if counts[value] > bestCount {
+			best, bestCount = value, counts[value]
+		}
+	}
+	return best
+}
+
+// Abbreviate long values in the table
+func cell(value string) string {
+	if len(value) > 24 {
+		return value[:21] + "..."
+	}
+	return value
+}
+
+// Dial the node of each config and report discrepancies.
+// Returns whether the cluster is consistent.
+func checkCluster(dir string, secretFile string, timeout time.Duration) (bool, error) {
+	configs, err := loadConfigs(dir)
+	if err != nil {
+		return false, err
+	}
+
+	var problems []string
+	// The configs themselves must agree
+	var configServers, seeds, initials []string
+	for _, config := range configs {
+		servers := append([]string(nil), config.Servers...)
+		sort.Strings(servers)
+		configServers = append(configServers, strings.Join(servers, ","))
+		seeds = append(seeds, config.Seed)
+		initials = append(initials, config.Initial)
+	}
+	for i, config := range configs {
+		if expected := majority(configServers); configServers[i] != expected {
+			problems = append(problems, fmt.Sprintf("%s: config lists servers %s, other configs %s", config.Self, configServers[i], expected))
+		}
+		if expected := majority(seeds); seeds[i] != expected {
+			problems = append(problems, fmt.Sprintf("%s: config has seed %q, other configs %q", config.Self, seeds[i], expected))
+		}
+		if expected := majority(initials); initials[i] != expected {
+			problems = append(problems, fmt.Sprintf("%s: config has initial server %q, other configs %q", config.Self, initials[i], expected))
+		}
+	}
+
+	var states []*nodeState
+	for _, config := range configs {
+		state := queryNode(config.Self, secretFile, timeout)
+		if state.err == nil && state.self != config.Self {
+			problems = append(problems, fmt.Sprintf("%s: answered as %s", config.Self, state.self))
+		}
+		states = append(states, state)
+	}
+
+	var reachable []*nodeState
+	for _, state := range states {
+		if state.err != nil {
+			problems = append(problems, fmt.Sprintf("%s: unreachable: %v", state.address, state.err))
+			continue
+		}
+		reachable = append(reachable, state)
+	}
+	expected := make([]string, len(columns))
+	for i, column := range columns {
+		var values []string
+		for _, state := range reachable {
+			values = append(values, column.value(state))
+		}
+		expected[i] = majority(values)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "NODE\tREACHABLE")
+	for _, column := range columns {
+		fmt.Fprintf(w, "\t%s", column.name)
+	}
+	fmt.Fprintln(w)
+	for _, state := range states {
+		if state.err != nil {
+			fmt.Fprintf(w, "%s\tno%s\n", state.address, strings.Repeat("\t-", len(columns)))
+			continue
+		}
+		fmt.Fprintf(w, "%s\tyes", state.address)
+		for i, column := range columns {
+			value := column.value(state)
+			if value == expected[i] {
+				fmt.Fprint(w, "\tok")
+				continue
+			}
+			fmt.Fprintf(w, "\t!= %s", cell(value))
+			problems = append(problems, fmt.Sprintf("%s: %s is %q, other nodes %q", state.address, strings.ToLower(column.name), value, expected[i]))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	if len(problems) == 0 {
+		fmt.Printf("\n%d nodes are consistent\n", len(states))
+		return true, nil
+	}
+	fmt.Printf("\n%d discrepancies:\n", len(problems))
+	for _, problem := range problems {
+		fmt.Printf("  %s\n", problem)
+	}
+	return false, nil
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	dbclient "github.com/DCsunset/openwhisk-grpc/client"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+)
+
+// Records read back after the import
+const importSamples = 100
+
+// Progress of an import, saved after every batch
+type importCheckpoint struct {
+	File   string
+	Format string
+	Chain  bool
+	Root   uint64
+	// Last node of the chain
+	Head uint64
+	// Records written (skipped when resuming)
+	Records int64
+	Samples []importSample
+}
+
+type importSample struct {
+	Key      string
+	Value    []byte
+	Location uint64
+}
+
+func (c *importCheckpoint) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Keep a uniform sample of the written records
+func (c *importCheckpoint) sample(request *db.SetRequest, location uint64, index int64) {
+	s := importSample{Key: request.Key, Value: request.Value, Location: location}
+	if len(c.Samples) < importSamples {
+		c.Samples = append(c.Samples, s)
+	} else if j := rand.Int63n(index + 1); j < importSamples {
+		c.Samples[j] = s
+	}
+}
+
+// Reads records as fields by name
+type recordReader interface {
+	Next() (map[string]interface{}, error)
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+}
+
+func (r *jsonlReader) Next() (map[string]interface{}, error) {
+	for r.scanner.Scan() {
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			return nil, err
+		}
+		return record, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (r *csvReader) Next() (map[string]interface{}, error) {
+	if r.header == nil {
+		header, err := r.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.header = header
+	}
+	row, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	record := make(map[string]interface{})
+	for i, name := range r.header {
+		if i < len(row) {
+			record[name] = row[i]
+		}
+	}
+	return record, nil
+}
+
+// Look up a dotted path of fields
+func field(record map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = record
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// Strings are taken as they are, other values as JSON
+func fieldBytes(value interface{}) ([]byte, error) {
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(value)
+}
+
+// Attempts of writing the records of a batch
+const importAttempts = 5
+
+// Number of requests of the batch not written
+func failed(resp *db.BatchSetResponse) int {
+	count := 0
+	for _, result := range resp.Results {
+		if result.GetStatus() != nil {
+			count += 1
+		}
+	}
+	return count
+}
+
+func retryable(resp *db.BatchSetResponse) bool {
+	for _, result := range resp.Results {
+		if dbclient.Retryable(result) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstFailure(resp *db.BatchSetResponse) string {
+	for _, result := range resp.Results {
+		if entry := result.GetStatus(); entry != nil {
+			return fmt.Sprintf("%v: %s", codes.Code(entry.Code), entry.Message)
+		}
+	}
+	return ""
+}
+
+func importFile(ctx context.Context, client db.DbServiceClient, args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	file := flags.String("file", "", "file to import")
+	format := flags.String("format", "jsonl", "jsonl, csv or activation (JSON lines of OpenWhisk activations)")
+	keyField := flags.String("key-field", "key", "field of the key (dotted for nested fields)")
+	valueField := flags.String("value-field", "value", "field of the value (dotted for nested fields)")
+	rootFlag := flags.String("root", "", "location of the root in hex (a new root if empty)")
+	batchSize := flags.Int("batch", 1000, "records per BatchSet call")
+	maxBytes := flags.Int("max-bytes", 3<<20, "max size of the values in a call (below maxRecvMsgSizeMB of the server)")
+	chain := flags.Bool("chain", false, "write records as a linear chain instead of children of the root")
+	checkpointPath := flags.String("checkpoint", "", "file to resume from (file.import if empty)")
+	flags.Parse(args)
+	if len(*file) == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if len(*checkpointPath) == 0 {
+		*checkpointPath = *file + ".import"
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer input.Close()
+	var reader recordReader
+	switch *format {
+	case "jsonl":
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(nil, 64<<20)
+		reader = &jsonlReader{scanner: scanner}
+	case "activation":
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(nil, 64<<20)
+		reader = &jsonlReader{scanner: scanner}
+		*keyField = "response.result." + *keyField
+		*valueField = "response.result." + *valueField
+	case "csv":
+		reader = &csvReader{reader: csv.NewReader(input)}
+	default:
+		log.Fatalf("Invalid format %s", *format)
+	}
+
+	checkpoint := &importCheckpoint{File: *file, Format: *format, Chain: *chain}
+	if data, err := ioutil.ReadFile(*checkpointPath); err == nil {
+		saved := &importCheckpoint{}
+		if err := json.Unmarshal(data, saved); err != nil {
+			log.Fatalf("Invalid checkpoint %s: %v", *checkpointPath, err)
+		}
+		if saved.File != *file || saved.Format != *format || saved.Chain != *chain {
+			log.Fatalf("Checkpoint %s belongs to another import", *checkpointPath)
+		}
+		checkpoint = saved
+		fmt.Fprintf(os.Stderr, "Resuming after %d records\n", checkpoint.Records)
+	} else if !os.IsNotExist(err) {
+		log.Fatalln(err)
+	}
+
+	if checkpoint.Root == 0 {
+		if len(*rootFlag) > 0 {
+			if _, err := fmt.Sscanf(*rootFlag, "%x", &checkpoint.Root); err != nil {
+				log.Fatalf("Invalid root %s", *rootFlag)
+			}
+		} else {
+			resp, err := client.CreateRoot(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			checkpoint.Root = resp.Location
+		}
+		checkpoint.Head = checkpoint.Root
+		if err := checkpoint.save(*checkpointPath); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	writer := dbclient.New(client)
+	start := time.Now()
+	var read, written int64
+	batch := &db.BatchSetRequest{Chain: *chain}
+	size := 0
+	flush := func() {
+		if len(batch.Requests) == 0 {
+			return
+		}
+		dep := checkpoint.Root
+		if *chain {
+			dep = checkpoint.Head
+		}
+		for _, request := range batch.Requests {
+			request.Dep = dep
+		}
+		resp, err := writer.BatchSet(ctx, batch)
+		// Records whose server is down are sent again until it is back
+		for attempt := 1; err == nil && attempt < importAttempts && retryable(resp); attempt++ {
+			time.Sleep(time.Duration(attempt) * time.Second)
+			resp, err = writer.RetryFailed(ctx, batch, resp)
+		}
+		if err == nil && failed(resp) > 0 {
+			err = fmt.Errorf("%d records of the batch failed, e.g. %s", failed(resp), firstFailure(resp))
+		}
+		if err != nil {
+			log.Fatalf("Import stopped after %d records (run again to resume): %v", checkpoint.Records, err)
+		}
+		for i, request := range batch.Requests {
+			checkpoint.sample(request, resp.Results[i].GetLocation(), checkpoint.Records+int64(i))
+		}
+		checkpoint.Records += int64(len(batch.Requests))
+		checkpoint.Head = resp.Results[len(resp.Results)-1].GetLocation()
+		if err := checkpoint.save(*checkpointPath); err != nil {
+			log.Fatalln(err)
+		}
+		written += int64(len(batch.Requests))
+		fmt.Fprintf(os.Stderr, "\r%d records (%.0f/s)", checkpoint.Records, float64(written)/time.Since(start).Seconds())
+		batch = &db.BatchSetRequest{Chain: *chain}
+		size = 0
+	}
+
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("Record %d: %v", read+1, err)
+		}
+		read++
+		if read <= checkpoint.Records {
+			continue
+		}
+		key, ok := field(record, *keyField)
+		if !ok {
+			log.Fatalf("Record %d has no field %s", read, *keyField)
+		}
+		value, ok := field(record, *valueField)
+		if !ok {
+			log.Fatalf("Record %d has no field %s", read, *valueField)
+		}
+		data, err := fieldBytes(value)
+		if err != nil {
+			log.Fatalf("Record %d: %v", read, err)
+		}
+		if size+len(data) > *maxBytes {
+			flush()
+		}
+		batch.Requests = append(batch.Requests, &db.SetRequest{
+			Key:   fmt.Sprint(key),
+			Value: data,
+		})
+		size += len(data)
+		if len(batch.Requests) >= *batchSize {
+			flush()
+		}
+	}
+	flush()
+	fmt.Fprintf(os.Stderr, "\nImported %d records under root %x (head %x)\n", checkpoint.Records, checkpoint.Root, checkpoint.Head)
+
+	// Read a sample back
+	failed := 0
+	for _, sample := range checkpoint.Samples {
+		resp, err := client.Get(ctx, &db.GetRequest{Key: sample.Key, Location: sample.Location})
+		if err != nil {
+			fmt.Printf("%s at %x: %v\n", sample.Key, sample.Location, err)
+			failed++
+		} else if !bytes.Equal(resp.Value, sample.Value) {
+			fmt.Printf("%s at %x: value differs\n", sample.Key, sample.Location)
+			failed++
+		}
+	}
+	fmt.Printf("Verified %d sampled records: %d failed\n", len(checkpoint.Samples), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,972 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: dbctl [-server address] [-secret-file path] [-token token] <command> [args]
+
+Commands:
+  version                         show the API version, build and features of the server
+  status                          show a summary of the server and warnings needing an operator
+  stats [-space] [-n 10]          show server statistics (or the largest values and key prefixes)
+  servers                         show cluster membership
+  mode <normal|readonly|draining> change server mode
+  split [-dry-run] [-target address] [-mid hash]
+                                  split the range of the server
+  decommission <address> [-target address|auto] [-status]
+                                  hand all ranges of the server to a peer before removing it
+  promote [-timeout 30s]          promote the standby to take over the ranges of its primary
+  config [reload]                 show the effective config or reload server.json
+  compact                         remove expired nodes and unused blobs
+  maintenance [run task|pause|resume]
+                                  show background tasks, run one now or pause scheduled runs
+  check                           find nodes at invalid locations or on the wrong server
+  merge-fn list                   show merge functions registered on the server
+  merge-fn failed                 show dead-lettered merges of the server
+  merge-fn retry [parent]         retry a dead-lettered merge (all if omitted)
+  merge-fn set [-key|-prefix|-range] [-no-validate] name
+                                  register a merge function for a key, prefix or hash range
+  merge-fn resolve [-key] [-location]      show which merge function fires for a parent and why
+  converged [-root location] [-wait 30s]
+                                  show pending merges of all servers (waiting until they are done)
+  events [-follow] [-replay n] [-type t,...]
+                                  show recent events of the server
+  slowlog                         show recent slow requests by phase
+  journal [-key k] [-location l] [-since 10m] [-n 100] [-json]
+                                  show recent mutating requests of the server (failed ones included)
+  heatmap [-rows 64] [-clear]     show requests by key hash with the ranges of the servers
+  acl [get|set file]              show or replace the ACL of all servers
+  validation [get|set file]       show or replace the value validation rules of all servers
+  copy -from location -to location [-move] [-id id]
+                                  copy a subtree under another dep (resume with the id printed)
+  compress location               collapse the linear run of a key ending at the location
+  redact -location location -reason text [-operator name] [-value v|-value-file path]
+                                  overwrite the value of a node in place (empty by default)
+  conflicts top [-n 10]           show keys creating the most siblings on all servers
+  suggest [-n 20] [-min-hit 0.8]  suggest cacheable keys and prefixes from the most read keys
+  snapshot [list|create name|delete name]
+                                  manage snapshots of the branch heads
+  whoowns <key>...                show the servers owning the keys
+  import -file path [-format jsonl|csv|activation] [-key-field k] [-value-field v]
+         [-root location] [-batch 1000] [-chain] [-checkpoint path]
+                                  bulk load records with BatchSet (resumable)
+  placement list                  show placement rules
+  placement set <prefix[=delimiter]>...
+                                  replace placement rules (no args to clear)
+  placement verify                count nodes not placed by the current rules
+  placement migrate               move them now (also done when the rules change)
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	address := flag.String("server", "localhost:9000", "address of the db server")
+	secretFile := flag.String("secret-file", "", "file with the cluster secret (for admin commands)")
+	token := flag.String("token", "", "bearer token identifying the caller to the ACL")
+	flag.Usage = usage
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	interceptors := []grpc.UnaryClientInterceptor{dberrors.UnaryClientInterceptor}
+	var streamInterceptors []grpc.StreamClientInterceptor
+	if len(*secretFile) > 0 {
+		data, err := ioutil.ReadFile(*secretFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		secret := []byte(strings.TrimSpace(string(data)))
+		interceptors = append(interceptors, auth.Signer{Secret: secret}.UnaryClientInterceptor)
+		streamInterceptors = append(streamInterceptors, auth.Signer{Secret: secret}.StreamClientInterceptor)
+	}
+	if len(*token) > 0 {
+		interceptors = append(interceptors, auth.Token(*token).UnaryClientInterceptor)
+	}
+	conn, err := grpc.Dial(*address, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...), grpc.WithChainStreamInterceptor(streamInterceptors...))
+	if err != nil {
+		log.Fatalf("Cannot connect: %v", err)
+	}
+	defer conn.Close()
+
+	client := db.NewDbServiceClient(conn)
+	ctx := context.Background()
+
+	switch args[0] {
+	case "stats":
+		flags := flag.NewFlagSet("stats", flag.ExitOnError)
+		space := flags.Bool("space", false, "show the largest values and key prefixes")
+		n := flags.Int("n", 10, "number of values and prefixes")
+		flags.Parse(args[1:])
+
+		if *space {
+			resp, err := client.TopSpaceConsumers(ctx, &db.TopSpaceConsumersRequest{Limit: int32(*n)})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("%d bytes of values\n\nPREFIX\tBYTES\tNODES\n", resp.ValueBytes)
+			for _, prefix := range resp.Prefixes {
+				fmt.Printf("%q\t%d\t%d\n", prefix.Prefix, prefix.Bytes, prefix.Nodes)
+			}
+			fmt.Printf("\nKEY\tLOCATION\tBYTES\n")
+			for _, value := range resp.Values {
+				fmt.Printf("%s\t%x\t%d\n", value.Key, value.Location, value.Bytes)
+			}
+			break
+		}
+		stats, err := client.GetStats(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(stats)
+
+	case "status":
+		stats, err := client.GetStats(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if stats.ExpansionNeeded {
+			fmt.Printf("!!! WARNING: EXPANSION NEEDED: %s\n", stats.ExpansionReason)
+			fmt.Printf("!!! Add servers before the next split (see lastResortSplit in server.json)\n\n")
+		}
+		if stats.MemoryPressure {
+			fmt.Printf("!!! WARNING: memory pressure, writes are rejected\n\n")
+		}
+		fmt.Printf("Server:               %s\n", stats.Self)
+		fmt.Printf("Mode:                 %s\n", stats.Mode)
+		fmt.Printf("Nodes:                %d\n", stats.Nodes)
+		fmt.Printf("Available servers:    %d %v\n", len(stats.AvailableServers), stats.AvailableServers)
+		if governance := stats.SplitGovernance; governance != nil && governance.CapacityLow {
+			fmt.Printf("Capacity low:         splits are spaced out (%s)\n", governance.DeferReason)
+		}
+		fmt.Printf("Dead-lettered merges: %d\n", stats.DeadLetteredMerges)
+
+	case "version":
+		version, err := client.GetVersion(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(version)
+
+	case "servers":
+		membership, err := client.ListServers(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(membership)
+
+	case "mode":
+		if len(args) != 2 {
+			usage()
+			os.Exit(2)
+		}
+		mode, ok := db.Mode_value[strings.ToUpper(args[1])]
+		if !ok {
+			log.Fatalf("Invalid mode %s", args[1])
+		}
+		_, err := client.SetMode(ctx, &db.SetModeRequest{
+			Mode: db.Mode(mode),
+		})
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+	case "split":
+		flags := flag.NewFlagSet("split", flag.ExitOnError)
+		dryRun := flags.Bool("dry-run", false, "only show the plan")
+		target := flags.String("target", "", "target server (random if empty)")
+		mid := flags.String("mid", "", "midpoint of the range in hex")
+		flags.Parse(args[1:])
+
+		request := &db.TriggerSplitRequest{
+			Target: *target,
+			DryRun: *dryRun,
+		}
+		if len(*mid) > 0 {
+			value, err := strconv.ParseUint(*mid, 16, 32)
+			if err != nil {
+				log.Fatalf("Invalid mid %s: %v", *mid, err)
+			}
+			request.HasMid = true
+			request.Mid = uint32(value)
+		}
+		plan, err := client.TriggerSplit(ctx, request)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(plan)
+
+	case "decommission":
+		if len(args) < 2 {
+			usage()
+			os.Exit(2)
+		}
+		flags := flag.NewFlagSet("decommission", flag.ExitOnError)
+		target := flags.String("target", "auto", "peer absorbing the ranges (auto for the least loaded)")
+		statusOnly := flags.Bool("status", false, "only show the progress")
+		flags.Parse(args[2:])
+		if *target == "auto" {
+			*target = ""
+		}
+
+		// Progress is kept by the decommissioned server
+		conn, err := grpc.Dial(args[1], grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
+		if err != nil {
+			log.Fatalf("Cannot connect: %v", err)
+		}
+		defer conn.Close()
+		server := db.NewDbServiceClient(conn)
+		if !*statusOnly {
+			if _, err := server.Decommission(ctx, &db.DecommissionRequest{Target: *target}); err != nil {
+				log.Fatalln(err)
+			}
+		}
+		for {
+			status, err := server.GetDecommissionStatus(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("%s to %s: %d/%d nodes, %d/%d bytes, %s left\n", status.Phase, status.Target,
+				status.NodesMoved, status.TotalNodes, status.BytesMoved, status.TotalBytes,
+				time.Duration(status.EstimatedMillis)*time.Millisecond)
+			if *statusOnly || status.Phase == "done" || status.Phase == "failed" || status.Phase == "" {
+				if len(status.Error) > 0 {
+					log.Fatalln(status.Error)
+				}
+				break
+			}
+			time.Sleep(time.Second)
+		}
+
+	case "promote":
+		flags := flag.NewFlagSet("promote", flag.ExitOnError)
+		timeout := flags.Duration("timeout", 30*time.Second, "max time to catch up with a reachable primary")
+		flags.Parse(args[1:])
+
+		resp, err := client.Promote(ctx, &db.PromoteRequest{TimeoutMillis: timeout.Milliseconds()})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if resp.PrimaryDemoted {
+			fmt.Printf("%s demoted to readonly\n", resp.Primary)
+		} else {
+			fmt.Printf("%s unreachable: records not applied are lost (%d bytes, %v behind)\n", resp.Primary,
+				resp.LagBytes, time.Duration(resp.LagMillis)*time.Millisecond)
+		}
+		for _, r := range resp.Ranges {
+			fmt.Printf("[%x, %x] taken over\n", r.Left, r.Right)
+		}
+
+	case "events":
+		flags := flag.NewFlagSet("events", flag.ExitOnError)
+		follow := flags.Bool("follow", false, "keep printing new events")
+		replay := flags.Int("replay", 256, "number of past events")
+		types := flags.String("type", "", "comma-separated event types (all if empty)")
+		flags.Parse(args[1:])
+
+		request := &db.WatchEventsRequest{
+			Replay:   int32(*replay),
+			NoFollow: !*follow,
+		}
+		if len(*types) > 0 {
+			request.Types = strings.Split(*types, ",")
+		}
+		stream, err := client.WatchEvents(ctx, request)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for {
+			event, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", time.Unix(0, event.Time).Format(time.RFC3339), event.Server, event.Type, event.Message)
+		}
+
+	case "copy":
+		flags := flag.NewFlagSet("copy", flag.ExitOnError)
+		from := flags.String("from", "", "root of the subtree")
+		to := flags.String("to", "", "dep of the copy")
+		move := flags.Bool("move", false, "expire the source once copied")
+		id := flags.String("id", "", "id of an interrupted copy to resume")
+		flags.Parse(args[1:])
+
+		source, err := strconv.ParseUint(*from, 16, 64)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		destination, err := strconv.ParseUint(*to, 16, 64)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		stream, err := client.CopySubtree(ctx, &db.CopySubtreeRequest{
+			Source:      source,
+			Destination: destination,
+			Move:        *move,
+			Id:          *id,
+		})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for {
+			progress, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalln(err)
+			}
+			for _, node := range progress.Nodes {
+				fmt.Printf("%x\t%x\n", node.Source, node.Copy)
+			}
+			fmt.Fprintf(os.Stderr, "Copy %s: %d nodes (%d already copied)\n", progress.Id, progress.Copied, progress.Skipped)
+			if progress.Done {
+				fmt.Fprintf(os.Stderr, "Copy %s done\n", progress.Id)
+			}
+		}
+
+	case "heatmap":
+		flags := flag.NewFlagSet("heatmap", flag.ExitOnError)
+		rows := flags.Int("rows", 64, "rows to group the buckets in")
+		clear := flags.Bool("clear", false, "reset the counters after reading them")
+		flags.Parse(args[1:])
+
+		heatmap, err := client.GetHeatmap(ctx, &db.GetHeatmapRequest{Clear: *clear})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		printHeatmap(heatmap, *rows)
+
+	case "conflicts":
+		flags := flag.NewFlagSet("conflicts", flag.ExitOnError)
+		n := flags.Int("n", 10, "number of keys")
+		if len(args) < 2 || args[1] != "top" {
+			usage()
+			os.Exit(2)
+		}
+		flags.Parse(args[2:])
+
+		membership, err := client.ListServers(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		servers := membership.Servers
+		if len(servers) == 0 {
+			servers = []string{*address}
+		}
+		// Keys are written on their owners
+		var keys []*db.ConflictingKey
+		var window int64
+		for _, server := range servers {
+			conn, err := grpc.Dial(server, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
+			if err != nil {
+				log.Fatalln(err)
+			}
+			resp, err := db.NewDbServiceClient(conn).TopConflictingKeys(ctx, &db.TopConflictingKeysRequest{Limit: int32(*n)})
+			conn.Close()
+			if err != nil {
+				log.Printf("Fail to get conflicts of %s: %v", server, err)
+				continue
+			}
+			keys = append(keys, resp.Keys...)
+			window = resp.WindowSeconds
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].Count > keys[j].Count })
+		if len(keys) > *n {
+			keys = keys[:*n]
+		}
+		fmt.Printf("Conflicts in the last %ds:\n", window)
+		for _, key := range keys {
+			merge := key.MergeFunction
+			if len(merge) == 0 {
+				merge = "(no merge function)"
+			}
+			fmt.Printf("%s\t%d\t%x\t%s\n", key.Key, key.Count, key.Parent, merge)
+		}
+
+	case "suggest":
+		flags := flag.NewFlagSet("suggest", flag.ExitOnError)
+		n := flags.Int("n", 20, "number of keys read from each server")
+		minHit := flags.Float64("min-hit", 0.8, "min expected hit rate of a suggestion")
+		flags.Parse(args[1:])
+
+		membership, err := client.ListServers(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		servers := membership.Servers
+		if len(servers) == 0 {
+			servers = []string{*address}
+		}
+		// Reads are counted where the walk finds the key
+		keys := make(map[string]*db.HotKey)
+		for _, server := range servers {
+			conn, err := grpc.Dial(server, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
+			if err != nil {
+				log.Fatalln(err)
+			}
+			resp, err := db.NewDbServiceClient(conn).HotPaths(ctx, &db.HotPathsRequest{Limit: int32(*n)})
+			conn.Close()
+			if err != nil {
+				log.Printf("Fail to get hot paths of %s: %v", server, err)
+				continue
+			}
+			for _, key := range resp.Keys {
+				if k, ok := keys[key.Key]; ok {
+					k.Reads += key.Reads
+					k.Writes += key.Writes
+				} else {
+					keys[key.Key] = key
+				}
+			}
+		}
+		printSuggestions(keys, *minHit)
+
+	case "compress":
+		if len(args) < 2 {
+			usage()
+			os.Exit(2)
+		}
+		location, err := strconv.ParseUint(args[1], 16, 64)
+		if err != nil {
+			log.Fatalf("Invalid location %s: %v", args[1], err)
+		}
+		resp, err := client.CompressChain(ctx, &db.CompressChainRequest{Location: location})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Removed %d nodes\n", resp.Removed)
+
+	case "redact":
+		flags := flag.NewFlagSet("redact", flag.ExitOnError)
+		at := flags.String("location", "", "location of the node")
+		reason := flags.String("reason", "", "why the value is redacted (recorded in the audit event)")
+		operator := flags.String("operator", os.Getenv("USER"), "who redacts the value")
+		value := flags.String("value", "", "replacement value")
+		valueFile := flags.String("value-file", "", "file with the replacement value")
+		flags.Parse(args[1:])
+
+		location, err := strconv.ParseUint(*at, 16, 64)
+		if err != nil {
+			log.Fatalf("Invalid location %s: %v", *at, err)
+		}
+		replacement := []byte(*value)
+		if len(*valueFile) > 0 {
+			if replacement, err = ioutil.ReadFile(*valueFile); err != nil {
+				log.Fatalln(err)
+			}
+		}
+		resp, err := client.RedactValue(ctx, &db.RedactValueRequest{
+			Location: location,
+			Value:    replacement,
+			Reason:   *reason,
+			Operator: *operator,
+		})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Redacted %x (%s), checksum %08x\n", location, resp.Key, resp.Checksum)
+		if resp.BlobRemoved {
+			fmt.Println("Blob of the old value removed")
+		}
+
+	case "acl":
+		if len(args) == 3 && args[1] == "set" {
+			data, err := ioutil.ReadFile(args[2])
+			if err != nil {
+				log.Fatalln(err)
+			}
+			if _, err := client.SetACL(ctx, &db.ACL{Json: string(data)}); err != nil {
+				log.Fatalln(err)
+			}
+			break
+		}
+		resp, err := client.GetACL(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println(resp.Json)
+
+	case "validation":
+		if len(args) == 3 && args[1] == "set" {
+			data, err := ioutil.ReadFile(args[2])
+			if err != nil {
+				log.Fatalln(err)
+			}
+			var rules db.ValidationRules
+			if err := json.Unmarshal(data, &rules); err != nil {
+				log.Fatalln(err)
+			}
+			if _, err := client.SetValidationRules(ctx, &rules); err != nil {
+				log.Fatalln(err)
+			}
+			break
+		}
+		rules, err := client.GetValidationRules(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(rules)
+
+	case "slowlog":
+		resp, err := client.GetSlowLog(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, r := range resp.Requests {
+			fmt.Printf("%s\t%s\t%s\t%x\t%.1fms\thops=%d\t%v\t%s\n",
+				time.Unix(0, r.Time).Format(time.RFC3339), r.Method, r.Key, r.Location,
+				float64(r.DurationMicros)/1000, r.Hops, r.PhaseMicros, r.Error)
+		}
+
+	case "journal":
+		flags := flag.NewFlagSet("journal", flag.ExitOnError)
+		key := flags.String("key", "", "only requests of the key")
+		at := flags.String("location", "", "only requests reading, creating or depending on the location")
+		since := flags.Duration("since", 0, "only requests of the last duration")
+		n := flags.Int("n", 0, "most recent requests shown (0 for all)")
+		asJSON := flags.Bool("json", false, "dump the entries as JSON lines")
+		flags.Parse(args[1:])
+
+		request := &db.GetJournalRequest{Key: *key, Limit: int32(*n)}
+		if len(*at) > 0 {
+			location, err := strconv.ParseUint(*at, 16, 64)
+			if err != nil {
+				log.Fatalf("Invalid location %s: %v", *at, err)
+			}
+			request.Location = location
+		}
+		if *since > 0 {
+			request.Since = time.Now().Add(-*since).UnixNano()
+		}
+		resp, err := client.GetJournal(ctx, request)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		for _, e := range resp.Entries {
+			if *asJSON {
+				encoder.Encode(e)
+				continue
+			}
+			fmt.Printf("%s\t%s\t%s\tloc=%x\tdep=%x\tcreated=%x\t%s\t%s(%s)\t%s\t%.1fms",
+				time.Unix(0, e.Time).Format(time.RFC3339Nano), e.Method, e.Key, e.Location, e.Dep, e.Created,
+				e.Code, e.Origin, e.Peer, e.Identity, float64(e.DurationMicros)/1000)
+			if len(e.RequestId) > 0 {
+				fmt.Printf("\trequest=%s", e.RequestId)
+			}
+			if len(e.TraceId) > 0 {
+				fmt.Printf("\ttrace=%s", e.TraceId)
+			}
+			if e.Slow {
+				fmt.Print("\tslow")
+			}
+			if e.Skipped > 0 {
+				fmt.Printf("\tskipped=%d", e.Skipped)
+			}
+			if len(e.Error) > 0 {
+				fmt.Printf("\t%s", e.Error)
+			}
+			fmt.Println()
+		}
+
+	case "snapshot":
+		if len(args) < 2 || args[1] == "list" {
+			resp, err := client.ListSnapshots(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			for _, snapshot := range resp.Snapshots {
+				fmt.Printf("%s\t%s\t%d heads\n", snapshot.Name, time.Unix(0, snapshot.Time).Format(time.RFC3339), len(snapshot.Heads))
+			}
+			break
+		}
+		if len(args) < 3 {
+			usage()
+			os.Exit(2)
+		}
+		switch args[1] {
+		case "create":
+			snapshot, err := client.CreateSnapshot(ctx, &db.CreateSnapshotRequest{Name: args[2]})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("Snapshot %s pinned %d heads\n", snapshot.Name, len(snapshot.Heads))
+		case "delete":
+			if _, err := client.DeleteSnapshot(ctx, &db.DeleteSnapshotRequest{Name: args[2]}); err != nil {
+				log.Fatalln(err)
+			}
+		default:
+			usage()
+			os.Exit(2)
+		}
+
+	case "whoowns":
+		if len(args) < 2 {
+			usage()
+			os.Exit(2)
+		}
+		resp, err := client.LocateKeys(ctx, &db.LocateKeysRequest{Keys: args[1:]})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, owner := range resp.Owners {
+			fmt.Printf("%s\t%08x\t%s\n", owner.Key, owner.Hash, owner.Address)
+		}
+		fmt.Printf("(epoch %d)\n", resp.Epoch)
+
+	case "config":
+		if len(args) == 2 && args[1] == "reload" {
+			resp, err := client.ReloadConfig(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			utils.Print(resp)
+			break
+		}
+		config, err := client.GetConfig(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println(config.Json)
+
+	case "check":
+		resp, err := client.CheckIntegrity(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(resp)
+
+	case "compact":
+		resp, err := client.Compact(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(resp)
+
+	case "maintenance":
+		if len(args) >= 3 && args[1] == "run" {
+			if _, err := client.RunTask(ctx, &db.RunTaskRequest{Name: args[2]}); err != nil {
+				log.Fatalln(err)
+			}
+			break
+		}
+		if len(args) >= 2 && (args[1] == "pause" || args[1] == "resume") {
+			if _, err := client.PauseMaintenance(ctx, &db.PauseMaintenanceRequest{Paused: args[1] == "pause"}); err != nil {
+				log.Fatalln(err)
+			}
+			break
+		}
+		stats, err := client.GetStats(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if stats.MaintenancePaused {
+			fmt.Println("Paused")
+		}
+		fmt.Printf("TASK\tSTATE\tLAST RUN\tNEXT RUN\tERROR\n")
+		for _, task := range stats.Maintenance {
+			state := "idle"
+			if task.Running {
+				state = "running"
+			} else if !task.InWindow {
+				state = "outside window"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\n", task.Name, state, formatTime(task.LastRun), formatTime(task.NextRun), task.LastError)
+		}
+
+	case "merge-fn":
+		if len(args) >= 2 && args[1] == "failed" {
+			failed, err := client.GetFailedMerges(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			for _, f := range failed.Merges {
+				fmt.Printf("%x\t%s\t%d attempts\t%s\n", f.Parent, f.Action, f.Attempts, f.Error)
+			}
+			break
+		}
+		if len(args) >= 2 && args[1] == "retry" {
+			var parent uint64
+			if len(args) == 3 {
+				parent, err = strconv.ParseUint(args[2], 16, 64)
+				if err != nil {
+					log.Fatalln(err)
+				}
+			}
+			if _, err := client.RetryMerge(ctx, &db.RetryMergeRequest{Parent: parent}); err != nil {
+				log.Fatalln(err)
+			}
+			break
+		}
+		if len(args) >= 2 && args[1] == "set" {
+			flags := flag.NewFlagSet("merge-fn set", flag.ExitOnError)
+			key := flags.String("key", "", "register for parents with this key")
+			prefix := flags.String("prefix", "", "register for parents with keys starting with this prefix")
+			hashRange := flags.String("range", "", "register for parents in this key hash range (left-right in hex)")
+			noValidate := flags.Bool("no-validate", false, "don't check that the action exists (e.g. offline)")
+			flags.Parse(args[2:])
+			if flags.NArg() != 1 {
+				usage()
+				os.Exit(2)
+			}
+			request := &db.SetScopedMergeFunctionRequest{
+				Key:            *key,
+				Prefix:         *prefix,
+				Name:           flags.Arg(0),
+				SkipValidation: *noValidate,
+			}
+			if len(*hashRange) > 0 {
+				bounds := strings.SplitN(*hashRange, "-", 2)
+				if len(bounds) != 2 {
+					log.Fatalf("Invalid range %s", *hashRange)
+				}
+				left, err := strconv.ParseUint(bounds[0], 16, 32)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				right, err := strconv.ParseUint(bounds[1], 16, 32)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				request.Ranged = true
+				request.Left, request.Right = uint32(left), uint32(right)
+			}
+			if _, err := client.SetScopedMergeFunction(ctx, request); err != nil {
+				log.Fatalln(err)
+			}
+			break
+		}
+		if len(args) >= 2 && args[1] == "resolve" {
+			flags := flag.NewFlagSet("merge-fn resolve", flag.ExitOnError)
+			key := flags.String("key", "", "key of the parent")
+			loc := flags.String("location", "0", "location of the parent")
+			flags.Parse(args[2:])
+			location, err := strconv.ParseUint(*loc, 16, 64)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			resolution, err := client.ResolveMergeFunction(ctx, &db.ResolveMergeFunctionRequest{
+				Location: location,
+				Key:      *key,
+			})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			if len(resolution.Matches) == 0 {
+				fmt.Println("no merge function")
+				break
+			}
+			for i, r := range resolution.Matches {
+				mark := " "
+				if i == 0 {
+					mark = "*"
+				}
+				fmt.Printf("%s %s\t%s\t%s\n", mark, formatRegistration(r), r.Name, utils.ToString(r.Policy))
+			}
+			break
+		}
+		if len(args) != 2 || args[1] != "list" {
+			usage()
+			os.Exit(2)
+		}
+		registrations, err := client.GetMergeRegistrations(ctx, &db.GetMergeRegistrationsRequest{
+			Left:  0,
+			Right: math.MaxUint32,
+		})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, r := range registrations.Registrations {
+			validation := r.Validation
+			if len(validation) == 0 {
+				validation = "unchecked"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", formatRegistration(r), r.Name, validation, utils.ToString(r.Policy))
+		}
+
+	case "converged":
+		flags := flag.NewFlagSet("converged", flag.ExitOnError)
+		root := flags.String("root", "0", "only count parents under this root")
+		wait := flags.Duration("wait", 0, "wait until merges are done for at most this long")
+		flags.Parse(args[1:])
+
+		location, err := strconv.ParseUint(*root, 16, 64)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		waitCtx := ctx
+		if *wait > 0 {
+			var cancel context.CancelFunc
+			waitCtx, cancel = context.WithTimeout(ctx, *wait)
+			defer cancel()
+		}
+		report, err := client.ConvergenceStatus(waitCtx, &db.ConvergenceStatusRequest{
+			Root:  location,
+			Block: *wait > 0,
+		})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		utils.Print(report)
+		if !report.Converged {
+			os.Exit(1)
+		}
+
+	case "import":
+		importFile(ctx, client, args[1:])
+
+	case "placement":
+		if len(args) < 2 {
+			usage()
+			os.Exit(2)
+		}
+		switch args[1] {
+		case "list":
+			rules, err := client.GetPlacementRules(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			for _, rule := range rules.Rules {
+				fmt.Printf("%s\t%q\n", rule.Prefix, rule.Delimiter)
+			}
+			fmt.Printf("(epoch %d)\n", rules.Epoch)
+		case "set":
+			rules := &db.PlacementRules{}
+			for _, arg := range args[2:] {
+				parts := strings.SplitN(arg, "=", 2)
+				rule := &db.PlacementRule{Prefix: parts[0]}
+				if len(parts) == 2 {
+					rule.Delimiter = parts[1]
+				}
+				rules.Rules = append(rules.Rules, rule)
+			}
+			if _, err := client.SetPlacementRules(ctx, rules); err != nil {
+				log.Fatalln(err)
+			}
+		case "verify":
+			resp, err := client.VerifyPlacement(ctx, &db.Empty{})
+			if err != nil {
+				log.Fatalln(err)
+			}
+			utils.Print(resp)
+		case "migrate":
+			if _, err := client.RunTask(ctx, &db.RunTaskRequest{Name: "placement"}); err != nil {
+				log.Fatalln(err)
+			}
+		default:
+			usage()
+			os.Exit(2)
+		}
+
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+// Format unix nanoseconds ("-" if zero)
+func formatTime(nanos int64) string {
+	if nanos == 0 {
+		return "-"
+	}
+	return time.Unix(0, nanos).Format(time.RFC3339)
+}
+
+// Scope of a merge registration
+func formatRegistration(r *db.MergeRegistration) string {
+	switch {
+	case r.Global:
+		return "global"
+	case r.Ranged:
+		return fmt.Sprintf("range %08x-%08x", r.Left, r.Right)
+	case len(r.Prefix) > 0:
+		return "prefix " + r.Prefix
+	case len(r.Key) > 0:
+		return "key " + r.Key
+	default:
+		return fmt.Sprintf("%x", r.Location)
+	}
+}
+
+// Print the heatmap in rows of adjacent buckets, with a line at each range boundary
+func printHeatmap(heatmap *db.Heatmap, rows int) {
+	if rows <= 0 || rows > len(heatmap.Buckets) {
+		rows = len(heatmap.Buckets)
+	}
+	per := (len(heatmap.Buckets) + rows - 1) / rows
+	type row struct {
+		left, right            uint32
+		reads, writes, latency int64
+	}
+	var grouped []row
+	max := int64(0)
+	for i := 0; i < len(heatmap.Buckets); i += per {
+		r := row{left: heatmap.Buckets[i].Left}
+		for _, bucket := range heatmap.Buckets[i:min(i+per, len(heatmap.Buckets))] {
+			r.right = bucket.Right
+			r.reads += bucket.Reads
+			r.writes += bucket.Writes
+			r.latency += bucket.LatencyMicros
+		}
+		if r.reads+r.writes > max {
+			max = r.reads + r.writes
+		}
+		grouped = append(grouped, r)
+	}
+
+	fmt.Printf("Since %s\n", time.Unix(0, heatmap.Since).Format(time.RFC3339))
+	fmt.Printf("HASHES\tREADS\tWRITES\tAVG LATENCY\n")
+	owner := ""
+	for _, r := range grouped {
+		for _, rg := range heatmap.Ranges {
+			if rg.Left <= r.right && r.left <= rg.Right && rg.Server != owner {
+				owner = rg.Server
+				fmt.Printf("---- %s [%08x, %08x]\n", rg.Server, rg.Left, rg.Right)
+			}
+		}
+		count := r.reads + r.writes
+		avg := time.Duration(0)
+		if count > 0 {
+			avg = time.Duration(r.latency/count) * time.Microsecond
+		}
+		bar := ""
+		if max > 0 {
+			bar = strings.Repeat("#", int(count*40/max))
+		}
+		fmt.Printf("%08x-%08x\t%d\t%d\t%s\t%s\n", r.left, r.right, r.reads, r.writes, avg, bar)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Staleness suggested for cacheable keys
+const suggestedStalenessMillis = 1000
+
+type accessCounts struct {
+	name   string
+	reads  float64
+	writes float64
+}
+
+// Every write invalidates the cached value, so the next read misses
+func (c *accessCounts) hitRate() float64 {
+	if c.reads == 0 {
+		return 0
+	}
+	hits := c.reads - c.writes
+	if hits < 0 {
+		hits = 0
+	}
+	return hits / c.reads
+}
+
+// Prefix of the key up to its last delimiter (empty if none)
+func keyPrefix(key string) string {
+	if i := strings.LastIndexAny(key, "/:."); i > 0 {
+		return key[:i+1]
+	}
+	return ""
+}
+
+func printSuggestions(keys map[string]*db.HotKey, minHit float64) {
+	var suggested []*accessCounts
+	prefixes := make(map[string]*accessCounts)
+	for _, key := range keys {
+		counts := &accessCounts{key.Key, key.Reads, key.Writes}
+		if counts.hitRate() >= minHit {
+			suggested = append(suggested, counts)
+		}
+		if prefix := keyPrefix(key.Key); len(prefix) > 0 {
+			p, ok := prefixes[prefix]
+			if !ok {
+				p = &accessCounts{name: prefix}
+				prefixes[prefix] = p
+			}
+			p.reads += key.Reads
+			p.writes += key.Writes
+		}
+	}
+	byReads := func(list []*accessCounts) {
+		sort.Slice(list, func(i, j int) bool { return list[i].reads > list[j].reads })
+	}
+	byReads(suggested)
+	var suggestedPrefixes []*accessCounts
+	for _, p := range prefixes {
+		if p.hitRate() >= minHit {
+			suggestedPrefixes = append(suggestedPrefixes, p)
+		}
+	}
+	byReads(suggestedPrefixes)
+
+	fmt.Println("Cacheable keys (estimated reads, writes, expected hit rate):")
+	for _, c := range suggested {
+		fmt.Printf("%s\t%.0f\t%.0f\t%.0f%%\n", c.name, c.reads, c.writes, c.hitRate()*100)
+	}
+	fmt.Println("\nCacheable prefixes:")
+	for _, c := range suggestedPrefixes {
+		fmt.Printf("%s\t%.0f\t%.0f\t%.0f%%\n", c.name, c.reads, c.writes, c.hitRate()*100)
+	}
+
+	// Prefixes cover their keys
+	var rules []map[string]interface{}
+	covered := func(key string) bool {
+		for _, p := range suggestedPrefixes {
+			if strings.HasPrefix(key, p.name) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, c := range suggestedPrefixes {
+		rules = append(rules, map[string]interface{}{"prefix": c.name, "maxStalenessMillis": suggestedStalenessMillis})
+	}
+	for _, c := range suggested {
+		if !covered(c.name) {
+			rules = append(rules, map[string]interface{}{"prefix": c.name, "maxStalenessMillis": suggestedStalenessMillis})
+		}
+	}
+	if len(rules) == 0 {
+		return
+	}
+	data, _ := json.MarshalIndent(map[string]interface{}{"cacheable": rules}, "", "  ")
+	fmt.Printf("\nIn server.json:\n%s\n", data)
+}
@@ -0,0 +1,492 @@
+package dberrors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sentinels for errors.Is
+var (
+	ErrKeyNotFound       = errors.New("key not found")
+	ErrLocationNotFound  = errors.New("location not found")
+	ErrNotResponsible    = errors.New("not responsible")
+	ErrIndexingLocked    = errors.New("indexing locked")
+	ErrConflictRejected  = errors.New("conflict rejected")
+	ErrQuotaExceeded     = errors.New("quota exceeded")
+	ErrInvalidLocation   = errors.New("invalid location")
+	ErrDiverged          = errors.New("diverged")
+	ErrOverloaded        = errors.New("overloaded")
+	ErrHistoryCompressed = errors.New("history compressed")
+	ErrInvalidKey        = errors.New("invalid key")
+	ErrNodeExists        = errors.New("node exists")
+	ErrChildrenChanged   = errors.New("children changed")
+	ErrChecksumMismatch  = errors.New("checksum mismatch")
+	ErrHopBudgetExceeded = errors.New("hop budget exceeded")
+)
+
+type KeyNotFoundError struct {
+	Key string
+}
+
+func (e *KeyNotFoundError) Error() string {
+	return fmt.Sprintf("Key %s not found", e.Key)
+}
+
+func (e *KeyNotFoundError) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+func (e *KeyNotFoundError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+type LocationNotFoundError struct {
+	Location uint64
+}
+
+func (e *LocationNotFoundError) Error() string {
+	return fmt.Sprintf("Location %x not found", e.Location)
+}
+
+func (e *LocationNotFoundError) Is(target error) bool {
+	return target == ErrLocationNotFound
+}
+
+func (e *LocationNotFoundError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// The server doesn't own the location in the epoch
+type NotResponsibleError struct {
+	Owner string
+	Epoch uint64
+}
+
+func (e *NotResponsibleError) Error() string {
+	return fmt.Sprintf("Not responsible (owner %s in epoch %d)", e.Owner, e.Epoch)
+}
+
+func (e *NotResponsibleError) Is(target error) bool {
+	return target == ErrNotResponsible
+}
+
+func (e *NotResponsibleError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+type IndexingLockedError struct {
+	Holder string
+	Expiry time.Time
+}
+
+func (e *IndexingLockedError) Error() string {
+	return fmt.Sprintf("Indexing lock is held by %s until %s", e.Holder, e.Expiry.Format(time.RFC3339Nano))
+}
+
+func (e *IndexingLockedError) Is(target error) bool {
+	return target == ErrIndexingLocked
+}
+
+func (e *IndexingLockedError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// A write was refused because of a conflict
+type ConflictRejectedError struct {
+	Location uint64
+	Reason   string
+}
+
+func (e *ConflictRejectedError) Error() string {
+	return fmt.Sprintf("Conflict at %x rejected: %s", e.Location, e.Reason)
+}
+
+func (e *ConflictRejectedError) Is(target error) bool {
+	return target == ErrConflictRejected
+}
+
+func (e *ConflictRejectedError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+type QuotaExceededError struct {
+	Resource string
+	Limit    int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("Quota of %s exceeded (max %d)", e.Resource, e.Limit)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+func (e *QuotaExceededError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// The key hash of the location does not match the key
+type InvalidLocationError struct {
+	Location uint64
+	Key      string
+}
+
+func (e *InvalidLocationError) Error() string {
+	return fmt.Sprintf("Location %x does not match the hash of key %s", e.Location, e.Key)
+}
+
+func (e *InvalidLocationError) Is(target error) bool {
+	return target == ErrInvalidLocation
+}
+
+func (e *InvalidLocationError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// Branch heads resolve the key to different values
+type DivergedError struct {
+	Key   string
+	Heads int64
+}
+
+func (e *DivergedError) Error() string {
+	return fmt.Sprintf("Key %s has different values on %d heads", e.Key, e.Heads)
+}
+
+func (e *DivergedError) Is(target error) bool {
+	return target == ErrDiverged
+}
+
+func (e *DivergedError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// The server is saturated, retry after the suggested time
+type OverloadedError struct {
+	Server     string
+	RetryAfter time.Duration
+}
+
+func (e *OverloadedError) Error() string {
+	return fmt.Sprintf("Server %s is overloaded (retry after %s)", e.Server, e.RetryAfter)
+}
+
+func (e *OverloadedError) Is(target error) bool {
+	return target == ErrOverloaded
+}
+
+func (e *OverloadedError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// Versions of the key read as of a time were collapsed by chain compression
+type HistoryCompressedError struct {
+	Key      string
+	Location uint64
+}
+
+func (e *HistoryCompressedError) Error() string {
+	return fmt.Sprintf("History of %s up to %x was compressed", e.Key, e.Location)
+}
+
+func (e *HistoryCompressedError) Is(target error) bool {
+	return target == ErrHistoryCompressed
+}
+
+func (e *HistoryCompressedError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// The key breaks a rule of key names (e.g. non-empty, max-length)
+type InvalidKeyError struct {
+	Key  string
+	Rule string
+}
+
+func (e *InvalidKeyError) Error() string {
+	return fmt.Sprintf("Invalid key %q (%s)", e.Key, e.Rule)
+}
+
+func (e *InvalidKeyError) Is(target error) bool {
+	return target == ErrInvalidKey
+}
+
+func (e *InvalidKeyError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// A different node is already stored at the location
+type NodeExistsError struct {
+	Location uint64
+	Existing *db.Node
+	Incoming *db.Node
+}
+
+func (e *NodeExistsError) Error() string {
+	return fmt.Sprintf("A different node exists at %x", e.Location)
+}
+
+func (e *NodeExistsError) Is(target error) bool {
+	return target == ErrNodeExists
+}
+
+func (e *NodeExistsError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// Children of the location changed since the version a replacement was based on
+type ChildrenChangedError struct {
+	Location uint64
+	Expected uint64
+	Actual   uint64
+}
+
+func (e *ChildrenChangedError) Error() string {
+	return fmt.Sprintf("Children of %x changed (version %d, expected %d)", e.Location, e.Actual, e.Expected)
+}
+
+func (e *ChildrenChangedError) Is(target error) bool {
+	return target == ErrChildrenChanged
+}
+
+func (e *ChildrenChangedError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// The content of a node does not match its checksum (corrupted on the way or at rest)
+type ChecksumMismatchError struct {
+	Location uint64
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("Checksum of node %x is %08x, expected %08x", e.Location, e.Actual, e.Expected)
+}
+
+func (e *ChecksumMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+func (e *ChecksumMismatchError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+// A chain walk needed more remote hops than allowed.
+// Path tells a deep chain from a routing loop (a location walked twice).
+type HopBudgetExceededError struct {
+	Key    string
+	Budget int32
+	// Servers walked and where the walk would continue
+	Path []*db.WalkStep
+	Next uint64
+}
+
+func (e *HopBudgetExceededError) Error() string {
+	path := make([]string, len(e.Path))
+	for i, step := range e.Path {
+		path[i] = fmt.Sprintf("%x@%s", step.Location, step.Server)
+	}
+	loop := ""
+	if e.Loop() {
+		loop = " (routing loop)"
+	}
+	return fmt.Sprintf("Walk for %s exceeded %d remote hops before %x%s: %s", e.Key, e.Budget, e.Next, loop, strings.Join(path, " -> "))
+}
+
+// Whether a location was walked twice
+func (e *HopBudgetExceededError) Loop() bool {
+	seen := make(map[uint64]bool)
+	for _, step := range e.Path {
+		if seen[step.Location] {
+			return true
+		}
+		seen[step.Location] = true
+	}
+	return seen[e.Next]
+}
+
+func (e *HopBudgetExceededError) Is(target error) bool {
+	return target == ErrHopBudgetExceeded
+}
+
+func (e *HopBudgetExceededError) GRPCStatus() *status.Status {
+	return toStatus(e)
+}
+
+func formatUint(v uint64) string {
+	return strconv.FormatUint(v, 10)
+}
+
+func parseUint(s string) uint64 {
+	v, _ := strconv.ParseUint(s, 10, 64)
+	return v
+}
+
+// Status with details of typed errors (nil for other errors)
+func toStatus(err error) *status.Status {
+	var code codes.Code
+	detail := &db.ErrorDetail{Fields: make(map[string]string)}
+
+	switch e := err.(type) {
+	case *KeyNotFoundError:
+		code = codes.NotFound
+		detail.Reason = "KEY_NOT_FOUND"
+		detail.Fields["key"] = e.Key
+	case *LocationNotFoundError:
+		code = codes.NotFound
+		detail.Reason = "LOCATION_NOT_FOUND"
+		detail.Fields["location"] = formatUint(e.Location)
+	case *NotResponsibleError:
+		code = codes.FailedPrecondition
+		detail.Reason = "NOT_RESPONSIBLE"
+		detail.Fields["owner"] = e.Owner
+		detail.Fields["epoch"] = formatUint(e.Epoch)
+	case *IndexingLockedError:
+		code = codes.Aborted
+		detail.Reason = "INDEXING_LOCKED"
+		detail.Fields["holder"] = e.Holder
+		detail.Fields["expiry"] = formatUint(uint64(e.Expiry.UnixNano()))
+	case *ConflictRejectedError:
+		code = codes.Aborted
+		detail.Reason = "CONFLICT_REJECTED"
+		detail.Fields["location"] = formatUint(e.Location)
+		detail.Fields["reason"] = e.Reason
+	case *QuotaExceededError:
+		code = codes.ResourceExhausted
+		detail.Reason = "QUOTA_EXCEEDED"
+		detail.Fields["resource"] = e.Resource
+		detail.Fields["limit"] = formatUint(uint64(e.Limit))
+	case *InvalidLocationError:
+		code = codes.InvalidArgument
+		detail.Reason = "INVALID_LOCATION"
+		detail.Fields["location"] = formatUint(e.Location)
+		detail.Fields["key"] = e.Key
+	case *DivergedError:
+		code = codes.AlreadyExists
+		detail.Reason = "DIVERGED"
+		detail.Fields["key"] = e.Key
+		detail.Fields["heads"] = formatUint(uint64(e.Heads))
+	case *OverloadedError:
+		code = codes.Unavailable
+		detail.Reason = "OVERLOADED"
+		detail.Fields["server"] = e.Server
+		detail.Fields["retry_after"] = formatUint(uint64(e.RetryAfter.Milliseconds()))
+	case *HistoryCompressedError:
+		code = codes.FailedPrecondition
+		detail.Reason = "HISTORY_COMPRESSED"
+		detail.Fields["key"] = e.Key
+		detail.Fields["location"] = formatUint(e.Location)
+	case *InvalidKeyError:
+		code = codes.InvalidArgument
+		detail.Reason = "INVALID_KEY"
+		detail.Fields["key"] = e.Key
+		detail.Fields["rule"] = e.Rule
+	case *NodeExistsError:
+		code = codes.AlreadyExists
+		detail.Reason = "NODE_EXISTS"
+		detail.Fields["location"] = formatUint(e.Location)
+		detail.Nodes = []*db.Node{e.Existing, e.Incoming}
+	case *ChildrenChangedError:
+		code = codes.Aborted
+		detail.Reason = "CHILDREN_CHANGED"
+		detail.Fields["location"] = formatUint(e.Location)
+		detail.Fields["expected"] = formatUint(e.Expected)
+		detail.Fields["actual"] = formatUint(e.Actual)
+	case *ChecksumMismatchError:
+		code = codes.DataLoss
+		detail.Reason = "CHECKSUM_MISMATCH"
+		detail.Fields["location"] = formatUint(e.Location)
+		detail.Fields["expected"] = formatUint(uint64(e.Expected))
+		detail.Fields["actual"] = formatUint(uint64(e.Actual))
+	case *HopBudgetExceededError:
+		code = codes.ResourceExhausted
+		detail.Reason = "HOP_BUDGET_EXCEEDED"
+		detail.Fields["key"] = e.Key
+		detail.Fields["budget"] = formatUint(uint64(e.Budget))
+		detail.Fields["next"] = formatUint(e.Next)
+		detail.Path = e.Path
+	default:
+		return nil
+	}
+
+	st, detailErr := status.New(code, err.Error()).WithDetails(detail)
+	if detailErr != nil {
+		return status.New(code, err.Error())
+	}
+	return st
+}
+
+// Convert statuses with details back to typed errors.
+// Typed errors are sent with details by grpc since they implement GRPCStatus.
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+	for _, d := range st.Details() {
+		detail, ok := d.(*db.ErrorDetail)
+		if !ok {
+			continue
+		}
+		f := detail.Fields
+		switch detail.Reason {
+		case "KEY_NOT_FOUND":
+			return &KeyNotFoundError{Key: f["key"]}
+		case "LOCATION_NOT_FOUND":
+			return &LocationNotFoundError{Location: parseUint(f["location"])}
+		case "NOT_RESPONSIBLE":
+			return &NotResponsibleError{Owner: f["owner"], Epoch: parseUint(f["epoch"])}
+		case "INDEXING_LOCKED":
+			return &IndexingLockedError{Holder: f["holder"], Expiry: time.Unix(0, int64(parseUint(f["expiry"])))}
+		case "CONFLICT_REJECTED":
+			return &ConflictRejectedError{Location: parseUint(f["location"]), Reason: f["reason"]}
+		case "QUOTA_EXCEEDED":
+			return &QuotaExceededError{Resource: f["resource"], Limit: int64(parseUint(f["limit"]))}
+		case "DIVERGED":
+			return &DivergedError{Key: f["key"], Heads: int64(parseUint(f["heads"]))}
+		case "OVERLOADED":
+			return &OverloadedError{Server: f["server"], RetryAfter: time.Duration(parseUint(f["retry_after"])) * time.Millisecond}
+		case "HISTORY_COMPRESSED":
+			return &HistoryCompressedError{Key: f["key"], Location: parseUint(f["location"])}
+		case "INVALID_LOCATION":
+			return &InvalidLocationError{Location: parseUint(f["location"]), Key: f["key"]}
+		case "INVALID_KEY":
+			return &InvalidKeyError{Key: f["key"], Rule: f["rule"]}
+		case "NODE_EXISTS":
+			e := &NodeExistsError{Location: parseUint(f["location"])}
+			if len(detail.Nodes) == 2 {
+				e.Existing, e.Incoming = detail.Nodes[0], detail.Nodes[1]
+			}
+			return e
+		case "CHILDREN_CHANGED":
+			return &ChildrenChangedError{
+				Location: parseUint(f["location"]),
+				Expected: parseUint(f["expected"]),
+				Actual:   parseUint(f["actual"]),
+			}
+		case "CHECKSUM_MISMATCH":
+			return &ChecksumMismatchError{
+				Location: parseUint(f["location"]),
+				Expected: uint32(parseUint(f["expected"])),
+				Actual:   uint32(parseUint(f["actual"])),
+			}
+		case "HOP_BUDGET_EXCEEDED":
+			return &HopBudgetExceededError{
+				Key:    f["key"],
+				Budget: int32(parseUint(f["budget"])),
+				Path:   detail.Path,
+				Next:   parseUint(f["next"]),
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,134 @@
+package dberrors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server failing every Get with the error it is given
+type failingServer struct {
+	db.UnimplementedDbServiceServer
+	err error
+}
+
+func (s *failingServer) Get(ctx context.Context, in *db.GetRequest) (*db.GetResponse, error) {
+	return nil, s.err
+}
+
+// Client of a failing server, returning typed errors through the interceptor
+func startFailing(t *testing.T) (*failingServer, db.DbServiceClient) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	failing := &failingServer{}
+	server := grpc.NewServer()
+	db.RegisterDbServiceServer(server, failing)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure(), grpc.WithUnaryInterceptor(UnaryClientInterceptor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return failing, db.NewDbServiceClient(conn)
+}
+
+// Every typed error comes back from the wire as the same type with the same fields
+func TestWireRoundTrip(t *testing.T) {
+	existing := &db.Node{Location: 1<<32 | 1, Key: "a", Value: []byte("1")}
+	incoming := &db.Node{Location: 1<<32 | 1, Key: "a", Value: []byte("2")}
+	path := []*db.WalkStep{{Location: 1, Server: "a:1"}, {Location: 2, Server: "b:1"}}
+	tests := []struct {
+		err      error
+		code     codes.Code
+		sentinel error
+	}{
+		{&KeyNotFoundError{Key: "k"}, codes.NotFound, ErrKeyNotFound},
+		{&LocationNotFoundError{Location: 1<<63 | 5}, codes.NotFound, ErrLocationNotFound},
+		{&NotResponsibleError{Owner: "a:1", Epoch: 7}, codes.FailedPrecondition, ErrNotResponsible},
+		{&IndexingLockedError{Holder: "a:1", Expiry: time.Unix(0, 1600000000123456789)}, codes.Aborted, ErrIndexingLocked},
+		{&ConflictRejectedError{Location: 42, Reason: "rejected by the merge function"}, codes.Aborted, ErrConflictRejected},
+		{&QuotaExceededError{Resource: "children of 2a", Limit: 100}, codes.ResourceExhausted, ErrQuotaExceeded},
+		{&InvalidLocationError{Location: 42, Key: "k"}, codes.InvalidArgument, ErrInvalidLocation},
+		{&DivergedError{Key: "k", Heads: 3}, codes.AlreadyExists, ErrDiverged},
+		{&OverloadedError{Server: "a:1", RetryAfter: 250 * time.Millisecond}, codes.Unavailable, ErrOverloaded},
+		{&HistoryCompressedError{Key: "k", Location: 42}, codes.FailedPrecondition, ErrHistoryCompressed},
+		{&InvalidKeyError{Key: "", Rule: "non-empty"}, codes.InvalidArgument, ErrInvalidKey},
+		{&NodeExistsError{Location: existing.Location, Existing: existing, Incoming: incoming}, codes.AlreadyExists, ErrNodeExists},
+		{&ChildrenChangedError{Location: 42, Expected: 3, Actual: 4}, codes.Aborted, ErrChildrenChanged},
+		{&ChecksumMismatchError{Location: 42, Expected: 0xdeadbeef, Actual: 0x12345678}, codes.DataLoss, ErrChecksumMismatch},
+		{&HopBudgetExceededError{Key: "k", Budget: 2, Path: path, Next: 1}, codes.ResourceExhausted, ErrHopBudgetExceeded},
+	}
+	failing, client := startFailing(t)
+	for _, tt := range tests {
+		name := reflect.TypeOf(tt.err).Elem().Name()
+		t.Run(name, func(t *testing.T) {
+			failing.err = tt.err
+			_, err := client.Get(context.Background(), &db.GetRequest{})
+
+			if status.Code(err) != tt.code {
+				t.Errorf("Code is %s, expected %s", status.Code(err), tt.code)
+			}
+			if !errors.Is(err, tt.sentinel) {
+				t.Errorf("%v is not %v", err, tt.sentinel)
+			}
+			target := reflect.New(reflect.TypeOf(tt.err))
+			if !errors.As(err, target.Interface()) {
+				t.Fatalf("%v (%T) is not a %T", err, err, tt.err)
+			}
+			received := target.Elem().Interface().(error)
+			if received.Error() != tt.err.Error() {
+				t.Errorf("Received %q, sent %q", received.Error(), tt.err.Error())
+			}
+
+			// Fields not in the message
+			switch sent := tt.err.(type) {
+			case *IndexingLockedError:
+				if !received.(*IndexingLockedError).Expiry.Equal(sent.Expiry) {
+					t.Errorf("Expiry is %v, expected %v", received.(*IndexingLockedError).Expiry, sent.Expiry)
+				}
+			case *NodeExistsError:
+				e := received.(*NodeExistsError)
+				if !proto.Equal(e.Existing, sent.Existing) || !proto.Equal(e.Incoming, sent.Incoming) {
+					t.Errorf("Nodes are %v and %v", e.Existing, e.Incoming)
+				}
+			case *HopBudgetExceededError:
+				e := received.(*HopBudgetExceededError)
+				if len(e.Path) != len(sent.Path) {
+					t.Fatalf("Path is %v, expected %v", e.Path, sent.Path)
+				}
+				for i := range e.Path {
+					if !proto.Equal(e.Path[i], sent.Path[i]) {
+						t.Errorf("Step %d is %v, expected %v", i, e.Path[i], sent.Path[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+// Statuses without details are returned as they are
+func TestWireUntyped(t *testing.T) {
+	failing, client := startFailing(t)
+	failing.err = status.Error(codes.PermissionDenied, "denied")
+	_, err := client.Get(context.Background(), &db.GetRequest{})
+	if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != "denied" {
+		t.Errorf("Error is %v", err)
+	}
+	if FromStatus(nil) != nil {
+		t.Errorf("FromStatus(nil) is not nil")
+	}
+}
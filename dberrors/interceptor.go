@@ -0,0 +1,12 @@
+package dberrors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// Return typed errors to callers (use with grpc.WithUnaryInterceptor)
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return FromStatus(invoker(ctx, method, req, reply, cc, opts...))
+}
@@ -0,0 +1,522 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/indexing"
+	"github.com/DCsunset/openwhisk-grpc/placement"
+	"google.golang.org/grpc"
+)
+
+// Buckets of the heatmap of servers
+const buckets = 256
+
+// Key hashes per bucket
+const bucketWidth = (1 << 32) / buckets
+
+// Projected workload
+type Workload struct {
+	// Distinct keys written
+	Keys int `json:"keys"`
+	// Share of the keys with each prefix (the rest are named key-N)
+	Prefixes []PrefixShare `json:"prefixes"`
+	// Sizes of values, uniformly distributed
+	ValueBytes struct {
+		Min int `json:"min"`
+		Max int `json:"max"`
+	} `json:"valueBytes"`
+	// Updates of existing keys (each creates a node) over the horizon
+	WritesPerSecond float64 `json:"writesPerSecond"`
+	Hours           float64 `json:"hours"`
+}
+
+type PrefixShare struct {
+	Prefix string  `json:"prefix"`
+	Share  float64 `json:"share"`
+}
+
+// Split policy of the cluster
+type Policy struct {
+	Threshold      int   `json:"threshold"`
+	ThresholdBytes int64 `json:"thresholdBytes"`
+	SplitByLoad    bool  `json:"splitByLoad"`
+}
+
+type Split struct {
+	// Simulated writes before the split
+	AfterWrites int64   `json:"afterWrites"`
+	AfterHours  float64 `json:"afterHours"`
+	Server      string  `json:"server"`
+	Left        uint32  `json:"left"`
+	Right       uint32  `json:"right"`
+	Mid         uint32  `json:"mid"`
+	Target      string  `json:"target"`
+	MovedNodes  int64   `json:"movedNodes"`
+}
+
+type Assignment struct {
+	Left   uint32 `json:"left"`
+	Right  uint32 `json:"right"`
+	Server string `json:"server"`
+	Nodes  int64  `json:"nodes"`
+	Bytes  int64  `json:"bytes"`
+}
+
+type Report struct {
+	Policy Policy `json:"policy"`
+	// Writes of the workload and the writes each simulated one stands for
+	Writes int64   `json:"writes"`
+	Scale  float64 `json:"scale"`
+	Splits []Split `json:"splits"`
+	// Splits not done because no server was available
+	Blocked int          `json:"blocked"`
+	Servers int          `json:"servers"`
+	Ranges  []Assignment `json:"ranges"`
+}
+
+// Simulated node
+type entry struct {
+	hash  uint32
+	bytes int64
+}
+
+type simulation struct {
+	policy    Policy
+	available int
+	scale     float64
+	hours     float64
+	total     int64
+
+	service indexing.Service
+	nodes   map[string][]entry
+	bytes   map[string]int64
+	// Load by bucket (the heatmap of the servers)
+	load    [buckets]float64
+	added   int
+	blocked map[string]bool
+	report  *Report
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: dbplan -workload path [-server address] [options]
+
+Simulates the splits of a projected workload with the split policy of the
+servers, starting from the current cluster (or from a single empty server),
+and reports the splits, the final ranges and the nodes and bytes per server.
+
+The workload is a JSON file, e.g.
+  {"keys": 1000000, "prefixes": [{"prefix": "user:", "share": 0.8}],
+   "valueBytes": {"min": 100, "max": 1000}, "writesPerSecond": 50, "hours": 24}
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	workloadFile := flag.String("workload", "", "file describing the projected workload")
+	address := flag.String("server", "", "address of a server of the current cluster (empty for a new cluster)")
+	secretFile := flag.String("secret-file", "", "file with the cluster secret")
+	threshold := flag.Int("threshold", 10000, "nodes per server before a split (default of the cluster with -server)")
+	thresholdBytes := flag.Int64("threshold-bytes", 0, "value bytes per server before a split")
+	splitByLoad := flag.Bool("split-by-load", false, "split at the median of the load instead of the middle")
+	available := flag.Int("available", -1, "servers available for splits (-1 for unlimited)")
+	samples := flag.Int("samples", 1000000, "max simulated writes (larger workloads are scaled down)")
+	seed := flag.Int64("seed", 1, "seed of the simulation")
+	jsonOutput := flag.Bool("json", false, "print the report as JSON")
+	flag.Usage = usage
+	flag.Parse()
+
+	if len(*workloadFile) == 0 {
+		usage()
+		os.Exit(2)
+	}
+	data, err := ioutil.ReadFile(*workloadFile)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var workload Workload
+	if err := json.Unmarshal(data, &workload); err != nil {
+		log.Fatalf("Invalid workload: %v", err)
+	}
+	if workload.Keys <= 0 {
+		log.Fatalln("Invalid workload: keys must be positive")
+	}
+
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	policy := Policy{Threshold: *threshold, ThresholdBytes: *thresholdBytes, SplitByLoad: *splitByLoad}
+	var cluster *Cluster
+	if len(*address) > 0 {
+		cluster, err = fetchCluster(*address, *secretFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		// Flags override the config of the cluster
+		if !set["threshold"] {
+			policy.Threshold = cluster.Policy.Threshold
+		}
+		if !set["threshold-bytes"] {
+			policy.ThresholdBytes = cluster.Policy.ThresholdBytes
+		}
+		if !set["split-by-load"] {
+			policy.SplitByLoad = cluster.Policy.SplitByLoad
+		}
+		if !set["available"] {
+			*available = cluster.Available
+		}
+	}
+
+	rand.Seed(*seed)
+	report := simulate(workload, policy, cluster, *available, *samples)
+	if *jsonOutput {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+		return
+	}
+	printReport(report)
+}
+
+// State of the current cluster
+type Cluster struct {
+	Policy    Policy
+	Ranges    []*db.Range
+	Stats     map[string]*db.GetStatsResponse
+	Heatmap   *db.Heatmap
+	Available int
+}
+
+func fetchCluster(address string, secretFile string) (*Cluster, error) {
+	var interceptors []grpc.UnaryClientInterceptor
+	if len(secretFile) > 0 {
+		data, err := ioutil.ReadFile(secretFile)
+		if err != nil {
+			return nil, err
+		}
+		secret := []byte(strings.TrimSpace(string(data)))
+		interceptors = append(interceptors, auth.Signer{Secret: secret}.UnaryClientInterceptor)
+	}
+	dial := func(address string) (db.DbServiceClient, *grpc.ClientConn, error) {
+		conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Cannot connect to %s: %v", address, err)
+		}
+		return db.NewDbServiceClient(conn), conn, nil
+	}
+	ctx := context.Background()
+	client, conn, err := dial(address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	cluster := &Cluster{Stats: make(map[string]*db.GetStatsResponse)}
+	config, err := client.GetConfig(ctx, &db.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(config.Json), &cluster.Policy); err != nil {
+		return nil, err
+	}
+	mapping, err := client.GetMapping(ctx, &db.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	cluster.Ranges = mapping.Ranges
+	if cluster.Heatmap, err = client.GetHeatmap(ctx, &db.GetHeatmapRequest{}); err != nil {
+		return nil, err
+	}
+	rules, err := client.GetPlacementRules(ctx, &db.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	var placementRules []placement.Rule
+	for _, rule := range rules.Rules {
+		placementRules = append(placementRules, placement.Rule{Prefix: rule.Prefix, Delimiter: rule.Delimiter})
+	}
+	placement.Default.Set(placementRules, rules.Epoch)
+
+	for _, r := range cluster.Ranges {
+		if _, ok := cluster.Stats[r.Server]; ok {
+			continue
+		}
+		client, conn, err := dial(r.Server)
+		if err != nil {
+			return nil, err
+		}
+		stats, err := client.GetStats(ctx, &db.Empty{})
+		conn.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Cannot get stats of %s: %v", r.Server, err)
+		}
+		cluster.Stats[r.Server] = stats
+		cluster.Available = len(stats.AvailableServers)
+	}
+	return cluster, nil
+}
+
+func simulate(workload Workload, policy Policy, cluster *Cluster, available int, samples int) *Report {
+	updates := int64(workload.WritesPerSecond * workload.Hours * 3600)
+	total := int64(workload.Keys) + updates
+	existing := int64(0)
+	if cluster != nil {
+		for _, stats := range cluster.Stats {
+			existing += stats.Nodes
+		}
+	}
+	scale := 1.0
+	if samples > 0 && existing+total > int64(samples) {
+		scale = float64(existing+total) / float64(samples)
+	}
+
+	s := &simulation{
+		policy:    policy,
+		available: available,
+		scale:     scale,
+		hours:     workload.Hours,
+		total:     total,
+		nodes:     make(map[string][]entry),
+		bytes:     make(map[string]int64),
+		blocked:   make(map[string]bool),
+		report:    &Report{Policy: policy, Writes: total, Scale: scale},
+	}
+	if cluster == nil {
+		s.service.AddMapping(0, math.MaxUint32, "server-1")
+	} else {
+		s.load = heatmapLoad(cluster.Heatmap)
+		s.populate(cluster)
+	}
+
+	creations := int64(float64(workload.Keys) / scale)
+	simulated := int64(float64(total) / scale)
+	for i := int64(0); i < simulated; i++ {
+		var k int
+		if i < creations {
+			k = int(i * int64(workload.Keys) / creations)
+		} else {
+			k = rand.Intn(workload.Keys)
+		}
+		key := keyName(workload, k)
+		size := workload.ValueBytes.Min
+		if workload.ValueBytes.Max > size {
+			size += rand.Intn(workload.ValueBytes.Max - size + 1)
+		}
+		hash := placement.Hash(key)
+		owner := s.service.Locate(hash)
+		s.nodes[owner] = append(s.nodes[owner], entry{hash, int64(size)})
+		s.bytes[owner] += int64(size)
+		s.load[hash/bucketWidth] += scale
+		if s.overThreshold(owner) {
+			s.split(owner, i+1)
+		}
+	}
+	s.finish()
+	return s.report
+}
+
+// Load of the cluster by bucket
+func heatmapLoad(heatmap *db.Heatmap) [buckets]float64 {
+	var load [buckets]float64
+	if heatmap == nil || len(heatmap.Buckets) != buckets {
+		return load
+	}
+	for i, bucket := range heatmap.Buckets {
+		load[i] = float64(bucket.Reads + bucket.Writes)
+	}
+	return load
+}
+
+// Spread the nodes of the servers over their ranges by the load of the buckets
+func (s *simulation) populate(cluster *Cluster) {
+	widths := make(map[string]float64)
+	for _, r := range cluster.Ranges {
+		s.service.AddMapping(r.Left, r.Right, r.Server)
+		widths[r.Server] += float64(r.Right-r.Left) + 1
+	}
+	for _, r := range cluster.Ranges {
+		stats := cluster.Stats[r.Server]
+		share := (float64(r.Right-r.Left) + 1) / widths[r.Server]
+		count := int(float64(stats.Nodes) * share / s.scale)
+		if count == 0 {
+			continue
+		}
+		size := int64(0)
+		if stats.Nodes > 0 {
+			size = stats.ValueBytes / stats.Nodes
+		}
+		first, last := r.Left/bucketWidth, r.Right/bucketWidth
+		weights := make([]float64, 0, last-first+1)
+		sum := 0.0
+		for b := first; b <= last; b++ {
+			// Buckets without load still hold nodes
+			weights = append(weights, s.load[b]+1)
+			sum += s.load[b] + 1
+		}
+		for i := 0; i < count; i++ {
+			// Pick the bucket by its weight, then a hash of the range in it
+			x := rand.Float64() * sum
+			b := first
+			for j, w := range weights {
+				if x < w {
+					b = first + uint32(j)
+					break
+				}
+				x -= w
+			}
+			left, right := b*bucketWidth, b*bucketWidth+bucketWidth-1
+			if left < r.Left {
+				left = r.Left
+			}
+			if right > r.Right {
+				right = r.Right
+			}
+			hash := left + uint32(rand.Int63n(int64(right-left)+1))
+			s.nodes[r.Server] = append(s.nodes[r.Server], entry{hash, size})
+			s.bytes[r.Server] += size
+		}
+	}
+}
+
+// Name of the key k, prefixed by the share of the keys it falls in
+func keyName(workload Workload, k int) string {
+	position := (float64(k) + 0.5) / float64(workload.Keys)
+	for _, p := range workload.Prefixes {
+		if position < p.Share {
+			return p.Prefix + strconv.Itoa(k)
+		}
+		position -= p.Share
+	}
+	return "key-" + strconv.Itoa(k)
+}
+
+func (s *simulation) count(server string) int {
+	return int(float64(len(s.nodes[server])) * s.scale)
+}
+
+func (s *simulation) overThreshold(server string) bool {
+	bytes := int64(float64(s.bytes[server]) * s.scale)
+	return indexing.OverThreshold(s.count(server), s.policy.Threshold, bytes, s.policy.ThresholdBytes)
+}
+
+// Split the range of the server like the server does (see planSplit)
+func (s *simulation) split(server string, writes int64) {
+	left, right := s.service.Range(server)
+	if left == right {
+		return
+	}
+	if s.available == 0 {
+		if !s.blocked[server] {
+			s.blocked[server] = true
+			s.report.Blocked += 1
+		}
+		return
+	}
+	mid := indexing.SplitPoint(left, right, s.policy.SplitByLoad, func(left, right uint32) (uint32, bool) {
+		return indexing.WeightedMedian(left, right, bucketWidth, func(b uint32) int64 {
+			return int64(s.load[b])
+		})
+	})
+
+	var lower, upper []entry
+	for _, e := range s.nodes[server] {
+		if e.hash >= left && e.hash <= mid {
+			lower = append(lower, e)
+		} else {
+			upper = append(upper, e)
+		}
+	}
+	s.added += 1
+	if s.available > 0 {
+		s.available -= 1
+	}
+	target := fmt.Sprintf("new-%d", s.added)
+	moveLeft, moveRight := mid+1, right
+	kept, moved := lower, upper
+	if indexing.MoveLower(int(float64(len(lower))*s.scale), int(float64(len(upper))*s.scale)) {
+		moveLeft, moveRight = left, mid
+		kept, moved = upper, lower
+	}
+	s.service.Assign(moveLeft, moveRight, target)
+	s.nodes[server], s.nodes[target] = kept, moved
+	s.bytes[server], s.bytes[target] = sumBytes(kept), sumBytes(moved)
+
+	s.report.Splits = append(s.report.Splits, Split{
+		AfterWrites: int64(float64(writes) * s.scale),
+		AfterHours:  s.hours * float64(writes) * s.scale / float64(s.total),
+		Server:      server,
+		Left:        left,
+		Right:       right,
+		Mid:         mid,
+		Target:      target,
+		MovedNodes:  int64(float64(len(moved)) * s.scale),
+	})
+}
+
+func sumBytes(entries []entry) int64 {
+	sum := int64(0)
+	for _, e := range entries {
+		sum += e.bytes
+	}
+	return sum
+}
+
+func (s *simulation) finish() {
+	servers := make(map[string]bool)
+	for _, m := range s.service.Mappings {
+		servers[m.Address] = true
+		nodes, bytes := int64(0), int64(0)
+		for _, e := range s.nodes[m.Address] {
+			if e.hash >= m.Left && e.hash <= m.Right {
+				nodes += 1
+				bytes += e.bytes
+			}
+		}
+		s.report.Ranges = append(s.report.Ranges, Assignment{
+			Left:   m.Left,
+			Right:  m.Right,
+			Server: m.Address,
+			Nodes:  int64(float64(nodes) * s.scale),
+			Bytes:  int64(float64(bytes) * s.scale),
+		})
+	}
+	sort.Slice(s.report.Ranges, func(i, j int) bool {
+		return s.report.Ranges[i].Left < s.report.Ranges[j].Left
+	})
+	s.report.Servers = len(servers)
+}
+
+func printReport(report *Report) {
+	fmt.Printf("Policy: threshold %d nodes", report.Policy.Threshold)
+	if report.Policy.ThresholdBytes > 0 {
+		fmt.Printf(", %d bytes", report.Policy.ThresholdBytes)
+	}
+	fmt.Printf(", split by load: %v\n", report.Policy.SplitByLoad)
+	fmt.Printf("Writes: %d (each simulated write stands for %.2f)\n", report.Writes, report.Scale)
+	fmt.Printf("Splits: %d, servers needed: %d\n", len(report.Splits), report.Servers)
+	if report.Blocked > 0 {
+		fmt.Printf("Servers over the threshold without an available server: %d\n", report.Blocked)
+	}
+
+	fmt.Printf("\nAFTER_WRITES\tAFTER_HOURS\tSERVER\tRANGE\tMID\tTARGET\tMOVED_NODES\n")
+	for _, split := range report.Splits {
+		fmt.Printf("%d\t%.2f\t%s\t%08x-%08x\t%08x\t%s\t%d\n", split.AfterWrites, split.AfterHours,
+			split.Server, split.Left, split.Right, split.Mid, split.Target, split.MovedNodes)
+	}
+	fmt.Printf("\nRANGE\tSERVER\tNODES\tBYTES\n")
+	for _, r := range report.Ranges {
+		fmt.Printf("%08x-%08x\t%s\t%d\t%d\n", r.Left, r.Right, r.Server, r.Nodes, r.Bytes)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Entry of the replay log written by servers with replayLog set
+type Record struct {
+	Time     int64
+	Server   string
+	ID       uint64
+	Method   string
+	Origin   string
+	Request  json.RawMessage
+	Response json.RawMessage
+	Code     string
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: dbreplay [-server address] [-secret-file path] [-speed 0] [-check] log...
+
+Replays client requests recorded in the replay logs of all servers
+against a fresh cluster in the original order, and reports the first
+operation whose outcome differs or leaves nodes invalid or misrouted.
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	address := flag.String("server", "localhost:9000", "address of the seed of the fresh cluster")
+	secretFile := flag.String("secret-file", "", "file with the cluster secret (for admin requests)")
+	speed := flag.Float64("speed", 0, "replay N times faster than recorded (0 for no delay)")
+	check := flag.Bool("check", true, "check integrity and placement of all servers after each operation")
+	internal := flag.Bool("include-internal", false, "also replay requests sent by servers to each other")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var interceptors []grpc.UnaryClientInterceptor
+	if len(*secretFile) > 0 {
+		data, err := ioutil.ReadFile(*secretFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		secret := []byte(strings.TrimSpace(string(data)))
+		interceptors = append(interceptors, auth.Signer{Secret: secret}.UnaryClientInterceptor)
+	}
+	dial := func(address string) *grpc.ClientConn {
+		conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
+		if err != nil {
+			log.Fatalf("Cannot connect: %v", err)
+		}
+		return conn
+	}
+	conn := dial(*address)
+	defer conn.Close()
+
+	records, err := readLogs(flag.Args(), *internal)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("Replaying %d operations\n", len(records))
+
+	ctx := context.Background()
+	client := db.NewDbServiceClient(conn)
+	conns := map[string]*grpc.ClientConn{*address: conn}
+	locations := make(map[uint64]uint64)
+	for i, record := range records {
+		if i > 0 && *speed > 0 {
+			time.Sleep(time.Duration(float64(record.Time-records[i-1].Time) / *speed))
+		}
+		if err := replay(ctx, conn, record, locations); err != nil {
+			fail(i, record, err)
+		}
+		if !*check {
+			continue
+		}
+		membership, err := client.ListServers(ctx, &db.Empty{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+		servers := membership.Servers
+		if len(servers) == 0 {
+			servers = []string{*address}
+		}
+		for _, server := range servers {
+			if conns[server] == nil {
+				conns[server] = dial(server)
+				defer conns[server].Close()
+			}
+			if err := checkServer(ctx, db.NewDbServiceClient(conns[server])); err != nil {
+				fail(i, record, fmt.Errorf("%s: %v", server, err))
+			}
+		}
+	}
+	fmt.Println("All operations replayed with the recorded outcome")
+}
+
+// Client requests of all logs in the order they started
+func readLogs(paths []string, internal bool) ([]*Record, error) {
+	var records []*Record
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(nil, 64<<20)
+		for line := 1; scanner.Scan(); line++ {
+			record := &Record{}
+			if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+			}
+			if record.Origin == "client" || internal {
+				records = append(records, record)
+			}
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		if a.Server != b.Server {
+			return a.Server < b.Server
+		}
+		return a.ID < b.ID
+	})
+	return records, nil
+}
+
+func fail(i int, record *Record, err error) {
+	fmt.Printf("Operation %d diverged: %s (server %s, id %d, at %s)\n  request: %s\n  %v\n",
+		i+1, record.Method, record.Server, record.ID,
+		time.Unix(0, record.Time).Format(time.RFC3339Nano), record.Request, err)
+	os.Exit(1)
+}
+
+// Message types of the method (/db.DbService/Name)
+func methodTypes(method string) (protoreflect.MessageType, protoreflect.MessageType, error) {
+	name := method[strings.LastIndex(method, "/")+1:]
+	m := db.File_db_proto.Services().Get(0).Methods().ByName(protoreflect.Name(name))
+	if m == nil {
+		return nil, nil, fmt.Errorf("Unknown method %s", method)
+	}
+	input, err := protoregistry.GlobalTypes.FindMessageByName(m.Input().FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	output, err := protoregistry.GlobalTypes.FindMessageByName(m.Output().FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	return input, output, nil
+}
+
+// Send the request and compare the outcome with the recorded one.
+// Locations in the request are translated to the ones created by the replay.
+func replay(ctx context.Context, conn *grpc.ClientConn, record *Record, locations map[uint64]uint64) error {
+	inputType, outputType, err := methodTypes(record.Method)
+	if err != nil {
+		return err
+	}
+	request := inputType.New().Interface()
+	if err := protojson.Unmarshal(record.Request, request); err != nil {
+		return err
+	}
+	remap(request.ProtoReflect(), locations)
+
+	response := outputType.New().Interface()
+	err = conn.Invoke(ctx, record.Method, request, response)
+	if code := status.Code(err).String(); code != record.Code {
+		return fmt.Errorf("got %s (%v), recorded %s", code, err, record.Code)
+	}
+	if err != nil || len(record.Response) == 0 {
+		return nil
+	}
+	recorded := outputType.New().Interface()
+	if err := protojson.Unmarshal(record.Response, recorded); err != nil {
+		return err
+	}
+	learn(recorded.ProtoReflect(), response.ProtoReflect(), locations)
+	return nil
+}
+
+func isLocation(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.Uint64Kind || fd.Kind() == protoreflect.Fixed64Kind
+}
+
+// Replace recorded locations with replayed ones
+func remap(m protoreflect.Message, locations map[uint64]uint64) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				if fd.Message() != nil {
+					remap(list.Get(i).Message(), locations)
+				} else if isLocation(fd) {
+					if l, ok := locations[list.Get(i).Uint()]; ok {
+						list.Set(i, protoreflect.ValueOfUint64(l))
+					}
+				}
+			}
+		case fd.Message() != nil:
+			remap(v.Message(), locations)
+		case isLocation(fd):
+			if l, ok := locations[v.Uint()]; ok {
+				m.Set(fd, protoreflect.ValueOfUint64(l))
+			}
+		}
+		return true
+	})
+}
+
+// Pair locations of the recorded and replayed responses
+func learn(recorded, replayed protoreflect.Message, locations map[uint64]uint64) {
+	recorded.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if !replayed.Has(fd) {
+			return true
+		}
+		w := replayed.Get(fd)
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			a, b := v.List(), w.List()
+			for i := 0; i < a.Len() && i < b.Len(); i++ {
+				if fd.Message() != nil {
+					learn(a.Get(i).Message(), b.Get(i).Message(), locations)
+				} else if isLocation(fd) {
+					locations[a.Get(i).Uint()] = b.Get(i).Uint()
+				}
+			}
+		case fd.Message() != nil:
+			learn(v.Message(), w.Message(), locations)
+		case isLocation(fd):
+			locations[v.Uint()] = w.Uint()
+		}
+		return true
+	})
+}
+
+func checkServer(ctx context.Context, client db.DbServiceClient) error {
+	integrity, err := client.CheckIntegrity(ctx, &db.Empty{})
+	if err != nil {
+		return err
+	}
+	if integrity.InvalidLocations > 0 || integrity.Misrouted > 0 {
+		return fmt.Errorf("%d nodes at invalid locations and %d misrouted: %x",
+			integrity.InvalidLocations, integrity.Misrouted, integrity.Locations)
+	}
+	placement, err := client.VerifyPlacement(ctx, &db.Empty{})
+	if err != nil {
+		return err
+	}
+	if placement.Misplaced > 0 {
+		return fmt.Errorf("%d nodes misplaced: %x", placement.Misplaced, placement.Locations)
+	}
+	return nil
+}
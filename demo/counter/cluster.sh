@@ -0,0 +1,32 @@
+#!/bin/sh
+# Run a cluster of three servers on localhost (usage: cluster.sh [start|stop])
+set -e
+DIR=${CLUSTER_DIR:-/tmp/openwhisk-grpc-counter}
+ROOT=$(cd "$(dirname "$0")/../.." && pwd)
+
+if [ "$1" = "stop" ]; then
+	for pid in "$DIR"/*/pid; do
+		kill "$(cat "$pid")" 2>/dev/null || true
+		rm -f "$pid"
+	done
+	exit 0
+fi
+
+mkdir -p "$DIR"
+(cd "$ROOT" && go build -o "$DIR/server" ./server)
+
+for i in 1 2 3; do
+	mkdir -p "$DIR/s$i"
+	cat > "$DIR/s$i/server.json" <<CONFIG
+{
+	"self": "localhost:900$i",
+	"seed": "localhost:9001",
+	"servers": ["localhost:9001", "localhost:9002", "localhost:9003"],
+	"availableServers": ["localhost:9002", "localhost:9003"],
+	"threshold": 100
+}
+CONFIG
+	(cd "$DIR/s$i" && "$DIR/server" > server.log 2>&1 & echo $! > "$DIR/s$i/pid")
+done
+
+echo "Cluster running in $DIR (stop with $0 stop)"
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/client"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc"
+)
+
+// Same logic as the increment action (used without OpenWhisk)
+func increment(ctx context.Context, branch *client.Branch) error {
+	res, err := branch.Get(ctx, "counter")
+	if err != nil {
+		return err
+	}
+	value, _ := strconv.Atoi(string(res.Value))
+	_, err = branch.Set(ctx, "counter", []byte(strconv.Itoa(value+1)))
+	return err
+}
+
+func main() {
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	servers := flag.String("servers", "localhost:9001,localhost:9002,localhost:9003", "comma-separated db servers")
+	n := flag.Int("n", 20, "number of concurrent increments")
+	action := flag.String("action", "", "increment action to invoke (increment directly if empty)")
+	merge := flag.String("merge", "counter-merge", "merge action")
+	timeout := flag.Duration("timeout", time.Minute, "time to wait for merges")
+	flag.Parse()
+
+	var clients []*client.Client
+	addresses := strings.Split(*servers, ",")
+	for _, address := range addresses {
+		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		if err != nil {
+			log.Fatalf("Cannot connect: %v", err)
+		}
+		defer conn.Close()
+		clients = append(clients, client.New(db.NewDbServiceClient(conn)))
+	}
+	ctx := context.Background()
+
+	root, err := clients[0].CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		log.Fatalln(err)
+	}
+	base := client.NewBranch(clients[0], root.Location)
+	if _, err := base.Set(ctx, "counter", []byte("0")); err != nil {
+		log.Fatalln(err)
+	}
+	if _, err := clients[0].SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
+		Location: base.Head(),
+		Name:     *merge,
+	}); err != nil {
+		log.Fatalln(err)
+	}
+
+	// Increments through different servers
+	var wg sync.WaitGroup
+	for i := 0; i < *n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if len(*action) > 0 {
+				params, _ := json.Marshal(map[string]interface{}{
+					"server":   addresses[i%len(addresses)],
+					"location": base.Head(),
+				})
+				_, err = utils.CallAction(*action, params)
+			} else {
+				// Each increment forks from the base, so they become siblings
+				err = increment(ctx, client.NewBranch(clients[i%len(clients)], base.Head()))
+			}
+			if err != nil {
+				log.Printf("Increment %d failed: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Wait until all increments are merged into one child
+	waitCtx, cancel := context.WithTimeout(ctx, *timeout)
+	report, err := clients[0].WaitConverged(waitCtx, root.Location)
+	cancel()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if !report.Converged {
+		log.Fatalf("Not converged after %v: %d mergeable parents, %d pending merges", *timeout, report.Mergeable, report.PendingMerges)
+	}
+	node, err := clients[0].GetNode(ctx, &db.GetNodeRequest{Location: base.Head()})
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if len(node.Children) != 1 {
+		log.Fatalf("Still %d children after convergence", len(node.Children))
+	}
+	res, err := clients[0].Get(ctx, "counter", node.Children[0])
+	if err != nil {
+		log.Fatalln(err)
+	}
+	value, _ := strconv.Atoi(string(res.Value))
+	if value != *n {
+		log.Fatalf("Counter is %d (expected %d)", value, *n)
+	}
+	fmt.Printf("Counter is %d\n", value)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/DCsunset/openwhisk-grpc/client"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+)
+
+type Argument struct {
+	Server   string `json:"server"`
+	Location uint64 `json:"location"`
+}
+
+func main() {
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	// parse json args
+	var args Argument
+	json.Unmarshal([]byte(os.Args[1]), &args)
+
+	conn, err := grpc.Dial(args.Server, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("Cannot connect: %v", err)
+	}
+	defer conn.Close()
+
+	// The node read is the dep so that concurrent increments become siblings
+	branch := client.NewBranch(client.New(db.NewDbServiceClient(conn)), args.Location)
+	ctx := context.Background()
+
+	res, err := branch.Get(ctx, "counter")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	value, _ := strconv.Atoi(string(res.Value))
+
+	if _, err := branch.Set(ctx, "counter", []byte(strconv.Itoa(value+1))); err != nil {
+		log.Fatalln(err)
+	}
+
+	fmt.Printf("{\"location\": %d}\n", branch.Head())
+}
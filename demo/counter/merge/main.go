@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/DCsunset/openwhisk-grpc/mergeapi"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Sum the increments of divergent children over the parent
+func main() {
+	var input mergeapi.Input
+	if err := json.Unmarshal([]byte(os.Args[1]), &input); err != nil {
+		log.Fatalln(err)
+	}
+	if input.Version != mergeapi.Version {
+		log.Fatalf("Unsupported version %d", input.Version)
+	}
+
+	base := 0
+	if input.Parent.Key == "counter" {
+		base, _ = strconv.Atoi(string(input.Parent.Value))
+	}
+
+	var children []mergeapi.Node
+	total := base
+	counters := 0
+	for _, child := range input.Children {
+		if child.Key == "counter" {
+			value, _ := strconv.Atoi(string(child.Value))
+			total += value - base
+			counters += 1
+		} else {
+			// Keep other nodes
+			children = append(children, child.Node)
+		}
+	}
+	if counters > 0 {
+		children = append(children, mergeapi.FromProto(storage.CreateNode(
+			"counter",
+			[]byte(strconv.Itoa(total)),
+			input.Parent.Location,
+		)))
+	}
+
+	utils.Print(mergeapi.Output{
+		Version: mergeapi.Version,
+		Nodes:   children,
+	})
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/embedded"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Same logic as the voting-merge action
+func mergeVotes(d *embedded.DB, parent *db.Node) ([]*db.Node, error) {
+	var children []*db.Node
+	votesNum := 0
+	maxVotes := 0
+	for _, child := range parent.Children {
+		node, err := d.GetNode(child)
+		if err != nil {
+			return nil, err
+		}
+
+		if node.Key == "votes" {
+			votesNum += 1
+			if maxVotes < utils.Str2Int(string(node.Value)) {
+				maxVotes = utils.Str2Int(string(node.Value))
+			}
+		} else {
+			// Keep other nodes
+			children = append(children, node)
+		}
+	}
+	children = append(children, storage.CreateNode(
+		"votes",
+		[]byte(strconv.Itoa(maxVotes+votesNum-1)),
+		parent.Location,
+	))
+	return children, nil
+}
+
+func main() {
+	d := embedded.New(embedded.Options{
+		Resolvers: map[string]embedded.Resolver{
+			"voting-merge": mergeVotes,
+		},
+		OnConflict: func(parent *db.Node) {
+			log.Printf("Conflict at %x (%d children)", parent.Location, len(parent.Children))
+		},
+	})
+	d.SetGlobalMergeFunction("voting-merge", engine.Policy{})
+
+	root, err := d.CreateRoot()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	base, err := d.Set("votes", []byte("0"), root)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Concurrent votes on the same version
+	for i := 0; i < 3; i++ {
+		if _, err := d.Set("votes", []byte("1"), base); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	node, err := d.GetNode(base)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	value, err := d.Get("votes", node.Children[0])
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Votes: %s", value)
+}
@@ -1,71 +1,48 @@
 package main
 
 import (
-	"context"
-	"crypto/tls"
 	"encoding/json"
 	"log"
-	"math/rand"
-	"net/http"
 	"os"
 	"strconv"
 
-	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/mergeapi"
 	"github.com/DCsunset/openwhisk-grpc/storage"
 	"github.com/DCsunset/openwhisk-grpc/utils"
-	"google.golang.org/grpc"
 )
 
-// Server list
-var addresses = [...]string{"aqua02:9000", "aqua03:9000", "aqua04:9000", "aqua05:9000"}
-
 func main() {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-
 	// parse json args
-	var parent *db.Node
-	json.Unmarshal([]byte(os.Args[1]), &parent)
-
-	// Randomly choose one server
-	address := addresses[rand.Intn(len(addresses))]
-
-	conn, err := grpc.Dial(address, grpc.WithInsecure())
-	if err != nil {
-		log.Fatalf("Cannot connect: %v", err)
+	var input mergeapi.Input
+	if err := json.Unmarshal([]byte(os.Args[1]), &input); err != nil {
+		log.Fatalln(err)
+	}
+	if input.Version != mergeapi.Version {
+		log.Fatalf("Unsupported version %d", input.Version)
 	}
-	defer conn.Close()
-
-	client := db.NewDbServiceClient(conn)
-	ctx := context.Background()
 
-	var children []*db.Node
+	var children []mergeapi.Node
 	votesNum := 0
 	maxVotes := 0
-	for _, child := range parent.Children {
-		node, err := client.GetNode(ctx, &db.GetNodeRequest{
-			Location: child,
-		})
-		if err != nil {
-			log.Fatalln(err)
-		}
-
-		if node.Key == "votes" {
+	for _, child := range input.Children {
+		if child.Key == "votes" {
 			votesNum += 1
-			if maxVotes < utils.Str2Int(node.Value) {
-				maxVotes = utils.Str2Int(node.Value)
+			if maxVotes < utils.Str2Int(string(child.Value)) {
+				maxVotes = utils.Str2Int(string(child.Value))
 			}
 		} else {
 			// Keep other nodes
-			children = append(children, node)
+			children = append(children, child.Node)
 		}
 	}
-	children = append(children, storage.CreateNode(
+	children = append(children, mergeapi.FromProto(storage.CreateNode(
 		"votes",
-		strconv.Itoa(maxVotes+votesNum-1),
-		parent.Location,
-	))
+		[]byte(strconv.Itoa(maxVotes+votesNum-1)),
+		input.Parent.Location,
+	)))
 
-	utils.Print(db.Nodes{
-		Nodes: children,
+	utils.Print(mergeapi.Output{
+		Version: mergeapi.Version,
+		Nodes:   children,
 	})
 }
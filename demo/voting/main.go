@@ -25,12 +25,12 @@ func vote(ctx context.Context, client db.DbServiceClient, name string) {
 		// Not found
 		votes = 1
 	} else {
-		votes, _ = strconv.Atoi(res.Value)
+		votes, _ = strconv.Atoi(string(res.Value))
 		votes += 1
 	}
 	setRequest := &db.SetRequest{
 		Key:   name,
-		Value: strconv.Itoa(votes),
+		Value: []byte(strconv.Itoa(votes)),
 		Dep:   0,
 	}
 
@@ -0,0 +1,143 @@
+package embedded
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+)
+
+// Max runs of a merge whose children are changed by its resolver
+const maxMergeRuns = 3
+
+// Resolve conflicts of the parent in process.
+// Same contract as merge actions: return the new children of the parent.
+type Resolver func(d *DB, parent *db.Node) ([]*db.Node, error)
+
+type Options struct {
+	// Resolvers referenced by name in merge functions
+	Resolvers map[string]Resolver
+	// Called after a conflict is detected (before merging)
+	OnConflict func(parent *db.Node)
+	// Where nodes are kept (in memory if nil)
+	Backend storage.Backend
+}
+
+// Single-node database without gRPC
+type DB struct {
+	engine  engine.Engine
+	options Options
+	// Writes and merges are serialized
+	lock sync.Mutex
+}
+
+func New(options Options) *DB {
+	d := &DB{options: options}
+	d.engine.Store.Backend = options.Backend
+	d.engine.Init()
+	return d
+}
+
+// Create a root for an independent DAG
+func (d *DB) CreateRoot() (uint64, error) {
+	root := storage.CreateRoot()
+	if _, err := d.engine.Store.AddNode(root); err != nil {
+		return 0, err
+	}
+	return root.Location, nil
+}
+
+func (d *DB) Get(key string, location uint64) ([]byte, error) {
+	node, err := d.engine.Get(key, location)
+	if err != nil {
+		return nil, err
+	}
+	return node.Value, nil
+}
+
+// Get the newest value of the key at or before the time
+func (d *DB) GetAsOf(key string, location uint64, asOf time.Time) ([]byte, error) {
+	walk, err := d.engine.Walk(context.Background(), key, location, asOf.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	return walk.Node.Value, nil
+}
+
+func (d *DB) GetNode(location uint64) (*db.Node, error) {
+	return d.engine.GetNode(location)
+}
+
+// Set the key on top of dep and merge if there's a conflict
+func (d *DB) Set(key string, value []byte, dep uint64) (uint64, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if dep == 0 {
+		return 0, fmt.Errorf("Dep is required (create a root with CreateRoot)")
+	}
+
+	loc, err := d.engine.Write(key, value, dep, nil, 0, "", db.NodeType_REGULAR)
+	if err != nil {
+		return 0, err
+	}
+	parent, conflict, err := d.engine.Link(dep, loc)
+	if err != nil {
+		d.engine.Store.RemoveNode(loc)
+		return 0, err
+	}
+	_, policy := d.engine.MergeFunction(dep)
+	if conflict && policy.Conflict(len(parent.Children)) {
+		if d.options.OnConflict != nil {
+			d.options.OnConflict(parent)
+		}
+		if err := d.merge(parent); err != nil {
+			return loc, err
+		}
+	}
+	return loc, nil
+}
+
+// Debounce of the policy is ignored since merges are synchronous
+func (d *DB) merge(parent *db.Node) error {
+	name, policy := d.engine.MergeFunction(parent.Location)
+	if len(name) == 0 {
+		return nil
+	}
+	resolver, ok := d.options.Resolvers[name]
+	if !ok {
+		return fmt.Errorf("Resolver %s not found", name)
+	}
+	for run := 1; ; run++ {
+		batch, rest := policy.Batch(parent.Children)
+		parent.Children = batch
+		children, err := resolver(d, parent)
+		if err != nil {
+			return err
+		}
+		err = d.engine.ApplyMerge(parent.Location, parent.ChildrenVersion, children, rest)
+		if !errors.Is(err, dberrors.ErrChildrenChanged) || run >= maxMergeRuns {
+			return err
+		}
+		// Resolve the children added meanwhile too
+		node := d.engine.Store.GetNode(parent.Location)
+		if node == nil || len(node.Children) < 2 {
+			return nil
+		}
+		parent = node.ToProto()
+	}
+}
+
+func (d *DB) SetMergeFunction(location uint64, name string, policy engine.Policy) {
+	d.engine.SetMergeFunction(location, name, policy)
+}
+
+func (d *DB) SetGlobalMergeFunction(name string, policy engine.Policy) {
+	d.engine.SetGlobalMergeFunction(name, policy)
+}
@@ -0,0 +1,461 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Engine holds the nodes owned by one process,
+// links children and detects conflicts.
+// It knows nothing about other servers.
+type Engine struct {
+	Store storage.Store
+
+	lock sync.RWMutex
+	// Merge functions by parent location
+	mergeFunction map[uint64]registration
+	// By key and key prefix of the parent
+	keyMergeFunction    map[string]registration
+	prefixMergeFunction map[string]registration
+	// By key hash range of the parent location
+	rangeMergeFunction  []rangeRegistration
+	globalMergeFunction registration
+}
+
+func (e *Engine) Init() {
+	e.Store.Init()
+	e.mergeFunction = make(map[uint64]registration)
+	e.keyMergeFunction = make(map[string]registration)
+	e.prefixMergeFunction = make(map[string]registration)
+	e.rangeMergeFunction = nil
+	e.globalMergeFunction = registration{}
+}
+
+// Create a node without linking it to its dep (expiresAt is 0 for never)
+func (e *Engine) Write(key string, value []byte, dep uint64, metadata map[string]string, expiresAt int64, contentType string, nodeType db.NodeType) (uint64, error) {
+	return e.Store.Set(key, value, dep, metadata, expiresAt, contentType, nodeType)
+}
+
+// Create a node at the location without linking it
+func (e *Engine) WriteAt(location uint64, key string, value []byte, dep uint64, metadata map[string]string, expiresAt int64, contentType string, nodeType db.NodeType) error {
+	return e.Store.SetAt(location, key, value, dep, metadata, expiresAt, contentType, nodeType)
+}
+
+// How long a merge claimed by Link keeps others from starting one
+// (until the children are replaced, e.g. when the merge fails)
+var MergeClaimTimeout = 30 * time.Second
+
+// Add child to a local node.
+// Returns the parent and whether it is in conflict (more than one child).
+// ConflictDetected of the parent is set for one child at a time once it
+// reaches the threshold of its merge function: the merge is claimed
+// under the store lock until it is applied (see Store.AddChildClaim).
+func (e *Engine) Link(location uint64, child uint64) (*db.Node, bool, error) {
+	name, policy := e.MergeFunction(location)
+	threshold := policy.Threshold()
+	if len(name) == 0 {
+		// Nothing to claim
+		threshold = math.MaxInt32
+	}
+	node, _, claimed, err := e.Store.AddChildClaim(location, child, threshold, MergeClaimTimeout)
+	if err != nil {
+		return nil, false, err
+	}
+	if node == nil {
+		return nil, false, &dberrors.LocationNotFoundError{Location: location}
+	}
+	node, err = e.Store.Load(node)
+	if err != nil {
+		return nil, false, err
+	}
+	parent := node.ToProto()
+	parent.ConflictDetected = claimed
+	return parent, len(parent.Children) > 1, nil
+}
+
+// Walks stop when less time than this is left before the deadline
+var DeadlineMargin = 5 * time.Millisecond
+
+type Walk struct {
+	// Nearest node defining the key (nil if the walk stopped)
+	Node *storage.Node
+	// Location to continue from
+	Next uint64
+	// Next is not stored locally
+	Remote bool
+	// Stopped because the deadline is near
+	DeadlineExceeded bool
+	// Nodes visited
+	Depth int
+}
+
+// Walk the chain from the location towards the root,
+// checking the deadline of ctx at every node.
+// Nodes created after asOf are skipped (unless asOf is 0),
+// and expiry is checked at asOf.
+func (e *Engine) Walk(ctx context.Context, key string, location uint64, asOf int64) (*Walk, error) {
+	deadline, hasDeadline := ctx.Deadline()
+	now := time.Now().UnixNano()
+	if asOf != 0 {
+		now = asOf
+	}
+	for depth := 1; ; depth++ {
+		if hasDeadline && time.Until(deadline) < DeadlineMargin {
+			return &Walk{Next: location, DeadlineExceeded: true, Depth: depth - 1}, nil
+		}
+		node := e.Store.GetNode(location)
+		if node == nil {
+			return &Walk{Next: location, Remote: true, Depth: depth - 1}, nil
+		}
+		// Expired nodes are skipped like other keys
+		if node.Defines(key) && asOf != 0 && node.CompressedSince != 0 && node.CompressedSince <= asOf && asOf < node.CreatedAt {
+			// The value at asOf was collapsed into this node
+			return nil, &dberrors.HistoryCompressedError{Key: key, Location: node.Location}
+		}
+		if node.Defines(key) && !node.Expired(now) && (asOf == 0 || node.CreatedAt <= asOf) {
+			node, err := e.Store.Load(node)
+			if err != nil {
+				return nil, err
+			}
+			return &Walk{Node: node, Depth: depth}, nil
+		}
+		if node.Dep == math.MaxUint64 {
+			return nil, &dberrors.KeyNotFoundError{Key: key}
+		}
+		location = node.Dep
+	}
+}
+
+// Find the nearest node defining the key
+func (e *Engine) Get(key string, location uint64) (*storage.Node, error) {
+	return e.Store.Resolve(key, location)
+}
+
+func (e *Engine) GetNode(location uint64) (*db.Node, error) {
+	node, err := e.Store.Load(e.Store.GetNode(location))
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, &dberrors.LocationNotFoundError{Location: location}
+	}
+	return node.ToProto(), nil
+}
+
+// When and how much to merge
+type Policy struct {
+	// Merge when the parent has at least this many children
+	MinChildren int
+	// Wait until no child is added for this long before merging
+	Debounce time.Duration
+	// Max children resolved per invocation (0 means all)
+	MaxBatch int
+}
+
+func PolicyFromProto(p *db.MergePolicy) Policy {
+	policy := Policy{MinChildren: 2}
+	if p == nil {
+		return policy
+	}
+	if p.MinChildren > 0 {
+		policy.MinChildren = int(p.MinChildren)
+	}
+	policy.Debounce = time.Duration(p.DebounceMillis) * time.Millisecond
+	policy.MaxBatch = int(p.MaxBatch)
+	return policy
+}
+
+func (p Policy) ToProto() *db.MergePolicy {
+	return &db.MergePolicy{
+		MinChildren:    int32(p.MinChildren),
+		DebounceMillis: p.Debounce.Milliseconds(),
+		MaxBatch:       int32(p.MaxBatch),
+	}
+}
+
+// Number of children from which the parent is merged
+func (p Policy) Threshold() int {
+	if p.MinChildren < 2 {
+		return 2
+	}
+	return p.MinChildren
+}
+
+// Whether the parent should be merged with the policy
+func (p Policy) Conflict(children int) bool {
+	return children >= p.Threshold()
+}
+
+// Split children into the batch to resolve and the rest
+func (p Policy) Batch(children []uint64) ([]uint64, []uint64) {
+	if p.MaxBatch <= 0 || len(children) <= p.MaxBatch {
+		return children, nil
+	}
+	return children[:p.MaxBatch], children[p.MaxBatch:]
+}
+
+type registration struct {
+	name   string
+	policy Policy
+}
+
+type rangeRegistration struct {
+	left, right uint32
+	registration
+}
+
+// Scopes of registrations in order of precedence
+const (
+	ScopeLocation = "location"
+	ScopeKey      = "key"
+	ScopePrefix   = "prefix"
+	ScopeRange    = "range"
+	ScopeGlobal   = "global"
+)
+
+// Empty name removes the merge function
+func (e *Engine) SetMergeFunction(location uint64, name string, policy Policy) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(name) == 0 {
+		delete(e.mergeFunction, location)
+	} else {
+		e.mergeFunction[location] = registration{name, policy}
+	}
+}
+
+// Merge function of parents with the key (empty name removes it)
+func (e *Engine) SetKeyMergeFunction(key string, name string, policy Policy) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(name) == 0 {
+		delete(e.keyMergeFunction, key)
+	} else {
+		e.keyMergeFunction[key] = registration{name, policy}
+	}
+}
+
+// Merge function of parents with keys starting with the prefix (empty name removes it)
+func (e *Engine) SetPrefixMergeFunction(prefix string, name string, policy Policy) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(name) == 0 {
+		delete(e.prefixMergeFunction, prefix)
+	} else {
+		e.prefixMergeFunction[prefix] = registration{name, policy}
+	}
+}
+
+// Merge function of parents at locations with key hash in [left, right]
+// (empty name removes it)
+func (e *Engine) SetRangeMergeFunction(left, right uint32, name string, policy Policy) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	ranges := e.rangeMergeFunction[:0:0]
+	for _, r := range e.rangeMergeFunction {
+		if r.left != left || r.right != right {
+			ranges = append(ranges, r)
+		}
+	}
+	if len(name) > 0 {
+		ranges = append(ranges, rangeRegistration{left, right, registration{name, policy}})
+	}
+	e.rangeMergeFunction = ranges
+}
+
+func (e *Engine) SetGlobalMergeFunction(name string, policy Policy) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.globalMergeFunction = registration{name, policy}
+}
+
+// Merge function for the local parent (see Resolve for the precedence)
+func (e *Engine) MergeFunction(location uint64) (string, Policy) {
+	e.lock.RLock()
+	r, ok := e.mergeFunction[location]
+	// The key is only needed by key and prefix registrations
+	scoped := len(e.keyMergeFunction) > 0 || len(e.prefixMergeFunction) > 0
+	e.lock.RUnlock()
+	if ok {
+		return r.name, r.policy
+	}
+
+	key := ""
+	if scoped {
+		if node := e.Store.GetNode(location); node != nil && !node.Keyless {
+			key = node.Key
+		}
+	}
+	matches := e.Resolve(location, true, key, utils.KeyHash(location))
+	if len(matches) == 0 {
+		return "", Policy{}
+	}
+	return matches[0].Name, PolicyFromProto(matches[0].Policy)
+}
+
+// Registrations applying to a parent, the one that fires first:
+// location > key > longest prefix > narrowest range > global.
+// The key is empty for roots and buckets, and the location
+// is ignored unless hasLocation (to resolve a key alone).
+func (e *Engine) Resolve(location uint64, hasLocation bool, key string, hash uint32) []*db.MergeRegistration {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	var matches []*db.MergeRegistration
+	if r, ok := e.mergeFunction[location]; ok && hasLocation {
+		matches = append(matches, &db.MergeRegistration{Location: location, Name: r.name, Policy: r.policy.ToProto()})
+	}
+	if len(key) > 0 {
+		if r, ok := e.keyMergeFunction[key]; ok {
+			matches = append(matches, &db.MergeRegistration{Key: key, Name: r.name, Policy: r.policy.ToProto()})
+		}
+		var prefixes []string
+		for prefix := range e.prefixMergeFunction {
+			if strings.HasPrefix(key, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+		sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+		for _, prefix := range prefixes {
+			r := e.prefixMergeFunction[prefix]
+			matches = append(matches, &db.MergeRegistration{Prefix: prefix, Name: r.name, Policy: r.policy.ToProto()})
+		}
+	}
+	var ranges []rangeRegistration
+	for _, r := range e.rangeMergeFunction {
+		if r.left <= hash && hash <= r.right {
+			ranges = append(ranges, r)
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].right-ranges[i].left < ranges[j].right-ranges[j].left })
+	for _, r := range ranges {
+		matches = append(matches, &db.MergeRegistration{Ranged: true, Left: r.left, Right: r.right, Name: r.name, Policy: r.policy.ToProto()})
+	}
+	if len(e.globalMergeFunction.name) > 0 {
+		matches = append(matches, &db.MergeRegistration{Global: true, Name: e.globalMergeFunction.name, Policy: e.globalMergeFunction.policy.ToProto()})
+	}
+	return matches
+}
+
+// Scope of the registration
+func Scope(r *db.MergeRegistration) string {
+	switch {
+	case r.Global:
+		return ScopeGlobal
+	case r.Ranged:
+		return ScopeRange
+	case len(r.Prefix) > 0:
+		return ScopePrefix
+	case len(r.Key) > 0:
+		return ScopeKey
+	}
+	return ScopeLocation
+}
+
+// Merge function registered for the location only
+func (e *Engine) LocalMergeFunction(location uint64) (string, Policy, bool) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	r, ok := e.mergeFunction[location]
+	return r.name, r.policy, ok
+}
+
+// All registered merge functions (the global one first if set)
+func (e *Engine) Registrations() []*db.MergeRegistration {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	var registrations []*db.MergeRegistration
+	if len(e.globalMergeFunction.name) > 0 {
+		registrations = append(registrations, &db.MergeRegistration{
+			Name:   e.globalMergeFunction.name,
+			Policy: e.globalMergeFunction.policy.ToProto(),
+			Global: true,
+		})
+	}
+	for location, r := range e.mergeFunction {
+		registrations = append(registrations, &db.MergeRegistration{
+			Location: location,
+			Name:     r.name,
+			Policy:   r.policy.ToProto(),
+		})
+	}
+	for key, r := range e.keyMergeFunction {
+		registrations = append(registrations, &db.MergeRegistration{
+			Key:    key,
+			Name:   r.name,
+			Policy: r.policy.ToProto(),
+		})
+	}
+	for prefix, r := range e.prefixMergeFunction {
+		registrations = append(registrations, &db.MergeRegistration{
+			Prefix: prefix,
+			Name:   r.name,
+			Policy: r.policy.ToProto(),
+		})
+	}
+	for _, r := range e.rangeMergeFunction {
+		registrations = append(registrations, &db.MergeRegistration{
+			Ranged: true,
+			Left:   r.left,
+			Right:  r.right,
+			Name:   r.name,
+			Policy: r.policy.ToProto(),
+		})
+	}
+	return registrations
+}
+
+// Replace children of a local parent with merged nodes.
+// All nodes must be local and depend on the parent.
+// Children in rest were not resolved and are kept as they are.
+// Fails with a ChildrenChangedError if the children are no longer at the version.
+func (e *Engine) ApplyMerge(parent uint64, version uint64, nodes []*db.Node, rest []uint64) error {
+	locations := append([]uint64(nil), rest...)
+	kept := make(map[uint64]bool)
+	for _, child := range rest {
+		kept[child] = true
+	}
+	for _, node := range nodes {
+		if node.Dep != parent {
+			return fmt.Errorf("Merged node %x does not depend on %x", node.Location, parent)
+		}
+		locations = append(locations, node.Location)
+		kept[node.Location] = true
+	}
+
+	for _, node := range nodes {
+		if _, err := e.Store.AddNode(node); err != nil {
+			return err
+		}
+	}
+	old, _, err := e.Store.ReplaceChildrenIf(parent, locations, version)
+	if err != nil {
+		for _, node := range nodes {
+			e.Store.RemoveNode(node.Location)
+		}
+		return err
+	}
+	for _, child := range old {
+		if !kept[child] {
+			e.Store.RemoveNode(child)
+		}
+	}
+	return nil
+}
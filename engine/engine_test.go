@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+)
+
+func newTestEngine(t *testing.T) (*Engine, uint64) {
+	t.Helper()
+	e := &Engine{}
+	e.Init()
+	parent, err := e.Write("p", []byte("0"), math.MaxUint64, nil, 0, "", db.NodeType_REGULAR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e, parent
+}
+
+// Link children concurrently and count the writers told to run the merge
+func linkConcurrently(t *testing.T, e *Engine, parent uint64, first, writers int) int64 {
+	t.Helper()
+	var resolvers int64
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(child uint64) {
+			defer wg.Done()
+			node, _, err := e.Link(parent, child)
+			if err != nil {
+				t.Errorf("Link failed: %v", err)
+				return
+			}
+			if node.ConflictDetected {
+				atomic.AddInt64(&resolvers, 1)
+			}
+		}(uint64(first + i))
+	}
+	wg.Wait()
+	return resolvers
+}
+
+func TestLinkConflictDetectedOnce(t *testing.T) {
+	tests := []struct {
+		name        string
+		minChildren int
+		writers     int
+		resolvers   int64
+	}{
+		{"default threshold", 0, 32, 1},
+		{"higher threshold", 5, 32, 1},
+		{"below threshold", 40, 32, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, parent := newTestEngine(t)
+			e.SetMergeFunction(parent, "merge", Policy{MinChildren: tt.minChildren})
+
+			if resolvers := linkConcurrently(t, e, parent, 100, tt.writers); resolvers != tt.resolvers {
+				t.Errorf("%d writers ran the merge, expected %d", resolvers, tt.resolvers)
+			}
+			// Retries of a child do not start another merge while one is in flight
+			if node, _, err := e.Link(parent, 100); err != nil || node.ConflictDetected {
+				t.Errorf("Retry returned %v, %v", node, err)
+			}
+		})
+	}
+}
+
+func TestLinkConflictAfterMerge(t *testing.T) {
+	e, parent := newTestEngine(t)
+	e.SetMergeFunction(parent, "merge", Policy{})
+
+	if resolvers := linkConcurrently(t, e, parent, 100, 8); resolvers != 1 {
+		t.Fatalf("%d writers ran the merge, expected 1", resolvers)
+	}
+	// Applying the merge releases it, even if children are still above the threshold
+	if _, err := e.Store.ReplaceChildren(parent, []uint64{100, 101}); err != nil {
+		t.Fatal(err)
+	}
+	if resolvers := linkConcurrently(t, e, parent, 200, 8); resolvers != 1 {
+		t.Errorf("%d writers ran the merge after the first one, expected 1", resolvers)
+	}
+}
+
+func TestLinkConflictClaimTimeout(t *testing.T) {
+	timeout := MergeClaimTimeout
+	MergeClaimTimeout = 10 * time.Millisecond
+	defer func() { MergeClaimTimeout = timeout }()
+
+	e, parent := newTestEngine(t)
+	e.SetMergeFunction(parent, "merge", Policy{})
+	if resolvers := linkConcurrently(t, e, parent, 100, 4); resolvers != 1 {
+		t.Fatalf("%d writers ran the merge, expected 1", resolvers)
+	}
+	// The merge failed without replacing the children
+	time.Sleep(2 * MergeClaimTimeout)
+	if resolvers := linkConcurrently(t, e, parent, 200, 4); resolvers != 1 {
+		t.Errorf("%d writers ran the merge after the claim timed out, expected 1", resolvers)
+	}
+}
+
+func TestLinkWithoutMergeFunction(t *testing.T) {
+	e, parent := newTestEngine(t)
+	if resolvers := linkConcurrently(t, e, parent, 100, 8); resolvers != 0 {
+		t.Errorf("%d writers ran a merge without merge function", resolvers)
+	}
+}
+
+// Backend taking a while to read each node
+type slowBackend struct {
+	*storage.MemoryBackend
+	latency time.Duration
+}
+
+func (b *slowBackend) GetNode(location uint64) (*storage.Node, error) {
+	time.Sleep(b.latency)
+	return b.MemoryBackend.GetNode(location)
+}
+
+// A walk near its deadline stops with the location to resume from,
+// and walks resumed from there reach the node defining the key
+func TestWalkResumesAfterDeadline(t *testing.T) {
+	backend := &slowBackend{MemoryBackend: storage.NewMemoryBackend()}
+	e := &Engine{Store: storage.Store{Backend: backend}}
+	e.Init()
+	const depth = 100
+	location, err := e.Write("k", []byte("v"), math.MaxUint64, nil, 0, "", db.NodeType_REGULAR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < depth; i++ {
+		if location, err = e.Write("other", []byte("x"), location, nil, 0, "", db.NodeType_REGULAR); err != nil {
+			t.Fatal(err)
+		}
+	}
+	head := location
+
+	backend.latency = time.Millisecond
+	attempts, visited := 0, 0
+	for ; attempts < depth; attempts++ {
+		ctx, cancel := context.WithTimeout(context.Background(), DeadlineMargin+20*time.Millisecond)
+		walk, err := e.Walk(ctx, "k", location, 0)
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		visited += walk.Depth
+		if !walk.DeadlineExceeded {
+			if walk.Node == nil || string(walk.Node.Value) != "v" {
+				t.Fatalf("Walk from %x returned %v", location, walk.Node)
+			}
+			break
+		}
+		if walk.Node != nil || walk.Remote || walk.Depth == 0 {
+			t.Fatalf("Walk stopped at the deadline returned %+v", walk)
+		}
+		location = walk.Next
+	}
+	if attempts == 0 || attempts == depth {
+		t.Fatalf("Walk from %x took %d attempts", head, attempts)
+	}
+	if visited != depth+1 {
+		t.Errorf("Resumed walks visited %d nodes instead of %d", visited, depth+1)
+	}
+
+	// Without deadline the walk is not stopped
+	backend.latency = 0
+	if walk, err := e.Walk(context.Background(), "k", head, 0); err != nil || walk.DeadlineExceeded || walk.Depth != depth+1 {
+		t.Errorf("Walk without deadline returned %+v, %v", walk, err)
+	}
+}
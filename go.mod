@@ -4,6 +4,8 @@ go 1.14
 
 require (
 	github.com/golang/protobuf v1.4.1
+	go.etcd.io/bbolt v1.3.6
+	golang.org/x/sys v0.7.0 // indirect
 	google.golang.org/grpc v1.30.0
 	google.golang.org/protobuf v1.25.0
 )
@@ -0,0 +1,77 @@
+package harness
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Increments applied concurrently through all servers are chained
+// on the owner of the key: the last value counts all of them and no sibling is created
+func TestApplyIncrementHammer(t *testing.T) {
+	c := startThree(t)
+	root := c.CreateRoot()
+	const (
+		writers    = 50
+		increments = 4
+	)
+	key := "applied"
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	seen := make(map[int64]bool)
+	var last *db.ApplyResponse
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := Context()
+			defer cancel()
+			for j := 0; j < increments; j++ {
+				resp, err := c.Nodes[i%len(c.Nodes)].Client.Apply(ctx, &db.ApplyRequest{
+					Key:       key,
+					Dep:       root,
+					Transform: "increment",
+					Arg:       []byte("1"),
+				})
+				if err != nil {
+					t.Errorf("Apply of writer %d failed: %v", i, err)
+					return
+				}
+				value, err := strconv.ParseInt(string(resp.Value), 10, 64)
+				if err != nil {
+					t.Errorf("Apply returned %q", resp.Value)
+					return
+				}
+				lock.Lock()
+				if seen[value] {
+					t.Errorf("Value %d returned twice", value)
+				}
+				seen[value] = true
+				if value == writers*increments {
+					last = resp
+				}
+				lock.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+	if last == nil {
+		t.Fatalf("No apply returned %d", writers*increments)
+	}
+
+	ctx, cancel := Context()
+	defer cancel()
+	for _, node := range c.Nodes {
+		value, err := node.Client.Get(ctx, key, last.Location)
+		if err != nil || string(value.Value) != strconv.Itoa(writers*increments) {
+			t.Errorf("Get from %s returned %v, %v", node.Address, value, err)
+		}
+	}
+	checkNoConflicts(t, c, key)
+}
@@ -0,0 +1,85 @@
+package harness
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Internal and admin RPCs not signed with the cluster secret are rejected
+// without changing the mapping, while splits between the servers still work
+func TestForgedSplit(t *testing.T) {
+	c := Start(t, Options{Servers: 2, Secret: "secret"})
+	root := c.CreateRoot()
+	before := checkMappings(t, c)
+	attacker := "127.0.0.1:1"
+
+	for _, secret := range []string{"", "forged"} {
+		conn, err := Dial(c.Nodes[0].Address, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		forged := db.NewDbServiceClient(conn)
+		ctx, cancel := Context()
+		defer cancel()
+
+		calls := map[string]func() error{
+			"Split": func() error {
+				_, err := forged.Split(ctx, &db.SplitRequest{
+					Left:        0,
+					Right:       math.MaxUint32,
+					Mid:         math.MaxUint32 / 2,
+					LeftServer:  c.Nodes[0].Address,
+					RightServer: attacker,
+					Epoch:       before.Epoch + 1,
+				})
+				return err
+			},
+			"SetIndexingLock": func() error {
+				_, err := forged.SetIndexingLock(ctx, &db.SetIndexingLockRequest{Lock: true, Holder: attacker, LeaseMillis: 60000})
+				return err
+			},
+			"AddNode": func() error {
+				_, err := forged.AddNode(ctx, &db.AddNodeRequest{Node: &db.Node{Location: root, Key: "injected"}})
+				return err
+			},
+			"TriggerSplit": func() error {
+				_, err := forged.TriggerSplit(ctx, &db.TriggerSplitRequest{})
+				return err
+			},
+			"SetMode": func() error {
+				_, err := forged.SetMode(ctx, &db.SetModeRequest{Mode: db.Mode_READONLY})
+				return err
+			},
+		}
+		for method, call := range calls {
+			if err := call(); status.Code(err) != codes.PermissionDenied {
+				t.Errorf("%s signed with %q returned %v, expected PermissionDenied", method, secret, err)
+			}
+		}
+	}
+	after := checkMappings(t, c)
+	if after.Epoch != before.Epoch || fmt.Sprint(ranges(after)) != fmt.Sprint(ranges(before)) {
+		t.Fatalf("Forged requests changed the mapping to %v (epoch %d)", ranges(after), after.Epoch)
+	}
+	if stats := c.Stats(0); stats.Mode != db.Mode_NORMAL {
+		t.Errorf("Forged SetMode switched the server to %s", stats.Mode)
+	}
+
+	// Signed by the servers
+	c.Split(0)
+	mapping := checkMappings(t, c)
+	if len(mapping.Ranges) != 2 || mapping.Epoch <= before.Epoch {
+		t.Fatalf("Split produced %v (epoch %d)", ranges(mapping), mapping.Epoch)
+	}
+	for _, r := range mapping.Ranges {
+		if r.Server == attacker {
+			t.Errorf("Range %d-%d mapped to the attacker", r.Left, r.Right)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package harness
+
+import (
+	"testing"
+	"time"
+)
+
+// A write of a cached key invalidates the caches of other servers
+// well before the staleness bound
+func TestReadCacheInvalidated(t *testing.T) {
+	c := Start(t, Options{
+		Servers: 2,
+		Config: map[string]interface{}{
+			"cacheable": []map[string]interface{}{{"prefix": "hot", "maxStalenessMillis": 5000}},
+		},
+	})
+	root := c.CreateRoot()
+	c.Split(0)
+	owner, other := c.Nodes[0], c.Nodes[1]
+	key := c.keyOwnedBy(t, owner.Address, "hot")
+	ctx, cancel := Context()
+	defer cancel()
+
+	written, err := owner.Client.Write(ctx, key, []byte("v1"), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := other.Client.Get(ctx, key, written.Location); err != nil || value.FromCache {
+		t.Fatalf("First Get from %s returned %v, %v", other.Address, value, err)
+	}
+	value, err := other.Client.Get(ctx, key, written.Location)
+	if err != nil || !value.FromCache || string(value.Value) != "v1" || value.CachedAt == 0 {
+		t.Fatalf("Second Get from %s returned %v, %v", other.Address, value, err)
+	}
+
+	updated, err := owner.Client.Write(ctx, key, []byte("v2"), written.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.WaitFor(time.Second, "the cache to be invalidated", func() bool {
+		value, err := other.Client.Get(ctx, key, written.Location)
+		return err == nil && !value.FromCache
+	})
+	if value, err := other.Client.Get(ctx, key, updated.Location); err != nil || string(value.Value) != "v2" {
+		t.Errorf("Get of the update from %s returned %v, %v", other.Address, value, err)
+	}
+}
@@ -0,0 +1,140 @@
+package harness
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Two servers owning the same range converge on the newer claim:
+// the other one yields its nodes, and a node conflicting at a location
+// moves to a sibling with its subtree
+func TestClaimConflict(t *testing.T) {
+	c := Start(t, Options{Servers: 2, Secret: "secret"})
+	root := c.CreateRoot()
+	c.Split(0)
+	ctx, cancel := Context()
+	defer cancel()
+	loser, winner := c.Nodes[0], c.Nodes[1]
+
+	// Keys of the range of the loser, written before the split brain
+	key := c.keyOwnedBy(t, loser.Address, "conflict")
+	childKey := c.keyOwnedBy(t, loser.Address, "child")
+	extraKey := c.keyOwnedBy(t, loser.Address, "extra")
+	written, err := loser.Client.Write(ctx, key, []byte("left"), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	location := written.Location
+	child, err := loser.Client.Write(ctx, childKey, []byte("child"), location)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The winner takes the whole range with a newer epoch (as after a lost split broadcast)
+	if _, err := winner.Client.Split(ctx, &db.SplitRequest{
+		Left:        0,
+		Right:       ^uint32(0),
+		Mid:         ^uint32(0),
+		LeftServer:  winner.Address,
+		RightServer: winner.Address,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// and writes a different node at the same location
+	if _, err := winner.Client.AddNode(ctx, &db.AddNodeRequest{
+		Node: &db.Node{
+			Location:  location,
+			Dep:       root,
+			Key:       key,
+			Value:     []byte("right"),
+			CreatedAt: time.Now().UnixNano(),
+		},
+		SkipLocationCheck: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	extra, err := winner.Client.Write(ctx, extraKey, []byte("extra"), location)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Claim of the winner
+	var claimed []*db.Range
+	mapping := c.Mapping(1)
+	for _, r := range mapping.Ranges {
+		if r.Server == winner.Address {
+			claimed = append(claimed, r)
+		}
+	}
+	resp, err := loser.Client.ClaimRanges(ctx, &db.ClaimRangesRequest{
+		Server: winner.Address,
+		Epoch:  mapping.Epoch,
+		Ranges: claimed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Conflict || resp.Winner != winner.Address {
+		t.Fatalf("ClaimRanges returned %v", resp)
+	}
+	c.WaitFor(30*time.Second, "the loser to yield", func() bool {
+		return strings.Contains(c.Log(0), "Split brain: yielded")
+	})
+	agreed := checkMappings(t, c)
+	for _, r := range agreed.Ranges {
+		if r.Server != winner.Address {
+			t.Errorf("Range %x-%x still on %s", r.Left, r.Right, r.Server)
+		}
+	}
+
+	// Both versions of the conflicting node are kept
+	moved, err := winner.Client.GetNode(ctx, &db.GetNodeRequest{Location: child.Location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Dep == location {
+		t.Fatalf("Child of the yielded node still depends on the node of the winner at %x", location)
+	}
+	sibling, err := winner.Client.GetNode(ctx, &db.GetNodeRequest{Location: moved.Dep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sibling.Key != key || string(sibling.Value) != "left" || len(sibling.Children) != 1 || sibling.Children[0] != child.Location {
+		t.Errorf("Sibling is %v", sibling)
+	}
+	for _, node := range c.Nodes {
+		for _, read := range []struct {
+			key      string
+			location uint64
+			value    string
+		}{
+			{key, location, "right"},
+			{key, sibling.Location, "left"},
+			{childKey, child.Location, "child"},
+			{extraKey, extra.Location, "extra"},
+		} {
+			value, err := node.Client.Get(ctx, read.key, read.location)
+			if err != nil {
+				t.Fatalf("Get of %s at %x from %s failed: %v", read.key, read.location, node.Address, err)
+			}
+			if string(value.Value) != read.value {
+				t.Errorf("Get of %s at %x from %s returned %q instead of %q", read.key, read.location, node.Address, value.Value, read.value)
+			}
+		}
+	}
+	// The sibling is linked to the root for merge functions
+	parent, err := winner.Client.GetNode(ctx, &db.GetNodeRequest{Location: root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, c := range parent.Children {
+		found = found || c == sibling.Location
+	}
+	if !found {
+		t.Errorf("Sibling %x not linked to the root: %v", sibling.Location, parent.Children)
+	}
+}
@@ -0,0 +1,138 @@
+package harness
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Copies of the progress stream by source, and the id of the copy
+func copySubtree(t *testing.T, c *Cluster, in *db.CopySubtreeRequest) (map[uint64]uint64, *db.CopyProgress) {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	stream, err := c.Nodes[1].Client.CopySubtree(ctx, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copies := make(map[uint64]uint64)
+	var last *db.CopyProgress
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, node := range progress.Nodes {
+			copies[node.Source] = node.Copy
+		}
+		last = progress
+	}
+	if last == nil || !last.Done {
+		t.Fatalf("Copy ended without Done")
+	}
+	return copies, last
+}
+
+func sortedChildren(node *db.Node) []uint64 {
+	children := append([]uint64(nil), node.Children...)
+	sort.Slice(children, func(i, j int) bool { return children[i] < children[j] })
+	return children
+}
+
+// A copy under another root has the structure and content of the source,
+// and every copy is stored by the owner of its key
+func TestCopySubtree(t *testing.T) {
+	// CopySubtree must be signed
+	c := Start(t, Options{Servers: 2, Secret: "secret"})
+	c.Split(0)
+	source, destination := c.CreateRoot(), c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+
+	// Keys owned by both servers, and a node with two children
+	first, second := c.Nodes[0].Address, c.Nodes[1].Address
+	set := func(key, value string, dep uint64) uint64 {
+		t.Helper()
+		written, err := c.Nodes[0].Client.Set(ctx, &db.SetRequest{Key: key, Value: []byte(value), Dep: dep, ContentType: "text/" + key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return written.Location
+	}
+	top := set(c.keyOwnedBy(t, first, "top"), "1", source)
+	left := set(c.keyOwnedBy(t, second, "left"), "2", top)
+	set(c.keyOwnedBy(t, first, "right"), "3", top)
+	set(c.keyOwnedBy(t, second, "leaf"), "4", left)
+	under := set("under", "5", destination)
+
+	copies, progress := copySubtree(t, c, &db.CopySubtreeRequest{Source: top, Destination: under})
+	if len(copies) != 4 || progress.Copied != 4 {
+		t.Fatalf("Copied %d nodes (%d reported), expected 4", len(copies), progress.Copied)
+	}
+
+	// Same content, with the dep and children of the source mapped to their copies
+	parents := map[uint64]uint64{top: under}
+	pending := []uint64{top}
+	for len(pending) > 0 {
+		location := pending[0]
+		pending = pending[1:]
+		original, copied := getNode(t, c, location), getNode(t, c, copies[location])
+		if copied.Key != original.Key || string(copied.Value) != string(original.Value) ||
+			copied.Type != original.Type || copied.ContentType != original.ContentType {
+			t.Errorf("Copy of %x is %v, source is %v", location, copied, original)
+		}
+		if copied.Dep != parents[location] {
+			t.Errorf("Copy of %x has dep %x, expected %x", location, copied.Dep, parents[location])
+		}
+		var expected []uint64
+		for _, child := range original.Children {
+			expected = append(expected, copies[child])
+			parents[child] = copies[location]
+			pending = append(pending, child)
+		}
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+		if children := sortedChildren(copied); fmt.Sprint(children) != fmt.Sprint(expected) {
+			t.Errorf("Copy of %x has children %x, expected %x", location, children, expected)
+		}
+
+		// Stored by the owner of the key, which lists it among the nodes of the key
+		owner := c.keyOwner(t, copied.Key)
+		if located := c.owner(t, copied.Location); located != owner {
+			t.Errorf("Copy of %s is at %x owned by %s, while the key is owned by %s", copied.Key, copied.Location, located, owner)
+		}
+		nodes, err := c.Nodes[c.Index(owner)].Client.GetKeyNodes(ctx, &db.GetKeyNodesRequest{Key: copied.Key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored := false
+		for _, node := range nodes.Nodes {
+			stored = stored || node.Location == copied.Location
+		}
+		if !stored {
+			t.Errorf("Copy of %s is not stored by %s", copied.Key, owner)
+		}
+	}
+	if children := getNode(t, c, under).Children; len(children) != 1 || children[0] != copies[top] {
+		t.Errorf("Destination has children %x, expected the copy %x", children, copies[top])
+	}
+
+	// Resumed with the same id, every node was already copied
+	again, resumed := copySubtree(t, c, &db.CopySubtreeRequest{Source: top, Destination: under, Id: progress.Id})
+	if resumed.Skipped != 4 {
+		t.Errorf("Resumed copy skipped %d nodes, expected 4", resumed.Skipped)
+	}
+	for location, copied := range copies {
+		if again[location] != copied {
+			t.Errorf("Resumed copy of %x is %x, expected %x", location, again[location], copied)
+		}
+	}
+	if children := getNode(t, c, under).Children; len(children) != 1 {
+		t.Errorf("Resumed copy linked %d children to the destination", len(children))
+	}
+}
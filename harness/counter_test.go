@@ -0,0 +1,62 @@
+package harness
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Concurrent increments through all servers all count,
+// without invoking the merge function registered under their dep
+func TestConcurrentIncrements(t *testing.T) {
+	c := startThree(t)
+	root := c.CreateRoot()
+	const increments = 100
+	key := "counter"
+	ctx, cancel := Context()
+	defer cancel()
+	// Not an action: an invocation would fail the merge
+	if _, err := c.Nodes[0].Client.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
+		Location:       root,
+		Name:           "resolver",
+		SkipValidation: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < increments; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := Context()
+			defer cancel()
+			written, err := c.Nodes[i%len(c.Nodes)].Client.Increment(ctx, key, 1, root)
+			if err != nil {
+				t.Errorf("Increment %d failed: %v", i, err)
+				return
+			}
+			if written.Merge != db.MergeStatus_MERGE_NONE {
+				t.Errorf("Increment %d merged: %v", i, written.Merge)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, node := range c.Nodes {
+		sum, err := node.Client.Counter(ctx, key, root)
+		if err != nil {
+			t.Fatalf("Counter from %s failed: %v", node.Address, err)
+		}
+		if sum != increments {
+			t.Errorf("Counter from %s is %d instead of %d", node.Address, sum, increments)
+		}
+	}
+	checkNoConflicts(t, c, key)
+	for i := range c.Nodes {
+		if stats := c.Stats(i); stats.DeadLetteredMerges > 0 {
+			t.Errorf("%s dead-lettered %d merges", c.Nodes[i].Address, stats.DeadLetteredMerges)
+		}
+	}
+}
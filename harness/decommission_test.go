@@ -0,0 +1,129 @@
+package harness
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// A server decommissioned while keys are written through all servers keeps serving
+// reads and forwarding writes during the transfer, and no acknowledged key is lost
+func TestDecommissionUnderLoad(t *testing.T) {
+	c := Start(t, Options{
+		Servers: 3,
+		// Small batches so that the transfer takes a while
+		Config: map[string]interface{}{"transferBatchMax": 2},
+	})
+	c.Split(0)
+	c.Split(0)
+	root := c.CreateRoot()
+
+	locations := make(map[string]uint64)
+	for _, key := range c.keysOwnedBy(t, c.Nodes[1].Address, "preloaded", 2000) {
+		ctx, cancel := Context()
+		written, err := c.Nodes[0].Client.Write(ctx, key, []byte(key), root)
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations[key] = written.Location
+	}
+	var probe string
+	var probeLocation uint64
+	for key, location := range locations {
+		probe, probeLocation = key, location
+		break
+	}
+
+	ctx, cancel := Context()
+	defer cancel()
+	// Owned by another server, like its dep (the root may be on the draining one)
+	forwarded := c.keyOwnedBy(t, c.Nodes[0].Address, "forwarded")
+	base, err := c.Nodes[0].Client.Write(ctx, forwarded, []byte(forwarded), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes owned by the draining server are rejected until its ranges move, so writers retry
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	for w := 0; w < 3; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			node := c.Nodes[w]
+			for i := 0; ; i++ {
+				key := fmt.Sprintf("load%d-%d", w, i)
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					ctx, cancel := Context()
+					written, err := node.Client.Write(ctx, key, []byte(key), root)
+					cancel()
+					if err == nil {
+						lock.Lock()
+						locations[key] = written.Location
+						lock.Unlock()
+						break
+					}
+					time.Sleep(20 * time.Millisecond)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}(w)
+	}
+
+	if _, err := c.Nodes[1].Client.Decommission(ctx, &db.DecommissionRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	probes := 0
+	c.WaitFor(60*time.Second, "the decommission", func() bool {
+		ctx, cancel := Context()
+		defer cancel()
+		status, err := c.Nodes[1].Client.GetDecommissionStatus(ctx, &db.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Phase == "failed" {
+			t.Fatalf("Decommission failed: %s", status.Error)
+		}
+		if status.Phase == "transferring" {
+			// Reads and forwarded writes are not blocked by the transfer
+			start := time.Now()
+			value, err := c.Nodes[1].Client.Get(ctx, probe, probeLocation)
+			if err != nil || string(value.Value) != probe {
+				t.Fatalf("Get during the transfer returned %v, %v", value, err)
+			}
+			if _, err := c.Nodes[1].Client.Write(ctx, forwarded, []byte(forwarded), base.Location); err != nil {
+				t.Fatalf("Forwarded write during the transfer failed: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+				t.Fatalf("Get and write during the transfer took %v", elapsed)
+			}
+			probes++
+		}
+		return status.Phase == "done"
+	})
+	close(stop)
+	wg.Wait()
+	if probes == 0 {
+		t.Error("Transfer too quick to read during it")
+	}
+
+	for _, r := range checkMappings(t, c).Ranges {
+		if r.Server == c.Nodes[1].Address {
+			t.Errorf("Decommissioned server still owns %x-%x", r.Left, r.Right)
+		}
+	}
+	if nodes := c.Stats(1).Nodes; nodes != 0 {
+		t.Errorf("Decommissioned server kept %d nodes", nodes)
+	}
+	checkKeys(t, c, locations)
+}
@@ -0,0 +1,92 @@
+package harness
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Start three servers owning a range each
+func startThree(t *testing.T) *Cluster {
+	c := Start(t, Options{Servers: 3})
+	c.Split(0)
+	c.Split(0)
+	return c
+}
+
+// Check that no server saw a conflict of the key
+func checkNoConflicts(t *testing.T, c *Cluster, key string) {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	for _, node := range c.Nodes {
+		top, err := node.Client.TopConflictingKeys(ctx, &db.TopConflictingKeysRequest{Limit: 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, conflicting := range top.Keys {
+			if conflicting.Key == key {
+				t.Errorf("%s saw %d conflicts of %s", node.Address, conflicting.Count, key)
+			}
+		}
+	}
+}
+
+// Initializers racing through all servers create the key once
+func TestGetOrSetRacingInitializers(t *testing.T) {
+	c := startThree(t)
+	root := c.CreateRoot()
+	const initializers = 20
+	key := "initialized"
+
+	var wg sync.WaitGroup
+	responses := make([]*db.GetOrSetResponse, initializers)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := Context()
+			defer cancel()
+			resp, err := c.Nodes[i%len(c.Nodes)].Client.GetOrSet(ctx, key, root, []byte(fmt.Sprintf("init%d", i)))
+			if err != nil {
+				t.Errorf("GetOrSet %d failed: %v", i, err)
+				return
+			}
+			responses[i] = resp
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	created := 0
+	for i, resp := range responses {
+		if resp.Created {
+			created++
+		}
+		if resp.Location != responses[0].Location || string(resp.Value) != string(responses[0].Value) {
+			t.Errorf("GetOrSet %d returned %s at %x, GetOrSet 0 %s at %x", i, resp.Value, resp.Location, responses[0].Value, responses[0].Location)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d initializers created the key", created)
+	}
+	checkNoConflicts(t, c, key)
+
+	ctx, cancel := Context()
+	defer cancel()
+	for _, node := range c.Nodes {
+		value, err := node.Client.Get(ctx, key, responses[0].Location)
+		if err != nil || string(value.Value) != string(responses[0].Value) {
+			t.Errorf("Get from %s returned %v, %v", node.Address, value, err)
+		}
+		// Later initializers find the key
+		resp, err := node.Client.GetOrSet(ctx, key, root, []byte("late"))
+		if err != nil || resp.Created || resp.Location != responses[0].Location {
+			t.Errorf("GetOrSet from %s after the race returned %v, %v", node.Address, resp, err)
+		}
+	}
+}
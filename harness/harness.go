@@ -0,0 +1,463 @@
+// Package harness runs clusters of server processes on localhost for end-to-end tests.
+// The server is built once per test binary with the go command
+// (with the race detector if the tests run with -race, failing tests whose servers report races).
+package harness
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/client"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc"
+)
+
+// Package of the server binary
+const serverPackage = "github.com/DCsunset/openwhisk-grpc/server"
+
+// How long a server has to answer after it is started
+var StartTimeout = 15 * time.Second
+
+var build struct {
+	once sync.Once
+	path string
+	err  error
+}
+
+// Path of the server binary (built on first use)
+func serverBinary() (string, error) {
+	build.once.Do(func() {
+		dir, err := ioutil.TempDir("", "harness")
+		if err != nil {
+			build.err = err
+			return
+		}
+		build.path = filepath.Join(dir, "dbserver")
+		args := []string{"build", "-o", build.path}
+		if raceEnabled {
+			args = append(args, "-race")
+		}
+		out, err := exec.Command("go", append(args, serverPackage)...).CombinedOutput()
+		if err != nil {
+			build.err = fmt.Errorf("Fail to build the server: %v\n%s", err, out)
+		}
+	})
+	return build.path, build.err
+}
+
+type Options struct {
+	// Number of servers (default 3)
+	Servers int
+	// Nodes per server before a split (default 10000)
+	Threshold int
+	// Cluster secret (internal requests are not signed if empty)
+	Secret string
+	// Only the first this many servers are started (default all);
+	// the others can be started later with Start
+	Started int
+	// Extra fields of server.json of every server (e.g. "backend")
+	Config map[string]interface{}
+	// Extra fields of server.json of a server by index
+	ServerConfig map[int]map[string]interface{}
+}
+
+type Node struct {
+	Address string
+	// Working directory with server.json and server.log
+	Dir    string
+	Client *client.Client
+	// Connection of Client
+	Conn *grpc.ClientConn
+
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Whether the process is running
+func (n *Node) Running() bool {
+	if n.cmd == nil {
+		return false
+	}
+	select {
+	case <-n.done:
+		return false
+	default:
+		return true
+	}
+}
+
+type Cluster struct {
+	Nodes   []*Node
+	Options Options
+
+	t      testing.TB
+	binary string
+}
+
+// Addresses of free ports on localhost
+func freeAddresses(n int) ([]string, error) {
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+	var addresses []string
+	for i := 0; i < n; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, l)
+		addresses = append(addresses, l.Addr().String())
+	}
+	return addresses, nil
+}
+
+// Start a cluster whose first server owns the whole hash range
+// (the others are available for splits). Skipped in short mode.
+// Processes are killed when the test ends, and their logs are printed if it failed.
+func Start(t testing.TB, options Options) *Cluster {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping cluster test in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("Skipping cluster test without the go command")
+	}
+	if options.Servers == 0 {
+		options.Servers = 3
+	}
+	if options.Threshold == 0 {
+		options.Threshold = 10000
+	}
+	if options.Started == 0 {
+		options.Started = options.Servers
+	}
+	binary, err := serverBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addresses, err := freeAddresses(options.Servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Cluster{Options: options, t: t, binary: binary}
+	root := t.TempDir()
+	for i, address := range addresses {
+		c.Nodes = append(c.Nodes, &Node{Address: address, Dir: filepath.Join(root, fmt.Sprintf("node%d", i))})
+	}
+	for i, node := range c.Nodes {
+		if err := os.MkdirAll(node.Dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.writeConfig(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(c.close)
+	// Launched together so that no server dials another one before it listens
+	for i := 0; i < options.Started; i++ {
+		c.launch(i)
+	}
+	for i := 0; i < options.Started; i++ {
+		c.wait(i)
+	}
+	return c
+}
+
+func (c *Cluster) Addresses() []string {
+	var addresses []string
+	for _, node := range c.Nodes {
+		addresses = append(addresses, node.Address)
+	}
+	return addresses
+}
+
+func (c *Cluster) writeConfig(i int) error {
+	addresses := c.Addresses()
+	config := map[string]interface{}{
+		"servers":          addresses,
+		"availableServers": addresses[1:],
+		"self":             addresses[i],
+		"initial":          addresses[0],
+		"seed":             addresses[0],
+		"threshold":        c.Options.Threshold,
+	}
+	if len(c.Options.Secret) > 0 {
+		config["clusterSecret"] = c.Options.Secret
+	}
+	for k, v := range c.Options.Config {
+		config[k] = v
+	}
+	for k, v := range c.Options.ServerConfig[i] {
+		config[k] = v
+	}
+	data, err := json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(c.Nodes[i].Dir, "server.json"), data, 0644)
+}
+
+// Client of a server (signed with the cluster secret if any)
+func Dial(address string, secret string) (*grpc.ClientConn, error) {
+	interceptors := []grpc.UnaryClientInterceptor{dberrors.UnaryClientInterceptor}
+	var streamInterceptors []grpc.StreamClientInterceptor
+	if len(secret) > 0 {
+		signer := auth.Signer{Secret: []byte(secret)}
+		interceptors = append(interceptors, signer.UnaryClientInterceptor)
+		streamInterceptors = append(streamInterceptors, signer.StreamClientInterceptor)
+	}
+	return grpc.Dial(address, grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithChainStreamInterceptor(streamInterceptors...))
+}
+
+// Start the process of the server and wait until it answers
+func (c *Cluster) Start(i int) {
+	c.t.Helper()
+	c.launch(i)
+	c.wait(i)
+}
+
+func (c *Cluster) launch(i int) {
+	c.t.Helper()
+	node := c.Nodes[i]
+	if node.Running() {
+		c.t.Fatalf("Server %s is already running", node.Address)
+	}
+	logFile, err := os.OpenFile(filepath.Join(node.Dir, "server.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	cmd := exec.Command(c.binary)
+	cmd.Dir = node.Dir
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		logFile.Close()
+		c.t.Fatal(err)
+	}
+	node.cmd = cmd
+	node.done = make(chan struct{})
+	go func(done chan struct{}) {
+		cmd.Wait()
+		logFile.Close()
+		close(done)
+	}(node.done)
+
+	if node.Conn == nil {
+		if node.Conn, err = Dial(node.Address, c.Options.Secret); err != nil {
+			c.t.Fatal(err)
+		}
+		node.Client = client.New(db.NewDbServiceClient(node.Conn))
+	}
+}
+
+// Wait until the server answers
+func (c *Cluster) wait(i int) {
+	c.t.Helper()
+	node := c.Nodes[i]
+	deadline := time.Now().Add(StartTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := node.Client.GetStats(ctx, &db.Empty{})
+		cancel()
+		if err == nil {
+			return
+		}
+		if !node.Running() {
+			c.t.Fatalf("Server %s exited:\n%s", node.Address, c.Log(i))
+		}
+		if time.Now().After(deadline) {
+			c.t.Fatalf("Server %s does not answer: %v", node.Address, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Stop the server like a crash (SIGKILL)
+func (c *Cluster) Kill(i int) {
+	c.stop(i, syscall.SIGKILL)
+}
+
+// Stop the server gracefully (SIGTERM)
+func (c *Cluster) Stop(i int) {
+	c.stop(i, syscall.SIGTERM)
+}
+
+func (c *Cluster) stop(i int, signal syscall.Signal) {
+	node := c.Nodes[i]
+	if !node.Running() {
+		return
+	}
+	node.cmd.Process.Signal(signal)
+	select {
+	case <-node.done:
+	case <-time.After(10 * time.Second):
+		node.cmd.Process.Kill()
+		<-node.done
+	}
+}
+
+// Restart the server with the same directory (and data with the disk backend)
+func (c *Cluster) Restart(i int) {
+	c.t.Helper()
+	c.Kill(i)
+	c.Start(i)
+}
+
+// Change server.json of a server (applied when it is started again or reloads it)
+func (c *Cluster) Configure(i int, config map[string]interface{}) {
+	c.t.Helper()
+	if c.Options.ServerConfig == nil {
+		c.Options.ServerConfig = make(map[int]map[string]interface{})
+	}
+	if c.Options.ServerConfig[i] == nil {
+		c.Options.ServerConfig[i] = make(map[string]interface{})
+	}
+	for k, v := range config {
+		c.Options.ServerConfig[i][k] = v
+	}
+	if err := c.writeConfig(i); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// Last lines of the log of the server
+func (c *Cluster) Log(i int) string {
+	file, err := os.Open(filepath.Join(c.Nodes[i].Dir, "server.log"))
+	if err != nil {
+		return err.Error()
+	}
+	defer file.Close()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1<<20), 1<<20)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > 200 {
+			lines = lines[1:]
+		}
+	}
+	var log string
+	for _, line := range lines {
+		log += line + "\n"
+	}
+	return log
+}
+
+// Whether the race detector wrote a report to the log of the server
+func (c *Cluster) reportedRace(i int) bool {
+	data, err := ioutil.ReadFile(filepath.Join(c.Nodes[i].Dir, "server.log"))
+	return err == nil && bytes.Contains(data, []byte("WARNING: DATA RACE"))
+}
+
+func (c *Cluster) close() {
+	for i, node := range c.Nodes {
+		c.Kill(i)
+		if node.Conn != nil {
+			node.Conn.Close()
+		}
+		if node.cmd == nil {
+			continue
+		}
+		if raceEnabled && c.reportedRace(i) {
+			c.t.Errorf("Server %s reported a data race", node.Address)
+		}
+		if c.t.Failed() {
+			c.t.Logf("Log of %s:\n%s", node.Address, c.Log(i))
+		}
+	}
+}
+
+// Index of the server with the address (-1 if none)
+func (c *Cluster) Index(address string) int {
+	for i, node := range c.Nodes {
+		if node.Address == address {
+			return i
+		}
+	}
+	return -1
+}
+
+// Wait until the condition holds, failing the test after the timeout
+func (c *Cluster) WaitFor(timeout time.Duration, what string, condition func() bool) {
+	c.t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			c.t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Context for a request in a test
+func Context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+// Create a root on the first server
+func (c *Cluster) CreateRoot() uint64 {
+	c.t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	root, err := c.Nodes[0].Client.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return root.Location
+}
+
+// Split the range of the server (sent to the seed)
+func (c *Cluster) Split(i int) *db.SplitPlan {
+	c.t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	plan, err := c.Nodes[i].Client.TriggerSplit(ctx, &db.TriggerSplitRequest{})
+	if err != nil {
+		c.t.Fatalf("Split of %s failed: %v", c.Nodes[i].Address, err)
+	}
+	return plan
+}
+
+// Mapping of the server
+func (c *Cluster) Mapping(i int) *db.Mapping {
+	c.t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	mapping, err := c.Nodes[i].Client.GetMapping(ctx, &db.Empty{})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return mapping
+}
+
+// Stats of the server
+func (c *Cluster) Stats(i int) *db.GetStatsResponse {
+	c.t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	stats, err := c.Nodes[i].Client.GetStats(ctx, &db.Empty{})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return stats
+}
@@ -0,0 +1,167 @@
+package harness
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Key owned by the server (from its mapping)
+func (c *Cluster) keyOwnedBy(t *testing.T, address string, prefix string) string {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("%s%d", prefix, i)
+		owners, err := c.Nodes[0].Client.LocateKeys(ctx, &db.LocateKeysRequest{Keys: []string{key}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if owners.Owners[0].Address == address {
+			return key
+		}
+	}
+	t.Fatalf("No key owned by %s", address)
+	return ""
+}
+
+// Owner of the key (from the mapping of the first server)
+func (c *Cluster) keyOwner(t *testing.T, key string) string {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	owners, err := c.Nodes[0].Client.LocateKeys(ctx, &db.LocateKeysRequest{Keys: []string{key}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return owners.Owners[0].Address
+}
+
+// Keys owned by the server (from its mapping)
+func (c *Cluster) keysOwnedBy(t *testing.T, address string, prefix string, n int) []string {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	var keys []string
+	for batch := 0; len(keys) < n; batch++ {
+		if batch == 100 {
+			t.Fatalf("Only %d keys owned by %s", len(keys), address)
+		}
+		candidates := make([]string, 1000)
+		for i := range candidates {
+			candidates[i] = fmt.Sprintf("%s%d", prefix, batch*len(candidates)+i)
+		}
+		owners, err := c.Nodes[0].Client.LocateKeys(ctx, &db.LocateKeysRequest{Keys: candidates})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, owner := range owners.Owners {
+			if owner.Address == address && len(keys) < n {
+				keys = append(keys, candidates[i])
+			}
+		}
+	}
+	return keys
+}
+
+// Hand the ranges of server i to the target and wait until it is done
+func (c *Cluster) Decommission(i int, target string) {
+	c.t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	if _, err := c.Nodes[i].Client.Decommission(ctx, &db.DecommissionRequest{Target: target}); err != nil {
+		c.t.Fatalf("Decommission of %s failed: %v", c.Nodes[i].Address, err)
+	}
+	c.WaitFor(30*time.Second, "the decommission", func() bool {
+		ctx, cancel := Context()
+		defer cancel()
+		status, err := c.Nodes[i].Client.GetDecommissionStatus(ctx, &db.Empty{})
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		if status.Phase == "failed" {
+			c.t.Fatalf("Decommission of %s failed: %s", c.Nodes[i].Address, status.Error)
+		}
+		return status.Phase == "done"
+	})
+}
+
+// Config of each storage backend
+var backends = []struct {
+	name   string
+	config map[string]interface{}
+}{
+	{"memory", nil},
+	{"disk", map[string]interface{}{"backend": "disk", "dataDir": "data"}},
+}
+
+func TestClusterReadsAndWrites(t *testing.T) {
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			c := Start(t, Options{Servers: 2, Config: backend.config})
+			root := c.CreateRoot()
+			c.Split(0)
+
+			ctx, cancel := Context()
+			defer cancel()
+			locations := make(map[string]uint64)
+			for i := 0; i < 20; i++ {
+				key := fmt.Sprintf("k%d", i)
+				written, err := c.Nodes[i%2].Client.Write(ctx, key, []byte(key), root)
+				if err != nil {
+					t.Fatal(err)
+				}
+				locations[key] = written.Location
+			}
+			for key, location := range locations {
+				for _, node := range c.Nodes {
+					value, err := node.Client.Get(ctx, key, location)
+					if err != nil {
+						t.Fatalf("Get of %s from %s failed: %v", key, node.Address, err)
+					}
+					if string(value.Value) != key {
+						t.Errorf("Value of %s is %q", key, value.Value)
+					}
+				}
+			}
+			if a, b := c.Stats(0).Nodes, c.Stats(1).Nodes; a == 0 || b == 0 {
+				t.Errorf("Nodes are not spread after the split: %d and %d", a, b)
+			}
+		})
+	}
+}
+
+// Nodes written with the disk backend survive a crash of their server
+func TestDiskBackendRestart(t *testing.T) {
+	c := Start(t, Options{Servers: 1, Config: backends[1].config})
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+	written, err := c.Nodes[0].Client.Set(ctx, &db.SetRequest{Key: "k", Value: []byte("v"), Dep: root, Durability: db.Durability_ACK_WAL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Restart(0)
+	value, err := c.Nodes[0].Client.Get(ctx, "k", written.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value.Value) != "v" {
+		t.Errorf("Value after the restart is %q", value.Value)
+	}
+}
+
+// Server owning the location
+func (c *Cluster) owner(t *testing.T, location uint64) string {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	owners, err := c.Nodes[0].Client.LocateKeys(ctx, &db.LocateKeysRequest{Hashes: []uint32{uint32(location >> 32)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return owners.Owners[0].Address
+}
@@ -0,0 +1,121 @@
+package harness
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Like grpcurl: list the services through reflection, then call Get
+// with messages built from the descriptors it returns, without db.proto
+func TestReflection(t *testing.T) {
+	metrics, err := freeAddresses(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := Start(t, Options{Servers: 1, Config: map[string]interface{}{
+		"enableReflection": true,
+		"metricsAddress":   metrics[0],
+	}})
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+	written, err := c.Nodes[0].Client.Write(ctx, "reflected", []byte("value"), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(c.Nodes[0].Conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ask := func(request *grpc_reflection_v1alpha.ServerReflectionRequest) *grpc_reflection_v1alpha.ServerReflectionResponse {
+		t.Helper()
+		if err := stream.Send(request); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failure := resp.GetErrorResponse(); failure != nil {
+			t.Fatalf("Reflection failed: %s", failure.ErrorMessage)
+		}
+		return resp
+	}
+
+	listed := ask(&grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+	})
+	services := make(map[string]bool)
+	for _, service := range listed.GetListServicesResponse().Service {
+		services[service.Name] = true
+	}
+	for _, name := range []string{"db.DbService", "grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"} {
+		if !services[name] {
+			t.Errorf("Service %s is not listed (listed: %v)", name, services)
+		}
+	}
+
+	found := ask(&grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "db.DbService"},
+	})
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, data := range found.GetFileDescriptorResponse().FileDescriptorProto {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(data, file); err != nil {
+			t.Fatal(err)
+		}
+		set.File = append(set.File, file)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	descriptor, err := files.FindDescriptorByName("db.DbService")
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := descriptor.(protoreflect.ServiceDescriptor).Methods().ByName("Get")
+	if method == nil {
+		t.Fatal("Get is not described")
+	}
+
+	request := dynamicpb.NewMessage(method.Input())
+	request.Set(method.Input().Fields().ByName("Key"), protoreflect.ValueOfString("reflected"))
+	request.Set(method.Input().Fields().ByName("Location"), protoreflect.ValueOfUint64(written.Location))
+	response := dynamicpb.NewMessage(method.Output())
+	if err := c.Nodes[0].Conn.Invoke(ctx, "/db.DbService/Get", request, response); err != nil {
+		t.Fatal(err)
+	}
+	if value := response.Get(method.Output().Fields().ByName("Value")).Bytes(); string(value) != "value" {
+		t.Errorf("Get through reflection returned %q", value)
+	}
+
+	// The OpenAPI document is served on the metrics address
+	resp, err := http.Get("http://" + metrics[0] + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	served, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := db.OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(served, expected) {
+		t.Errorf("Served OpenAPI document differs from db.OpenAPI")
+	}
+}
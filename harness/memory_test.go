@@ -0,0 +1,83 @@
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Above a small high-water mark writes are refused while reads work,
+// and they resume once deleted nodes are compacted away
+func TestMemoryPressure(t *testing.T) {
+	c := Start(t, Options{Servers: 1})
+	root := c.CreateRoot()
+	node := c.Nodes[0]
+	ctx, cancel := Context()
+	defer cancel()
+
+	c.WaitFor(5*time.Second, "a memory sample", func() bool { return c.Stats(0).MemoryBytes > 0 })
+	baseline := c.Stats(0).MemoryBytes >> 20
+	c.Configure(0, map[string]interface{}{
+		"memoryHighWaterMB": baseline + 48,
+		"memoryLowWaterMB":  baseline + 24,
+	})
+	if _, err := node.Client.ReloadConfig(ctx, &db.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+
+	value := make([]byte, 1<<20)
+	var written []uint64
+	var refused error
+	for i := 0; i < 200 && refused == nil; i++ {
+		result, err := node.Client.Write(ctx, fmt.Sprintf("big%d", i), value, root)
+		if err != nil {
+			refused = err
+			break
+		}
+		written = append(written, result.Location)
+		// Sampled every second
+		time.Sleep(20 * time.Millisecond)
+	}
+	var quota *dberrors.QuotaExceededError
+	if !errors.As(refused, &quota) || quota.Resource != "memory" {
+		t.Fatalf("Writes of %d MB returned %v", len(written), refused)
+	}
+	if !node.Running() {
+		t.Fatal("Server died under memory pressure")
+	}
+	if stats := c.Stats(0); !stats.MemoryPressure || stats.MemoryRejectedWrites == 0 {
+		t.Errorf("GetStats under memory pressure returned %v", stats)
+	}
+	health := healthpb.NewHealthClient(node.Conn)
+	if resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "db.DbService/writes"}); err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Health of writes under memory pressure is %v, %v", resp, err)
+	}
+	if resp, err := node.Client.Get(ctx, "big0", written[0]); err != nil || len(resp.Value) != len(value) {
+		t.Errorf("Get under memory pressure returned %v", err)
+	}
+
+	now := time.Now().UnixNano()
+	for _, location := range written {
+		if _, err := node.Client.ExpireNode(ctx, &db.ExpireNodeRequest{Location: location, ExpiresAt: now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if resp, err := node.Client.Compact(ctx, &db.Empty{}); err != nil || resp.RemovedNodes != int64(len(written)) {
+		t.Fatalf("Compact returned %v, %v", resp, err)
+	}
+	c.WaitFor(10*time.Second, "writes to resume", func() bool {
+		_, err := node.Client.Write(ctx, "small", []byte("v"), root)
+		return err == nil
+	})
+	if stats := c.Stats(0); stats.MemoryPressure {
+		t.Errorf("GetStats after compaction returned %v", stats)
+	}
+	if resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "db.DbService/writes"}); err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Health of writes after compaction is %v, %v", resp, err)
+	}
+}
@@ -0,0 +1,88 @@
+package harness
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Misplaced nodes after a rule change are moved,
+// and their old locations keep working for reads and writes
+func TestPlacementMigration(t *testing.T) {
+	c := Start(t, Options{Servers: 2})
+	root := c.CreateRoot()
+	c.Split(0)
+	ctx, cancel := Context()
+	defer cancel()
+
+	setRules := func(rules ...*db.PlacementRule) {
+		if _, err := c.Nodes[0].Client.SetPlacementRules(ctx, &db.PlacementRules{Rules: rules}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setRules(&db.PlacementRule{Prefix: "order:", Delimiter: ":"})
+	locations := make(map[string]uint64)
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("order:1:item%d", i)
+		written, err := c.Nodes[i%2].Client.Write(ctx, key, []byte(key), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations[key] = written.Location
+	}
+
+	// Each key is placed by itself now
+	setRules()
+	c.WaitFor(30*time.Second, "misplaced nodes to be moved", func() bool {
+		for _, node := range c.Nodes {
+			resp, err := node.Client.VerifyPlacement(ctx, &db.Empty{})
+			if err != nil || resp.Misplaced > 0 {
+				return false
+			}
+		}
+		return true
+	})
+	moved := 0
+	for i := range c.Nodes {
+		moved += strings.Count(c.Log(i), "Misplaced node")
+	}
+	if moved != len(locations) {
+		t.Errorf("%d nodes moved instead of %d", moved, len(locations))
+	}
+
+	for key, location := range locations {
+		for _, node := range c.Nodes {
+			resp, err := node.Client.Get(ctx, key, location)
+			if err != nil {
+				t.Fatalf("Get of %s at its old location from %s failed: %v", key, node.Address, err)
+			}
+			if string(resp.Value) != key {
+				t.Errorf("Get of %s at its old location returned %q", key, resp.Value)
+			}
+		}
+	}
+	// Writes depending on an old location follow the moved node
+	key := "order:1:item0"
+	written, err := c.Nodes[1].Client.Write(ctx, key, []byte("updated"), locations[key])
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Nodes[0].Client.Get(ctx, key, written.Location)
+	if err != nil || string(resp.Value) != "updated" {
+		t.Errorf("Get of the write on an old location returned %v, %v", resp, err)
+	}
+	keyed := 0
+	for _, node := range c.Nodes {
+		resp, err := node.Client.VerifyPlacement(ctx, &db.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyed += int(resp.Checked)
+	}
+	if keyed != len(locations)+1 {
+		t.Errorf("%d nodes with a key instead of %d", keyed, len(locations)+1)
+	}
+}
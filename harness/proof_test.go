@@ -0,0 +1,88 @@
+package harness
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/client"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/protobuf/proto"
+)
+
+// Check that the proof fails verification at the link
+func checkProofFails(t *testing.T, what string, proof *db.Proof, key string, root uint64, link int) {
+	t.Helper()
+	_, err := client.VerifyProof(proof, key, root)
+	var proofErr *client.ProofError
+	if !errors.Is(err, client.ErrInvalidProof) || !errors.As(err, &proofErr) {
+		t.Errorf("Verification of a %s returned %v, expected an invalid proof", what, err)
+		return
+	}
+	if proofErr.Link != link {
+		t.Errorf("Verification of a %s failed at link %d, expected %d: %v", what, proofErr.Link, link, err)
+	}
+}
+
+// A proof of a chain stored by both servers verifies, and fails at the altered link
+// once a value is tampered with or the chain is spliced with another branch
+func TestProof(t *testing.T) {
+	c := Start(t, Options{Servers: 2})
+	c.Split(0)
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+	set := func(key, value string, dep uint64) uint64 {
+		t.Helper()
+		written, err := c.Nodes[0].Client.Set(ctx, &db.SetRequest{Key: key, Value: []byte(value), Dep: dep})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return written.Location
+	}
+	first, second := c.Nodes[0].Address, c.Nodes[1].Address
+	key := c.keyOwnedBy(t, first, "a")
+	a := set(key, "1", root)
+	b := set(c.keyOwnedBy(t, second, "b"), "2", a)
+	end := set(c.keyOwnedBy(t, first, "c"), "3", b)
+
+	proof, err := c.Nodes[1].Client.GetProof(ctx, key, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defining, err := client.VerifyProof(proof, key, root)
+	if err != nil {
+		t.Fatalf("Valid proof fails verification: %v", err)
+	}
+	if defining.Location != a || string(defining.Value) != "1" {
+		t.Errorf("Proof defines %s at %x with %q, expected %x with \"1\"", key, defining.Location, defining.Value, a)
+	}
+	if len(proof.Steps) != 4 || proof.Root != root {
+		t.Fatalf("Proof has %d steps up to root %x, expected 4 up to %x", len(proof.Steps), proof.Root, root)
+	}
+	// Each segment is annotated with the server storing it
+	for i, step := range proof.Steps {
+		if owner := c.owner(t, step.Location); step.Server != owner {
+			t.Errorf("Step %d (%x) is from %s, expected its owner %s", i, step.Location, step.Server, owner)
+		}
+	}
+
+	tampered := proto.Clone(proof).(*db.Proof)
+	tampered.Steps[proof.Defining].Value = []byte("forged")
+	checkProofFails(t, "tampered value", tampered, key, root, int(proof.Defining))
+
+	// Another branch of the same root
+	otherKey := c.keyOwnedBy(t, second, "x")
+	other := set(otherKey, "4", root)
+	branch, err := c.Nodes[0].Client.GetProof(ctx, otherKey, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spliced := proto.Clone(proof).(*db.Proof)
+	spliced.Steps = append(spliced.Steps[:2], branch.Steps...)
+	checkProofFails(t, "spliced chain", spliced, key, root, 1)
+	// Pointing the dep at the branch breaks the checksum covering it
+	spliced.Steps[1].Dep = other
+	checkProofFails(t, "spliced chain with a rewritten dep", spliced, key, root, 1)
+
+	checkProofFails(t, "proof of another root", proof, key, c.CreateRoot(), 3)
+}
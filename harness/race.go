@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package harness
+
+// Servers are built with the race detector too
+const raceEnabled = true
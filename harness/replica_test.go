@@ -0,0 +1,118 @@
+package harness
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/client"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// A primary with one standby (two replicas of every range):
+// round robin reads alternate between them, then fail over when either stops
+func TestReplicaReads(t *testing.T) {
+	c := Start(t, Options{Servers: 2, Started: 1, Config: map[string]interface{}{"backend": "disk"}})
+	c.Configure(1, map[string]interface{}{"standbyOf": c.Nodes[0].Address})
+	c.Start(1)
+
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+	var locations []uint64
+	for i := 0; i < 10; i++ {
+		written, err := c.Nodes[0].Client.Write(ctx, fmt.Sprintf("k%d", i), []byte(fmt.Sprintf("v%d", i)), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations = append(locations, written.Location)
+	}
+	c.WaitFor(10*time.Second, "the standby to catch up", func() bool {
+		ctx, cancel := Context()
+		defer cancel()
+		stats, err := c.Nodes[1].Client.GetStats(ctx, &db.Empty{})
+		return err == nil && stats.Standby != nil && stats.Standby.Connected &&
+			stats.Standby.Offset > 0 && stats.Standby.LagBytes == 0
+	})
+
+	mapping, err := c.Nodes[0].Client.GetMapping(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range mapping.Ranges {
+		if len(r.Replicas) != 2 || r.Replicas[0] != c.Nodes[0].Address || r.Replicas[1] != c.Nodes[1].Address {
+			t.Fatalf("Range %d-%d has replicas %v", r.Left, r.Right, r.Replicas)
+		}
+	}
+
+	reader := c.Nodes[0].Client
+	reader.Preference = client.RoundRobin
+	err = reader.ConnectReplicas(ctx, func(address string) (db.DbServiceClient, error) {
+		conn, err := Dial(address, c.Options.Secret)
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(func() { conn.Close() })
+		return db.NewDbServiceClient(conn), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reader.Replicas) != 1 || reader.Replicas[0].Address != c.Nodes[1].Address {
+		t.Fatalf("Client connected to replicas %v", reader.Replicas)
+	}
+
+	// Server that served each read
+	read := func(i int) string {
+		t.Helper()
+		ctx, cancel := Context()
+		defer cancel()
+		var header metadata.MD
+		resp, err := reader.Get(ctx, fmt.Sprintf("k%d", i), locations[i], grpc.Header(&header))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := fmt.Sprintf("v%d", i); string(resp.Value) != expected {
+			t.Fatalf("Read of k%d returned %q, expected %q", i, resp.Value, expected)
+		}
+		if servedBy := header.Get("served-by"); len(servedBy) > 0 {
+			return servedBy[0]
+		}
+		return ""
+	}
+
+	var servers []string
+	for i := range locations {
+		servers = append(servers, read(i))
+	}
+	for i, server := range servers {
+		if server != c.Nodes[0].Address && server != c.Nodes[1].Address {
+			t.Fatalf("Read %d served by %q", i, server)
+		}
+		if i > 0 && server == servers[i-1] {
+			t.Fatalf("Reads do not alternate between the replicas: %v", servers)
+		}
+	}
+
+	// Both replicas serve every read while the other one is stopped
+	for _, stopped := range []int{1, 0} {
+		c.Stop(stopped)
+		remaining := c.Nodes[1-stopped].Address
+		for i := range locations {
+			if server := read(i); server != remaining {
+				t.Fatalf("Read of k%d served by %q with %s stopped", i, server, c.Nodes[stopped].Address)
+			}
+		}
+		c.Start(stopped)
+		if stopped == 1 {
+			c.WaitFor(10*time.Second, "the standby to reconnect", func() bool {
+				ctx, cancel := Context()
+				defer cancel()
+				stats, err := c.Nodes[1].Client.GetStats(ctx, &db.Empty{})
+				return err == nil && stats.Standby != nil && stats.Standby.Connected && stats.Standby.LagBytes == 0
+			})
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package harness
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+const (
+	soakDuration = 60 * time.Second
+	// Goroutines of a server under the load of the soak test
+	soakMaxGoroutines = 300
+	// Keys read back from every server at the end
+	soakSampledKeys = 500
+)
+
+// Sustained writes over a small threshold, so that ranges are split again and again
+// (last resort splits once no server is available).
+// Goroutines and connections of the servers must stay bounded,
+// and every written key must stay readable.
+func TestSoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Soak test skipped in short mode")
+	}
+	c := Start(t, Options{
+		Servers:   4,
+		Threshold: 300,
+		Config: map[string]interface{}{
+			"lastResortSplit":         true,
+			"minSplitIntervalSeconds": 2,
+		},
+	})
+	root := c.CreateRoot()
+	deadline := time.Now().Add(soakDuration)
+
+	var lock sync.Mutex
+	locations := make(map[string]uint64)
+	var wg sync.WaitGroup
+	for writer := range c.Nodes {
+		wg.Add(1)
+		go func(writer int) {
+			defer wg.Done()
+			for i := 0; time.Now().Before(deadline); i++ {
+				key := fmt.Sprintf("w%dk%d", writer, i)
+				var written uint64
+				var err error
+				// A write racing a split may be aborted once
+				for attempt := 0; attempt < 2; attempt++ {
+					ctx, cancel := Context()
+					var resp *db.SetResponse
+					resp, err = c.Nodes[writer].Client.Set(ctx, &db.SetRequest{Key: key, Value: []byte(key), Dep: root})
+					cancel()
+					if err == nil {
+						written = resp.Location
+						break
+					}
+					time.Sleep(100 * time.Millisecond)
+				}
+				if err != nil {
+					t.Errorf("Write of %s failed: %v", key, err)
+					return
+				}
+				lock.Lock()
+				locations[key] = written
+				lock.Unlock()
+			}
+		}(writer)
+	}
+
+	first := c.Mapping(0).Epoch
+	maxGoroutines, maxConnections := int64(0), int64(0)
+	for time.Now().Before(deadline) {
+		for i := range c.Nodes {
+			stats := c.Stats(i)
+			if stats.Goroutines > maxGoroutines {
+				maxGoroutines = stats.Goroutines
+			}
+			if stats.Connections > maxConnections {
+				maxConnections = stats.Connections
+			}
+		}
+		time.Sleep(time.Second)
+	}
+	wg.Wait()
+
+	mapping := checkMappings(t, c)
+	splits := mapping.Epoch - first
+	t.Logf("%d keys written, %d splits, %d ranges, at most %d goroutines and %d connections",
+		len(locations), splits, len(mapping.Ranges), maxGoroutines, maxConnections)
+	if splits < 5 {
+		t.Errorf("Only %d splits during the soak", splits)
+	}
+	if maxGoroutines > soakMaxGoroutines {
+		t.Errorf("A server ran %d goroutines (bound %d)", maxGoroutines, soakMaxGoroutines)
+	}
+	// A connection per peer
+	if maxConnections > int64(len(c.Nodes)-1) {
+		t.Errorf("A server opened %d connections to %d peers", maxConnections, len(c.Nodes)-1)
+	}
+
+	sampled := make(map[string]uint64)
+	for key, location := range locations {
+		if len(sampled) >= soakSampledKeys {
+			break
+		}
+		sampled[key] = location
+	}
+	checkKeys(t, c, sampled)
+}
@@ -0,0 +1,198 @@
+package harness
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Ranges of the mapping of the server as sorted "left-right:server" strings
+func ranges(mapping *db.Mapping) []string {
+	var ranges []string
+	for _, r := range mapping.Ranges {
+		ranges = append(ranges, fmt.Sprintf("%08x-%08x:%s", r.Left, r.Right, r.Server))
+	}
+	sort.Strings(ranges)
+	return ranges
+}
+
+// Check that the running servers agree on a mapping covering the whole hash range once
+func checkMappings(t *testing.T, c *Cluster) *db.Mapping {
+	t.Helper()
+	var mapping *db.Mapping
+	c.WaitFor(10*time.Second, "the mappings to agree", func() bool {
+		mapping = nil
+		for i, node := range c.Nodes {
+			if !node.Running() {
+				continue
+			}
+			m := c.Mapping(i)
+			if mapping == nil {
+				mapping = m
+			} else if m.Epoch != mapping.Epoch || fmt.Sprint(ranges(m)) != fmt.Sprint(ranges(mapping)) {
+				return false
+			}
+		}
+		return true
+	})
+
+	sorted := append([]*db.Range(nil), mapping.Ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Left < sorted[j].Left })
+	next := uint64(0)
+	for _, r := range sorted {
+		if uint64(r.Left) != next {
+			t.Fatalf("Mapping has a gap or an overlap at %x: %v", next, ranges(mapping))
+		}
+		next = uint64(r.Right) + 1
+	}
+	if next != math.MaxUint32+1 {
+		t.Fatalf("Mapping ends at %x: %v", next, ranges(mapping))
+	}
+	return mapping
+}
+
+// Check that every key can be read from every running server
+func checkKeys(t *testing.T, c *Cluster, locations map[string]uint64) {
+	t.Helper()
+	for key, location := range locations {
+		for _, node := range c.Nodes {
+			if !node.Running() {
+				continue
+			}
+			ctx, cancel := Context()
+			value, err := node.Client.Get(ctx, key, location)
+			cancel()
+			if err != nil {
+				t.Fatalf("Get of %s from %s failed: %v", key, node.Address, err)
+			}
+			if string(value.Value) != key {
+				t.Fatalf("Value of %s is %q", key, value.Value)
+			}
+		}
+	}
+}
+
+// Split the owners at the same time while keys are written
+func TestConcurrentSplits(t *testing.T) {
+	c := Start(t, Options{Servers: 7})
+	root := c.CreateRoot()
+	locations := make(map[string]uint64)
+	var lock sync.Mutex
+	write := func(round, writer int) {
+		ctx, cancel := Context()
+		defer cancel()
+		for i := 0; i < 10; i++ {
+			key := fmt.Sprintf("r%dw%dk%d", round, writer, i)
+			written, err := c.Nodes[writer%len(c.Nodes)].Client.Write(ctx, key, []byte(key), root)
+			if err != nil {
+				t.Errorf("Write of %s failed: %v", key, err)
+				return
+			}
+			lock.Lock()
+			locations[key] = written.Location
+			lock.Unlock()
+		}
+	}
+	write(0, 0)
+	c.Split(0)
+
+	for round := 1; round <= 2; round++ {
+		mapping := checkMappings(t, c)
+		var wg sync.WaitGroup
+		var splits, failed int
+		for _, r := range mapping.Ranges {
+			i := c.Index(r.Server)
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := Context()
+				defer cancel()
+				_, err := c.Nodes[i].Client.TriggerSplit(ctx, &db.TriggerSplitRequest{})
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					// Losing a race for the same target or the indexing locks aborts the split
+					t.Logf("Split of %s failed: %v", c.Nodes[i].Address, err)
+					failed++
+				} else {
+					splits++
+				}
+			}()
+			go func(writer int) {
+				defer wg.Done()
+				write(round, writer)
+			}(i)
+		}
+		wg.Wait()
+		if splits == 0 {
+			t.Fatalf("No split of round %d succeeded (%d failed)", round, failed)
+		}
+		after := checkMappings(t, c)
+		if len(after.Ranges) != len(mapping.Ranges)+splits {
+			t.Errorf("%d ranges after %d splits of %d ranges", len(after.Ranges), splits, len(mapping.Ranges))
+		}
+		if after.Epoch <= mapping.Epoch {
+			t.Errorf("Epoch did not advance: %d after %d", after.Epoch, mapping.Epoch)
+		}
+		checkKeys(t, c, locations)
+	}
+}
+
+// A server owning two ranges splits the one overflowing,
+// even if it is not the first in its mapping
+func TestSplitSecondRange(t *testing.T) {
+	const threshold = 100
+	c := Start(t, Options{
+		Servers:   4,
+		Threshold: threshold,
+		Config:    map[string]interface{}{"minSplitIntervalSeconds": 1},
+	})
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+	for _, target := range []int{1, 2} {
+		if _, err := c.Nodes[0].Client.TriggerSplit(ctx, &db.TriggerSplitRequest{Target: c.Nodes[target].Address}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkMappings(t, c)
+	// Keys of the range absorbed by server 2 on top of its own
+	keys := c.keysOwnedBy(t, c.Nodes[1].Address, "absorbed", threshold*3/2)
+	c.Decommission(1, c.Nodes[2].Address)
+	owned := 0
+	for _, r := range checkMappings(t, c).Ranges {
+		if r.Server == c.Nodes[2].Address {
+			owned++
+		}
+	}
+	if owned != 2 {
+		t.Fatalf("Server 2 owns %d ranges instead of 2: %v", owned, c.Mapping(2).Ranges)
+	}
+
+	locations := make(map[string]uint64)
+	for _, key := range keys {
+		written, err := c.Nodes[2].Client.Write(ctx, key, []byte(key), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations[key] = written.Location
+	}
+	c.WaitFor(20*time.Second, "the absorbed range to be split", func() bool {
+		for _, key := range keys {
+			if owner := c.keyOwner(t, key); owner == c.Nodes[3].Address {
+				return true
+			}
+		}
+		return false
+	})
+	checkMappings(t, c)
+	if nodes := c.Stats(2).Nodes; nodes >= int64(len(keys)) {
+		t.Errorf("Server 2 kept %d nodes after the split", nodes)
+	}
+	checkKeys(t, c, locations)
+}
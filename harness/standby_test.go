@@ -0,0 +1,124 @@
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Size of the log of the server (as reported to a follower)
+func logSize(t *testing.T, c *Cluster, i int) int64 {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	stream, err := c.Nodes[i].Client.FollowWAL(ctx, &db.FollowWALRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return batch.LogSize
+}
+
+// A standby promoted after its primary is killed takes over its ranges
+// and serves the keys written before it, losing only writes within the reported lag
+func TestStandbyPromotion(t *testing.T) {
+	// The primary is not the seed, which must stay up to register the standby
+	c := Start(t, Options{Servers: 3, Config: map[string]interface{}{"backend": "disk"}})
+	c.Split(0)
+	// The server receiving the split is the primary, the other one its standby
+	p, s := 2, 1
+	for _, r := range checkMappings(t, c).Ranges {
+		if r.Server == c.Nodes[1].Address {
+			p, s = 1, 2
+		}
+	}
+	primary, standby := c.Nodes[p].Address, c.Nodes[s].Address
+	c.Configure(s, map[string]interface{}{"standbyOf": primary})
+	c.Restart(s)
+
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+	keys := c.keysOwnedBy(t, primary, "k", 40)
+	locations := make([]uint64, len(keys))
+	written := make([]time.Time, len(keys))
+	write := func(i int) {
+		t.Helper()
+		resp, err := c.Nodes[0].Client.Write(ctx, keys[i], []byte(fmt.Sprintf("v%d", i)), root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations[i], written[i] = resp.Location, time.Now()
+	}
+
+	// Applied by the standby before the primary is killed
+	for i := 0; i < 20; i++ {
+		write(i)
+	}
+	size := logSize(t, c, p)
+	c.WaitFor(10*time.Second, "the standby to catch up", func() bool {
+		return c.Stats(s).Standby.Offset >= size
+	})
+	// Killed right after the last ones, which may not have been sent
+	for i := 20; i < len(keys); i++ {
+		write(i)
+	}
+	c.Kill(p)
+	c.WaitFor(10*time.Second, "the standby to lose its primary", func() bool {
+		return !c.Stats(s).Standby.Connected
+	})
+
+	promoted, err := c.Nodes[s].Client.Promote(ctx, &db.PromoteRequest{})
+	if err != nil {
+		t.Fatalf("Promote failed: %v\n%s", err, c.Log(s))
+	}
+	now := time.Now()
+	if promoted.Primary != primary || promoted.PrimaryDemoted || len(promoted.Ranges) == 0 {
+		t.Fatalf("Promote returned %+v", promoted)
+	}
+	if stats := c.Stats(s); stats.Mode != db.Mode_NORMAL || !stats.Standby.Promoted {
+		t.Errorf("Promoted standby is in mode %v with status %+v", stats.Mode, stats.Standby)
+	}
+	for _, r := range c.Mapping(0).Ranges {
+		if r.Server == primary {
+			t.Errorf("Range %x-%x still owned by the dead primary", r.Left, r.Right)
+		}
+	}
+
+	// Records are applied in order, so writes not applied were all acknowledged
+	// after the last time the standby caught up (the reported lag)
+	caughtUp := now.Add(-time.Duration(promoted.LagMillis)*time.Millisecond - time.Second)
+	lost := 0
+	for i, key := range keys {
+		resp, err := c.Nodes[0].Client.Get(ctx, key, locations[i])
+		if errors.Is(err, dberrors.ErrLocationNotFound) || errors.Is(err, dberrors.ErrKeyNotFound) {
+			lost++
+			if i < 20 || written[i].Before(caughtUp) {
+				t.Errorf("Write of %s acknowledged %v before the promotion is lost (lag %d ms)", key, now.Sub(written[i]), promoted.LagMillis)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := fmt.Sprintf("v%d", i); string(resp.Value) != expected {
+			t.Errorf("Read of %s returned %q, expected %q", key, resp.Value, expected)
+		}
+		if owner := c.owner(t, locations[i]); owner != standby {
+			t.Errorf("Location of %s owned by %s, expected the promoted standby", key, owner)
+		}
+	}
+	t.Logf("Lost %d of the last %d writes with a lag of %d bytes, %d ms", lost, len(keys)-20, promoted.LagBytes, promoted.LagMillis)
+
+	// Writable in place of the primary
+	if _, err := c.Nodes[0].Client.Write(ctx, keys[0], []byte("after"), locations[0]); err != nil {
+		t.Errorf("Write after the promotion failed: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package harness
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/metadata"
+)
+
+type collectedSpan struct {
+	// Server that exported the span
+	Service      string
+	TraceId      string
+	SpanId       string
+	ParentSpanId string
+	Name         string
+	Kind         int
+	Attributes   map[string]string
+}
+
+// OTLP/HTTP collector keeping the spans it receives in memory
+type collector struct {
+	lock  sync.Mutex
+	spans []collectedSpan
+}
+
+type otlpAttributes []struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+func (a otlpAttributes) toMap() map[string]string {
+	m := make(map[string]string)
+	for _, attribute := range a {
+		m[attribute.Key] = attribute.Value.StringValue
+	}
+	return m
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes otlpAttributes `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceId      string         `json:"traceId"`
+					SpanId       string         `json:"spanId"`
+					ParentSpanId string         `json:"parentSpanId"`
+					Name         string         `json:"name"`
+					Kind         int            `json:"kind"`
+					Attributes   otlpAttributes `json:"attributes"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, resource := range request.ResourceSpans {
+		service := resource.Resource.Attributes.toMap()["service.name"]
+		for _, scope := range resource.ScopeSpans {
+			for _, span := range scope.Spans {
+				c.spans = append(c.spans, collectedSpan{
+					Service:      service,
+					TraceId:      span.TraceId,
+					SpanId:       span.SpanId,
+					ParentSpanId: span.ParentSpanId,
+					Name:         span.Name,
+					Kind:         span.Kind,
+					Attributes:   span.Attributes.toMap(),
+				})
+			}
+		}
+	}
+}
+
+// Span of the trace with the parent, name and service (nil if not received)
+func (c *collector) find(trace, parent, name, service string) *collectedSpan {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i := range c.spans {
+		span := &c.spans[i]
+		if span.TraceId == trace && span.ParentSpanId == parent && span.Name == name && span.Service == service {
+			return span
+		}
+	}
+	return nil
+}
+
+// A Set sent to the server not owning the key is one trace:
+// the request on the receiver, its forward to the owner, the request on the owner and the store write
+func TestForwardedSetTrace(t *testing.T) {
+	spans := &collector{}
+	endpoint := httptest.NewServer(spans)
+	defer endpoint.Close()
+	c := Start(t, Options{Servers: 2, Config: map[string]interface{}{"traceEndpoint": endpoint.URL}})
+	c.Split(0)
+	root := c.CreateRoot()
+	receiver, owner := c.Nodes[0].Address, c.Nodes[1].Address
+	key := c.keyOwnedBy(t, owner, "traced")
+
+	// Span of the caller
+	var traceID [16]byte
+	var spanID [8]byte
+	rand.Read(traceID[:])
+	rand.Read(spanID[:])
+	trace, caller := hex.EncodeToString(traceID[:]), hex.EncodeToString(spanID[:])
+	ctx, cancel := Context()
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "traceparent", "00-"+trace+"-"+caller+"-01")
+	if _, err := c.Nodes[0].Client.DbServiceClient.Set(ctx, &db.SetRequest{Key: key, Value: []byte("v"), Dep: root}); err != nil {
+		t.Fatal(err)
+	}
+
+	const method = "/db.DbService/Set"
+	var received, forward, owned, write *collectedSpan
+	// Spans are exported every second
+	c.WaitFor(10*time.Second, "the spans of the Set", func() bool {
+		if received = spans.find(trace, caller, method, receiver); received == nil {
+			return false
+		}
+		if forward = spans.find(trace, received.SpanId, method, receiver); forward == nil {
+			return false
+		}
+		if owned = spans.find(trace, forward.SpanId, method, owner); owned == nil {
+			return false
+		}
+		write = spans.find(trace, owned.SpanId, "store.write", owner)
+		return write != nil
+	})
+
+	// Kinds of OTLP
+	const internal, server, client = 1, 2, 3
+	if received.Kind != server || forward.Kind != client || owned.Kind != server || write.Kind != internal {
+		t.Errorf("Spans have kinds %d, %d, %d and %d", received.Kind, forward.Kind, owned.Kind, write.Kind)
+	}
+	if target := forward.Attributes["net.peer.name"]; target != owner {
+		t.Errorf("Forward has target %q, expected %s", target, owner)
+	}
+	// Nothing of the owner is outside the forward
+	spans.lock.Lock()
+	defer spans.lock.Unlock()
+	for _, span := range spans.spans {
+		if span.TraceId == trace && span.Service == owner && span.Name == method && span.ParentSpanId != forward.SpanId {
+			t.Errorf("Set on the owner has parent %s, expected the forward %s", span.ParentSpanId, forward.SpanId)
+		}
+	}
+}
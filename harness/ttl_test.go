@@ -0,0 +1,49 @@
+package harness
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Nodes moved by a split keep their expiry time
+func TestTTLAcrossSplit(t *testing.T) {
+	c := Start(t, Options{Servers: 2})
+	root := c.CreateRoot()
+	ctx, cancel := Context()
+	defer cancel()
+
+	expiry := make(map[uint64]int64)
+	for i := 0; i < 50; i++ {
+		written, err := c.Nodes[0].Client.Set(ctx, &db.SetRequest{Key: fmt.Sprintf("session%d", i), Value: []byte("v"), Dep: root, TtlSeconds: 3600})
+		if err != nil {
+			t.Fatal(err)
+		}
+		node := getNode(t, c, written.Location)
+		if node.ExpiresAt == 0 {
+			t.Fatalf("Node written with a TTL has no expiry")
+		}
+		expiry[written.Location] = node.ExpiresAt
+	}
+
+	c.Split(0)
+	checkMappings(t, c)
+	moved := 0
+	for location, expiresAt := range expiry {
+		owner := c.Index(c.owner(t, location))
+		if owner != 0 {
+			moved++
+		}
+		node, err := c.Nodes[owner].Client.GetNode(ctx, &db.GetNodeRequest{Location: location})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node.ExpiresAt != expiresAt {
+			t.Errorf("Node %x on %s expires at %d, expected %d", location, c.Nodes[owner].Address, node.ExpiresAt, expiresAt)
+		}
+	}
+	if moved == 0 {
+		t.Fatalf("No node moved in the split")
+	}
+}
@@ -0,0 +1,145 @@
+package harness
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Hammer one key through the server not owning it and return the nodes written
+func hammer(t *testing.T, c *Cluster, via int, key string, dep uint64, serialize bool, writers int) []uint64 {
+	t.Helper()
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var locations []uint64
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := Context()
+			defer cancel()
+			resp, err := c.Nodes[via].Client.Set(ctx, &db.SetRequest{
+				Key:             key,
+				Value:           []byte("v"),
+				Dep:             dep,
+				SerializeWrites: serialize,
+			})
+			if err != nil {
+				t.Errorf("Set failed: %v", err)
+				return
+			}
+			lock.Lock()
+			locations = append(locations, resp.Location)
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+	return locations
+}
+
+func getNode(t *testing.T, c *Cluster, location uint64) *db.Node {
+	t.Helper()
+	ctx, cancel := Context()
+	defer cancel()
+	node, err := c.Nodes[0].Client.GetNode(ctx, &db.GetNodeRequest{Location: location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node
+}
+
+// Concurrent Sets of one key with and without SerializeWrites,
+// sent to the server not owning the key (which would pipeline them)
+func TestSerializeWritesShape(t *testing.T) {
+	const writers = 16
+	// Pipelined Sets are signed with the cluster secret
+	c := Start(t, Options{Servers: 2, Secret: "secret"})
+	c.Split(0)
+	root := c.CreateRoot()
+	rootOwner := c.Index(c.owner(t, root))
+	// Keys owned by the other server, so links cross servers
+	other := c.Nodes[1-rootOwner].Address
+
+	t.Run("latest node", func(t *testing.T) {
+		key := c.keyOwnedBy(t, other, "chain")
+		first := hammer(t, c, rootOwner, key, root, true, 1)
+		written := hammer(t, c, rootOwner, key, db.DepAuto, true, writers)
+		// Each write chains onto the previous one
+		location := first[0]
+		for i := 0; i < writers; i++ {
+			node := getNode(t, c, location)
+			if len(node.Children) != 1 {
+				t.Fatalf("Node %d of the chain has %d children", i, len(node.Children))
+			}
+			location = node.Children[0]
+		}
+		if len(getNode(t, c, location).Children) != 0 || len(written) != writers {
+			t.Errorf("Chain does not end at the last write")
+		}
+	})
+
+	t.Run("serialized siblings", func(t *testing.T) {
+		key := c.keyOwnedBy(t, other, "serial")
+		parent := hammer(t, c, rootOwner, key, root, true, 1)[0]
+		hammer(t, c, rootOwner, key, parent, true, writers)
+		// Linked by the owner in the order it wrote them
+		children := getNode(t, c, parent).Children
+		if len(children) != writers {
+			t.Fatalf("%d children, expected %d", len(children), writers)
+		}
+		created := make([]int64, len(children))
+		for i, child := range children {
+			created[i] = getNode(t, c, child).CreatedAt
+		}
+		if !sort.SliceIsSorted(created, func(i, j int) bool { return created[i] < created[j] }) {
+			t.Errorf("Children are not linked in write order: %v", created)
+		}
+	})
+
+	t.Run("concurrent siblings", func(t *testing.T) {
+		key := c.keyOwnedBy(t, other, "free")
+		parent := hammer(t, c, rootOwner, key, root, false, 1)[0]
+		hammer(t, c, rootOwner, key, parent, false, writers)
+		// Every writer conflicts with the others
+		if children := getNode(t, c, parent).Children; len(children) != writers {
+			t.Errorf("%d children, expected %d", len(children), writers)
+		}
+	})
+}
+
+// A Set retried through another server is written once,
+// even though both servers link the node to its dep themselves
+func TestSetRetriedElsewhere(t *testing.T) {
+	c := Start(t, Options{Servers: 3})
+	c.Split(0)
+	c.Split(0)
+	root := c.CreateRoot()
+	rootOwner := c.Index(c.owner(t, root))
+	var key string
+	var others []int
+	for i := range c.Nodes {
+		if i != rootOwner {
+			others = append(others, i)
+		}
+	}
+	key = c.keyOwnedBy(t, c.Nodes[others[0]].Address, "retried")
+
+	var locations []uint64
+	for _, via := range []int{rootOwner, others[1]} {
+		ctx, cancel := Context()
+		resp, err := c.Nodes[via].Client.Set(ctx, &db.SetRequest{Key: key, Value: []byte("v"), Dep: root, RequestId: "retried"})
+		cancel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations = append(locations, resp.Location)
+	}
+	if locations[0] != locations[1] {
+		t.Errorf("Retry wrote %x after %x", locations[1], locations[0])
+	}
+	if children := getNode(t, c, root).Children; len(children) != 1 || children[0] != locations[0] {
+		t.Errorf("Children of the dep are %x, expected %x", children, locations[0])
+	}
+}
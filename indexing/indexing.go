@@ -2,7 +2,11 @@ package indexing
 
 import (
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/DCsunset/openwhisk-grpc/placement"
 	"github.com/DCsunset/openwhisk-grpc/utils"
 )
 
@@ -12,20 +16,133 @@ type Mapping struct {
 	Address string
 }
 
+// Mappings and Epoch are only accessed through methods once the service is shared.
 type Service struct {
 	Mappings []Mapping
-	Lock     bool
+	// Incremented by every committed split
+	Epoch uint64
+	// Protects Mappings and Epoch
+	mappingLock sync.RWMutex
+
+	lock sync.Mutex
+	// Server holding the indexing lock and when its lease expires
+	holder string
+	expiry time.Time
 }
 
 func (self *Service) Init() {
-	self.Lock = false
+	self.holder = ""
+}
+
+// Acquire the indexing lock for holder.
+// The lock can be taken over after the lease expires.
+func (s *Service) TryLock(holder string, lease time.Duration) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.holder != "" && s.holder != holder && time.Now().Before(s.expiry) {
+		return false
+	}
+	s.holder = holder
+	s.expiry = time.Now().Add(lease)
+	return true
+}
+
+// Release the lock if it is held by holder
+func (s *Service) Unlock(holder string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.holder != holder {
+		return false
+	}
+	s.holder = ""
+	return true
+}
+
+// Check whether holder still holds an unexpired lock
+func (s *Service) HeldBy(holder string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.holder == holder && time.Now().Before(s.expiry)
+}
+
+// When the lease of the holder expires
+func (s *Service) Expiry() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.expiry
+}
+
+func (s *Service) Holder() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.holder
+}
+
+// Copy of the mappings and the epoch
+func (s *Service) Snapshot() ([]Mapping, uint64) {
+	s.mappingLock.RLock()
+	defer s.mappingLock.RUnlock()
+
+	return append([]Mapping(nil), s.Mappings...), s.Epoch
+}
+
+// Copy of the mappings
+func (s *Service) List() []Mapping {
+	mappings, _ := s.Snapshot()
+	return mappings
+}
+
+func (s *Service) CurrentEpoch() uint64 {
+	s.mappingLock.RLock()
+	defer s.mappingLock.RUnlock()
+
+	return s.Epoch
+}
+
+// Raise the epoch to at least the given one
+func (s *Service) AdvanceEpoch(epoch uint64) {
+	s.mappingLock.Lock()
+	defer s.mappingLock.Unlock()
+
+	if epoch > s.Epoch {
+		s.Epoch = epoch
+	}
+}
+
+// Increment the epoch and return the new one
+func (s *Service) NextEpoch() uint64 {
+	s.mappingLock.Lock()
+	defer s.mappingLock.Unlock()
+
+	s.Epoch += 1
+	return s.Epoch
+}
+
+// Replace all mappings and the epoch
+func (s *Service) Reset(mappings []Mapping, epoch uint64) {
+	s.mappingLock.Lock()
+	defer s.mappingLock.Unlock()
+
+	s.Mappings = append([]Mapping(nil), mappings...)
+	s.Epoch = epoch
 }
 
 func (s *Service) AddMapping(left, right uint32, server string) {
+	s.mappingLock.Lock()
+	defer s.mappingLock.Unlock()
+
 	s.Mappings = append(s.Mappings, Mapping{left, right, server})
 }
 
 func (s *Service) RemoveMapping(left, right uint32) {
+	s.mappingLock.Lock()
+	defer s.mappingLock.Unlock()
+
 	for i, mapping := range s.Mappings {
 		if mapping.Left == left && mapping.Right == right {
 			l := len(s.Mappings)
@@ -36,7 +153,31 @@ func (s *Service) RemoveMapping(left, right uint32) {
 	}
 }
 
+// Map [left, right] to the server, splitting overlapping mappings
+func (s *Service) Assign(left, right uint32, server string) {
+	s.mappingLock.Lock()
+	defer s.mappingLock.Unlock()
+
+	var mappings []Mapping
+	for _, m := range s.Mappings {
+		if m.Right < left || m.Left > right {
+			mappings = append(mappings, m)
+			continue
+		}
+		if m.Left < left {
+			mappings = append(mappings, Mapping{m.Left, left - 1, m.Address})
+		}
+		if m.Right > right {
+			mappings = append(mappings, Mapping{right + 1, m.Right, m.Address})
+		}
+	}
+	s.Mappings = append(mappings, Mapping{left, right, server})
+}
+
 func (self *Service) Locate(keyHash uint32) string {
+	self.mappingLock.RLock()
+	defer self.mappingLock.RUnlock()
+
 	for _, m := range self.Mappings {
 		if keyHash >= m.Left && keyHash <= m.Right {
 			return m.Address
@@ -45,12 +186,49 @@ func (self *Service) Locate(keyHash uint32) string {
 	panic(fmt.Sprintf("Key hash %x not found", keyHash))
 }
 
+// Locate many hashes in one pass over the sorted mappings
+func (s *Service) LocateAll(hashes []uint32) []string {
+	order := make([]int, len(hashes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return hashes[order[i]] < hashes[order[j]] })
+	mappings, _ := s.Snapshot()
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].Left < mappings[j].Left })
+
+	addresses := make([]string, len(hashes))
+	m := 0
+	for _, i := range order {
+		for m < len(mappings) && mappings[m].Right < hashes[i] {
+			m++
+		}
+		if m == len(mappings) || mappings[m].Left > hashes[i] {
+			panic(fmt.Sprintf("Key hash %x not found", hashes[i]))
+		}
+		addresses[i] = mappings[m].Address
+	}
+	return addresses
+}
+
+// Owner of the node at the location.
+// Routing by location goes through here so that the placement
+// encoded in locations (the key hash in the upper bits) is decoded in one place.
+// There is a single location format: every 64-bit value is a valid location,
+// so no bit is left to mark another format, and the store, splits and transfers
+// also partition nodes by the hash in the location.
+func (s *Service) ResolveOwner(location uint64) string {
+	return s.Locate(utils.KeyHash(location))
+}
+
 func (s *Service) LocateKey(key string) string {
-	keyHash := utils.Hash2Uint(utils.Hash([]byte(key)))
-	return s.Locate(keyHash)
+	return s.Locate(placement.Hash(key))
 }
 
+// First range of the server (servers can own several)
 func (s *Service) Range(server string) (uint32, uint32) {
+	s.mappingLock.RLock()
+	defer s.mappingLock.RUnlock()
+
 	for _, mapping := range s.Mappings {
 		if mapping.Address == server {
 			return mapping.Left, mapping.Right
@@ -59,7 +237,23 @@ func (s *Service) Range(server string) (uint32, uint32) {
 	return 0, 0
 }
 
+// Whether the server owns any range
+func (s *Service) Owns(server string) bool {
+	s.mappingLock.RLock()
+	defer s.mappingLock.RUnlock()
+
+	for _, mapping := range s.Mappings {
+		if mapping.Address == server {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) Print() {
+	s.mappingLock.RLock()
+	defer s.mappingLock.RUnlock()
+
 	fmt.Println("Mappings:")
 	for _, m := range s.Mappings {
 		fmt.Printf("%x-%x: %s\n", m.Left, m.Right, m.Address)
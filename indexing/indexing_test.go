@@ -0,0 +1,54 @@
+package indexing
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/storage"
+)
+
+// Mappings over the whole range, in no particular order
+func testService() *Service {
+	s := &Service{}
+	s.AddMapping(0xc0000000, math.MaxUint32, "d")
+	s.AddMapping(0, 0x3fffffff, "a")
+	s.AddMapping(0x80000000, 0xbfffffff, "c")
+	s.AddMapping(0x40000000, 0x7fffffff, "b")
+	return s
+}
+
+// Nodes of a key are owned by the owner of the key, whichever way their location was made
+func TestResolveOwnerOfKeys(t *testing.T) {
+	s := testService()
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("k%d", i)
+		owner := s.LocateKey(key)
+		node := storage.CreateNode(key, nil, math.MaxUint64)
+		if resolved := s.ResolveOwner(node.Location); resolved != owner {
+			t.Fatalf("Node of %s at %x resolves to %s, expected %s", key, node.Location, resolved, owner)
+		}
+		derived := storage.DeriveLocation(key, "seed", uint64(i))
+		if resolved := s.ResolveOwner(derived); resolved != owner {
+			t.Fatalf("Derived location %x of %s resolves to %s, expected %s", derived, key, resolved, owner)
+		}
+	}
+}
+
+// Locations at the bounds of every range resolve to its owner, whatever their lower bits
+func TestResolveOwnerBounds(t *testing.T) {
+	s := testService()
+	for _, m := range s.Mappings {
+		for _, hash := range []uint32{m.Left, m.Right} {
+			for _, low := range []uint64{0, 1, math.MaxUint32} {
+				location := uint64(hash)<<32 | low
+				if resolved := s.ResolveOwner(location); resolved != m.Address {
+					t.Errorf("Location %x resolves to %s, expected %s", location, resolved, m.Address)
+				}
+				if resolved := s.ResolveOwner(storage.NewLocation(hash)); resolved != m.Address {
+					t.Errorf("New location with hash %x resolves to %s, expected %s", hash, resolved, m.Address)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package indexing
+
+// Split policy of servers, also used by dbplan to simulate splits offline
+
+// Whether a server with the nodes and value bytes must split (thresholdBytes is 0 for no limit)
+func OverThreshold(nodes int, threshold int, bytes int64, thresholdBytes int64) bool {
+	if nodes > threshold {
+		return true
+	}
+	return thresholdBytes > 0 && bytes > thresholdBytes
+}
+
+// Middle of the hash range
+func Midpoint(left, right uint32) uint32 {
+	return uint32((uint64(left) + uint64(right)) / 2)
+}
+
+// Hash to split [left, right] at: the median of the load if byLoad and known,
+// the middle of the range otherwise
+func SplitPoint(left, right uint32, byLoad bool, median func(left, right uint32) (uint32, bool)) uint32 {
+	if byLoad && median != nil {
+		if mid, ok := median(left, right); ok {
+			return mid
+		}
+	}
+	return Midpoint(left, right)
+}
+
+// Bucket boundary splitting the weight of [left, right] in half,
+// with buckets of width hashes and weight giving the weight of a bucket
+// (false if no bucket boundary inside the range has weight on both sides)
+func WeightedMedian(left, right uint32, width uint32, weight func(bucket uint32) int64) (uint32, bool) {
+	first, last := left/width, right/width
+	total := int64(0)
+	for i := first; i <= last; i++ {
+		total += weight(i)
+	}
+	if total == 0 {
+		return 0, false
+	}
+	sum := int64(0)
+	for i := first; i < last; i++ {
+		sum += weight(i)
+		if 2*sum >= total {
+			if sum == total {
+				break
+			}
+			// Last hash of the bucket
+			return i*width + width - 1, true
+		}
+	}
+	return 0, false
+}
+
+// Whether the lower half [left, mid] moves to the new server.
+// The smaller half moves.
+func MoveLower(lower, upper int) bool {
+	return upper >= lower
+}
@@ -0,0 +1,140 @@
+// Package mergeapi defines the payloads exchanged with merge actions.
+// Action authors can import it to decode the input and encode the output.
+package mergeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Current version of the schema
+const Version = 1
+
+type Node struct {
+	Location  uint64            `json:"location"`
+	Dep       uint64            `json:"dep"`
+	Key       string            `json:"key"`
+	Value     []byte            `json:"value"`
+	CreatedAt int64             `json:"createdAt"`
+	Children  []uint64          `json:"children,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	// e.g. application/json (empty if not given)
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Child of the parent with its value
+type Child struct {
+	Node
+	// Server storing the child
+	Server string `json:"server"`
+}
+
+// Registration that selected the merge action
+type Registration struct {
+	Name string `json:"name"`
+	// location, key, prefix, range or global
+	Scope string `json:"scope,omitempty"`
+	// Location of the registration (unset if global)
+	Location uint64 `json:"location,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+	// Key hash range (set if the scope is range)
+	Left   uint32 `json:"left,omitempty"`
+	Right  uint32 `json:"right,omitempty"`
+	Global bool   `json:"global"`
+}
+
+type Input struct {
+	Version      int           `json:"version"`
+	Parent       Node          `json:"parent"`
+	Stats        *db.NodeStats `json:"stats,omitempty"`
+	Children     []Child       `json:"children"`
+	Registration Registration  `json:"registration"`
+	// W3C trace context of the merge (empty if tracing is disabled)
+	Traceparent string `json:"traceparent,omitempty"`
+	// Version of the children of the parent being resolved
+	ChildrenVersion uint64 `json:"childrenVersion"`
+}
+
+type Output struct {
+	Version int `json:"version"`
+	// New children of the parent (or nodes depending on other locations)
+	Nodes []Node `json:"nodes"`
+}
+
+func FromProto(n *db.Node) Node {
+	return Node{
+		Location:  n.Location,
+		Dep:       n.Dep,
+		Key:       n.Key,
+		Value:     n.Value,
+		CreatedAt: n.CreatedAt,
+		Children:  n.Children,
+		Metadata:  n.Metadata,
+
+		ContentType: n.ContentType,
+	}
+}
+
+func (n Node) ToProto() *db.Node {
+	return &db.Node{
+		Location:  n.Location,
+		Dep:       n.Dep,
+		Key:       n.Key,
+		Value:     n.Value,
+		CreatedAt: n.CreatedAt,
+		Children:  n.Children,
+		Metadata:  n.Metadata,
+
+		ContentType: n.ContentType,
+	}
+}
+
+// Output of actions written before the versioned schema (db.Nodes)
+type legacyOutput struct {
+	Version int        `json:"version"`
+	Nodes   []*db.Node `json:"Nodes"`
+}
+
+// Decode the output of an action.
+// Outputs without version are decoded as db.Nodes for compatibility.
+func ParseOutput(data []byte) (*Output, error) {
+	var legacy legacyOutput
+	if err := json.Unmarshal(data, &legacy); err == nil && legacy.Version == 0 {
+		output := &Output{Version: Version}
+		for _, node := range legacy.Nodes {
+			output.Nodes = append(output.Nodes, FromProto(node))
+		}
+		return output, nil
+	}
+
+	var output Output
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, err
+	}
+	if output.Version != Version {
+		return nil, fmt.Errorf("Unsupported version %d (expected %d)", output.Version, Version)
+	}
+	return &output, nil
+}
+
+// Check the output before applying any of it
+func (o *Output) Validate() error {
+	seen := make(map[uint64]bool)
+	for _, node := range o.Nodes {
+		if node.Location == 0 || node.Location == math.MaxUint64 {
+			return fmt.Errorf("Invalid location %x", node.Location)
+		}
+		if node.Dep == node.Location {
+			return fmt.Errorf("Node %x depends on itself", node.Location)
+		}
+		if seen[node.Location] {
+			return fmt.Errorf("Duplicate location %x", node.Location)
+		}
+		seen[node.Location] = true
+	}
+	return nil
+}
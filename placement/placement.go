@@ -0,0 +1,79 @@
+package placement
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Keys starting with Prefix are placed by the key up to the first
+// Delimiter after the prefix (or by the prefix if Delimiter is empty).
+// e.g. {"order:", ":"} places order:123:items and order:123:total together.
+type Rule struct {
+	Prefix    string `json:"prefix"`
+	Delimiter string `json:"delimiter"`
+}
+
+// Rules must be identical on all servers (and clients locating keys)
+type Rules struct {
+	lock  sync.RWMutex
+	rules []Rule
+	epoch uint64
+}
+
+// Rules used by this process
+var Default = &Rules{}
+
+// Replace the rules if the epoch is newer
+func (r *Rules) Set(rules []Rule, epoch uint64) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if epoch <= r.epoch {
+		return false
+	}
+	r.rules = append([]Rule(nil), rules...)
+	r.epoch = epoch
+	return true
+}
+
+func (r *Rules) Get() ([]Rule, uint64) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return append([]Rule(nil), r.rules...), r.epoch
+}
+
+// Key whose hash places the key (the longest matching prefix wins)
+func (r *Rules) Key(key string) string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var match *Rule
+	for i, rule := range r.rules {
+		if strings.HasPrefix(key, rule.Prefix) && (match == nil || len(rule.Prefix) > len(match.Prefix)) {
+			match = &r.rules[i]
+		}
+	}
+	if match == nil {
+		return key
+	}
+	if len(match.Delimiter) == 0 {
+		return match.Prefix
+	}
+	rest := key[len(match.Prefix):]
+	if i := strings.Index(rest, match.Delimiter); i >= 0 {
+		return match.Prefix + rest[:i]
+	}
+	return key
+}
+
+func (r *Rules) Hash(key string) uint32 {
+	return utils.Hash2Uint(utils.Hash([]byte(r.Key(key))))
+}
+
+// Hash of the key with the default rules
+func Hash(key string) uint32 {
+	return Default.Hash(key)
+}
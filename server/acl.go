@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var acl = auth.ACL{}
+
+// Enable the ACL of aclFile (internal requests must be signed to bypass it)
+func (s *Server) loadACL() {
+	if len(s.ACLFile) == 0 {
+		return
+	}
+	if len(pool.Secret) == 0 {
+		log.Fatalln("ACLs require a cluster secret to authenticate servers")
+	}
+	acl.Verifier = verifier
+	acl.KeyOf = s.keyOf
+
+	data, err := ioutil.ReadFile(s.ACLFile)
+	if os.IsNotExist(err) {
+		// Deny everything but internal requests until SetACL
+		acl.Set(&auth.ACLConfig{})
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	config, err := auth.ParseACL(data)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	acl.Set(config)
+}
+
+// Key of the node at the location for rules with keys (the owner is asked if it is elsewhere)
+func (s *Server) keyOf(ctx context.Context, location uint64) (string, bool, error) {
+	node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location, ChildrenLimit: 1})
+	if errors.Is(err, dberrors.ErrLocationNotFound) {
+		// Nothing to protect (the request fails anyway)
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return node.Key, !node.Keyless && len(node.Key) > 0, nil
+}
+
+func (s *Server) GetACL(ctx context.Context, in *db.Empty) (*db.ACL, error) {
+	config := acl.Get()
+	if config == nil {
+		return &db.ACL{}, status.Errorf(codes.FailedPrecondition, "ACLs are disabled (aclFile is unset)")
+	}
+	data, _ := json.MarshalIndent(config, "", "  ")
+	return &db.ACL{Json: string(data)}, nil
+}
+
+// Replace the ACL on all servers
+func (s *Server) SetACL(ctx context.Context, in *db.ACL) (*db.Empty, error) {
+	if len(s.ACLFile) == 0 {
+		return &db.Empty{}, status.Errorf(codes.FailedPrecondition, "ACLs are disabled (aclFile is unset)")
+	}
+	config, err := auth.ParseACL([]byte(in.Json))
+	if err != nil {
+		return &db.Empty{}, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err := ioutil.WriteFile(s.ACLFile, []byte(in.Json), 0600); err != nil {
+		return &db.Empty{}, err
+	}
+	acl.Set(config)
+	log.Printf("ACL updated (%d rules)", len(config.Rules))
+	if in.Replicated {
+		return &db.Empty{}, nil
+	}
+
+	for _, addr := range s.Servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			_, err = client.SetACL(ctx, &db.ACL{Json: in.Json, Replicated: true})
+		}
+		if err != nil {
+			log.Printf("Fail to push ACL to %s: %v", addr, err)
+		}
+	}
+	return &db.Empty{}, nil
+}
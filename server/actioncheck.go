@@ -0,0 +1,83 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Actions found by OpenWhisk are not checked again for this long
+const actionCheckTTL = time.Minute
+
+// Results of checks in registration listings
+const (
+	validationFound   = "found"
+	validationSkipped = "skipped"
+)
+
+var actionChecker utils.ActionChecker = utils.OpenWhiskInvoker{}
+
+type actionCheck struct {
+	result string
+	at     time.Time
+}
+
+// Last check of the action of each merge function registered on this server
+type ActionChecks struct {
+	lock   sync.Mutex
+	checks map[string]actionCheck
+}
+
+var actionChecks = ActionChecks{checks: make(map[string]actionCheck)}
+
+// Check that the action of a merge function exists before registering it,
+// so that typos don't fail silently when the first conflict fires.
+// An empty name (removing a registration) is not checked.
+func (a *ActionChecks) Validate(name string, skip bool) error {
+	if len(name) == 0 {
+		return nil
+	}
+	a.lock.Lock()
+	check, ok := a.checks[name]
+	if skip {
+		if !ok || check.result != validationFound {
+			a.checks[name] = actionCheck{validationSkipped, time.Now()}
+		}
+		a.lock.Unlock()
+		return nil
+	}
+	a.lock.Unlock()
+	if ok && check.result == validationFound && time.Since(check.at) < actionCheckTTL {
+		return nil
+	}
+
+	found, err := actionChecker.ActionExists(name)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "Cannot check action %s in namespace %s (skip validation to register it anyway): %v", name, utils.NAMESPACE, err)
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "Action %s not found in namespace %s", name, utils.NAMESPACE)
+	}
+	a.lock.Lock()
+	a.checks[name] = actionCheck{validationFound, time.Now()}
+	a.lock.Unlock()
+	return nil
+}
+
+// Set the last check of the action of each registration
+func (a *ActionChecks) Annotate(registrations []*db.MergeRegistration) []*db.MergeRegistration {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for _, r := range registrations {
+		if check, ok := a.checks[r.Name]; ok {
+			r.Validation = check.result
+			r.ValidatedAt = check.at.UnixNano()
+		}
+	}
+	return registrations
+}
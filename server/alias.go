@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Aliases are persisted so that replaced locations stay resolvable after a restart
+const aliasFile = "aliases.json"
+
+// Locations of this server replaced by a merge or compression, by location.
+// Clients keep using old locations as deps, so they resolve to their successors.
+type Aliases struct {
+	lock    sync.Mutex
+	aliases map[uint64]*db.Alias
+	// Reads and writes redirected to a successor (accessed atomically)
+	Redirects int64
+}
+
+var aliases = Aliases{aliases: make(map[uint64]*db.Alias)}
+
+func (a *Aliases) Add(location uint64, successor uint64) {
+	if location == successor || successor == 0 {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.aliases[location] = &db.Alias{
+		Location:  location,
+		Successor: successor,
+		Time:      time.Now().UnixNano(),
+	}
+}
+
+// Add aliases moved from another server (keeping their time)
+func (a *Aliases) AddAll(list []*db.Alias) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for _, alias := range list {
+		a.aliases[alias.Location] = alias
+	}
+}
+
+func (a *Aliases) Get(location uint64) (uint64, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	alias, ok := a.aliases[location]
+	if !ok {
+		return 0, false
+	}
+	return alias.Successor, true
+}
+
+func (a *Aliases) List() []*db.Alias {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	list := make([]*db.Alias, 0, len(a.aliases))
+	for _, alias := range a.aliases {
+		list = append(list, alias)
+	}
+	return list
+}
+
+func (a *Aliases) Len() int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return len(a.aliases)
+}
+
+// Aliases of locations in the hash range [left, right]
+func (a *Aliases) InRange(left, right uint32) []*db.Alias {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var list []*db.Alias
+	for location, alias := range a.aliases {
+		if hash := utils.KeyHash(location); hash >= left && hash <= right {
+			list = append(list, alias)
+		}
+	}
+	return list
+}
+
+// Forget aliases of locations in the hash range [left, right] (moved by a split)
+func (a *Aliases) RemoveRange(left, right uint32) int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	removed := 0
+	for location := range a.aliases {
+		if hash := utils.KeyHash(location); hash >= left && hash <= right {
+			delete(a.aliases, location)
+			removed += 1
+		}
+	}
+	return removed
+}
+
+// Forget aliases created before the time
+func (a *Aliases) Expire(before int64) int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	removed := 0
+	for location, alias := range a.aliases {
+		if alias.Time < before {
+			delete(a.aliases, location)
+			removed += 1
+		}
+	}
+	return removed
+}
+
+func loadAliases() {
+	data, err := ioutil.ReadFile(statePath(aliasFile))
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var list db.Aliases
+	if err := json.Unmarshal(data, &list); err != nil {
+		log.Fatalln(err)
+	}
+	aliases.AddAll(list.Aliases)
+}
+
+func saveAliases() error {
+	data, _ := json.Marshal(&db.Aliases{Aliases: aliases.List()})
+	return ioutil.WriteFile(statePath(aliasFile), data, 0644)
+}
+
+// Resolve the replaced locations to their successor (unless aliases are disabled)
+func (s *Server) aliasTo(successor uint64, locations ...uint64) {
+	if s.AliasRetentionSeconds < 0 {
+		return
+	}
+	for _, location := range locations {
+		aliases.Add(location, successor)
+	}
+	if err := saveAliases(); err != nil {
+		log.Printf("Fail to persist aliases: %v", err)
+	}
+}
+
+// Forget aliases older than aliasRetentionSeconds (run with the sweep)
+func (s *Server) expireAliases() {
+	if s.AliasRetentionSeconds <= 0 {
+		return
+	}
+	before := time.Now().Add(-time.Duration(s.AliasRetentionSeconds) * time.Second).UnixNano()
+	if aliases.Expire(before) > 0 {
+		if err := saveAliases(); err != nil {
+			log.Printf("Fail to persist aliases: %v", err)
+		}
+	}
+}
+
+// Stored location the location resolves to through the aliases of replaced nodes
+// (itself if stored), following at most maxAliasChain aliases
+func (s *Server) ResolveAlias(ctx context.Context, in *db.ResolveAliasRequest) (*db.ResolveAliasResponse, error) {
+	location, chain := in.Location, in.Chain
+	for {
+		address := indexingService.ResolveOwner(location)
+		if address != s.Self {
+			// Forward request to the correct server
+			client, err := pool.Get(address)
+			if err != nil {
+				return &db.ResolveAliasResponse{}, err
+			}
+			return client.ResolveAlias(ctx, &db.ResolveAliasRequest{Location: location, Chain: chain})
+		}
+		if store.GetNode(location) != nil {
+			return &db.ResolveAliasResponse{Location: location, Chain: chain}, nil
+		}
+		successor, ok := aliases.Get(location)
+		if !ok {
+			return &db.ResolveAliasResponse{}, &dberrors.LocationNotFoundError{Location: location}
+		}
+		chain += 1
+		if chain > int32(s.MaxAliasChain) {
+			return &db.ResolveAliasResponse{}, status.Errorf(codes.FailedPrecondition, "Alias chain of %x is longer than %d", location, s.MaxAliasChain)
+		}
+		location = successor
+	}
+}
+
+// Add aliases of locations moved to this server by a split
+func (s *Server) AddAliases(ctx context.Context, in *db.Aliases) (*db.Empty, error) {
+	aliases.AddAll(in.Aliases)
+	if err := saveAliases(); err != nil {
+		return &db.Empty{}, err
+	}
+	return &db.Empty{}, nil
+}
+
+// Copy aliases of [left, right] to the client
+func (s *Server) transferAliases(ctx context.Context, client db.DbServiceClient, left, right uint32) error {
+	list := aliases.InRange(left, right)
+	if len(list) == 0 {
+		return nil
+	}
+	_, err := client.AddAliases(ctx, &db.Aliases{Aliases: list})
+	return err
+}
+
+// Walk from the location of the request, continuing from the successors
+// of local nodes replaced on the way.
+// Returns whether the walk was redirected.
+func (s *Server) walkAliased(ctx context.Context, in *db.GetRequest) (*engine.Walk, bool, error) {
+	walk, err := core.Walk(ctx, in.Key, in.Location, in.AsOf)
+	chain := int32(0)
+	for err == nil && walk.Remote && indexingService.ResolveOwner(walk.Next) == s.Self {
+		var resolved *db.ResolveAliasResponse
+		resolved, err = s.ResolveAlias(ctx, &db.ResolveAliasRequest{Location: walk.Next, Chain: chain})
+		if err != nil {
+			return nil, false, err
+		}
+		if resolved.Chain == chain {
+			// Stored again meanwhile
+			return nil, false, &dberrors.LocationNotFoundError{Location: walk.Next}
+		}
+		chain = resolved.Chain
+		walk, err = core.Walk(ctx, in.Key, resolved.Location, in.AsOf)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if chain > 0 {
+		atomic.AddInt64(&aliases.Redirects, 1)
+	}
+	return walk, chain > 0, nil
+}
+
+// Most foreign deps remembered as stored
+const maxStoredDeps = 1 << 16
+
+// Deps owned by other servers found stored (not replaced) at the epoch,
+// so that writes depending on them again skip resolving them remotely.
+// A dep replaced since is forgotten when linking to it fails.
+type StoredDeps struct {
+	lock      sync.Mutex
+	epoch     uint64
+	locations map[uint64]bool
+}
+
+var storedDeps = StoredDeps{locations: make(map[uint64]bool)}
+
+func (d *StoredDeps) Contains(location uint64, epoch uint64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	// Ranges (and their aliases) moved since
+	if epoch != d.epoch {
+		d.epoch, d.locations = epoch, make(map[uint64]bool)
+	}
+	return d.locations[location]
+}
+
+func (d *StoredDeps) Add(location uint64, epoch uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if epoch != d.epoch || len(d.locations) >= maxStoredDeps {
+		d.epoch, d.locations = epoch, make(map[uint64]bool)
+	}
+	d.locations[location] = true
+}
+
+func (d *StoredDeps) Forget(location uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	delete(d.locations, location)
+}
+
+// Returned when linking to a remembered dep fails because it was replaced meanwhile
+var errStaleDep = status.Error(codes.Aborted, "Dep was replaced during the write")
+
+// Dep of a write, or the successor it was replaced by.
+// Deps that were never written are rejected unless dangling deps are allowed.
+// Local deps are resolved with the local store and aliases, and foreign ones
+// through their owner unless they were found stored before (then cached is true).
+func (s *Server) resolveDep(ctx context.Context, dep uint64, allowDangling bool) (resolved uint64, cached bool, err error) {
+	epoch := indexingService.CurrentEpoch()
+	foreign := indexingService.ResolveOwner(dep) != s.Self
+	if foreign && storedDeps.Contains(dep, epoch) {
+		return dep, true, nil
+	}
+	alias, err := s.ResolveAlias(ctx, &db.ResolveAliasRequest{Location: dep})
+	if status.Code(err) == codes.Unimplemented {
+		// Owners older than aliases
+		if allowDangling {
+			return dep, false, nil
+		}
+		return dep, false, s.checkDep(ctx, dep)
+	}
+	if err != nil {
+		if !errors.Is(dberrors.FromStatus(err), dberrors.ErrLocationNotFound) {
+			return dep, false, err
+		}
+		if allowDangling {
+			return dep, false, nil
+		}
+		return dep, false, missingDep(dep)
+	}
+	if alias.Chain > 0 {
+		atomic.AddInt64(&aliases.Redirects, 1)
+	} else if foreign {
+		storedDeps.Add(dep, epoch)
+	}
+	return alias.Location, false, nil
+}
+
+// Node the replaced location of the request resolves to
+func (s *Server) getSuccessor(ctx context.Context, in *db.GetNodeRequest) (*db.Node, error) {
+	resolved, err := s.ResolveAlias(ctx, &db.ResolveAliasRequest{Location: in.Location})
+	if err != nil {
+		return &db.Node{}, err
+	}
+	if resolved.Location == in.Location {
+		// Stored again meanwhile
+		return &db.Node{}, &dberrors.LocationNotFoundError{Location: in.Location}
+	}
+	atomic.AddInt64(&aliases.Redirects, 1)
+	result, err := s.GetNode(ctx, &db.GetNodeRequest{
+		Location:         resolved.Location,
+		IncludeStats:     in.IncludeStats,
+		DescendantBudget: in.DescendantBudget,
+		ChildrenLimit:    in.ChildrenLimit,
+		ChildrenCursor:   in.ChildrenCursor,
+		VerifyChecksum:   in.VerifyChecksum,
+		ResolveKeys:      in.ResolveKeys,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.RedirectedFrom = in.Location
+	return result, nil
+}
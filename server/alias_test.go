@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Owner of the upper half of the range, where one location was replaced by another
+type aliasPeer struct {
+	db.UnimplementedDbServiceServer
+	lock      sync.Mutex
+	replaced  uint64
+	successor uint64
+	resolves  int64
+	sets      []*db.SetRequest
+}
+
+func (p *aliasPeer) replace(location, successor uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.replaced, p.successor = location, successor
+}
+
+func (p *aliasPeer) ResolveAlias(ctx context.Context, in *db.ResolveAliasRequest) (*db.ResolveAliasResponse, error) {
+	atomic.AddInt64(&p.resolves, 1)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if in.Location == p.replaced {
+		return &db.ResolveAliasResponse{Location: p.successor, Chain: in.Chain + 1}, nil
+	}
+	return &db.ResolveAliasResponse{Location: in.Location, Chain: in.Chain}, nil
+}
+
+func (p *aliasPeer) AddChild(ctx context.Context, in *db.AddChildRequest) (*db.Node, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if in.Location == p.replaced {
+		return &db.Node{}, &dberrors.LocationNotFoundError{Location: in.Location}
+	}
+	return &db.Node{Location: in.Location, Children: []uint64{in.Child}}, nil
+}
+
+func (p *aliasPeer) Set(ctx context.Context, in *db.SetRequest) (*db.SetResponse, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.sets = append(p.sets, in)
+	return &db.SetResponse{Location: in.Location}, nil
+}
+
+// Location owned by the upper half of the range
+func upperLocation(i uint64) uint64 {
+	return uint64(math.MaxUint32)<<32 | i
+}
+
+// Foreign deps are resolved by their owner once per epoch,
+// and again once linking to them shows they were replaced
+func TestResolveForeignDep(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	peer := &aliasPeer{}
+	address := startPeer(t, peer)
+	indexingService.Reset(nil, 0)
+	indexingService.AddMapping(0, math.MaxUint32/2, s.Self)
+	indexingService.AddMapping(math.MaxUint32/2+1, math.MaxUint32, address)
+	key := keyOwnedBy(t, s.Self)
+	dep := upperLocation(1)
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Set(ctx, &db.SetRequest{Key: key, Value: []byte("v"), Dep: dep}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if resolves := atomic.LoadInt64(&peer.resolves); resolves != 1 {
+		t.Errorf("Dep resolved %d times by its owner, expected once", resolves)
+	}
+
+	// Replaced by a merge after it was found stored
+	successor := upperLocation(2)
+	peer.replace(dep, successor)
+	result, err := s.Set(ctx, &db.SetRequest{Key: key, Value: []byte("v"), Dep: dep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RedirectedDep != successor {
+		t.Errorf("Set returned RedirectedDep %x, expected %x", result.RedirectedDep, successor)
+	}
+	if node := store.GetNode(result.Location); node == nil || node.Dep != successor {
+		t.Errorf("Node written with dep %v, expected %x", node, successor)
+	}
+
+	// Ranges may have moved with a new epoch
+	resolves := atomic.LoadInt64(&peer.resolves)
+	indexingService.NextEpoch()
+	if _, err := s.Set(ctx, &db.SetRequest{Key: key, Value: []byte("v"), Dep: successor}); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt64(&peer.resolves) != resolves+1 {
+		t.Errorf("Dep not resolved again in a new epoch")
+	}
+}
+
+// A pipelined Set depending on a replaced location is written and linked under its successor
+func TestPipelinedSetResolvesAlias(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	atomic.StoreInt32(&negotiatedProtocol, protocolCurrent)
+	defer atomic.StoreInt32(&negotiatedProtocol, 0)
+	defer func(secret []byte) { pool.Secret = secret }(pool.Secret)
+	pool.Secret = []byte("secret")
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Successor owned by the lower half, kept by this server
+	var successor uint64
+	for i := 0; successor == 0; i++ {
+		result, err := s.Set(ctx, &db.SetRequest{Key: fmt.Sprintf("k%d", i), Value: []byte("v"), Dep: root.Location})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Location>>32 <= math.MaxUint32/2 {
+			successor = result.Location
+		}
+	}
+	replaced := uint64(1)<<32 | 1
+	aliases.Add(replaced, successor)
+
+	peer := &aliasPeer{}
+	address := startPeer(t, peer)
+	indexingService.Reset(nil, 0)
+	indexingService.AddMapping(0, math.MaxUint32/2, s.Self)
+	indexingService.AddMapping(math.MaxUint32/2+1, math.MaxUint32, address)
+	in := &db.SetRequest{Key: keyOwnedBy(t, address), Value: []byte("v"), Dep: replaced}
+	if !s.canPipeline(in, address) {
+		t.Fatal("Set not pipelined")
+	}
+
+	result, err := s.Set(ctx, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RedirectedDep != successor {
+		t.Errorf("Set returned RedirectedDep %x, expected %x", result.RedirectedDep, successor)
+	}
+	if len(peer.sets) != 1 || peer.sets[0].Dep != successor {
+		t.Fatalf("Owner of the key was sent %v", peer.sets)
+	}
+	children := store.GetNode(successor).Children
+	if len(children) != 1 || children[0] != result.Location {
+		t.Errorf("Successor has children %v, expected %x", children, result.Location)
+	}
+}
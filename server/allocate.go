@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Split targets are reserved on the seed, which owns the list of available servers.
+// Other servers only keep a copy refreshed with the membership.
+func (s *Server) AllocateServer(ctx context.Context, in *db.AllocateServerRequest) (*db.AllocateServerResponse, error) {
+	if !s.isSeed() {
+		return &db.AllocateServerResponse{}, status.Errorf(codes.FailedPrecondition, "Server %s is not the seed", s.Self)
+	}
+	s.lock.Lock()
+	target, err := s.allocate(in.Requester, in.Target)
+	membership := s.membership()
+	s.lock.Unlock()
+	if err != nil {
+		return &db.AllocateServerResponse{}, err
+	}
+	// The requester holds its lock until the split is done
+	go s.broadcastMembership(context.Background(), membership, "")
+	return &db.AllocateServerResponse{Address: target, Epoch: membership.Epoch}, nil
+}
+
+// Return a reserved server to the pool (e.g. when the split aborts)
+func (s *Server) ReleaseServer(ctx context.Context, in *db.ReleaseServerRequest) (*db.Empty, error) {
+	if !s.isSeed() {
+		return &db.Empty{}, status.Errorf(codes.FailedPrecondition, "Server %s is not the seed", s.Self)
+	}
+	s.lock.Lock()
+	changed := s.release(in.Address, in.RolledBack)
+	membership := s.membership()
+	s.lock.Unlock()
+	if changed {
+		go s.broadcastMembership(context.Background(), membership, "")
+	}
+	return &db.Empty{}, nil
+}
+
+// Reserve the target (an empty one picks the least loaded server).
+// Must be called with the lock held on the seed.
+func (s *Server) allocate(requester, target string) (string, error) {
+	if len(target) == 0 {
+		target = s.leastLoaded(s.AvailableServers)
+		if len(target) == 0 && len(s.AvailableServers) > 0 {
+			target = s.AvailableServers[rand.Intn(len(s.AvailableServers))]
+		}
+		if len(target) == 0 {
+			return "", status.Errorf(codes.FailedPrecondition, "No available servers")
+		}
+	} else if !contains(s.AvailableServers, target) {
+		if owner, ok := s.reserved[target]; ok {
+			return "", status.Errorf(codes.FailedPrecondition, "Server %s is reserved by %s", target, owner)
+		}
+		return "", status.Errorf(codes.InvalidArgument, "Server %s is not available", target)
+	}
+	s.removeAvailable(target)
+	s.reserved[target] = requester
+	s.membershipEpoch += 1
+	log.Printf("Server %s reserved by %s", target, requester)
+	if len(s.AvailableServers) == 0 {
+		expansion.Raise(0, fmt.Sprintf("split of %s took the last available server %s", requester, target))
+	}
+	return target, nil
+}
+
+// Must be called with the lock held on the seed
+func (s *Server) release(target string, rolledBack bool) bool {
+	if _, ok := s.reserved[target]; !ok && !rolledBack {
+		return false
+	}
+	delete(s.reserved, target)
+	// Only a server holding no range can be a split target
+	if !indexingService.Owns(target) && !contains(s.AvailableServers, target) {
+		s.AvailableServers = append(s.AvailableServers, target)
+	}
+	s.membershipEpoch += 1
+	expansion.Check(len(s.AvailableServers))
+	log.Printf("Server %s released", target)
+	return true
+}
+
+// Reserve a split target on the seed.
+// Must be called with the lock held.
+func (s *Server) allocateServer(ctx context.Context, target string) (string, error) {
+	if s.isSeed() {
+		target, err := s.allocate(s.Self, target)
+		if err == nil {
+			go s.broadcastMembership(context.Background(), s.membership(), "")
+		}
+		return target, err
+	}
+	client, err := pool.Get(s.Seed)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.AllocateServer(ctx, &db.AllocateServerRequest{
+		Requester: s.Self,
+		Target:    target,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Address, nil
+}
+
+// Must be called with the lock held
+func (s *Server) releaseServer(ctx context.Context, target string) {
+	s.releaseTarget(ctx, &db.ReleaseServerRequest{Address: target})
+}
+
+// Must be called with the lock held
+func (s *Server) releaseTarget(ctx context.Context, in *db.ReleaseServerRequest) {
+	if s.isSeed() {
+		if s.release(in.Address, in.RolledBack) {
+			go s.broadcastMembership(context.Background(), s.membership(), "")
+		}
+		return
+	}
+	client, err := pool.Get(s.Seed)
+	if err == nil {
+		_, err = client.ReleaseServer(ctx, in)
+	}
+	if err != nil {
+		log.Printf("Fail to release %s: %v", in.Address, err)
+	}
+}
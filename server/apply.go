@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Max time an action-backed transform can take
+const applyActionTimeout = 10 * time.Second
+
+// Chains forgotten at once when more links are kept
+const appliedSize = 1 << 16
+
+// Compute the new value of a key from its current value (nil if unset)
+type Transform func(value []byte, arg []byte) ([]byte, error)
+
+func parseInt(value []byte, empty int64) (int64, error) {
+	if len(value) == 0 {
+		return empty, nil
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// Builtin transforms on decimal or raw values
+var transforms = map[string]Transform{
+	"increment": func(value []byte, arg []byte) ([]byte, error) {
+		n, err := parseInt(value, 0)
+		if err != nil {
+			return nil, fmt.Errorf("Value is not an integer: %v", err)
+		}
+		delta, err := parseInt(arg, 1)
+		if err != nil {
+			return nil, fmt.Errorf("Arg is not an integer: %v", err)
+		}
+		return []byte(strconv.FormatInt(n+delta, 10)), nil
+	},
+	"append": func(value []byte, arg []byte) ([]byte, error) {
+		return append(append([]byte(nil), value...), arg...), nil
+	},
+	"max": func(value []byte, arg []byte) ([]byte, error) {
+		if len(value) == 0 {
+			return arg, nil
+		}
+		n, err := parseInt(value, 0)
+		if err != nil {
+			return nil, fmt.Errorf("Value is not an integer: %v", err)
+		}
+		m, err := parseInt(arg, 0)
+		if err != nil {
+			return nil, fmt.Errorf("Arg is not an integer: %v", err)
+		}
+		if m > n {
+			return arg, nil
+		}
+		return value, nil
+	},
+}
+
+// Input of action-backed transforms
+type applyInput struct {
+	Key   string
+	Value []byte
+	Arg   []byte
+	// Values of the extra keys read by the transform (missing if unset)
+	Values map[string][]byte
+}
+
+type applyOutput struct {
+	Value []byte
+}
+
+// Writes of a key are serialized by one of these
+var keyLocks [256]sync.Mutex
+
+func keyLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &keyLocks[h.Sum32()%uint32(len(keyLocks))]
+}
+
+type appliedKey struct {
+	key      string
+	location uint64
+}
+
+// Node written by Apply after a location for the key,
+// so later applies continue the chain instead of adding siblings
+var applied = struct {
+	lock sync.Mutex
+	next map[appliedKey]uint64
+}{next: make(map[appliedKey]uint64)}
+
+// Last node written by Apply for the key from the location
+func appliedHead(key string, location uint64) uint64 {
+	applied.lock.Lock()
+	defer applied.lock.Unlock()
+	for {
+		next, ok := applied.next[appliedKey{key, location}]
+		if !ok {
+			return location
+		}
+		location = next
+	}
+}
+
+func setApplied(key string, location uint64, next uint64) {
+	applied.lock.Lock()
+	defer applied.lock.Unlock()
+	if len(applied.next) >= appliedSize {
+		applied.next = make(map[appliedKey]uint64)
+	}
+	applied.next[appliedKey{key, location}] = next
+}
+
+// Forget chains of keys that moved to other servers
+func clearApplied() {
+	applied.lock.Lock()
+	defer applied.lock.Unlock()
+	applied.next = make(map[appliedKey]uint64)
+}
+
+// Read the key, returning nil if it is unset
+func (s *Server) readValue(ctx context.Context, key string, location uint64) ([]byte, error) {
+	resp, err := s.Get(ctx, &db.GetRequest{Key: key, Location: location})
+	if errors.Is(dberrors.FromStatus(err), dberrors.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.DeadlineExceeded {
+		return nil, status.Errorf(codes.DeadlineExceeded, "Walk for %s stopped at %x", key, resp.ResumeFrom)
+	}
+	return resp.Value, nil
+}
+
+// Run the transform on the owner of the key and write the result.
+// Concurrent applies of a key are chained one after another, so they create no conflict.
+func (s *Server) Apply(ctx context.Context, in *db.ApplyRequest) (*db.ApplyResponse, error) {
+	var err error
+	if in.Key, err = s.normalizeKey(in.Key); err != nil {
+		return &db.ApplyResponse{}, err
+	}
+	address := indexingService.LocateKey(in.Key)
+	if address != s.Self {
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.ApplyResponse{}, err
+		}
+		return client.Apply(forwardContext(ctx), in)
+	}
+
+	// Values of other keys could change during the transform
+	for _, key := range in.ReadKeys {
+		if owner := indexingService.LocateKey(key); owner != s.Self {
+			return &db.ApplyResponse{}, status.Errorf(codes.FailedPrecondition, "Key %s read by the transform is owned by %s, not by %s (the owner of %s)", key, owner, s.Self, in.Key)
+		}
+	}
+	transform, builtin := transforms[in.Transform]
+
+	lock := keyLock(in.Key)
+	start := time.Now()
+	lock.Lock()
+	timePhase(ctx, phaseLockWait, start)
+	defer lock.Unlock()
+
+	dep := appliedHead(in.Key, in.Dep)
+	value, err := s.readValue(ctx, in.Key, dep)
+	if dep != in.Dep && errors.Is(dberrors.FromStatus(err), dberrors.ErrLocationNotFound) {
+		// The chain was merged away
+		dep = in.Dep
+		value, err = s.readValue(ctx, in.Key, dep)
+	}
+	if err != nil {
+		return &db.ApplyResponse{}, err
+	}
+
+	var result []byte
+	if builtin {
+		result, err = transform(value, in.Arg)
+	} else {
+		result, err = s.applyAction(ctx, in, dep, value)
+	}
+	if err != nil {
+		return &db.ApplyResponse{}, status.Errorf(codes.InvalidArgument, "Transform %s failed: %v", in.Transform, err)
+	}
+
+	resp, err := s.Set(ctx, &db.SetRequest{
+		Key:   in.Key,
+		Value: result,
+		Dep:   dep,
+	})
+	if err != nil {
+		return &db.ApplyResponse{}, err
+	}
+	setApplied(in.Key, dep, resp.Location)
+	if dep != in.Dep {
+		setApplied(in.Key, in.Dep, resp.Location)
+	}
+	return &db.ApplyResponse{
+		Location: resp.Location,
+		Value:    result,
+	}, nil
+}
+
+// Return the value of the key, writing the default if it is unset.
+// Concurrent calls for the key and dep create one node, returned to all of them.
+func (s *Server) GetOrSet(ctx context.Context, in *db.GetOrSetRequest) (*db.GetOrSetResponse, error) {
+	var err error
+	if in.Key, err = s.normalizeKey(in.Key); err != nil {
+		return &db.GetOrSetResponse{}, err
+	}
+	address := indexingService.LocateKey(in.Key)
+	if address != s.Self {
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.GetOrSetResponse{}, err
+		}
+		return client.GetOrSet(forwardContext(ctx), in)
+	}
+
+	lock := keyLock(in.Key)
+	start := time.Now()
+	lock.Lock()
+	timePhase(ctx, phaseLockWait, start)
+	defer lock.Unlock()
+
+	// Nodes written from the dep are children of it, so they are not on its path
+	dep := appliedHead(in.Key, in.Dep)
+	resp, err := s.Get(ctx, &db.GetRequest{Key: in.Key, Location: dep})
+	if dep != in.Dep && errors.Is(dberrors.FromStatus(err), dberrors.ErrLocationNotFound) {
+		// The chain was merged away
+		dep = in.Dep
+		resp, err = s.Get(ctx, &db.GetRequest{Key: in.Key, Location: dep})
+	}
+	if err == nil {
+		if resp.DeadlineExceeded {
+			return &db.GetOrSetResponse{}, status.Errorf(codes.DeadlineExceeded, "Walk for %s stopped at %x", in.Key, resp.ResumeFrom)
+		}
+		return &db.GetOrSetResponse{
+			Value:    resp.Value,
+			Location: resp.Location,
+		}, nil
+	}
+	if !errors.Is(dberrors.FromStatus(err), dberrors.ErrKeyNotFound) {
+		return &db.GetOrSetResponse{}, err
+	}
+
+	set, err := s.Set(ctx, &db.SetRequest{
+		Key:        in.Key,
+		Value:      in.Default,
+		Dep:        dep,
+		TtlSeconds: in.TtlSeconds,
+	})
+	if err != nil {
+		return &db.GetOrSetResponse{}, err
+	}
+	setApplied(in.Key, dep, set.Location)
+	if dep != in.Dep {
+		setApplied(in.Key, in.Dep, set.Location)
+	}
+	return &db.GetOrSetResponse{
+		Value:    in.Default,
+		Location: set.Location,
+		Created:  true,
+	}, nil
+}
+
+// Invoke the action named by the transform
+func (s *Server) applyAction(ctx context.Context, in *db.ApplyRequest, dep uint64, value []byte) ([]byte, error) {
+	input := &applyInput{
+		Key:    in.Key,
+		Value:  value,
+		Arg:    in.Arg,
+		Values: make(map[string][]byte),
+	}
+	for _, key := range in.ReadKeys {
+		v, err := s.readValue(ctx, key, dep)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			input.Values[key] = v
+		}
+	}
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	json.NewEncoder(buf).Encode(input)
+	params := append([]byte(nil), buf.Bytes()...)
+	bufferPool.Put(buf)
+
+	ctx, cancel := context.WithTimeout(ctx, applyActionTimeout)
+	defer cancel()
+	type result struct {
+		body []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := invoker.CallAction(in.Transform, params)
+		if err != nil {
+			done <- result{nil, err}
+			return
+		}
+		done <- result{resp.Body, nil}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("Action %s timed out: %v", in.Transform, ctx.Err())
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		var output applyOutput
+		if err := json.Unmarshal(r.body, &output); err != nil {
+			return nil, fmt.Errorf("Invalid output of action %s: %v", in.Transform, err)
+		}
+		return output.Value, nil
+	}
+}
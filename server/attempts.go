@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Phases of a split attempt
+const (
+	phaseLocking      = "locking"
+	phaseTransferring = "transferring"
+	phaseCommitting   = "committing"
+	// Moved nodes are read back from the target before the local copies are removed
+	phaseVerifying = "verifying"
+	phaseCommitted = "committed"
+	// Nodes sent to the target were removed and it is back in the pool
+	phaseAborted = "aborted"
+	// The target could not be cleaned up and is out of the pool until it can
+	phaseFailed = "failed"
+)
+
+// Max finished attempts kept for GetStats
+const maxSplitAttempts = 20
+
+// Interval of retrying the cleanup of failed attempts
+const reclaimInterval = 10 * time.Second
+
+type splitAttempt struct {
+	target      string
+	phase       string
+	transferred []uint64
+	// Bytes of the values of the transferred nodes
+	transferredBytes int64
+	err              string
+	started          time.Time
+	// Samples of the verification
+	verified int
+	failed   int
+	// The mapping was restored after being applied
+	rolledBack bool
+	// Batch sizes and throughput of the transfer (see transferWindow)
+	batching map[string]string
+}
+
+type SplitAttempts struct {
+	lock     sync.Mutex
+	attempts []*splitAttempt
+}
+
+var splitAttempts = SplitAttempts{}
+
+func (a *SplitAttempts) Start(target string) *splitAttempt {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempt := &splitAttempt{
+		target:  target,
+		phase:   phaseLocking,
+		started: time.Now(),
+	}
+	a.attempts = append(a.attempts, attempt)
+	events.Publish(eventSplitStarted, map[string]string{"target": target}, "Split to %s started", target)
+
+	// Drop the oldest finished attempts
+	if len(a.attempts) > maxSplitAttempts {
+		var kept []*splitAttempt
+		extra := len(a.attempts) - maxSplitAttempts
+		for _, attempt := range a.attempts {
+			finished := attempt.phase == phaseCommitted || attempt.phase == phaseAborted
+			if extra > 0 && finished {
+				extra -= 1
+				continue
+			}
+			kept = append(kept, attempt)
+		}
+		a.attempts = kept
+	}
+	return attempt
+}
+
+func (a *SplitAttempts) SetPhase(attempt *splitAttempt, phase string, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempt.phase = phase
+	if err != nil {
+		attempt.err = err.Error()
+	}
+
+	attributes := map[string]string{"target": attempt.target, "phase": phase}
+	if attempt.verified > 0 || attempt.failed > 0 {
+		attributes["verified"] = strconv.Itoa(attempt.verified)
+		attributes["failed"] = strconv.Itoa(attempt.failed)
+	}
+	if attempt.rolledBack {
+		attributes["rolledBack"] = "true"
+	}
+	for name, value := range attempt.batching {
+		attributes[name] = value
+	}
+	switch phase {
+	case phaseCommitted:
+		events.Publish(eventSplitCommitted, attributes, "Split to %s committed", attempt.target)
+	case phaseAborted, phaseFailed:
+		events.Publish(eventSplitAborted, attributes, "Split to %s %s: %s", attempt.target, phase, attempt.err)
+	}
+}
+
+func (a *SplitAttempts) Transferred(attempt *splitAttempt, node *db.Node) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempt.transferred = append(attempt.transferred, node.Location)
+	attempt.transferredBytes += int64(len(node.Value))
+}
+
+// Nodes and bytes transferred so far
+func (a *SplitAttempts) Progress(attempt *splitAttempt) (int64, int64) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return int64(len(attempt.transferred)), attempt.transferredBytes
+}
+
+func (a *SplitAttempts) Batched(attempt *splitAttempt, batching map[string]string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempt.batching = batching
+}
+
+func (a *SplitAttempts) Verified(attempt *splitAttempt, verified int, failed int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempt.verified = verified
+	attempt.failed = failed
+}
+
+func (a *SplitAttempts) RolledBack(attempt *splitAttempt) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	attempt.rolledBack = true
+}
+
+// Attempts in the phase
+func (a *SplitAttempts) InPhase(phase string) []*splitAttempt {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var result []*splitAttempt
+	for _, attempt := range a.attempts {
+		if attempt.phase == phase {
+			result = append(result, attempt)
+		}
+	}
+	return result
+}
+
+func (a *SplitAttempts) ToProto() []*db.SplitAttempt {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var result []*db.SplitAttempt
+	for _, attempt := range a.attempts {
+		result = append(result, &db.SplitAttempt{
+			Target:           attempt.target,
+			Phase:            attempt.phase,
+			TransferredNodes: int64(len(attempt.transferred)),
+			Error:            attempt.err,
+			StartedAt:        attempt.started.UnixNano(),
+			VerifiedSamples:  int64(attempt.verified),
+			FailedSamples:    int64(attempt.failed),
+			RolledBack:       attempt.rolledBack,
+		})
+	}
+	return result
+}
+
+// Remove nodes already sent to the target of an aborted split.
+// Must be called with the server lock held.
+func (s *Server) abortSplit(ctx context.Context, attempt *splitAttempt, cause error) {
+	if err := s.cleanUpTarget(ctx, attempt); err != nil {
+		log.Printf("Fail to clean up split target %s: %v", attempt.target, err)
+		// Keep it reserved until it is cleaned up
+		splitAttempts.SetPhase(attempt, phaseFailed, cause)
+		return
+	}
+	splitAttempts.SetPhase(attempt, phaseAborted, cause)
+	s.releaseAttempt(ctx, attempt)
+}
+
+// Return the target of an aborted attempt to the pool.
+// Must be called with the lock held.
+func (s *Server) releaseAttempt(ctx context.Context, attempt *splitAttempt) {
+	splitAttempts.lock.Lock()
+	rolledBack := attempt.rolledBack
+	splitAttempts.lock.Unlock()
+	s.releaseTarget(ctx, &db.ReleaseServerRequest{Address: attempt.target, RolledBack: rolledBack})
+}
+
+func (s *Server) cleanUpTarget(ctx context.Context, attempt *splitAttempt) error {
+	splitAttempts.lock.Lock()
+	locations := append([]uint64(nil), attempt.transferred...)
+	splitAttempts.lock.Unlock()
+	if len(locations) == 0 {
+		return nil
+	}
+
+	client, err := pool.Get(attempt.target)
+	if err != nil {
+		return err
+	}
+	_, err = client.RemoveNodes(ctx, &db.RemoveNodesRequest{
+		Locations: locations,
+		Local:     true,
+	})
+	return err
+}
+
+// Remove every occurrence of the server.
+// Must be called with the server lock held.
+func (s *Server) removeAvailable(server string) {
+	available := s.AvailableServers[:0]
+	for _, addr := range s.AvailableServers {
+		if addr != server {
+			available = append(available, addr)
+		}
+	}
+	s.AvailableServers = available
+}
+
+// Return targets of failed splits to the pool once they are cleaned up
+func (s *Server) reclaimLoop() {
+	for range time.Tick(reclaimInterval) {
+		for _, attempt := range splitAttempts.InPhase(phaseFailed) {
+			ctx, cancel := context.WithTimeout(context.Background(), reclaimInterval)
+			err := s.cleanUpTarget(ctx, attempt)
+			cancel()
+			if err != nil {
+				continue
+			}
+
+			s.lock.Lock()
+			s.releaseAttempt(context.Background(), attempt)
+			s.lock.Unlock()
+			splitAttempts.SetPhase(attempt, phaseAborted, nil)
+			log.Printf("Split target %s reclaimed", attempt.target)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error of the request at index i in a batch
+func batchError(i int, key string, err error) error {
+	return status.Errorf(status.Code(err), "Request %d (%s): %v", i, key, err)
+}
+
+func batchResult(resp *db.SetResponse) *db.BatchSetResult {
+	return &db.BatchSetResult{
+		Result:      &db.BatchSetResult_Location{Location: resp.Location},
+		MergeStatus: resp.MergeStatus,
+		MergeError:  resp.MergeError,
+	}
+}
+
+func batchFailure(err error) *db.BatchSetResult {
+	s := status.Convert(err)
+	return &db.BatchSetResult{
+		Result: &db.BatchSetResult_Status{Status: &db.EntryStatus{Code: uint32(s.Code()), Message: s.Message()}},
+	}
+}
+
+// Write several keys in one call.
+// Requests owned by other servers are forwarded in one batch per server,
+// retried while they fail transiently.
+// Requests that could not be written have a status in their result, the others are written.
+func (s *Server) BatchSet(ctx context.Context, in *db.BatchSetRequest) (*db.BatchSetResponse, error) {
+	results := make([]*db.BatchSetResult, len(in.Requests))
+	// Nothing is written if a key is invalid
+	for i, request := range in.Requests {
+		var err error
+		if request.Key, err = s.normalizeKey(request.Key); err != nil {
+			return &db.BatchSetResponse{}, batchError(i, request.Key, err)
+		}
+		// Retried requests are written once
+		if len(request.RequestId) == 0 {
+			request.RequestId = db.NewRequestId()
+		}
+	}
+	if in.Chain {
+		// Each write needs the location of the previous one
+		for i, request := range in.Requests {
+			if i > 0 {
+				loc, ok := results[i-1].Result.(*db.BatchSetResult_Location)
+				if !ok {
+					results[i] = batchFailure(status.Errorf(codes.Aborted, "Request %d was not written", i-1))
+					continue
+				}
+				request.Dep = loc.Location
+			}
+			resp, err := s.Set(ctx, request)
+			if err != nil {
+				results[i] = batchFailure(err)
+				continue
+			}
+			results[i] = batchResult(resp)
+		}
+		return batchResponse(results), nil
+	}
+
+	groups := make(map[string][]int)
+	for i, request := range in.Requests {
+		address := indexingService.LocateKey(request.Key)
+		groups[address] = append(groups[address], i)
+	}
+
+	var wg sync.WaitGroup
+	for address, indices := range groups {
+		wg.Add(1)
+		go func(address string, indices []int) {
+			defer wg.Done()
+			s.batchSet(ctx, address, in.Requests, indices, results)
+		}(address, indices)
+	}
+	wg.Wait()
+	return batchResponse(results), nil
+}
+
+// Response with Responses as well if all requests were written,
+// for clients and servers without the batch-results feature
+func batchResponse(results []*db.BatchSetResult) *db.BatchSetResponse {
+	resp := &db.BatchSetResponse{Results: results}
+	responses := make([]*db.SetResponse, len(results))
+	for i, result := range results {
+		loc, ok := result.Result.(*db.BatchSetResult_Location)
+		if !ok {
+			return resp
+		}
+		responses[i] = &db.SetResponse{Location: loc.Location, MergeStatus: result.MergeStatus, MergeError: result.MergeError}
+	}
+	resp.Responses = responses
+	return resp
+}
+
+// Write the requests at the indices owned by the address
+func (s *Server) batchSet(ctx context.Context, address string, requests []*db.SetRequest, indices []int, results []*db.BatchSetResult) {
+	if address == s.Self {
+		for _, i := range indices {
+			// Set forwards keys moved since they were located
+			resp, err := s.Set(ctx, requests[i])
+			if err != nil {
+				results[i] = batchFailure(err)
+				continue
+			}
+			results[i] = batchResult(resp)
+		}
+		return
+	}
+
+	if !peerSupports(address, db.FeatureBatchResults) {
+		s.legacyBatchSet(ctx, address, requests, indices, results)
+		return
+	}
+
+	// Requests failing transiently are sent again (their RequestId prevents duplicates)
+	pending := indices
+	// Whether the last attempt returned results
+	answered := false
+	err := pool.Retry(ctx, func() error {
+		answered = false
+		batch := &db.BatchSetRequest{}
+		for _, i := range pending {
+			batch.Requests = append(batch.Requests, requests[i])
+		}
+		client, err := pool.Get(address)
+		if err != nil {
+			return err
+		}
+		resp, err := client.BatchSet(ctx, batch)
+		if err != nil {
+			return err
+		}
+		forwarded := resp.AllResults()
+		if len(forwarded) != len(pending) {
+			return status.Errorf(codes.Internal, "%s returned %d results for %d requests", address, len(forwarded), len(pending))
+		}
+		answered = true
+		var failed []int
+		var failure error
+		for j, i := range pending {
+			results[i] = forwarded[j]
+			if entry := forwarded[j].GetStatus(); entry != nil && transient(status.Error(codes.Code(entry.Code), "")) {
+				failed = append(failed, i)
+				failure = status.Error(codes.Code(entry.Code), entry.Message)
+			}
+		}
+		pending = failed
+		return failure
+	})
+	if err != nil && !answered {
+		for _, i := range pending {
+			results[i] = batchFailure(err)
+		}
+	}
+}
+
+// Forward the requests to a server answering with Responses only.
+// It fails the whole batch at the first failed request (after writing the previous ones)
+// and ignores RequestIds, so the batch is sent once and its failures are not retryable.
+func (s *Server) legacyBatchSet(ctx context.Context, address string, requests []*db.SetRequest, indices []int, results []*db.BatchSetResult) {
+	fail := func(err error) {
+		for _, i := range indices {
+			results[i] = batchFailure(err)
+		}
+	}
+	batch := &db.BatchSetRequest{}
+	for _, i := range indices {
+		batch.Requests = append(batch.Requests, requests[i])
+	}
+	client, err := pool.Get(address)
+	if err != nil {
+		fail(err)
+		return
+	}
+	resp, err := client.BatchSet(ctx, batch)
+	if err != nil {
+		fail(status.Errorf(codes.Unknown, "Batch forwarded to %s failed (some requests may be written): %v", address, err))
+		return
+	}
+	forwarded := resp.AllResults()
+	if len(forwarded) != len(indices) {
+		fail(status.Errorf(codes.Internal, "%s returned %d results for %d requests", address, len(forwarded), len(indices)))
+		return
+	}
+	for j, i := range indices {
+		results[i] = forwarded[j]
+	}
+}
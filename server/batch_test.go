@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server answering BatchSet with one location per request
+type batchPeer struct {
+	db.UnimplementedDbServiceServer
+	features []string
+	// Answer with Responses only, like servers before batch-results
+	legacy bool
+	// Error of the first call (or the status of its first entry if not legacy)
+	failFirst error
+	calls     int32
+}
+
+func (p *batchPeer) GetVersion(ctx context.Context, in *db.Empty) (*db.VersionResponse, error) {
+	return &db.VersionResponse{ApiVersion: db.APIVersion, Features: p.features, ProtocolLevel: protocolCurrent}, nil
+}
+
+func (p *batchPeer) BatchSet(ctx context.Context, in *db.BatchSetRequest) (*db.BatchSetResponse, error) {
+	first := atomic.AddInt32(&p.calls, 1) == 1
+	if p.legacy {
+		if first && p.failFirst != nil {
+			return nil, p.failFirst
+		}
+		resp := &db.BatchSetResponse{}
+		for i := range in.Requests {
+			resp.Responses = append(resp.Responses, &db.SetResponse{Location: uint64(i + 1)})
+		}
+		return resp, nil
+	}
+	var results []*db.BatchSetResult
+	for i := range in.Requests {
+		if first && i == 0 && p.failFirst != nil {
+			results = append(results, batchFailure(p.failFirst))
+			continue
+		}
+		results = append(results, &db.BatchSetResult{Result: &db.BatchSetResult_Location{Location: uint64(i + 1)}})
+	}
+	return &db.BatchSetResponse{Results: results}, nil
+}
+
+func TestBatchSetForwarded(t *testing.T) {
+	attempts, backoff := pool.Attempts, pool.Backoff
+	pool.Attempts, pool.Backoff = 3, time.Millisecond
+	defer func() { pool.Attempts, pool.Backoff = attempts, backoff }()
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	tests := []struct {
+		name  string
+		peer  *batchPeer
+		codes []codes.Code
+		calls int32
+	}{
+		{"results", &batchPeer{features: db.Features}, []codes.Code{codes.OK, codes.OK}, 1},
+		{"results retried", &batchPeer{features: db.Features, failFirst: unavailable}, []codes.Code{codes.OK, codes.OK}, 2},
+		{"responses", &batchPeer{legacy: true}, []codes.Code{codes.OK, codes.OK}, 1},
+		// Requests before the failure may be written, so the batch is not sent again
+		{"responses failed", &batchPeer{legacy: true, failFirst: unavailable}, []codes.Code{codes.Unknown, codes.Unknown}, 1},
+		// Results are read from Responses even if the feature is announced
+		{"responses with feature", &batchPeer{features: db.Features, legacy: true}, []codes.Code{codes.OK, codes.OK}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := startPeer(t, tt.peer)
+			s := &Server{Self: "self"}
+			ctx := context.Background()
+			if _, err := s.negotiateProtocol(ctx, []string{address}); err != nil {
+				t.Fatal(err)
+			}
+
+			requests := []*db.SetRequest{{Key: "a", RequestId: "1"}, {Key: "b", RequestId: "2"}}
+			results := make([]*db.BatchSetResult, len(requests))
+			s.batchSet(ctx, address, requests, []int{0, 1}, results)
+			for i, result := range results {
+				code := codes.OK
+				if entry := result.GetStatus(); entry != nil {
+					code = codes.Code(entry.Code)
+				} else if result.GetLocation() != uint64(i+1) {
+					t.Errorf("Request %d written at %d", i, result.GetLocation())
+				}
+				if code != tt.codes[i] {
+					t.Errorf("Request %d returned %v, expected %v", i, code, tt.codes[i])
+				}
+			}
+			if calls := atomic.LoadInt32(&tt.peer.calls); calls != tt.calls {
+				t.Errorf("Peer called %d times, expected %d", calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestBatchResponse(t *testing.T) {
+	written := []*db.BatchSetResult{
+		{Result: &db.BatchSetResult_Location{Location: 1}},
+		{Result: &db.BatchSetResult_Location{Location: 2}, MergeStatus: db.MergeStatus_MERGE_FAILED},
+	}
+	resp := batchResponse(written)
+	if len(resp.Responses) != 2 || resp.Responses[1].Location != 2 || resp.Responses[1].MergeStatus != db.MergeStatus_MERGE_FAILED {
+		t.Errorf("Responses of a written batch: %v", resp.Responses)
+	}
+
+	failed := append(written, batchFailure(status.Error(codes.Aborted, "")))
+	if resp := batchResponse(failed); len(resp.Responses) != 0 || len(resp.Results) != 3 {
+		t.Errorf("Responses set for a failed batch: %v", resp.Responses)
+	}
+}
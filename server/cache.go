@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Keys with the prefix can be served from the read cache of non-owners
+type CacheRule struct {
+	Prefix             string `json:"prefix"`
+	MaxStalenessMillis int64  `json:"maxStalenessMillis"`
+}
+
+type cacheEntry struct {
+	response *db.GetResponse
+	fetched  time.Time
+	// Last time the entry was found up to date by read repair
+	checked time.Time
+}
+
+// Identifies the cached response of a key and location
+func cacheID(key string, location uint64) string {
+	return fmt.Sprintf("%s@%x", key, location)
+}
+
+type ReadCache struct {
+	lock sync.Mutex
+	// Cached responses by key and location
+	entries map[string]map[uint64]cacheEntry
+	// Servers caching each key (tracked by the owner)
+	subscribers map[string]map[string]bool
+
+	Hits   int64
+	Misses int64
+	// Hits allowed by the staleness bound of the request
+	StaleServed int64
+}
+
+var readCache = ReadCache{}
+
+func (c *ReadCache) Init() {
+	c.entries = make(map[string]map[uint64]cacheEntry)
+	c.subscribers = make(map[string]map[string]bool)
+}
+
+func (c *ReadCache) Lookup(key string, location uint64, staleness time.Duration) (*db.GetResponse, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key][location]
+	if !ok || time.Since(entry.fetched) > staleness {
+		atomic.AddInt64(&c.Misses, 1)
+		return nil, false
+	}
+	atomic.AddInt64(&c.Hits, 1)
+	return &db.GetResponse{
+		Value:     entry.response.Value,
+		FromCache: true,
+		CreatedAt: entry.response.CreatedAt,
+		Location:  entry.response.Location,
+		CachedAt:  entry.fetched.UnixNano(),
+		Checksum:  entry.response.Checksum,
+		Type:      entry.response.Type,
+	}, true
+}
+
+// Whether the entry was neither fetched nor checked within the interval
+func (c *ReadCache) NeedsCheck(key string, location uint64, interval time.Duration) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key][location]
+	return ok && time.Since(entry.fetched) >= interval && time.Since(entry.checked) >= interval
+}
+
+func (c *ReadCache) MarkChecked(key string, location uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry, ok := c.entries[key][location]; ok {
+		entry.checked = time.Now()
+		c.entries[key][location] = entry
+	}
+}
+
+func (c *ReadCache) Store(key string, location uint64, response *db.GetResponse) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries[key] == nil {
+		c.entries[key] = make(map[uint64]cacheEntry)
+	}
+	c.entries[key][location] = cacheEntry{
+		response: response,
+		fetched:  time.Now(),
+	}
+}
+
+func (c *ReadCache) Invalidate(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Drop all entries (e.g. when the mapping changes)
+func (c *ReadCache) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[string]map[uint64]cacheEntry)
+}
+
+func (c *ReadCache) Subscribe(key string, server string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.subscribers[key] == nil {
+		c.subscribers[key] = make(map[string]bool)
+	}
+	c.subscribers[key][server] = true
+}
+
+// Remove and return servers caching the key
+func (c *ReadCache) TakeSubscribers(key string) []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var servers []string
+	for server := range c.subscribers[key] {
+		servers = append(servers, server)
+	}
+	delete(c.subscribers, key)
+	return servers
+}
+
+// Max staleness of the key if it is cacheable
+func (s *Server) cacheStaleness(key string) (time.Duration, bool) {
+	for _, rule := range s.Cacheable {
+		if strings.HasPrefix(key, rule.Prefix) {
+			return time.Duration(rule.MaxStalenessMillis) * time.Millisecond, true
+		}
+	}
+	return 0, false
+}
+
+// Tell servers caching the key that a new node was written
+func (s *Server) invalidateKey(key string) {
+	for _, server := range readCache.TakeSubscribers(key) {
+		go func(server string) {
+			client, err := pool.Get(server)
+			if err == nil {
+				_, err = client.Invalidate(context.Background(), &db.InvalidateRequest{Key: key})
+			}
+			if err != nil {
+				log.Printf("Fail to invalidate %s on %s: %v", key, server, err)
+			}
+		}(server)
+	}
+}
+
+func (s *Server) Invalidate(ctx context.Context, in *db.InvalidateRequest) (*db.Empty, error) {
+	readCache.Invalidate(in.Key)
+	return &db.Empty{}, nil
+}
+
+// Forward a Get to the owner, using the read cache for cacheable keys
+func (s *Server) forwardGet(ctx context.Context, address string, in *db.GetRequest) (*db.GetResponse, error) {
+	staleness, cacheable := s.cacheStaleness(in.Key)
+	// The bound of the request applies to any key (and tightens the rules)
+	requested := time.Duration(in.MaxStalenessMillis) * time.Millisecond
+	if requested > 0 && (!cacheable || requested < staleness) {
+		staleness, cacheable = requested, true
+	}
+	// As-of and metadata reads bypass the cache
+	cacheable = cacheable && in.AsOf == 0 && !in.MetadataOnly
+	if cacheable {
+		if resp, ok := readCache.Lookup(in.Key, in.Location, staleness); ok {
+			if in.SyncRepair {
+				return s.repair(ctx, address, in, resp)
+			}
+			s.scheduleRepair(address, in, resp)
+			if requested > 0 {
+				atomic.AddInt64(&readCache.StaleServed, 1)
+			}
+			return resp, nil
+		}
+		in.Subscriber = s.Self
+	}
+
+	client, err := pool.Get(address)
+	if err != nil {
+		return &db.GetResponse{}, err
+	}
+	start := time.Now()
+	resp, err := client.Get(ctx, in)
+	timePhase(ctx, phaseForward, start)
+	if cacheable && errors.Is(err, dberrors.ErrOverloaded) {
+		// Serve a cached value instead of queueing on the owner
+		if cached, ok := readCache.Lookup(in.Key, in.Location, staleness); ok {
+			return cached, nil
+		}
+	}
+	if err == nil && cacheable && !resp.DeadlineExceeded {
+		readCache.Store(in.Key, in.Location, resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"sync"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health service reporting whether the cluster has servers left for splits
+const capacityHealthService = "db.DbService/capacity"
+
+// Whether servers must be added before the next split.
+// Raised when a split takes the last available server or when this server
+// is over its threshold with none left, and cleared once one is available.
+type Expansion struct {
+	lock   sync.Mutex
+	needed bool
+	reason string
+}
+
+var expansion = Expansion{}
+
+func (e *Expansion) Raise(available int, reason string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.needed {
+		return
+	}
+	e.needed, e.reason = true, reason
+	log.Printf("Expansion needed: %s", reason)
+	healthServer.SetServingStatus(capacityHealthService, healthpb.HealthCheckResponse_NOT_SERVING)
+	events.Alert(eventCapacityExhausted, map[string]string{"available": strconv.Itoa(available), "reason": reason},
+		"Expansion needed: %s", reason)
+}
+
+// Clear the condition if servers are available again
+func (e *Expansion) Check(available int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.needed || available == 0 {
+		return
+	}
+	e.needed, e.reason = false, ""
+	log.Printf("Expansion no longer needed: %d available servers", available)
+	healthServer.SetServingStatus(capacityHealthService, healthpb.HealthCheckResponse_SERVING)
+	events.Publish(eventCapacityRestored, map[string]string{"available": strconv.Itoa(available)},
+		"%d available servers: expansion no longer needed", available)
+}
+
+func (e *Expansion) Needed() (bool, string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.needed, e.reason
+}
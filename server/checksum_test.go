@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Values containing it are corrupted in transit by corruptingConn
+const pristine = "pristine"
+
+// Connection flipping the last byte of pristine in what it sends and receives
+type corruptingConn struct {
+	net.Conn
+	flipped *int64
+}
+
+func (c *corruptingConn) corrupt(b []byte) {
+	for i := bytes.Index(b, []byte(pristine)); i >= 0; i = bytes.Index(b, []byte(pristine)) {
+		b[i+len(pristine)-1] ^= 1
+		atomic.AddInt64(c.flipped, 1)
+	}
+}
+
+func (c *corruptingConn) Write(b []byte) (int, error) {
+	b = append([]byte(nil), b...)
+	c.corrupt(b)
+	return c.Conn.Write(b)
+}
+
+func (c *corruptingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.corrupt(b[:n])
+	return n, err
+}
+
+func corruptingDialer(flipped *int64) func(ctx context.Context, address string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return nil, err
+		}
+		return &corruptingConn{conn, flipped}, nil
+	}
+}
+
+// Serve the server on localhost (owning the whole range at that address),
+// with the connections of the pool corrupted until the test ends
+func serveCorrupted(t *testing.T, s *Server, flipped *int64) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	db.RegisterDbServiceServer(server, s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	s.Self = listener.Addr().String()
+	resetTestServer(s)
+	corruptPool(t, flipped)
+	return s.Self
+}
+
+// Corrupt the connections of the pool until the test ends
+func corruptPool(t *testing.T, flipped *int64) {
+	t.Helper()
+	closeAll := func() {
+		pool.lock.Lock()
+		defer pool.lock.Unlock()
+		for address, pooled := range pool.conns {
+			pooled.conn.Close()
+			delete(pool.conns, address)
+		}
+	}
+	closeAll()
+	pool.Dialer = corruptingDialer(flipped)
+	t.Cleanup(func() {
+		pool.Dialer = nil
+		closeAll()
+	})
+}
+
+func checkDataLoss(t *testing.T, what string, err error) {
+	t.Helper()
+	if status.Code(err) != codes.DataLoss || !errors.Is(err, dberrors.ErrChecksumMismatch) {
+		t.Errorf("%s of a corrupted node returned %v, expected a checksum mismatch", what, err)
+	}
+}
+
+func checksumMismatches(t *testing.T, s *Server) int64 {
+	t.Helper()
+	stats, err := s.GetStats(context.Background(), &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stats.ChecksumMismatches
+}
+
+// Nodes corrupted on the way to AddNode and AddNodes are rejected and counted
+func TestChecksumOnAddNode(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	var flipped int64
+	address := serveCorrupted(t, s, &flipped)
+	conn, err := grpc.Dial(address, grpc.WithInsecure(),
+		grpc.WithContextDialer(corruptingDialer(&flipped)),
+		grpc.WithUnaryInterceptor(dberrors.UnaryClientInterceptor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := db.NewDbServiceClient(conn)
+	before := checksumMismatches(t, s)
+
+	corrupted := storage.Seal(storage.CreateNode("k", []byte(pristine), math.MaxUint64))
+	_, err = client.AddNode(ctx, &db.AddNodeRequest{Node: corrupted})
+	checkDataLoss(t, "AddNode", err)
+	if store.GetNode(corrupted.Location) != nil {
+		t.Errorf("Corrupted node stored by AddNode")
+	}
+
+	// The batch stops at the corrupted node
+	intact := storage.Seal(storage.CreateNode("k", []byte("v"), math.MaxUint64))
+	corrupted = storage.Seal(storage.CreateNode("k", []byte(pristine), math.MaxUint64))
+	resp, err := client.AddNodes(ctx, &db.AddNodesRequest{Requests: []*db.AddNodeRequest{{Node: intact}, {Node: corrupted}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != 1 || resp.Failure == nil || codes.Code(resp.Failure.Code) != codes.DataLoss {
+		t.Errorf("AddNodes returned %d results and failure %v, expected a checksum mismatch on the second node", len(resp.Results), resp.Failure)
+	}
+	if store.GetNode(intact.Location) == nil || store.GetNode(corrupted.Location) != nil {
+		t.Errorf("AddNodes did not store only the intact node")
+	}
+
+	if n := atomic.LoadInt64(&flipped); n != 2 {
+		t.Fatalf("Corrupted %d nodes in transit, expected 2", n)
+	}
+	if mismatches := checksumMismatches(t, s) - before; mismatches != 2 {
+		t.Errorf("GetStats counts %d checksum mismatches, expected 2", mismatches)
+	}
+}
+
+// A merge whose output is corrupted on the way to its owner fails
+// and leaves the children of the parent as they were
+func TestChecksumOnMergeOutput(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	var flipped int64
+	serveCorrupted(t, s, &flipped)
+	previous := invoker
+	invoker = &resolverStub{value: pristine}
+	defer func() { invoker = previous }()
+	mergeFailures.Init(0, 0)
+
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent := mustSet(t, s, &db.SetRequest{Key: "k", Value: []byte("0"), Dep: root.Location})
+	mustSet(t, s, &db.SetRequest{Key: "k", Value: []byte("a"), Dep: parent})
+	mustSet(t, s, &db.SetRequest{Key: "k", Value: []byte("b"), Dep: parent})
+	// Registered after the writes so that the merge only runs here
+	core.SetKeyMergeFunction("k", "resolver", engine.Policy{})
+	before := checksumMismatches(t, s)
+
+	node, err := core.GetNode(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.merge(ctx, node)
+	checkDataLoss(t, "Merge", err)
+	if atomic.LoadInt64(&flipped) == 0 {
+		t.Fatalf("Merge output not corrupted in transit")
+	}
+	if children := store.GetNode(parent).Children; len(children) != 2 {
+		t.Errorf("Parent has children %x after the failed merge, expected the two writes", children)
+	}
+	if mismatches := checksumMismatches(t, s) - before; mismatches != 1 {
+		t.Errorf("GetStats counts %d checksum mismatches, expected 1", mismatches)
+	}
+}
+
+// Owner of the upper half of the range, answering GetNode with a sealed node
+type checksumPeer struct {
+	db.UnimplementedDbServiceServer
+}
+
+func (p *checksumPeer) GetNode(ctx context.Context, in *db.GetNodeRequest) (*db.Node, error) {
+	return storage.Seal(&db.Node{Location: in.Location, Dep: math.MaxUint64, Key: "k", Value: []byte(pristine)}), nil
+}
+
+// A node corrupted on the way back from its owner is caught by GetNode with VerifyChecksum
+func TestChecksumOnForwardedGetNode(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	address := startPeer(t, &checksumPeer{})
+	indexingService.Reset(nil, 0)
+	indexingService.AddMapping(0, math.MaxUint32/2, s.Self)
+	indexingService.AddMapping(math.MaxUint32/2+1, math.MaxUint32, address)
+	var flipped int64
+	corruptPool(t, &flipped)
+
+	location := upperLocation(1)
+	node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(node.Value) == pristine {
+		t.Fatalf("Node not corrupted in transit")
+	}
+	_, err = s.GetNode(ctx, &db.GetNodeRequest{Location: location, VerifyChecksum: true})
+	checkDataLoss(t, "GetNode", err)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Cap the children of nodes for the test
+func setMaxChildren(t *testing.T, s *Server, max int, autoBucket bool) {
+	s.MaxChildren, s.AutoBucket = max, autoBucket
+	store.MaxChildren = max
+	t.Cleanup(func() { store.MaxChildren = 0 })
+}
+
+// Parent with the children written under it
+func writeChildren(t *testing.T, s *Server, n int) (uint64, []uint64) {
+	t.Helper()
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := s.Set(ctx, &db.SetRequest{Key: "parent", Value: []byte("p"), Dep: root.Location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var children []uint64
+	for i := 0; i < n; i++ {
+		child, err := s.Set(ctx, &db.SetRequest{Key: fmt.Sprintf("child%d", i), Value: []byte("c"), Dep: parent.Location})
+		if err != nil {
+			t.Fatalf("Set of child %d failed: %v", i, err)
+		}
+		children = append(children, child.Location)
+	}
+	return parent.Location, children
+}
+
+func TestMaxChildren(t *testing.T) {
+	s := newTestServer(t)
+	setMaxChildren(t, s, 3, false)
+	parent, _ := writeChildren(t, s, 3)
+
+	nodes := store.NodeCount()
+	_, err := s.Set(context.Background(), &db.SetRequest{Key: "child3", Value: []byte("c"), Dep: parent})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Set on a full node returned %v, expected ResourceExhausted", err)
+	}
+	if store.NodeCount() != nodes {
+		t.Errorf("Rejected child was stored")
+	}
+	if children := store.GetNode(parent).Children; len(children) != 3 {
+		t.Errorf("Parent has %d children, expected 3", len(children))
+	}
+}
+
+// Children beyond the cap go to bucket nodes, which reads walk through
+func TestAutoBucket(t *testing.T) {
+	s := newTestServer(t)
+	setMaxChildren(t, s, 3, true)
+	parent, children := writeChildren(t, s, 10)
+	ctx := context.Background()
+
+	// Every node under the parent has at most 3 children
+	pending := []uint64{parent}
+	buckets, reached := 0, make(map[uint64]bool)
+	for len(pending) > 0 {
+		node := store.GetNode(pending[0])
+		pending = pending[1:]
+		if len(node.Children) > 3 {
+			t.Errorf("Node %x has %d children", node.Location, len(node.Children))
+		}
+		if node.Metadata[storage.BucketKey] == "true" {
+			buckets++
+		}
+		for _, child := range node.Children {
+			reached[child] = true
+			pending = append(pending, child)
+		}
+	}
+	if buckets == 0 {
+		t.Errorf("No bucket was inserted")
+	}
+
+	for i, child := range children {
+		if !reached[child] {
+			t.Errorf("Child %d is not reachable from the parent", i)
+		}
+		// Reads of the parent from the children are unchanged by the buckets
+		resp, err := s.Get(ctx, &db.GetRequest{Key: "parent", Location: child})
+		if err != nil {
+			t.Fatalf("Read of the parent from child %d failed: %v", i, err)
+		}
+		if string(resp.Value) != "p" || resp.Location != parent {
+			t.Errorf("Read of the parent from child %d returned %q at %x", i, resp.Value, resp.Location)
+		}
+	}
+}
+
+func TestGetNodeChildrenPages(t *testing.T) {
+	s := newTestServer(t)
+	parent, children := writeChildren(t, s, 7)
+	ctx := context.Background()
+
+	var paged []uint64
+	var sizes []int
+	cursor := uint64(0)
+	for {
+		node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: parent, ChildrenLimit: 3, ChildrenCursor: cursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node.ChildCount != int64(len(children)) {
+			t.Errorf("ChildCount is %d, expected %d", node.ChildCount, len(children))
+		}
+		paged = append(paged, node.Children...)
+		sizes = append(sizes, len(node.Children))
+		if node.NextChildrenCursor == 0 {
+			break
+		}
+		cursor = node.NextChildrenCursor
+	}
+	if fmt.Sprint(sizes) != "[3 3 1]" {
+		t.Errorf("Pages have %v children, expected [3 3 1]", sizes)
+	}
+	if fmt.Sprint(paged) != fmt.Sprint(children) {
+		t.Errorf("Paged children %x, expected %x", paged, children)
+	}
+
+	// Without a limit all children are returned
+	node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(node.Children) != len(children) || node.NextChildrenCursor != 0 {
+		t.Errorf("Unpaged GetNode returned %d children and cursor %d", len(node.Children), node.NextChildrenCursor)
+	}
+	// Past the end
+	node, err = s.GetNode(ctx, &db.GetNodeRequest{Location: parent, ChildrenLimit: 3, ChildrenCursor: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(node.Children) != 0 || node.NextChildrenCursor != 0 {
+		t.Errorf("Page past the end returned %x and cursor %d", node.Children, node.NextChildrenCursor)
+	}
+}
@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Servers assert their ranges to peers periodically
+const claimInterval = 30 * time.Second
+
+// Counters of ranges claimed by two servers (accessed atomically)
+var splitBrainDetected, splitBrainResolved int64
+
+// Ranges owned by this server in its own mapping
+func (s *Server) ownedRanges() []*db.Range {
+	var ranges []*db.Range
+	for _, m := range indexingService.List() {
+		if m.Address == s.Self {
+			ranges = append(ranges, &db.Range{
+				Left:   m.Left,
+				Right:  m.Right,
+				Server: m.Address,
+			})
+		}
+	}
+	return ranges
+}
+
+// The claim with the newer epoch wins (the smaller address on ties)
+func claimWins(server string, epoch uint64, other string, otherEpoch uint64) bool {
+	if epoch != otherEpoch {
+		return epoch > otherEpoch
+	}
+	return server < other
+}
+
+func (s *Server) claimLoop() {
+	for {
+		time.Sleep(claimInterval)
+		s.claimRanges()
+	}
+}
+
+func (s *Server) claimRanges() {
+	s.lock.RLock()
+	request := &db.ClaimRangesRequest{
+		Server: s.Self,
+		Epoch:  indexingService.CurrentEpoch(),
+		Ranges: s.ownedRanges(),
+	}
+	servers := append([]string(nil), s.Servers...)
+	s.lock.RUnlock()
+
+	if len(request.Ranges) == 0 {
+		return
+	}
+	for _, addr := range servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err != nil {
+			log.Printf("Fail to claim ranges on %s: %v", addr, err)
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), claimInterval)
+		resp, err := client.ClaimRanges(ctx, request)
+		cancel()
+		if err != nil {
+			log.Printf("Fail to claim ranges on %s: %v", addr, err)
+			continue
+		}
+		if resp.Conflict && resp.Winner != s.Self {
+			s.yieldRanges(resp.Ranges, resp.Winner, resp.Epoch)
+			return
+		}
+	}
+}
+
+func (s *Server) ClaimRanges(ctx context.Context, in *db.ClaimRangesRequest) (*db.ClaimRangesResponse, error) {
+	s.lock.Lock()
+	var disputed, outdated []*db.Range
+	for _, claim := range in.Ranges {
+		for _, m := range indexingService.List() {
+			if m.Right < claim.Left || m.Left > claim.Right {
+				continue
+			}
+			left, right := m.Left, m.Right
+			if claim.Left > left {
+				left = claim.Left
+			}
+			if claim.Right < right {
+				right = claim.Right
+			}
+			if m.Address == s.Self {
+				disputed = append(disputed, &db.Range{Left: left, Right: right, Server: s.Self})
+			} else if m.Address != in.Server && in.Epoch > indexingService.CurrentEpoch() {
+				// Our mapping of a range owned by others is outdated
+				outdated = append(outdated, &db.Range{Left: left, Right: right, Server: in.Server})
+			}
+		}
+	}
+	for _, r := range outdated {
+		indexingService.Assign(r.Left, r.Right, r.Server)
+	}
+	epoch := indexingService.CurrentEpoch()
+	s.lock.Unlock()
+
+	if len(disputed) == 0 {
+		return &db.ClaimRangesResponse{}, nil
+	}
+
+	atomic.AddInt64(&splitBrainDetected, 1)
+	log.Printf("Split brain: %s (epoch %d) and %s (epoch %d) both own %s", in.Server, in.Epoch, s.Self, epoch, utils.ToString(disputed))
+
+	if claimWins(s.Self, epoch, in.Server, in.Epoch) {
+		return &db.ClaimRangesResponse{
+			Conflict: true,
+			Winner:   s.Self,
+			Epoch:    epoch,
+			Ranges:   disputed,
+		}, nil
+	}
+	go s.yieldRanges(disputed, in.Server, in.Epoch)
+	return &db.ClaimRangesResponse{
+		Conflict: true,
+		Winner:   in.Server,
+		Epoch:    in.Epoch,
+		Ranges:   disputed,
+	}, nil
+}
+
+// Give the disputed ranges to the winner with their nodes.
+// The reassignment is broadcast with a new epoch under the indexing locks, like a decommission.
+func (s *Server) yieldRanges(ranges []*db.Range, winner string, epoch uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	client, err := pool.Get(winner)
+	if err != nil {
+		log.Printf("Fail to yield ranges to %s: %v", winner, err)
+		return
+	}
+	ctx := context.Background()
+	level, err := s.negotiateProtocol(ctx, append([]string{winner}, s.Servers...))
+	if err == nil && level < protocolAbsorb {
+		err = fmt.Errorf("some servers speak split protocol level %d, older than %d needed to absorb ranges", level, protocolAbsorb)
+	}
+	if err != nil {
+		log.Printf("Fail to yield ranges to %s: %v", winner, err)
+		return
+	}
+	held, lockEpoch, err := s.lockServers(ctx, winner)
+	if err != nil {
+		log.Printf("Fail to yield ranges to %s: %v", winner, err)
+		return
+	}
+	defer s.unlockServers(ctx, held)
+	if lockEpoch > epoch {
+		epoch = lockEpoch
+	}
+
+	for _, r := range ranges {
+		var nodes []*db.Node
+		var loadErr error
+		store.IterateHashRange(r.Left, r.Right, func(node *storage.Node) bool {
+			// Spilled values are sent inline
+			node, loadErr = store.Load(node)
+			if loadErr != nil {
+				return false
+			}
+			nodes = append(nodes, node.ToProto())
+			return true
+		})
+		if loadErr != nil {
+			log.Printf("Fail to yield %x-%x: %v", r.Left, r.Right, loadErr)
+			continue
+		}
+
+		moved := make([]uint64, len(nodes))
+		for i, node := range nodes {
+			moved[i] = node.Location
+		}
+		siblings, err := migrateNodes(ctx, client, r, nodes)
+		if err != nil {
+			// Keep the range until the next claim
+			log.Printf("Fail to yield %x-%x to %s: %v", r.Left, r.Right, winner, err)
+			return
+		}
+
+		epoch += 1
+		request := &db.SplitRequest{
+			Left:        r.Left,
+			Right:       r.Right,
+			Mid:         r.Right,
+			LeftServer:  winner,
+			RightServer: winner,
+			Epoch:       epoch,
+			Holder:      s.Self,
+		}
+		if err := s.splitLocally(request); err != nil {
+			log.Printf("Fail to yield %x-%x to %s: %v", r.Left, r.Right, winner, err)
+			return
+		}
+		// Servers missing the new mapping catch up with the claims of the winner
+		for _, addr := range s.Servers {
+			if addr == s.Self {
+				continue
+			}
+			if err := pool.Retry(ctx, func() error { return s.applySplitOn(ctx, addr, request) }); err != nil {
+				log.Printf("Fail to update the mapping of %s: %v", addr, err)
+			}
+		}
+		store.RemoveNodes(moved)
+
+		// Link siblings so that merge functions resolve the divergence
+		for _, sibling := range siblings {
+			if _, err := s.AddChild(ctx, &db.AddChildRequest{
+				Location: sibling.Dep,
+				Child:    sibling.Location,
+			}); err != nil {
+				log.Printf("Fail to link sibling %x: %v", sibling.Location, err)
+			}
+		}
+	}
+
+	atomic.AddInt64(&splitBrainResolved, 1)
+	log.Printf("Split brain: yielded %s to %s", utils.ToString(ranges), winner)
+}
+
+// Copy the nodes of a yielded range to the winner.
+// A node differing from the one of the winner at its location is added
+// as a sibling at a new location, and its descendants in the range follow it
+// (their deps and the children of the sibling are rewritten).
+// Nothing is copied if such a node has children outside the range,
+// since their deps cannot follow it.
+// Returns the siblings whose parents are outside the range, to be linked to them.
+func migrateNodes(ctx context.Context, client db.DbServiceClient, r *db.Range, nodes []*db.Node) ([]*db.Node, error) {
+	existing := make(map[uint64]*db.Node)
+	relocated := make(map[uint64]uint64)
+	for _, node := range nodes {
+		current, err := client.GetNode(ctx, &db.GetNodeRequest{Location: node.Location})
+		if errors.Is(err, dberrors.ErrLocationNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if current.Key == node.Key && bytes.Equal(current.Value, node.Value) {
+			existing[node.Location] = current
+			continue
+		}
+		for _, child := range node.Children {
+			if hash := utils.KeyHash(child); hash < r.Left || hash > r.Right {
+				return nil, fmt.Errorf("node %x conflicting with the winner has child %x outside the range", node.Location, child)
+			}
+		}
+		relocated[node.Location] = storage.NewLocation(utils.KeyHash(node.Location))
+	}
+
+	inRange := make(map[uint64]bool, len(nodes))
+	for _, node := range nodes {
+		inRange[node.Location] = true
+	}
+	var siblings []*db.Node
+	for _, node := range nodes {
+		current, same := existing[node.Location]
+		_, conflicting := relocated[node.Location]
+		linked := inRange[node.Dep]
+		if location, ok := relocated[node.Dep]; ok {
+			node.Dep = location
+			// The checksum covers the dep
+			node.Checksum = 0
+			storage.Seal(node)
+		}
+		children := make([]uint64, len(node.Children))
+		for i, child := range node.Children {
+			if location, ok := relocated[child]; ok {
+				child = location
+			}
+			children[i] = child
+		}
+		node.Children = children
+
+		if same {
+			// Keep children added on both sides
+			for _, child := range children {
+				if !containsLocation(current.Children, child) {
+					if _, err := client.AddChild(ctx, &db.AddChildRequest{
+						Location: node.Location,
+						Child:    child,
+					}); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+		if conflicting {
+			node.Location = relocated[node.Location]
+			if !linked {
+				siblings = append(siblings, node)
+			}
+		}
+		if _, err := client.AddNode(ctx, &db.AddNodeRequest{Node: node, SkipLocationCheck: true}); err != nil {
+			return nil, err
+		}
+	}
+	return siblings, nil
+}
+
+func containsLocation(locations []uint64, location uint64) bool {
+	for _, l := range locations {
+		if l == location {
+			return true
+		}
+	}
+	return false
+}
+
+// Count and digest of the local nodes in a hash range.
+// Servers holding the same nodes of the range return the same digest.
+func (s *Server) GetRangeDigest(ctx context.Context, in *db.GetRangeDigestRequest) (*db.RangeDigest, error) {
+	count, digest, err := store.RangeDigest(in.Left, in.Right)
+	if err != nil {
+		return &db.RangeDigest{}, err
+	}
+	return &db.RangeDigest{
+		Left:   in.Left,
+		Right:  in.Right,
+		Count:  count,
+		Digest: digest,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Interval of the compression pass
+const compressInterval = time.Minute
+
+// Compressed runs and nodes removed by them (accessed atomically)
+var compressStats struct {
+	Runs  int64
+	Nodes int64
+}
+
+func (s *Server) compressible(key string) bool {
+	if len(key) == 0 {
+		return false
+	}
+	for _, prefix := range s.CompressPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Nodes created after this are not compressed
+// (they may still be read as of a time or by a snapshot)
+func (s *Server) compressCutoff() int64 {
+	cutoff := time.Now().Add(-time.Duration(s.CompressRetentionSeconds) * time.Second).UnixNano()
+	return snapshots.ReclaimBefore(cutoff)
+}
+
+// Whether the node can be collapsed into a later node of its key
+func compressibleNode(node *storage.Node, cutoff int64) bool {
+	if node == nil || node.CreatedAt > cutoff || node.ExpiresAt != 0 {
+		return false
+	}
+	// Merge functions are registered by location
+	_, _, registered := core.LocalMergeFunction(node.Location)
+	return !registered
+}
+
+// Linear run of local nodes of the same key ending at the location (oldest first).
+// Runs stop at a node of another key: the summary is a single node of one key,
+// so the value of another key in the run would be lost for reads through it.
+func findRun(location uint64, cutoff int64) []uint64 {
+	last := store.GetNode(location)
+	if !compressibleNode(last, cutoff) {
+		return nil
+	}
+	run := []uint64{location}
+	child := last
+	for child.Dep != math.MaxUint64 {
+		node := store.GetNode(child.Dep)
+		if !compressibleNode(node, cutoff) || node.Key != last.Key ||
+			len(node.Children) != 1 || node.Children[0] != child.Location {
+			break
+		}
+		run = append([]uint64{node.Location}, run...)
+		child = node
+	}
+	return run
+}
+
+// Collapse the run into its last node and relink the dep of the run to it
+func (s *Server) compressRun(ctx context.Context, run []uint64) error {
+	first := store.GetNode(run[0])
+	if first == nil {
+		return &dberrors.LocationNotFoundError{Location: run[0]}
+	}
+	dep, last := first.Dep, run[len(run)-1]
+	if err := store.CompressRun(run); err != nil {
+		return err
+	}
+	s.aliasTo(last, run[:len(run)-1]...)
+	if _, err := s.UnlinkChild(ctx, &db.AddChildRequest{Location: dep, Child: first.Location}); err != nil {
+		return err
+	}
+	if _, err := s.AddChild(ctx, &db.AddChildRequest{Location: dep, Child: last}); err != nil {
+		return err
+	}
+	atomic.AddInt64(&compressStats.Runs, 1)
+	atomic.AddInt64(&compressStats.Nodes, int64(len(run)-1))
+	return nil
+}
+
+// Compress the run of the key ending at the location
+func (s *Server) CompressChain(ctx context.Context, in *db.CompressChainRequest) (*db.CompressChainResponse, error) {
+	address := indexingService.ResolveOwner(in.Location)
+	if address != s.Self {
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.CompressChainResponse{}, err
+		}
+		return client.CompressChain(ctx, in)
+	}
+	if err := s.checkWritable(); err != nil {
+		return &db.CompressChainResponse{}, err
+	}
+
+	run := findRun(in.Location, s.compressCutoff())
+	if len(run) < 2 {
+		return &db.CompressChainResponse{}, status.Errorf(codes.FailedPrecondition, "No compressible run ends at %x", in.Location)
+	}
+	if err := s.compressRun(ctx, run); err != nil {
+		return &db.CompressChainResponse{}, err
+	}
+	return &db.CompressChainResponse{Removed: int64(len(run) - 1)}, nil
+}
+
+// Compress runs of keys with the configured prefixes
+// (at most budget runs unless it is 0)
+func (s *Server) compressPass(budget int) {
+	if len(s.CompressPrefixes) == 0 || s.checkWritable() != nil {
+		return
+	}
+	cutoff := s.compressCutoff()
+	minRun := s.CompressRunLength
+	if minRun < 2 {
+		minRun = 2
+	}
+
+	// Ends of runs: nodes without a single child of the same key
+	var ends []uint64
+	store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		if !s.compressible(node.Key) {
+			return true
+		}
+		if len(node.Children) == 1 {
+			if child := store.GetNode(node.Children[0]); child != nil && child.Key == node.Key {
+				return true
+			}
+		}
+		ends = append(ends, node.Location)
+		return true
+	})
+
+	ctx := context.Background()
+	compressed := 0
+	for _, end := range ends {
+		if budget > 0 && compressed >= budget {
+			break
+		}
+		run := findRun(end, cutoff)
+		if len(run) < minRun {
+			continue
+		}
+		if err := s.compressRun(ctx, run); err != nil {
+			log.Printf("Fail to compress the run ending at %x: %v", end, err)
+			continue
+		}
+		compressed += 1
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Value of the key read from the location as of the time ("error: ..." if it fails)
+func readAsOf(s *Server, key string, location uint64, asOf int64) string {
+	resp, err := s.Get(context.Background(), &db.GetRequest{Key: key, Location: location, AsOf: asOf})
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return string(resp.Value)
+}
+
+// Reads of every key through the end of a chain return the same values once
+// its runs are compressed, except as of a time inside a compressed run
+func TestCompressionEquivalence(t *testing.T) {
+	s := newTestServer(t)
+	s.CompressPrefixes = []string{"c/"}
+	s.CompressRunLength = 2
+	s.CompressRetentionSeconds = 0
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another key in the middle splits the history of c/k into two runs
+	writes := []string{"other", "c/k", "c/k", "c/k", "c/k", "c/j", "c/k", "c/k", "c/k"}
+	dep := root.Location
+	var chain []uint64
+	for i, key := range writes {
+		resp, err := s.Set(ctx, &db.SetRequest{Key: key, Value: []byte(fmt.Sprintf("%s=%d", key, i)), Dep: dep})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dep = resp.Location
+		chain = append(chain, dep)
+	}
+	end := chain[len(chain)-1]
+	var created, times []int64
+	for _, location := range chain {
+		at := store.GetNode(location).CreatedAt
+		created = append(created, at)
+		times = append(times, at-1, at)
+	}
+	times = append(times, 0)
+
+	keys := []string{"other", "c/k", "c/j", "missing"}
+	before := make(map[string]string)
+	for _, key := range keys {
+		for _, asOf := range times {
+			before[fmt.Sprintf("%s@%d", key, asOf)] = readAsOf(s, key, end, asOf)
+		}
+	}
+
+	nodes := store.NodeCount()
+	s.compressPass(0)
+	// Runs of 4 and 3 nodes of c/k
+	if removed := nodes - store.NodeCount(); removed != 3+2 {
+		t.Fatalf("Compression removed %d nodes, expected 5", removed)
+	}
+
+	// Times inside the runs of c/k (from the first node to before the last one)
+	compressed := func(asOf int64) bool {
+		return asOf >= created[1] && asOf < created[4] || asOf >= created[6] && asOf < created[8]
+	}
+	for _, key := range keys {
+		for _, asOf := range times {
+			label := fmt.Sprintf("%s@%d", key, asOf)
+			if key == "c/k" && asOf != 0 && compressed(asOf) {
+				_, err := s.Get(ctx, &db.GetRequest{Key: key, Location: end, AsOf: asOf})
+				if !errors.Is(dberrors.FromStatus(err), dberrors.ErrHistoryCompressed) {
+					t.Errorf("Read of %s inside a compressed run returned %v", label, err)
+				}
+				continue
+			}
+			if after := readAsOf(s, key, end, asOf); after != before[label] {
+				t.Errorf("Read of %s returned %q after compression, %q before", label, after, before[label])
+			}
+		}
+	}
+}
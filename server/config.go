@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const configFile = "./server.json"
+
+// Directory of the state files (see Server.StateDir)
+var stateDir = "."
+
+func statePath(name string) string {
+	return filepath.Join(stateDir, name)
+}
+
+// Fields that can change without a restart
+var reloadable = map[string]bool{
+	"threshold":                 true,
+	"thresholdBytes":            true,
+	"maxKeyBytes":               true,
+	"accessSampleEvery":         true,
+	"minSplitSize":              true,
+	"allowImplicitRoot":         true,
+	"maxConnections":            true,
+	"forwardAttempts":           true,
+	"forwardBackoffMillis":      true,
+	"maxRemoteHops":             true,
+	"minSplitIntervalSeconds":   true,
+	"maxSplitIntervalSeconds":   true,
+	"splitReserveServers":       true,
+	"lastResortSplit":           true,
+	"readRepairPerSecond":       true,
+	"maxResolveKeys":            true,
+	"transferBatchMin":          true,
+	"transferBatchMax":          true,
+	"transferApplyTargetMillis": true,
+	"maxResolveBytes":           true,
+	"splitVerifySamples":        true,
+	"splitVerifyTolerance":      true,
+	"journalSize":               true,
+	"journalMaxPerKey":          true,
+	"journalSampleEvery":        true,
+	"standbyLagAlertSeconds":    true,
+	"cacheable":                 true,
+	"serializeWrites":           true,
+	"maxChildren":               true,
+	"autoBucket":                true,
+	"durability":                true,
+	"splitByLoad":               true,
+	"warmUpNodes":               true,
+	"warmUpBudget":              true,
+	"spillThreshold":            true,
+	"maxMergeAttempts":          true,
+	"maxMergeRetryDelaySeconds": true,
+	"memoryHighWaterMB":         true,
+	"memoryLowWaterMB":          true,
+	"memoryLimitFromCgroup":     true,
+	"slowLogMillis":             true,
+	"shedInflight":              true,
+	"compressPrefixes":          true,
+	"compressRunLength":         true,
+	"compressRetentionSeconds":  true,
+	"aliasRetentionSeconds":     true,
+	"maxAliasChain":             true,
+	"shedP99Millis":             true,
+	"slowLogMethodMillis":       true,
+	"maintenance":               true,
+	"maintenanceConcurrency":    true,
+}
+
+// Fields changed at runtime by the cluster (ignored on reload)
+var managed = map[string]bool{
+	"servers":          true,
+	"availableServers": true,
+}
+
+// Fields never returned by GetConfig
+var secret = map[string]bool{
+	"clusterSecret": true,
+}
+
+func (s *Server) applyDefaults() {
+	if len(s.Initial) == 0 {
+		s.Initial = s.Seed
+	}
+	if s.Backend == "disk" && len(s.DataDir) == 0 {
+		s.DataDir = "./data"
+	}
+	if len(s.StateDir) == 0 {
+		s.StateDir = "."
+	}
+	if s.SpillThreshold > 0 && len(s.BlobDir) == 0 {
+		s.BlobDir = "./blobs"
+	}
+	if s.AccessSampleEvery == 0 {
+		s.AccessSampleEvery = 16
+	}
+	if s.MaxKeyBytes == 0 {
+		s.MaxKeyBytes = defaultMaxKeyBytes
+	}
+	if s.SpacePrefixLength == 0 {
+		s.SpacePrefixLength = 4
+	}
+	if s.ForwardAttempts == 0 {
+		s.ForwardAttempts = 3
+	}
+	if s.ForwardBackoffMillis == 0 {
+		s.ForwardBackoffMillis = 100
+	}
+	if s.MaxRemoteHops == 0 {
+		s.MaxRemoteHops = 8
+	}
+	if s.MinSplitIntervalSeconds == 0 {
+		s.MinSplitIntervalSeconds = 10
+	}
+	if s.MaxSplitIntervalSeconds == 0 {
+		s.MaxSplitIntervalSeconds = 600
+	}
+	if s.SplitReserveServers == 0 {
+		s.SplitReserveServers = 1
+	}
+	if s.SplitVerifySamples == 0 {
+		s.SplitVerifySamples = 16
+	}
+	if s.JournalSize == 0 {
+		s.JournalSize = 1024
+	}
+	if s.JournalSampleEvery == 0 {
+		s.JournalSampleEvery = 10
+	}
+	if s.StandbyLagAlertSeconds == 0 {
+		s.StandbyLagAlertSeconds = 10
+	}
+	if s.CompressRunLength == 0 {
+		s.CompressRunLength = 8
+	}
+	if s.CompressRetentionSeconds == 0 {
+		s.CompressRetentionSeconds = 3600
+	}
+	if s.AliasRetentionSeconds == 0 {
+		s.AliasRetentionSeconds = 86400
+	}
+	if s.MaxAliasChain == 0 {
+		s.MaxAliasChain = 8
+	}
+	if s.ReadRepairPerSecond == 0 {
+		s.ReadRepairPerSecond = 50
+	}
+	if s.TransferBatchMin == 0 {
+		s.TransferBatchMin = 1
+	}
+	if s.TransferBatchMax == 0 {
+		s.TransferBatchMax = 512
+	}
+	if s.TransferApplyTargetMillis == 0 {
+		s.TransferApplyTargetMillis = 50
+	}
+	if s.MaxResolveKeys == 0 {
+		s.MaxResolveKeys = 16
+	}
+	if s.MaxResolveBytes == 0 {
+		s.MaxResolveBytes = 65536
+	}
+	if s.MaintenanceConcurrency == 0 {
+		s.MaintenanceConcurrency = 1
+	}
+}
+
+// Pass reloadable settings to the components using them
+func (s *Server) applyTunables() {
+	store.MaxChildren = s.MaxChildren
+	access.SetRate(s.AccessSampleEvery)
+	warmUp.Init(s.WarmUpNodes, s.WarmUpBudget)
+	pool.Max = s.MaxConnections
+	pool.Attempts = s.ForwardAttempts
+	pool.Backoff = time.Duration(s.ForwardBackoffMillis) * time.Millisecond
+	splitGovernor.lock.Lock()
+	splitGovernor.MinInterval = time.Duration(s.MinSplitIntervalSeconds) * time.Second
+	splitGovernor.MaxInterval = time.Duration(s.MaxSplitIntervalSeconds) * time.Second
+	splitGovernor.Reserve = s.SplitReserveServers
+	splitGovernor.lock.Unlock()
+	journal.Configure(s.JournalSize, s.JournalMaxPerKey, s.JournalSampleEvery)
+	standby.SetLagAlert(time.Duration(s.StandbyLagAlertSeconds) * time.Second)
+	memoryWatchdog.Init(s.MemoryHighWaterMB, s.MemoryLowWaterMB, s.MemoryLimitFromCgroup)
+	mergeFailures.Init(s.MaxMergeAttempts, time.Duration(s.MaxMergeRetryDelaySeconds)*time.Second)
+	scheduler.Configure(s.Maintenance, s.MaintenanceConcurrency)
+	if store.Blobs != nil && s.SpillThreshold > 0 {
+		store.Blobs.Threshold = s.SpillThreshold
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// Re-read server.json and apply the fields that can change at runtime.
+// Other changes are reported and need a restart.
+func (s *Server) ReloadConfig(ctx context.Context, in *db.Empty) (*db.ReloadConfigResponse, error) {
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return &db.ReloadConfigResponse{}, err
+	}
+	var next Server
+	if err := json.Unmarshal(data, &next); err != nil {
+		return &db.ReloadConfigResponse{}, status.Errorf(codes.InvalidArgument, "Invalid %s: %v", configFile, err)
+	}
+	next.applyDefaults()
+	// Spilling cannot be turned on or off at runtime
+	if (next.SpillThreshold > 0) != (store.Blobs != nil) {
+		next.SpillThreshold = s.SpillThreshold
+	}
+
+	s.lock.Lock()
+	resp := &db.ReloadConfigResponse{}
+	current := reflect.ValueOf(s).Elem()
+	updated := reflect.ValueOf(&next).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		name := jsonName(current.Type().Field(i))
+		if len(name) == 0 || managed[name] {
+			continue
+		}
+		if reflect.DeepEqual(current.Field(i).Interface(), updated.Field(i).Interface()) {
+			continue
+		}
+		if reloadable[name] {
+			current.Field(i).Set(updated.Field(i))
+			resp.Applied = append(resp.Applied, name)
+		} else {
+			resp.Rejected = append(resp.Rejected, name)
+		}
+	}
+	s.applyTunables()
+	s.lock.Unlock()
+
+	log.Printf("Config reloaded (applied: %v, restart required: %v)", resp.Applied, resp.Rejected)
+	events.Publish(eventConfigReloaded, nil, "Config reloaded (applied: %v, restart required: %v)", resp.Applied, resp.Rejected)
+	if len(resp.Rejected) > 0 {
+		return resp, status.Errorf(codes.FailedPrecondition, "Applied %v; restart required for %v", resp.Applied, resp.Rejected)
+	}
+	return resp, nil
+}
+
+// Effective configuration with secrets redacted
+func (s *Server) GetConfig(ctx context.Context, in *db.Empty) (*db.ConfigResponse, error) {
+	s.lock.RLock()
+	data, err := json.Marshal(s)
+	s.lock.RUnlock()
+	if err != nil {
+		return &db.ConfigResponse{}, err
+	}
+
+	var config map[string]interface{}
+	json.Unmarshal(data, &config)
+	for name := range secret {
+		if value, ok := config[name]; ok && value != "" {
+			config[name] = "<redacted>"
+		}
+	}
+	data, _ = json.Marshal(config)
+	return &db.ConfigResponse{Json: string(data)}, nil
+}
+
+// Reload the config on SIGHUP
+func (s *Server) handleReloadSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		if _, err := s.ReloadConfig(context.Background(), &db.Empty{}); err != nil {
+			log.Printf("Reload: %v", err)
+		}
+	}
+}
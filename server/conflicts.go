@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Conflicts are counted over the last conflictBuckets intervals
+const (
+	conflictBucketInterval = time.Minute
+	conflictBuckets        = 5
+)
+
+// Size of the count-min sketches
+const (
+	sketchDepth = 4
+	sketchWidth = 1024
+)
+
+// Keys tracked as candidates for the top list
+const maxConflictCandidates = 256
+
+type countMinSketch [sketchDepth][sketchWidth]uint32
+
+func sketchIndexes(key string) [sketchDepth]uint32 {
+	var indexes [sketchDepth]uint32
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	// Double hashing from the two halves of the hash
+	h1, h2 := uint32(sum), uint32(sum>>32)
+	for i := range indexes {
+		indexes[i] = (h1 + uint32(i)*h2) % sketchWidth
+	}
+	return indexes
+}
+
+type conflictCandidate struct {
+	// Parent of the last conflict
+	parent uint64
+	last   time.Time
+}
+
+// Writes creating a sibling by key over a sliding window
+type ConflictTracker struct {
+	lock sync.Mutex
+	// Ring of sketches, one per interval
+	buckets    [conflictBuckets]countMinSketch
+	current    int
+	rotated    time.Time
+	candidates map[string]*conflictCandidate
+}
+
+var conflicts = ConflictTracker{
+	candidates: make(map[string]*conflictCandidate),
+}
+
+// Must be called with the lock held
+func (t *ConflictTracker) rotate(now time.Time) {
+	if t.rotated.IsZero() {
+		t.rotated = now
+		return
+	}
+	for now.Sub(t.rotated) >= conflictBucketInterval {
+		t.current = (t.current + 1) % conflictBuckets
+		t.buckets[t.current] = countMinSketch{}
+		t.rotated = t.rotated.Add(conflictBucketInterval)
+	}
+}
+
+// Must be called with the lock held
+func (t *ConflictTracker) estimate(key string) uint32 {
+	var min uint32
+	for i, index := range sketchIndexes(key) {
+		var count uint32
+		for b := range t.buckets {
+			count += t.buckets[b][i][index]
+		}
+		if i == 0 || count < min {
+			min = count
+		}
+	}
+	return min
+}
+
+// Count a write of the key adding a sibling under the parent
+func (t *ConflictTracker) Record(key string, parent uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	t.rotate(now)
+	for i, index := range sketchIndexes(key) {
+		t.buckets[t.current][i][index] += 1
+	}
+
+	if c, ok := t.candidates[key]; ok {
+		c.parent, c.last = parent, now
+		return
+	}
+	if len(t.candidates) >= maxConflictCandidates {
+		// Replace the coldest candidate if the key is hotter
+		coldest, coldestCount := "", uint32(0)
+		for k := range t.candidates {
+			if count := t.estimate(k); coldest == "" || count < coldestCount {
+				coldest, coldestCount = k, count
+			}
+		}
+		if t.estimate(key) <= coldestCount {
+			return
+		}
+		delete(t.candidates, coldest)
+	}
+	t.candidates[key] = &conflictCandidate{parent: parent, last: now}
+}
+
+// Keys with the most conflicts in the window (estimated, never under-counted)
+func (t *ConflictTracker) Top(limit int) []*db.ConflictingKey {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.rotate(time.Now())
+	var keys []*db.ConflictingKey
+	for key, c := range t.candidates {
+		count := t.estimate(key)
+		if count == 0 {
+			// No conflict in the window anymore
+			delete(t.candidates, key)
+			continue
+		}
+		keys = append(keys, &db.ConflictingKey{
+			Key:          key,
+			Count:        int64(count),
+			Parent:       c.parent,
+			LastConflict: c.last.UnixNano(),
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Count != keys[j].Count {
+			return keys[i].Count > keys[j].Count
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys
+}
+
+// Keys written on this server that created the most siblings recently
+func (s *Server) TopConflictingKeys(ctx context.Context, in *db.TopConflictingKeysRequest) (*db.ConflictingKeys, error) {
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = 10
+	}
+	keys := conflicts.Top(limit)
+	for _, key := range keys {
+		name, _ := core.MergeFunction(key.Parent)
+		key.MergeFunction = name
+	}
+	return &db.ConflictingKeys{
+		Keys:          keys,
+		WindowSeconds: int64(conflictBuckets * conflictBucketInterval / time.Second),
+	}, nil
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+)
+
+// Pending merges and divergence times of local parents,
+// so that clients can wait until merges are done
+type Convergence struct {
+	lock sync.Mutex
+	// Number of scheduled merges by parent
+	pending map[uint64]int
+	// When parents got a second child (unix nanoseconds)
+	since map[uint64]int64
+	// Closed and replaced whenever a merge finishes
+	changed chan struct{}
+}
+
+var convergence = Convergence{
+	pending: make(map[uint64]int),
+	since:   make(map[uint64]int64),
+	changed: make(chan struct{}),
+}
+
+// A merge of the parent is queued or will be
+func (c *Convergence) Schedule(parent uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pending[parent] += 1
+}
+
+// A scheduled merge of the parent was run or skipped
+func (c *Convergence) Done(parent uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.pending[parent] > 1 {
+		c.pending[parent] -= 1
+	} else {
+		delete(c.pending, parent)
+	}
+	c.notify()
+}
+
+// Wake up waiters (e.g. after a merge run by a Set)
+func (c *Convergence) Notify() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.notify()
+}
+
+// Must be called with the lock held
+func (c *Convergence) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Closed at the next notification
+func (c *Convergence) Changed() <-chan struct{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.changed
+}
+
+func (c *Convergence) Diverged(parent uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.since[parent]; !ok {
+		c.since[parent] = time.Now().UnixNano()
+	}
+}
+
+func (c *Convergence) Pending() []uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	parents := make([]uint64, 0, len(c.pending))
+	for parent := range c.pending {
+		parents = append(parents, parent)
+	}
+	return parents
+}
+
+// Divergence times of the diverged parents (now for unknown ones).
+// Other parents are forgotten.
+func (c *Convergence) Since(diverged map[uint64]bool) map[uint64]int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for parent := range c.since {
+		if !diverged[parent] {
+			delete(c.since, parent)
+		}
+	}
+	now := time.Now().UnixNano()
+	since := make(map[uint64]int64, len(diverged))
+	for parent := range diverged {
+		if _, ok := c.since[parent]; !ok {
+			c.since[parent] = now
+		}
+		since[parent] = c.since[parent]
+	}
+	return since
+}
+
+// Whether merges are done on all servers (or under a root).
+// With Block, wait until they are or until the deadline of the call.
+func (s *Server) ConvergenceStatus(ctx context.Context, in *db.ConvergenceStatusRequest) (*db.ConvergenceReport, error) {
+	if in.Local {
+		for {
+			changed := convergence.Changed()
+			report, err := s.localConvergence(ctx, in.Root)
+			if err != nil {
+				return &db.ConvergenceReport{}, err
+			}
+			if report.Converged || !in.Block {
+				return report, nil
+			}
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return report, nil
+			}
+		}
+	}
+
+	s.lock.RLock()
+	servers := append([]string(nil), s.Servers...)
+	s.lock.RUnlock()
+	if len(servers) == 0 {
+		servers = []string{s.Self}
+	}
+	for {
+		report, err := s.gatherConvergence(ctx, servers, in.Root, in.Block)
+		if err != nil || !report.Converged || !in.Block {
+			return report, err
+		}
+		// Merges finishing on one server can link nodes on another
+		report, err = s.gatherConvergence(ctx, servers, in.Root, false)
+		if err != nil || report.Converged || ctx.Err() != nil {
+			return report, err
+		}
+	}
+}
+
+// Sum of the local reports of the servers
+func (s *Server) gatherConvergence(ctx context.Context, servers []string, root uint64, block bool) (*db.ConvergenceReport, error) {
+	reports := make([]*db.ConvergenceReport, len(servers))
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, addr := range servers {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			request := &db.ConvergenceStatusRequest{Root: root, Block: block, Local: true}
+			if addr == s.Self {
+				reports[i], errs[i] = s.ConvergenceStatus(ctx, request)
+				return
+			}
+			client, err := pool.Get(addr)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			reports[i], errs[i] = client.ConvergenceStatus(ctx, request)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	total := &db.ConvergenceReport{}
+	for i, report := range reports {
+		if errs[i] != nil {
+			return &db.ConvergenceReport{}, errs[i]
+		}
+		total.DivergedParents += report.DivergedParents
+		total.Mergeable += report.Mergeable
+		total.PendingMerges += report.PendingMerges
+		total.DeadLettered += report.DeadLettered
+		if report.OldestDivergence != 0 && (total.OldestDivergence == 0 || report.OldestDivergence < total.OldestDivergence) {
+			total.OldestDivergence = report.OldestDivergence
+		}
+	}
+	total.Converged = total.Mergeable == 0 && total.PendingMerges == 0
+	return total, nil
+}
+
+func (s *Server) localConvergence(ctx context.Context, root uint64) (*db.ConvergenceReport, error) {
+	children := make(map[uint64]int)
+	diverged := make(map[uint64]bool)
+	err := store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		if len(node.Children) > 1 {
+			children[node.Location] = len(node.Children)
+			diverged[node.Location] = true
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	since := convergence.Since(diverged)
+
+	report := &db.ConvergenceReport{}
+	roots := make(map[uint64]uint64)
+	for parent, n := range children {
+		if root != 0 {
+			r, err := s.rootOf(ctx, parent, roots)
+			if err != nil {
+				return nil, err
+			}
+			if r != root {
+				continue
+			}
+		}
+		report.DivergedParents += 1
+		merge, policy := core.MergeFunction(parent)
+		if len(merge) == 0 || !policy.Conflict(n) {
+			continue
+		}
+		report.Mergeable += 1
+		if mergeFailures.Dead(parent) {
+			report.DeadLettered += 1
+		}
+		if report.OldestDivergence == 0 || since[parent] < report.OldestDivergence {
+			report.OldestDivergence = since[parent]
+		}
+	}
+	for _, parent := range convergence.Pending() {
+		if root != 0 {
+			// Removed parents have nothing left to merge
+			if r, err := s.rootOf(ctx, parent, roots); err != nil || r != root {
+				continue
+			}
+		}
+		report.PendingMerges += 1
+	}
+	report.Converged = report.Mergeable == 0 && report.PendingMerges == 0
+	return report, nil
+}
+
+// Root of the location, following deps across servers
+func (s *Server) rootOf(ctx context.Context, location uint64, roots map[uint64]uint64) (uint64, error) {
+	var path []uint64
+	for {
+		if root, ok := roots[location]; ok {
+			location = root
+			break
+		}
+		path = append(path, location)
+		var dep uint64
+		if node := store.GetNode(location); node != nil {
+			dep = node.Dep
+		} else {
+			node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location})
+			if err != nil {
+				return 0, err
+			}
+			dep = node.Dep
+		}
+		if dep == math.MaxUint64 {
+			break
+		}
+		location = dep
+	}
+	for _, l := range path {
+		roots[l] = location
+	}
+	return location, nil
+}
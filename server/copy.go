@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+)
+
+// Copies reported per progress message
+const copyProgressBatch = 100
+
+// Children fetched per GetNode while walking the source
+const copyChildrenPage = 1000
+
+// Expire a node (used to soft-delete the source of a move)
+func (self *Server) ExpireNode(ctx context.Context, in *db.ExpireNodeRequest) (*db.Empty, error) {
+	address := indexingService.ResolveOwner(in.Location)
+
+	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.Empty{}, err
+		}
+		node := store.GetNode(in.Location)
+		if err := store.Expire(in.Location, in.ExpiresAt); err != nil {
+			return &db.Empty{}, err
+		}
+		if node != nil && !node.Keyless {
+			self.invalidateKey(node.Key)
+		}
+		return &db.Empty{}, nil
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.Empty{}, err
+		}
+
+		return client.ExpireNode(ctx, in)
+	}
+}
+
+// Node of the source waiting to be copied under the copy of its parent
+type pendingCopy struct {
+	source uint64
+	parent uint64
+}
+
+// Copy the subtree under the destination, node by node.
+// Copies are at locations derived from the id and their source,
+// so a copy resumed with the same id skips nodes already written.
+// Roots and buckets are not copied: their children go under the copy of their parent.
+func (s *Server) CopySubtree(in *db.CopySubtreeRequest, stream db.DbService_CopySubtreeServer) error {
+	ctx := stream.Context()
+	if err := s.checkWritable(); err != nil {
+		return err
+	}
+	if err := s.checkDep(ctx, in.Destination); err != nil {
+		return err
+	}
+	id := in.Id
+	if len(id) == 0 {
+		id = fmt.Sprintf("%016x", rand.Uint64())
+	}
+	log.Printf("Copy %s of %x to %x started (move: %v)", id, in.Source, in.Destination, in.Move)
+
+	progress := &db.CopyProgress{Id: id}
+	// Copies are never copied again (e.g. when the destination is in the source)
+	copies := make(map[uint64]bool)
+	var sources []uint64
+	queue := []pendingCopy{{in.Source, in.Destination}}
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		node, children, err := s.copySource(ctx, item.source)
+		if err != nil {
+			return fmt.Errorf("Copy %s stopped at %x: %v", id, item.source, err)
+		}
+		sources = append(sources, item.source)
+		parent := item.parent
+		if !node.Keyless {
+			location, skipped, err := s.copyNode(ctx, id, node, item.parent)
+			if err != nil {
+				return fmt.Errorf("Copy %s stopped at %x: %v", id, item.source, err)
+			}
+			copies[location] = true
+			parent = location
+			progress.Copied += 1
+			if skipped {
+				progress.Skipped += 1
+			}
+			progress.Nodes = append(progress.Nodes, &db.CopiedNode{Source: node.Location, Copy: location})
+		}
+		for _, child := range children {
+			if !copies[child] {
+				queue = append(queue, pendingCopy{child, parent})
+			}
+		}
+
+		if len(progress.Nodes) >= copyProgressBatch {
+			if err := stream.Send(progress); err != nil {
+				return err
+			}
+			progress.Nodes = nil
+		}
+	}
+
+	if in.Move {
+		now := time.Now().UnixNano()
+		for _, source := range sources {
+			if _, err := s.ExpireNode(ctx, &db.ExpireNodeRequest{
+				Location:  source,
+				ExpiresAt: now,
+			}); err != nil {
+				return fmt.Errorf("Copy %s done but fail to expire source %x: %v", id, source, err)
+			}
+		}
+	}
+	log.Printf("Copy %s of %x to %x done (%d nodes, %d skipped)", id, in.Source, in.Destination, progress.Copied, progress.Skipped)
+	progress.Done = true
+	return stream.Send(progress)
+}
+
+// Node of the source with all of its children
+func (s *Server) copySource(ctx context.Context, location uint64) (*db.Node, []uint64, error) {
+	var children []uint64
+	cursor := uint64(0)
+	for {
+		node, err := s.GetNode(ctx, &db.GetNodeRequest{
+			Location:       location,
+			ChildrenLimit:  copyChildrenPage,
+			ChildrenCursor: cursor,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		children = append(children, node.Children...)
+		if node.NextChildrenCursor == 0 {
+			return node, children, nil
+		}
+		cursor = node.NextChildrenCursor
+	}
+}
+
+// Write the copy of the node on its owner and link it to the parent.
+// Returns its location and whether a previous attempt already wrote it.
+func (s *Server) copyNode(ctx context.Context, id string, node *db.Node, parent uint64) (uint64, bool, error) {
+	location := storage.DeriveLocation(node.Key, id, node.Location)
+	exists, err := s.Exists(ctx, &db.ExistsRequest{Location: location})
+	if err != nil {
+		return 0, false, err
+	}
+	dep := parent
+	if exists.Exists {
+		// Linked to the dep it was written with (AddChild is idempotent)
+		copied, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location, ChildrenLimit: 1})
+		if err != nil {
+			return 0, false, err
+		}
+		dep = copied.Dep
+	} else {
+		if s.AutoBucket && s.MaxChildren > 0 {
+			bucket, err := s.ResolveBucket(ctx, &db.ResolveBucketRequest{Location: parent})
+			if err != nil {
+				return 0, false, err
+			}
+			dep = bucket.Location
+		}
+		if err := s.distributeNodes([]*db.Node{storage.Seal(&db.Node{
+			Location:    location,
+			Dep:         dep,
+			Key:         node.Key,
+			Value:       node.Value,
+			CreatedAt:   time.Now().UnixNano(),
+			Metadata:    node.Metadata,
+			ExpiresAt:   node.ExpiresAt,
+			ContentType: node.ContentType,
+			Type:        node.Type,
+		})}); err != nil {
+			return 0, false, err
+		}
+	}
+	if _, err := s.AddChild(ctx, &db.AddChildRequest{
+		Location: dep,
+		Child:    location,
+	}); err != nil {
+		return 0, false, err
+	}
+	return location, exists.Exists, nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func parseDelta(value []byte) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(string(value)), 10, 64)
+}
+
+// Whether the local nodes of the key are counter nodes
+func isCounter(key string) bool {
+	for _, location := range store.LocationsForKey(key) {
+		if node := store.GetNode(location); node != nil {
+			return node.Type == db.NodeType_COUNTER
+		}
+	}
+	return false
+}
+
+// A key holds either counter or regular nodes, and deltas must be integers
+func checkNodeType(key string, nodeType db.NodeType, value []byte) error {
+	if nodeType == db.NodeType_COUNTER {
+		if _, err := parseDelta(value); err != nil {
+			return status.Errorf(codes.InvalidArgument, "Delta of counter %s must be a decimal integer: %v", key, err)
+		}
+	}
+	for _, location := range store.LocationsForKey(key) {
+		if node := store.GetNode(location); node != nil && node.Type != nodeType {
+			return status.Errorf(codes.FailedPrecondition, "Key %s holds %v nodes, not %v", key, node.Type, nodeType)
+		}
+	}
+	return nil
+}
+
+// Sum of the deltas of the counter nodes of the key that are ancestors of the location
+// or descend from it, so concurrent increments under the same node all count without a merge.
+// Must run on the owner of the key.
+func (s *Server) getCounter(ctx context.Context, in *db.GetRequest) (*db.GetResponse, error) {
+	// Deps of the nodes visited, local or not
+	deps := make(map[uint64]uint64)
+	depOf := func(location uint64) (uint64, error) {
+		if dep, ok := deps[location]; ok {
+			return dep, nil
+		}
+		var dep uint64
+		if node := store.GetNode(location); node != nil {
+			dep = node.Dep
+		} else {
+			node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location, ChildrenLimit: 1})
+			if err != nil {
+				return 0, err
+			}
+			dep = node.Dep
+		}
+		deps[location] = dep
+		return dep, nil
+	}
+
+	ancestors := map[uint64]bool{in.Location: true}
+	for location := in.Location; ; {
+		dep, err := depOf(location)
+		if err != nil {
+			return &db.GetResponse{}, err
+		}
+		if dep == math.MaxUint64 {
+			break
+		}
+		ancestors[dep] = true
+		location = dep
+	}
+
+	// Whether the chain of the location reaches in.Location
+	reaches := map[uint64]bool{in.Location: true}
+	descends := func(location uint64) (bool, error) {
+		var path []uint64
+		result := false
+		for {
+			if r, ok := reaches[location]; ok {
+				result = r
+				break
+			}
+			// Joins the chain of in.Location above it (another branch)
+			if ancestors[location] {
+				break
+			}
+			path = append(path, location)
+			dep, err := depOf(location)
+			if err != nil {
+				return false, err
+			}
+			if dep == math.MaxUint64 {
+				break
+			}
+			location = dep
+		}
+		for _, location := range path {
+			reaches[location] = result
+		}
+		return result, nil
+	}
+
+	now := time.Now().UnixNano()
+	if in.AsOf != 0 {
+		now = in.AsOf
+	}
+	sum := int64(0)
+	found := false
+	for _, location := range store.LocationsForKey(in.Key) {
+		node := store.GetNode(location)
+		if node == nil || node.Type != db.NodeType_COUNTER || node.Expired(now) || (in.AsOf != 0 && node.CreatedAt > in.AsOf) {
+			continue
+		}
+		counted := ancestors[location]
+		if !counted {
+			var err error
+			if counted, err = descends(location); err != nil {
+				return &db.GetResponse{}, err
+			}
+		}
+		if !counted {
+			continue
+		}
+		node, err := store.Load(node)
+		if err != nil {
+			return &db.GetResponse{}, err
+		}
+		delta, err := parseDelta(node.Value)
+		if err != nil {
+			return &db.GetResponse{}, status.Errorf(codes.DataLoss, "Invalid delta of counter %s at %x: %v", in.Key, location, err)
+		}
+		sum += delta
+		found = true
+	}
+	if !found {
+		return &db.GetResponse{}, &dberrors.KeyNotFoundError{Key: in.Key}
+	}
+	return &db.GetResponse{
+		Value:       []byte(strconv.FormatInt(sum, 10)),
+		Location:    in.Location,
+		ContentType: "text/plain",
+		Type:        db.NodeType_COUNTER,
+	}, nil
+}
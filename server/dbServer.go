@@ -1,27 +1,75 @@
 package main
 
 import (
+	"flag"
 	"log"
-	"net"
+	"os"
 
 	"github.com/DCsunset/openwhisk-grpc/db"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	lis, err := net.Listen("tcp", ":9000")
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
-	}
-
-	log.Println("Server listen at :9000")
+	verify := flag.Bool("verify", false, "audit the store before serving (exit with 2 if it is inconsistent)")
+	repair := flag.Bool("repair", false, "repair what is safely fixable when verifying")
+	verifyOnly := flag.Bool("verify-only", false, "exit after verifying (0 clean, 1 repaired, 2 unrecoverable)")
+	flag.Parse()
 
 	server := Server{}
 	server.Init()
-	grpcServer := grpc.NewServer()
+	if *verify || *repair || *verifyOnly || server.VerifyOnStart {
+		code := server.verifyStore(*repair)
+		if *verifyOnly || code == verifyUnrecoverable {
+			os.Exit(code)
+		}
+	}
+	server.startMetrics()
+	grpcServer := grpc.NewServer(server.serverOptions()...)
 	db.RegisterDbServiceServer(grpcServer, &server)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus(capacityHealthService, healthpb.HealthCheckResponse_SERVING)
+	if server.EnableReflection {
+		reflection.Register(grpcServer)
+	}
 
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	addresses, err := server.listenAddresses()
+	if err != nil {
+		log.Fatalln(err)
 	}
+
+	for _, address := range addresses {
+		lis, err := listen(address)
+		if err != nil {
+			log.Fatalf("failed to bind %s: %v", address, err)
+		}
+
+		log.Printf("Server listen at %s", address)
+
+		go func() {
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("failed to serve: %v", err)
+			}
+		}()
+	}
+
+	if err := server.checkAdvertised(); err != nil {
+		log.Fatalf("advertise mismatch: %v", err)
+	}
+	go server.pullRegistrations()
+	go server.pullPlacement()
+	go server.pullSnapshots()
+	go server.loadLoop()
+	go server.negotiateLoop()
+	go server.registerLoop()
+	go server.followLoop()
+	go server.claimLoop()
+	go server.reclaimLoop()
+	go server.resumeDecommission()
+	server.scheduleMaintenance()
+	go server.memoryLoop()
+	go server.handleReloadSignal()
+
+	select {}
 }
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Defaults of the retries of failed merges
+const defaultMaxMergeAttempts = 8
+const defaultMaxMergeRetryDelay = 5 * time.Minute
+
+// Failed merges being retried and merges given up on (dead letters).
+// Kept by the server running the merge.
+type MergeFailures struct {
+	// Attempts before a merge is dead-lettered (0 for default)
+	MaxAttempts int
+	// Cap of the exponential backoff (0 for default)
+	MaxDelay time.Duration
+
+	lock     sync.Mutex
+	failures map[uint64]*db.FailedMerge
+	// Counter for GetStats
+	DeadLettered int64
+}
+
+var mergeFailures = MergeFailures{failures: make(map[uint64]*db.FailedMerge)}
+
+func (f *MergeFailures) Init(maxAttempts int, maxDelay time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.MaxAttempts = maxAttempts
+	if f.MaxAttempts <= 0 {
+		f.MaxAttempts = defaultMaxMergeAttempts
+	}
+	f.MaxDelay = maxDelay
+	if f.MaxDelay <= 0 {
+		f.MaxDelay = defaultMaxMergeRetryDelay
+	}
+}
+
+// Record a failed attempt.
+// Returns the delay before the next one, or false if the merge is dead-lettered.
+func (f *MergeFailures) Fail(parent uint64, action string, err error) (time.Duration, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	now := time.Now().UnixNano()
+	failure, ok := f.failures[parent]
+	if !ok {
+		failure = &db.FailedMerge{Parent: parent, FirstFailure: now}
+		f.failures[parent] = failure
+	}
+	failure.Action = action
+	failure.Error = err.Error()
+	failure.LastFailure = now
+	failure.Attempts++
+	if failure.DeadLettered {
+		return 0, false
+	}
+	if int(failure.Attempts) >= f.MaxAttempts {
+		failure.DeadLettered = true
+		f.DeadLettered++
+		log.Printf("Merge of %x dead-lettered after %d attempts: %v", parent, failure.Attempts, err)
+		events.Publish(eventMergeDead, map[string]string{
+			"parent": fmt.Sprintf("%x", parent),
+			"action": action,
+		}, "Merge of %x dead-lettered after %d attempts: %v", parent, failure.Attempts, err)
+		return 0, false
+	}
+
+	delay := mergeRetryDelay << uint(failure.Attempts-1)
+	if delay > f.MaxDelay || delay <= 0 {
+		delay = f.MaxDelay
+	}
+	return delay, true
+}
+
+func (f *MergeFailures) Succeed(parent uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.failures, parent)
+}
+
+// Whether merges of the parent were given up on
+func (f *MergeFailures) Dead(parent uint64) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	failure, ok := f.failures[parent]
+	return ok && failure.DeadLettered
+}
+
+// Remove the dead letter so the merge can be attempted again
+func (f *MergeFailures) Revive(parent uint64) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	failure, ok := f.failures[parent]
+	if !ok || !failure.DeadLettered {
+		return false
+	}
+	delete(f.failures, parent)
+	return true
+}
+
+func (f *MergeFailures) DeadLetters() []*db.FailedMerge {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var merges []*db.FailedMerge
+	for _, failure := range f.failures {
+		if failure.DeadLettered {
+			merges = append(merges, failure)
+		}
+	}
+	sort.Slice(merges, func(i, j int) bool {
+		return merges[i].LastFailure < merges[j].LastFailure
+	})
+	return merges
+}
+
+// Retry the failed merge with backoff (unless it is dead-lettered)
+func retryMerge(parent uint64, err error) {
+	merge, _ := core.MergeFunction(parent)
+	delay, ok := mergeFailures.Fail(parent, merge, err)
+	if !ok {
+		return
+	}
+	convergence.Schedule(parent)
+	time.AfterFunc(delay, func() {
+		mergeQueue <- parent
+	})
+}
+
+func (s *Server) GetFailedMerges(ctx context.Context, in *db.Empty) (*db.FailedMerges, error) {
+	return &db.FailedMerges{Merges: mergeFailures.DeadLetters()}, nil
+}
+
+// Enqueue a dead-lettered merge again (all of them if Parent is 0)
+func (s *Server) RetryMerge(ctx context.Context, in *db.RetryMergeRequest) (*db.Empty, error) {
+	var parents []uint64
+	if in.Parent == 0 {
+		for _, failure := range mergeFailures.DeadLetters() {
+			parents = append(parents, failure.Parent)
+		}
+	} else {
+		parents = []uint64{in.Parent}
+	}
+
+	for _, parent := range parents {
+		if !mergeFailures.Revive(parent) {
+			return &db.Empty{}, status.Errorf(codes.NotFound, "No dead-lettered merge of %x on %s", parent, s.Self)
+		}
+		log.Printf("Merge of %x re-enqueued", parent)
+		convergence.Schedule(parent)
+		mergeQueue <- parent
+	}
+	return &db.Empty{}, nil
+}
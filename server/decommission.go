@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Progress is persisted so that a restart resumes the decommission
+const decommissionFile = "decommission.json"
+
+// Phases of a decommission
+const (
+	decommissionDraining     = "draining"
+	decommissionTransferring = "transferring"
+	decommissionCommitting   = "committing"
+	decommissionDone         = "done"
+	decommissionFailed       = "failed"
+)
+
+// Handoff of all ranges of this server to a peer
+type Decommission struct {
+	lock       sync.Mutex
+	Phase      string
+	Target     string
+	Error      string
+	Started    time.Time
+	total      int64
+	totalBytes int64
+	// Nodes sent so far
+	transfer *splitAttempt
+}
+
+var decommission = Decommission{}
+
+func (d *Decommission) running() bool {
+	return d.Phase == decommissionDraining || d.Phase == decommissionTransferring || d.Phase == decommissionCommitting
+}
+
+// Returns false if a decommission is already running.
+// A retry without target keeps the target of the failed attempt.
+func (d *Decommission) Start(target string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.running() {
+		return false
+	}
+	if len(target) > 0 {
+		d.Target = target
+	}
+	d.Phase, d.Error, d.Started = decommissionDraining, "", time.Now()
+	d.total, d.totalBytes, d.transfer = 0, 0, nil
+	d.save()
+	return true
+}
+
+func (d *Decommission) GetTarget() string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.Target
+}
+
+func (d *Decommission) SetPhase(phase string, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.Phase = phase
+	if err != nil {
+		d.Error = err.Error()
+	}
+	d.save()
+}
+
+func (d *Decommission) SetTarget(target string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.Target = target
+	d.save()
+}
+
+func (d *Decommission) SetTotal(nodes, bytes int64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.total, d.totalBytes = nodes, bytes
+}
+
+func (d *Decommission) SetTransfer(transfer *splitAttempt) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.transfer = transfer
+}
+
+// Must be called with the lock held
+func (d *Decommission) save() {
+	data, _ := json.Marshal(d)
+	if err := ioutil.WriteFile(statePath(decommissionFile), data, 0644); err != nil {
+		log.Printf("Fail to save decommission: %v", err)
+	}
+}
+
+func (d *Decommission) ToProto() *db.DecommissionStatus {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	status := &db.DecommissionStatus{
+		Phase:      d.Phase,
+		Target:     d.Target,
+		TotalNodes: d.total,
+		TotalBytes: d.totalBytes,
+		Error:      d.Error,
+	}
+	if !d.Started.IsZero() {
+		status.Started = d.Started.UnixNano()
+	}
+	if d.transfer != nil {
+		status.NodesMoved, status.BytesMoved = splitAttempts.Progress(d.transfer)
+	}
+	if d.Phase == decommissionTransferring && status.NodesMoved > 0 {
+		elapsed := time.Since(d.transfer.started)
+		status.EstimatedMillis = (elapsed * time.Duration(d.total-status.NodesMoved) / time.Duration(status.NodesMoved)).Milliseconds()
+	}
+	return status
+}
+
+// Resume a decommission interrupted by a restart
+func (s *Server) resumeDecommission() {
+	data, err := ioutil.ReadFile(statePath(decommissionFile))
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var saved Decommission
+	if err := json.Unmarshal(data, &saved); err != nil {
+		log.Fatalln(err)
+	}
+	decommission.Phase = saved.Phase
+	decommission.Target = saved.Target
+	decommission.Error = saved.Error
+	decommission.Started = saved.Started
+	if !saved.running() {
+		return
+	}
+	log.Printf("Resuming decommission to %s from phase %s", saved.Target, saved.Phase)
+	s.runDecommission()
+}
+
+// Hand all ranges of the server to a peer and leave it empty (see GetDecommissionStatus)
+func (s *Server) Decommission(ctx context.Context, in *db.DecommissionRequest) (*db.DecommissionStatus, error) {
+	if len(in.Server) > 0 && in.Server != s.Self {
+		client, err := pool.Get(in.Server)
+		if err != nil {
+			return &db.DecommissionStatus{}, err
+		}
+		return client.Decommission(ctx, in)
+	}
+	if s.isSeed() {
+		return &db.DecommissionStatus{}, status.Errorf(codes.FailedPrecondition, "Seed %s keeps the membership and cannot be decommissioned", s.Self)
+	}
+	if in.Target == s.Self {
+		return &db.DecommissionStatus{}, status.Errorf(codes.InvalidArgument, "Server %s cannot absorb its own ranges", s.Self)
+	}
+	if decommission.Start(in.Target) {
+		go s.runDecommission()
+	}
+	return decommission.ToProto(), nil
+}
+
+func (s *Server) GetDecommissionStatus(ctx context.Context, in *db.Empty) (*db.DecommissionStatus, error) {
+	return decommission.ToProto(), nil
+}
+
+func (s *Server) runDecommission() {
+	target := decommission.GetTarget()
+	err := s.decommission(target)
+	if err != nil {
+		log.Printf("Decommission failed: %v", err)
+		decommission.SetPhase(decommissionFailed, err)
+		events.Publish(eventDecommissionFailed, map[string]string{"target": decommission.GetTarget()}, "Decommission failed: %v", err)
+		return
+	}
+	decommission.SetPhase(decommissionDone, nil)
+	log.Printf("Decommission done: %s is empty and can be removed", s.Self)
+	events.Publish(eventDecommissioned, map[string]string{"target": decommission.GetTarget()}, "Decommission done: %s is empty and can be removed", s.Self)
+}
+
+func (s *Server) decommission(target string) error {
+	ctx := context.Background()
+	if s.getMode() != db.Mode_DRAINING {
+		if _, err := s.SetMode(ctx, &db.SetModeRequest{Mode: db.Mode_DRAINING}); err != nil {
+			return err
+		}
+	}
+
+	// Writes started before the mode changed are done once the lock is taken
+	// and later ones are rejected, so the nodes no longer change
+	s.lock.Lock()
+	// Nodes of ranges committed by an interrupted attempt
+	if len(target) > 0 {
+		s.removeMoved(target)
+	}
+	ranges := s.ownedRanges()
+	if len(target) == 0 {
+		target = s.pickAbsorber()
+	}
+	servers := append([]string(nil), s.Servers...)
+	s.lock.Unlock()
+	if len(ranges) == 0 {
+		return nil
+	}
+	if len(target) == 0 {
+		return status.Errorf(codes.FailedPrecondition, "No peer owns a range to absorb the ranges of %s", s.Self)
+	} else if !indexingService.Owns(target) {
+		return status.Errorf(codes.InvalidArgument, "Server %s owns no range", target)
+	}
+	decommission.SetTarget(target)
+
+	client, err := pool.Get(target)
+	if err != nil {
+		return err
+	}
+	level, err := s.negotiateProtocol(ctx, append([]string{target}, servers...))
+	if err != nil {
+		return err
+	}
+	if level < protocolAbsorb {
+		return status.Errorf(codes.FailedPrecondition, "Some servers speak split protocol level %d, older than %d needed to absorb ranges", level, protocolAbsorb)
+	}
+	held, epoch, err := s.lockServersOf(ctx, servers, target)
+	if err != nil {
+		return err
+	}
+	defer s.unlockServers(ctx, held)
+
+	var nodes []*db.Node
+	var bytes int64
+	for _, r := range ranges {
+		var loadErr error
+		err := store.IterateHashRange(r.Left, r.Right, func(node *storage.Node) bool {
+			// Spilled values are transferred inline
+			node, loadErr = store.Load(node)
+			if loadErr != nil {
+				return false
+			}
+			nodes = append(nodes, node.ToProto())
+			bytes += int64(len(node.Value))
+			return true
+		})
+		if err == nil {
+			err = loadErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	decommission.SetTotal(int64(len(nodes)), bytes)
+	decommission.SetPhase(decommissionTransferring, nil)
+
+	// Batched like a split, but not listed with the split attempts
+	transfer := &splitAttempt{target: target, phase: phaseTransferring, started: time.Now()}
+	decommission.SetTransfer(transfer)
+	// Removes the nodes sent before a failure, since the ranges are not updated yet
+	abort := func(err error) error {
+		if cleanUpErr := s.cleanUpTarget(ctx, transfer); cleanUpErr != nil {
+			log.Printf("Fail to remove the nodes transferred to %s: %v", target, cleanUpErr)
+		}
+		return err
+	}
+	if _, err := s.sendNodes(ctx, client, transfer, nodes, "", held); err != nil {
+		return abort(fmt.Errorf("Transfer to %s failed: %v", target, err))
+	}
+	if err := s.transferMergeFunctions(ctx, client, nodes); err != nil {
+		return abort(fmt.Errorf("Transfer of merge functions to %s failed: %v", target, err))
+	}
+	for _, r := range ranges {
+		if err := s.transferScopedMergeFunctions(ctx, client, r.Left, r.Right); err != nil {
+			return abort(fmt.Errorf("Transfer of scoped merge functions to %s failed: %v", target, err))
+		}
+	}
+	// The commit is rejected by servers whose lease expired
+	if err := s.renewLocks(ctx, held, target); err != nil {
+		return abort(err)
+	}
+
+	decommission.SetPhase(decommissionCommitting, nil)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, r := range ranges {
+		epoch += 1
+		request := &db.SplitRequest{
+			Left:        r.Left,
+			Right:       r.Right,
+			Mid:         r.Right,
+			LeftServer:  target,
+			RightServer: target,
+			Epoch:       epoch,
+			Holder:      s.Self,
+		}
+		for _, addr := range servers {
+			if addr == s.Self {
+				err = s.splitLocally(request)
+			} else {
+				var peer db.DbServiceClient
+				peer, err = pool.Get(addr)
+				if err == nil {
+					_, err = peer.Split(ctx, request)
+				}
+			}
+			if err != nil {
+				// Retrying removes the nodes of the ranges already committed
+				return fmt.Errorf("Fail to update the mapping of %s: %v", addr, err)
+			}
+		}
+	}
+
+	for _, r := range ranges {
+		if err := s.dropMergeFunctions(nodes, r.Left, r.Right); err != nil {
+			log.Println(err)
+		}
+	}
+	locations := make([]uint64, len(nodes))
+	for i, node := range nodes {
+		locations[i] = node.Location
+	}
+	store.RemoveNodes(locations)
+	return nil
+}
+
+// Peer owning a range with the lowest load
+func (s *Server) pickAbsorber() string {
+	var candidates []string
+	for _, addr := range s.Servers {
+		if addr != s.Self && indexingService.Owns(addr) {
+			candidates = append(candidates, addr)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	if target := s.leastLoaded(candidates); len(target) > 0 {
+		return target
+	}
+	return candidates[0]
+}
+
+// Remove local nodes of ranges owned by the target.
+// Must be called with the server lock held.
+func (s *Server) removeMoved(target string) {
+	var locations []uint64
+	store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		if indexingService.ResolveOwner(node.Location) == target {
+			locations = append(locations, node.Location)
+		}
+		return true
+	})
+	store.RemoveNodes(locations)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+)
+
+// Schema of the service for clients without reflection
+func (s *Server) DescribeService(ctx context.Context, in *db.Empty) (*db.ServiceDescription, error) {
+	file := protodesc.ToFileDescriptorProto(db.File_db_proto)
+	data, err := proto.Marshal(file)
+	if err != nil {
+		return &db.ServiceDescription{}, err
+	}
+
+	var methods []string
+	services := db.File_db_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		service := services.Get(i)
+		for j := 0; j < service.Methods().Len(); j++ {
+			methods = append(methods, string(service.Methods().Get(j).FullName()))
+		}
+	}
+	return &db.ServiceDescription{
+		FileDescriptor: data,
+		Methods:        methods,
+	}, nil
+}
+
+// Build of the server, set with -ldflags "-X main.build=..."
+var build = "dev"
+
+// API version and features, checked by Client.Handshake
+func (s *Server) GetVersion(ctx context.Context, in *db.Empty) (*db.VersionResponse, error) {
+	return &db.VersionResponse{
+		ApiVersion:    db.APIVersion,
+		MinApiVersion: db.MinAPIVersion,
+		Build:         build,
+		GoVersion:     runtime.Version(),
+		Features:      db.Features,
+		ProtocolLevel: s.protocolLevel(),
+	}, nil
+}
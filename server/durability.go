@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Values of durability in server.json
+var durabilityLevels = map[string]db.Durability{
+	"memory":     db.Durability_ACK_MEMORY,
+	"wal":        db.Durability_ACK_WAL,
+	"replicated": db.Durability_ACK_REPLICATED,
+}
+
+// Writes acknowledged at a lower level than requested (accessed atomically)
+var degradedWrites int64
+
+// Level requested by the write (the default of the server if unset)
+func (s *Server) durability(requested db.Durability) db.Durability {
+	if requested != db.Durability_DURABILITY_DEFAULT {
+		return requested
+	}
+	if level, ok := durabilityLevels[s.Durability]; ok {
+		return level
+	}
+	return db.Durability_ACK_MEMORY
+}
+
+// Make local writes durable at the level, or at the highest level available below it.
+// Returns the level reached.
+func (s *Server) persist(level db.Durability) (db.Durability, error) {
+	reached := db.Durability_ACK_MEMORY
+	if level >= db.Durability_ACK_WAL {
+		// Nodes are not replicated, so the log is the most durable copy
+		synced, err := store.Sync()
+		if err != nil {
+			return reached, fmt.Errorf("Fail to flush the log: %v", err)
+		}
+		if synced {
+			reached = db.Durability_ACK_WAL
+		}
+	}
+	if reached < level {
+		atomic.AddInt64(&degradedWrites, 1)
+	}
+	return reached, nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Types of cluster events
+const (
+	eventSplitStarted        = "split.started"
+	eventSplitCommitted      = "split.committed"
+	eventSplitAborted        = "split.aborted"
+	eventSplitApplied        = "split.applied"
+	eventMergeCompleted      = "merge.completed"
+	eventMergeFailed         = "merge.failed"
+	eventMergeDead           = "merge.dead_lettered"
+	eventRegistered          = "membership.registered"
+	eventModeChanged         = "mode.changed"
+	eventCompacted           = "compaction"
+	eventMemoryPressure      = "memory.pressure"
+	eventMemoryRelieved      = "memory.relieved"
+	eventConfigReloaded      = "config.reloaded"
+	eventPlacementUpdate     = "placement.updated"
+	eventSnapshotCreated     = "snapshot.created"
+	eventSnapshotDeleted     = "snapshot.deleted"
+	eventDecommissioned      = "decommission.done"
+	eventDecommissionFailed  = "decommission.failed"
+	eventProtocolNegotiated  = "protocol.negotiated"
+	eventMaintenanceStarted  = "maintenance.started"
+	eventMaintenanceFinished = "maintenance.finished"
+	eventMaintenancePaused   = "maintenance.paused"
+	eventCapacityLow         = "capacity.low"
+	eventCapacityExhausted   = "capacity.exhausted"
+	eventCapacityRestored    = "capacity.restored"
+	eventValueRedacted       = "value.redacted"
+	eventStandbyLagging      = "standby.lagging"
+	eventStandbyCaughtUp     = "standby.caught_up"
+	eventStandbyPromoted     = "standby.promoted"
+)
+
+// Severity of events needing an operator
+const severityCritical = "critical"
+
+// Past events replayed to new watchers
+const eventReplaySize = 256
+
+// Events buffered per watcher before it is dropped
+const eventWatcherBuffer = 64
+
+// Publishes significant transitions of this server to watchers
+type EventBus struct {
+	// Server reported as the origin
+	Server string
+
+	lock     sync.Mutex
+	sequence uint64
+	recent   []*db.Event
+	watchers map[chan *db.Event]bool
+	counts   map[string]int64
+}
+
+var events = EventBus{
+	watchers: make(map[chan *db.Event]bool),
+	counts:   make(map[string]int64),
+}
+
+func (b *EventBus) Publish(eventType string, attributes map[string]string, format string, args ...interface{}) {
+	b.publish("", eventType, attributes, fmt.Sprintf(format, args...))
+}
+
+// Publish an event needing an operator
+func (b *EventBus) Alert(eventType string, attributes map[string]string, format string, args ...interface{}) {
+	b.publish(severityCritical, eventType, attributes, fmt.Sprintf(format, args...))
+}
+
+func (b *EventBus) publish(severity string, eventType string, attributes map[string]string, message string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.sequence += 1
+	event := &db.Event{
+		Sequence:   b.sequence,
+		Time:       time.Now().UnixNano(),
+		Server:     b.Server,
+		Type:       eventType,
+		Message:    message,
+		Attributes: attributes,
+		Severity:   severity,
+	}
+	b.counts[eventType] += 1
+	b.recent = append(b.recent, event)
+	if len(b.recent) > eventReplaySize {
+		b.recent = b.recent[len(b.recent)-eventReplaySize:]
+	}
+	for watcher := range b.watchers {
+		select {
+		case watcher <- event:
+		default:
+			// Too slow: the stream is ended
+			delete(b.watchers, watcher)
+			close(watcher)
+		}
+	}
+}
+
+// Last replay events and a channel of the next ones
+func (b *EventBus) Watch(replay int) ([]*db.Event, chan *db.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if replay > len(b.recent) {
+		replay = len(b.recent)
+	}
+	past := append([]*db.Event(nil), b.recent[len(b.recent)-replay:]...)
+	watcher := make(chan *db.Event, eventWatcherBuffer)
+	b.watchers[watcher] = true
+	return past, watcher
+}
+
+func (b *EventBus) Unwatch(watcher chan *db.Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.watchers[watcher] {
+		delete(b.watchers, watcher)
+		close(watcher)
+	}
+}
+
+// Number of events published by type
+func (b *EventBus) Counts() map[string]int64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	counts := make(map[string]int64)
+	for t, c := range b.counts {
+		counts[t] = c
+	}
+	return counts
+}
+
+func (s *Server) WatchEvents(in *db.WatchEventsRequest, stream db.DbService_WatchEventsServer) error {
+	types := make(map[string]bool)
+	for _, t := range in.Types {
+		types[t] = true
+	}
+	send := func(event *db.Event) error {
+		if len(types) > 0 && !types[event.Type] {
+			return nil
+		}
+		return stream.Send(event)
+	}
+
+	past, watcher := events.Watch(int(in.Replay))
+	defer events.Unwatch(watcher)
+	for _, event := range past {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	if in.NoFollow {
+		return nil
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher:
+			if !ok {
+				return status.Errorf(codes.ResourceExhausted, "Watcher fell behind the events of %s", s.Self)
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
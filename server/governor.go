@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Failed splits older than this no longer widen the interval
+const splitFailureWindow = 10 * time.Minute
+
+// Widening of the interval when available servers are at or below the reserve
+const lowCapacityFactor = 4
+
+// Spacing of automatic splits, so that a server under a write burst
+// does not split again as soon as it refills
+type SplitGovernor struct {
+	// Time between two splits (0 disables the spacing)
+	MinInterval time.Duration
+	// Bound of the interval widened after failures
+	MaxInterval time.Duration
+	// Available servers kept in reserve: at or below it the interval widens
+	Reserve int
+
+	lock     sync.Mutex
+	last     time.Time
+	failures []time.Time
+	low      bool
+	reason   string
+	deferred int64
+}
+
+var splitGovernor = SplitGovernor{}
+
+// Interval before the next split, doubled for each recent failure
+// and widened when few servers are left.
+// Must be called with the lock held.
+func (g *SplitGovernor) interval(available int) time.Duration {
+	cutoff := time.Now().Add(-splitFailureWindow)
+	recent := g.failures[:0]
+	for _, failure := range g.failures {
+		if failure.After(cutoff) {
+			recent = append(recent, failure)
+		}
+	}
+	g.failures = recent
+
+	interval := g.MinInterval
+	for range g.failures {
+		if interval >= g.MaxInterval {
+			break
+		}
+		interval *= 2
+	}
+	if available <= g.Reserve {
+		interval *= lowCapacityFactor
+	}
+	if g.MaxInterval > 0 && interval > g.MaxInterval {
+		interval = g.MaxInterval
+	}
+	return interval
+}
+
+// Whether an automatic split may start now.
+// A split must wait for the migration of the previous one to finish
+// (including the cleanup of a failed target) and for the interval.
+func (g *SplitGovernor) Allow(available int) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.checkCapacity(available)
+	reason := ""
+	if migrating := unfinishedSplits(); migrating > 0 {
+		reason = strconv.Itoa(migrating) + " split migrations not finished"
+	} else if wait := time.Until(g.last.Add(g.interval(available))); !g.last.IsZero() && wait > 0 {
+		reason = "next split in " + wait.Round(time.Millisecond).String()
+	}
+	if len(reason) == 0 {
+		return true
+	}
+	g.reason = reason
+	g.deferred += 1
+	return false
+}
+
+// Record the end of a split started after Allow
+func (g *SplitGovernor) Done(err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.last = time.Now()
+	if err != nil {
+		g.failures = append(g.failures, g.last)
+	}
+}
+
+// Alert once when available servers drop to the reserve.
+// Must be called with the lock held.
+func (g *SplitGovernor) checkCapacity(available int) {
+	low := available <= g.Reserve
+	if low && !g.low {
+		events.Publish(eventCapacityLow, map[string]string{"available": strconv.Itoa(available)},
+			"%d available servers left (reserve %d): splits are spaced out", available, g.Reserve)
+	}
+	g.low = low
+}
+
+// Attempts whose migration is in progress or whose target is not cleaned up
+func unfinishedSplits() int {
+	count := 0
+	for _, phase := range []string{phaseLocking, phaseTransferring, phaseCommitting, phaseVerifying, phaseFailed} {
+		count += len(splitAttempts.InPhase(phase))
+	}
+	return count
+}
+
+func (g *SplitGovernor) ToProto(available int) *db.SplitGovernance {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	interval := g.interval(available)
+	governance := &db.SplitGovernance{
+		MinIntervalMillis: g.MinInterval.Milliseconds(),
+		IntervalMillis:    interval.Milliseconds(),
+		RecentFailures:    int32(len(g.failures)),
+		CapacityLow:       g.low,
+		Deferred:          g.deferred,
+		DeferReason:       g.reason,
+	}
+	if !g.last.IsZero() {
+		governance.LastSplitAt = g.last.UnixNano()
+		governance.NextSplitAt = g.last.Add(interval).UnixNano()
+	}
+	return governance
+}
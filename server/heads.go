@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Read the key from several branch heads (e.g. children not merged yet).
+// The value is returned if all heads agree on it;
+// otherwise Conflict is set and the values of the heads are listed.
+func (s *Server) GetMerged(ctx context.Context, in *db.GetMergedRequest) (*db.GetMergedResponse, error) {
+	heads := in.Locations
+	if len(heads) == 0 {
+		parent, err := s.GetNode(ctx, &db.GetNodeRequest{Location: in.Parent})
+		if err != nil {
+			return &db.GetMergedResponse{}, err
+		}
+		heads = parent.Children
+		if len(heads) == 0 {
+			heads = []uint64{in.Parent}
+		}
+	}
+
+	resp := &db.GetMergedResponse{}
+	found := 0
+	for _, head := range heads {
+		value := &db.HeadValue{Head: head}
+		result, err := s.Get(ctx, &db.GetRequest{Key: in.Key, Location: head, MaxRemoteHops: in.MaxRemoteHops})
+		if err != nil && !errors.Is(dberrors.FromStatus(err), dberrors.ErrKeyNotFound) {
+			return &db.GetMergedResponse{}, err
+		}
+		if result != nil && result.DeadlineExceeded {
+			return &db.GetMergedResponse{}, status.Errorf(codes.DeadlineExceeded, "Walk for %s from %x stopped at %x", in.Key, head, result.ResumeFrom)
+		}
+		if err == nil {
+			value.Found = true
+			value.Location = result.Location
+			value.Value = result.Value
+			found += 1
+		}
+		resp.Heads = append(resp.Heads, value)
+	}
+	if found == 0 {
+		return &db.GetMergedResponse{}, &dberrors.KeyNotFoundError{Key: in.Key}
+	}
+
+	first := resp.Heads[0]
+	sameNode := true
+	for _, head := range resp.Heads[1:] {
+		if head.Found != first.Found || !bytes.Equal(head.Value, first.Value) {
+			resp.Conflict = true
+			return resp, nil
+		}
+		sameNode = sameNode && head.Location == first.Location
+	}
+	resp.Value = first.Value
+	if sameNode {
+		resp.Location = first.Location
+	}
+	return resp, nil
+}
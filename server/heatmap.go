@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/indexing"
+	"github.com/DCsunset/openwhisk-grpc/placement"
+	"google.golang.org/grpc"
+)
+
+// Buckets of equal width over the hash space
+const heatmapBuckets = 256
+
+// Key hashes per bucket
+const heatmapWidth = (1 << 32) / heatmapBuckets
+
+// Methods counted in the heatmap (true for writes)
+var heatmapMethods = map[string]bool{
+	"/db.DbService/Get":      false,
+	"/db.DbService/Set":      true,
+	"/db.DbService/GetOrSet": true,
+	"/db.DbService/Apply":    true,
+}
+
+// Reads, writes and latency of the keys owned by this server by hash bucket
+type Heatmap struct {
+	// Accessed atomically
+	reads   [heatmapBuckets]int64
+	writes  [heatmapBuckets]int64
+	latency [heatmapBuckets]int64
+	since   int64
+}
+
+var heatmap = Heatmap{since: time.Now().UnixNano()}
+
+func (h *Heatmap) Record(hash uint32, write bool, duration time.Duration) {
+	bucket := hash / heatmapWidth
+	if write {
+		atomic.AddInt64(&h.writes[bucket], 1)
+	} else {
+		atomic.AddInt64(&h.reads[bucket], 1)
+	}
+	atomic.AddInt64(&h.latency[bucket], duration.Microseconds())
+}
+
+func (h *Heatmap) Reset() {
+	for i := 0; i < heatmapBuckets; i++ {
+		atomic.StoreInt64(&h.reads[i], 0)
+		atomic.StoreInt64(&h.writes[i], 0)
+		atomic.StoreInt64(&h.latency[i], 0)
+	}
+	atomic.StoreInt64(&h.since, time.Now().UnixNano())
+}
+
+func (h *Heatmap) ToProto() *db.Heatmap {
+	heatmap := &db.Heatmap{Since: atomic.LoadInt64(&h.since)}
+	for i := 0; i < heatmapBuckets; i++ {
+		heatmap.Buckets = append(heatmap.Buckets, &db.HeatmapBucket{
+			Left:          uint32(i * heatmapWidth),
+			Right:         uint32(i*heatmapWidth + heatmapWidth - 1),
+			Reads:         atomic.LoadInt64(&h.reads[i]),
+			Writes:        atomic.LoadInt64(&h.writes[i]),
+			LatencyMicros: atomic.LoadInt64(&h.latency[i]),
+		})
+	}
+	return heatmap
+}
+
+// Bucket boundary splitting the accesses of [left, right] in half
+// (false if no bucket boundary inside the range has accesses on both sides)
+func (h *Heatmap) Median(left, right uint32) (uint32, bool) {
+	return indexing.WeightedMedian(left, right, heatmapWidth, func(i uint32) int64 {
+		return atomic.LoadInt64(&h.reads[i]) + atomic.LoadInt64(&h.writes[i])
+	})
+}
+
+// Count requests for keys owned by this server
+func (s *Server) heatmapInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	write, ok := heatmapMethods[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	// Continuations of walks are counted by the server receiving the Get
+	if r, ok := req.(interface{ GetContinuation() bool }); ok && r.GetContinuation() {
+		return resp, err
+	}
+	// Keys are normalized by the handler
+	if r, ok := req.(interface{ GetKey() string }); ok && len(r.GetKey()) > 0 {
+		hash := placement.Hash(r.GetKey())
+		if indexingService.Locate(hash) == s.Self {
+			heatmap.Record(hash, write, time.Since(start))
+		}
+	}
+	return resp, err
+}
+
+// Heatmap of the cluster with the ranges of the servers
+func (s *Server) GetHeatmap(ctx context.Context, in *db.GetHeatmapRequest) (*db.Heatmap, error) {
+	if in.Local {
+		result := heatmap.ToProto()
+		if in.Clear {
+			heatmap.Reset()
+		}
+		return result, nil
+	}
+
+	s.lock.RLock()
+	servers := append([]string(nil), s.Servers...)
+	s.lock.RUnlock()
+	if len(servers) == 0 {
+		servers = []string{s.Self}
+	}
+	heatmaps := make([]*db.Heatmap, len(servers))
+	errs := make([]error, len(servers))
+	var wg sync.WaitGroup
+	for i, addr := range servers {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			request := &db.GetHeatmapRequest{Local: true, Clear: in.Clear}
+			if addr == s.Self {
+				heatmaps[i], errs[i] = s.GetHeatmap(ctx, request)
+				return
+			}
+			client, err := pool.Get(addr)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			heatmaps[i], errs[i] = client.GetHeatmap(ctx, request)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	total := heatmap.ToProto()
+	for _, bucket := range total.Buckets {
+		bucket.Reads, bucket.Writes, bucket.LatencyMicros = 0, 0, 0
+	}
+	for i, h := range heatmaps {
+		if errs[i] != nil {
+			return &db.Heatmap{}, errs[i]
+		}
+		for j, bucket := range h.Buckets {
+			if j >= len(total.Buckets) {
+				break
+			}
+			total.Buckets[j].Reads += bucket.Reads
+			total.Buckets[j].Writes += bucket.Writes
+			total.Buckets[j].LatencyMicros += bucket.LatencyMicros
+		}
+		if h.Since < total.Since {
+			total.Since = h.Since
+		}
+	}
+	mapping, _ := s.GetMapping(ctx, &db.Empty{})
+	total.Ranges = mapping.Ranges
+	return total, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Remote hops of chain walks started on this server
+type HopStats struct {
+	lock   sync.Mutex
+	counts map[int32]int64
+	// Walks stopped by their budget on this server (accessed atomically)
+	Exceeded int64
+}
+
+var hopStats = HopStats{counts: make(map[int32]int64)}
+
+func (h *HopStats) Record(hops int32) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.counts[hops] += 1
+}
+
+func (h *HopStats) ToProto() map[int32]int64 {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	counts := make(map[int32]int64, len(h.counts))
+	for hops, count := range h.counts {
+		counts[hops] = count
+	}
+	return counts
+}
+
+// Budget of a walk (negative for no limit)
+func (s *Server) hopBudget(in *db.GetRequest) int32 {
+	if in.MaxRemoteHops != 0 {
+		return in.MaxRemoteHops
+	}
+	return int32(s.MaxRemoteHops)
+}
+
+// Request continuing the walk of in at next on another server,
+// or HopBudgetExceededError if the walk is out of remote hops
+func (s *Server) continueWalk(in *db.GetRequest, next uint64) (*db.GetRequest, error) {
+	budget := s.hopBudget(in)
+	path := append(append([]*db.WalkStep(nil), in.Path...), &db.WalkStep{
+		Location: in.Location,
+		Server:   s.Self,
+	})
+	if budget >= 0 && len(path) > int(budget) {
+		atomic.AddInt64(&hopStats.Exceeded, 1)
+		return nil, &dberrors.HopBudgetExceededError{
+			Key:    in.Key,
+			Budget: budget,
+			Path:   path,
+			Next:   next,
+		}
+	}
+	return &db.GetRequest{
+		Key:           in.Key,
+		Location:      next,
+		Continuation:  true,
+		AsOf:          in.AsOf,
+		MaxRemoteHops: budget,
+		Path:          path,
+		MetadataOnly:  in.MetadataOnly,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Counts are halved at this interval so old accesses fade out
+const accessHalfLife = 5 * time.Minute
+
+// Keys and locations tracked at most (new ones are ignored until counts decay)
+const maxAccessEntries = 4096
+
+type keyAccess struct {
+	reads  float64
+	writes float64
+	// Sum of the walk depths of the reads
+	depth float64
+	node  uint64
+}
+
+// Sampled reads and writes by key, start location and defining node
+type AccessTracker struct {
+	// Accessed atomically
+	every   uint64
+	counter uint64
+
+	lock     sync.Mutex
+	keys     map[string]*keyAccess
+	branches map[uint64]float64
+	nodes    map[uint64]float64
+	decayed  time.Time
+}
+
+var access = AccessTracker{
+	keys:     make(map[string]*keyAccess),
+	branches: make(map[uint64]float64),
+	nodes:    make(map[uint64]float64),
+	decayed:  time.Now(),
+}
+
+// Sample one in every accesses (0 disables sampling)
+func (t *AccessTracker) SetRate(every int) {
+	if every < 0 {
+		every = 0
+	}
+	atomic.StoreUint64(&t.every, uint64(every))
+}
+
+// Weight of the access if it is sampled (0 if not)
+func (t *AccessTracker) sample() float64 {
+	every := atomic.LoadUint64(&t.every)
+	if every == 0 || atomic.AddUint64(&t.counter, 1)%every != 0 {
+		return 0
+	}
+	return float64(every)
+}
+
+// Must be called with the lock held
+func (t *AccessTracker) decay() {
+	for time.Since(t.decayed) >= accessHalfLife {
+		t.decayed = t.decayed.Add(accessHalfLife)
+		for key, a := range t.keys {
+			a.reads, a.writes, a.depth = a.reads/2, a.writes/2, a.depth/2
+			if a.reads+a.writes < 1 {
+				delete(t.keys, key)
+			}
+		}
+		for _, counts := range []map[uint64]float64{t.branches, t.nodes} {
+			for location, count := range counts {
+				if count/2 < 1 {
+					delete(counts, location)
+				} else {
+					counts[location] = count / 2
+				}
+			}
+		}
+	}
+}
+
+// Must be called with the lock held (nil if too many keys are tracked)
+func (t *AccessTracker) key(key string) *keyAccess {
+	a, ok := t.keys[key]
+	if !ok && len(t.keys) < maxAccessEntries {
+		a = &keyAccess{}
+		t.keys[key] = a
+	}
+	return a
+}
+
+func addCount(counts map[uint64]float64, location uint64, weight float64) {
+	if _, ok := counts[location]; ok || len(counts) < maxAccessEntries {
+		counts[location] += weight
+	}
+}
+
+// A read from the location found the key at the node after visiting depth nodes
+func (t *AccessTracker) RecordRead(key string, from uint64, node uint64, depth int) {
+	weight := t.sample()
+	if weight == 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.decay()
+	if a := t.key(key); a != nil {
+		a.reads += weight
+		a.depth += weight * float64(depth)
+		a.node = node
+	}
+	addCount(t.branches, from, weight)
+	addCount(t.nodes, node, weight)
+}
+
+func (t *AccessTracker) RecordWrite(key string) {
+	weight := t.sample()
+	if weight == 0 {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.decay()
+	if a := t.key(key); a != nil {
+		a.writes += weight
+	}
+}
+
+func topLocations(counts map[uint64]float64, limit int) []*db.HotBranch {
+	var result []*db.HotBranch
+	for location, reads := range counts {
+		result = append(result, &db.HotBranch{Location: location, Reads: reads})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Reads > result[j].Reads })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// Most read keys, start locations and defining nodes
+func (t *AccessTracker) Top(limit int) *db.HotPathsResponse {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.decay()
+	resp := &db.HotPathsResponse{
+		SampleEvery:     int64(atomic.LoadUint64(&t.every)),
+		HalfLifeSeconds: int64(accessHalfLife / time.Second),
+		Branches:        topLocations(t.branches, limit),
+		Nodes:           topLocations(t.nodes, limit),
+	}
+	for key, a := range t.keys {
+		hot := &db.HotKey{Key: key, Reads: a.reads, Writes: a.writes, Node: a.node}
+		if a.reads > 0 {
+			hot.AverageDepth = a.depth / a.reads
+		}
+		resp.Keys = append(resp.Keys, hot)
+	}
+	sort.Slice(resp.Keys, func(i, j int) bool { return resp.Keys[i].Reads > resp.Keys[j].Reads })
+	if len(resp.Keys) > limit {
+		resp.Keys = resp.Keys[:limit]
+	}
+	return resp
+}
+
+// Most read keys and branches of this server
+func (s *Server) HotPaths(ctx context.Context, in *db.HotPathsRequest) (*db.HotPathsResponse, error) {
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = 10
+	}
+	resp := access.Top(limit)
+	for _, key := range resp.Keys {
+		key.Server = indexingService.LocateKey(key.Key)
+	}
+	for _, branch := range append(resp.Branches, resp.Nodes...) {
+		branch.Server = indexingService.ResolveOwner(branch.Location)
+	}
+	return resp, nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Last mutating requests received by this server, including failed and rejected ones.
+// Unlike the log of the disk backend it is only kept in memory.
+type RequestJournal struct {
+	lock    sync.Mutex
+	size    int
+	entries []*db.JournalEntry
+	// Entries kept per key and requests of the key not kept since its last entry
+	perKey  map[string]int
+	pending map[string]int64
+	// Beyond maxPerKey entries of a key, one request in sampleEvery is kept
+	maxPerKey   int
+	sampleEvery int
+}
+
+var journal = RequestJournal{
+	perKey:  make(map[string]int),
+	pending: make(map[string]int64),
+}
+
+// Size 0 disables the journal
+func (j *RequestJournal) Configure(size int, maxPerKey int, sampleEvery int) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if size < 0 {
+		size = 0
+	}
+	j.size = size
+	j.maxPerKey = maxPerKey
+	j.sampleEvery = sampleEvery
+	j.trim()
+}
+
+func (j *RequestJournal) Enabled() bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.size > 0
+}
+
+func (j *RequestJournal) Add(entry *db.JournalEntry) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.size == 0 {
+		return
+	}
+	if len(entry.Key) > 0 {
+		if j.maxPerKey > 0 && j.perKey[entry.Key] >= j.maxPerKey {
+			j.pending[entry.Key] += 1
+			if j.sampleEvery <= 1 || j.pending[entry.Key] < int64(j.sampleEvery) {
+				return
+			}
+			j.pending[entry.Key] -= 1
+		}
+		entry.Skipped = j.pending[entry.Key]
+		delete(j.pending, entry.Key)
+		j.perKey[entry.Key] += 1
+	}
+	j.entries = append(j.entries, entry)
+	j.trim()
+}
+
+// Drop the oldest entries beyond the size.
+// Must be called with the lock held.
+func (j *RequestJournal) trim() {
+	extra := len(j.entries) - j.size
+	if extra <= 0 {
+		return
+	}
+	for _, entry := range j.entries[:extra] {
+		if len(entry.Key) == 0 {
+			continue
+		}
+		j.perKey[entry.Key] -= 1
+		if j.perKey[entry.Key] <= 0 {
+			delete(j.perKey, entry.Key)
+		}
+	}
+	j.entries = append([]*db.JournalEntry(nil), j.entries[extra:]...)
+}
+
+func (j *RequestJournal) Query(in *db.GetJournalRequest) *db.Journal {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var entries []*db.JournalEntry
+	for _, entry := range j.entries {
+		if len(in.Key) > 0 && entry.Key != in.Key {
+			continue
+		}
+		if in.Location != 0 && entry.Location != in.Location && entry.Created != in.Location && entry.Dep != in.Location {
+			continue
+		}
+		if (in.Since != 0 && entry.Time < in.Since) || (in.Until != 0 && entry.Time > in.Until) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if in.Limit > 0 && len(entries) > int(in.Limit) {
+		entries = entries[len(entries)-int(in.Limit):]
+	}
+	return &db.Journal{Entries: entries, Capacity: int32(j.size)}
+}
+
+func (s *Server) GetJournal(ctx context.Context, in *db.GetJournalRequest) (*db.Journal, error) {
+	return journal.Query(in), nil
+}
+
+// Journal mutating requests (the methods of the replay log) with their outcome.
+// Requests of a batch get an entry each.
+func (s *Server) journalInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !recordedMethods[info.FullMethod] || !journal.Enabled() {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(start)
+
+	var address string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		address = p.Addr.String()
+	}
+	newEntry := func() *db.JournalEntry {
+		entry := &db.JournalEntry{
+			Method:         info.FullMethod,
+			Identity:       acl.Identity(ctx),
+			Peer:           address,
+			Origin:         "client",
+			Time:           start.UnixNano(),
+			DurationMicros: duration.Microseconds(),
+			Code:           status.Code(err).String(),
+			TraceId:        tracing.TraceID(ctx),
+		}
+		if incomingHops(ctx) > 0 {
+			entry.Origin = "server"
+		}
+		if threshold := s.slowThreshold(info.FullMethod); threshold > 0 && duration >= threshold {
+			entry.Slow = true
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		return entry
+	}
+
+	if batch, ok := req.(*db.BatchSetRequest); ok {
+		batchResp, _ := resp.(*db.BatchSetResponse)
+		results := batchResp.GetResults()
+		for i, request := range batch.Requests {
+			entry := newEntry()
+			entry.Key, entry.Location, entry.Dep, entry.RequestId = request.Key, request.Location, request.Dep, request.RequestId
+			if i < len(results) {
+				if e := results[i].GetStatus(); e != nil {
+					entry.Code, entry.Error = codes.Code(e.Code).String(), e.Message
+				} else {
+					entry.Created = results[i].GetLocation()
+				}
+			}
+			journal.Add(entry)
+		}
+		return resp, err
+	}
+
+	entry := newEntry()
+	if r, ok := req.(interface{ GetNode() *db.Node }); ok && r.GetNode() != nil {
+		entry.Key, entry.Location, entry.Dep = r.GetNode().Key, r.GetNode().Location, r.GetNode().Dep
+	}
+	if r, ok := req.(interface{ GetKey() string }); ok {
+		entry.Key = r.GetKey()
+	}
+	if r, ok := req.(interface{ GetLocation() uint64 }); ok {
+		entry.Location = r.GetLocation()
+	}
+	if r, ok := req.(interface{ GetDep() uint64 }); ok {
+		entry.Dep = r.GetDep()
+	}
+	if r, ok := req.(interface{ GetRequestId() string }); ok {
+		entry.RequestId = r.GetRequestId()
+	}
+	if err == nil {
+		switch r := resp.(type) {
+		case *db.SetResponse:
+			entry.Created = r.Location
+		case *db.ApplyResponse:
+			entry.Created = r.Location
+		case *db.GetOrSetResponse:
+			if r.Created {
+				entry.Created = r.Location
+			}
+		case *db.CreateRootResponse:
+			entry.Created = r.GetLocation()
+		}
+	}
+	journal.Add(entry)
+	return resp, err
+}
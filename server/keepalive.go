@@ -0,0 +1,58 @@
+package main
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Defaults of keepalive pings, short enough to keep connections
+// through NAT and load balancers that drop idle flows after minutes
+const (
+	defaultKeepaliveTime          = 60 * time.Second
+	defaultKeepaliveTimeout       = 20 * time.Second
+	defaultKeepaliveMinTime       = 20 * time.Second
+	defaultClientKeepaliveTime    = 30 * time.Second
+	defaultClientKeepaliveTimeout = 10 * time.Second
+	defaultMaxConnectionAgeGrace  = 10 * time.Second
+)
+
+func seconds(value int, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return time.Duration(value) * time.Second
+}
+
+func (s *Server) keepaliveOptions() []grpc.ServerOption {
+	params := keepalive.ServerParameters{
+		MaxConnectionIdle: time.Duration(s.MaxConnectionIdleSeconds) * time.Second,
+		Time:              seconds(s.KeepaliveTimeSeconds, defaultKeepaliveTime),
+		Timeout:           seconds(s.KeepaliveTimeoutSeconds, defaultKeepaliveTimeout),
+	}
+	if s.MaxConnectionAgeSeconds > 0 {
+		params.MaxConnectionAge = time.Duration(s.MaxConnectionAgeSeconds) * time.Second
+		params.MaxConnectionAgeGrace = seconds(s.MaxConnectionAgeGraceSeconds, defaultMaxConnectionAgeGrace)
+	}
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(params),
+		// Other servers ping idle connections
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             seconds(s.KeepaliveMinTimeSeconds, defaultKeepaliveMinTime),
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// Keepalive of connections to other servers (assumed to use the same config)
+func (s *Server) configurePool() {
+	pool.Keepalive = keepalive.ClientParameters{
+		Time:                seconds(s.ClientKeepaliveTimeSeconds, defaultClientKeepaliveTime),
+		Timeout:             seconds(s.ClientKeepaliveTimeoutSeconds, defaultClientKeepaliveTimeout),
+		PermitWithoutStream: !s.KeepaliveStreamsOnly,
+	}
+	pool.MaxAge = time.Duration(s.MaxConnectionAgeSeconds) * time.Second
+	pool.Grace = seconds(s.MaxConnectionAgeGraceSeconds, defaultMaxConnectionAgeGrace)
+	pool.Interceptors = []grpc.UnaryClientInterceptor{s.loadClientInterceptor}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Default max length of keys in bytes
+const defaultMaxKeyBytes = 4096
+
+// Keys in errors are cut to this length
+const maxKeyInError = 64
+
+// Normalize the key with the configured steps and check it is valid.
+// Normalization must be idempotent since forwarded requests are normalized again.
+func (s *Server) normalizeKey(key string) (string, error) {
+	for _, step := range s.KeyNormalization {
+		switch step {
+		case "trim":
+			key = strings.TrimSpace(key)
+		case "lowercase":
+			key = strings.ToLower(key)
+		}
+	}
+	invalid := func(rule string) error {
+		shown := key
+		if len(shown) > maxKeyInError {
+			shown = shown[:maxKeyInError] + "..."
+		}
+		return &dberrors.InvalidKeyError{Key: shown, Rule: rule}
+	}
+	switch {
+	case len(key) == 0:
+		return key, invalid("non-empty")
+	case s.MaxKeyBytes > 0 && len(key) > s.MaxKeyBytes:
+		return key, invalid("max-length")
+	case !utf8.ValidString(key):
+		return key, invalid("utf-8")
+	case strings.IndexByte(key, 0) >= 0:
+		return key, invalid("no-nul")
+	}
+	return key, nil
+}
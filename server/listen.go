@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+)
+
+const advertiseTimeout = 5 * time.Second
+
+// Addresses to listen on.
+// Defaults to all interfaces with the port of Self.
+func (s *Server) listenAddresses() ([]string, error) {
+	if len(s.BindAddresses) > 0 {
+		return s.BindAddresses, nil
+	}
+	_, port, err := net.SplitHostPort(s.Self)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot derive bind address from self %s: %v", s.Self, err)
+	}
+	return []string{net.JoinHostPort("0.0.0.0", port)}, nil
+}
+
+// Listen on a TCP address or a unix socket (unix:/path/to/socket)
+func listen(address string) (net.Listener, error) {
+	if strings.HasPrefix(address, "unix:") {
+		path := strings.TrimPrefix(address, "unix:")
+		// Remove stale socket from previous run
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return net.Listen("unix", path)
+	}
+	config := net.ListenConfig{Control: reuseAddress}
+	return config.Listen(context.Background(), "tcp", address)
+}
+
+// Let a restarted server bind its port while connections
+// of the previous process are still in TIME_WAIT
+func reuseAddress(network, address string, conn syscall.RawConn) error {
+	var err error
+	if controlErr := conn.Control(func(fd uintptr) {
+		err = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1)
+	}); controlErr != nil {
+		return controlErr
+	}
+	return err
+}
+
+// Make sure peers can reach this server through the advertised address
+func (s *Server) checkAdvertised() error {
+	ctx, cancel := context.WithTimeout(context.Background(), advertiseTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, s.Self, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("Cannot dial advertised address %s: %v", s.Self, err)
+	}
+	defer conn.Close()
+
+	stats, err := db.NewDbServiceClient(conn).GetStats(ctx, &db.Empty{})
+	if err != nil {
+		return fmt.Errorf("Advertised address %s is not a db server: %v", s.Self, err)
+	}
+	if stats.Self != s.Self {
+		return fmt.Errorf("Advertised address %s reaches server %s", s.Self, stats.Self)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net"
+	"syscall"
+	"testing"
+)
+
+// The port is bound again right after the listener is closed
+// with a connection of the previous listener in TIME_WAIT
+func TestListenRestart(t *testing.T) {
+	lis, err := listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := lis.Addr().String()
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	accepted, err := lis.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Closed by the server first, so the server side goes to TIME_WAIT
+	accepted.Close()
+	lis.Close()
+
+	lis, err = listen(address)
+	if err != nil {
+		t.Fatalf("Fail to bind %s again: %v", address, err)
+	}
+	defer lis.Close()
+
+	raw, err := lis.(*net.TCPListener).SyscallConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reuse int
+	raw.Control(func(fd uintptr) {
+		reuse, err = syscall.GetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR)
+	})
+	if err != nil || reuse == 0 {
+		t.Errorf("SO_REUSEADDR is %d, %v", reuse, err)
+	}
+}
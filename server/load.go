@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata with the load of the server sending a response
+const loadKey = "x-load"
+
+// Load signals older than this are ignored (so saturated peers are probed again)
+const loadSignalTTL = 2 * time.Second
+
+// Latencies kept to compute the p99
+const latencySamples = 512
+
+// Shortest retry-after suggested to callers
+const minRetryAfter = 50 * time.Millisecond
+
+// Forwarded requests failed fast when the peer is saturated.
+// Splits, transfers and admin requests are always sent.
+var sheddable = map[string]bool{
+	"/db.DbService/Get":         true,
+	"/db.DbService/Set":         true,
+	"/db.DbService/BatchSet":    true,
+	"/db.DbService/Apply":       true,
+	"/db.DbService/GetOrSet":    true,
+	"/db.DbService/Exists":      true,
+	"/db.DbService/GetNode":     true,
+	"/db.DbService/GetKeyNodes": true,
+	"/db.DbService/GetMerged":   true,
+}
+
+// Load of this server
+type Load struct {
+	// Accessed atomically
+	Inflight  int64
+	P99Micros int64
+
+	lock      sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+var load = Load{}
+
+func (l *Load) Record(d time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(l.latencies) < latencySamples {
+		l.latencies = append(l.latencies, d)
+	} else {
+		l.latencies[l.next] = d
+		l.next = (l.next + 1) % latencySamples
+	}
+}
+
+// Recompute the p99 of recent requests
+func (l *Load) Update() {
+	l.lock.Lock()
+	sorted := append([]time.Duration(nil), l.latencies...)
+	l.lock.Unlock()
+	if len(sorted) == 0 {
+		return
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	atomic.StoreInt64(&l.P99Micros, sorted[len(sorted)*99/100].Microseconds())
+}
+
+// Time callers should wait before sending more requests
+func (l *Load) RetryAfter() time.Duration {
+	retryAfter := time.Duration(atomic.LoadInt64(&l.P99Micros)) * time.Microsecond
+	if retryAfter < minRetryAfter {
+		return minRetryAfter
+	}
+	return retryAfter
+}
+
+func (l *Load) Signal() *db.PeerLoad {
+	return &db.PeerLoad{
+		Inflight:         atomic.LoadInt64(&l.Inflight),
+		P99Millis:        atomic.LoadInt64(&l.P99Micros) / 1000,
+		MemoryPressure:   memoryWatchdog.UnderPressure(),
+		RetryAfterMillis: l.RetryAfter().Milliseconds(),
+	}
+}
+
+// Format as inflight,p99,pressure,retryAfter
+func formatLoad(signal *db.PeerLoad) string {
+	pressure := 0
+	if signal.MemoryPressure {
+		pressure = 1
+	}
+	return fmt.Sprintf("%d,%d,%d,%d", signal.Inflight, signal.P99Millis, pressure, signal.RetryAfterMillis)
+}
+
+func parseLoad(value string) (*db.PeerLoad, bool) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, false
+	}
+	var numbers [4]int64
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+	return &db.PeerLoad{
+		Inflight:         numbers[0],
+		P99Millis:        numbers[1],
+		MemoryPressure:   numbers[2] == 1,
+		RetryAfterMillis: numbers[3],
+	}, true
+}
+
+// Whether the load is above the shedding thresholds
+func (s *Server) saturated(signal *db.PeerLoad) bool {
+	if signal.MemoryPressure {
+		return true
+	}
+	if s.ShedInflight > 0 && signal.Inflight >= int64(s.ShedInflight) {
+		return true
+	}
+	return s.ShedP99Millis > 0 && signal.P99Millis >= int64(s.ShedP99Millis)
+}
+
+// Track in-flight requests and latencies and publish the load in the response
+func (s *Server) loadInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	atomic.AddInt64(&load.Inflight, 1)
+	grpc.SetHeader(ctx, metadata.Pairs(loadKey, formatLoad(load.Signal())))
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	load.Record(time.Since(start))
+	atomic.AddInt64(&load.Inflight, -1)
+	return resp, err
+}
+
+// Load of other servers as perceived from their responses
+type PeerLoads struct {
+	lock    sync.Mutex
+	signals map[string]*db.PeerLoad
+	updated map[string]time.Time
+	// Requests not forwarded to saturated peers
+	Shed int64
+}
+
+var peerLoads = PeerLoads{
+	signals: make(map[string]*db.PeerLoad),
+	updated: make(map[string]time.Time),
+}
+
+func (p *PeerLoads) Set(address string, signal *db.PeerLoad) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.signals[address] = signal
+	p.updated[address] = time.Now()
+}
+
+// Recent load of the peer (nil if unknown)
+func (p *PeerLoads) Get(address string) *db.PeerLoad {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if time.Since(p.updated[address]) > loadSignalTTL {
+		return nil
+	}
+	return p.signals[address]
+}
+
+func (p *PeerLoads) ToProto() map[string]*db.PeerLoad {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	result := make(map[string]*db.PeerLoad)
+	for address, signal := range p.signals {
+		if time.Since(p.updated[address]) <= loadSignalTTL {
+			result[address] = signal
+		}
+	}
+	return result
+}
+
+// Fail fast instead of queueing on saturated peers,
+// and record the load they report
+func (s *Server) loadClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	address := cc.Target()
+	if signal := peerLoads.Get(address); signal != nil && sheddable[method] && s.saturated(signal) {
+		atomic.AddInt64(&peerLoads.Shed, 1)
+		return &dberrors.OverloadedError{
+			Server:     address,
+			RetryAfter: time.Duration(signal.RetryAfterMillis) * time.Millisecond,
+		}
+	}
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	if values := header.Get(loadKey); len(values) > 0 {
+		if signal, ok := parseLoad(values[0]); ok {
+			peerLoads.Set(address, signal)
+		}
+	}
+	return err
+}
+
+// Split earlier when this server is saturated
+func (s *Server) splitThreshold() int {
+	if s.saturated(load.Signal()) {
+		return s.Threshold / 2
+	}
+	return s.Threshold
+}
+
+// Available server with the lowest perceived load (unknown counts as idle)
+func (s *Server) leastLoaded(servers []string) string {
+	best := ""
+	bestInflight := int64(-1)
+	for _, addr := range servers {
+		inflight := int64(0)
+		if signal := peerLoads.Get(addr); signal != nil {
+			if s.saturated(signal) {
+				continue
+			}
+			inflight = signal.Inflight
+		}
+		if bestInflight < 0 || inflight < bestInflight {
+			best, bestInflight = addr, inflight
+		}
+	}
+	return best
+}
+
+func (s *Server) loadLoop() {
+	for range time.Tick(time.Second) {
+		load.Update()
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Servers re-register periodically as a heartbeat
+const registerInterval = 10 * time.Second
+const maxRegisterBackoff = time.Minute
+
+func (s *Server) membership() *db.Membership {
+	capacity := make(map[string]int64)
+	for addr, c := range s.capacity {
+		capacity[addr] = c
+	}
+	reserved := make(map[string]string)
+	for addr, requester := range s.reserved {
+		reserved[addr] = requester
+	}
+	return &db.Membership{
+		Servers:          append([]string(nil), s.Servers...),
+		AvailableServers: append([]string(nil), s.AvailableServers...),
+		Epoch:            s.membershipEpoch,
+		Capacity:         capacity,
+		Reserved:         reserved,
+	}
+}
+
+// Register at the seed server and keep re-registering
+func (s *Server) registerLoop() {
+	if len(s.Seed) == 0 || s.Seed == s.Self {
+		return
+	}
+
+	backoff := time.Second
+	for {
+		// Standbys join the cluster when promoted
+		if s.getMode() == db.Mode_STANDBY {
+			time.Sleep(registerInterval)
+			continue
+		}
+		err := s.register()
+		if err != nil {
+			log.Printf("Fail to register at seed %s: %v", s.Seed, err)
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > maxRegisterBackoff {
+				backoff = maxRegisterBackoff
+			}
+			continue
+		}
+		backoff = time.Second
+		time.Sleep(registerInterval)
+	}
+}
+
+func (s *Server) register() error {
+	return s.registerReplacing("")
+}
+
+// Register and remove the replaced server (if any) from the membership
+func (s *Server) registerReplacing(replaced string) error {
+	client, err := pool.Get(s.Seed)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), registerInterval)
+	defer cancel()
+
+	membership, err := client.Register(ctx, &db.RegisterRequest{
+		Address:  s.Self,
+		Capacity: int64(s.Threshold),
+		Replaces: replaced,
+	})
+	if err != nil {
+		return err
+	}
+	s.UpdateMembership(ctx, membership)
+	return nil
+}
+
+// Add a server to the membership (only on the seed server)
+func (s *Server) Register(ctx context.Context, in *db.RegisterRequest) (*db.Membership, error) {
+	if s.Seed != s.Self {
+		return &db.Membership{}, status.Errorf(codes.FailedPrecondition, "Server %s is not the seed", s.Self)
+	}
+
+	s.lock.Lock()
+	changed := false
+	if !contains(s.Servers, in.Address) {
+		s.Servers = append(s.Servers, in.Address)
+		changed = true
+	}
+	// A server owning no range can be used for splits
+	_, reserved := s.reserved[in.Address]
+	if !reserved && !indexingService.Owns(in.Address) && !contains(s.AvailableServers, in.Address) {
+		s.AvailableServers = append(s.AvailableServers, in.Address)
+		changed = true
+	}
+	if s.capacity[in.Address] != in.Capacity {
+		s.capacity[in.Address] = in.Capacity
+		changed = true
+	}
+	if len(in.Replaces) > 0 && in.Replaces != s.Self && contains(s.Servers, in.Replaces) {
+		s.Servers = without(s.Servers, in.Replaces)
+		s.AvailableServers = without(s.AvailableServers, in.Replaces)
+		delete(s.capacity, in.Replaces)
+		delete(s.reserved, in.Replaces)
+		changed = true
+	}
+	if changed {
+		s.membershipEpoch += 1
+	}
+	expansion.Check(len(s.AvailableServers))
+	membership := s.membership()
+	s.lock.Unlock()
+
+	// The server listens by now
+	pool.ResetBackoff(in.Address)
+	if changed {
+		if len(in.Replaces) > 0 {
+			log.Printf("Server %s replaced by %s", in.Replaces, in.Address)
+		}
+		log.Printf("Server %s registered", in.Address)
+		events.Publish(eventRegistered, map[string]string{"address": in.Address}, "Server %s registered", in.Address)
+		s.broadcastMembership(ctx, membership, in.Address)
+	}
+	return membership, nil
+}
+
+// Send membership to all servers except self and the excluded one
+func (s *Server) broadcastMembership(ctx context.Context, membership *db.Membership, exclude string) {
+	for _, addr := range membership.Servers {
+		if addr == s.Self || addr == exclude {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			_, err = client.UpdateMembership(ctx, membership)
+		}
+		if err != nil {
+			log.Printf("Fail to update membership of %s: %v", addr, err)
+		}
+	}
+}
+
+func (s *Server) UpdateMembership(ctx context.Context, in *db.Membership) (*db.Empty, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Ignore stale views
+	if in.Epoch <= s.membershipEpoch {
+		return &db.Empty{}, nil
+	}
+	s.Servers = in.Servers
+	s.AvailableServers = in.AvailableServers
+	s.capacity = in.Capacity
+	if s.capacity == nil {
+		s.capacity = make(map[string]int64)
+	}
+	s.reserved = in.Reserved
+	if s.reserved == nil {
+		s.reserved = make(map[string]string)
+	}
+	s.membershipEpoch = in.Epoch
+	expansion.Check(len(s.AvailableServers))
+	return &db.Empty{}, nil
+}
+
+func (s *Server) ListServers(ctx context.Context, in *db.Empty) (*db.Membership, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.membership(), nil
+}
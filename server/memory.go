@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Interval of sampling the memory usage
+const memorySampleInterval = time.Second
+
+// Health service reporting whether writes are accepted
+const writeHealthService = "db.DbService/writes"
+
+// Files with the memory limit of the cgroup (v2, then v1)
+var cgroupLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// Rejects writes above the high-water mark until usage
+// drops below the low-water mark. Reads keep working.
+type MemoryWatchdog struct {
+	// Bytes (0 disables the watchdog)
+	High uint64
+	Low  uint64
+
+	// Accessed atomically
+	pressure int32
+	Bytes    int64
+	Rejected int64
+}
+
+var memoryWatchdog = MemoryWatchdog{}
+
+// Memory limit of the cgroup of the process (0 if unlimited or unknown)
+func cgroupMemoryLimit() uint64 {
+	for _, file := range cgroupLimitFiles {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		limit, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		// "max" or a huge value means unlimited
+		if err != nil || limit >= 1<<62 {
+			return 0
+		}
+		return limit
+	}
+	return 0
+}
+
+// Marks in MB (the high mark falls back to 90% of the cgroup limit if enabled)
+func (w *MemoryWatchdog) Init(highMB, lowMB int, useCgroup bool) {
+	high := uint64(highMB) << 20
+	if high == 0 && useCgroup {
+		high = cgroupMemoryLimit() / 10 * 9
+	}
+	low := uint64(lowMB) << 20
+	if low == 0 || low > high {
+		low = high / 10 * 8
+	}
+	atomic.StoreUint64(&w.High, high)
+	atomic.StoreUint64(&w.Low, low)
+}
+
+func (w *MemoryWatchdog) UnderPressure() bool {
+	return atomic.LoadInt32(&w.pressure) == 1
+}
+
+// Memory obtained from the OS and not released
+func memoryUsage() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.Sys - m.HeapReleased
+}
+
+// Update the state from a sample.
+// Returns true when the server enters memory pressure.
+func (w *MemoryWatchdog) Sample(used uint64) bool {
+	atomic.StoreInt64(&w.Bytes, int64(used))
+	high, low := atomic.LoadUint64(&w.High), atomic.LoadUint64(&w.Low)
+	if high == 0 {
+		atomic.StoreInt32(&w.pressure, 0)
+		return false
+	}
+	if used >= high && atomic.CompareAndSwapInt32(&w.pressure, 0, 1) {
+		log.Printf("Memory pressure (%d bytes used, %d by values, high-water mark %d): rejecting writes", used, store.ValueBytes(), high)
+		healthServer.SetServingStatus(writeHealthService, healthpb.HealthCheckResponse_NOT_SERVING)
+		events.Publish(eventMemoryPressure, nil, "Memory pressure (%d bytes used): rejecting writes", used)
+		return true
+	}
+	if used < low && atomic.CompareAndSwapInt32(&w.pressure, 1, 0) {
+		log.Printf("Memory pressure relieved (%d bytes used): accepting writes", used)
+		healthServer.SetServingStatus(writeHealthService, healthpb.HealthCheckResponse_SERVING)
+		events.Publish(eventMemoryRelieved, nil, "Memory pressure relieved (%d bytes used)", used)
+	}
+	return false
+}
+
+// Reject writes under memory pressure
+func (s *Server) checkMemory() error {
+	if !memoryWatchdog.UnderPressure() {
+		return nil
+	}
+	atomic.AddInt64(&memoryWatchdog.Rejected, 1)
+	return &dberrors.QuotaExceededError{
+		Resource: "memory",
+		Limit:    int64(atomic.LoadUint64(&memoryWatchdog.High)),
+	}
+}
+
+func (s *Server) memoryLoop() {
+	healthServer.SetServingStatus(writeHealthService, healthpb.HealthCheckResponse_SERVING)
+	for range time.Tick(memorySampleInterval) {
+		if memoryWatchdog.Sample(memoryUsage()) {
+			s.relieveMemory()
+		} else if memoryWatchdog.UnderPressure() {
+			// Nothing may allocate enough to trigger a GC,
+			// so return the memory freed by deletions to the OS
+			debug.FreeOSMemory()
+		}
+	}
+}
+
+// Free memory by compacting and moving part of the range away
+func (s *Server) relieveMemory() {
+	if _, err := s.Compact(context.Background(), &db.Empty{}); err != nil {
+		log.Printf("Compaction under memory pressure failed: %v", err)
+	}
+	s.lock.Lock()
+	s.autoSplit()
+	s.lock.Unlock()
+	debug.FreeOSMemory()
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/mergeapi"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Buffers for marshaling the inputs of merge and transform actions
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// Delay before retrying a failed merge
+const mergeRetryDelay = time.Second
+
+// Max number of records kept in the merge history
+const mergeHistorySize = 100
+
+// Max runs of a merge whose children keep changing before it fails
+const mergeMaxRuns = 3
+
+// Parents whose merge should be retried
+var mergeQueue = make(chan uint64, 1024)
+
+// Used to invoke merge functions
+var invoker utils.Invoker = utils.OpenWhiskInvoker{}
+
+// Audit history of recent merges
+type MergeHistory struct {
+	lock    sync.Mutex
+	records []*db.MergeRecord
+}
+
+var mergeHistory = MergeHistory{}
+
+func (h *MergeHistory) Add(record *db.MergeRecord) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.records = append(h.records, record)
+	if len(h.records) > mergeHistorySize {
+		h.records = h.records[len(h.records)-mergeHistorySize:]
+	}
+}
+
+func (h *MergeHistory) Records() []*db.MergeRecord {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return append([]*db.MergeRecord(nil), h.records...)
+}
+
+func (s *Server) GetMergeHistory(ctx context.Context, in *db.Empty) (*db.MergeHistory, error) {
+	return &db.MergeHistory{Records: mergeHistory.Records()}, nil
+}
+
+// Resolve conflicts of the parent with its merge function.
+// The parent keeps its original children unless every step succeeds.
+// Returns the record of the merge (nil if no merge function is registered).
+func (s *Server) merge(ctx context.Context, parent *db.Node) (*db.MergeRecord, error) {
+	merge, policy := core.MergeFunction(parent.Location)
+	if len(merge) == 0 {
+		return nil, nil
+	}
+	// Resolve only a batch of children
+	batch, rest := policy.Batch(parent.Children)
+	parent.Children = batch
+
+	record := &db.MergeRecord{
+		Parent: parent.Location,
+		Action: merge,
+		Time:   time.Now().UnixNano(),
+	}
+	ctx, span := tracing.Start(ctx, "merge")
+	span.SetAttribute("merge.action", merge)
+	err := s.applyMerge(ctx, parent, rest, record)
+	// Children added meanwhile must not be dropped, so resolve them all again
+	for errors.Is(err, dberrors.ErrChildrenChanged) && record.Reruns+1 < mergeMaxRuns {
+		updated, getErr := s.GetNode(ctx, &db.GetNodeRequest{Location: parent.Location})
+		if getErr != nil {
+			err = getErr
+			break
+		}
+		record.Reruns += 1
+		if len(updated.Children) < 2 {
+			err = nil
+			break
+		}
+		batch, rest = policy.Batch(updated.Children)
+		updated.Children = batch
+		parent = updated
+		err = s.applyMerge(ctx, parent, rest, record)
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	span.SetAttribute("merge.activation_id", record.ActivationId)
+	span.SetError(err)
+	span.Finish()
+	attributes := map[string]string{
+		"parent":     fmt.Sprintf("%x", parent.Location),
+		"action":     merge,
+		"activation": record.ActivationId,
+	}
+	if err == nil {
+		mergeFailures.Succeed(parent.Location)
+		events.Publish(eventMergeCompleted, attributes, "Merge of %x by %s completed", parent.Location, merge)
+	} else {
+		events.Publish(eventMergeFailed, attributes, "Merge of %x by %s failed: %v", parent.Location, merge, err)
+	}
+	mergeHistory.Add(record)
+	convergence.Notify()
+	log.Printf("Merge of %x by %s (activation %s, %d ms): %v", parent.Location, merge, record.ActivationId, record.DurationMillis, err)
+
+	return record, err
+}
+
+func (s *Server) applyMerge(ctx context.Context, parent *db.Node, rest []uint64, record *db.MergeRecord) error {
+	merge := record.Action
+	record.ChildrenVersion = parent.ChildrenVersion
+
+	// Resolvers can use the stats to decide the strategy
+	if parent.Stats == nil {
+		stats, err := s.nodeStats(ctx, parent, 0)
+		if err != nil {
+			return err
+		}
+		parent.Stats = stats
+	}
+
+	input, counters, err := s.mergeInput(ctx, parent, merge)
+	if err != nil {
+		return err
+	}
+	if len(input.Children) < 2 {
+		return nil
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	json.NewEncoder(buf).Encode(input)
+	resp, err := invoker.CallAction(merge, buf.Bytes())
+	bufferPool.Put(buf)
+	if err != nil {
+		return err
+	}
+	record.ActivationId = resp.ActivationId
+	record.DurationMillis = resp.Duration.Milliseconds()
+
+	// Nothing is applied unless the whole output is valid
+	output, err := mergeapi.ParseOutput(resp.Body)
+	if err == nil {
+		err = output.Validate()
+	}
+	if err == nil {
+		// Nodes at locations not matching their keys would be routed to the wrong server
+		for _, node := range output.Nodes {
+			if err = storage.CheckLocation(node.Location, node.Key); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid output of merge function %s: %v", merge, err)
+	}
+	children := &db.Nodes{}
+	for _, node := range output.Nodes {
+		// Checked by the servers receiving them
+		children.Nodes = append(children.Nodes, storage.Seal(node.ToProto()))
+	}
+
+	if err := s.distributeNodes(children.Nodes); err != nil {
+		return err
+	}
+
+	// Counter nodes are kept as they are
+	locations := append(append([]uint64(nil), rest...), counters...)
+	kept := make(map[uint64]bool)
+	for _, child := range locations {
+		kept[child] = true
+	}
+	for _, child := range children.Nodes {
+		if child.Dep == parent.Location {
+			locations = append(locations, child.Location)
+			kept[child.Location] = true
+		}
+	}
+
+	// Swap children atomically on the owner of the parent
+	// if no child was added or removed while the merge function was running
+	replaced, err := s.ReplaceChildren(ctx, &db.ReplaceChildrenRequest{
+		Location:        parent.Location,
+		Children:        locations,
+		CheckVersion:    true,
+		ExpectedVersion: parent.ChildrenVersion,
+	})
+	if err != nil {
+		// Merged nodes are not linked yet
+		for _, child := range children.Nodes {
+			s.RemoveNode(ctx, &db.RemoveNodeRequest{Location: child.Location})
+		}
+		return dberrors.FromStatus(err)
+	}
+
+	// Link nodes depending on other locations
+	for _, child := range children.Nodes {
+		if child.Dep != parent.Location {
+			if _, err := s.AddChild(ctx, &db.AddChildRequest{
+				Location: child.Dep,
+				Child:    child.Location,
+			}); err != nil {
+				log.Printf("Fail to link merged node %x: %v", child.Location, err)
+			}
+		}
+	}
+
+	resolved := make(map[uint64]bool)
+	for _, child := range parent.Children {
+		resolved[child] = true
+	}
+	// Old children resolve to the merged child of their key (or the first one),
+	// since clients may still use them as deps
+	var successor uint64
+	successors := make(map[string]uint64)
+	for _, child := range children.Nodes {
+		if child.Dep != parent.Location {
+			continue
+		}
+		if successor == 0 {
+			successor = child.Location
+		}
+		if _, ok := successors[child.Key]; !ok {
+			successors[child.Key] = child.Location
+		}
+	}
+	keys := make(map[uint64]string)
+	for _, child := range input.Children {
+		keys[child.Location] = child.Key
+	}
+	for _, child := range replaced.Children {
+		if kept[child] || !resolved[child] {
+			continue
+		}
+		next, ok := successors[keys[child]]
+		if !ok {
+			next = successor
+		}
+		// Old children are no longer reachable
+		s.RemoveNode(ctx, &db.RemoveNodeRequest{
+			Location:  child,
+			Successor: next,
+		})
+	}
+
+	// Resolve the rest in the next round
+	if len(rest) > 0 {
+		scheduleMerge(parent.Location)
+	}
+
+	// Debug
+	fmt.Println("[Merge]")
+	indexingService.Print()
+	fmt.Printf("Nodes: %d\n", store.NodeCount())
+	// store.Print()
+
+	return nil
+}
+
+// Registration of the parent that selected the merge action
+func mergeRegistration(parent *db.Node, merge string) mergeapi.Registration {
+	key := ""
+	if !parent.Keyless {
+		key = parent.Key
+	}
+	matches := core.Resolve(parent.Location, true, key, utils.KeyHash(parent.Location))
+	if len(matches) == 0 || matches[0].Name != merge {
+		// Changed since the merge was scheduled
+		return mergeapi.Registration{Name: merge, Global: true, Scope: engine.ScopeGlobal}
+	}
+	r := matches[0]
+	return mergeapi.Registration{
+		Name:     r.Name,
+		Scope:    engine.Scope(r),
+		Location: r.Location,
+		Key:      r.Key,
+		Prefix:   r.Prefix,
+		Left:     r.Left,
+		Right:    r.Right,
+		Global:   r.Global,
+	}
+}
+
+// Payload of the merge action with the values of the children,
+// and the children that are counter nodes (left out of it)
+func (s *Server) mergeInput(ctx context.Context, parent *db.Node, merge string) (*mergeapi.Input, []uint64, error) {
+	input := &mergeapi.Input{
+		Version:         mergeapi.Version,
+		Parent:          mergeapi.FromProto(parent),
+		Stats:           parent.Stats,
+		Registration:    mergeRegistration(parent, merge),
+		Traceparent:     tracing.Traceparent(ctx),
+		ChildrenVersion: parent.ChildrenVersion,
+	}
+
+	var counters []uint64
+	for _, location := range parent.Children {
+		child, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location})
+		if err != nil {
+			return nil, nil, err
+		}
+		if child.Type == db.NodeType_COUNTER {
+			counters = append(counters, location)
+			continue
+		}
+		input.Children = append(input.Children, mergeapi.Child{
+			Node:   mergeapi.FromProto(child),
+			Server: indexingService.ResolveOwner(location),
+		})
+	}
+	return input, counters, nil
+}
+
+// Merge again later (e.g. for the rest of the children)
+func scheduleMerge(location uint64) {
+	convergence.Schedule(location)
+	time.AfterFunc(mergeRetryDelay, func() {
+		mergeQueue <- location
+	})
+}
+
+// Pending merges waiting for writes to settle
+var debounceTimers = struct {
+	lock   sync.Mutex
+	timers map[uint64]*time.Timer
+}{timers: make(map[uint64]*time.Timer)}
+
+// Merge the parent once no child is added for the delay
+func debounceMerge(location uint64, delay time.Duration) {
+	debounceTimers.lock.Lock()
+	defer debounceTimers.lock.Unlock()
+
+	if timer, ok := debounceTimers.timers[location]; ok {
+		timer.Reset(delay)
+		return
+	}
+	convergence.Schedule(location)
+	debounceTimers.timers[location] = time.AfterFunc(delay, func() {
+		debounceTimers.lock.Lock()
+		delete(debounceTimers.timers, location)
+		debounceTimers.lock.Unlock()
+		mergeQueue <- location
+	})
+}
+
+func (s *Server) mergeWorker() {
+	ctx := context.Background()
+	for location := range mergeQueue {
+		if mergeFailures.Dead(location) {
+			// Waits for RetryMerge
+			convergence.Done(location)
+			continue
+		}
+		s.lock.RLock()
+		parent, err := s.GetNode(ctx, &db.GetNodeRequest{
+			Location: location,
+		})
+		if err == nil && len(parent.Children) > 1 {
+			_, err = s.merge(ctx, parent)
+		}
+		s.lock.RUnlock()
+
+		if err != nil {
+			log.Printf("Merge of %x failed: %v", location, err)
+			retryMerge(location, err)
+		}
+		convergence.Done(location)
+	}
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/mergeapi"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMergeInputRegistration(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Set(ctx, &db.SetRequest{Key: "users/1", Value: []byte("v"), Dep: root.Location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := core.GetNode(resp.Location)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	core.SetGlobalMergeFunction("global", engine.Policy{})
+	core.SetPrefixMergeFunction("users/", "prefix", engine.Policy{})
+	core.SetKeyMergeFunction("users/1", "key", engine.Policy{})
+	for _, c := range []struct {
+		merge    string
+		expected mergeapi.Registration
+	}{
+		{"key", mergeapi.Registration{Name: "key", Scope: engine.ScopeKey, Key: "users/1"}},
+		{"prefix", mergeapi.Registration{Name: "prefix", Scope: engine.ScopePrefix, Prefix: "users/"}},
+		{"global", mergeapi.Registration{Name: "global", Scope: engine.ScopeGlobal, Global: true}},
+	} {
+		input, _, err := s.mergeInput(ctx, parent, c.merge)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input.Registration != c.expected {
+			t.Errorf("Registration %+v instead of %+v", input.Registration, c.expected)
+		}
+		// Removed so that the next one applies
+		switch c.expected.Scope {
+		case engine.ScopeKey:
+			core.SetKeyMergeFunction("users/1", "", engine.Policy{})
+		case engine.ScopePrefix:
+			core.SetPrefixMergeFunction("users/", "", engine.Policy{})
+		}
+	}
+}
+
+func TestReplicatedMergeFunctionRejected(t *testing.T) {
+	s := newTestServer(t)
+	defer func(v *auth.Verifier) { verifier = v }(verifier)
+	verifier = auth.NewVerifier([]byte("secret"), time.Minute)
+	ctx := context.Background()
+
+	// Sent by a client instead of a peer, skipping validation
+	_, err := s.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{Location: 1, Name: "merge", Replicated: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Replicated merge function from a client answered with %v", err)
+	}
+	_, err = s.SetGlobalMergeFunction(ctx, &db.SetGlobalMergeFunctionRequest{Name: "merge", Replicated: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Replicated global merge function from a client answered with %v", err)
+	}
+	_, err = s.SetScopedMergeFunction(ctx, &db.SetScopedMergeFunctionRequest{Key: "k", Name: "merge", Replicated: true})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Replicated scoped merge function from a client answered with %v", err)
+	}
+	if len(core.Registrations()) != 0 {
+		t.Errorf("Rejected registrations applied: %v", core.Registrations())
+	}
+	for _, method := range []string{"SetMergeFunction", "SetGlobalMergeFunction", "SetScopedMergeFunction"} {
+		if !auth.ProtectedMethods["/db.DbService/"+method] {
+			t.Errorf("%s is not protected", method)
+		}
+	}
+}
+
+// Resolver failing its first invocations,
+// then replacing the children with one node of the key of the first one
+type resolverStub struct {
+	lock     sync.Mutex
+	failures int
+	// Value of the merged node ("merged" if empty)
+	value string
+	calls    int
+	// Children of each successful invocation
+	merged []int
+}
+
+func (r *resolverStub) CallAction(action string, params []byte) (*utils.ActionResult, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.calls += 1
+	if r.failures > 0 {
+		r.failures -= 1
+		return nil, errors.New("resolver failed")
+	}
+	var input mergeapi.Input
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, err
+	}
+	r.merged = append(r.merged, len(input.Children))
+	first := input.Children[0]
+	value := r.value
+	if len(value) == 0 {
+		value = "merged"
+	}
+	output := mergeapi.Output{Version: mergeapi.Version, Nodes: []mergeapi.Node{{
+		Location: storage.NewLocation(utils.KeyHash(first.Location)),
+		Dep:      input.Parent.Location,
+		Key:      first.Key,
+		Value:    []byte(value),
+	}}}
+	body, err := json.Marshal(output)
+	return &utils.ActionResult{Body: body}, err
+}
+
+func (r *resolverStub) Calls() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.calls
+}
+
+// Server reachable by the calls it makes to the owner of a location,
+// running the merge worker (which reads the global queue, so it is started once)
+var mergeTestServer struct {
+	once   sync.Once
+	server *Server
+}
+
+// Reset the merge test server with the stub as invoker
+func useResolver(t *testing.T, r *resolverStub) *Server {
+	mergeTestServer.once.Do(func() {
+		s := newTestServer(t)
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := grpc.NewServer()
+		db.RegisterDbServiceServer(server, s)
+		go server.Serve(listener)
+		s.Self = listener.Addr().String()
+		go s.mergeWorker()
+		mergeTestServer.server = s
+	})
+	s := mergeTestServer.server
+	chdirTemp(t)
+	resetTestServer(s)
+	mergeFailures.Init(0, 0)
+
+	previous := invoker
+	invoker = r
+	t.Cleanup(func() { invoker = previous })
+	return s
+}
+
+func waitFor(t *testing.T, timeout time.Duration, what string, condition func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(timeout); !condition(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+	}
+}
+
+// A burst of sibling writes is resolved by a single invocation
+// once no child is added for the debounce window
+func TestDebouncedMerge(t *testing.T) {
+	resolver := &resolverStub{}
+	s := useResolver(t, resolver)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := s.Set(ctx, &db.SetRequest{Key: "k", Value: []byte("0"), Dep: root.Location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const (
+		siblings = 10
+		debounce = 200 * time.Millisecond
+	)
+	if _, err := s.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
+		Location:       parent.Location,
+		Name:           "resolver",
+		Policy:         &db.MergePolicy{DebounceMillis: debounce.Milliseconds()},
+		SkipValidation: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < siblings; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := s.Set(ctx, &db.SetRequest{Key: "k", Value: []byte{byte('a' + i)}, Dep: parent.Location})
+			if err != nil {
+				t.Errorf("Set %d failed: %v", i, err)
+				return
+			}
+			if resp.MergeStatus != db.MergeStatus_MERGE_NONE && resp.MergeStatus != db.MergeStatus_MERGE_PENDING {
+				t.Errorf("Set %d returned %v", i, resp.MergeStatus)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if calls := resolver.Calls(); calls != 0 && time.Since(start) < debounce {
+		t.Fatalf("Resolver invoked %d times during the burst", calls)
+	}
+
+	waitFor(t, 5*time.Second, "the merge", func() bool { return resolver.Calls() > 0 })
+	if elapsed := time.Since(start); elapsed < debounce {
+		t.Errorf("Resolver invoked after %v, before the debounce window", elapsed)
+	}
+	time.Sleep(3 * debounce)
+	resolver.lock.Lock()
+	defer resolver.lock.Unlock()
+	if resolver.calls != 1 || len(resolver.merged) != 1 || resolver.merged[0] != siblings {
+		t.Errorf("Resolver invoked %d times with %v children", resolver.calls, resolver.merged)
+	}
+	node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: parent.Location})
+	if err != nil || len(node.Children) != 1 {
+		t.Errorf("GetNode of the parent returned %v, %v", node, err)
+	}
+}
+
+// A failing merge is retried with capped backoff, dead-lettered after its attempts
+// with its parent flagged, and merged again by RetryMerge once the resolver works
+func TestFailingMergeDeadLettered(t *testing.T) {
+	resolver := &resolverStub{failures: 3}
+	s := useResolver(t, resolver)
+	mergeFailures.Init(3, 1500*time.Millisecond)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parent, err := s.Set(ctx, &db.SetRequest{Key: "k", Value: []byte("0"), Dep: root.Location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
+		Location:       parent.Location,
+		Name:           "resolver",
+		SkipValidation: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := s.GetStats(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadLettered := stats.DeadLetteredMerges
+
+	start := time.Now()
+	for i, value := range []string{"a", "b"} {
+		resp, err := s.Set(ctx, &db.SetRequest{Key: "k", Value: []byte(value), Dep: parent.Location})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := []db.MergeStatus{db.MergeStatus_MERGE_NONE, db.MergeStatus_MERGE_FAILED}[i]; resp.MergeStatus != expected {
+			t.Fatalf("Set of %s returned %v instead of %v", value, resp.MergeStatus, expected)
+		}
+	}
+	failed := func() []*db.FailedMerge {
+		merges, err := s.GetFailedMerges(ctx, &db.Empty{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return merges.Merges
+	}
+	waitFor(t, 10*time.Second, "the merge to be dead-lettered", func() bool { return len(failed()) > 0 })
+	// Retried after 1s, then after 1.5s instead of 2s
+	if elapsed := time.Since(start); elapsed < 2500*time.Millisecond || elapsed > 4*time.Second {
+		t.Errorf("Merge dead-lettered after %v", elapsed)
+	}
+	merges := failed()
+	if len(merges) != 1 || merges[0].Parent != parent.Location || merges[0].Attempts != 3 || merges[0].Action != "resolver" {
+		t.Fatalf("GetFailedMerges returned %v", merges)
+	}
+	// Not retried anymore
+	time.Sleep(2 * time.Second)
+	if calls := resolver.Calls(); calls != 3 {
+		t.Errorf("Resolver invoked %d times instead of 3", calls)
+	}
+	node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: parent.Location})
+	if err != nil || !node.UnresolvedConflict || len(node.Children) != 2 {
+		t.Errorf("GetNode of the dead-lettered parent returned %v, %v", node, err)
+	}
+	if stats, err := s.GetStats(ctx, &db.Empty{}); err != nil || stats.DeadLetteredMerges != deadLettered+1 {
+		t.Errorf("GetStats returned %v, %v", stats, err)
+	}
+
+	// Fixed resolver
+	if _, err := s.RetryMerge(ctx, &db.RetryMergeRequest{Parent: parent.Location}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 5*time.Second, "the merge to be retried", func() bool {
+		node, err := s.GetNode(ctx, &db.GetNodeRequest{Location: parent.Location})
+		return err == nil && len(node.Children) == 1 && !node.UnresolvedConflict
+	})
+	if calls := resolver.Calls(); calls != 4 || len(failed()) != 0 {
+		t.Errorf("Resolver invoked %d times, failed merges %v", calls, failed())
+	}
+	if _, err := s.RetryMerge(ctx, &db.RetryMergeRequest{Parent: parent.Location}); status.Code(err) != codes.NotFound {
+		t.Errorf("RetryMerge of a merged parent returned %v", err)
+	}
+}
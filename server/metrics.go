@@ -0,0 +1,100 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
+	"google.golang.org/grpc"
+)
+
+// Serve debugging endpoints on the metrics address
+func (s *Server) startMetrics() {
+	if len(s.MetricsAddress) == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", serveOpenAPI)
+	if s.EnablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	go func() {
+		log.Printf("Metrics listen at %s", s.MetricsAddress)
+		if err := http.ListenAndServe(s.MetricsAddress, mux); err != nil {
+			log.Fatalf("failed to serve metrics: %v", err)
+		}
+	}()
+}
+
+// Description of the service for HTTP tooling
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	data, err := db.OpenAPI()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// Options of the grpc server from configuration
+func (s *Server) serverOptions() []grpc.ServerOption {
+	var options []grpc.ServerOption
+	if s.MaxConcurrentStreams > 0 {
+		options = append(options, grpc.MaxConcurrentStreams(s.MaxConcurrentStreams))
+	}
+	if s.MaxRecvMsgSizeMB > 0 {
+		options = append(options, grpc.MaxRecvMsgSize(s.MaxRecvMsgSizeMB<<20))
+	}
+	options = append(options, s.keepaliveOptions()...)
+	// The journal sees requests rejected by the later interceptors and the trace started by the first
+	interceptors := []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor, s.journalInterceptor, s.slowLogInterceptor, s.heatmapInterceptor, s.loadInterceptor, s.standbyInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{s.standbyStreamInterceptor}
+	if recorder != nil {
+		interceptors = append([]grpc.UnaryServerInterceptor{s.recordInterceptor}, interceptors...)
+	}
+	if verifier != nil {
+		interceptors = append(interceptors, verifier.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, verifier.StreamServerInterceptor)
+	}
+	interceptors = append(interceptors, acl.UnaryServerInterceptor)
+	streamInterceptors = append(streamInterceptors, acl.StreamServerInterceptor)
+	options = append(options, grpc.ChainUnaryInterceptor(interceptors...))
+	options = append(options, grpc.ChainStreamInterceptor(streamInterceptors...))
+	return options
+}
+
+// Checks signatures of internal requests (nil without a cluster secret)
+var verifier *auth.Verifier
+
+// Read the cluster secret for internal authentication
+func (s *Server) loadSecret() {
+	secret := s.ClusterSecret
+	if len(s.ClusterSecretFile) > 0 {
+		data, err := ioutil.ReadFile(s.ClusterSecretFile)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		secret = strings.TrimSpace(string(data))
+	}
+	if len(secret) > 0 {
+		pool.Secret = []byte(secret)
+		skew := time.Duration(s.AuthSkewSeconds) * time.Second
+		if skew == 0 {
+			skew = 30 * time.Second
+		}
+		verifier = auth.NewVerifier(pool.Secret, skew)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Mode is persisted so that a restart doesn't re-enable writes
+const modeFile = "mode.json"
+
+var healthServer = health.NewServer()
+
+type modeConfig struct {
+	Mode string `json:"mode"`
+}
+
+func (s *Server) getMode() db.Mode {
+	return db.Mode(atomic.LoadInt32(&s.mode))
+}
+
+func (s *Server) setMode(mode db.Mode) {
+	atomic.StoreInt32(&s.mode, int32(mode))
+
+	// Announced transfers must not land on a server leaving normal mode
+	if mode != db.Mode_NORMAL {
+		transfers.Revoke()
+	}
+	if mode == db.Mode_DRAINING || mode == db.Mode_STANDBY {
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	} else {
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+func (s *Server) loadMode() {
+	data, err := ioutil.ReadFile(statePath(modeFile))
+	if os.IsNotExist(err) {
+		s.setMode(db.Mode_NORMAL)
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var config modeConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		log.Fatalln(err)
+	}
+	mode, ok := db.Mode_value[config.Mode]
+	if !ok {
+		log.Fatalf("Invalid mode %s in %s", config.Mode, modeFile)
+	}
+	s.setMode(db.Mode(mode))
+}
+
+func saveMode(mode db.Mode) error {
+	data, _ := json.Marshal(modeConfig{Mode: mode.String()})
+	return ioutil.WriteFile(statePath(modeFile), data, 0644)
+}
+
+// Reject mutations unless in normal mode
+func (s *Server) checkWritable() error {
+	mode := s.getMode()
+	if mode != db.Mode_NORMAL {
+		return status.Errorf(codes.FailedPrecondition, "Server %s is in %s mode", s.Self, mode)
+	}
+	return nil
+}
+
+func (s *Server) SetMode(ctx context.Context, in *db.SetModeRequest) (*db.Empty, error) {
+	if _, ok := db.Mode_name[int32(in.Mode)]; !ok {
+		return &db.Empty{}, status.Errorf(codes.InvalidArgument, "Invalid mode %d", in.Mode)
+	}
+	// Standbys are configured by standbyOf and leave the mode by Promote
+	if in.Mode == db.Mode_STANDBY || s.getMode() == db.Mode_STANDBY {
+		return &db.Empty{}, status.Errorf(codes.FailedPrecondition, "Mode %s is only entered by standbyOf and left by Promote", db.Mode_STANDBY)
+	}
+	if err := saveMode(in.Mode); err != nil {
+		return &db.Empty{}, status.Errorf(codes.Internal, "Fail to persist mode: %v", err)
+	}
+	s.setMode(in.Mode)
+	log.Printf("Mode changed to %s", in.Mode)
+	events.Publish(eventModeChanged, map[string]string{"mode": in.Mode.String()}, "Mode changed to %s", in.Mode)
+
+	return &db.Empty{}, nil
+}
+
+func (s *Server) GetStats(ctx context.Context, in *db.Empty) (*db.GetStatsResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var blobCount, blobBytes int64
+	if store.Blobs != nil {
+		blobCount, blobBytes = store.Blobs.Size()
+	}
+	maintenance, paused := scheduler.ToProto()
+	expansionNeeded, expansionReason := expansion.Needed()
+	return &db.GetStatsResponse{
+		BlobCount:            blobCount,
+		BlobBytes:            blobBytes,
+		ExpiredNodes:         atomic.LoadInt64(&expiredNodes),
+		DeadLetteredMerges:   atomic.LoadInt64(&mergeFailures.DeadLettered),
+		MemoryBytes:          atomic.LoadInt64(&memoryWatchdog.Bytes),
+		MemoryPressure:       memoryWatchdog.UnderPressure(),
+		MemoryRejectedWrites: atomic.LoadInt64(&memoryWatchdog.Rejected),
+		EventCounts:          events.Counts(),
+		PeerLoads:            peerLoads.ToProto(),
+		ShedRequests:         atomic.LoadInt64(&peerLoads.Shed),
+		AclAllowed:           atomic.LoadInt64(&acl.Allowed),
+		AclDenied:            atomic.LoadInt64(&acl.Denied),
+		CompressedRuns:       atomic.LoadInt64(&compressStats.Runs),
+		CompressedNodes:      atomic.LoadInt64(&compressStats.Nodes),
+		ValueBytes:           store.ValueBytes(),
+		ProtocolLevel:        atomic.LoadInt32(&negotiatedProtocol),
+		Maintenance:          maintenance,
+		MaintenancePaused:    paused,
+		DegradedWrites:       atomic.LoadInt64(&degradedWrites),
+		ChecksumMismatches:   atomic.LoadInt64(&store.ChecksumMismatches),
+		Standby:              standby.ToProto(),
+		RemoteHops:           hopStats.ToProto(),
+		HopBudgetExceeded:    atomic.LoadInt64(&hopStats.Exceeded),
+		Aliases:              int64(aliases.Len()),
+		AliasRedirects:       atomic.LoadInt64(&aliases.Redirects),
+		ExpansionNeeded:      expansionNeeded,
+		ExpansionReason:      expansionReason,
+		Self:                 s.Self,
+		Nodes:                int64(store.NodeCount()),
+		Roots:                int64(store.RootCount()),
+		CacheHits:            atomic.LoadInt64(&readCache.Hits),
+		CacheMisses:          atomic.LoadInt64(&readCache.Misses),
+		CacheStaleServed:     atomic.LoadInt64(&readCache.StaleServed),
+		ReadRepairChecks:     atomic.LoadInt64(&readRepair.Checks),
+		ReadRepairs:          atomic.LoadInt64(&readRepair.Repairs),
+		ReadRepairsSkipped:   atomic.LoadInt64(&readRepair.Skipped),
+		SplitBrainDetected:   atomic.LoadInt64(&splitBrainDetected),
+		SplitBrainResolved:   atomic.LoadInt64(&splitBrainResolved),
+		WarmUpQueued:         atomic.LoadInt64(&warmUp.Queued),
+		WarmUpWarmed:         atomic.LoadInt64(&warmUp.Warmed),
+		SplitAttempts:        splitAttempts.ToProto(),
+		SplitGovernance:      splitGovernor.ToProto(len(s.AvailableServers)),
+		Mode:                 s.getMode(),
+		AvailableServers:     s.AvailableServers,
+		Goroutines:           int64(runtime.NumGoroutine()),
+		Connections:          int64(pool.Size()),
+		MaxConnections:       int64(s.MaxConnections),
+		MaxConcurrentStreams: s.MaxConcurrentStreams,
+	}, nil
+}
+
+// Remove expired leaves and garbage-collect blobs of removed nodes
+func (s *Server) Compact(ctx context.Context, in *db.Empty) (*db.CompactResponse, error) {
+	return s.compact(ctx, 0)
+}
+
+// Compact removing at most budget expired nodes (0 for all)
+func (s *Server) compact(ctx context.Context, budget int) (*db.CompactResponse, error) {
+	if err := s.checkWritable(); err != nil {
+		return &db.CompactResponse{}, err
+	}
+	nodes := s.removeExpired(ctx, budget)
+	if _, err := store.Tombstone(snapshots.ReclaimBefore(time.Now().UnixNano())); err != nil {
+		return &db.CompactResponse{RemovedNodes: nodes}, err
+	}
+	removed, freed, err := store.CompactBlobs()
+	if err != nil {
+		return &db.CompactResponse{RemovedNodes: nodes}, err
+	}
+	records, err := store.CompactLog()
+	if err != nil {
+		return &db.CompactResponse{RemovedBlobs: removed, FreedBytes: freed, RemovedNodes: nodes}, err
+	}
+	log.Printf("Compaction removed %d expired nodes, %d blobs (%d bytes) and %d log records", nodes, removed, freed, records)
+	events.Publish(eventCompacted, nil, "Compaction removed %d expired nodes, %d blobs (%d bytes) and %d log records", nodes, removed, freed, records)
+	return &db.CompactResponse{RemovedBlobs: removed, FreedBytes: freed, RemovedNodes: nodes}, nil
+}
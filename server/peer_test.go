@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+)
+
+// Serve a stub of another server on localhost until the test ends,
+// with the pool initialized to reach it
+func startPeer(t testing.TB, peer db.DbServiceServer) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	db.RegisterDbServiceServer(server, peer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	if pool.conns == nil {
+		pool.Init()
+	}
+	// The port may be reused from a stopped peer whose connection is still pooled
+	address := listener.Addr().String()
+	pool.lock.Lock()
+	if pooled, ok := pool.conns[address]; ok {
+		pooled.conn.Close()
+		delete(pool.conns, address)
+	}
+	pool.lock.Unlock()
+	return address
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Whether the Set forwarded to the owner of the key can be linked
+// by this server concurrently, saving the hop from the owner of the key
+// to the owner of the dep
+func (s *Server) canPipeline(in *db.SetRequest, address string) bool {
+	if in.Dep == 0 || in.Location != 0 || in.AllowDanglingDep {
+		return false
+	}
+	// Serialized writes are linked by the owner under the write lock of the key
+	if s.serializesWrites(in) {
+		return false
+	}
+	// The owner only accepts the location of a signed request
+	if len(pool.Secret) == 0 {
+		return false
+	}
+	// The bucket must be resolved before the link
+	if s.AutoBucket && s.MaxChildren > 0 {
+		return false
+	}
+	// All servers must write at the location without linking
+	if atomic.LoadInt32(&negotiatedProtocol) < protocolPipelined || s.protocolLevel() < protocolPipelined {
+		return false
+	}
+	return indexingService.ResolveOwner(in.Dep) != address
+}
+
+// Write the node on the owner of the key and link it to its dep at the same time.
+// If either fails, the other is rolled back so no half-linked node remains.
+func (s *Server) pipelinedSet(ctx context.Context, in *db.SetRequest, address string) (*db.SetResponse, error) {
+	client, err := pool.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	// The owner of the key does not resolve the dep of a write it does not link
+	dep, cached, err := s.resolveDep(ctx, in.Dep, false)
+	if err != nil {
+		return nil, err
+	}
+	// The location is chosen here so that the link can be sent along
+	request := &db.SetRequest{
+		Key:         in.Key,
+		Value:       in.Value,
+		Dep:         dep,
+		TtlSeconds:  in.TtlSeconds,
+		ContentType: in.ContentType,
+		Durability:  in.Durability,
+		Type:        in.Type,
+		Location:    storage.CreateNode(in.Key, nil, dep).Location,
+		ResolveKeys: in.ResolveKeys,
+		RequestId:   in.RequestId,
+
+		SerializeWrites: in.SerializeWrites,
+	}
+
+	var wg sync.WaitGroup
+	var written *db.SetResponse
+	var parent *db.Node
+	var writeErr, linkErr error
+	start := time.Now()
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		written, writeErr = client.Set(forwardContext(ctx), request)
+	}()
+	go func() {
+		defer wg.Done()
+		parent, linkErr = s.AddChild(ctx, &db.AddChildRequest{
+			Location: dep,
+			Child:    request.Location,
+		})
+	}()
+	wg.Wait()
+	timePhase(ctx, phaseForward, start)
+
+	// A retry answered with the node written by the first attempt (linked by it)
+	if writeErr == nil && linkErr == nil && written.Location != request.Location {
+		if _, err := s.UnlinkChild(ctx, &db.AddChildRequest{Location: dep, Child: request.Location}); err != nil {
+			log.Printf("Fail to unlink %x from %x after a retried write: %v", request.Location, dep, err)
+		}
+		return &db.SetResponse{Location: written.Location, Durability: written.Durability, Resolved: written.Resolved}, nil
+	}
+	if writeErr != nil && linkErr == nil {
+		if _, err := s.UnlinkChild(ctx, &db.AddChildRequest{Location: dep, Child: request.Location}); err != nil {
+			log.Printf("Fail to unlink %x from %x after a failed write: %v", request.Location, dep, err)
+		}
+	}
+	if linkErr != nil && writeErr == nil {
+		if _, err := s.RemoveNode(ctx, &db.RemoveNodeRequest{Location: request.Location}); err != nil {
+			log.Printf("Fail to remove %x after a failed link: %v", request.Location, err)
+		}
+	}
+	if writeErr != nil {
+		return nil, writeErr
+	}
+	if linkErr != nil {
+		if errors.Is(linkErr, dberrors.ErrQuotaExceeded) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%v: create an intermediate node to add more children", linkErr)
+		}
+		if errors.Is(linkErr, dberrors.ErrLocationNotFound) {
+			if cached {
+				storedDeps.Forget(dep)
+				return nil, errStaleDep
+			}
+			return nil, missingDep(dep)
+		}
+		return nil, linkErr
+	}
+
+	result := &db.SetResponse{Location: written.Location, Durability: written.Durability, Resolved: written.Resolved}
+	if dep != in.Dep {
+		result.RedirectedDep = dep
+	}
+	if in.Type != db.NodeType_COUNTER {
+		s.resolveConflict(ctx, in.Key, parent, result)
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+func TestCanPipeline(t *testing.T) {
+	indexingService.Init()
+	indexingService.Reset(nil, 0)
+	indexingService.AddMapping(0, math.MaxUint32/2, "a")
+	indexingService.AddMapping(math.MaxUint32/2+1, math.MaxUint32, "b")
+	atomic.StoreInt32(&negotiatedProtocol, protocolCurrent)
+	defer atomic.StoreInt32(&negotiatedProtocol, 0)
+	defer func(secret []byte) { pool.Secret = secret }(pool.Secret)
+	pool.Secret = []byte("secret")
+
+	// Owned by a
+	dep := uint64(1)<<32 + 1
+	tests := []struct {
+		name    string
+		in      *db.SetRequest
+		address string
+		ok      bool
+	}{
+		{"dep elsewhere", &db.SetRequest{Key: "k", Dep: dep}, "b", true},
+		{"dep on the owner", &db.SetRequest{Key: "k", Dep: dep}, "a", false},
+		{"no dep", &db.SetRequest{Key: "k"}, "b", false},
+		{"location chosen", &db.SetRequest{Key: "k", Dep: dep, Location: 5}, "b", false},
+		{"dangling dep", &db.SetRequest{Key: "k", Dep: dep, AllowDanglingDep: true}, "b", false},
+		{"serialized", &db.SetRequest{Key: "k", Dep: dep, SerializeWrites: true}, "b", false},
+		{"serialized prefix", &db.SetRequest{Key: "serial/k", Dep: dep}, "b", false},
+		{"latest node", &db.SetRequest{Key: "k", Dep: db.DepAuto}, "b", false},
+	}
+	s := &Server{SerializeWrites: []string{"serial/"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ok := s.canPipeline(tt.in, tt.address); ok != tt.ok {
+				t.Errorf("canPipeline returned %v, expected %v", ok, tt.ok)
+			}
+		})
+	}
+
+	atomic.StoreInt32(&negotiatedProtocol, protocolPipelined-1)
+	if s.canPipeline(tests[0].in, "b") {
+		t.Errorf("Pipelined before all servers support it")
+	}
+
+	// The owner would reject the location of an unsigned Set
+	atomic.StoreInt32(&negotiatedProtocol, protocolCurrent)
+	pool.Secret = nil
+	if s.canPipeline(tests[0].in, "b") {
+		t.Errorf("Pipelined without a cluster secret")
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/placement"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Placement rules are persisted with their epoch
+const placementFile = "placement.json"
+
+// Max misplaced locations listed by VerifyPlacement
+const maxMisplacedListed = 100
+
+// Interval of the placement migration when it has windows configured
+// (it otherwise runs when triggered and when the rules change)
+const placementInterval = time.Hour
+
+func rulesToProto(rules []placement.Rule, epoch uint64) *db.PlacementRules {
+	result := &db.PlacementRules{Epoch: epoch}
+	for _, rule := range rules {
+		result.Rules = append(result.Rules, &db.PlacementRule{
+			Prefix:    rule.Prefix,
+			Delimiter: rule.Delimiter,
+		})
+	}
+	return result
+}
+
+func rulesFromProto(in *db.PlacementRules) []placement.Rule {
+	var rules []placement.Rule
+	for _, rule := range in.Rules {
+		rules = append(rules, placement.Rule{
+			Prefix:    rule.Prefix,
+			Delimiter: rule.Delimiter,
+		})
+	}
+	return rules
+}
+
+// Rules from placement.json, or from server.json on the seed
+func (s *Server) loadPlacement() {
+	data, err := ioutil.ReadFile(statePath(placementFile))
+	if os.IsNotExist(err) {
+		if s.isSeed() && len(s.Placement) > 0 {
+			placement.Default.Set(s.Placement, 1)
+		}
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var rules db.PlacementRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		log.Fatalln(err)
+	}
+	placement.Default.Set(rulesFromProto(&rules), rules.Epoch)
+}
+
+func savePlacement() error {
+	data, _ := json.Marshal(rulesToProto(placement.Default.Get()))
+	return ioutil.WriteFile(statePath(placementFile), data, 0644)
+}
+
+func (s *Server) GetPlacementRules(ctx context.Context, in *db.Empty) (*db.PlacementRules, error) {
+	return rulesToProto(placement.Default.Get()), nil
+}
+
+// Change the rules on the seed, which assigns the epoch and pushes them to all servers.
+// Existing nodes are moved by the placement task (see migratePlacement).
+func (s *Server) SetPlacementRules(ctx context.Context, in *db.PlacementRules) (*db.Empty, error) {
+	if in.Epoch > 0 {
+		// Pushed by the seed
+		if placement.Default.Set(rulesFromProto(in), in.Epoch) {
+			log.Printf("Placement rules updated (epoch %d)", in.Epoch)
+			events.Publish(eventPlacementUpdate, nil, "Placement rules updated (epoch %d)", in.Epoch)
+			scheduler.Trigger(taskPlacement)
+			return &db.Empty{}, savePlacement()
+		}
+		return &db.Empty{}, nil
+	}
+
+	if !s.isSeed() {
+		client, err := pool.Get(s.Seed)
+		if err != nil {
+			return &db.Empty{}, err
+		}
+		return client.SetPlacementRules(ctx, in)
+	}
+
+	_, epoch := placement.Default.Get()
+	rules := rulesFromProto(in)
+	placement.Default.Set(rules, epoch+1)
+	if err := savePlacement(); err != nil {
+		return &db.Empty{}, err
+	}
+	events.Publish(eventPlacementUpdate, nil, "Placement rules updated (epoch %d)", epoch+1)
+	scheduler.Trigger(taskPlacement)
+	update := rulesToProto(rules, epoch+1)
+	for _, addr := range s.Servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			_, err = client.SetPlacementRules(ctx, update)
+		}
+		if err != nil {
+			log.Printf("Fail to push placement rules to %s: %v", addr, err)
+		}
+	}
+	return &db.Empty{}, nil
+}
+
+// Pull the rules from the seed on startup
+func (s *Server) pullPlacement() {
+	if s.isSeed() {
+		return
+	}
+	client, err := pool.Get(s.Seed)
+	if err == nil {
+		var rules *db.PlacementRules
+		rules, err = client.GetPlacementRules(context.Background(), &db.Empty{})
+		if err == nil && placement.Default.Set(rulesFromProto(rules), rules.Epoch) {
+			err = savePlacement()
+		}
+	}
+	if err != nil {
+		log.Printf("Fail to pull placement rules: %v", err)
+	}
+}
+
+// Find local nodes at locations not matching their keys
+// or not owned by this server
+func (s *Server) CheckIntegrity(ctx context.Context, in *db.Empty) (*db.CheckIntegrityResponse, error) {
+	resp := &db.CheckIntegrityResponse{}
+	err := store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		resp.Checked += 1
+		invalid := false
+		if storage.CheckLocation(node.Location, node.Key) != nil {
+			resp.InvalidLocations += 1
+			invalid = true
+		}
+		if indexingService.ResolveOwner(node.Location) != s.Self {
+			resp.Misrouted += 1
+			invalid = true
+		}
+		if invalid && len(resp.Locations) < maxMisplacedListed {
+			resp.Locations = append(resp.Locations, node.Location)
+		}
+		return true
+	})
+	return resp, err
+}
+
+// Find local nodes placed by other rules than the current ones.
+// Such nodes stay reachable by location but are not co-located with their family
+// until the placement task moves them.
+func (s *Server) VerifyPlacement(ctx context.Context, in *db.Empty) (*db.VerifyPlacementResponse, error) {
+	resp := &db.VerifyPlacementResponse{}
+	err := store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		// Roots and buckets have no key
+		if node.Keyless {
+			return true
+		}
+		resp.Checked += 1
+		if utils.KeyHash(node.Location) != placement.Hash(node.Key) {
+			resp.Misplaced += 1
+			if len(resp.Locations) < maxMisplacedListed {
+				resp.Locations = append(resp.Locations, node.Location)
+			}
+		}
+		return true
+	})
+	return resp, err
+}
+
+// Whether the node can be moved to a location following the current rules.
+// Children depend on the location of their parent beyond the retention of aliases,
+// so only leaves are moved (like merges, later writes make the old ones inner nodes).
+func relocatable(node *storage.Node) bool {
+	if node.Keyless || len(node.Children) > 0 || node.Dep == math.MaxUint64 {
+		return false
+	}
+	if utils.KeyHash(node.Location) == placement.Hash(node.Key) {
+		return false
+	}
+	// Merge functions are registered by location
+	if _, _, registered := core.LocalMergeFunction(node.Location); registered {
+		return false
+	}
+	return len(snapshots.Unpinned([]uint64{node.Location})) > 0
+}
+
+// Move misplaced leaves to locations following the current rules
+// (at most budget nodes unless it is 0). Returns the number of nodes moved.
+func (s *Server) migratePlacement(ctx context.Context, budget int) (int, error) {
+	// Old locations must stay resolvable
+	if s.AliasRetentionSeconds < 0 || s.checkWritable() != nil {
+		return 0, nil
+	}
+	var misplaced []*storage.Node
+	err := store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		if relocatable(node) {
+			misplaced = append(misplaced, node)
+		}
+		return budget <= 0 || len(misplaced) < budget
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, node := range misplaced {
+		location, err := s.relocateNode(ctx, node)
+		if err != nil {
+			log.Printf("Fail to move misplaced node %x: %v", node.Location, err)
+			continue
+		}
+		log.Printf("Misplaced node %x of %s moved to %x", node.Location, node.Key, location)
+		moved += 1
+	}
+	return moved, nil
+}
+
+// Copy the node to a new location of its key on the owner of that location,
+// link it in place of the old one and alias the old location to it
+func (s *Server) relocateNode(ctx context.Context, node *storage.Node) (uint64, error) {
+	loaded, err := store.Load(node)
+	if err != nil {
+		return 0, err
+	}
+	copied := loaded.ToProto()
+	copied.Location = storage.CreateNode(node.Key, nil, node.Dep).Location
+	copied.Children = nil
+	copied.ChildrenVersion = 0
+
+	if owner := indexingService.ResolveOwner(copied.Location); owner == s.Self {
+		_, err = store.AddNode(copied)
+	} else {
+		var client db.DbServiceClient
+		client, err = pool.Get(owner)
+		if err == nil {
+			_, err = client.AddNode(ctx, &db.AddNodeRequest{Node: copied})
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	// Writes and reads using the old location are redirected from now on
+	s.aliasTo(copied.Location, node.Location)
+	if _, err := s.UnlinkChild(ctx, &db.AddChildRequest{Location: node.Dep, Child: node.Location}); err != nil {
+		return 0, err
+	}
+	if _, err := s.AddChild(ctx, &db.AddChildRequest{Location: node.Dep, Child: copied.Location}); err != nil {
+		return 0, err
+	}
+	// Children linked before the alias follow the node
+	if current := store.GetNode(node.Location); current != nil {
+		for _, child := range current.Children {
+			if _, err := s.AddChild(ctx, &db.AddChildRequest{Location: copied.Location, Child: child}); err != nil {
+				return 0, err
+			}
+		}
+	}
+	store.RemoveNode(node.Location)
+	return copied.Location, nil
+}
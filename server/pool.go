@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	protov2 "google.golang.org/protobuf/proto"
+)
+
+// Reuse connections to other servers instead of dialing per request
+type ConnPool struct {
+	// Max number of open connections (0 means unlimited)
+	Max int
+	// Cluster secret to sign requests (not signed if empty)
+	Secret []byte
+	// Pings of idle connections (disabled if Time is 0)
+	Keepalive keepalive.ClientParameters
+	// Max age of connections enforced by the peers (0 means unlimited).
+	// Connections are re-dialed before reaching it.
+	MaxAge time.Duration
+	// Time given to requests on a replaced connection
+	Grace time.Duration
+	// Run after the built-in interceptors
+	Interceptors []grpc.UnaryClientInterceptor
+	// Opens the connections (e.g. to corrupt them in tests; TCP if nil)
+	Dialer func(ctx context.Context, address string) (net.Conn, error)
+	// Attempts of requests safe to repeat that fail transiently (see Retry)
+	Attempts int
+	// Wait before the second attempt, doubled for each further one
+	Backoff time.Duration
+
+	lock  sync.Mutex
+	conns map[string]*pooledConn
+}
+
+type pooledConn struct {
+	conn   *grpc.ClientConn
+	dialed time.Time
+}
+
+func (p *ConnPool) Init() {
+	p.conns = make(map[string]*pooledConn)
+}
+
+// Number of open connections
+func (p *ConnPool) Size() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return len(p.conns)
+}
+
+func (p *ConnPool) Get(address string) (db.DbServiceClient, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	pooled, ok := p.conns[address]
+	if !ok {
+		if p.Max > 0 && len(p.conns) >= p.Max {
+			return nil, &dberrors.QuotaExceededError{Resource: "connections", Limit: int64(p.Max)}
+		}
+		conn, err := p.dial(address)
+		if err != nil {
+			return nil, err
+		}
+		pooled = &pooledConn{conn: conn, dialed: time.Now()}
+		p.conns[address] = pooled
+	} else if p.MaxAge > 0 && time.Since(pooled.dialed) > p.MaxAge*8/10 {
+		// Peers close connections at their max age (with 10% jitter),
+		// so requests must not land on a connection about to close
+		if conn, err := p.dial(address); err == nil {
+			old := pooled.conn
+			time.AfterFunc(p.Grace, func() { old.Close() })
+			pooled = &pooledConn{conn: conn, dialed: time.Now()}
+			p.conns[address] = pooled
+		}
+	}
+	return db.NewDbServiceClient(pooled.conn), nil
+}
+
+// Dial the server at once if its connection waits to reconnect
+// (e.g. it was dialed before the server started)
+func (p *ConnPool) ResetBackoff(address string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if pooled, ok := p.conns[address]; ok {
+		pooled.conn.ResetConnectBackoff()
+	}
+}
+
+// Whether a failed request may succeed if sent again
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// Run the request up to Attempts times while it fails transiently and the context is not done
+func (p *ConnPool) Retry(ctx context.Context, request func() error) error {
+	backoff := p.Backoff
+	for attempt := 1; ; attempt++ {
+		err := request()
+		if err == nil || !transient(err) || attempt >= p.Attempts || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+func (p *ConnPool) dial(address string) (*grpc.ClientConn, error) {
+	interceptors := []grpc.UnaryClientInterceptor{dberrors.UnaryClientInterceptor, tracing.UnaryClientInterceptor, hopsClientInterceptor}
+	interceptors = append(interceptors, p.Interceptors...)
+	interceptors = append(interceptors, marshalClientInterceptor)
+	options := []grpc.DialOption{grpc.WithInsecure()}
+	if len(p.Secret) > 0 {
+		interceptors = append(interceptors, auth.Signer{Secret: p.Secret}.UnaryClientInterceptor)
+		options = append(options, grpc.WithChainStreamInterceptor(auth.Signer{Secret: p.Secret}.StreamClientInterceptor))
+	}
+	options = append(options, grpc.WithChainUnaryInterceptor(interceptors...))
+	if p.Keepalive.Time > 0 {
+		options = append(options, grpc.WithKeepaliveParams(p.Keepalive))
+	}
+	if p.Dialer != nil {
+		options = append(options, grpc.WithContextDialer(p.Dialer))
+	}
+	return grpc.Dial(address, options...)
+}
+
+// Buffers for marshaling requests to other servers
+var marshalPool = sync.Pool{
+	New: func() interface{} {
+		return new([]byte)
+	},
+}
+
+// Larger buffers are left to the garbage collector
+const maxPooledBuffer = 4 << 20
+
+// Marshal requests into pooled buffers (disabled to compare in benchmarks)
+var poolMarshalBuffers = true
+
+// Codec of a single call, marshaling its request into a pooled buffer
+type callCodec struct {
+	buf *[]byte
+}
+
+func (c *callCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("Fail to marshal %T: not a proto message", v)
+	}
+	if c.buf == nil {
+		c.buf = marshalPool.Get().(*[]byte)
+	}
+	data, err := protov2.MarshalOptions{}.MarshalAppend((*c.buf)[:0], proto.MessageV2(m))
+	*c.buf = data
+	return data, err
+}
+
+func (c *callCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("Fail to unmarshal %T: not a proto message", v)
+	}
+	// Bytes fields are copied, so data is not retained
+	return proto.Unmarshal(data, m)
+}
+
+func (c *callCodec) Name() string {
+	return "proto"
+}
+
+// Release the buffer once the transport is done with it
+func (c *callCodec) release() {
+	if c.buf != nil && cap(*c.buf) <= maxPooledBuffer {
+		marshalPool.Put(c.buf)
+	}
+	c.buf = nil
+}
+
+// Marshal the request into a pooled buffer, reused after the response arrived
+// (the whole request was sent by then). Buffers of failed calls may still be
+// queued in the transport, so they are not reused.
+func marshalClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !poolMarshalBuffers {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	codec := &callCodec{}
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.ForceCodec(codec))...)
+	if err == nil {
+		codec.release()
+	}
+	return err
+}
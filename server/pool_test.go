@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Answers Sets with the length of the value if it is the key repeated
+type echoPeer struct {
+	db.UnimplementedDbServiceServer
+}
+
+func (p *echoPeer) Set(ctx context.Context, in *db.SetRequest) (*db.SetResponse, error) {
+	if !bytes.Equal(in.Value, bytes.Repeat([]byte(in.Key), len(in.Value)/len(in.Key))) {
+		return nil, fmt.Errorf("Value of %s is corrupted", in.Key)
+	}
+	return &db.SetResponse{Location: uint64(len(in.Value))}, nil
+}
+
+// Concurrent requests reusing marshaling buffers never see each other's bytes
+func TestMarshalPool(t *testing.T) {
+	address := startPeer(t, &echoPeer{})
+	client, err := pool.Get(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("%d", i)
+			for j := 0; j < 50; j++ {
+				// Sizes vary so that buffers are grown and reused
+				size := (i*50 + j) % 7 * 100000
+				resp, err := client.Set(context.Background(), &db.SetRequest{Key: key, Value: bytes.Repeat([]byte(key), size)})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if resp.Location != uint64(size) {
+					t.Errorf("Peer got %d bytes, expected %d", resp.Location, size)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Longest chain returned by GetProof
+const maxProofSteps = 10000
+
+// Nodes from the location to its root with the content covered by their checksums,
+// so that clients can check offline that the value of the key descends from the root.
+// Segments stored by other servers are fetched from them.
+func (s *Server) GetProof(ctx context.Context, in *db.GetProofRequest) (*db.Proof, error) {
+	if !in.Chain {
+		var err error
+		if in.Key, err = s.normalizeKey(in.Key); err != nil {
+			return &db.Proof{}, err
+		}
+	}
+	address := indexingService.ResolveOwner(in.Location)
+	if address != s.Self {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.Proof{}, err
+		}
+		return client.GetProof(ctx, in)
+	}
+
+	proof := &db.Proof{Key: in.Key, Location: in.Location, Defining: -1}
+	found := in.Chain
+	now := time.Now().UnixNano()
+	for location := in.Location; ; {
+		if len(proof.Steps) >= maxProofSteps {
+			return &db.Proof{}, status.Errorf(codes.ResourceExhausted, "Chain of %x is longer than %d nodes", in.Location, maxProofSteps)
+		}
+		if owner := indexingService.ResolveOwner(location); owner != s.Self {
+			client, err := pool.Get(owner)
+			if err != nil {
+				return &db.Proof{}, err
+			}
+			rest, err := client.GetProof(ctx, &db.GetProofRequest{Key: in.Key, Location: location, Chain: found})
+			if err != nil {
+				return &db.Proof{}, err
+			}
+			if !found && rest.Defining >= 0 {
+				proof.Defining = int32(len(proof.Steps)) + rest.Defining
+				found = true
+			}
+			proof.Steps = append(proof.Steps, rest.Steps...)
+			proof.Root = rest.Root
+			break
+		}
+		node, err := store.Load(store.GetNode(location))
+		if err != nil {
+			return &db.Proof{}, err
+		}
+		if node == nil {
+			return &db.Proof{}, &dberrors.LocationNotFoundError{Location: location}
+		}
+		proof.Steps = append(proof.Steps, &db.ProofStep{
+			Location: node.Location,
+			Dep:      node.Dep,
+			Key:      node.Key,
+			Value:    node.Value,
+			Type:     node.Type,
+			Metadata: node.Metadata,
+			Checksum: node.Checksum,
+			Server:   s.Self,
+		})
+		// Like reads, expired nodes are skipped
+		if !found && node.Defines(in.Key) && !node.Expired(now) {
+			proof.Defining = int32(len(proof.Steps) - 1)
+			found = true
+		}
+		if node.Dep == math.MaxUint64 {
+			proof.Root = node.Location
+			break
+		}
+		location = node.Dep
+	}
+	if !found {
+		return &db.Proof{}, &dberrors.KeyNotFoundError{Key: in.Key}
+	}
+	return proof, nil
+}
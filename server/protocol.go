@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Levels of the split protocol, so that servers of different versions
+// can split during a rolling upgrade
+const (
+	// Single-shot Split without indexing locks or epochs
+	protocolLegacy = 1
+	// Leased indexing locks and epochs of the mapping
+	protocolLeases = 2
+	// Splits mapping a whole range to one server (used by Decommission)
+	protocolAbsorb = 3
+	// SetRequest.Location, so that the receiving server links the node (see pipelinedSet)
+	protocolPipelined = 4
+
+	protocolCurrent = protocolPipelined
+)
+
+// Interval of the negotiation with all servers
+const negotiateInterval = time.Minute
+
+// Level negotiated last (accessed atomically)
+var negotiatedProtocol int32
+
+// Features of the other servers at the last negotiation, by address
+var peerFeatures = struct {
+	lock     sync.RWMutex
+	features map[string]map[string]bool
+}{features: make(map[string]map[string]bool)}
+
+// Whether the server announced the feature (false until it is negotiated with)
+func peerSupports(address string, feature string) bool {
+	peerFeatures.lock.RLock()
+	defer peerFeatures.lock.RUnlock()
+	return peerFeatures.features[address][feature]
+}
+
+func setPeerFeatures(address string, version *db.VersionResponse) {
+	features := make(map[string]bool)
+	for _, feature := range version.Features {
+		features[feature] = true
+	}
+	peerFeatures.lock.Lock()
+	defer peerFeatures.lock.Unlock()
+	peerFeatures.features[address] = features
+}
+
+// Highest level spoken by this server
+func (s *Server) protocolLevel() int32 {
+	if s.ProtocolLevel > 0 && s.ProtocolLevel < protocolCurrent {
+		return int32(s.ProtocolLevel)
+	}
+	return protocolCurrent
+}
+
+// Highest level spoken by this server and all the servers
+func (s *Server) negotiateProtocol(ctx context.Context, servers []string) (int32, error) {
+	level := s.protocolLevel()
+	for _, addr := range servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err != nil {
+			return 0, err
+		}
+		peer := int32(protocolLeases)
+		version, err := client.GetVersion(ctx, &db.Empty{})
+		if status.Code(err) == codes.Unimplemented {
+			// Servers before versioning support no optional feature
+			version, err = &db.VersionResponse{}, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		setPeerFeatures(addr, version)
+		if version.ProtocolLevel > 0 {
+			peer = version.ProtocolLevel
+		}
+		// Servers before the negotiation use leases and epochs
+		if peer < level {
+			level = peer
+		}
+	}
+
+	if previous := atomic.SwapInt32(&negotiatedProtocol, level); previous != level {
+		events.Publish(eventProtocolNegotiated, map[string]string{
+			"level": strconv.Itoa(int(level)),
+		}, "Split protocol level %d negotiated (was %d)", level, previous)
+	}
+	return level, nil
+}
+
+// Renegotiate periodically, e.g. once all servers are upgraded
+func (s *Server) negotiateLoop() {
+	for {
+		s.lock.RLock()
+		servers := append([]string(nil), s.Servers...)
+		s.lock.RUnlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), negotiateInterval/2)
+		if _, err := s.negotiateProtocol(ctx, servers); err != nil {
+			log.Printf("Fail to negotiate the protocol level: %v", err)
+		}
+		cancel()
+		time.Sleep(negotiateInterval)
+	}
+}
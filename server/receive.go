@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Lifetime of a transfer token, extended by each node received with it
+const transferTokenTTL = splitLease
+
+// Max conflicting locations listed by PrepareReceive
+const maxConflictingListed = 1000
+
+type transfer struct {
+	sender      string
+	left, right uint32
+	expires     time.Time
+}
+
+// Transfers announced with PrepareReceive by token
+type Transfers struct {
+	lock      sync.Mutex
+	transfers map[string]*transfer
+}
+
+var transfers = Transfers{transfers: make(map[string]*transfer)}
+
+func (t *Transfers) Add(sender string, left, right uint32) (string, time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for token, transfer := range t.transfers {
+		if time.Now().After(transfer.expires) {
+			delete(t.transfers, token)
+		}
+	}
+	token := db.NewRequestId()
+	expires := time.Now().Add(transferTokenTTL)
+	t.transfers[token] = &transfer{sender, left, right, expires}
+	return token, expires
+}
+
+// Check that the node belongs to the transfer of the token and extend it
+func (t *Transfers) Use(token string, location uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	transfer, ok := t.transfers[token]
+	if !ok || time.Now().After(transfer.expires) {
+		delete(t.transfers, token)
+		return status.Errorf(codes.FailedPrecondition, "Transfer token %s is unknown or expired", token)
+	}
+	if hash := utils.KeyHash(location); hash < transfer.left || hash > transfer.right {
+		return status.Errorf(codes.InvalidArgument, "Location %x is outside the transfer [%x, %x] from %s", location, transfer.left, transfer.right, transfer.sender)
+	}
+	transfer.expires = time.Now().Add(transferTokenTTL)
+	return nil
+}
+
+// Revoke all tokens (e.g. when leaving normal mode)
+func (t *Transfers) Revoke() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.transfers = make(map[string]*transfer)
+}
+
+// Accept a transfer if the server is writable, has the memory for it,
+// and list the nodes it already holds in the range
+func (s *Server) PrepareReceive(ctx context.Context, in *db.PrepareReceiveRequest) (*db.PrepareReceiveResponse, error) {
+	if in.Left > in.Right {
+		return &db.PrepareReceiveResponse{}, status.Errorf(codes.InvalidArgument, "Invalid range [%x, %x]", in.Left, in.Right)
+	}
+	if err := s.checkWritable(); err != nil {
+		return &db.PrepareReceiveResponse{}, err
+	}
+	if err := s.checkMemory(); err != nil {
+		return &db.PrepareReceiveResponse{}, err
+	}
+	if high := atomic.LoadUint64(&memoryWatchdog.High); high > 0 && uint64(atomic.LoadInt64(&memoryWatchdog.Bytes)+in.Bytes) >= high {
+		return &db.PrepareReceiveResponse{}, &dberrors.QuotaExceededError{Resource: "memory", Limit: int64(high)}
+	}
+
+	resp := &db.PrepareReceiveResponse{}
+	err := store.IterateHashRange(in.Left, in.Right, func(node *storage.Node) bool {
+		if len(resp.Conflicting) == maxConflictingListed {
+			resp.ConflictingTruncated = true
+			return false
+		}
+		resp.Conflicting = append(resp.Conflicting, node.Location)
+		return true
+	})
+	if err != nil {
+		return &db.PrepareReceiveResponse{}, err
+	}
+	token, expires := transfers.Add(in.Sender, in.Left, in.Right)
+	resp.Token = token
+	resp.ExpiresAt = expires.UnixNano()
+	return resp, nil
+}
+
+// Add the nodes of a batch in order, stopping at the first failure
+// (reported in the response so that the sender knows which nodes were added).
+// The response tells the sender how long the batch took and how busy this server is,
+// so that it sizes the next batches (see transferWindow).
+func (s *Server) AddNodes(ctx context.Context, in *db.AddNodesRequest) (*db.AddNodesResponse, error) {
+	start := time.Now()
+	resp := &db.AddNodesResponse{QueueDepth: atomic.LoadInt64(&load.Inflight)}
+	for _, request := range in.Requests {
+		result, err := s.AddNode(ctx, request)
+		if err != nil {
+			st := status.Convert(err)
+			resp.Failure = &db.EntryStatus{Code: uint32(st.Code()), Message: st.Message()}
+			break
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	resp.ApplyMicros = time.Since(start).Microseconds()
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Transfer of the key hash of the location
+func prepareReceive(t *testing.T, s *Server, location uint64) *db.PrepareReceiveResponse {
+	t.Helper()
+	hash := utils.KeyHash(location)
+	resp, err := s.PrepareReceive(context.Background(), &db.PrepareReceiveRequest{Left: hash, Right: hash, Nodes: 1, Bytes: 100, Sender: "peer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// Location hash of the key
+func hashOf(key string) uint32 {
+	return utils.KeyHash(storage.CreateNode(key, nil, 0).Location)
+}
+
+func addTransferred(s *Server, token string, key string) (*db.Node, error) {
+	node := storage.Seal(storage.CreateNode(key, []byte("moved"), math.MaxUint64))
+	_, err := s.AddNode(context.Background(), &db.AddNodeRequest{Node: node, TransferToken: token})
+	return node, err
+}
+
+func TestPrepareReceive(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Left in the range by a previous attempt
+	stored, err := s.Set(ctx, &db.SetRequest{Key: "k", Value: []byte("v"), Dep: root.Location})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prepared := prepareReceive(t, s, stored.Location)
+	if len(prepared.Token) == 0 || prepared.ExpiresAt <= time.Now().UnixNano() {
+		t.Fatalf("PrepareReceive returned token %q expiring at %d", prepared.Token, prepared.ExpiresAt)
+	}
+	listed := false
+	for _, location := range prepared.Conflicting {
+		listed = listed || location == stored.Location
+	}
+	if !listed {
+		t.Errorf("Node stored in the range not listed as conflicting: %x", prepared.Conflicting)
+	}
+
+	node, err := addTransferred(s, prepared.Token, "k")
+	if err != nil {
+		t.Fatalf("Node of the transfer rejected: %v", err)
+	}
+	if store.GetNode(node.Location) == nil {
+		t.Errorf("Node of the transfer not stored")
+	}
+	// Only nodes of the announced range
+	var other string
+	for i := 0; other == ""; i++ {
+		if key := string(rune('a' + i)); hashOf(key) != utils.KeyHash(stored.Location) {
+			other = key
+		}
+	}
+	if _, err := addTransferred(s, prepared.Token, other); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Node outside the range returned %v, expected InvalidArgument", err)
+	}
+}
+
+func TestPrepareReceiveRejectedByMode(t *testing.T) {
+	s := newTestServer(t)
+	defer s.setMode(db.Mode_NORMAL)
+	location := storage.NewLocation(hashOf("k"))
+
+	for _, mode := range []db.Mode{db.Mode_READONLY, db.Mode_DRAINING} {
+		s.setMode(mode)
+		hash := utils.KeyHash(location)
+		_, err := s.PrepareReceive(context.Background(), &db.PrepareReceiveRequest{Left: hash, Right: hash, Sender: "peer"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("PrepareReceive in %s mode returned %v, expected FailedPrecondition", mode, err)
+		}
+	}
+
+	// Leaving normal mode revokes the tokens given before
+	s.setMode(db.Mode_NORMAL)
+	prepared := prepareReceive(t, s, location)
+	s.setMode(db.Mode_READONLY)
+	s.setMode(db.Mode_NORMAL)
+	if _, err := addTransferred(s, prepared.Token, "k"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Node with a revoked token returned %v, expected FailedPrecondition", err)
+	}
+}
+
+func TestStaleTransferToken(t *testing.T) {
+	s := newTestServer(t)
+	location := storage.NewLocation(hashOf("k"))
+
+	if _, err := addTransferred(s, "unknown", "k"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Node with an unknown token returned %v, expected FailedPrecondition", err)
+	}
+
+	prepared := prepareReceive(t, s, location)
+	transfers.lock.Lock()
+	transfers.transfers[prepared.Token].expires = time.Now().Add(-time.Second)
+	transfers.lock.Unlock()
+	node, err := addTransferred(s, prepared.Token, "k")
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Node with an expired token returned %v, expected FailedPrecondition", err)
+	}
+	if store.GetNode(node.Location) != nil {
+		t.Errorf("Node with an expired token was stored")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Overwrite the value of a node in place (e.g. a leaked secret or an erasure request),
+// so that chains through the node still resolve.
+// Snapshots see the new value since they reference the location.
+func (s *Server) RedactValue(ctx context.Context, in *db.RedactValueRequest) (*db.RedactValueResponse, error) {
+	if len(in.Reason) == 0 || len(in.Operator) == 0 {
+		return &db.RedactValueResponse{}, status.Errorf(codes.InvalidArgument, "A reason and an operator are required")
+	}
+	if !in.Forwarded {
+		in.Identity = acl.Identity(ctx)
+		in.Forwarded = true
+	}
+
+	address := indexingService.ResolveOwner(in.Location)
+	if address != s.Self {
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.RedactValueResponse{}, err
+		}
+		return client.RedactValue(ctx, in)
+	}
+	// A node being moved by a split would keep the old value on the target
+	if holder := indexingService.Holder(); len(holder) > 0 && time.Now().Before(indexingService.Expiry()) {
+		return &db.RedactValueResponse{}, &dberrors.IndexingLockedError{
+			Holder: holder,
+			Expiry: indexingService.Expiry(),
+		}
+	}
+
+	node, blobRemoved, err := store.Redact(in.Location, in.Value)
+	if err != nil {
+		return &db.RedactValueResponse{}, err
+	}
+	if _, err := store.Sync(); err != nil {
+		return &db.RedactValueResponse{}, fmt.Errorf("Fail to flush the log: %v", err)
+	}
+	if !node.Keyless {
+		readCache.Invalidate(node.Key)
+		s.invalidateKey(node.Key)
+	}
+
+	attributes := map[string]string{
+		"location": fmt.Sprintf("%x", in.Location),
+		"key":      node.Key,
+		"operator": in.Operator,
+		"identity": in.Identity,
+		"reason":   in.Reason,
+	}
+	log.Printf("Value of %x (%s) redacted by %s (identity %q): %s", in.Location, node.Key, in.Operator, in.Identity, in.Reason)
+	events.Publish(eventValueRedacted, attributes, "Value of %x (%s) redacted by %s: %s", in.Location, node.Key, in.Operator, in.Reason)
+
+	return &db.RedactValueResponse{
+		Key:         node.Key,
+		Checksum:    node.Checksum,
+		BlobRemoved: blobRemoved,
+	}, nil
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/DCsunset/openwhisk-grpc/auth"
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/indexing"
+	"github.com/DCsunset/openwhisk-grpc/placement"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Merge functions are persisted so that a restart doesn't disable merges
+const registryFile = "merge.json"
+
+var registryLock sync.Mutex
+
+func loadRegistrations() {
+	data, err := ioutil.ReadFile(statePath(registryFile))
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var registrations db.MergeRegistrations
+	if err := json.Unmarshal(data, &registrations); err != nil {
+		log.Fatalln(err)
+	}
+	applyRegistrations(registrations.Registrations)
+}
+
+func applyRegistrations(registrations []*db.MergeRegistration) {
+	for _, r := range registrations {
+		policy := engine.PolicyFromProto(r.Policy)
+		switch engine.Scope(r) {
+		case engine.ScopeGlobal:
+			core.SetGlobalMergeFunction(r.Name, policy)
+		case engine.ScopeRange:
+			core.SetRangeMergeFunction(r.Left, r.Right, r.Name, policy)
+		case engine.ScopePrefix:
+			core.SetPrefixMergeFunction(r.Prefix, r.Name, policy)
+		case engine.ScopeKey:
+			core.SetKeyMergeFunction(r.Key, r.Name, policy)
+		default:
+			core.SetMergeFunction(r.Location, r.Name, policy)
+		}
+	}
+}
+
+func saveRegistrations() error {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	data, _ := json.Marshal(&db.MergeRegistrations{
+		Registrations: core.Registrations(),
+	})
+	return ioutil.WriteFile(statePath(registryFile), data, 0644)
+}
+
+// Whether this server is the registry of record
+func (s *Server) isSeed() bool {
+	return len(s.Seed) == 0 || s.Seed == s.Self
+}
+
+func (s *Server) seedUnavailable(err error) error {
+	return status.Errorf(codes.Unavailable, "Seed %s is unreachable (merge function only registered on %s): %v", s.Seed, s.Self, err)
+}
+
+// Replicated registrations skip validation and forwarding, so only peers may send them
+func checkReplicated(ctx context.Context, replicated bool) error {
+	if replicated && verifier != nil && !auth.Verified(ctx) {
+		return status.Errorf(codes.PermissionDenied, "Replicated merge functions can only be sent by a peer")
+	}
+	return nil
+}
+
+func (s *Server) SetMergeFunction(ctx context.Context, in *db.SetMergeFunctionRequest) (*db.Empty, error) {
+	if err := checkReplicated(ctx, in.Replicated); err != nil {
+		return &db.Empty{}, err
+	}
+	address := indexingService.ResolveOwner(in.Location)
+	if address != s.Self && !in.Replicated {
+		// Forward request to the owner of the location
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.Empty{}, err
+		}
+		return client.SetMergeFunction(ctx, in)
+	}
+	if !in.Replicated {
+		if err := actionChecks.Validate(in.Name, in.SkipValidation); err != nil {
+			return &db.Empty{}, err
+		}
+	}
+
+	core.SetMergeFunction(in.Location, in.Name, engine.PolicyFromProto(in.Policy))
+	if err := saveRegistrations(); err != nil {
+		return &db.Empty{}, err
+	}
+
+	// Write through to the seed
+	if !in.Replicated && !s.isSeed() {
+		client, err := pool.Get(s.Seed)
+		if err == nil {
+			_, err = client.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
+				Location:   in.Location,
+				Name:       in.Name,
+				Policy:     in.Policy,
+				Replicated: true,
+			})
+		}
+		if err != nil {
+			return &db.Empty{}, s.seedUnavailable(err)
+		}
+	}
+	return &db.Empty{}, nil
+}
+
+func (s *Server) SetGlobalMergeFunction(ctx context.Context, in *db.SetGlobalMergeFunctionRequest) (*db.Empty, error) {
+	if err := checkReplicated(ctx, in.Replicated); err != nil {
+		return &db.Empty{}, err
+	}
+	if !in.Replicated {
+		if err := actionChecks.Validate(in.Name, in.SkipValidation); err != nil {
+			return &db.Empty{}, err
+		}
+	}
+	core.SetGlobalMergeFunction(in.Name, engine.PolicyFromProto(in.Policy))
+	if err := saveRegistrations(); err != nil {
+		return &db.Empty{}, err
+	}
+	if in.Replicated {
+		return &db.Empty{}, nil
+	}
+
+	// Forward request to all servers (including the seed)
+	for _, addr := range s.Servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			_, err = client.SetGlobalMergeFunction(ctx, &db.SetGlobalMergeFunctionRequest{
+				Name:       in.Name,
+				Policy:     in.Policy,
+				Replicated: true,
+			})
+		}
+		if err != nil {
+			if addr == s.Seed {
+				return &db.Empty{}, s.seedUnavailable(err)
+			}
+			log.Printf("Fail to set global merge function on %s: %v", addr, err)
+		}
+	}
+	return &db.Empty{}, nil
+}
+
+// Registrations applying to parents in [left, right] (prefixes and the global one apply everywhere)
+func registrationsInRange(left, right uint32) []*db.MergeRegistration {
+	var registrations []*db.MergeRegistration
+	for _, r := range core.Registrations() {
+		switch engine.Scope(r) {
+		case engine.ScopeGlobal, engine.ScopePrefix:
+		case engine.ScopeRange:
+			if r.Right < left || r.Left > right {
+				continue
+			}
+		case engine.ScopeKey:
+			if hash := placement.Hash(r.Key); hash < left || hash > right {
+				continue
+			}
+		default:
+			if hash := utils.KeyHash(r.Location); hash < left || hash > right {
+				continue
+			}
+		}
+		registrations = append(registrations, r)
+	}
+	return registrations
+}
+
+func (s *Server) GetMergeRegistrations(ctx context.Context, in *db.GetMergeRegistrationsRequest) (*db.MergeRegistrations, error) {
+	return &db.MergeRegistrations{Registrations: actionChecks.Annotate(registrationsInRange(in.Left, in.Right))}, nil
+}
+
+// Register a merge function for a key, a key prefix or a key hash range.
+// Key registrations are stored by the owner of the key, range ones by the owners
+// of overlapping ranges and prefix ones by all servers (and all of them by the seed).
+func (s *Server) SetScopedMergeFunction(ctx context.Context, in *db.SetScopedMergeFunctionRequest) (*db.Empty, error) {
+	if err := checkReplicated(ctx, in.Replicated); err != nil {
+		return &db.Empty{}, err
+	}
+	scopes := 0
+	for _, set := range []bool{len(in.Key) > 0, len(in.Prefix) > 0, in.Ranged} {
+		if set {
+			scopes += 1
+		}
+	}
+	if scopes != 1 {
+		return &db.Empty{}, status.Errorf(codes.InvalidArgument, "Exactly one of key, prefix or ranged must be set")
+	}
+	if in.Ranged && in.Left > in.Right {
+		return &db.Empty{}, status.Errorf(codes.InvalidArgument, "Invalid range [%x, %x]", in.Left, in.Right)
+	}
+	if len(in.Key) > 0 {
+		var err error
+		if in.Key, err = s.normalizeKey(in.Key); err != nil {
+			return &db.Empty{}, err
+		}
+		address := indexingService.LocateKey(in.Key)
+		if address != s.Self && !in.Replicated {
+			// Forward request to the owner of the key
+			client, err := pool.Get(address)
+			if err != nil {
+				return &db.Empty{}, err
+			}
+			return client.SetScopedMergeFunction(ctx, in)
+		}
+	}
+	if !in.Replicated {
+		if err := actionChecks.Validate(in.Name, in.SkipValidation); err != nil {
+			return &db.Empty{}, err
+		}
+	}
+
+	applyRegistrations([]*db.MergeRegistration{{
+		Key:    in.Key,
+		Prefix: in.Prefix,
+		Ranged: in.Ranged,
+		Left:   in.Left,
+		Right:  in.Right,
+		Name:   in.Name,
+		Policy: in.Policy,
+	}})
+	if err := saveRegistrations(); err != nil {
+		return &db.Empty{}, err
+	}
+	if in.Replicated {
+		return &db.Empty{}, nil
+	}
+
+	var targets []string
+	switch {
+	case len(in.Prefix) > 0:
+		targets = append(targets, s.Servers...)
+	case in.Ranged:
+		for _, m := range indexingService.List() {
+			if m.Left <= in.Right && in.Left <= m.Right && !contains(targets, m.Address) {
+				targets = append(targets, m.Address)
+			}
+		}
+	}
+	if !s.isSeed() && !contains(targets, s.Seed) {
+		targets = append(targets, s.Seed)
+	}
+	for _, addr := range targets {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			_, err = client.SetScopedMergeFunction(ctx, &db.SetScopedMergeFunctionRequest{
+				Key:        in.Key,
+				Prefix:     in.Prefix,
+				Ranged:     in.Ranged,
+				Left:       in.Left,
+				Right:      in.Right,
+				Name:       in.Name,
+				Policy:     in.Policy,
+				Replicated: true,
+			})
+		}
+		if err != nil {
+			if addr == s.Seed {
+				return &db.Empty{}, s.seedUnavailable(err)
+			}
+			log.Printf("Fail to set merge function on %s: %v", addr, err)
+		}
+	}
+	return &db.Empty{}, nil
+}
+
+// Which registration would fire for a parent and why
+func (s *Server) ResolveMergeFunction(ctx context.Context, in *db.ResolveMergeFunctionRequest) (*db.MergeResolution, error) {
+	var address string
+	if in.Location != 0 {
+		address = indexingService.ResolveOwner(in.Location)
+	} else {
+		var err error
+		if in.Key, err = s.normalizeKey(in.Key); err != nil {
+			return &db.MergeResolution{}, err
+		}
+		address = indexingService.LocateKey(in.Key)
+	}
+	if address != s.Self {
+		// Forward request to the owner of the parent
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.MergeResolution{}, err
+		}
+		return client.ResolveMergeFunction(ctx, in)
+	}
+
+	var matches []*db.MergeRegistration
+	if in.Location != 0 {
+		node := store.GetNode(in.Location)
+		if node == nil {
+			return &db.MergeResolution{}, &dberrors.LocationNotFoundError{Location: in.Location}
+		}
+		key := ""
+		if !node.Keyless {
+			key = node.Key
+		}
+		matches = core.Resolve(in.Location, true, key, utils.KeyHash(in.Location))
+	} else {
+		matches = core.Resolve(0, false, in.Key, placement.Hash(in.Key))
+	}
+	resolution := &db.MergeResolution{Matches: matches}
+	if len(matches) > 0 {
+		resolution.Name = matches[0].Name
+		resolution.Policy = matches[0].Policy
+		resolution.Scope = engine.Scope(matches[0])
+	}
+	return resolution, nil
+}
+
+// Pull registrations of owned ranges from the seed
+func (s *Server) pullRegistrations() {
+	if s.isSeed() {
+		return
+	}
+	client, err := pool.Get(s.Seed)
+	if err != nil {
+		log.Printf("Fail to pull merge functions: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	ranges := []indexing.Mapping{}
+	for _, m := range indexingService.List() {
+		if m.Address == s.Self {
+			ranges = append(ranges, m)
+		}
+	}
+	if len(ranges) == 0 {
+		// Still need the global merge function
+		ranges = append(ranges, indexing.Mapping{Left: math.MaxUint32, Right: 0})
+	}
+	for _, m := range ranges {
+		registrations, err := client.GetMergeRegistrations(ctx, &db.GetMergeRegistrationsRequest{
+			Left:  m.Left,
+			Right: m.Right,
+		})
+		if err != nil {
+			log.Printf("Fail to pull merge functions: %v", err)
+			return
+		}
+		applyRegistrations(registrations.Registrations)
+	}
+	if err := saveRegistrations(); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Cached values fetched or checked this recently are not checked again
+const repairCheckInterval = time.Second
+
+// Read repair of the read cache: values served from the cache of a non-owner
+// are compared with the node defining the key on the owner, and fetched again if stale
+// (e.g. after a lost invalidation).
+type ReadRepair struct {
+	lock sync.Mutex
+	// Checks started in the current second
+	window time.Time
+	used   int
+	// Checks in progress by key and location
+	pending map[string]bool
+
+	Checks  int64
+	Repairs int64
+	// Checks skipped by the rate limit
+	Skipped int64
+}
+
+var readRepair = ReadRepair{pending: make(map[string]bool)}
+
+// Start a check of the key unless one is in progress or perSecond checks were started this second
+func (r *ReadRepair) Acquire(id string, perSecond int) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pending[id] {
+		return false
+	}
+	if now := time.Now(); now.Sub(r.window) >= time.Second {
+		r.window, r.used = now, 0
+	}
+	if r.used >= perSecond {
+		atomic.AddInt64(&r.Skipped, 1)
+		return false
+	}
+	r.used += 1
+	r.pending[id] = true
+	return true
+}
+
+func (r *ReadRepair) Release(id string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.pending, id)
+}
+
+// Whether the cached response matches the metadata of the owner
+func sameNode(cached *db.GetResponse, owner *db.GetResponse) bool {
+	if cached.Type == db.NodeType_COUNTER || owner.Type == db.NodeType_COUNTER {
+		// Sums of deltas have no node of their own
+		return bytes.Equal(cached.Value, owner.Value)
+	}
+	if cached.Location != owner.Location || cached.CreatedAt != owner.CreatedAt {
+		return false
+	}
+	// Nodes written before checksums and owners older than read repair have none
+	return cached.Checksum == 0 || owner.Checksum == 0 || cached.Checksum == owner.Checksum
+}
+
+// Check the cached response of the request in the background (rate limited by readRepairPerSecond)
+func (s *Server) scheduleRepair(address string, in *db.GetRequest, cached *db.GetResponse) {
+	if s.ReadRepairPerSecond < 0 || !readCache.NeedsCheck(in.Key, in.Location, repairCheckInterval) {
+		return
+	}
+	id := cacheID(in.Key, in.Location)
+	if !readRepair.Acquire(id, s.ReadRepairPerSecond) {
+		return
+	}
+	request := &db.GetRequest{Key: in.Key, Location: in.Location}
+	go func() {
+		defer readRepair.Release(id)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := s.repair(ctx, address, request, cached)
+		if err != nil && !errors.Is(dberrors.FromStatus(err), dberrors.ErrKeyNotFound) {
+			log.Printf("Fail to check cached %s at %x on %s: %v", in.Key, in.Location, address, err)
+		}
+	}()
+}
+
+// Compare the cached response with the owner and fetch the value again if stale.
+// Returns the response to serve (the cached one if up to date).
+func (s *Server) repair(ctx context.Context, address string, in *db.GetRequest, cached *db.GetResponse) (*db.GetResponse, error) {
+	client, err := pool.Get(address)
+	if err != nil {
+		return cached, err
+	}
+	atomic.AddInt64(&readRepair.Checks, 1)
+	meta, err := client.Get(ctx, &db.GetRequest{Key: in.Key, Location: in.Location, MetadataOnly: true})
+	if errors.Is(dberrors.FromStatus(err), dberrors.ErrKeyNotFound) {
+		// Removed on the owner
+		readCache.Invalidate(in.Key)
+		return nil, err
+	}
+	if err != nil {
+		return cached, err
+	}
+	if meta.DeadlineExceeded {
+		// Inconclusive
+		return cached, nil
+	}
+	if sameNode(cached, meta) {
+		readCache.MarkChecked(in.Key, in.Location)
+		return cached, nil
+	}
+
+	resp, err := client.Get(ctx, &db.GetRequest{Key: in.Key, Location: in.Location, Subscriber: s.Self})
+	if err != nil {
+		return cached, err
+	}
+	if resp.DeadlineExceeded {
+		return cached, nil
+	}
+	readCache.Store(in.Key, in.Location, resp)
+	atomic.AddInt64(&readRepair.Repairs, 1)
+	log.Printf("Repaired cached %s at %x (node %x replaced by %x)", in.Key, in.Location, cached.Location, resp.Location)
+	return resp, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Mutating methods written to the replay log
+var recordedMethods = map[string]bool{
+	"/db.DbService/CreateRoot":             true,
+	"/db.DbService/Set":                    true,
+	"/db.DbService/BatchSet":               true,
+	"/db.DbService/Apply":                  true,
+	"/db.DbService/GetOrSet":               true,
+	"/db.DbService/AddNode":                true,
+	"/db.DbService/AddNodes":               true,
+	"/db.DbService/AddChild":               true,
+	"/db.DbService/UnlinkChild":            true,
+	"/db.DbService/RemoveNode":             true,
+	"/db.DbService/RemoveNodes":            true,
+	"/db.DbService/AddAliases":             true,
+	"/db.DbService/RemoveChildren":         true,
+	"/db.DbService/ReplaceChildren":        true,
+	"/db.DbService/Split":                  true,
+	"/db.DbService/TriggerSplit":           true,
+	"/db.DbService/SetMergeFunction":       true,
+	"/db.DbService/SetGlobalMergeFunction": true,
+	"/db.DbService/RetryMerge":             true,
+	"/db.DbService/CompressChain":          true,
+	"/db.DbService/RedactValue":            true,
+	"/db.DbService/Decommission":           true,
+}
+
+// Entry of the replay log (one JSON object per line)
+type ReplayRecord struct {
+	// Start of the request (unix nanoseconds)
+	Time   int64
+	Server string
+	// Increasing per server
+	ID     uint64
+	Method string
+	// client, or server for requests sent by other servers
+	Origin   string
+	Request  json.RawMessage
+	Response json.RawMessage `json:",omitempty"`
+	// Status code of the outcome
+	Code string
+}
+
+// Appends mutating requests to the replay log
+type Recorder struct {
+	lock    sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+	next    uint64
+}
+
+var recorder *Recorder
+
+func openRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func marshalMessage(m interface{}) json.RawMessage {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (r *Recorder) Record(record *ReplayRecord) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.next += 1
+	record.ID = r.next
+	if err := r.encoder.Encode(record); err != nil {
+		log.Printf("Fail to write replay log: %v", err)
+	}
+}
+
+// Record mutating requests with their outcome
+func (s *Server) recordInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !recordedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	origin := "client"
+	if incomingHops(ctx) > 0 {
+		origin = "server"
+	}
+	// Serialized before handlers change it (e.g. BatchSet in chain mode)
+	request := marshalMessage(req)
+	resp, err := handler(ctx, req)
+	record := &ReplayRecord{
+		Time:    start.UnixNano(),
+		Server:  s.Self,
+		Method:  info.FullMethod,
+		Origin:  origin,
+		Request: request,
+		Code:    status.Code(err).String(),
+	}
+	if err == nil {
+		record.Response = marshalMessage(resp)
+	}
+	recorder.Record(record)
+	return resp, err
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// How long the response of a Set with a RequestId is kept for its retries
+const requestIdRetention = 10 * time.Minute
+
+type requestEntry struct {
+	id string
+	// Closed once the first Set finished
+	done     chan struct{}
+	response *db.SetResponse
+	err      error
+	finished time.Time
+}
+
+// Responses of recent Sets by RequestId, so retries don't write duplicates
+type RequestIds struct {
+	lock    sync.Mutex
+	entries map[string]*requestEntry
+	// Finished entries, oldest first
+	order *list.List
+}
+
+var requestIds = RequestIds{
+	entries: make(map[string]*requestEntry),
+	order:   list.New(),
+}
+
+// Run the Set once per id: later calls wait for the first one and return its response.
+// Failed Sets are forgotten so that they can be retried.
+func (r *RequestIds) Do(ctx context.Context, id string, set func() (*db.SetResponse, error)) (*db.SetResponse, error) {
+	r.lock.Lock()
+	r.expire()
+	if entry, ok := r.entries[id]; ok {
+		r.lock.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return &db.SetResponse{}, ctx.Err()
+		}
+		if entry.err == nil {
+			return entry.response, nil
+		}
+		// The first attempt failed, so this one writes
+		return r.Do(ctx, id, set)
+	}
+	entry := &requestEntry{id: id, done: make(chan struct{})}
+	r.entries[id] = entry
+	r.lock.Unlock()
+
+	entry.response, entry.err = set()
+
+	r.lock.Lock()
+	if entry.err != nil {
+		delete(r.entries, id)
+	} else {
+		entry.finished = time.Now()
+		r.order.PushBack(entry)
+	}
+	r.lock.Unlock()
+	close(entry.done)
+	return entry.response, entry.err
+}
+
+func (r *RequestIds) expire() {
+	for e := r.order.Front(); e != nil; e = r.order.Front() {
+		entry := e.Value.(*requestEntry)
+		if time.Since(entry.finished) < requestIdRetention {
+			break
+		}
+		delete(r.entries, entry.id)
+		r.order.Remove(e)
+	}
+}
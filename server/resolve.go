@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reject requests resolving more than maxResolveKeys keys before doing any work
+func (s *Server) checkResolveKeys(keys []string) error {
+	if len(keys) > s.MaxResolveKeys {
+		return status.Errorf(codes.InvalidArgument, "%d keys to resolve (at most %d)", len(keys), s.MaxResolveKeys)
+	}
+	return nil
+}
+
+// Read the keys from the location, so that callers get the values they need next
+// without another round trip. Values beyond maxResolveBytes in total are left
+// to a Get (RESOURCE_EXHAUSTED), and keys failing to resolve get their status.
+func (s *Server) resolveKeys(ctx context.Context, location uint64, keys []string) []*db.ResolvedKey {
+	if len(keys) == 0 {
+		return nil
+	}
+	budget := s.MaxResolveBytes
+	resolved := make([]*db.ResolvedKey, 0, len(keys))
+	for _, key := range keys {
+		result := &db.ResolvedKey{Key: key}
+		resolved = append(resolved, result)
+		resp, err := s.Get(ctx, &db.GetRequest{Key: key, Location: location})
+		if err == nil && resp.DeadlineExceeded {
+			err = status.Errorf(codes.DeadlineExceeded, "Walk for %s stopped at %x", key, resp.ResumeFrom)
+		}
+		if err == nil && len(resp.Value) > budget {
+			err = status.Errorf(codes.ResourceExhausted, "Value of %s (%d bytes) exceeds the remaining %d bytes of maxResolveBytes", key, len(resp.Value), budget)
+		}
+		if err != nil {
+			st := status.Convert(err)
+			result.Status = &db.EntryStatus{Code: uint32(st.Code()), Message: st.Message()}
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		budget -= len(resp.Value)
+		result.Value = resp.Value
+		result.Location = resp.Location
+		result.CreatedAt = resp.CreatedAt
+		result.ContentType = resp.ContentType
+	}
+	return resolved
+}
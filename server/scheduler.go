@@ -0,0 +1,427 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Heavy background tasks run by the scheduler
+const (
+	taskCompaction  = "compaction"
+	taskCompression = "compression"
+	taskSweep       = "sweep"
+	taskPlacement   = "placement"
+)
+
+// Interval of compaction when it has windows configured
+const compactionInterval = time.Hour
+
+// When a background task may run (see maintenance in server.json)
+type TaskConfig struct {
+	// e.g. "22:00-06:00" or "Sat,Sun 00:00-24:00" in local time (always if empty)
+	Windows []string `json:"windows"`
+	// Max chains compressed or expired nodes removed per run (0 for unlimited)
+	Budget int `json:"budget"`
+}
+
+// Days and minutes of the day (end <= start wraps past midnight)
+type window struct {
+	days       [7]bool
+	start, end int
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func parseMinutes(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("Invalid time %s", value)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, err
+	}
+	total := hours*60 + minutes
+	if minutes < 0 || minutes >= 60 || total < 0 || total > 24*60 {
+		return 0, fmt.Errorf("Invalid time %s", value)
+	}
+	return total, nil
+}
+
+func parseWindow(value string) (window, error) {
+	var w window
+	fields := strings.Fields(value)
+	if len(fields) == 0 || len(fields) > 2 {
+		return w, fmt.Errorf("Invalid window %q", value)
+	}
+	if len(fields) == 2 {
+		for _, day := range strings.Split(fields[0], ",") {
+			// A range of days (e.g. Mon-Fri)
+			bounds := strings.Split(strings.ToLower(day), "-")
+			first, ok := weekdays[bounds[0]]
+			last, ok2 := weekdays[bounds[len(bounds)-1]]
+			if !ok || !ok2 || len(bounds) > 2 {
+				return w, fmt.Errorf("Invalid day %s in window %q", day, value)
+			}
+			for d := first; ; d = (d + 1) % 7 {
+				w.days[d] = true
+				if d == last {
+					break
+				}
+			}
+		}
+		fields = fields[1:]
+	} else {
+		for d := range w.days {
+			w.days[d] = true
+		}
+	}
+	bounds := strings.Split(fields[0], "-")
+	if len(bounds) != 2 {
+		return w, fmt.Errorf("Invalid window %q", value)
+	}
+	var err error
+	if w.start, err = parseMinutes(bounds[0]); err != nil {
+		return w, err
+	}
+	if w.end, err = parseMinutes(bounds[1]); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+func (w window) contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return w.days[t.Weekday()] && w.start <= m && m < w.end
+	}
+	// Windows past midnight belong to the day they start
+	return (w.days[t.Weekday()] && m >= w.start) || (w.days[(t.Weekday()+6)%7] && m < w.end)
+}
+
+// Next start of the window after t
+func (w window) next(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for d := 0; d <= 7; d++ {
+		start := day.AddDate(0, 0, d).Add(time.Duration(w.start) * time.Minute)
+		if start.After(t) && w.days[start.Weekday()] {
+			return start
+		}
+	}
+	return t
+}
+
+type task struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context, budget int) error
+	// Only run when triggered unless windows are configured
+	manual bool
+
+	windows []window
+	budget  int
+	// When the task is due (postponed to the next window if outside)
+	due          time.Time
+	next         time.Time
+	running      bool
+	lastRun      time.Time
+	lastDuration time.Duration
+	lastError    string
+	trigger      chan struct{}
+}
+
+// Runs heavy background tasks inside their maintenance windows
+type Scheduler struct {
+	// Clock (replaced in tests)
+	Now func() time.Time
+
+	lock    sync.Mutex
+	cond    *sync.Cond
+	tasks   map[string]*task
+	order   []string
+	configs map[string]TaskConfig
+	paused  bool
+	// Max tasks running at once
+	concurrency int
+	running     int
+	// Closed and replaced when the config changes
+	changed chan struct{}
+}
+
+var scheduler = newScheduler()
+
+func newScheduler() *Scheduler {
+	s := &Scheduler{
+		Now:         time.Now,
+		tasks:       make(map[string]*task),
+		concurrency: 1,
+		changed:     make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.lock)
+	return s
+}
+
+// Apply the maintenance config (invalid windows are ignored)
+func (s *Scheduler) Configure(configs map[string]TaskConfig, concurrency int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.configs = configs
+	s.concurrency = concurrency
+	if s.concurrency <= 0 {
+		s.concurrency = 1
+	}
+	for _, t := range s.tasks {
+		s.configure(t)
+	}
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.cond.Broadcast()
+}
+
+// Must be called with the lock held
+func (s *Scheduler) configure(t *task) {
+	config := s.configs[t.name]
+	t.budget = config.Budget
+	t.windows = nil
+	for _, value := range config.Windows {
+		w, err := parseWindow(value)
+		if err != nil {
+			log.Printf("Maintenance window of %s ignored: %v", t.name, err)
+			continue
+		}
+		t.windows = append(t.windows, w)
+	}
+}
+
+// Run the task every interval inside its windows
+func (s *Scheduler) Register(name string, interval time.Duration, manual bool, run func(ctx context.Context, budget int) error) {
+	s.lock.Lock()
+	t := &task{
+		name:     name,
+		interval: interval,
+		run:      run,
+		manual:   manual,
+		due:      s.Now().Add(interval),
+		trigger:  make(chan struct{}, 1),
+	}
+	s.configure(t)
+	s.tasks[name] = t
+	s.order = append(s.order, name)
+	s.lock.Unlock()
+
+	go s.loop(t)
+}
+
+// Must be called with the lock held
+func (s *Scheduler) inWindow(t *task, now time.Time) bool {
+	if len(t.windows) == 0 {
+		return !t.manual
+	}
+	for _, w := range t.windows {
+		if w.contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Next run of the task (zero if it only runs when triggered).
+// Must be called with the lock held.
+func (s *Scheduler) plan(t *task) time.Time {
+	if len(t.windows) == 0 {
+		if t.manual {
+			return time.Time{}
+		}
+		return t.due
+	}
+	if s.inWindow(t, t.due) {
+		return t.due
+	}
+	var next time.Time
+	for _, w := range t.windows {
+		if start := w.next(t.due); next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
+
+func (s *Scheduler) loop(t *task) {
+	for {
+		s.lock.Lock()
+		t.next = s.plan(t)
+		changed := s.changed
+		s.lock.Unlock()
+
+		var fire <-chan time.Time
+		var timer *time.Timer
+		if !t.next.IsZero() {
+			timer = time.NewTimer(t.next.Sub(s.Now()))
+			fire = timer.C
+		}
+		forced, reconfigured := false, false
+		select {
+		case <-fire:
+		case <-t.trigger:
+			forced = true
+		case <-changed:
+			reconfigured = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if reconfigured {
+			continue
+		}
+
+		s.lock.Lock()
+		now := s.Now()
+		t.due = now.Add(t.interval)
+		if !forced && (s.paused || !s.inWindow(t, now)) {
+			s.lock.Unlock()
+			continue
+		}
+		for s.running >= s.concurrency {
+			s.cond.Wait()
+		}
+		s.running += 1
+		t.running = true
+		budget := t.budget
+		s.lock.Unlock()
+
+		events.Publish(eventMaintenanceStarted, map[string]string{"task": t.name}, "Maintenance task %s started", t.name)
+		err := t.run(context.Background(), budget)
+		duration := s.Now().Sub(now)
+
+		s.lock.Lock()
+		s.running -= 1
+		t.running = false
+		t.lastRun = now
+		t.lastDuration = duration
+		t.lastError = ""
+		if err != nil {
+			t.lastError = err.Error()
+		}
+		next := s.plan(t)
+		s.cond.Signal()
+		s.lock.Unlock()
+
+		if err != nil {
+			log.Printf("Maintenance task %s failed: %v", t.name, err)
+		}
+		events.Publish(eventMaintenanceFinished, map[string]string{
+			"task":  t.name,
+			"error": t.lastError,
+		}, "Maintenance task %s finished in %s (next run %s)", t.name, duration.Round(time.Millisecond), formatRun(next))
+	}
+}
+
+func formatRun(t time.Time) string {
+	if t.IsZero() {
+		return "when triggered"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Run the task now, even outside its windows or when paused
+func (s *Scheduler) Trigger(name string) bool {
+	s.lock.Lock()
+	t, ok := s.tasks[name]
+	s.lock.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case t.trigger <- struct{}{}:
+	default:
+		// Already triggered
+	}
+	return true
+}
+
+func (s *Scheduler) Pause(paused bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.paused = paused
+}
+
+func (s *Scheduler) ToProto() ([]*db.MaintenanceTask, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var tasks []*db.MaintenanceTask
+	now := s.Now()
+	for _, name := range s.order {
+		t := s.tasks[name]
+		task := &db.MaintenanceTask{
+			Name:               t.name,
+			Running:            t.running,
+			InWindow:           s.inWindow(t, now),
+			LastDurationMillis: t.lastDuration.Milliseconds(),
+			LastError:          t.lastError,
+		}
+		if !t.lastRun.IsZero() {
+			task.LastRun = t.lastRun.UnixNano()
+		}
+		if !t.next.IsZero() {
+			task.NextRun = t.next.UnixNano()
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, s.paused
+}
+
+// Register the heavy background tasks of the server
+func (s *Server) scheduleMaintenance() {
+	scheduler.Register(taskSweep, ttlSweepInterval, false, func(ctx context.Context, budget int) error {
+		return s.sweep()
+	})
+	scheduler.Register(taskCompression, compressInterval, false, func(ctx context.Context, budget int) error {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		s.compressPass(budget)
+		return nil
+	})
+	scheduler.Register(taskCompaction, compactionInterval, true, func(ctx context.Context, budget int) error {
+		_, err := s.compact(ctx, budget)
+		return err
+	})
+	scheduler.Register(taskPlacement, placementInterval, true, func(ctx context.Context, budget int) error {
+		_, err := s.migratePlacement(ctx, budget)
+		return err
+	})
+}
+
+// Run a maintenance task now
+func (s *Server) RunTask(ctx context.Context, in *db.RunTaskRequest) (*db.Empty, error) {
+	if !scheduler.Trigger(in.Name) {
+		return &db.Empty{}, status.Errorf(codes.NotFound, "No maintenance task %s", in.Name)
+	}
+	return &db.Empty{}, nil
+}
+
+// Pause or resume scheduled maintenance (triggered tasks still run)
+func (s *Server) PauseMaintenance(ctx context.Context, in *db.PauseMaintenanceRequest) (*db.Empty, error) {
+	scheduler.Pause(in.Paused)
+	log.Printf("Maintenance paused: %v", in.Paused)
+	events.Publish(eventMaintenancePaused, map[string]string{"paused": strconv.FormatBool(in.Paused)}, "Maintenance paused: %v", in.Paused)
+	return &db.Empty{}, nil
+}
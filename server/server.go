@@ -3,50 +3,279 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"math"
-	"math/rand"
+	"os"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/DCsunset/openwhisk-grpc/auth"
 	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
 	"github.com/DCsunset/openwhisk-grpc/indexing"
+	"github.com/DCsunset/openwhisk-grpc/placement"
 	"github.com/DCsunset/openwhisk-grpc/storage"
-	"github.com/DCsunset/openwhisk-grpc/utils"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
 	Servers          []string `json:"servers"`
 	AvailableServers []string `json:"availableServers"`
-	// Self address
+	// Self address (used by peers to dial this server)
 	Self string `json:"self"`
-	// Initial server
+	// Addresses to listen on (TCP or unix:/path)
+	BindAddresses []string `json:"bindAddresses"`
+	// Initial server (defaults to the seed)
 	Initial string `json:"initial"`
+	// Seed server keeping the authoritative membership
+	Seed string `json:"seed"`
+	// Primary followed by this server as a warm standby until Promote
+	StandbyOf string `json:"standbyOf"`
+	// Publish standby.lagging when the standby is behind for this long (default 10)
+	StandbyLagAlertSeconds int `json:"standbyLagAlertSeconds"`
 	// Split threshold
 	Threshold int `json:"threshold"`
+	// Sample one in this many reads and writes for HotPaths (0 for default 16, -1 disables it)
+	AccessSampleEvery int `json:"accessSampleEvery"`
+	// Max length of keys in bytes (0 for default 4096, -1 for unlimited)
+	MaxKeyBytes int `json:"maxKeyBytes"`
+	// Steps applied to keys before use ("trim", "lowercase"), the same on all servers
+	KeyNormalization []string `json:"keyNormalization"`
+	// Append mutating requests to this file for dbreplay (disabled if empty)
+	ReplayLog string `json:"replayLog"`
+	// Highest split protocol level spoken (0 for the current one).
+	// Lower it to act as an older server, e.g. to test rolling upgrades.
+	ProtocolLevel int `json:"protocolLevel"`
+	// Also split when values take more bytes than this (0 disables it)
+	ThresholdBytes int64 `json:"thresholdBytes"`
+	// Length of key prefixes counted by TopSpaceConsumers (0 for default 4)
+	SpacePrefixLength int `json:"spacePrefixLength"`
+	// Manual splits are refused below this number of nodes
+	MinSplitSize int `json:"minSplitSize"`
+	// Allow Set with Dep=0 to use the global root (deprecated)
+	AllowImplicitRoot bool `json:"allowImplicitRoot"`
+	// HTTP address for debugging endpoints (disabled if empty)
+	MetricsAddress string `json:"metricsAddress"`
+	// Serve /debug/pprof on the metrics address
+	EnablePprof bool `json:"enablePprof"`
+	// Limits of the grpc server (0 means default)
+	MaxConcurrentStreams    uint32 `json:"maxConcurrentStreams"`
+	MaxConnectionAgeSeconds int    `json:"maxConnectionAgeSeconds"`
+	// Max size of a received message in MB (0 for default 4), e.g. for large BatchSet calls
+	MaxRecvMsgSizeMB int `json:"maxRecvMsgSizeMB"`
+	// Time given to requests on connections closed for their age (0 for default 10)
+	MaxConnectionAgeGraceSeconds int `json:"maxConnectionAgeGraceSeconds"`
+	// Close connections idle for this long (0 means never)
+	MaxConnectionIdleSeconds int `json:"maxConnectionIdleSeconds"`
+	// Ping idle clients after this long and drop them without an answer within the timeout (0 for 60 and 20)
+	KeepaliveTimeSeconds    int `json:"keepaliveTimeSeconds"`
+	KeepaliveTimeoutSeconds int `json:"keepaliveTimeoutSeconds"`
+	// Min interval of pings accepted from clients (0 for default 20)
+	KeepaliveMinTimeSeconds int `json:"keepaliveMinTimeSeconds"`
+	// Same for outbound connections to other servers (0 for 30 and 10)
+	ClientKeepaliveTimeSeconds    int `json:"clientKeepaliveTimeSeconds"`
+	ClientKeepaliveTimeoutSeconds int `json:"clientKeepaliveTimeoutSeconds"`
+	// Only ping outbound connections with requests in flight
+	KeepaliveStreamsOnly bool `json:"keepaliveStreamsOnly"`
+	// Max number of outbound connections (0 means unlimited)
+	MaxConnections int `json:"maxConnections"`
+	// Attempts of forwarded batches failing transiently (default 3) and the wait before the second one
+	ForwardAttempts      int `json:"forwardAttempts"`
+	ForwardBackoffMillis int `json:"forwardBackoffMillis"`
+	// Servers a chain walk may continue on before failing (default 8, negative for no limit)
+	MaxRemoteHops int `json:"maxRemoteHops"`
+	// Time between automatic splits of this server (default 10), widened up to
+	// maxSplitIntervalSeconds (default 600) after failed splits
+	// and when no more than splitReserveServers (default 1) servers are available
+	MinSplitIntervalSeconds int `json:"minSplitIntervalSeconds"`
+	MaxSplitIntervalSeconds int `json:"maxSplitIntervalSeconds"`
+	SplitReserveServers     int `json:"splitReserveServers"`
+	// Without available servers, split onto the least loaded peer owning a range
+	// instead of not splitting (until servers are added)
+	LastResortSplit bool `json:"lastResortSplit"`
+	// Moved nodes read back from the target before a split removes the local copies
+	// (default 16, negative to skip) and failed reads tolerated (default 0).
+	// The split is rolled back when more fail.
+	SplitVerifySamples   int `json:"splitVerifySamples"`
+	SplitVerifyTolerance int `json:"splitVerifyTolerance"`
+	// Mutating requests kept in memory for GetJournal (default 1024, negative to disable).
+	// Beyond journalMaxPerKey entries of a key (0 for no limit), one request in journalSampleEvery (default 10) is kept.
+	JournalSize        int `json:"journalSize"`
+	JournalMaxPerKey   int `json:"journalMaxPerKey"`
+	JournalSampleEvery int `json:"journalSampleEvery"`
+	// Keys that non-owners can serve from their read cache
+	Cacheable []CacheRule `json:"cacheable"`
+	// Key prefixes whose Sets are serialized on the owner (see SetRequest.SerializeWrites)
+	SerializeWrites []string `json:"serializeWrites"`
+	// Storage backend of nodes: memory (default) or disk
+	Backend string `json:"backend"`
+	// Directory of the disk backend
+	DataDir string `json:"dataDir"`
+	// Directory of the state files (aliases.json, mode.json, ...), default the working directory
+	StateDir string `json:"stateDir"`
+	// Shared secret signing internal and admin RPCs (disabled if empty)
+	ClusterSecret     string `json:"clusterSecret"`
+	ClusterSecretFile string `json:"clusterSecretFile"`
+	// Allowed clock skew of signed requests (default 30)
+	AuthSkewSeconds int `json:"authSkewSeconds"`
+	// ACL of identities, methods and keys (disabled if empty)
+	ACLFile string `json:"aclFile"`
+	// Register the grpc reflection service (for grpcurl and similar tools)
+	EnableReflection bool `json:"enableReflection"`
+	// Nodes received by splits warmed before taking traffic (0 for default, negative to disable)
+	WarmUpNodes int `json:"warmUpNodes"`
+	// Max nodes visited by each warm-up (0 for default)
+	WarmUpBudget int `json:"warmUpBudget"`
+	// Rules co-locating keys with a common prefix (used by the seed if placement.json is missing)
+	Placement []placement.Rule `json:"placement"`
+	// Values larger than this are spilled to blob files (0 disables spilling)
+	SpillThreshold int `json:"spillThreshold"`
+	// Directory of blob files (default ./blobs)
+	BlobDir string `json:"blobDir"`
+	// OTLP/HTTP collector receiving trace spans (disabled if empty)
+	TraceEndpoint string `json:"traceEndpoint"`
+	// Max children of a node (0 means unlimited)
+	MaxChildren int `json:"maxChildren"`
+	// Insert bucket nodes instead of rejecting children of full nodes
+	AutoBucket bool `json:"autoBucket"`
+	// When writes without Durability are acknowledged: memory, wal or replicated (default memory)
+	Durability string `json:"durability"`
+	// Split at the median of the requests in the heatmap instead of the middle of the range
+	SplitByLoad bool `json:"splitByLoad"`
+	// Attempts of a failing merge before it is dead-lettered (0 for default 8)
+	MaxMergeAttempts int `json:"maxMergeAttempts"`
+	// Cap of the backoff between merge attempts (0 for default 300)
+	MaxMergeRetryDelaySeconds int `json:"maxMergeRetryDelaySeconds"`
+	// Fail forwards to peers with this many requests in flight (0 disables it)
+	ShedInflight int `json:"shedInflight"`
+	// Fail forwards to peers with a p99 latency above this (0 disables it)
+	ShedP99Millis int `json:"shedP99Millis"`
+	// Compress linear histories of keys with these prefixes
+	CompressPrefixes []string `json:"compressPrefixes"`
+	// Min number of nodes of a compressed run (default 8)
+	CompressRunLength int `json:"compressRunLength"`
+	// Nodes younger than this are not compressed (default 3600)
+	CompressRetentionSeconds int `json:"compressRetentionSeconds"`
+	// Replaced locations resolve to their successors for this long (default 86400, negative disables aliases)
+	AliasRetentionSeconds int `json:"aliasRetentionSeconds"`
+	// Max aliases followed to resolve a location (default 8)
+	MaxAliasChain int `json:"maxAliasChain"`
+	// Nodes per AddNodes call of a split transfer (default 1 to 512),
+	// sized to keep the time the receiver takes to add a batch under transferApplyTargetMillis (default 50)
+	TransferBatchMin          int `json:"transferBatchMin"`
+	TransferBatchMax          int `json:"transferBatchMax"`
+	TransferApplyTargetMillis int `json:"transferApplyTargetMillis"`
+	// Max keys resolved by a Set or GetNode (default 16) and bytes of their values (default 65536)
+	MaxResolveKeys  int `json:"maxResolveKeys"`
+	MaxResolveBytes int `json:"maxResolveBytes"`
+	// Max background checks per second of cached values against their owner (default 50, negative disables read repair)
+	ReadRepairPerSecond int `json:"readRepairPerSecond"`
+	// Record requests slower than this in the slow log (0 disables it)
+	SlowLogMillis int `json:"slowLogMillis"`
+	// Thresholds by method name (e.g. "Get") overriding slowLogMillis
+	SlowLogMethodMillis map[string]int `json:"slowLogMethodMillis"`
+	// Audit the store before serving (like the -verify flag)
+	VerifyOnStart bool `json:"verifyOnStart"`
+	// Reject writes above this memory usage in MB (0 disables the watchdog)
+	MemoryHighWaterMB int `json:"memoryHighWaterMB"`
+	// Accept writes again below this usage in MB (default 80% of the high-water mark)
+	MemoryLowWaterMB int `json:"memoryLowWaterMB"`
+	// Use 90% of the cgroup memory limit if memoryHighWaterMB is 0
+	MemoryLimitFromCgroup bool `json:"memoryLimitFromCgroup"`
+	// Windows and budgets of background tasks by name (compaction, compression, sweep)
+	Maintenance map[string]TaskConfig `json:"maintenance"`
+	// Max background tasks running at once (default 1)
+	MaintenanceConcurrency int `json:"maintenanceConcurrency"`
 
-	lock                sync.RWMutex
-	mergeFunction       map[uint64]string
-	globalMergeFunction string
+	lock sync.RWMutex
+	// Current db.Mode (accessed atomically)
+	mode int32
+	// Reported capacity of each server
+	capacity        map[string]int64
+	membershipEpoch uint64
+	// Split targets reserved on the seed by their requesters
+	reserved map[string]string
+	// Nodes per second of the last split transfer
+	transferRate float64
 }
 
-var store = storage.Store{}
+// Local nodes and merge functions
+var core = engine.Engine{}
+var store = &core.Store
 var indexingService = indexing.Service{}
+var pool = ConnPool{}
 
 func (s *Server) Init() {
-	store.Init()
 	indexingService.Init()
-	s.globalMergeFunction = ""
-	s.mergeFunction = make(map[uint64]string)
+	pool.Init()
+	readCache.Init()
 
 	// Server configuration
-	data, err := ioutil.ReadFile("./server.json")
+	data, err := ioutil.ReadFile(configFile)
 	if err != nil {
 		log.Fatalln(err)
 	}
 	json.Unmarshal(data, s)
+	s.applyDefaults()
+	stateDir = s.StateDir
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		log.Fatalln(err)
+	}
+	switch s.Backend {
+	case "", "memory":
+	case "disk":
+		store.Backend, err = storage.NewDiskBackend(s.DataDir)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	default:
+		log.Fatalf("Invalid backend %s", s.Backend)
+	}
+	if s.SpillThreshold > 0 {
+		store.Blobs, err = storage.NewBlobStore(s.BlobDir, s.SpillThreshold)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	store.PrefixLength = s.SpacePrefixLength
+	if len(s.ReplayLog) > 0 {
+		recorder, err = openRecorder(s.ReplayLog)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+	s.applyTunables()
+	core.Init()
+	s.loadMode()
+	s.loadStandby()
+	loadRegistrations()
+	s.loadPlacement()
+	loadSnapshots()
+	loadAliases()
+	loadValidation()
+	s.capacity = make(map[string]int64)
+	s.reserved = make(map[string]string)
+	if len(s.Seed) > 0 && !contains(s.Servers, s.Self) {
+		s.Servers = append(s.Servers, s.Self)
+	}
+	s.loadSecret()
+	s.loadACL()
+	s.configurePool()
+	events.Server = s.Self
+	if len(s.TraceEndpoint) > 0 {
+		tracing.SetExporter(tracing.NewOTLPExporter(s.TraceEndpoint, s.Self))
+	}
+
+	go s.mergeWorker()
 
 	// Use initial server first
 	indexingService.AddMapping(
@@ -57,469 +286,892 @@ func (s *Server) Init() {
 }
 
 func (self *Server) RemoveChildren(ctx context.Context, in *db.RemoveChildrenRequest) (*db.Empty, error) {
-	address := indexingService.Locate(utils.KeyHash(in.Location))
+	address := indexingService.ResolveOwner(in.Location)
 
 	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.Empty{}, err
+		}
 		node := store.GetNode(in.Location)
-		for _, child := range node.Children {
-			store.RemoveNode(child)
+		if node == nil {
+			return &db.Empty{}, &dberrors.LocationNotFoundError{Location: in.Location}
+		}
+		// Children might be stored on other servers
+		_, err := self.RemoveNodes(ctx, &db.RemoveNodesRequest{
+			Locations: snapshots.Unpinned(node.Children),
+		})
+		if err != nil {
+			return &db.Empty{}, err
+		}
+		if _, err := store.ReplaceChildren(in.Location, nil); err != nil {
+			return &db.Empty{}, err
 		}
-		node.Children = nil
 		return &db.Empty{}, nil
 	} else {
 		// Forward request to the correct server
-		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		client, err := pool.Get(address)
 		if err != nil {
 			return &db.Empty{}, err
 		}
-		defer conn.Close()
-		client := db.NewDbServiceClient(conn)
 
 		return client.RemoveChildren(ctx, in)
 	}
 }
 
+func (self *Server) ReplaceChildren(ctx context.Context, in *db.ReplaceChildrenRequest) (*db.ReplaceChildrenResponse, error) {
+	address := indexingService.ResolveOwner(in.Location)
+
+	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.ReplaceChildrenResponse{}, err
+		}
+		if in.CheckVersion {
+			old, version, err := store.ReplaceChildrenIf(in.Location, in.Children, in.ExpectedVersion)
+			return &db.ReplaceChildrenResponse{Children: old, Version: version}, err
+		}
+		old, err := store.ReplaceChildren(in.Location, in.Children)
+		return &db.ReplaceChildrenResponse{Children: old}, err
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.ReplaceChildrenResponse{}, err
+		}
+
+		return client.ReplaceChildren(ctx, in)
+	}
+}
+
+// Create a root for an independent DAG
+func (self *Server) CreateRoot(ctx context.Context, in *db.Empty) (*db.CreateRootResponse, error) {
+	root := storage.CreateRoot()
+	address := indexingService.ResolveOwner(root.Location)
+
+	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.CreateRootResponse{}, err
+		}
+		if _, err := store.AddNode(root); err != nil {
+			return &db.CreateRootResponse{}, err
+		}
+	} else {
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.CreateRootResponse{}, err
+		}
+		if _, err := client.AddNode(ctx, &db.AddNodeRequest{Node: root}); err != nil {
+			return &db.CreateRootResponse{}, err
+		}
+	}
+	return &db.CreateRootResponse{Location: root.Location}, nil
+}
+
+func (self *Server) RemoveNode(ctx context.Context, in *db.RemoveNodeRequest) (*db.Empty, error) {
+	address := indexingService.ResolveOwner(in.Location)
+
+	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.Empty{}, err
+		}
+		if len(snapshots.Unpinned([]uint64{in.Location})) > 0 {
+			store.RemoveNode(in.Location)
+		}
+		if in.Successor != 0 {
+			self.aliasTo(in.Successor, in.Location)
+		}
+		return &db.Empty{}, nil
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.Empty{}, err
+		}
+
+		return client.RemoveNode(ctx, in)
+	}
+}
+
+// Remove nodes in bulk, grouped by their owners
+func (self *Server) RemoveNodes(ctx context.Context, in *db.RemoveNodesRequest) (*db.RemoveNodesResponse, error) {
+	if in.Local {
+		if err := self.checkWritable(); err != nil {
+			return &db.RemoveNodesResponse{}, err
+		}
+		return &db.RemoveNodesResponse{Removed: int64(store.RemoveNodes(in.Locations))}, nil
+	}
+
+	groups := make(map[string][]uint64)
+	for _, location := range in.Locations {
+		address := indexingService.ResolveOwner(location)
+		groups[address] = append(groups[address], location)
+	}
+
+	removed := int64(0)
+	for address, locations := range groups {
+		if address == self.Self {
+			if err := self.checkWritable(); err != nil {
+				return &db.RemoveNodesResponse{Removed: removed}, err
+			}
+			removed += int64(store.RemoveNodes(locations))
+		} else {
+			// Forward request to the correct server
+			client, err := pool.Get(address)
+			if err != nil {
+				return &db.RemoveNodesResponse{Removed: removed}, err
+			}
+			resp, err := client.RemoveNodes(ctx, &db.RemoveNodesRequest{
+				Locations: locations,
+			})
+			if err != nil {
+				return &db.RemoveNodesResponse{Removed: removed}, err
+			}
+			removed += resp.Removed
+		}
+	}
+	return &db.RemoveNodesResponse{Removed: removed}, nil
+}
+
 func (self *Server) AddChild(ctx context.Context, in *db.AddChildRequest) (*db.Node, error) {
-	address := indexingService.Locate(utils.KeyHash(in.Location))
+	address := indexingService.ResolveOwner(in.Location)
 
 	if address == self.Self {
-		node := store.AddChild(in.Location, in.Child)
-		return &db.Node{
-			Location: node.Location,
-			Dep:      node.Dep,
-			Key:      node.Key,
-			Value:    node.Value,
-			Children: node.Children,
-		}, nil
+		if err := self.checkWritable(); err != nil {
+			return &db.Node{}, err
+		}
+		node, _, err := core.Link(in.Location, in.Child)
+		if err != nil {
+			return &db.Node{}, err
+		}
+		if len(node.Children) > 1 {
+			convergence.Diverged(in.Location)
+		}
+		return node, nil
 	} else {
 		// Forward request to the correct server
-		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		client, err := pool.Get(address)
 		if err != nil {
 			return &db.Node{}, err
 		}
-		defer conn.Close()
-		client := db.NewDbServiceClient(conn)
 
 		return client.AddChild(ctx, in)
 	}
 }
 
 func (s *Server) Get(ctx context.Context, in *db.GetRequest) (*db.GetResponse, error) {
+	if s.getMode() == db.Mode_STANDBY {
+		return s.standbyGet(ctx, in)
+	}
+	if len(in.Snapshot) > 0 {
+		snapshot, ok := snapshots.Get(in.Snapshot)
+		if !ok {
+			return &db.GetResponse{}, status.Errorf(codes.NotFound, "Snapshot %s not found", in.Snapshot)
+		}
+		// Nodes written after the snapshot are skipped
+		if in.AsOf == 0 || in.AsOf > snapshot.Time {
+			in.AsOf = snapshot.Time
+		}
+	}
+	var err error
+	if in.Key, err = s.normalizeKey(in.Key); err != nil {
+		return &db.GetResponse{}, err
+	}
 	address := indexingService.LocateKey(in.Key)
+	if in.Continuation {
+		address = indexingService.ResolveOwner(in.Location)
+	}
+
+	if !in.Continuation {
+		setServedByHeader(ctx, s.Self)
+	}
 
 	if address == s.Self {
-		value, err := store.Get(in.Key, in.Location)
-		return &db.GetResponse{Value: value}, err
-	} else {
-		// Forward request to the correct server
-		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		if len(in.Subscriber) > 0 {
+			readCache.Subscribe(in.Key, in.Subscriber)
+		}
+		if !in.Continuation && isCounter(in.Key) {
+			return s.getCounter(ctx, in)
+		}
+
+		_, span := tracing.Start(ctx, "walk")
+		span.SetAttribute("db.key", in.Key)
+		start := time.Now()
+		walk, redirected, err := s.walkAliased(ctx, in)
+		timePhase(ctx, phaseWalk, start)
+		span.SetError(err)
+		span.Finish()
 		if err != nil {
 			return &db.GetResponse{}, err
 		}
-		defer conn.Close()
-		client := db.NewDbServiceClient(conn)
-
-		return client.Get(ctx, in)
+		if walk.DeadlineExceeded {
+			return &db.GetResponse{DeadlineExceeded: true, ResumeFrom: walk.Next}, nil
+		}
+		if walk.Remote {
+			// Continue the walk on the owner of the next location
+			owner := indexingService.ResolveOwner(walk.Next)
+			if owner == s.Self {
+				return &db.GetResponse{}, &dberrors.LocationNotFoundError{Location: walk.Next}
+			}
+			request, err := s.continueWalk(in, walk.Next)
+			if err != nil {
+				return &db.GetResponse{}, err
+			}
+			client, err := pool.Get(owner)
+			if err != nil {
+				return &db.GetResponse{}, err
+			}
+			start = time.Now()
+			resp, err := client.Get(ctx, request)
+			timePhase(ctx, phaseForward, start)
+			if status.Code(err) == codes.DeadlineExceeded {
+				return &db.GetResponse{DeadlineExceeded: true, ResumeFrom: walk.Next}, nil
+			}
+			if err == nil && !in.Continuation {
+				hopStats.Record(resp.RemoteHops)
+			}
+			if err == nil {
+				resp.Redirected = resp.Redirected || redirected
+			}
+			return resp, err
+		}
+		access.RecordRead(in.Key, in.Location, walk.Node.Location, walk.Depth)
+		if !in.Continuation {
+			hopStats.Record(0)
+		}
+		resp := &db.GetResponse{
+			Value:       walk.Node.Value,
+			CreatedAt:   walk.Node.CreatedAt,
+			Location:    walk.Node.Location,
+			ContentType: walk.Node.ContentType,
+			RemoteHops:  int32(len(in.Path)),
+			Redirected:  redirected,
+			Checksum:    walk.Node.Checksum,
+		}
+		if in.MetadataOnly {
+			resp.Value = nil
+		}
+		return resp, nil
+	} else {
+		// Forward request to the correct server
+		return s.forwardGet(ctx, address, in)
 	}
 }
 
-func (self *Server) distributeNodes(nodes []*db.Node) {
+func (self *Server) distributeNodes(nodes []*db.Node) error {
 	nodeMapping := make(map[string][]*db.Node)
 
 	for _, node := range nodes {
-		server := indexingService.Locate(utils.KeyHash(node.Location))
+		server := indexingService.ResolveOwner(node.Location)
 		nodeMapping[server] = append(nodeMapping[server], node)
 	}
 
 	ctx := context.Background()
 	for server, nodes := range nodeMapping {
 		// Forward request to the correct server
-		conn, err := grpc.Dial(server, grpc.WithInsecure())
+		client, err := pool.Get(server)
 		if err != nil {
-			log.Fatalln(err)
+			return err
 		}
-		defer conn.Close()
-		client := db.NewDbServiceClient(conn)
 		for _, node := range nodes {
-			client.AddNode(ctx, &db.AddNodeRequest{
+			_, err := client.AddNode(ctx, &db.AddNodeRequest{
 				Node: node,
 			})
+			if err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func (s *Server) Set(ctx context.Context, in *db.SetRequest) (result *db.SetResponse, err error) {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
+func (self *Server) Exists(ctx context.Context, in *db.ExistsRequest) (*db.ExistsResponse, error) {
+	address := indexingService.ResolveOwner(in.Location)
 
-	address := indexingService.LocateKey(in.Key)
+	if address == self.Self {
+		return &db.ExistsResponse{Exists: store.GetNode(in.Location) != nil}, nil
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.ExistsResponse{}, err
+		}
 
-	if address == s.Self {
-		loc := store.Set(in.Key, in.Value, in.Dep)
-		// Add child
-		if in.Dep != 0 {
-			parent, _ := s.AddChild(ctx, &db.AddChildRequest{
-				Location: in.Dep,
-				Child:    loc,
-			})
+		return client.Exists(ctx, in)
+	}
+}
 
-			// Trigger function if there's conflict
-			if len(parent.Children) > 1 {
-				merge, ok := s.mergeFunction[in.Dep]
-				if !ok {
-					merge = s.globalMergeFunction
-				}
-				if len(merge) > 0 {
-					params, _ := json.Marshal(parent)
-					resp := utils.CallAction(merge, params)
-					var children *db.Nodes
-					err := json.Unmarshal(resp, &children)
-					if err != nil {
-						return &db.SetResponse{Location: loc}, err
-					}
-
-					s.distributeNodes(children.Nodes)
-
-					// Remove current children and use new children
-					s.RemoveChildren(ctx, &db.RemoveChildrenRequest{
-						Location: parent.Location,
-					})
-					for _, child := range children.Nodes {
-						s.AddChild(ctx, &db.AddChildRequest{
-							Location: child.Dep,
-							Child:    child.Location,
-						})
-					}
-
-					// Debug
-					fmt.Println("[Merge]")
-					indexingService.Print()
-					fmt.Printf("Nodes: %d\n", store.Size)
-					// store.Print()
-				}
-			}
-		}
+// Reject writes depending on a location that was never written
+func (s *Server) checkDep(ctx context.Context, dep uint64) error {
+	resp, err := s.Exists(ctx, &db.ExistsRequest{Location: dep})
+	if err != nil {
+		return err
+	}
+	if !resp.Exists {
+		return missingDep(dep)
+	}
+	return nil
+}
 
-		if store.Size > s.Threshold && len(s.AvailableServers) > 0 {
-			s.lock.RUnlock()
-			s.lock.Lock()
-			s.splitRange()
-			s.lock.Unlock()
-			s.lock.RLock()
-		}
+func missingDep(dep uint64) error {
+	return status.Errorf(codes.FailedPrecondition, "Dep %x does not exist (set allowDanglingDep to write anyway)", dep)
+}
+
+// Remove a child from its parent (used when expired leaves are removed)
+func (self *Server) UnlinkChild(ctx context.Context, in *db.AddChildRequest) (*db.Node, error) {
+	address := indexingService.ResolveOwner(in.Location)
 
-		result = &db.SetResponse{Location: loc}
+	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.Node{}, err
+		}
+		node, err := store.RemoveChild(in.Location, in.Child)
+		if err != nil {
+			return &db.Node{}, err
+		}
+		return node.ToProto(), nil
 	} else {
 		// Forward request to the correct server
-		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		client, err := pool.Get(address)
 		if err != nil {
-			return &db.SetResponse{}, err
+			return &db.Node{}, err
 		}
-		defer conn.Close()
-		client := db.NewDbServiceClient(conn)
 
-		result, err = client.Set(ctx, in)
-		if store.Size > s.Threshold && len(s.AvailableServers) > 0 {
-			s.lock.RUnlock()
-			s.lock.Lock()
-			s.splitRange()
-			s.lock.Unlock()
-			s.lock.RLock()
-		}
+		return client.UnlinkChild(ctx, in)
 	}
-
-	// Debug
-	fmt.Println("[Set]")
-	indexingService.Print()
-	fmt.Printf("Nodes: %d\n", store.Size)
-	// store.Print()
-	return result, nil
 }
 
-// [l, m] [m+1, r]
-func (s *Server) Split(ctx context.Context, in *db.SplitRequest) (*db.Empty, error) {
-	indexingService.RemoveMapping(in.Left, in.Right)
-	indexingService.AddMapping(in.Left, in.Mid, in.LeftServer)
-	indexingService.AddMapping(in.Mid+1, in.Right, in.RightServer)
+// Node taking new children of the location (see Store.Bucket)
+func (self *Server) ResolveBucket(ctx context.Context, in *db.ResolveBucketRequest) (*db.ResolveBucketResponse, error) {
+	address := indexingService.ResolveOwner(in.Location)
 
-	// Remove from available servers
-	for i, server := range s.AvailableServers {
-		if server == in.LeftServer || server == in.RightServer {
-			l := len(s.AvailableServers)
-			s.AvailableServers[i] = s.AvailableServers[l-1]
-			s.AvailableServers = s.AvailableServers[:l-1]
-			break
+	if address == self.Self {
+		if err := self.checkWritable(); err != nil {
+			return &db.ResolveBucketResponse{}, err
+		}
+		location, err := store.Bucket(in.Location)
+		if err != nil {
+			return &db.ResolveBucketResponse{}, err
+		}
+		return &db.ResolveBucketResponse{Location: location}, nil
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.ResolveBucketResponse{}, err
 		}
-	}
-
-	// Debug
-	fmt.Println("[Split]")
-	indexingService.Print()
-	fmt.Printf("Nodes: %d\n", store.Size)
-	// store.Print()
 
-	return &db.Empty{}, nil
+		return client.ResolveBucket(ctx, in)
+	}
 }
 
-// Split based on key range
-// FIXME: multiple servers might split at the same
-func (s *Server) splitRange() {
-	left, right := indexingService.Range(s.Self)
-	if left == right {
-		return
+// Write the key (once per RequestId)
+func (s *Server) Set(ctx context.Context, in *db.SetRequest) (*db.SetResponse, error) {
+	if len(in.RequestId) == 0 {
+		return s.setResolvingDep(ctx, in)
 	}
+	return requestIds.Do(ctx, in.RequestId, func() (*db.SetResponse, error) {
+		return s.setResolvingDep(ctx, in)
+	})
+}
 
-	number := len(s.AvailableServers)
-	if number == 0 {
-		return
+// Write the key, again if its dep was remembered as stored but replaced since
+// (it is forgotten, so the dep is resolved through its owner the second time)
+func (s *Server) setResolvingDep(ctx context.Context, in *db.SetRequest) (*db.SetResponse, error) {
+	result, err := s.set(ctx, in)
+	if err == errStaleDep {
+		return s.set(ctx, in)
 	}
-	server := s.AvailableServers[rand.Intn(number)]
+	return result, err
+}
 
-	conn, err := grpc.Dial(server, grpc.WithInsecure())
-	if err != nil {
-		log.Fatalln(err)
+func (s *Server) set(ctx context.Context, in *db.SetRequest) (result *db.SetResponse, err error) {
+	start := time.Now()
+	s.lock.RLock()
+	timePhase(ctx, phaseLockWait, start)
+	defer s.lock.RUnlock()
+
+	if in.Dep == 0 && !s.AllowImplicitRoot {
+		return &db.SetResponse{}, status.Errorf(codes.InvalidArgument, "Dep is required (create a root with CreateRoot)")
 	}
-	defer conn.Close()
-	client := db.NewDbServiceClient(conn)
-	ctx := context.Background()
-	// Acquire lock first
-	resp, err := client.SetIndexingLock(ctx, &db.SetIndexingLockRequest{Lock: true})
-	if err != nil {
-		log.Fatalln(err)
+	// Only peers pipelining a Set choose its location (and link it themselves)
+	if in.Location != 0 && !auth.Verified(ctx) {
+		return &db.SetResponse{}, status.Errorf(codes.PermissionDenied, "The location of a Set can only be chosen by a peer")
 	}
-	if !resp.Success {
-		return
+	if in.Key, err = s.normalizeKey(in.Key); err != nil {
+		return &db.SetResponse{}, err
+	}
+	if err := s.checkResolveKeys(in.ResolveKeys); err != nil {
+		return &db.SetResponse{}, err
 	}
 
-	mid := uint32((uint64(left) + uint64(right)) / 2)
+	address := indexingService.LocateKey(in.Key)
 
-	var keys []uint32
-	for i, node := range store.Nodes {
-		if i == 0 || node.Key == "" {
-			continue
+	if address == s.Self {
+		if err := s.checkWritable(); err != nil {
+			return &db.SetResponse{}, err
 		}
-		keys = append(keys, utils.KeyHash(node.Location))
-	}
-
-	le := 0
-	greater := 0
-	for _, key := range keys {
-		if key > mid {
-			greater += 1
-		} else if key <= mid {
-			le += 1
+		if err := s.checkMemory(); err != nil {
+			return &db.SetResponse{}, err
 		}
-	}
-
-	// Debug
-	fmt.Println("[SplitRange]")
-	utils.Print(s.AvailableServers)
-	fmt.Println()
-
-	var leftServer, rightServer string
-	var results []*db.Node
-	if greater >= le {
-		i := 0
-		for _, node := range store.Nodes {
-			if node.Key == "" {
-				continue
+		var contentType string
+		if contentType, err = validateValue(in.Key, in.ContentType, in.Value); err != nil {
+			return &db.SetResponse{}, err
+		}
+		if err := checkNodeType(in.Key, in.Type, in.Value); err != nil {
+			return &db.SetResponse{}, err
+		}
+		// Resolve, write and link in order with the other serialized writes of the key
+		unlock := s.lockWrite(ctx, in)
+		defer unlock()
+		dep := in.Dep
+		if dep == db.DepAuto {
+			if dep, err = latestNode(in.Key); err != nil {
+				return &db.SetResponse{}, err
 			}
-			if keys[i] <= mid {
-				results = append(results, &db.Node{
-					Location: node.Location,
-					Dep:      node.Dep,
-					Key:      node.Key,
-					Value:    node.Value,
-					Children: node.Children,
-				})
+		}
+		// Linked by the server that received the Set
+		linked := in.Location != 0
+		var redirectedDep uint64
+		var cachedDep bool
+		if dep != 0 && !linked {
+			// Deps replaced by a merge or compression resolve to their successors
+			var resolved uint64
+			resolved, cachedDep, err = s.resolveDep(ctx, dep, in.AllowDanglingDep)
+			if err != nil {
+				return &db.SetResponse{}, err
+			}
+			if resolved != dep {
+				dep, redirectedDep = resolved, resolved
 			}
-			i += 1
 		}
-		rightServer = s.Self
-		leftServer = server
-	} else {
-		i := 0
-		for _, node := range store.Nodes {
-			if node.Key == "" {
-				continue
+		if s.AutoBucket && s.MaxChildren > 0 && !linked {
+			bucket, err := s.ResolveBucket(ctx, &db.ResolveBucketRequest{Location: dep})
+			if err == nil {
+				dep = bucket.Location
+			} else if !in.AllowDanglingDep || !errors.Is(err, dberrors.ErrLocationNotFound) {
+				return &db.SetResponse{}, err
+			}
+		}
+		_, span := tracing.Start(ctx, "store.write")
+		var expiresAt int64
+		if in.TtlSeconds > 0 {
+			expiresAt = time.Now().Add(time.Duration(in.TtlSeconds) * time.Second).UnixNano()
+		}
+		start = time.Now()
+		loc := in.Location
+		if linked {
+			err = core.WriteAt(loc, in.Key, in.Value, dep, nodeMetadata(ctx), expiresAt, contentType, in.Type)
+		} else {
+			loc, err = core.Write(in.Key, in.Value, dep, nodeMetadata(ctx), expiresAt, contentType, in.Type)
+		}
+		timePhase(ctx, phaseStore, start)
+		span.SetError(err)
+		span.Finish()
+		if err != nil {
+			return &db.SetResponse{}, err
+		}
+		s.invalidateKey(in.Key)
+		access.RecordWrite(in.Key)
+		result = &db.SetResponse{Location: loc, RedirectedDep: redirectedDep}
+		// Add child
+		if dep != 0 && !linked {
+			start = time.Now()
+			parent, err := s.AddChild(ctx, &db.AddChildRequest{
+				Location: dep,
+				Child:    loc,
+			})
+			timePhase(ctx, phaseLink, start)
+			if errors.Is(err, dberrors.ErrQuotaExceeded) {
+				// Not linked, so the node is unreachable
+				store.RemoveNode(loc)
+				return &db.SetResponse{}, status.Errorf(codes.ResourceExhausted, "%v: create an intermediate node to add more children", err)
 			}
-			if keys[i] > mid {
-				results = append(results, &db.Node{
-					Location: node.Location,
-					Dep:      node.Dep,
-					Key:      node.Key,
-					Value:    node.Value,
-					Children: node.Children,
-				})
+			if cachedDep && errors.Is(err, dberrors.ErrLocationNotFound) {
+				storedDeps.Forget(dep)
+				store.RemoveNode(loc)
+				return &db.SetResponse{}, errStaleDep
 			}
-			i += 1
+			if in.AllowDanglingDep && errors.Is(err, dberrors.ErrLocationNotFound) {
+				// The node is left unlinked
+				parent, err = &db.Node{Location: dep}, nil
+			}
+			if err != nil {
+				// Errors mean the node was not created
+				store.RemoveNode(loc)
+				return &db.SetResponse{}, err
+			}
+
+			// Concurrent deltas of a counter commute, so they are never merged
+			if in.Type != db.NodeType_COUNTER {
+				s.resolveConflict(ctx, in.Key, parent, result)
+			}
+		}
+		start = time.Now()
+		result.Durability, err = s.persist(s.durability(in.Durability))
+		timePhase(ctx, phaseStore, start)
+		if err != nil {
+			return &db.SetResponse{}, err
+		}
+		// Writers waiting for the key hold the server lock
+		unlock()
+
+		result.Resolved = s.resolveKeys(ctx, loc, in.ResolveKeys)
+		s.splitIfNeeded()
+	} else if s.canPipeline(in, address) {
+		// A peer splitting under its lock may be waiting for this server
+		// (e.g. to reserve a target on the seed), so don't hold ours
+		s.lock.RUnlock()
+		result, err = s.pipelinedSet(ctx, in, address)
+		s.lock.RLock()
+		if err != nil {
+			return &db.SetResponse{}, err
+		}
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.SetResponse{}, err
 		}
-		rightServer = server
-		leftServer = s.Self
+
+		var header metadata.MD
+		start = time.Now()
+		s.lock.RUnlock()
+		result, err = client.Set(forwardContext(ctx), in, grpc.Header(&header))
+		s.lock.RLock()
+		timePhase(ctx, phaseForward, start)
+		if err != nil {
+			return &db.SetResponse{}, err
+		}
+		copyMergeHeader(ctx, header)
+		s.splitIfNeeded()
 	}
 
 	// Debug
-	fmt.Printf("AddNodes: %d\n", len(results))
-	fmt.Printf("Address: %s\n", server)
+	fmt.Println("[Set]")
+	indexingService.Print()
+	fmt.Printf("Nodes: %d\n", store.NodeCount())
+	// store.Print()
+	return result, nil
+}
 
-	for _, node := range results {
-		_, err = client.AddNode(ctx, &db.AddNodeRequest{
-			Node: node,
-		})
+// Record the conflict of a new child of the parent and trigger its merge
+func (s *Server) resolveConflict(ctx context.Context, key string, parent *db.Node, result *db.SetResponse) {
+	if len(parent.Children) > 1 {
+		// The node is a sibling of another write
+		conflicts.Record(key, parent.Location)
+	}
+
+	// Trigger function if there's conflict
+	_, policy := core.MergeFunction(parent.Location)
+	if policy.Debounce > 0 && policy.Conflict(len(parent.Children)) {
+		debounceMerge(parent.Location, policy.Debounce)
+		result.MergeStatus = db.MergeStatus_MERGE_PENDING
+	} else if parent.ConflictDetected {
+		// Only the writer reaching the threshold resolves the conflict
+		start := time.Now()
+		record, err := s.merge(ctx, parent)
+		timePhase(ctx, phaseMerge, start)
+		if record != nil {
+			setMergeHeader(ctx, record)
+			result.MergeStatus = db.MergeStatus_MERGE_COMPLETED
+		}
 		if err != nil {
-			log.Fatalln(err)
+			// The write succeeded, so retry the merge asynchronously
+			retryMerge(parent.Location, err)
+			result.MergeStatus = db.MergeStatus_MERGE_FAILED
+			result.MergeError = err.Error()
 		}
 	}
+}
 
-	// Transfer merge function
-	for _, node := range results {
-		f, ok := s.mergeFunction[node.Location]
-		if ok {
-			_, err := client.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
-				Location: node.Location,
-				Name:     f,
-			})
-			if err != nil {
-				log.Fatalln(err)
-			}
-			delete(s.mergeFunction, node.Location)
-		}
+// [l, m] [m+1, r]
+func (s *Server) Split(ctx context.Context, in *db.SplitRequest) (*db.Empty, error) {
+	if err := s.applySplit(in); err != nil {
+		return &db.Empty{}, err
 	}
+	// The splitting server may be waiting for this one with its lock held
+	// (e.g. the seed for a reservation of this server), so don't wait for ours
+	go func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.takeSplitServers(in)
+	}()
+	return &db.Empty{}, nil
+}
 
-	// Update indexing server
-	request := &db.SplitRequest{
-		Left:        left,
-		Right:       right,
-		Mid:         mid,
-		LeftServer:  leftServer,
-		RightServer: rightServer,
+// Split requested by this server.
+// Must be called with the lock held.
+func (s *Server) splitLocally(in *db.SplitRequest) error {
+	if err := s.applySplit(in); err != nil {
+		return err
 	}
+	s.takeSplitServers(in)
+	return nil
+}
 
-	for _, addr := range s.Servers {
-		if addr == s.Self {
-			_, err := s.Split(ctx, request)
-			if err != nil {
-				log.Fatalln(err)
+// Update the mapping with the split
+func (s *Server) applySplit(in *db.SplitRequest) error {
+	if in.Epoch == 0 || s.protocolLevel() < protocolLeases {
+		// Legacy splits take no lock, but must not interleave with a locked one
+		if holder := indexingService.Holder(); len(holder) > 0 && holder != in.Holder && time.Now().Before(indexingService.Expiry()) {
+			return &dberrors.IndexingLockedError{
+				Holder: holder,
+				Expiry: indexingService.Expiry(),
 			}
-		} else if addr == server {
-			_, err := client.Split(ctx, request)
-			if err != nil {
-				log.Fatalln(err)
-			}
-		} else {
-			// Forward request to all servers
-			conn, err := grpc.Dial(addr, grpc.WithInsecure())
-			if err != nil {
-				log.Fatalln(err)
-			}
-			defer conn.Close()
-			client := db.NewDbServiceClient(conn)
-
-			_, err = client.Split(context.Background(), request)
-			if err != nil {
-				log.Fatalln(err)
+		}
+		indexingService.NextEpoch()
+	} else {
+		// Reject late broadcasts from aborted or stale splits
+		if !indexingService.HeldBy(in.Holder) {
+			return &dberrors.IndexingLockedError{
+				Holder: indexingService.Holder(),
+				Expiry: indexingService.Expiry(),
 			}
 		}
+		if in.Epoch <= indexingService.CurrentEpoch() {
+			return status.Errorf(codes.FailedPrecondition, "Stale split epoch %d (current %d)", in.Epoch, indexingService.CurrentEpoch())
+		}
+		indexingService.AdvanceEpoch(in.Epoch)
 	}
 
-	// Remove nodes after range has been updated
-	for _, node := range results {
-		store.RemoveNode(node.Location)
+	// Warm up received nodes before the new mapping routes traffic here
+	if in.Holder != s.Self && (in.LeftServer == s.Self || in.RightServer == s.Self) {
+		warmUp.Flush()
 	}
 
-	_, err = client.SetIndexingLock(ctx, &db.SetIndexingLockRequest{
-		Lock: false,
-	})
-	if err != nil {
-		log.Fatalln(err)
+	// Assigned over the current mappings, which are split in two by a rollback
+	if in.LeftServer == in.RightServer {
+		// The range is absorbed as a whole (e.g. by a decommission)
+		indexingService.Assign(in.Left, in.Right, in.LeftServer)
+	} else {
+		indexingService.Assign(in.Left, in.Mid, in.LeftServer)
+		indexingService.Assign(in.Mid+1, in.Right, in.RightServer)
 	}
+	// Cached values may come from previous owners
+	readCache.Clear()
+	clearApplied()
+	events.Publish(eventSplitApplied, map[string]string{
+		"holder": in.Holder,
+		"epoch":  strconv.FormatUint(in.Epoch, 10),
+	}, "Mapping updated: [%x, %x] on %s, [%x, %x] on %s", in.Left, in.Mid, in.LeftServer, in.Mid+1, in.Right, in.RightServer)
+
+	// Debug
+	fmt.Println("[Split]")
+	indexingService.Print()
+	fmt.Printf("Nodes: %d\n", store.NodeCount())
+	// store.Print()
+
+	return nil
 }
 
-func (s *Server) AddNode(ctx context.Context, in *db.AddNodeRequest) (*db.Empty, error) {
-	store.AddNode(in.Node)
+// Remove the servers of the split from available servers (and reservations on the seed).
+// Must be called with the lock held.
+func (s *Server) takeSplitServers(in *db.SplitRequest) {
+	s.removeAvailable(in.LeftServer)
+	s.removeAvailable(in.RightServer)
+	delete(s.reserved, in.LeftServer)
+	delete(s.reserved, in.RightServer)
+}
+
+// Nodes already stored are skipped, and a different node at the location
+// is never replaced (NodeExistsError with both nodes).
+func (s *Server) AddNode(ctx context.Context, in *db.AddNodeRequest) (*db.AddNodeResponse, error) {
+	if err := s.checkWritable(); err != nil {
+		return &db.AddNodeResponse{}, err
+	}
+	if err := s.checkMemory(); err != nil {
+		return &db.AddNodeResponse{}, err
+	}
+	if len(in.TransferToken) > 0 {
+		if err := transfers.Use(in.TransferToken, in.Node.Location); err != nil {
+			return &db.AddNodeResponse{}, err
+		}
+	}
+	add := store.AddNode
+	if in.SkipLocationCheck {
+		add = store.ImportNode
+	}
+	start := time.Now()
+	added, err := add(in.Node)
+	timePhase(ctx, phaseStore, start)
+	if err != nil {
+		return &db.AddNodeResponse{}, err
+	}
+	if !added {
+		return &db.AddNodeResponse{Skipped: true}, nil
+	}
+	warmUp.Add(in.Node)
 	// Debug
 	fmt.Println("[AddNodes]")
 	indexingService.Print()
-	fmt.Printf("Nodes: %d\n", store.Size)
+	fmt.Printf("Nodes: %d\n", store.NodeCount())
 
-	return &db.Empty{}, nil
+	return &db.AddNodeResponse{}, nil
 }
 
-func (self *Server) SetMergeFunction(ctx context.Context, in *db.SetMergeFunctionRequest) (*db.Empty, error) {
-	// FIXME: find the right server to add merge function
+func (self *Server) GetNode(ctx context.Context, in *db.GetNodeRequest) (*db.Node, error) {
+	address := indexingService.ResolveOwner(in.Location)
 
-	if len(in.Name) == 0 {
-		delete(self.mergeFunction, in.Location)
-	} else {
-		self.mergeFunction[in.Location] = in.Name
+	if err := self.checkResolveKeys(in.ResolveKeys); err != nil {
+		return &db.Node{}, err
 	}
-	return &db.Empty{}, nil
-}
 
-func (self *Server) SetGlobalMergeFunction(ctx context.Context, in *db.SetGlobalMergeFunctionRequest) (*db.Empty, error) {
-	for _, addr := range self.Servers {
-		if addr == self.Self {
-			self.globalMergeFunction = in.Name
-		} else {
-			// Forward request to all servers
-			conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if address == self.Self {
+		result, err := core.GetNode(in.Location)
+		if errors.Is(err, dberrors.ErrLocationNotFound) {
+			if _, ok := aliases.Get(in.Location); ok {
+				return self.getSuccessor(ctx, in)
+			}
+		}
+		if err != nil {
+			return &db.Node{}, err
+		}
+		if in.VerifyChecksum {
+			if err := store.VerifyChecksum(result); err != nil {
+				return &db.Node{}, err
+			}
+		}
+
+		if in.IncludeStats {
+			stats, err := self.nodeStats(ctx, result, in.DescendantBudget)
 			if err != nil {
-				log.Fatalln(err)
+				return &db.Node{}, err
 			}
-			defer conn.Close()
-			client := db.NewDbServiceClient(conn)
+			result.Stats = stats
+		}
+		result.UnresolvedConflict = mergeFailures.Dead(in.Location)
+		result.ChildCount = int64(len(result.Children))
+		result.Children, result.NextChildrenCursor = pageChildren(result.Children, in.ChildrenCursor, in.ChildrenLimit)
+		result.Resolved = self.resolveKeys(ctx, result.Location, in.ResolveKeys)
+		return result, nil
+	} else {
+		// Forward request to the correct server
+		client, err := pool.Get(address)
+		if err != nil {
+			return &db.Node{}, err
+		}
 
-			client.SetGlobalMergeFunction(ctx, in)
+		result, err := client.GetNode(ctx, in)
+		if err != nil {
+			return result, err
 		}
+		// Checked again after the hop
+		if in.VerifyChecksum {
+			if err := store.VerifyChecksum(result); err != nil {
+				return &db.Node{}, err
+			}
+		}
+		// Merges can be dead-lettered by the server that ran them
+		result.UnresolvedConflict = result.UnresolvedConflict || mergeFailures.Dead(in.Location)
+		return result, nil
 	}
-	return &db.Empty{}, nil
 }
 
-func (self *Server) GetNode(ctx context.Context, in *db.GetNodeRequest) (*db.Node, error) {
-	address := indexingService.Locate(utils.KeyHash(in.Location))
+// Children from the cursor (an offset) and the cursor of the next page
+func pageChildren(children []uint64, cursor uint64, limit int32) ([]uint64, uint64) {
+	if cursor >= uint64(len(children)) {
+		if cursor == 0 {
+			return children, 0
+		}
+		return nil, 0
+	}
+	children = children[cursor:]
+	if limit <= 0 || int(limit) >= len(children) {
+		return children, 0
+	}
+	return children[:limit], cursor + uint64(limit)
+}
+
+func (self *Server) GetKeyNodes(ctx context.Context, in *db.GetKeyNodesRequest) (*db.Nodes, error) {
+	address := indexingService.LocateKey(in.Key)
 
 	if address == self.Self {
-		node := store.GetNode(in.Location)
-		if node == nil {
-			return &db.Node{}, fmt.Errorf("Location %x not found", in.Location)
+		var nodes []*db.Node
+		for _, loc := range store.LocationsForKey(in.Key) {
+			node, err := store.Load(store.GetNode(loc))
+			if err != nil {
+				return &db.Nodes{}, err
+			}
+			if node != nil {
+				nodes = append(nodes, node.ToProto())
+			}
 		}
-
-		return &db.Node{
-			Location: node.Location,
-			Dep:      node.Dep,
-			Key:      node.Key,
-			Value:    node.Value,
-			Children: node.Children,
-		}, nil
+		return &db.Nodes{Nodes: nodes}, nil
 	} else {
 		// Forward request to the correct server
-		conn, err := grpc.Dial(address, grpc.WithInsecure())
+		client, err := pool.Get(address)
 		if err != nil {
-			return &db.Node{}, err
+			return &db.Nodes{}, err
 		}
-		defer conn.Close()
-		client := db.NewDbServiceClient(conn)
 
-		return client.GetNode(ctx, in)
+		return client.GetKeyNodes(ctx, in)
 	}
 }
 
 func (self *Server) SetIndexingLock(ctx context.Context, in *db.SetIndexingLockRequest) (*db.SetIndexingLockResponse, error) {
+	if self.protocolLevel() < protocolLeases {
+		return &db.SetIndexingLockResponse{}, status.Errorf(codes.Unimplemented, "Server %s speaks split protocol level %d without indexing locks", self.Self, self.protocolLevel())
+	}
 	if in.Lock {
-		if !indexingService.Lock {
-			indexingService.Lock = true
-			return &db.SetIndexingLockResponse{
-				Success: true,
-			}, nil
-		} else {
-			return &db.SetIndexingLockResponse{
-				Success: false,
-			}, nil
+		// Only servers in normal mode can be split targets
+		if in.Target {
+			if err := self.checkWritable(); err != nil {
+				return &db.SetIndexingLockResponse{
+					Success: false,
+				}, err
+			}
 		}
+		lease := time.Duration(in.LeaseMillis) * time.Millisecond
+		return &db.SetIndexingLockResponse{
+			Success: indexingService.TryLock(in.Holder, lease),
+			Epoch:   indexingService.CurrentEpoch(),
+			Holder:  indexingService.Holder(),
+		}, nil
 	} else {
-		indexingService.Lock = false
 		return &db.SetIndexingLockResponse{
-			Success: true,
+			Success: indexingService.Unlock(in.Holder),
+			Epoch:   indexingService.CurrentEpoch(),
+			Holder:  indexingService.Holder(),
 		}, nil
 	}
 }
+
+// Ranges of the indexing service.
+// Replicas of the ranges of this server also list its connected standbys,
+// those of other servers only their owner.
+func (self *Server) GetMapping(ctx context.Context, in *db.Empty) (*db.Mapping, error) {
+	mappings, epoch := indexingService.Snapshot()
+	mapping := &db.Mapping{Epoch: epoch}
+	standbys := followers.List()
+	for _, m := range mappings {
+		replicas := []string{m.Address}
+		if m.Address == self.Self {
+			replicas = append(replicas, standbys...)
+		}
+		mapping.Ranges = append(mapping.Ranges, &db.Range{
+			Left:     m.Left,
+			Right:    m.Right,
+			Server:   m.Address,
+			Replicas: replicas,
+		})
+	}
+	return mapping, nil
+}
+
+// Owners of many keys in one round trip
+func (self *Server) LocateKeys(ctx context.Context, in *db.LocateKeysRequest) (*db.LocateKeysResponse, error) {
+	var hashes []uint32
+	for _, key := range in.Keys {
+		hashes = append(hashes, placement.Hash(key))
+	}
+	hashes = append(hashes, in.Hashes...)
+
+	resp := &db.LocateKeysResponse{Epoch: indexingService.CurrentEpoch()}
+	for i, address := range indexingService.LocateAll(hashes) {
+		owner := &db.KeyOwner{Hash: hashes[i], Address: address}
+		if i < len(in.Keys) {
+			owner.Key = in.Keys[i]
+		}
+		resp.Owners = append(resp.Owners, owner)
+	}
+	return resp, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server owning the whole range in this process (with an empty store)
+func newTestServer(t testing.TB) *Server {
+	t.Helper()
+	chdirTemp(t)
+	s := &Server{Self: "self", Initial: "self", Threshold: math.MaxInt32}
+	s.applyDefaults()
+	resetTestServer(s)
+	return s
+}
+
+// Run the test in a temporary directory, where the state files are written
+func chdirTemp(t testing.TB) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// Give the whole range to the server, with empty state
+func resetTestServer(s *Server) {
+	indexingService.Init()
+	indexingService.Reset(nil, 0)
+	indexingService.AddMapping(0, math.MaxUint32, s.Self)
+	if pool.conns == nil {
+		pool.Init()
+	}
+	readCache.Init()
+	core.Init()
+	s.capacity = make(map[string]int64)
+	s.reserved = make(map[string]string)
+}
+
+// Owner of the upper half of the range, answering Sets without storing them
+type setPeer struct {
+	db.UnimplementedDbServiceServer
+	sets int64
+}
+
+func (p *setPeer) Set(ctx context.Context, in *db.SetRequest) (*db.SetResponse, error) {
+	return &db.SetResponse{Location: uint64(atomic.AddInt64(&p.sets, 1))}, nil
+}
+
+// Key owned by the server
+func keyOwnedBy(t testing.TB, address string) string {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		if key := fmt.Sprintf("k%d", i); indexingService.LocateKey(key) == address {
+			return key
+		}
+	}
+	t.Fatalf("No key owned by %s", address)
+	return ""
+}
+
+func BenchmarkSet(b *testing.B) {
+	value := make([]byte, 1<<20)
+	s := newTestServer(b)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("local", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(value)))
+		// Chained so that no merge is triggered
+		dep := root.Location
+		for i := 0; i < b.N; i++ {
+			resp, err := s.Set(ctx, &db.SetRequest{Key: "local", Value: value, Dep: dep})
+			if err != nil {
+				b.Fatal(err)
+			}
+			dep = resp.Location
+		}
+	})
+
+	address := startPeer(b, &setPeer{})
+	indexingService.Reset(nil, 0)
+	indexingService.AddMapping(0, math.MaxUint32/2, s.Self)
+	indexingService.AddMapping(math.MaxUint32/2+1, math.MaxUint32, address)
+	key := keyOwnedBy(b, address)
+	for _, pooled := range []bool{true, false} {
+		name := "forwarded"
+		if !pooled {
+			name = "forwarded without buffer pool"
+		}
+		b.Run(name, func(b *testing.B) {
+			defer func(enabled bool) { poolMarshalBuffers = enabled }(poolMarshalBuffers)
+			poolMarshalBuffers = pooled
+			b.ReportAllocs()
+			b.SetBytes(int64(len(value)))
+			for i := 0; i < b.N; i++ {
+				if _, err := s.Set(ctx, &db.SetRequest{Key: key, Value: value, Dep: root.Location}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetLocationRejected(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := store.NodeCount()
+	// Chosen by a client instead of a peer pipelining the Set
+	location := storage.CreateNode("k", nil, root.Location).Location
+	_, err = s.Set(ctx, &db.SetRequest{Key: "k", Value: []byte("v"), Dep: root.Location, Location: location})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Set with a location chosen by a client answered with %v", err)
+	}
+	if store.NodeCount() != nodes {
+		t.Errorf("Node written for a rejected Set")
+	}
+}
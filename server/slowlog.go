@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata with the number of servers a request went through
+const hopsKey = "x-hops"
+
+// Slow requests kept in the slow log
+const slowLogSize = 256
+
+// Phases of a request
+const (
+	phaseLockWait = "lockWait"
+	phaseStore    = "store"
+	phaseWalk     = "walk"
+	phaseLink     = "link"
+	phaseMerge    = "merge"
+	phaseForward  = "forward"
+)
+
+type SlowLog struct {
+	lock     sync.Mutex
+	requests []*db.SlowRequest
+}
+
+var slowLog = SlowLog{}
+
+func (l *SlowLog) Add(request *db.SlowRequest) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.requests = append(l.requests, request)
+	if len(l.requests) > slowLogSize {
+		l.requests = l.requests[len(l.requests)-slowLogSize:]
+	}
+}
+
+func (l *SlowLog) Requests() []*db.SlowRequest {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return append([]*db.SlowRequest(nil), l.requests...)
+}
+
+func (s *Server) GetSlowLog(ctx context.Context, in *db.Empty) (*db.SlowLog, error) {
+	return &db.SlowLog{Requests: slowLog.Requests()}, nil
+}
+
+// Time spent per phase by a request
+type phases struct {
+	lock   sync.Mutex
+	micros map[string]int64
+}
+
+type phasesKey struct{}
+
+// Add the time since start to the phase of the request
+func timePhase(ctx context.Context, phase string, start time.Time) {
+	p, ok := ctx.Value(phasesKey{}).(*phases)
+	if !ok {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.micros[phase] += time.Since(start).Microseconds()
+}
+
+// Threshold of the method (0 if not logged)
+func (s *Server) slowThreshold(method string) time.Duration {
+	name := method[strings.LastIndex(method, "/")+1:]
+	if millis, ok := s.SlowLogMethodMillis[name]; ok {
+		return time.Duration(millis) * time.Millisecond
+	}
+	return time.Duration(s.SlowLogMillis) * time.Millisecond
+}
+
+func incomingHops(ctx context.Context) int32 {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0
+	}
+	values := md.Get(hopsKey)
+	if len(values) == 0 {
+		return 0
+	}
+	hops, _ := strconv.Atoi(values[0])
+	return int32(hops)
+}
+
+// Record requests slower than the threshold of their method
+func (s *Server) slowLogInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	threshold := s.slowThreshold(info.FullMethod)
+	if threshold <= 0 {
+		return handler(ctx, req)
+	}
+
+	p := &phases{micros: make(map[string]int64)}
+	start := time.Now()
+	resp, err := handler(context.WithValue(ctx, phasesKey{}, p), req)
+	duration := time.Since(start)
+	if duration < threshold {
+		return resp, err
+	}
+
+	record := &db.SlowRequest{
+		Method:         info.FullMethod,
+		Time:           start.UnixNano(),
+		DurationMicros: duration.Microseconds(),
+		Hops:           incomingHops(ctx),
+		TraceId:        tracing.TraceID(ctx),
+	}
+	if r, ok := req.(interface{ GetKey() string }); ok {
+		record.Key = r.GetKey()
+	}
+	if r, ok := req.(interface{ GetLocation() uint64 }); ok {
+		record.Location = r.GetLocation()
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	p.lock.Lock()
+	record.PhaseMicros = p.micros
+	p.lock.Unlock()
+	slowLog.Add(record)
+	return resp, err
+}
+
+// Count the hop on requests forwarded to other servers
+func hopsClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, hopsKey, strconv.Itoa(int(incomingHops(ctx))+1))
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
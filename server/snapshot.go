@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Snapshots are persisted on every server
+const snapshotFile = "snapshots.json"
+
+// Named sets of branch heads pinned at a time
+type Snapshots struct {
+	lock      sync.RWMutex
+	snapshots map[string]*db.Snapshot
+	// Heads referenced by any snapshot
+	pinned map[uint64]int
+}
+
+var snapshots = Snapshots{
+	snapshots: make(map[string]*db.Snapshot),
+	pinned:    make(map[uint64]int),
+}
+
+func (s *Snapshots) Add(snapshot *db.Snapshot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.remove(snapshot.Name)
+	s.snapshots[snapshot.Name] = snapshot
+	for _, head := range snapshot.Heads {
+		s.pinned[head] += 1
+	}
+}
+
+// Replace all snapshots
+func (s *Snapshots) Set(list []*db.Snapshot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.snapshots = make(map[string]*db.Snapshot)
+	s.pinned = make(map[uint64]int)
+	for _, snapshot := range list {
+		s.snapshots[snapshot.Name] = snapshot
+		for _, head := range snapshot.Heads {
+			s.pinned[head] += 1
+		}
+	}
+}
+
+func (s *Snapshots) Remove(name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.remove(name)
+}
+
+func (s *Snapshots) remove(name string) bool {
+	snapshot, ok := s.snapshots[name]
+	if !ok {
+		return false
+	}
+	delete(s.snapshots, name)
+	for _, head := range snapshot.Heads {
+		s.pinned[head] -= 1
+		if s.pinned[head] == 0 {
+			delete(s.pinned, head)
+		}
+	}
+	return true
+}
+
+func (s *Snapshots) Get(name string) (*db.Snapshot, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	snapshot, ok := s.snapshots[name]
+	return snapshot, ok
+}
+
+// Sorted by time
+func (s *Snapshots) List() []*db.Snapshot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var list []*db.Snapshot
+	for _, snapshot := range s.snapshots {
+		list = append(list, snapshot)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Time < list[j].Time
+	})
+	return list
+}
+
+// Locations not pinned by any snapshot
+func (s *Snapshots) Unpinned(locations []uint64) []uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var result []uint64
+	for _, location := range locations {
+		if s.pinned[location] == 0 {
+			result = append(result, location)
+		}
+	}
+	return result
+}
+
+// Time before which expired nodes can be reclaimed
+// (nodes still alive at the oldest snapshot are kept)
+func (s *Snapshots) ReclaimBefore(now int64) int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, snapshot := range s.snapshots {
+		if snapshot.Time < now {
+			now = snapshot.Time
+		}
+	}
+	return now
+}
+
+func loadSnapshots() {
+	data, err := ioutil.ReadFile(statePath(snapshotFile))
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var list db.Snapshots
+	if err := json.Unmarshal(data, &list); err != nil {
+		log.Fatalln(err)
+	}
+	snapshots.Set(list.Snapshots)
+}
+
+func saveSnapshots() error {
+	data, _ := json.Marshal(&db.Snapshots{Snapshots: snapshots.List()})
+	return ioutil.WriteFile(statePath(snapshotFile), data, 0644)
+}
+
+// Local nodes without children
+func (s *Server) GetHeads(ctx context.Context, in *db.Empty) (*db.Heads, error) {
+	heads := &db.Heads{}
+	err := store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		if len(node.Children) == 0 {
+			heads.Locations = append(heads.Locations, node.Location)
+		}
+		return true
+	})
+	return heads, err
+}
+
+// Pin the current heads of all servers under the name.
+// The seed collects the heads and pushes the snapshot to all servers.
+func (s *Server) CreateSnapshot(ctx context.Context, in *db.CreateSnapshotRequest) (*db.Snapshot, error) {
+	if in.Snapshot != nil {
+		// Pushed by the seed
+		snapshots.Add(in.Snapshot)
+		return in.Snapshot, saveSnapshots()
+	}
+	if len(in.Name) == 0 {
+		return &db.Snapshot{}, status.Errorf(codes.InvalidArgument, "Name is required")
+	}
+
+	if !s.isSeed() {
+		client, err := pool.Get(s.Seed)
+		if err != nil {
+			return &db.Snapshot{}, err
+		}
+		return client.CreateSnapshot(ctx, in)
+	}
+
+	if _, ok := snapshots.Get(in.Name); ok {
+		return &db.Snapshot{}, status.Errorf(codes.AlreadyExists, "Snapshot %s already exists", in.Name)
+	}
+	snapshot := &db.Snapshot{
+		Name: in.Name,
+		Time: time.Now().UnixNano(),
+	}
+	for _, addr := range s.Servers {
+		heads, err := s.serverHeads(ctx, addr)
+		if err != nil {
+			return &db.Snapshot{}, err
+		}
+		snapshot.Heads = append(snapshot.Heads, heads.Locations...)
+	}
+	if err := s.pushSnapshot(ctx, &db.CreateSnapshotRequest{Snapshot: snapshot}, nil); err != nil {
+		return &db.Snapshot{}, err
+	}
+	events.Publish(eventSnapshotCreated, map[string]string{"name": in.Name}, "Snapshot %s created with %d heads", in.Name, len(snapshot.Heads))
+	return snapshot, nil
+}
+
+func (s *Server) serverHeads(ctx context.Context, addr string) (*db.Heads, error) {
+	if addr == s.Self {
+		return s.GetHeads(ctx, &db.Empty{})
+	}
+	client, err := pool.Get(addr)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetHeads(ctx, &db.Empty{})
+}
+
+// Release the snapshot so that its nodes can be reclaimed
+func (s *Server) DeleteSnapshot(ctx context.Context, in *db.DeleteSnapshotRequest) (*db.Empty, error) {
+	if in.Replicated {
+		snapshots.Remove(in.Name)
+		return &db.Empty{}, saveSnapshots()
+	}
+	if !s.isSeed() {
+		client, err := pool.Get(s.Seed)
+		if err != nil {
+			return &db.Empty{}, err
+		}
+		return client.DeleteSnapshot(ctx, in)
+	}
+
+	if _, ok := snapshots.Get(in.Name); !ok {
+		return &db.Empty{}, status.Errorf(codes.NotFound, "Snapshot %s not found", in.Name)
+	}
+	err := s.pushSnapshot(ctx, nil, &db.DeleteSnapshotRequest{Name: in.Name, Replicated: true})
+	if err == nil {
+		events.Publish(eventSnapshotDeleted, map[string]string{"name": in.Name}, "Snapshot %s deleted", in.Name)
+	}
+	return &db.Empty{}, err
+}
+
+// Apply the change on the seed, then on all other servers
+func (s *Server) pushSnapshot(ctx context.Context, create *db.CreateSnapshotRequest, remove *db.DeleteSnapshotRequest) error {
+	var err error
+	if create != nil {
+		_, err = s.CreateSnapshot(ctx, create)
+	} else {
+		_, err = s.DeleteSnapshot(ctx, remove)
+	}
+	if err != nil {
+		return err
+	}
+	for _, addr := range s.Servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			if create != nil {
+				_, err = client.CreateSnapshot(ctx, create)
+			} else {
+				_, err = client.DeleteSnapshot(ctx, remove)
+			}
+		}
+		if err != nil {
+			log.Printf("Fail to push snapshot to %s: %v", addr, err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) ListSnapshots(ctx context.Context, in *db.Empty) (*db.Snapshots, error) {
+	return &db.Snapshots{Snapshots: snapshots.List()}, nil
+}
+
+// Pull the snapshots from the seed on startup
+func (s *Server) pullSnapshots() {
+	if s.isSeed() {
+		return
+	}
+	client, err := pool.Get(s.Seed)
+	if err == nil {
+		var list *db.Snapshots
+		list, err = client.ListSnapshots(context.Background(), &db.Empty{})
+		if err == nil {
+			snapshots.Set(list.Snapshots)
+			err = saveSnapshots()
+		}
+	}
+	if err != nil {
+		log.Printf("Fail to pull snapshots: %v", err)
+	}
+}
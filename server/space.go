@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/indexing"
+)
+
+// Largest values and key prefixes of this server
+func (s *Server) TopSpaceConsumers(ctx context.Context, in *db.TopSpaceConsumersRequest) (*db.SpaceConsumers, error) {
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = 10
+	}
+	values, prefixes, err := store.TopSpace()
+	if err != nil {
+		return &db.SpaceConsumers{}, err
+	}
+	resp := &db.SpaceConsumers{ValueBytes: store.ValueBytes()}
+	for i, value := range values {
+		if i == limit {
+			break
+		}
+		resp.Values = append(resp.Values, &db.SpaceConsumer{
+			Key:      value.Key,
+			Location: value.Location,
+			Bytes:    value.Bytes,
+		})
+	}
+	for i, prefix := range prefixes {
+		if i == limit {
+			break
+		}
+		resp.Prefixes = append(resp.Prefixes, &db.SpacePrefix{
+			Prefix: prefix.Prefix,
+			Bytes:  prefix.Bytes,
+			Nodes:  prefix.Nodes,
+		})
+	}
+	return resp, nil
+}
+
+// Whether the server holds enough nodes or bytes to split
+func (s *Server) overThreshold() bool {
+	return indexing.OverThreshold(store.NodeCount(), s.splitThreshold(), store.ValueBytes(), s.ThresholdBytes)
+}
@@ -0,0 +1,690 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"github.com/DCsunset/openwhisk-grpc/indexing"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"github.com/DCsunset/openwhisk-grpc/tracing"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Lease of indexing locks held during a split (renewed while nodes are sent)
+const splitLease = 30 * time.Second
+
+func (s *Server) setIndexingLock(ctx context.Context, address string, in *db.SetIndexingLockRequest) (*db.SetIndexingLockResponse, error) {
+	if address == s.Self {
+		return s.SetIndexingLock(ctx, in)
+	}
+	client, err := pool.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	return client.SetIndexingLock(ctx, in)
+}
+
+// Acquire indexing locks on all servers in a fixed global order to avoid deadlock.
+// Returns the locked servers and the highest epoch seen.
+// On failure, the locks already held are released.
+func (s *Server) lockServers(ctx context.Context, target string) ([]string, uint64, error) {
+	return s.lockServersOf(ctx, s.Servers, target)
+}
+
+// Same for the given servers (the target, if any, is added)
+func (s *Server) lockServersOf(ctx context.Context, list []string, target string) ([]string, uint64, error) {
+	servers := make([]string, len(list))
+	copy(servers, list)
+	if len(target) > 0 && !contains(servers, target) {
+		servers = append(servers, target)
+	}
+	sort.Strings(servers)
+
+	var held []string
+	var epoch uint64
+	for _, addr := range servers {
+		resp, err := s.setIndexingLock(ctx, addr, &db.SetIndexingLockRequest{
+			Lock:        true,
+			Holder:      s.Self,
+			LeaseMillis: splitLease.Milliseconds(),
+			Target:      addr == target,
+		})
+		if err == nil && !resp.Success {
+			err = fmt.Errorf("Indexing lock of %s is held by %s", addr, resp.Holder)
+		}
+		if err != nil {
+			s.unlockServers(ctx, held)
+			return nil, 0, err
+		}
+		held = append(held, addr)
+		if resp.Epoch > epoch {
+			epoch = resp.Epoch
+		}
+	}
+	return held, epoch, nil
+}
+
+// Extend the leases of the indexing locks
+func (s *Server) renewLocks(ctx context.Context, servers []string, target string) error {
+	for _, addr := range servers {
+		resp, err := s.setIndexingLock(ctx, addr, &db.SetIndexingLockRequest{
+			Lock:        true,
+			Holder:      s.Self,
+			LeaseMillis: splitLease.Milliseconds(),
+			Target:      addr == target,
+		})
+		if err == nil && !resp.Success {
+			err = fmt.Errorf("Indexing lock of %s is held by %s", addr, resp.Holder)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release indexing locks in reverse order
+func (s *Server) unlockServers(ctx context.Context, servers []string) {
+	for i := len(servers) - 1; i >= 0; i-- {
+		_, err := s.setIndexingLock(ctx, servers[i], &db.SetIndexingLockRequest{
+			Lock:   false,
+			Holder: s.Self,
+		})
+		if err != nil {
+			log.Printf("Fail to release indexing lock of %s: %v", servers[i], err)
+		}
+	}
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy of the list without the item
+func without(list []string, item string) []string {
+	var result []string
+	for _, v := range list {
+		if v != item {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Split automatically when the threshold is reached.
+// Without available servers, half of the range goes to the least loaded peer
+// if lastResortSplit is set.
+// Must be called with the lock held.
+func (s *Server) autoSplit() {
+	available := len(s.AvailableServers)
+	if available == 0 && !s.LastResortSplit {
+		return
+	}
+	if !splitGovernor.Allow(available) {
+		return
+	}
+	var err error
+	if available == 0 {
+		_, err = s.splitRangeTo("", 0, false, true)
+	} else {
+		_, err = s.splitRange("", 0, false)
+	}
+	splitGovernor.Done(err)
+	if err != nil {
+		log.Printf("Split failed: %v", err)
+	}
+}
+
+// Split when over the threshold.
+// Must be called with the read lock held.
+func (s *Server) splitIfNeeded() {
+	if !s.overThreshold() {
+		return
+	}
+	if len(s.AvailableServers) == 0 {
+		expansion.Raise(0, fmt.Sprintf("%s is over its threshold with no available server", s.Self))
+		if !s.LastResortSplit {
+			return
+		}
+	}
+	s.lock.RUnlock()
+	s.lock.Lock()
+	s.autoSplit()
+	s.lock.Unlock()
+	s.lock.RLock()
+}
+
+// Compute which nodes move to the target.
+// The smaller half of the range is moved.
+// A reserved target was allocated by the seed and is no longer listed as available.
+func (s *Server) planSplit(target string, mid uint32, hasMid bool, reserved bool) (*db.SplitPlan, []*db.Node, error) {
+	left, right, err := s.splitCandidate(mid, hasMid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(target) == 0 {
+		number := len(s.AvailableServers)
+		if number == 0 {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "No available servers")
+		}
+		// Saturated servers are skipped unless all of them are
+		target = s.leastLoaded(s.AvailableServers)
+		if len(target) == 0 {
+			target = s.AvailableServers[rand.Intn(number)]
+		}
+	} else if !reserved && !contains(s.AvailableServers, target) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Server %s is not available", target)
+	}
+
+	if !hasMid {
+		mid = indexing.SplitPoint(left, right, s.SplitByLoad, heatmap.Median)
+	}
+
+	plan := &db.SplitPlan{
+		Left:   left,
+		Right:  right,
+		Mid:    mid,
+		Target: target,
+	}
+	// Transfer the smaller half
+	lower := store.CountHashRange(left, mid)
+	upper := store.CountHashRange(mid+1, right)
+	transferLeft, transferRight := left, mid
+	if indexing.MoveLower(lower, upper) {
+		plan.KeepNodes = int64(upper)
+		plan.LeftServer = target
+		plan.RightServer = s.Self
+	} else {
+		transferLeft, transferRight = mid+1, right
+		plan.KeepNodes = int64(lower)
+		plan.LeftServer = s.Self
+		plan.RightServer = target
+	}
+
+	var results []*db.Node
+	var loadErr error
+	err = store.IterateHashRange(transferLeft, transferRight, func(node *storage.Node) bool {
+		// Spilled values are transferred inline
+		node, loadErr = store.Load(node)
+		if loadErr != nil {
+			return false
+		}
+		results = append(results, node.ToProto())
+		plan.TransferBytes += int64(len(node.Value))
+		return true
+	})
+	if err == nil {
+		err = loadErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	plan.TransferNodes = int64(len(results))
+	if s.transferRate > 0 {
+		plan.EstimatedMillis = int64(float64(len(results)) / s.transferRate * 1000)
+	}
+
+	return plan, results, nil
+}
+
+// Range of this server to split: the one containing mid if given,
+// otherwise the one with the most nodes (a server owns several ranges
+// after last resort splits, decommissions and rollbacks)
+func (s *Server) splitCandidate(mid uint32, hasMid bool) (uint32, uint32, error) {
+	var left, right uint32
+	most := -1
+	for _, r := range s.ownedRanges() {
+		if r.Left == r.Right {
+			continue
+		}
+		if hasMid {
+			if mid >= r.Left && mid < r.Right {
+				return r.Left, r.Right, nil
+			}
+			continue
+		}
+		if count := store.CountHashRange(r.Left, r.Right); count > most {
+			left, right, most = r.Left, r.Right, count
+		}
+	}
+	if hasMid {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "Mid %x is out of the ranges of %s", mid, s.Self)
+	}
+	if most < 0 {
+		return 0, 0, status.Errorf(codes.FailedPrecondition, "Range of %s cannot be split", s.Self)
+	}
+	return left, right, nil
+}
+
+// Split based on key range.
+// An empty target lets the seed pick an available server.
+func (s *Server) splitRange(target string, mid uint32, hasMid bool) (*db.SplitPlan, error) {
+	return s.splitRangeTo(target, mid, hasMid, false)
+}
+
+// A last resort split moves part of the range to a peer already owning one
+// (the least loaded if target is empty) instead of an available server.
+func (s *Server) splitRangeTo(target string, mid uint32, hasMid bool, lastResort bool) (*db.SplitPlan, error) {
+	ctx, span := tracing.Start(context.Background(), "split")
+	defer span.Finish()
+	available := len(s.AvailableServers)
+	server := target
+	if lastResort {
+		if len(server) == 0 {
+			server = s.pickAbsorber()
+		}
+		if len(server) == 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "No peer owns a range to take a last resort split")
+		}
+	} else {
+		// Reserve the target so that concurrent splits pick different ones
+		var err error
+		server, err = s.allocateServer(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+	}
+	span.SetAttribute("split.target", server)
+	plan, _, err := s.planSplit(server, mid, hasMid, true)
+	if err != nil {
+		s.releaseServer(ctx, server)
+		return nil, err
+	}
+	attempt := splitAttempts.Start(server)
+
+	client, err := pool.Get(server)
+	if err != nil {
+		splitAttempts.SetPhase(attempt, phaseAborted, err)
+		s.releaseServer(ctx, server)
+		return nil, err
+	}
+	// Old servers in the cluster may not know indexing locks
+	level, err := s.negotiateProtocol(ctx, append([]string{server}, s.Servers...))
+	if err != nil {
+		splitAttempts.SetPhase(attempt, phaseAborted, err)
+		s.releaseServer(ctx, server)
+		return nil, fmt.Errorf("Abort split to %s: %v", server, err)
+	}
+	span.SetAttribute("split.protocol", strconv.Itoa(int(level)))
+	if lastResort && level < protocolAbsorb {
+		err := fmt.Errorf("Abort last resort split to %s: some servers speak split protocol level %d, older than %d needed to absorb ranges", server, level, protocolAbsorb)
+		splitAttempts.SetPhase(attempt, phaseAborted, err)
+		return nil, err
+	}
+	var epoch uint64
+	var held []string
+	if level >= protocolLeases {
+		// Acquire locks on all servers first
+		held, epoch, err = s.lockServers(ctx, server)
+		if err != nil {
+			splitAttempts.SetPhase(attempt, phaseAborted, err)
+			s.releaseServer(ctx, server)
+			return nil, fmt.Errorf("Abort split to %s: %v", server, err)
+		}
+		defer s.unlockServers(ctx, held)
+	}
+
+	// Nodes might have changed before locks were acquired
+	plan, results, err := s.planSplit(server, plan.Mid, true, true)
+	if err != nil {
+		splitAttempts.SetPhase(attempt, phaseAborted, err)
+		s.releaseServer(ctx, server)
+		return nil, err
+	}
+	splitAttempts.SetPhase(attempt, phaseTransferring, nil)
+	moved := [2]uint32{plan.Mid + 1, plan.Right}
+	if plan.LeftServer == server {
+		moved = [2]uint32{plan.Left, plan.Mid}
+	}
+	token, err := s.prepareReceive(ctx, client, moved[0], moved[1], plan, results)
+	if err != nil {
+		err = fmt.Errorf("Abort split to %s: %v", server, err)
+		s.abortSplit(ctx, attempt, err)
+		return nil, err
+	}
+
+	// Debug
+	fmt.Println("[SplitRange]")
+	utils.Print(s.AvailableServers)
+	fmt.Println()
+	fmt.Printf("AddNodes: %d\n", len(results))
+	fmt.Printf("Address: %s\n", server)
+
+	start := time.Now()
+	// Left on the target by an earlier attempt
+	skipped, err := s.sendNodes(ctx, client, attempt, results, token, held)
+	if err != nil {
+		// Keep local nodes since the range is not updated yet
+		err = fmt.Errorf("Abort split to %s: %v", server, err)
+		s.abortSplit(ctx, attempt, err)
+		return nil, err
+	}
+	log.Printf("Transferred %d nodes to %s (%d already there)", len(results), server, skipped)
+	if elapsed := time.Since(start).Seconds(); elapsed > 0 && len(results) > 0 {
+		s.transferRate = float64(len(results)) / elapsed
+	}
+
+	// Transfer merge function
+	if err := s.transferMergeFunctions(ctx, client, results); err != nil {
+		// The range is not updated yet, so dropping the sent nodes undoes the split
+		err = fmt.Errorf("Abort split to %s: fail to transfer merge functions: %v", server, err)
+		s.abortSplit(ctx, attempt, err)
+		return nil, err
+	}
+	if err := s.transferScopedMergeFunctions(ctx, client, moved[0], moved[1]); err != nil {
+		err = fmt.Errorf("Abort split to %s: fail to transfer scoped merge functions: %v", server, err)
+		s.abortSplit(ctx, attempt, err)
+		return nil, err
+	}
+	// Old locations of the range stay resolvable on the target
+	if err := s.transferAliases(ctx, client, moved[0], moved[1]); err != nil {
+		log.Printf("Fail to transfer aliases to %s: %v", server, err)
+	}
+
+	// The commit is rejected by servers whose lease expired
+	if len(held) > 0 {
+		if err := s.renewLocks(ctx, held, server); err != nil {
+			err = fmt.Errorf("Abort split to %s: %v", server, err)
+			s.abortSplit(ctx, attempt, err)
+			return nil, err
+		}
+	}
+
+	// Update indexing server
+	splitAttempts.SetPhase(attempt, phaseCommitting, nil)
+	request := &db.SplitRequest{
+		Left:        plan.Left,
+		Right:       plan.Right,
+		Mid:         plan.Mid,
+		LeftServer:  plan.LeftServer,
+		RightServer: plan.RightServer,
+		Epoch:       epoch + 1,
+		Holder:      s.Self,
+	}
+	if level < protocolLeases {
+		request.Epoch = 0
+	}
+	if err := s.broadcastSplit(ctx, request); err != nil {
+		// Servers that applied the split go back to the old mapping
+		err = fmt.Errorf("Abort split to %s: %v", server, err)
+		if rollbackErr := s.rollbackSplit(ctx, request); rollbackErr != nil {
+			err = fmt.Errorf("%v (%v)", err, rollbackErr)
+		}
+		splitAttempts.RolledBack(attempt)
+		s.abortSplit(ctx, attempt, err)
+		return nil, err
+	}
+
+	// Local copies are only removed once the target serves them
+	splitAttempts.SetPhase(attempt, phaseVerifying, nil)
+	verified, failed := s.verifySplit(ctx, server, results)
+	splitAttempts.Verified(attempt, verified, failed)
+	if failed > s.SplitVerifyTolerance {
+		err := fmt.Errorf("Abort split to %s: %d of %d moved nodes sampled cannot be read back", server, failed, verified+failed)
+		if rollbackErr := s.rollbackSplit(ctx, request); rollbackErr != nil {
+			err = fmt.Errorf("%v (%v)", err, rollbackErr)
+		}
+		splitAttempts.RolledBack(attempt)
+		s.abortSplit(ctx, attempt, err)
+		return nil, err
+	}
+	if err := s.dropMergeFunctions(results, moved[0], moved[1]); err != nil {
+		log.Println(err)
+	}
+	if aliases.RemoveRange(moved[0], moved[1]) > 0 {
+		if err := saveAliases(); err != nil {
+			log.Println(err)
+		}
+	}
+
+	// Remove nodes after range has been updated
+	var locations []uint64
+	for _, node := range results {
+		locations = append(locations, node.Location)
+	}
+	store.RemoveNodes(locations)
+
+	splitAttempts.SetPhase(attempt, phaseCommitted, nil)
+	plan.Executed = true
+	if lastResort {
+		log.Printf("Last resort split: [%x, %x] moved to %s", moved[0], moved[1], server)
+	} else if available <= 1 {
+		expansion.Raise(0, fmt.Sprintf("split of %s took the last available server %s", s.Self, server))
+	}
+	return plan, nil
+}
+
+// Apply the new mapping on all servers, stopping at the first one rejecting it
+func (s *Server) broadcastSplit(ctx context.Context, request *db.SplitRequest) error {
+	for _, addr := range s.Servers {
+		if err := s.applySplitOn(ctx, addr, request); err != nil {
+			return fmt.Errorf("Fail to update the mapping of %s: %v", addr, err)
+		}
+	}
+	return nil
+}
+
+func (s *Server) applySplitOn(ctx context.Context, addr string, request *db.SplitRequest) error {
+	if addr == s.Self {
+		return s.splitLocally(request)
+	}
+	client, err := pool.Get(addr)
+	if err != nil {
+		return err
+	}
+	_, err = client.Split(ctx, request)
+	return err
+}
+
+// Give the whole range of an applied split back to this server
+// (the indexing locks of the split must still be held).
+// Every server is tried, retrying transient failures; the ones left on the
+// split mapping are reported and catch up with the claims of this server.
+func (s *Server) rollbackSplit(ctx context.Context, request *db.SplitRequest) error {
+	rollback := &db.SplitRequest{
+		Left:        request.Left,
+		Right:       request.Right,
+		Mid:         request.Mid,
+		LeftServer:  s.Self,
+		RightServer: s.Self,
+		Holder:      s.Self,
+	}
+	if request.Epoch > 0 {
+		rollback.Epoch = request.Epoch + 1
+	}
+	var failed []string
+	for _, addr := range s.Servers {
+		err := pool.Retry(ctx, func() error {
+			return s.applySplitOn(ctx, addr, rollback)
+		})
+		if err != nil {
+			log.Printf("Fail to roll back split of [%x, %x] on %s: %v", request.Left, request.Right, addr, err)
+			failed = append(failed, addr)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("Split of [%x, %x] not rolled back on %s", request.Left, request.Right, strings.Join(failed, ", "))
+	}
+	log.Printf("Split of [%x, %x] rolled back", request.Left, request.Right)
+	return nil
+}
+
+// Read a sample of the moved nodes through the new mapping
+// and compare their checksums with the local copies.
+// Returns the number of samples verified and failed.
+func (s *Server) verifySplit(ctx context.Context, target string, nodes []*db.Node) (int, int) {
+	samples := s.SplitVerifySamples
+	if samples < 0 {
+		return 0, 0
+	}
+	if samples > len(nodes) {
+		samples = len(nodes)
+	}
+	verified, failed := 0, 0
+	for _, i := range rand.Perm(len(nodes))[:samples] {
+		node := nodes[i]
+		expected := node.Checksum
+		if expected == 0 {
+			// Given one by the target
+			expected = storage.Checksum(node.Key, node.Value, node.Dep, node.Type, node.Metadata)
+		}
+		if owner := indexingService.ResolveOwner(node.Location); owner != target {
+			log.Printf("Moved node %x is routed to %s instead of %s", node.Location, owner, target)
+			failed += 1
+			continue
+		}
+		read, err := s.GetNode(ctx, &db.GetNodeRequest{
+			Location:       node.Location,
+			ChildrenLimit:  1,
+			VerifyChecksum: true,
+		})
+		if err == nil && read.Checksum != expected {
+			err = &dberrors.ChecksumMismatchError{Location: node.Location, Expected: expected, Actual: read.Checksum}
+		}
+		if err != nil {
+			log.Printf("Moved node %x cannot be read back from %s: %v", node.Location, target, err)
+			failed += 1
+			continue
+		}
+		verified += 1
+	}
+	return verified, failed
+}
+
+// Announce the transfer of the nodes in [left, right] to the target and get its token.
+// Nodes the target holds in the range that are not transferred were left by earlier attempts and are removed.
+// Targets not knowing PrepareReceive take the nodes without token.
+func (s *Server) prepareReceive(ctx context.Context, client db.DbServiceClient, left, right uint32, plan *db.SplitPlan, nodes []*db.Node) (string, error) {
+	resp, err := client.PrepareReceive(ctx, &db.PrepareReceiveRequest{
+		Left:   left,
+		Right:  right,
+		Nodes:  plan.TransferNodes,
+		Bytes:  plan.TransferBytes,
+		Sender: s.Self,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	transferred := make(map[uint64]bool)
+	for _, node := range nodes {
+		transferred[node.Location] = true
+	}
+	var stray []uint64
+	for _, location := range resp.Conflicting {
+		if !transferred[location] {
+			stray = append(stray, location)
+		}
+	}
+	if len(stray) > 0 {
+		log.Printf("Removing %d stray nodes from %s", len(stray), plan.Target)
+		if _, err := client.RemoveNodes(ctx, &db.RemoveNodesRequest{Locations: stray, Local: true}); err != nil {
+			return "", err
+		}
+	}
+	return resp.Token, nil
+}
+
+// Copy merge functions registered on the nodes to the client
+func (s *Server) transferMergeFunctions(ctx context.Context, client db.DbServiceClient, nodes []*db.Node) error {
+	for _, node := range nodes {
+		f, policy, ok := core.LocalMergeFunction(node.Location)
+		if !ok {
+			continue
+		}
+		_, err := client.SetMergeFunction(ctx, &db.SetMergeFunctionRequest{
+			Location: node.Location,
+			Name:     f,
+			Policy:   policy.ToProto(),
+			// The seed already has it
+			Replicated: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Copy key merge functions of [left, right] and range ones overlapping it to the client
+func (s *Server) transferScopedMergeFunctions(ctx context.Context, client db.DbServiceClient, left, right uint32) error {
+	for _, r := range registrationsInRange(left, right) {
+		scope := engine.Scope(r)
+		if scope != engine.ScopeKey && scope != engine.ScopeRange {
+			continue
+		}
+		_, err := client.SetScopedMergeFunction(ctx, &db.SetScopedMergeFunctionRequest{
+			Key:    r.Key,
+			Ranged: r.Ranged,
+			Left:   r.Left,
+			Right:  r.Right,
+			Name:   r.Name,
+			Policy: r.Policy,
+			// The seed already has it
+			Replicated: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Forget merge functions of moved nodes and keys once the split is verified
+// (they are kept if the split is rolled back)
+func (s *Server) dropMergeFunctions(nodes []*db.Node, left, right uint32) error {
+	for _, node := range nodes {
+		if _, _, ok := core.LocalMergeFunction(node.Location); ok {
+			core.SetMergeFunction(node.Location, "", engine.Policy{})
+		}
+	}
+	for _, r := range registrationsInRange(left, right) {
+		if engine.Scope(r) == engine.ScopeKey {
+			core.SetKeyMergeFunction(r.Key, "", engine.Policy{})
+		}
+	}
+	return saveRegistrations()
+}
+
+// Split manually or preview the split with dry run
+func (s *Server) TriggerSplit(ctx context.Context, in *db.TriggerSplitRequest) (*db.SplitPlan, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if store.NodeCount() < s.MinSplitSize {
+		return &db.SplitPlan{}, status.Errorf(codes.FailedPrecondition, "Server has %d nodes (minimum %d to split)", store.NodeCount(), s.MinSplitSize)
+	}
+
+	if in.DryRun {
+		plan, _, err := s.planSplit(in.Target, in.Mid, in.HasMid, false)
+		return plan, err
+	}
+	plan, err := s.splitRange(in.Target, in.Mid, in.HasMid)
+	if err != nil {
+		// Failures of peers during the split abort it, and it can be triggered again
+		if _, ok := status.FromError(err); !ok {
+			err = status.Error(codes.Aborted, err.Error())
+		}
+		return &db.SplitPlan{}, err
+	}
+	return plan, nil
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/engine"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server rejecting the first Split requests like a server whose lease expired,
+// and failing the first rollbacks (or all of them when failRollbacks < 0) like an unreachable one
+type splitPeer struct {
+	db.UnimplementedDbServiceServer
+	lock          sync.Mutex
+	reject        int
+	failRollbacks int
+	rollbacks     int
+	requests      []*db.SplitRequest
+	applied       []*db.SplitRequest
+}
+
+func (p *splitPeer) Split(ctx context.Context, in *db.SplitRequest) (*db.Empty, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.requests = append(p.requests, in)
+	if len(p.requests) <= p.reject {
+		return &db.Empty{}, &dberrors.IndexingLockedError{Holder: "other"}
+	}
+	if in.LeftServer == in.RightServer {
+		p.rollbacks += 1
+		if p.failRollbacks < 0 || p.rollbacks <= p.failRollbacks {
+			return &db.Empty{}, status.Error(codes.Unavailable, "peer down")
+		}
+	}
+	p.applied = append(p.applied, in)
+	return &db.Empty{}, nil
+}
+
+// Owner of the range after the last mapping update applied by the peer
+func (p *splitPeer) owner(hash uint32) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.applied) == 0 {
+		return ""
+	}
+	last := p.applied[len(p.applied)-1]
+	if hash < last.Mid {
+		return last.LeftServer
+	}
+	return last.RightServer
+}
+
+func TestBroadcastSplitRejected(t *testing.T) {
+	s := newTestServer(t)
+	peer := &splitPeer{reject: 1}
+	s.Servers = []string{s.Self, startPeer(t, peer)}
+	indexingService.TryLock(s.Self, splitLease)
+	defer indexingService.Unlock(s.Self)
+
+	ctx := context.Background()
+	request := &db.SplitRequest{
+		Left:        0,
+		Right:       math.MaxUint32,
+		Mid:         math.MaxUint32 / 2,
+		LeftServer:  s.Self,
+		RightServer: "target",
+		Epoch:       1,
+		Holder:      s.Self,
+	}
+	s.lock.Lock()
+	err := s.broadcastSplit(ctx, request)
+	if err == nil {
+		s.lock.Unlock()
+		t.Fatal("Split rejected by a peer succeeded")
+	}
+	if owner := indexingService.Locate(math.MaxUint32); owner != "target" {
+		t.Fatalf("Split not applied locally: upper half owned by %s", owner)
+	}
+	if err := s.rollbackSplit(ctx, request); err != nil {
+		t.Error(err)
+	}
+	s.lock.Unlock()
+
+	if owner := indexingService.Locate(math.MaxUint32); owner != s.Self {
+		t.Errorf("Upper half owned by %s after the rollback", owner)
+	}
+	if epoch := indexingService.CurrentEpoch(); epoch != 2 {
+		t.Errorf("Epoch is %d after the rollback", epoch)
+	}
+	if len(peer.requests) != 2 || peer.requests[1].RightServer != s.Self {
+		t.Errorf("Peer did not receive the rollback: %v", peer.requests)
+	}
+}
+
+// Peer of a split taking the nodes and rejecting the first mapping updates
+type targetPeer struct {
+	splitPeer
+	nodes int64
+}
+
+func (p *targetPeer) GetVersion(ctx context.Context, in *db.Empty) (*db.VersionResponse, error) {
+	return &db.VersionResponse{ApiVersion: db.APIVersion, ProtocolLevel: protocolCurrent}, nil
+}
+
+func (p *targetPeer) SetIndexingLock(ctx context.Context, in *db.SetIndexingLockRequest) (*db.SetIndexingLockResponse, error) {
+	return &db.SetIndexingLockResponse{Success: true}, nil
+}
+
+func (p *targetPeer) PrepareReceive(ctx context.Context, in *db.PrepareReceiveRequest) (*db.PrepareReceiveResponse, error) {
+	return &db.PrepareReceiveResponse{}, nil
+}
+
+func (p *targetPeer) AddNodes(ctx context.Context, in *db.AddNodesRequest) (*db.AddNodesResponse, error) {
+	resp := &db.AddNodesResponse{}
+	for range in.Requests {
+		resp.Results = append(resp.Results, &db.AddNodeResponse{})
+	}
+	atomic.AddInt64(&p.nodes, int64(len(in.Requests)))
+	return resp, nil
+}
+
+func (p *targetPeer) RemoveNodes(ctx context.Context, in *db.RemoveNodesRequest) (*db.RemoveNodesResponse, error) {
+	atomic.AddInt64(&p.nodes, -int64(len(in.Locations)))
+	return &db.RemoveNodesResponse{}, nil
+}
+
+func TestTriggerSplitRejected(t *testing.T) {
+	s := newTestServer(t)
+	peer := &targetPeer{splitPeer: splitPeer{reject: 1}}
+	address := startPeer(t, peer)
+	s.Seed = s.Self
+	s.Servers = []string{s.Self, address}
+	s.AvailableServers = []string{address}
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		if _, err := s.Set(ctx, &db.SetRequest{Key: fmt.Sprintf("k%d", i), Value: []byte("v"), Dep: root.Location}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nodes := store.NodeCount()
+
+	_, err = s.TriggerSplit(ctx, &db.TriggerSplitRequest{})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("Split rejected by a peer answered with %v", err)
+	}
+	for _, hash := range []uint32{0, math.MaxUint32} {
+		if owner := indexingService.Locate(hash); owner != s.Self {
+			t.Errorf("Hash %x owned by %s after the rollback", hash, owner)
+		}
+	}
+	if store.NodeCount() != nodes {
+		t.Errorf("%d nodes left of %d", store.NodeCount(), nodes)
+	}
+	if sent := atomic.LoadInt64(&peer.nodes); sent != 0 {
+		t.Errorf("%d nodes left on the target", sent)
+	}
+	if len(peer.requests) != 2 {
+		t.Errorf("Peer received %d mapping updates instead of the split and its rollback", len(peer.requests))
+	}
+	if !contains(s.AvailableServers, address) {
+		t.Errorf("Target not available again: %v", s.AvailableServers)
+	}
+}
+
+// Target of a split whose moved nodes cannot be read back
+type unreadablePeer struct {
+	targetPeer
+}
+
+func (p *unreadablePeer) GetNode(ctx context.Context, in *db.GetNodeRequest) (*db.Node, error) {
+	return &db.Node{}, status.Errorf(codes.NotFound, "Location %x not found", in.Location)
+}
+
+func TestSplitVerifyFailedRolledBack(t *testing.T) {
+	s := newTestServer(t)
+	target := &unreadablePeer{}
+	// Down during the first rollback attempts
+	bystander := &targetPeer{splitPeer: splitPeer{failRollbacks: 2}}
+	address := startPeer(t, target)
+	bystanderAddress := startPeer(t, bystander)
+	s.Seed = s.Self
+	s.Servers = []string{s.Self, address, bystanderAddress}
+	s.AvailableServers = []string{address}
+	s.SplitVerifyTolerance = 0
+	attempts, backoff := pool.Attempts, pool.Backoff
+	pool.Attempts, pool.Backoff = 3, time.Millisecond
+	defer func() { pool.Attempts, pool.Backoff = attempts, backoff }()
+
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		if _, err := s.Set(ctx, &db.SetRequest{Key: fmt.Sprintf("k%d", i), Value: []byte("v"), Dep: root.Location}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nodes := store.NodeCount()
+
+	_, err = s.TriggerSplit(ctx, &db.TriggerSplitRequest{})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("Split failing verification answered with %v", err)
+	}
+	if !strings.Contains(err.Error(), "cannot be read back") {
+		t.Fatalf("Split aborted before the verification: %v", err)
+	}
+	if strings.Contains(err.Error(), "not rolled back") || bystander.rollbacks != 3 {
+		t.Errorf("Rollback not retried on the server down: %v", err)
+	}
+	for _, hash := range []uint32{0, math.MaxUint32} {
+		if owner := indexingService.Locate(hash); owner != s.Self {
+			t.Errorf("Hash %x owned by %s after the rollback", hash, owner)
+		}
+		for _, peer := range []*splitPeer{&target.splitPeer, &bystander.splitPeer} {
+			if owner := peer.owner(hash); owner != s.Self {
+				t.Errorf("Hash %x owned by %s on a peer after the rollback", hash, owner)
+			}
+		}
+	}
+	if store.NodeCount() != nodes {
+		t.Errorf("%d nodes left of %d", store.NodeCount(), nodes)
+	}
+}
+
+func TestRollbackSplitPeerDown(t *testing.T) {
+	s := newTestServer(t)
+	down := &splitPeer{failRollbacks: -1}
+	up := &splitPeer{}
+	downAddress := startPeer(t, down)
+	s.Servers = []string{s.Self, downAddress, startPeer(t, up)}
+	attempts, backoff := pool.Attempts, pool.Backoff
+	pool.Attempts, pool.Backoff = 2, time.Millisecond
+	defer func() { pool.Attempts, pool.Backoff = attempts, backoff }()
+	indexingService.TryLock(s.Self, splitLease)
+	defer indexingService.Unlock(s.Self)
+
+	ctx := context.Background()
+	request := &db.SplitRequest{
+		Left:        0,
+		Right:       math.MaxUint32,
+		Mid:         math.MaxUint32 / 2,
+		LeftServer:  s.Self,
+		RightServer: "target",
+		Epoch:       1,
+		Holder:      s.Self,
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.broadcastSplit(ctx, request); err != nil {
+		t.Fatal(err)
+	}
+	err := s.rollbackSplit(ctx, request)
+	if err == nil || !strings.Contains(err.Error(), downAddress) {
+		t.Errorf("Rollback did not report the server down: %v", err)
+	}
+	if down.rollbacks != 2 {
+		t.Errorf("Rollback tried %d times on the server down", down.rollbacks)
+	}
+	// The other servers are still rolled back
+	if owner := indexingService.Locate(math.MaxUint32); owner != s.Self {
+		t.Errorf("Upper half owned by %s after the rollback", owner)
+	}
+	if owner := up.owner(math.MaxUint32); owner != s.Self {
+		t.Errorf("Upper half owned by %s on a peer after the rollback", owner)
+	}
+}
+
+// Target of a split failing to register merge functions
+type registryDownPeer struct {
+	targetPeer
+}
+
+func (p *registryDownPeer) SetMergeFunction(ctx context.Context, in *db.SetMergeFunctionRequest) (*db.Empty, error) {
+	return &db.Empty{}, status.Error(codes.Unavailable, "registry down")
+}
+
+func (p *registryDownPeer) SetScopedMergeFunction(ctx context.Context, in *db.SetScopedMergeFunctionRequest) (*db.Empty, error) {
+	return &db.Empty{}, status.Error(codes.Unavailable, "registry down")
+}
+
+func TestSplitMergeFunctionTransferFailed(t *testing.T) {
+	for _, scoped := range []bool{false, true} {
+		t.Run(fmt.Sprintf("scoped=%v", scoped), func(t *testing.T) {
+			testSplitMergeFunctionTransferFailed(t, scoped)
+		})
+	}
+}
+
+func testSplitMergeFunctionTransferFailed(t *testing.T, scoped bool) {
+	s := newTestServer(t)
+	peer := &registryDownPeer{}
+	address := startPeer(t, peer)
+	s.Seed = s.Self
+	s.Servers = []string{s.Self, address}
+	s.AvailableServers = []string{address}
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		resp, err := s.Set(ctx, &db.SetRequest{Key: fmt.Sprintf("k%d", i), Value: []byte("v"), Dep: root.Location})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if scoped {
+			core.SetKeyMergeFunction(fmt.Sprintf("k%d", i), "merge", engine.Policy{})
+		} else {
+			core.SetMergeFunction(resp.Location, "merge", engine.Policy{})
+		}
+	}
+	nodes := store.NodeCount()
+
+	_, err = s.TriggerSplit(ctx, &db.TriggerSplitRequest{})
+	if status.Code(err) != codes.Aborted || !strings.Contains(err.Error(), "merge functions") {
+		t.Fatalf("Split failing to transfer merge functions answered with %v", err)
+	}
+	if owner := indexingService.Locate(math.MaxUint32); owner != s.Self {
+		t.Errorf("Upper half owned by %s after the aborted split", owner)
+	}
+	if store.NodeCount() != nodes {
+		t.Errorf("%d nodes left of %d", store.NodeCount(), nodes)
+	}
+	if sent := atomic.LoadInt64(&peer.nodes); sent != 0 {
+		t.Errorf("%d nodes left on the target", sent)
+	}
+	if len(peer.requests) != 0 {
+		t.Errorf("Mapping updated for a split that failed to transfer merge functions: %v", peer.requests)
+	}
+	if !contains(s.AvailableServers, address) {
+		t.Errorf("Target not available again: %v", s.AvailableServers)
+	}
+}
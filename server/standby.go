@@ -0,0 +1,758 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/indexing"
+	"github.com/DCsunset/openwhisk-grpc/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Progress of a standby is persisted so that a restart resumes from the applied offset
+const standbyFile = "standby.json"
+
+// Bytes of the log sent per batch by FollowWAL,
+// how often the log is polled and how often heartbeats are sent when it doesn't grow
+const walBatchBytes = 1 << 20
+const walPollInterval = 100 * time.Millisecond
+const walHeartbeat = time.Second
+
+// The applied offset is persisted at most this often
+const standbySaveInterval = time.Second
+const maxFollowBackoff = 10 * time.Second
+
+// Time given to a primary to switch to READONLY when promoting
+const demoteTimeout = 5 * time.Second
+
+// Methods served by a standby
+var standbyMethods = map[string]bool{
+	"/db.DbService/GetStats":        true,
+	"/db.DbService/GetVersion":      true,
+	"/db.DbService/GetConfig":       true,
+	"/db.DbService/ReloadConfig":    true,
+	"/db.DbService/GetSlowLog":      true,
+	"/db.DbService/DescribeService": true,
+	"/db.DbService/WatchEvents":     true,
+	"/db.DbService/Promote":         true,
+	// Served from the applied records (see standbyGet)
+	"/db.DbService/Get": true,
+}
+
+type standbyConfig struct {
+	Primary  string `json:"primary"`
+	Offset   int64  `json:"offset"`
+	Promoted bool   `json:"promoted"`
+}
+
+// Progress of this server following the log of a primary
+type Standby struct {
+	lock    sync.Mutex
+	primary string
+	// Offset of the next record to apply and size of the log last reported by the primary
+	offset  int64
+	logSize int64
+	// When the whole log of the primary was last applied
+	caughtUp  time.Time
+	connected bool
+	err       error
+	applied   int64
+	promoting bool
+	promoted  bool
+	// Whether standby.lagging was published (and not yet standby.caught_up)
+	lagging  bool
+	lagAlert time.Duration
+	saved    time.Time
+	// Stop the follow loop and closed once it stopped
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var standby Standby
+
+// Standbys following the log of this server (listed as replicas of its ranges)
+type Followers struct {
+	lock sync.Mutex
+	// Open FollowWAL streams by follower
+	streams map[string]int
+}
+
+var followers Followers
+
+func (f *Followers) Add(address string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.streams == nil {
+		f.streams = make(map[string]int)
+	}
+	f.streams[address]++
+}
+
+func (f *Followers) Remove(address string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.streams[address]--
+	if f.streams[address] <= 0 {
+		delete(f.streams, address)
+	}
+}
+
+// Followers with an open stream, sorted
+func (f *Followers) List() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var addresses []string
+	for address := range f.streams {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+func (st *Standby) SetLagAlert(alert time.Duration) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	st.lagAlert = alert
+}
+
+func (st *Standby) Primary() string {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return st.primary
+}
+
+// Must be called with the lock held
+func (st *Standby) lag() (int64, time.Duration) {
+	bytes := st.logSize - st.offset
+	if bytes < 0 {
+		bytes = 0
+	}
+	if bytes == 0 && st.connected {
+		return 0, 0
+	}
+	return bytes, time.Since(st.caughtUp)
+}
+
+// Nil unless configured as a standby
+func (st *Standby) ToProto() *db.StandbyStatus {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if len(st.primary) == 0 {
+		return nil
+	}
+	bytes, lag := st.lag()
+	status := &db.StandbyStatus{
+		Primary:        st.primary,
+		Offset:         st.offset,
+		PrimaryLogSize: st.logSize,
+		Connected:      st.connected,
+		AppliedRecords: st.applied,
+		Promoted:       st.promoted,
+	}
+	if !st.promoted {
+		status.LagBytes = bytes
+		status.LagMillis = lag.Milliseconds()
+	}
+	if st.err != nil {
+		status.Error = st.err.Error()
+	}
+	return status
+}
+
+// Start following unless promoted (or promoting).
+// The returned channel must be closed once the follower stopped.
+func (st *Standby) begin() (context.Context, chan struct{}) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if len(st.primary) == 0 || st.promoting || st.promoted {
+		return nil, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	st.cancel, st.done = cancel, make(chan struct{})
+	return ctx, st.done
+}
+
+// Stop the follower for a promotion.
+// Returns false if a promotion is already in progress.
+func (st *Standby) halt() bool {
+	st.lock.Lock()
+	if st.promoting || st.promoted {
+		st.lock.Unlock()
+		return false
+	}
+	st.promoting = true
+	cancel, done := st.cancel, st.done
+	st.lock.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return true
+}
+
+// Record the progress after a batch.
+// Returns whether the whole log of the primary is applied.
+func (st *Standby) advance(offset int64, records int, logSize int64) bool {
+	st.lock.Lock()
+	st.offset = offset
+	st.applied += int64(records)
+	st.logSize = logSize
+	st.connected = true
+	st.err = nil
+	caughtUp := offset >= logSize
+	if caughtUp {
+		st.caughtUp = time.Now()
+	}
+	st.lock.Unlock()
+
+	st.checkLag()
+	return caughtUp
+}
+
+func (st *Standby) disconnected(err error) {
+	st.lock.Lock()
+	st.connected = false
+	st.err = err
+	st.lock.Unlock()
+
+	st.checkLag()
+}
+
+// Publish when the lag crosses the alert threshold
+func (st *Standby) checkLag() {
+	st.lock.Lock()
+	bytes, lag := st.lag()
+	lagging := st.lagAlert > 0 && lag >= st.lagAlert
+	changed := lagging != st.lagging
+	st.lagging = lagging
+	primary := st.primary
+	st.lock.Unlock()
+
+	if !changed {
+		return
+	}
+	attributes := map[string]string{
+		"primary":    primary,
+		"lag_bytes":  strconv.FormatInt(bytes, 10),
+		"lag_millis": strconv.FormatInt(lag.Milliseconds(), 10),
+	}
+	if lagging {
+		events.Publish(eventStandbyLagging, attributes, "Standby of %s is %v (%d bytes) behind", primary, lag.Round(time.Millisecond), bytes)
+	} else {
+		events.Publish(eventStandbyCaughtUp, attributes, "Standby of %s caught up", primary)
+	}
+}
+
+// Whether the applied offset is due to be persisted
+func (st *Standby) saveDue() bool {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	return time.Since(st.saved) >= standbySaveInterval
+}
+
+func (st *Standby) save() error {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	data, _ := json.Marshal(standbyConfig{
+		Primary:  st.primary,
+		Offset:   st.offset,
+		Promoted: st.promoted,
+	})
+	st.saved = time.Now()
+	return ioutil.WriteFile(statePath(standbyFile), data, 0644)
+}
+
+func (s *Server) loadStandby() {
+	if len(s.StandbyOf) == 0 {
+		if s.getMode() == db.Mode_STANDBY {
+			log.Fatalf("Mode %s needs standbyOf in %s", db.Mode_STANDBY, configFile)
+		}
+		return
+	}
+
+	config := standbyConfig{Primary: s.StandbyOf}
+	data, err := ioutil.ReadFile(statePath(standbyFile))
+	if err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			log.Fatalln(err)
+		}
+	} else if !os.IsNotExist(err) {
+		log.Fatalln(err)
+	}
+	standby.caughtUp = time.Now()
+
+	if config.Promoted {
+		log.Printf("Promoted from standby of %s (standbyOf is ignored)", config.Primary)
+		standby.primary, standby.offset, standby.promoted = config.Primary, config.Offset, true
+		// Interrupted between persisting the promotion and the mode
+		if s.getMode() == db.Mode_STANDBY {
+			s.setMode(db.Mode_NORMAL)
+			saveMode(db.Mode_NORMAL)
+		}
+		return
+	}
+	if config.Primary != s.StandbyOf {
+		config = standbyConfig{Primary: s.StandbyOf}
+	}
+	// Nodes applied before a restart are only kept by the disk backend
+	if _, ok := store.Backend.(storage.Syncer); !ok {
+		config.Offset = 0
+	}
+	if config.Offset == 0 && store.NodeCount() > 0 {
+		log.Fatalf("Standby of %s must start with an empty store (%d nodes stored)", config.Primary, store.NodeCount())
+	}
+	standby.primary, standby.offset = config.Primary, config.Offset
+	if s.getMode() != db.Mode_STANDBY {
+		s.setMode(db.Mode_STANDBY)
+		if err := saveMode(db.Mode_STANDBY); err != nil {
+			log.Fatalln(err)
+		}
+	}
+	log.Printf("Standby of %s from offset %d", config.Primary, config.Offset)
+}
+
+// Apply the log of the primary until promoted
+func (s *Server) followLoop() {
+	backoff := time.Second
+	for {
+		ctx, done := standby.begin()
+		if ctx == nil {
+			return
+		}
+		err := s.follow(ctx, false)
+		close(done)
+		if ctx.Err() != nil {
+			return
+		}
+
+		standby.disconnected(err)
+		log.Printf("Fail to follow %s: %v", standby.Primary(), err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxFollowBackoff {
+			backoff = maxFollowBackoff
+		}
+	}
+}
+
+// Apply records of the log of the primary from the applied offset.
+// Returns once the whole log is applied if untilCaughtUp is set.
+func (s *Server) follow(ctx context.Context, untilCaughtUp bool) error {
+	standby.lock.Lock()
+	primary, offset := standby.primary, standby.offset
+	standby.lock.Unlock()
+
+	client, err := pool.Get(primary)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Values are sent inline, whatever their size
+	stream, err := client.FollowWAL(ctx, &db.FollowWALRequest{
+		Offset:   offset,
+		Follower: s.Self,
+	}, grpc.MaxCallRecvMsgSize(math.MaxInt32))
+	if err != nil {
+		return err
+	}
+
+	for {
+		batch, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		for _, record := range batch.Records {
+			if record.Offset != offset {
+				return fmt.Errorf("Record at offset %d while expecting %d", record.Offset, offset)
+			}
+			if record.Node != nil {
+				err = store.Replicate(record.Node)
+			} else {
+				store.RemoveNode(record.Location)
+			}
+			if err != nil {
+				return err
+			}
+			offset = record.Next
+		}
+
+		caughtUp := standby.advance(offset, len(batch.Records), batch.LogSize)
+		if standby.saveDue() || (caughtUp && untilCaughtUp) {
+			// The offset must not get ahead of the applied records
+			if _, err := store.Sync(); err != nil {
+				return err
+			}
+			if err := standby.save(); err != nil {
+				log.Printf("Fail to persist the standby offset: %v", err)
+			}
+		}
+		if caughtUp && untilCaughtUp {
+			return nil
+		}
+	}
+}
+
+// Stream the log of the disk backend to a standby
+func (s *Server) FollowWAL(in *db.FollowWALRequest, stream db.DbService_FollowWALServer) error {
+	reader, ok := store.Backend.(storage.LogReader)
+	if !ok {
+		return status.Errorf(codes.FailedPrecondition, "Server %s keeps no log to follow (backend %q)", s.Self, s.Backend)
+	}
+	log.Printf("Standby %s following from offset %d", in.Follower, in.Offset)
+	if len(in.Follower) > 0 {
+		followers.Add(in.Follower)
+		defer followers.Remove(in.Follower)
+	}
+
+	offset := in.Offset
+	var sent time.Time
+	for {
+		records, size, err := reader.ReadLog(offset, walBatchBytes)
+		if err != nil {
+			return status.Errorf(codes.OutOfRange, "%v", err)
+		}
+		if len(records) > 0 || time.Since(sent) >= walHeartbeat {
+			batch := &db.WALBatch{LogSize: size, Time: time.Now().UnixNano()}
+			for _, record := range records {
+				r := &db.WALRecord{
+					Offset:   record.Offset,
+					Next:     record.Next,
+					Location: record.Location,
+				}
+				if record.Node != nil {
+					if r.Node, err = walNode(record.Node); err != nil {
+						return err
+					}
+				}
+				batch.Records = append(batch.Records, r)
+			}
+			if err := stream.Send(batch); err != nil {
+				return err
+			}
+			sent = time.Now()
+			if len(records) > 0 {
+				offset = records[len(records)-1].Next
+			}
+		}
+		if offset < size {
+			continue
+		}
+
+		select {
+		case <-time.After(walPollInterval):
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// Node of a put record with its value inline.
+// A blob removed since (e.g. by a redaction) is replaced by the current node
+// (nil if removed), which a later record of the log puts too.
+func walNode(node *storage.Node) (*db.Node, error) {
+	loaded, err := store.Load(node)
+	if err == nil {
+		return loaded.ToProto(), nil
+	}
+	current := store.GetNode(node.Location)
+	if current == nil {
+		return nil, nil
+	}
+	if loaded, err = store.Load(current); err != nil {
+		return nil, err
+	}
+	return loaded.ToProto(), nil
+}
+
+func (s *Server) standbyError() error {
+	return status.Errorf(codes.Unavailable, "Server %s is a standby of %s until promoted", s.Self, s.StandbyOf)
+}
+
+// Reads of a standby walk the records applied from the primary (lagging by the reported lag)
+// and continue on the primary from the first node not stored.
+// Snapshots, counters and subscriptions are kept by the primary, so such reads are forwarded.
+func (s *Server) standbyGet(ctx context.Context, in *db.GetRequest) (*db.GetResponse, error) {
+	var err error
+	if in.Key, err = s.normalizeKey(in.Key); err != nil {
+		return &db.GetResponse{}, err
+	}
+	client, err := pool.Get(standby.Primary())
+	if err != nil {
+		return &db.GetResponse{}, err
+	}
+	if len(in.Snapshot) > 0 || len(in.Subscriber) > 0 || (!in.Continuation && isCounter(in.Key)) {
+		return client.Get(ctx, in)
+	}
+	if !in.Continuation {
+		setServedByHeader(ctx, s.Self)
+	}
+
+	walk, err := core.Walk(ctx, in.Key, in.Location, in.AsOf)
+	if err != nil {
+		return &db.GetResponse{}, err
+	}
+	if walk.DeadlineExceeded {
+		return &db.GetResponse{DeadlineExceeded: true, ResumeFrom: walk.Next}, nil
+	}
+	if walk.Remote {
+		request, err := s.continueWalk(in, walk.Next)
+		if err != nil {
+			return &db.GetResponse{}, err
+		}
+		return client.Get(ctx, request)
+	}
+	resp := &db.GetResponse{
+		Value:       walk.Node.Value,
+		CreatedAt:   walk.Node.CreatedAt,
+		Location:    walk.Node.Location,
+		ContentType: walk.Node.ContentType,
+		RemoteHops:  int32(len(in.Path)),
+		Checksum:    walk.Node.Checksum,
+	}
+	if in.MetadataOnly {
+		resp.Value = nil
+	}
+	return resp, nil
+}
+
+// Standbys serve no client traffic but reads
+func (s *Server) standbyInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if s.getMode() == db.Mode_STANDBY && !standbyMethods[info.FullMethod] {
+		return nil, s.standbyError()
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) standbyStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if s.getMode() == db.Mode_STANDBY && !standbyMethods[info.FullMethod] {
+		return s.standbyError()
+	}
+	return handler(srv, ss)
+}
+
+// Take over the ranges of the primary.
+// A reachable primary switches to READONLY and its last records are applied first,
+// otherwise records not applied yet (the reported lag) are lost.
+func (s *Server) Promote(ctx context.Context, in *db.PromoteRequest) (*db.PromoteResponse, error) {
+	if s.getMode() != db.Mode_STANDBY {
+		return &db.PromoteResponse{}, status.Errorf(codes.FailedPrecondition, "Server %s is not a standby", s.Self)
+	}
+	if !standby.halt() {
+		return &db.PromoteResponse{}, status.Errorf(codes.Aborted, "Server %s is already being promoted", s.Self)
+	}
+
+	resp, err := s.promote(ctx, in)
+	if err != nil {
+		log.Printf("Promotion failed: %v", err)
+		standby.lock.Lock()
+		standby.promoting = false
+		standby.lock.Unlock()
+		go s.followLoop()
+		return &db.PromoteResponse{}, err
+	}
+	return resp, nil
+}
+
+func (s *Server) promote(ctx context.Context, in *db.PromoteRequest) (*db.PromoteResponse, error) {
+	timeout := time.Duration(in.TimeoutMillis) * time.Millisecond
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	primary := standby.Primary()
+	demoted := false
+	client, err := pool.Get(primary)
+	if err == nil {
+		demoteCtx, demoteCancel := context.WithTimeout(ctx, demoteTimeout)
+		_, err = client.SetMode(demoteCtx, &db.SetModeRequest{Mode: db.Mode_READONLY})
+		demoteCancel()
+	}
+	if err == nil {
+		demoted = true
+		if err := s.follow(ctx, true); err != nil {
+			return nil, fmt.Errorf("Fail to catch up with %s: %v", primary, err)
+		}
+	} else {
+		log.Printf("Promoting without the records of %s not applied yet: %v", primary, err)
+	}
+	if _, err := store.Sync(); err != nil {
+		return nil, err
+	}
+
+	if len(s.Seed) > 0 {
+		if err := s.register(); err != nil {
+			return nil, fmt.Errorf("Fail to register at seed %s: %v", s.Seed, err)
+		}
+	}
+	s.lock.RLock()
+	servers := append([]string(nil), s.Servers...)
+	s.lock.RUnlock()
+	if !demoted {
+		servers = without(servers, primary)
+	}
+	if !contains(servers, s.Self) {
+		servers = append(servers, s.Self)
+	}
+
+	// Every server learns the whole mapping from the splits
+	sources := servers
+	if demoted {
+		sources = []string{primary}
+	}
+	mapping, err := s.fetchMapping(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	ranges, epoch, err := s.takeOver(ctx, primary, mapping, servers)
+	if err != nil {
+		return nil, err
+	}
+
+	standby.lock.Lock()
+	lagBytes, lag := standby.lag()
+	standby.promoted = true
+	standby.promoting = false
+	standby.lock.Unlock()
+	// Persisted before the mode so that a restart in between doesn't follow again
+	if err := standby.save(); err != nil {
+		log.Printf("Fail to persist the promotion: %v", err)
+	}
+	s.setMode(db.Mode_NORMAL)
+	if err := saveMode(db.Mode_NORMAL); err != nil {
+		log.Printf("Fail to persist mode: %v", err)
+	}
+	if !demoted && len(s.Seed) > 0 {
+		if err := s.registerReplacing(primary); err != nil {
+			log.Printf("Fail to remove %s from the membership: %v", primary, err)
+		}
+	}
+	go s.pullRegistrations()
+
+	attributes := map[string]string{
+		"primary":    primary,
+		"demoted":    strconv.FormatBool(demoted),
+		"lag_bytes":  strconv.FormatInt(lagBytes, 10),
+		"lag_millis": strconv.FormatInt(lag.Milliseconds(), 10),
+		"epoch":      strconv.FormatUint(epoch, 10),
+	}
+	log.Printf("Promoted: %d ranges of %s taken over (primary demoted: %v, lag %d bytes)", len(ranges), primary, demoted, lagBytes)
+	events.Publish(eventStandbyPromoted, attributes, "Standby %s promoted to replace %s (%d ranges, lag %d bytes)", s.Self, primary, len(ranges), lagBytes)
+
+	return &db.PromoteResponse{
+		Primary:        primary,
+		Ranges:         ranges,
+		PrimaryDemoted: demoted,
+		LagBytes:       lagBytes,
+		LagMillis:      lag.Milliseconds(),
+		Epoch:          epoch,
+	}, nil
+}
+
+// Mapping of the first server answering
+func (s *Server) fetchMapping(ctx context.Context, servers []string) (*db.Mapping, error) {
+	err := status.Errorf(codes.Unavailable, "No server to get the mapping from")
+	for _, addr := range servers {
+		if addr == s.Self {
+			continue
+		}
+		var client db.DbServiceClient
+		client, err = pool.Get(addr)
+		if err != nil {
+			continue
+		}
+		var mapping *db.Mapping
+		mapping, err = client.GetMapping(ctx, &db.Empty{})
+		if err == nil {
+			return mapping, nil
+		}
+	}
+	return nil, fmt.Errorf("Fail to get the mapping: %v", err)
+}
+
+// Adopt the mapping and assign the ranges of the primary to this server on all servers
+func (s *Server) takeOver(ctx context.Context, primary string, mapping *db.Mapping, servers []string) ([]*db.Range, uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var mappings []indexing.Mapping
+	var ranges []*db.Range
+	for _, r := range mapping.Ranges {
+		mappings = append(mappings, indexing.Mapping{Left: r.Left, Right: r.Right, Address: r.Server})
+		if r.Server == primary {
+			ranges = append(ranges, r)
+		}
+	}
+	indexingService.Reset(mappings, mapping.Epoch)
+	if len(ranges) == 0 {
+		return nil, 0, status.Errorf(codes.FailedPrecondition, "Primary %s owns no range", primary)
+	}
+
+	level, err := s.negotiateProtocol(ctx, servers)
+	if err != nil {
+		return nil, 0, err
+	}
+	if level < protocolAbsorb {
+		return nil, 0, status.Errorf(codes.FailedPrecondition, "Some servers speak split protocol level %d, older than %d needed to absorb ranges", level, protocolAbsorb)
+	}
+	held, epoch, err := s.lockServersOf(ctx, servers, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer s.unlockServers(ctx, held)
+
+	for _, r := range ranges {
+		epoch += 1
+		request := &db.SplitRequest{
+			Left:        r.Left,
+			Right:       r.Right,
+			Mid:         r.Right,
+			LeftServer:  s.Self,
+			RightServer: s.Self,
+			Epoch:       epoch,
+			Holder:      s.Self,
+		}
+		for _, addr := range servers {
+			if addr == s.Self {
+				err = s.splitLocally(request)
+			} else {
+				var peer db.DbServiceClient
+				peer, err = pool.Get(addr)
+				if err == nil {
+					_, err = peer.Split(ctx, request)
+				}
+			}
+			if err != nil {
+				// Retrying takes over the ranges not committed yet
+				return nil, 0, fmt.Errorf("Fail to update the mapping of %s: %v", addr, err)
+			}
+		}
+		r.Server = s.Self
+		r.Replicas = []string{s.Self}
+	}
+	return ranges, epoch, nil
+}
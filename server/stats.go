@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"math"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+// Default max descendants visited for node stats
+const defaultDescendantBudget = 1000
+
+// Depth of the node from its root and the number of cross-server hops
+func (s *Server) nodeDepth(ctx context.Context, location uint64) (int64, int64, error) {
+	var path []uint64
+	var depth, hops int64
+
+	for {
+		if d, ok := store.CachedDepth(location); ok {
+			depth = d
+			break
+		}
+
+		address := indexingService.ResolveOwner(location)
+		if address != s.Self {
+			// Continue on the owner of the location
+			node, err := s.GetNode(ctx, &db.GetNodeRequest{
+				Location:         location,
+				IncludeStats:     true,
+				DescendantBudget: -1,
+			})
+			if err != nil {
+				return 0, 0, err
+			}
+			depth = node.Stats.Depth
+			hops = node.Stats.Hops + 1
+			break
+		}
+
+		node := store.GetNode(location)
+		if node == nil {
+			return 0, 0, &dberrors.LocationNotFoundError{Location: location}
+		}
+		if node.Dep == math.MaxUint64 {
+			store.CacheDepth(location, 0)
+			depth = 0
+			break
+		}
+		path = append(path, location)
+		location = node.Dep
+	}
+
+	// Cache depth of local nodes on the path
+	for i := len(path) - 1; i >= 0; i-- {
+		depth += 1
+		store.CacheDepth(path[i], depth)
+	}
+	return depth, hops, nil
+}
+
+// Count descendants of the node with a traversal budget
+func (s *Server) countDescendants(ctx context.Context, node *db.Node, budget int64) (int64, bool, error) {
+	count := int64(0)
+	queue := append([]uint64(nil), node.Children...)
+	for len(queue) > 0 {
+		if count >= budget {
+			return count, true, nil
+		}
+		location := queue[0]
+		queue = queue[1:]
+		count += 1
+
+		child, err := s.GetNode(ctx, &db.GetNodeRequest{Location: location})
+		if err != nil {
+			return count, false, err
+		}
+		queue = append(queue, child.Children...)
+	}
+	return count, false, nil
+}
+
+func (s *Server) nodeStats(ctx context.Context, node *db.Node, budget int64) (*db.NodeStats, error) {
+	depth, hops, err := s.nodeDepth(ctx, node.Location)
+	if err != nil {
+		return nil, err
+	}
+	stats := &db.NodeStats{
+		Depth:    depth,
+		Hops:     hops,
+		Children: int64(len(node.Children)),
+	}
+
+	if budget == 0 {
+		budget = defaultDescendantBudget
+	}
+	if budget > 0 {
+		stats.Descendants, stats.Truncated, err = s.countDescendants(ctx, node, budget)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata set by clients with the activation ID of the invoking action
+const activationIdKey = "activation-id"
+
+// Response headers of a Set that triggered a merge synchronously
+const mergeActivationKey = "merge-activation-id"
+const mergeDurationKey = "merge-duration-ms"
+
+// Response header with the server that received a read
+const servedByKey = "served-by"
+
+// Metadata to store on nodes created by the request
+func nodeMetadata(ctx context.Context) map[string]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	values := md.Get(activationIdKey)
+	if len(values) == 0 {
+		return nil
+	}
+	return map[string]string{activationIdKey: values[0]}
+}
+
+// Keep the activation ID when forwarding the request
+func forwardContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get(activationIdKey)
+	if len(values) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, activationIdKey, values[0])
+}
+
+func setMergeHeader(ctx context.Context, record *db.MergeRecord) {
+	grpc.SetHeader(ctx, metadata.Pairs(
+		mergeActivationKey, record.ActivationId,
+		mergeDurationKey, strconv.FormatInt(record.DurationMillis, 10),
+	))
+}
+
+// Pass merge headers from the owner back to the client
+func copyMergeHeader(ctx context.Context, header metadata.MD) {
+	md := metadata.MD{}
+	for _, key := range []string{mergeActivationKey, mergeDurationKey} {
+		if values := header.Get(key); len(values) > 0 {
+			md.Set(key, values...)
+		}
+	}
+	if md.Len() > 0 {
+		grpc.SetHeader(ctx, md)
+	}
+}
+
+func setServedByHeader(ctx context.Context, server string) {
+	grpc.SetHeader(ctx, metadata.Pairs(servedByKey, server))
+}
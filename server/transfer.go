@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of nodes per AddNodes call of a split, adjusted like a congestion window:
+// halved when the receiver takes longer than the target to add a batch,
+// grown by a quarter when it takes less than half of it and its queue is not growing.
+type transferWindow struct {
+	size     int
+	min, max int
+	target   time.Duration
+	queue    int64
+	// Sizes sent, for the split event
+	batches  int
+	smallest int
+	largest  int
+}
+
+func newTransferWindow(min, max int, target time.Duration) *transferWindow {
+	if min < 1 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+	return &transferWindow{size: min, min: min, max: max, target: target}
+}
+
+func (w *transferWindow) Sent(size int) {
+	w.batches += 1
+	if w.smallest == 0 || size < w.smallest {
+		w.smallest = size
+	}
+	if size > w.largest {
+		w.largest = size
+	}
+}
+
+// Adjust the size to the feedback of the receiver
+func (w *transferWindow) Update(apply time.Duration, queue int64) {
+	switch {
+	case apply > w.target:
+		w.size /= 2
+	case apply < w.target/2 && queue <= w.queue:
+		w.size += w.size/4 + 1
+	}
+	if w.size < w.min {
+		w.size = w.min
+	}
+	if w.size > w.max {
+		w.size = w.max
+	}
+	w.queue = queue
+}
+
+// Attributes of the split event
+func (w *transferWindow) Attributes(nodes int, elapsed time.Duration) map[string]string {
+	attributes := map[string]string{
+		"batches":       strconv.Itoa(w.batches),
+		"smallestBatch": strconv.Itoa(w.smallest),
+		"largestBatch":  strconv.Itoa(w.largest),
+		"finalBatch":    strconv.Itoa(w.size),
+	}
+	if elapsed > 0 {
+		attributes["nodesPerSecond"] = strconv.FormatFloat(float64(nodes)/elapsed.Seconds(), 'f', 1, 64)
+	}
+	return attributes
+}
+
+// Send the nodes of a split to the target in batches sized by its feedback
+// (one by one if it is older than AddNodes).
+// The leases of the indexing locks held on the servers are renewed meanwhile.
+// Returns the number of nodes it already had.
+func (s *Server) sendNodes(ctx context.Context, client db.DbServiceClient, attempt *splitAttempt, nodes []*db.Node, token string, held []string) (int, error) {
+	window := newTransferWindow(s.TransferBatchMin, s.TransferBatchMax, time.Duration(s.TransferApplyTargetMillis)*time.Millisecond)
+	start := time.Now()
+	renewed := start
+	skipped := 0
+	batched := true
+	for sent := 0; sent < len(nodes); {
+		// Transfers can outlast the lease
+		if len(held) > 0 && time.Since(renewed) > splitLease/2 {
+			if err := s.renewLocks(ctx, held, attempt.target); err != nil {
+				return skipped, err
+			}
+			renewed = time.Now()
+		}
+		size := 1
+		if batched {
+			size = window.size
+		}
+		if sent+size > len(nodes) {
+			size = len(nodes) - sent
+		}
+		batch := nodes[sent : sent+size]
+		requests := make([]*db.AddNodeRequest, len(batch))
+		for i, node := range batch {
+			requests[i] = &db.AddNodeRequest{
+				Node: node,
+				// Placed by the rules when the node was created
+				SkipLocationCheck: true,
+				TransferToken:     token,
+			}
+		}
+
+		var results []*db.AddNodeResponse
+		if batched {
+			// Nodes a failed call added are skipped when the batch is sent again
+			var resp *db.AddNodesResponse
+			err := pool.Retry(ctx, func() error {
+				var err error
+				resp, err = client.AddNodes(ctx, &db.AddNodesRequest{Requests: requests})
+				return err
+			})
+			if status.Code(err) == codes.Unimplemented {
+				batched = false
+				continue
+			}
+			if err != nil {
+				return skipped, err
+			}
+			if failure := resp.Failure; failure != nil {
+				// Nodes added before the failure are cleaned up with the attempt
+				for i := range resp.Results {
+					splitAttempts.Transferred(attempt, batch[i])
+				}
+				return skipped, status.Error(codes.Code(failure.Code), failure.Message)
+			}
+			window.Sent(size)
+			window.Update(time.Duration(resp.ApplyMicros)*time.Microsecond, resp.QueueDepth)
+			results = resp.Results
+		} else {
+			resp, err := client.AddNode(ctx, requests[0])
+			if err != nil {
+				return skipped, err
+			}
+			results = []*db.AddNodeResponse{resp}
+		}
+		for i, result := range results {
+			if result.Skipped {
+				skipped += 1
+			}
+			splitAttempts.Transferred(attempt, batch[i])
+		}
+		sent += size
+	}
+	if batched && window.batches > 0 {
+		splitAttempts.Batched(attempt, window.Attributes(len(nodes), time.Since(start)))
+	}
+	return skipped, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Receiver of AddNodes reporting the feedback of a script,
+// failing the first calls with errors
+type transferClient struct {
+	db.DbServiceClient
+	errors []error
+	// Feedback of the nth successful call
+	feedback func(n int) (time.Duration, int64)
+	// Node of a call (counted from 0) failing with a Failure
+	failAt int
+	calls  int
+	sizes  []int
+}
+
+func (c *transferClient) AddNodes(ctx context.Context, in *db.AddNodesRequest, opts ...grpc.CallOption) (*db.AddNodesResponse, error) {
+	c.calls += 1
+	if len(c.errors) > 0 {
+		err := c.errors[0]
+		c.errors = c.errors[1:]
+		return nil, err
+	}
+	resp := &db.AddNodesResponse{}
+	for _, request := range in.Requests {
+		if c.failAt >= 0 && request.Node.Location == uint64(c.failAt) {
+			resp.Failure = &db.EntryStatus{Code: uint32(codes.ResourceExhausted), Message: "full"}
+			return resp, nil
+		}
+		resp.Results = append(resp.Results, &db.AddNodeResponse{})
+	}
+	apply, queue := c.feedback(len(c.sizes))
+	c.sizes = append(c.sizes, len(in.Requests))
+	resp.ApplyMicros = apply.Microseconds()
+	resp.QueueDepth = queue
+	return resp, nil
+}
+
+func transferNodes(n int) []*db.Node {
+	nodes := make([]*db.Node, n)
+	for i := range nodes {
+		nodes[i] = &db.Node{Location: uint64(i), Key: "k"}
+	}
+	return nodes
+}
+
+func TestTransferWindow(t *testing.T) {
+	w := newTransferWindow(1, 64, 10*time.Millisecond)
+	previous := w.size
+	for i := 0; i < 30; i++ {
+		w.Update(time.Millisecond, 0)
+		if w.size < previous {
+			t.Fatalf("Window shrank from %d to %d on fast batches", previous, w.size)
+		}
+		previous = w.size
+	}
+	if w.size != 64 {
+		t.Errorf("Window grew to %d instead of the max 64", w.size)
+	}
+
+	// A growing queue stops the growth
+	w.size = 8
+	for queue := int64(1); queue < 10; queue++ {
+		w.Update(time.Millisecond, queue)
+		if w.size != 8 {
+			t.Fatalf("Window changed to %d with a growing queue", w.size)
+		}
+	}
+
+	// Slow batches halve it down to the min
+	w.size = 64
+	for _, expected := range []int{32, 16, 8, 4, 2, 1, 1} {
+		w.Update(20*time.Millisecond, 0)
+		if w.size != expected {
+			t.Fatalf("Window is %d instead of %d after a slow batch", w.size, expected)
+		}
+	}
+}
+
+// The window follows the feedback of the receiver during a transfer
+func TestSendNodesWindow(t *testing.T) {
+	s := newTestServer(t)
+	s.TransferBatchMin, s.TransferBatchMax, s.TransferApplyTargetMillis = 1, 16, 10
+	client := &transferClient{
+		failAt: -1,
+		feedback: func(n int) (time.Duration, int64) {
+			switch {
+			case n < 5:
+				// Fast but queueing
+				return time.Millisecond, int64(n + 1)
+			case n < 15:
+				return time.Millisecond, 5
+			default:
+				return 50 * time.Millisecond, 5
+			}
+		},
+	}
+	nodes := transferNodes(200)
+	attempt := splitAttempts.Start("target")
+	if _, err := s.sendNodes(context.Background(), client, attempt, nodes, "", nil); err != nil {
+		t.Fatal(err)
+	}
+	sent := 0
+	for _, size := range client.sizes {
+		sent += size
+	}
+	if sent != len(nodes) || len(attempt.transferred) != len(nodes) {
+		t.Fatalf("%d nodes sent and %d transferred instead of %d", sent, len(attempt.transferred), len(nodes))
+	}
+	for i := 1; i < len(client.sizes); i++ {
+		size, previous := client.sizes[i], client.sizes[i-1]
+		switch {
+		case i <= 5 && size != 1:
+			t.Errorf("Batch %d of %d nodes while the queue grows", i, size)
+		case i > 5 && i <= 15 && size < previous:
+			t.Errorf("Batch %d shrank from %d to %d on fast batches", i, previous, size)
+		case i > 15 && size > previous:
+			t.Errorf("Batch %d grew from %d to %d on slow batches", i, previous, size)
+		}
+	}
+	if client.sizes[15] != 16 {
+		t.Errorf("Window reached %d instead of the max 16: %v", client.sizes[15], client.sizes)
+	}
+	if last := client.sizes[len(client.sizes)-2]; last != 1 {
+		t.Errorf("Window shrank to %d instead of the min 1: %v", last, client.sizes)
+	}
+}
+
+// Failed batches are sent again when the failure is transient,
+// otherwise the transfer is aborted with the nodes added before the failure recorded
+func TestSendNodesFailedBatch(t *testing.T) {
+	s := newTestServer(t)
+	s.TransferBatchMin, s.TransferBatchMax = 4, 4
+	attempts, backoff := pool.Attempts, pool.Backoff
+	pool.Attempts, pool.Backoff = 3, time.Millisecond
+	defer func() { pool.Attempts, pool.Backoff = attempts, backoff }()
+	fast := func(n int) (time.Duration, int64) { return 0, 0 }
+	unavailable := status.Error(codes.Unavailable, "receiver down")
+
+	t.Run("retried", func(t *testing.T) {
+		client := &transferClient{failAt: -1, feedback: fast, errors: []error{unavailable, unavailable}}
+		attempt := splitAttempts.Start("target")
+		if _, err := s.sendNodes(context.Background(), client, attempt, transferNodes(8), "", nil); err != nil {
+			t.Fatal(err)
+		}
+		if client.calls != 4 || len(attempt.transferred) != 8 {
+			t.Errorf("%d calls and %d nodes transferred", client.calls, len(attempt.transferred))
+		}
+	})
+
+	t.Run("receiver down", func(t *testing.T) {
+		client := &transferClient{failAt: -1, feedback: fast, errors: []error{unavailable, unavailable, unavailable}}
+		attempt := splitAttempts.Start("target")
+		_, err := s.sendNodes(context.Background(), client, attempt, transferNodes(8), "", nil)
+		if status.Code(err) != codes.Unavailable || client.calls != 3 || len(attempt.transferred) != 0 {
+			t.Errorf("sendNodes returned %v after %d calls with %d nodes transferred", err, client.calls, len(attempt.transferred))
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		client := &transferClient{failAt: 6, feedback: fast}
+		attempt := splitAttempts.Start("target")
+		_, err := s.sendNodes(context.Background(), client, attempt, transferNodes(8), "", nil)
+		if status.Code(err) != codes.ResourceExhausted || client.calls != 2 {
+			t.Errorf("sendNodes returned %v after %d calls", err, client.calls)
+		}
+		// Cleaned up with the attempt
+		if len(attempt.transferred) != 6 {
+			t.Errorf("%d nodes transferred instead of 6", len(attempt.transferred))
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Interval of dropping values of expired nodes
+const ttlSweepInterval = time.Minute
+
+// Expired nodes as of the last sweep
+var expiredNodes int64
+
+// Turn expired nodes into stubs (run by the scheduler)
+func (s *Server) sweep() error {
+	expired, err := store.Tombstone(snapshots.ReclaimBefore(time.Now().UnixNano()))
+	if err != nil {
+		return fmt.Errorf("Fail to sweep expired nodes: %v", err)
+	}
+	atomic.StoreInt64(&expiredNodes, int64(expired))
+	s.expireAliases()
+	return nil
+}
+
+// Remove expired nodes that no node depends on.
+// Their parents may become removable leaves for the next compaction.
+// At most budget nodes are removed unless it is 0.
+func (s *Server) removeExpired(ctx context.Context, budget int) int64 {
+	removed := int64(0)
+	for _, node := range store.ExpiredLeaves(snapshots.ReclaimBefore(time.Now().UnixNano())) {
+		if budget > 0 && removed >= int64(budget) {
+			break
+		}
+		if len(snapshots.Unpinned([]uint64{node.Location})) == 0 {
+			continue
+		}
+		if node.Dep != math.MaxUint64 {
+			if _, err := s.UnlinkChild(ctx, &db.AddChildRequest{
+				Location: node.Dep,
+				Child:    node.Location,
+			}); err != nil {
+				log.Printf("Fail to unlink expired node %x: %v", node.Location, err)
+				continue
+			}
+		}
+		store.RemoveNode(node.Location)
+		removed += 1
+	}
+	return removed
+}
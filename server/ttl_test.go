@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+func mustSet(t *testing.T, s *Server, in *db.SetRequest) uint64 {
+	t.Helper()
+	resp, err := s.Set(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Set of %s failed: %v", in.Key, err)
+	}
+	return resp.Location
+}
+
+// Value of the key read from the location (the error if it fails)
+func readValue(t *testing.T, s *Server, key string, location uint64) (string, error) {
+	t.Helper()
+	resp, err := s.Get(context.Background(), &db.GetRequest{Key: key, Location: location})
+	if err != nil {
+		return "", err
+	}
+	return string(resp.Value), nil
+}
+
+func expireNow(t *testing.T, location uint64) {
+	t.Helper()
+	if err := store.Expire(location, time.Now().Add(-time.Second).UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// An expired node in the middle of a chain is skipped by reads,
+// and kept as a stub until the nodes depending on it are gone
+func TestExpiryMidChain(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := mustSet(t, s, &db.SetRequest{Key: "a", Value: []byte("1"), Dep: root.Location})
+	middle := mustSet(t, s, &db.SetRequest{Key: "a", Value: []byte("2"), Dep: first, TtlSeconds: 3600})
+	end := mustSet(t, s, &db.SetRequest{Key: "b", Value: []byte("x"), Dep: middle})
+	if value, err := readValue(t, s, "a", end); err != nil || value != "2" {
+		t.Fatalf("Read before the expiry returned %q, %v", value, err)
+	}
+
+	expireNow(t, middle)
+	if value, err := readValue(t, s, "a", end); err != nil || value != "1" {
+		t.Errorf("Read past the expired node returned %q, %v, expected the older value", value, err)
+	}
+	if err := s.sweep(); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := s.GetStats(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ExpiredNodes < 1 {
+		t.Errorf("GetStats reports %d expired nodes", stats.ExpiredNodes)
+	}
+	stub := store.GetNode(middle)
+	if stub == nil || stub.Value != nil {
+		t.Fatalf("Expired node with a child is %+v, expected a stub", stub)
+	}
+	for _, read := range []struct{ key, value string }{{"a", "1"}, {"b", "x"}} {
+		if value, err := readValue(t, s, read.key, end); err != nil || value != read.value {
+			t.Errorf("Read of %s through the stub returned %q, %v", read.key, value, err)
+		}
+	}
+
+	s.removeExpired(ctx, 0)
+	if store.GetNode(middle) == nil {
+		t.Fatalf("Expired node removed while a node depends on it")
+	}
+	// Reclaimed from the end of the chain
+	expireNow(t, end)
+	s.removeExpired(ctx, 0)
+	s.removeExpired(ctx, 0)
+	if store.GetNode(end) != nil || store.GetNode(middle) != nil {
+		t.Errorf("Expired chain end not reclaimed")
+	}
+	if children := store.GetNode(first).Children; len(children) != 0 {
+		t.Errorf("Reclaimed node still linked to its parent: %x", children)
+	}
+}
+
+// Children of an expired conflict parent still read their own values and the keys above it
+func TestExpiryOfConflictParent(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	root, err := s.CreateRoot(ctx, &db.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := mustSet(t, s, &db.SetRequest{Key: "base", Value: []byte("b"), Dep: root.Location})
+	parent := mustSet(t, s, &db.SetRequest{Key: "p", Value: []byte("parent"), Dep: base, TtlSeconds: 3600})
+	left := mustSet(t, s, &db.SetRequest{Key: "k", Value: []byte("left"), Dep: parent})
+	right := mustSet(t, s, &db.SetRequest{Key: "k", Value: []byte("right"), Dep: parent})
+
+	expireNow(t, parent)
+	if err := s.sweep(); err != nil {
+		t.Fatal(err)
+	}
+	s.removeExpired(ctx, 0)
+
+	node := store.GetNode(parent)
+	if node == nil || node.Value != nil || len(node.Children) != 2 {
+		t.Fatalf("Expired conflict parent is %+v, expected a stub with both children", node)
+	}
+	for _, branch := range []struct {
+		location uint64
+		value    string
+	}{{left, "left"}, {right, "right"}} {
+		if value, err := readValue(t, s, "k", branch.location); err != nil || value != branch.value {
+			t.Errorf("Read of the branch returned %q, %v, expected %q", value, err, branch.value)
+		}
+		if value, err := readValue(t, s, "base", branch.location); err != nil || value != "b" {
+			t.Errorf("Read above the expired parent returned %q, %v", value, err)
+		}
+		if _, err := readValue(t, s, "p", branch.location); !errors.Is(err, dberrors.ErrKeyNotFound) {
+			t.Errorf("Read of the expired key returned %v, expected not found", err)
+		}
+	}
+}
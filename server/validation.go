@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Validation rules of values by key prefix
+const validationFile = "validation.json"
+
+const contentTypeJSON = "application/json"
+
+type ValueRules struct {
+	lock  sync.RWMutex
+	rules []*db.ValidationRule
+	// Parsed schemas by prefix
+	schemas map[string]map[string]interface{}
+}
+
+var valueRules = ValueRules{}
+
+// Replace the rules (nothing is changed if a schema is invalid)
+func (r *ValueRules) Set(rules []*db.ValidationRule) error {
+	schemas := make(map[string]map[string]interface{})
+	for _, rule := range rules {
+		if len(rule.Schema) == 0 {
+			continue
+		}
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(rule.Schema), &schema); err != nil {
+			return fmt.Errorf("Invalid schema of %s: %v", rule.Prefix, err)
+		}
+		schemas[rule.Prefix] = schema
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.rules = rules
+	r.schemas = schemas
+	return nil
+}
+
+func (r *ValueRules) Get() []*db.ValidationRule {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.rules
+}
+
+// Rule with the longest prefix of the key (nil if none)
+func (r *ValueRules) Match(key string) (*db.ValidationRule, map[string]interface{}) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var match *db.ValidationRule
+	for _, rule := range r.rules {
+		if strings.HasPrefix(key, rule.Prefix) && (match == nil || len(rule.Prefix) > len(match.Prefix)) {
+			match = rule
+		}
+	}
+	if match == nil {
+		return nil, nil
+	}
+	return match, r.schemas[match.Prefix]
+}
+
+func loadValidation() {
+	data, err := ioutil.ReadFile(statePath(validationFile))
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var rules db.ValidationRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		log.Fatalln(err)
+	}
+	if err := valueRules.Set(rules.Rules); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Check the value against the rule of the key.
+// Returns the content type to store (JSON if required and unset).
+func validateValue(key string, contentType string, value []byte) (string, error) {
+	rule, schema := valueRules.Match(key)
+	if rule == nil || (!rule.RequireJson && schema == nil) {
+		return contentType, nil
+	}
+	if len(contentType) == 0 {
+		contentType = contentTypeJSON
+	} else if !strings.HasPrefix(contentType, contentTypeJSON) {
+		return "", status.Errorf(codes.InvalidArgument, "Values of keys under %q must be %s, not %s", rule.Prefix, contentTypeJSON, contentType)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal(value, &parsed); err != nil {
+		if syntax, ok := err.(*json.SyntaxError); ok {
+			return "", status.Errorf(codes.InvalidArgument, "Value of %s is not valid JSON at offset %d: %v", key, syntax.Offset, err)
+		}
+		return "", status.Errorf(codes.InvalidArgument, "Value of %s is not valid JSON: %v", key, err)
+	}
+	if schema != nil {
+		if err := matchSchema(schema, parsed, "$"); err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "Value of %s does not match the schema of %q: %v", key, rule.Prefix, err)
+		}
+	}
+	return contentType, nil
+}
+
+// Check the value against a subset of JSON Schema: type, enum, properties,
+// required, additionalProperties (false), items, minimum and maximum
+func matchSchema(schema map[string]interface{}, value interface{}, path string) error {
+	if t, ok := schema["type"]; ok && !matchType(t, value) {
+		return fmt.Errorf("%s is %s, expected %v", path, typeName(value), t)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s is not one of %v", path, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				n, _ := name.(string)
+				if _, ok := v[n]; !ok {
+					return fmt.Errorf("%s misses %s", path, n)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, field := range v {
+			property, ok := properties[name].(map[string]interface{})
+			if !ok {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s has unexpected property %s", path, name)
+				}
+				continue
+			}
+			if err := matchSchema(property, field, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := matchSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			return fmt.Errorf("%s is less than %v", path, min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && v > max {
+			return fmt.Errorf("%s is greater than %v", path, max)
+		}
+	}
+	return nil
+}
+
+// The type is a name or a list of names
+func matchType(t interface{}, value interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		name := typeName(value)
+		return name == t || (t == "number" && name == "integer")
+	case []interface{}:
+		for _, name := range t {
+			if matchType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func typeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "unknown"
+}
+
+func (s *Server) GetValidationRules(ctx context.Context, in *db.Empty) (*db.ValidationRules, error) {
+	return &db.ValidationRules{Rules: valueRules.Get()}, nil
+}
+
+// Replace the validation rules on all servers
+func (s *Server) SetValidationRules(ctx context.Context, in *db.ValidationRules) (*db.Empty, error) {
+	if err := valueRules.Set(in.Rules); err != nil {
+		return &db.Empty{}, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	data, _ := json.Marshal(&db.ValidationRules{Rules: in.Rules})
+	if err := ioutil.WriteFile(statePath(validationFile), data, 0644); err != nil {
+		return &db.Empty{}, err
+	}
+	log.Printf("Validation rules updated (%d rules)", len(in.Rules))
+	if in.Replicated {
+		return &db.Empty{}, nil
+	}
+
+	for _, addr := range s.Servers {
+		if addr == s.Self {
+			continue
+		}
+		client, err := pool.Get(addr)
+		if err == nil {
+			_, err = client.SetValidationRules(ctx, &db.ValidationRules{Rules: in.Rules, Replicated: true})
+		}
+		if err != nil {
+			log.Printf("Fail to push validation rules to %s: %v", addr, err)
+		}
+	}
+	return &db.Empty{}, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/DCsunset/openwhisk-grpc/storage"
+)
+
+// Exit codes of the startup verification
+const (
+	verifyClean         = 0
+	verifyRepaired      = 1
+	verifyUnrecoverable = 2
+)
+
+// Examples listed per check
+const verifyExamples = 5
+
+type verifyCheck struct {
+	name     string
+	failures int
+	examples []string
+}
+
+func (c *verifyCheck) fail(format string, args ...interface{}) {
+	c.failures += 1
+	if len(c.examples) < verifyExamples {
+		c.examples = append(c.examples, fmt.Sprintf(format, args...))
+	}
+}
+
+func (c *verifyCheck) report() {
+	if c.failures == 0 {
+		log.Printf("Verify %s: ok", c.name)
+		return
+	}
+	log.Printf("Verify %s: %d failures, e.g. %v", c.name, c.failures, c.examples)
+}
+
+// Audit the local store before serving.
+// With repair, rebuilds the indexes and drops children missing locally;
+// nodes at invalid locations are never repaired.
+// Returns one of the verify exit codes.
+func (s *Server) verifyStore(repair bool) int {
+	accounting := &verifyCheck{name: "accounting"}
+	if err := store.Verify(); err != nil {
+		accounting.fail("%v", err)
+	}
+
+	locations := &verifyCheck{name: "locations"}
+	dangling := &verifyCheck{name: "children"}
+	type link struct{ parent, child uint64 }
+	var missing []link
+	store.IterateHashRange(0, math.MaxUint32, func(node *storage.Node) bool {
+		if err := storage.CheckLocation(node.Location, node.Key); err != nil {
+			locations.fail("%v", err)
+		}
+		for _, child := range node.Children {
+			// Children owned by other servers are assumed to exist
+			if indexingService.ResolveOwner(child) != s.Self {
+				continue
+			}
+			if store.GetNode(child) == nil {
+				dangling.fail("%x of %x", child, node.Location)
+				missing = append(missing, link{node.Location, child})
+			}
+		}
+		return true
+	})
+
+	accounting.report()
+	locations.report()
+	dangling.report()
+	// The mapping of ranges is not persisted, so owned ranges are only known from the seed
+	log.Printf("Verify ranges: skipped (the mapping is not persisted)")
+
+	if locations.failures > 0 {
+		return verifyUnrecoverable
+	}
+	if accounting.failures == 0 && dangling.failures == 0 {
+		return verifyClean
+	}
+	if !repair {
+		return verifyUnrecoverable
+	}
+
+	store.Rebuild()
+	for _, l := range missing {
+		if _, err := store.RemoveChild(l.parent, l.child); err != nil {
+			log.Printf("Repair failed to drop child %x of %x: %v", l.child, l.parent, err)
+			return verifyUnrecoverable
+		}
+	}
+	if err := store.Verify(); err != nil {
+		log.Printf("Repair failed: %v", err)
+		return verifyUnrecoverable
+	}
+	log.Printf("Repaired %d accounting errors and %d dangling children", accounting.failures, dangling.failures)
+	return verifyRepaired
+}
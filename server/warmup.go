@@ -0,0 +1,144 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+)
+
+// Defaults of the warm-up after receiving nodes
+const defaultWarmUpNodes = 1000
+const defaultWarmUpBudget = 10000
+
+// Delay after the last received node before warming up
+const warmUpDelay = 100 * time.Millisecond
+
+type warmUpNode struct {
+	location  uint64
+	createdAt int64
+}
+
+// Resolves chains of recently received nodes to fill the depth cache
+// (and the page cache of the disk backend) before traffic moves over
+type WarmUp struct {
+	// Max nodes warmed per batch (negative disables warm-up)
+	Nodes int
+	// Max nodes visited per batch
+	Budget int
+
+	lock    sync.Mutex
+	pending []warmUpNode
+	timer   *time.Timer
+
+	// Counters for GetStats
+	Queued int64
+	Warmed int64
+}
+
+var warmUp = WarmUp{}
+
+func (w *WarmUp) Init(nodes, budget int) {
+	w.Nodes = nodes
+	if w.Nodes == 0 {
+		w.Nodes = defaultWarmUpNodes
+	}
+	w.Budget = budget
+	if w.Budget <= 0 {
+		w.Budget = defaultWarmUpBudget
+	}
+}
+
+// Must be called with the lock held
+func (w *WarmUp) truncate() {
+	sort.Slice(w.pending, func(i, j int) bool {
+		return w.pending[i].createdAt > w.pending[j].createdAt
+	})
+	if len(w.pending) > w.Nodes {
+		w.pending = w.pending[:w.Nodes]
+	}
+	atomic.StoreInt64(&w.Queued, int64(len(w.pending)))
+}
+
+func (w *WarmUp) Add(node *db.Node) {
+	if w.Nodes < 0 {
+		return
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.pending = append(w.pending, warmUpNode{node.Location, node.CreatedAt})
+	// Keep memory bounded while nodes stream in
+	if len(w.pending) >= 4*w.Nodes {
+		w.truncate()
+	} else {
+		atomic.AddInt64(&w.Queued, 1)
+	}
+	if w.timer == nil {
+		w.timer = time.AfterFunc(warmUpDelay, w.Flush)
+	} else {
+		w.timer.Reset(warmUpDelay)
+	}
+}
+
+// Warm up the newest pending nodes now
+func (w *WarmUp) Flush() {
+	w.lock.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	w.truncate()
+	pending := w.pending
+	w.pending = nil
+	atomic.StoreInt64(&w.Queued, 0)
+	w.lock.Unlock()
+
+	budget := w.Budget
+	for _, node := range pending {
+		if budget <= 0 {
+			break
+		}
+		budget -= warmChain(node.location, budget)
+		atomic.AddInt64(&w.Warmed, 1)
+	}
+}
+
+// Cache depths along the local chain of the location.
+// Returns the number of nodes visited.
+func warmChain(location uint64, budget int) int {
+	var path []uint64
+	visited := 0
+	depth := int64(-1)
+
+	for visited < budget {
+		if d, ok := store.CachedDepth(location); ok {
+			depth = d
+			break
+		}
+		node := store.GetNode(location)
+		if node == nil {
+			// The rest of the chain is on other servers
+			break
+		}
+		visited += 1
+		if node.Dep == math.MaxUint64 {
+			store.CacheDepth(location, 0)
+			depth = 0
+			break
+		}
+		path = append(path, location)
+		location = node.Dep
+	}
+
+	if depth >= 0 {
+		for i := len(path) - 1; i >= 0; i-- {
+			depth += 1
+			store.CacheDepth(path[i], depth)
+		}
+	}
+	return visited
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Serialized Sets of a key are ordered by one of these
+// (apart from keyLocks, which are held by Apply and GetOrSet around their Set)
+var writeLocks [256]sync.Mutex
+
+func writeLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &writeLocks[h.Sum32()%uint32(len(writeLocks))]
+}
+
+// Whether Sets of the key are serialized on its owner
+func (s *Server) serializesWrites(in *db.SetRequest) bool {
+	if in.SerializeWrites || in.Dep == db.DepAuto {
+		return true
+	}
+	for _, prefix := range s.SerializeWrites {
+		if strings.HasPrefix(in.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Take the write lock of the key if its Sets are serialized.
+// The returned function releases it and can be called more than once.
+func (s *Server) lockWrite(ctx context.Context, in *db.SetRequest) func() {
+	if !s.serializesWrites(in) {
+		return func() {}
+	}
+	lock := writeLock(in.Key)
+	start := time.Now()
+	lock.Lock()
+	timePhase(ctx, phaseLockWait, start)
+	var once sync.Once
+	return func() {
+		once.Do(lock.Unlock)
+	}
+}
+
+// Newest live node of the key, the dep of a Set with db.DepAuto.
+// Must be called on the owner with the write lock of the key.
+func latestNode(key string) (uint64, error) {
+	now := time.Now().UnixNano()
+	var latest uint64
+	var createdAt int64
+	for _, location := range store.LocationsForKey(key) {
+		node := store.GetNode(location)
+		if node == nil || node.Expired(now) {
+			continue
+		}
+		if latest == 0 || node.CreatedAt >= createdAt {
+			latest, createdAt = location, node.CreatedAt
+		}
+	}
+	if latest == 0 {
+		return 0, status.Errorf(codes.FailedPrecondition, "Key %s has no node to chain onto (pass a dep for the first write)", key)
+	}
+	return latest, nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"sync"
+
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+// Where nodes are kept.
+// Implementations must be safe for concurrent use.
+type Backend interface {
+	PutNode(node *Node) error
+	// Returns nil if the location is not stored
+	GetNode(location uint64) (*Node, error)
+	DeleteNode(location uint64) error
+	// Call f on nodes with key hash in [left, right] until it returns false
+	IterateRange(left, right uint32, f func(node *Node) bool) error
+	Stats() BackendStats
+	Close() error
+}
+
+// Backends writing to disk flush their writes with Sync
+type Syncer interface {
+	Sync() error
+}
+
+// Backends storing children apart from their nodes
+// add a child without writing the other children again
+type ChildAppender interface {
+	// Store the node whose last child was just added
+	AppendChild(node *Node) error
+}
+
+// Backends keeping a log can be followed by a standby
+type LogReader interface {
+	// Records from offset (the start of a record) on, up to about limit bytes.
+	// Also returns the size of the log.
+	ReadLog(offset int64, limit int64) ([]LogRecord, int64, error)
+}
+
+// Backends whose log grows with every write remove its superseded records
+type LogCompactor interface {
+	// Returns the number of records removed
+	CompactLog() (int64, error)
+}
+
+type LogRecord struct {
+	// Offset of the record and of the next one
+	Offset   int64
+	Next     int64
+	Location uint64
+	// Nil for a removal
+	Node *Node
+}
+
+type BackendStats struct {
+	Nodes int64
+	// Bytes used by the backend (0 if unknown)
+	Bytes int64
+}
+
+// Keep all nodes in memory.
+// Nodes returned by GetNode are never modified (updates store a new copy).
+type MemoryBackend struct {
+	lock  sync.RWMutex
+	nodes map[uint64]*Node
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		nodes: make(map[uint64]*Node),
+	}
+}
+
+func (b *MemoryBackend) PutNode(node *Node) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// Replaced rather than updated so that nodes returned earlier can be read concurrently
+	copy := *node
+	b.nodes[node.Location] = &copy
+	return nil
+}
+
+func (b *MemoryBackend) GetNode(location uint64) (*Node, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.nodes[location], nil
+}
+
+func (b *MemoryBackend) DeleteNode(location uint64) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.nodes, location)
+	return nil
+}
+
+func (b *MemoryBackend) IterateRange(left, right uint32, f func(node *Node) bool) error {
+	b.lock.RLock()
+	var nodes []*Node
+	for location, node := range b.nodes {
+		keyHash := utils.KeyHash(location)
+		if keyHash >= left && keyHash <= right {
+			nodes = append(nodes, node)
+		}
+	}
+	b.lock.RUnlock()
+
+	for _, node := range nodes {
+		if !f(node) {
+			break
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBackend) Stats() BackendStats {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return BackendStats{Nodes: int64(len(b.nodes))}
+}
+
+func (b *MemoryBackend) Close() error {
+	return nil
+}
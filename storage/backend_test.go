@@ -0,0 +1,359 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var backends = []struct {
+	name string
+	open func(t testing.TB, dir string) Backend
+}{
+	{"memory", func(t testing.TB, dir string) Backend { return NewMemoryBackend() }},
+	{"disk", func(t testing.TB, dir string) Backend {
+		b, err := NewDiskBackend(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}},
+}
+
+func testNode(hash uint32, i int, value []byte) *Node {
+	return &Node{
+		Location: uint64(hash)<<32 + uint64(i),
+		Key:      fmt.Sprintf("k%d", i),
+		Value:    value,
+		Children: []uint64{uint64(i) + 1},
+	}
+}
+
+func TestBackends(t *testing.T) {
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			b := backend.open(t, t.TempDir())
+			defer b.Close()
+
+			for i := 0; i < 10; i++ {
+				if err := b.PutNode(testNode(uint32(i), i, []byte("v"))); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// Replaced in place
+			updated := testNode(3, 3, []byte("updated"))
+			if err := b.PutNode(updated); err != nil {
+				t.Fatal(err)
+			}
+			if node, err := b.GetNode(updated.Location); err != nil || !reflect.DeepEqual(node, updated) {
+				t.Errorf("GetNode returned %v, %v", node, err)
+			}
+			if err := b.DeleteNode(testNode(5, 5, nil).Location); err != nil {
+				t.Fatal(err)
+			}
+			if node, err := b.GetNode(testNode(5, 5, nil).Location); err != nil || node != nil {
+				t.Errorf("Deleted node returned %v, %v", node, err)
+			}
+			if node, err := b.GetNode(math.MaxUint64); err != nil || node != nil {
+				t.Errorf("Missing node returned %v, %v", node, err)
+			}
+
+			var hashes []uint32
+			b.IterateRange(2, 7, func(node *Node) bool {
+				hashes = append(hashes, uint32(node.Location>>32))
+				return true
+			})
+			if len(hashes) != 5 {
+				t.Errorf("IterateRange(2, 7) returned hashes %v", hashes)
+			}
+			if stats := b.Stats(); stats.Nodes != 9 {
+				t.Errorf("Stats returned %d nodes, expected 9", stats.Nodes)
+			}
+		})
+	}
+}
+
+// Nodes of the disk backend survive a reopen
+func TestDiskBackendReopen(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewDiskBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		b.PutNode(testNode(uint32(i), i, []byte("v")))
+	}
+	b.PutNode(testNode(3, 3, []byte("updated")))
+	b.DeleteNode(testNode(5, 5, nil).Location)
+	if err := b.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	b.Close()
+
+	reopened, err := NewDiskBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if stats := reopened.Stats(); stats.Nodes != 9 {
+		t.Errorf("Reopened backend has %d nodes, expected 9", stats.Nodes)
+	}
+	if node, _ := reopened.GetNode(testNode(3, 3, nil).Location); node == nil || string(node.Value) != "updated" {
+		t.Errorf("Reopened backend returned %v", node)
+	}
+	if node, _ := reopened.GetNode(testNode(5, 5, nil).Location); node != nil {
+		t.Errorf("Deleted node came back: %v", node)
+	}
+}
+
+// Writes acknowledged before the process dies are kept, and the file is consistent.
+// The test runs itself in a child process, which exits without closing the backend.
+func TestDiskBackendCrash(t *testing.T) {
+	if dir := os.Getenv("DISK_BACKEND_CRASH_DIR"); len(dir) > 0 {
+		b, err := NewDiskBackend(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 100; i++ {
+			if err := b.PutNode(testNode(uint32(i), i, []byte("v"))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		os.Exit(0)
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestDiskBackendCrash$")
+	cmd.Env = append(os.Environ(), "DISK_BACKEND_CRASH_DIR="+dir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Writer failed: %v\n%s", err, output)
+	}
+
+	b, err := NewDiskBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if stats := b.Stats(); stats.Nodes != 100 {
+		t.Errorf("Reopened backend has %d nodes, expected 100", stats.Nodes)
+	}
+	err = b.db.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Reopened database is inconsistent: %v", err)
+	}
+}
+
+// Children appended one at a time are kept in order, and survive a reopen
+func TestDiskBackendAppendChild(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewDiskBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := testNode(1, 1, []byte("v"))
+	node.Children = nil
+	b.PutNode(node)
+	for i := 0; i < 5; i++ {
+		node.Children = append(node.Children, uint64(10+i))
+		node.ChildrenVersion += 1
+		if err := b.AppendChild(node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A full write dropping children
+	node.Children = node.Children[:3]
+	b.PutNode(node)
+	b.Close()
+
+	reopened, err := NewDiskBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if stored, err := reopened.GetNode(node.Location); err != nil || !reflect.DeepEqual(stored, node) {
+		t.Errorf("GetNode returned %v, %v instead of %v", stored, err, node)
+	}
+	if err := reopened.DeleteNode(node.Location); err != nil {
+		t.Fatal(err)
+	}
+	// Children of a deleted location don't come back with it
+	node.Children = nil
+	reopened.PutNode(node)
+	if stored, _ := reopened.GetNode(node.Location); len(stored.Children) != 0 {
+		t.Errorf("Children of a deleted node came back: %v", stored.Children)
+	}
+}
+
+// The append-only log of older versions is imported on the first open
+func TestDiskBackendImportLog(t *testing.T) {
+	dir := t.TempDir()
+	var legacy []byte
+	record := func(kind byte, node *Node) {
+		var payload []byte
+		if kind == recordPut {
+			payload, _ = json.Marshal(node)
+		}
+		header := make([]byte, 13)
+		header[0] = kind
+		binary.BigEndian.PutUint64(header[1:9], node.Location)
+		binary.BigEndian.PutUint32(header[9:13], uint32(len(payload)))
+		legacy = append(append(legacy, header...), payload...)
+	}
+	for i := 0; i < 10; i++ {
+		record(recordPut, testNode(uint32(i), i, []byte("v")))
+	}
+	record(recordPut, testNode(3, 3, []byte("updated")))
+	record(recordDelete, testNode(5, 5, nil))
+	// Torn record at the end
+	legacy = append(legacy, recordPut, 1, 2)
+	if err := ioutil.WriteFile(filepath.Join(dir, legacyLogFile), legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := NewDiskBackend(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if stats := b.Stats(); stats.Nodes != 9 {
+		t.Errorf("Imported log has %d nodes, expected 9", stats.Nodes)
+	}
+	updated := testNode(3, 3, []byte("updated"))
+	if node, _ := b.GetNode(updated.Location); !reflect.DeepEqual(node, updated) {
+		t.Errorf("Imported log returned %v", node)
+	}
+	if _, err := os.Stat(filepath.Join(dir, legacyLogFile)); !os.IsNotExist(err) {
+		t.Errorf("Imported log not renamed: %v", err)
+	}
+}
+
+// Compaction of the log keeps the latest record of each location,
+// and offsets read before it still lead to the following records
+func TestDiskBackendCompactLog(t *testing.T) {
+	b, err := NewDiskBackend(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	for i := 0; i < 10; i++ {
+		b.PutNode(testNode(uint32(i), i, []byte("v")))
+	}
+	middle, _, err := b.ReadLog(0, 1)
+	if err != nil || len(middle) != 1 {
+		t.Fatalf("ReadLog returned %v, %v", middle, err)
+	}
+	for i := 0; i < 10; i++ {
+		b.PutNode(testNode(uint32(i), i, []byte("updated")))
+	}
+	b.DeleteNode(testNode(5, 5, nil).Location)
+
+	removed, err := b.CompactLog()
+	if err != nil || removed != 11 {
+		t.Errorf("CompactLog returned %d, %v instead of 11 records", removed, err)
+	}
+	for _, offset := range []int64{0, middle[0].Next} {
+		records, size, err := b.ReadLog(offset, math.MaxInt64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 10 || records[0].Offset != offset || records[len(records)-1].Next != size {
+			t.Errorf("ReadLog(%d) returned %d records: %v", offset, len(records), records)
+		}
+		for i := 1; i < len(records); i++ {
+			if records[i].Offset != records[i-1].Next {
+				t.Errorf("Record at %d after one ending at %d", records[i].Offset, records[i-1].Next)
+			}
+		}
+		for _, record := range records {
+			if record.Node != nil && string(record.Node.Value) != "updated" {
+				t.Errorf("Record of %x has value %q", record.Location, record.Node.Value)
+			}
+		}
+	}
+}
+
+func BenchmarkBackends(b *testing.B) {
+	value := make([]byte, 1024)
+	for _, backend := range backends {
+		b.Run(backend.name+"/put", func(b *testing.B) {
+			store := backend.open(b, b.TempDir())
+			defer store.Close()
+			b.ReportAllocs()
+			b.SetBytes(int64(len(value)))
+			for i := 0; i < b.N; i++ {
+				if err := store.PutNode(testNode(uint32(i), i, value)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(backend.name+"/put and sync", func(b *testing.B) {
+			store := backend.open(b, b.TempDir())
+			defer store.Close()
+			b.ReportAllocs()
+			b.SetBytes(int64(len(value)))
+			for i := 0; i < b.N; i++ {
+				if err := store.PutNode(testNode(uint32(i), i, value)); err != nil {
+					b.Fatal(err)
+				}
+				if syncer, ok := store.(Syncer); ok {
+					if err := syncer.Sync(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+
+		b.Run(backend.name+"/get", func(b *testing.B) {
+			const nodes = 10000
+			store := backend.open(b, b.TempDir())
+			defer store.Close()
+			for i := 0; i < nodes; i++ {
+				store.PutNode(testNode(uint32(i), i, value))
+			}
+			b.ReportAllocs()
+			b.SetBytes(int64(len(value)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if node, err := store.GetNode(testNode(uint32(i%nodes), i%nodes, nil).Location); err != nil || node == nil {
+					b.Fatalf("GetNode returned %v, %v", node, err)
+				}
+			}
+		})
+
+		b.Run(backend.name+"/iterate", func(b *testing.B) {
+			const nodes = 10000
+			store := backend.open(b, b.TempDir())
+			defer store.Close()
+			for i := 0; i < nodes; i++ {
+				store.PutNode(testNode(uint32(i), i, value))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				count := 0
+				store.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+					count++
+					return true
+				})
+				if count != nodes {
+					b.Fatalf("IterateRange returned %d nodes", count)
+				}
+			}
+		})
+	}
+}
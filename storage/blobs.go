@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Values larger than the threshold are kept in files named by their hash,
+// so nodes in memory only hold a reference.
+type BlobStore struct {
+	Dir string
+	// Values up to this size stay in nodes
+	Threshold int
+}
+
+func NewBlobStore(dir string, threshold int) (*BlobStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &BlobStore{Dir: dir, Threshold: threshold}, nil
+}
+
+func (b *BlobStore) path(hash string) string {
+	return filepath.Join(b.Dir, hash)
+}
+
+// Write the value (once per content) and return its hash
+func (b *BlobStore) Put(value []byte) (string, error) {
+	digest := sha256.Sum256(value)
+	hash := hex.EncodeToString(digest[:])
+	if _, err := os.Stat(b.path(hash)); err == nil {
+		return hash, nil
+	}
+
+	// Rename so that readers never see a partial blob
+	file, err := ioutil.TempFile(b.Dir, "tmp-")
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(value); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return hash, os.Rename(file.Name(), b.path(hash))
+}
+
+func (b *BlobStore) Get(hash string) ([]byte, error) {
+	return ioutil.ReadFile(b.path(hash))
+}
+
+func (b *BlobStore) Remove(hash string) error {
+	err := os.Remove(b.path(hash))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Number of blobs and their total size
+func (b *BlobStore) Size() (int64, int64) {
+	files, err := ioutil.ReadDir(b.Dir)
+	if err != nil {
+		return 0, 0
+	}
+	var count, bytes int64
+	for _, file := range files {
+		count += 1
+		bytes += file.Size()
+	}
+	return count, bytes
+}
+
+// Remove blobs that are not referenced.
+// Returns the number of blobs removed and the bytes freed.
+func (b *BlobStore) Collect(referenced map[string]bool) (int64, int64, error) {
+	files, err := ioutil.ReadDir(b.Dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	var removed, freed int64
+	for _, file := range files {
+		if referenced[file.Name()] {
+			continue
+		}
+		if err := os.Remove(b.path(file.Name())); err != nil {
+			return removed, freed, err
+		}
+		removed += 1
+		freed += file.Size()
+	}
+	return removed, freed, nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"sort"
+	"sync/atomic"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// CRC32C of the content of a node (0 is never returned, it means unknown).
+// Children, versions and times are not covered: they change after creation.
+func Checksum(key string, value []byte, dep uint64, nodeType db.NodeType, metadata map[string]string) uint32 {
+	h := crc32.New(castagnoli)
+	var buf [8]byte
+	write := func(b []byte) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(b)))
+		h.Write(buf[:])
+		h.Write(b)
+	}
+	write([]byte(key))
+	write(value)
+	binary.LittleEndian.PutUint64(buf[:], dep)
+	h.Write(buf[:])
+	binary.LittleEndian.PutUint64(buf[:], uint64(nodeType))
+	h.Write(buf[:])
+
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		write([]byte(name))
+		write([]byte(metadata[name]))
+	}
+	if sum := h.Sum32(); sum != 0 {
+		return sum
+	}
+	return 1
+}
+
+// Set the checksum of a node created outside the store (kept if already set)
+func Seal(node *db.Node) *db.Node {
+	if node.Checksum == 0 {
+		node.Checksum = Checksum(node.Key, node.Value, node.Dep, node.Type, node.Metadata)
+	}
+	return node
+}
+
+// Check the content of a node against its checksum (nodes without one pass).
+// Mismatches are counted in ChecksumMismatches.
+func (s *Store) VerifyChecksum(node *db.Node) error {
+	if node.Checksum == 0 {
+		return nil
+	}
+	actual := Checksum(node.Key, node.Value, node.Dep, node.Type, node.Metadata)
+	if actual == node.Checksum {
+		return nil
+	}
+	atomic.AddInt64(&s.ChecksumMismatches, 1)
+	return &dberrors.ChecksumMismatchError{
+		Location: node.Location,
+		Expected: node.Checksum,
+		Actual:   actual,
+	}
+}
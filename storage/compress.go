@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// Collapse a run of nodes of one key (oldest first) where each node
+// but the last has the next one as its only child.
+// The last node takes the dep of the first and the others are removed,
+// so reads of any key through the last node resolve the same values.
+// The dep of the first node must be relinked by the caller.
+func (s *Store) CompressRun(run []uint64) error {
+	if len(run) < 2 {
+		return fmt.Errorf("Run of %d nodes cannot be compressed", len(run))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nodes := make([]*Node, len(run))
+	for i, location := range run {
+		node, err := s.Backend.GetNode(location)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("Node %x of the run is not stored locally", location)
+		}
+		nodes[i] = node
+	}
+	first, last := nodes[0], nodes[len(nodes)-1]
+	for i, node := range nodes[:len(nodes)-1] {
+		if node.Key != last.Key || len(node.Children) != 1 || node.Children[0] != run[i+1] {
+			return fmt.Errorf("Node %x is not part of a linear run of %s", node.Location, last.Key)
+		}
+	}
+
+	summary := *last
+	summary.Dep = first.Dep
+	// The dep is covered by the checksum
+	loaded, err := s.Load(&summary)
+	if err != nil {
+		return err
+	}
+	summary.Checksum = Checksum(loaded.Key, loaded.Value, loaded.Dep, loaded.Type, loaded.Metadata)
+	summary.CompressedSince = first.CreatedAt
+	if first.CompressedSince != 0 {
+		summary.CompressedSince = first.CompressedSince
+	}
+	if err := s.Backend.PutNode(&summary); err != nil {
+		return err
+	}
+	for _, location := range run[:len(run)-1] {
+		s.removeNode(location)
+	}
+	// Depths below the run changed
+	s.depths = make(map[uint64]int64)
+	return nil
+}
@@ -0,0 +1,429 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/DCsunset/openwhisk-grpc/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	diskFile = "nodes.db"
+	// Log of the backend before it used bbolt, imported on the first open
+	legacyLogFile = "nodes.log"
+)
+
+var (
+	// Nodes without their children by location
+	nodesBucket = []byte("nodes")
+	// Children by parent location and index
+	childrenBucket = []byte("children")
+	// Changed locations by log offset, followed by standbys
+	logBucket  = []byte("log")
+	metaBucket = []byte("meta")
+	// Size of the log in bytes in the meta bucket
+	logSizeKey = []byte("logSize")
+)
+
+// Record types of the log
+const (
+	recordPut    byte = 1
+	recordDelete byte = 2
+)
+
+// Nodes read by IterateRange in a transaction
+const iterateBatch = 256
+
+// Keep nodes in a bbolt database on disk.
+// Children are stored apart from their node, so adding one writes a single key.
+// Each change appends the changed location to a log (see ReadLog),
+// whose records superseded by a later record of the same location are removed by CompactLog.
+type DiskBackend struct {
+	db    *bolt.DB
+	nodes int64
+}
+
+// Open the database in dir (importing the log of an older version)
+func NewDiskBackend(dir string) (*DiskBackend, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(filepath.Join(dir, diskFile), 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	// Commits are flushed before they return (NoSync could corrupt the file on a crash)
+	b := &DiskBackend{db: db}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{nodesBucket, childrenBucket, logBucket, metaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		b.nodes = int64(tx.Bucket(nodesBucket).Stats().KeyN)
+		return nil
+	})
+	if err == nil {
+		err = b.importLog(filepath.Join(dir, legacyLogFile))
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Replay the append-only log of an older version, then rename it.
+// Record: type (1 byte), location (8 bytes), length (4 bytes), payload
+func (b *DiskBackend) importLog(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, 13)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			// End of the log or a torn record
+			break
+		}
+		location := binary.BigEndian.Uint64(header[1:9])
+		payload := make([]byte, binary.BigEndian.Uint32(header[9:13]))
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			break
+		}
+		if header[0] == recordDelete {
+			err = b.DeleteNode(location)
+		} else {
+			var node Node
+			if err = json.Unmarshal(payload, &node); err == nil {
+				err = b.PutNode(&node)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("Fail to import %s: %v", path, err)
+		}
+	}
+	if err := b.Sync(); err != nil {
+		return err
+	}
+	return os.Rename(path, path+".imported")
+}
+
+func locationKey(location uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, location)
+	return key
+}
+
+// Parent location followed by the index of the child
+func childKey(parent uint64, i int) []byte {
+	key := make([]byte, 16)
+	binary.BigEndian.PutUint64(key, parent)
+	binary.BigEndian.PutUint64(key[8:], uint64(i))
+	return key
+}
+
+// Append a record of the location to the log, counting size bytes
+func appendLog(tx *bolt.Tx, kind byte, location uint64, size int) error {
+	meta := tx.Bucket(metaBucket)
+	offset := logSize(tx)
+	record := make([]byte, 9)
+	record[0] = kind
+	binary.BigEndian.PutUint64(record[1:], location)
+	if err := tx.Bucket(logBucket).Put(locationKey(uint64(offset)), record); err != nil {
+		return err
+	}
+	return meta.Put(logSizeKey, locationKey(uint64(offset+13+int64(size))))
+}
+
+func logSize(tx *bolt.Tx) int64 {
+	size := tx.Bucket(metaBucket).Get(logSizeKey)
+	if size == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(size))
+}
+
+// Write the node without its children.
+// Returns the size of the payload.
+func putNodeOnly(tx *bolt.Tx, node *Node) (int, error) {
+	stored := *node
+	stored.Children = nil
+	payload, err := json.Marshal(&stored)
+	if err != nil {
+		return 0, err
+	}
+	return len(payload), tx.Bucket(nodesBucket).Put(locationKey(node.Location), payload)
+}
+
+// Run a write transaction (flushed to disk when it returns)
+func (b *DiskBackend) update(f func(tx *bolt.Tx) error) error {
+	return b.db.Update(f)
+}
+
+func (b *DiskBackend) PutNode(node *Node) error {
+	added := false
+	err := b.update(func(tx *bolt.Tx) error {
+		added = tx.Bucket(nodesBucket).Get(locationKey(node.Location)) == nil
+		size, err := putNodeOnly(tx, node)
+		if err != nil {
+			return err
+		}
+		// Only children that changed are written
+		children := tx.Bucket(childrenBucket)
+		stored := readChildren(tx, node.Location)
+		for i, child := range node.Children {
+			if i >= len(stored) || stored[i] != child {
+				if err := children.Put(childKey(node.Location, i), locationKey(child)); err != nil {
+					return err
+				}
+			}
+		}
+		for i := len(node.Children); i < len(stored); i++ {
+			if err := children.Delete(childKey(node.Location, i)); err != nil {
+				return err
+			}
+		}
+		return appendLog(tx, recordPut, node.Location, size+8*len(node.Children))
+	})
+	if err == nil && added {
+		atomic.AddInt64(&b.nodes, 1)
+	}
+	return err
+}
+
+// Store the node whose last child was just added,
+// without reading or writing its other children
+func (b *DiskBackend) AppendChild(node *Node) error {
+	n := len(node.Children)
+	if n == 0 {
+		return b.PutNode(node)
+	}
+	return b.update(func(tx *bolt.Tx) error {
+		size, err := putNodeOnly(tx, node)
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(childrenBucket).Put(childKey(node.Location, n-1), locationKey(node.Children[n-1])); err != nil {
+			return err
+		}
+		return appendLog(tx, recordPut, node.Location, size+8)
+	})
+}
+
+func readChildren(tx *bolt.Tx, parent uint64) []uint64 {
+	var children []uint64
+	prefix := locationKey(parent)
+	cursor := tx.Bucket(childrenBucket).Cursor()
+	for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+		children = append(children, binary.BigEndian.Uint64(v))
+	}
+	return children
+}
+
+// Returns nil if the location is not stored
+func readNode(tx *bolt.Tx, location uint64, payload []byte) (*Node, error) {
+	if payload == nil {
+		return nil, nil
+	}
+	var node Node
+	if err := json.Unmarshal(payload, &node); err != nil {
+		return nil, err
+	}
+	node.Children = readChildren(tx, location)
+	return &node, nil
+}
+
+func (b *DiskBackend) GetNode(location uint64) (*Node, error) {
+	var node *Node
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		node, err = readNode(tx, location, tx.Bucket(nodesBucket).Get(locationKey(location)))
+		return err
+	})
+	return node, err
+}
+
+func (b *DiskBackend) DeleteNode(location uint64) error {
+	deleted := false
+	err := b.update(func(tx *bolt.Tx) error {
+		key := locationKey(location)
+		nodes := tx.Bucket(nodesBucket)
+		if nodes.Get(key) == nil {
+			return nil
+		}
+		deleted = true
+		if err := nodes.Delete(key); err != nil {
+			return err
+		}
+		children := tx.Bucket(childrenBucket)
+		for i := range readChildren(tx, location) {
+			if err := children.Delete(childKey(location, i)); err != nil {
+				return err
+			}
+		}
+		return appendLog(tx, recordDelete, location, 0)
+	})
+	if err == nil && deleted {
+		atomic.AddInt64(&b.nodes, -1)
+	}
+	return err
+}
+
+// Key hash is the upper 32 bits of the location,
+// so the range is a contiguous part of the nodes bucket.
+// Nodes are read in batches, as f may use the backend.
+func (b *DiskBackend) IterateRange(left, right uint32, f func(node *Node) bool) error {
+	from := uint64(left) << 32
+	for {
+		var nodes []*Node
+		err := b.db.View(func(tx *bolt.Tx) error {
+			cursor := tx.Bucket(nodesBucket).Cursor()
+			for k, v := cursor.Seek(locationKey(from)); k != nil && len(nodes) < iterateBatch; k, v = cursor.Next() {
+				location := binary.BigEndian.Uint64(k)
+				if utils.KeyHash(location) > right {
+					break
+				}
+				node, err := readNode(tx, location, v)
+				if err != nil {
+					return err
+				}
+				nodes = append(nodes, node)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if !f(node) {
+				return nil
+			}
+		}
+		if len(nodes) < iterateBatch || nodes[len(nodes)-1].Location == ^uint64(0) {
+			return nil
+		}
+		from = nodes[len(nodes)-1].Location + 1
+	}
+}
+
+// Records of the log carry the current node of their location.
+// Records removed by CompactLog are skipped: a record starts at the offset
+// read from (or the end of the previous one), so the offsets stay contiguous.
+func (b *DiskBackend) ReadLog(offset int64, limit int64) ([]LogRecord, int64, error) {
+	var records []LogRecord
+	var size int64
+	err := b.db.View(func(tx *bolt.Tx) error {
+		size = logSize(tx)
+		if offset < 0 || offset > size {
+			return fmt.Errorf("Offset %d is beyond the log (%d bytes)", offset, size)
+		}
+		nodes := tx.Bucket(nodesBucket)
+		cursor := tx.Bucket(logBucket).Cursor()
+		k, v := cursor.Seek(locationKey(uint64(offset)))
+		for read := int64(0); k != nil && (read == 0 || read < limit); {
+			record := LogRecord{
+				Offset:   offset,
+				Next:     size,
+				Location: binary.BigEndian.Uint64(v[1:]),
+			}
+			kind := v[0]
+			k, v = cursor.Next()
+			if k != nil {
+				record.Next = int64(binary.BigEndian.Uint64(k))
+			}
+			if kind == recordPut {
+				key := locationKey(record.Location)
+				node, err := readNode(tx, record.Location, nodes.Get(key))
+				if err != nil {
+					return err
+				}
+				record.Node = node
+			}
+			records = append(records, record)
+			read += record.Next - offset
+			offset = record.Next
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, size, err
+	}
+	return records, size, nil
+}
+
+// Remove records of the log followed by a later record of the same location.
+// Returns the number of records removed.
+func (b *DiskBackend) CompactLog() (int64, error) {
+	// Later records only supersede more of them
+	var superseded [][]byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		latest := make(map[uint64][]byte)
+		return tx.Bucket(logBucket).ForEach(func(k, v []byte) error {
+			location := binary.BigEndian.Uint64(v[1:])
+			if previous, ok := latest[location]; ok {
+				superseded = append(superseded, previous)
+			}
+			latest[location] = append([]byte(nil), k...)
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	removed := int64(0)
+	for len(superseded) > 0 {
+		batch := superseded
+		if len(batch) > iterateBatch*16 {
+			batch = batch[:iterateBatch*16]
+		}
+		err := b.update(func(tx *bolt.Tx) error {
+			log := tx.Bucket(logBucket)
+			for _, k := range batch {
+				if err := log.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return removed, err
+		}
+		removed += int64(len(batch))
+		superseded = superseded[len(batch):]
+	}
+	return removed, nil
+}
+
+func (b *DiskBackend) Stats() BackendStats {
+	stats := BackendStats{Nodes: atomic.LoadInt64(&b.nodes)}
+	b.db.View(func(tx *bolt.Tx) error {
+		stats.Bytes = tx.Size()
+		return nil
+	})
+	return stats
+}
+
+// Committed writes are already on disk
+func (b *DiskBackend) Sync() error {
+	return nil
+}
+
+func (b *DiskBackend) Close() error {
+	return b.db.Close()
+}
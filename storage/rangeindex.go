@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+)
+
+// Locations of live nodes are kept sorted (excluding the global root).
+// The key hash is the upper half of a location,
+// so the nodes of a hash range are contiguous.
+
+// Must be called with the lock held
+func (s *Store) insertLocation(location uint64) {
+	i := sort.Search(len(s.locations), func(i int) bool { return s.locations[i] >= location })
+	if i < len(s.locations) && s.locations[i] == location {
+		return
+	}
+	s.locations = append(s.locations, 0)
+	copy(s.locations[i+1:], s.locations[i:])
+	s.locations[i] = location
+}
+
+// Must be called with the lock held
+func (s *Store) deleteLocation(location uint64) {
+	i := sort.Search(len(s.locations), func(i int) bool { return s.locations[i] >= location })
+	if i < len(s.locations) && s.locations[i] == location {
+		s.locations = append(s.locations[:i], s.locations[i+1:]...)
+	}
+}
+
+// Indexes of the locations with key hash in [left, right]
+// (must be called with the lock held)
+func (s *Store) hashRange(left, right uint32) (int, int) {
+	begin := sort.Search(len(s.locations), func(i int) bool {
+		return s.locations[i] >= uint64(left)<<32
+	})
+	end := sort.Search(len(s.locations), func(i int) bool {
+		return s.locations[i]>>32 > uint64(right)
+	})
+	if end < begin {
+		end = begin
+	}
+	return begin, end
+}
+
+// Call f on live nodes with key hash in [left, right] in location order
+// until it returns false. The global root is skipped.
+func (s *Store) IterateHashRange(left, right uint32, f func(node *Node) bool) error {
+	s.lock.RLock()
+	begin, end := s.hashRange(left, right)
+	locations := append([]uint64(nil), s.locations[begin:end]...)
+	s.lock.RUnlock()
+
+	for _, location := range locations {
+		node, err := s.Backend.GetNode(location)
+		if err != nil {
+			return err
+		}
+		// Removed since the locations were copied
+		if node == nil {
+			continue
+		}
+		if !f(node) {
+			break
+		}
+	}
+	return nil
+}
+
+// Number of live nodes with key hash in [left, right]
+func (s *Store) CountHashRange(left, right uint32) int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	begin, end := s.hashRange(left, right)
+	return end - begin
+}
+
+// Count and digest of the nodes with key hash in [left, right].
+// The digest is a sum of node hashes, so it does not depend on
+// the order of the nodes and two replicas of a range can be compared.
+func (s *Store) RangeDigest(left, right uint32) (int64, uint64, error) {
+	var count int64
+	var digest uint64
+	err := s.IterateHashRange(left, right, func(node *Node) bool {
+		count += 1
+		digest += nodeHash(node)
+		return true
+	})
+	return count, digest, err
+}
+
+func nodeHash(node *Node) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, n := range []uint64{node.Location, node.Dep, node.ChildrenVersion, uint64(node.ExpiresAt)} {
+		binary.LittleEndian.PutUint64(buf[:], n)
+		h.Write(buf[:])
+	}
+	h.Write([]byte(node.Key))
+	// Spilled values are identified by their hash
+	h.Write([]byte(node.Blob))
+	h.Write(node.Value)
+	// Covers the content as it was created, not only as it is stored
+	binary.LittleEndian.PutUint32(buf[:4], node.Checksum)
+	h.Write(buf[:4])
+	return h.Sum64()
+}
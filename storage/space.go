@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"math"
+	"sort"
+)
+
+// Largest values kept by the store
+const TopValues = 32
+
+// Value of a node counted by the space accounting
+type SpaceConsumer struct {
+	Key      string
+	Location uint64
+	Bytes    int64
+}
+
+// Values of keys with a common prefix
+type PrefixSpace struct {
+	Prefix string
+	Bytes  int64
+	Nodes  int64
+}
+
+// Size of the value whether it is spilled or not
+func valueSize(node *Node) int64 {
+	if len(node.Blob) > 0 {
+		return node.BlobSize
+	}
+	return int64(len(node.Value))
+}
+
+// Prefix of the key counted in the histogram
+func (s *Store) prefix(key string) string {
+	if len(key) <= s.PrefixLength {
+		return key
+	}
+	return key[:s.PrefixLength]
+}
+
+// Update the accounting when old is replaced by node (either can be nil).
+// Must be called with the lock held.
+func (s *Store) account(old *Node, node *Node) {
+	if old != nil && old.Location != 0 {
+		size := valueSize(old)
+		s.valueBytes -= size
+		prefix := s.prefix(old.Key)
+		if p := s.prefixes[prefix]; p != nil {
+			p.Bytes -= size
+			p.Nodes -= 1
+			if p.Nodes == 0 {
+				delete(s.prefixes, prefix)
+			}
+		}
+		for i, c := range s.top {
+			if c.Location == old.Location {
+				// Another value may take its place
+				s.top = append(s.top[:i], s.top[i+1:]...)
+				s.topDirty = true
+				break
+			}
+		}
+	}
+	if node != nil && node.Location != 0 {
+		size := valueSize(node)
+		s.valueBytes += size
+		prefix := s.prefix(node.Key)
+		p := s.prefixes[prefix]
+		if p == nil {
+			p = &PrefixSpace{Prefix: prefix}
+			s.prefixes[prefix] = p
+		}
+		p.Bytes += size
+		p.Nodes += 1
+		if !s.topDirty {
+			s.insertTop(SpaceConsumer{node.Key, node.Location, size})
+		}
+	}
+}
+
+// Must be called with the lock held
+func (s *Store) insertTop(c SpaceConsumer) {
+	if len(s.top) == TopValues && c.Bytes <= s.top[len(s.top)-1].Bytes {
+		return
+	}
+	i := sort.Search(len(s.top), func(i int) bool {
+		return s.top[i].Bytes < c.Bytes
+	})
+	s.top = append(s.top, SpaceConsumer{})
+	copy(s.top[i+1:], s.top[i:])
+	s.top[i] = c
+	if len(s.top) > TopValues {
+		s.top = s.top[:TopValues]
+	}
+}
+
+// Size read under the lock
+func (s *Store) NodeCount() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.Size
+}
+
+// Roots read under the lock
+func (s *Store) RootCount() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.Roots
+}
+
+// Bytes of the values of local nodes (including spilled ones)
+func (s *Store) ValueBytes() int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.valueBytes
+}
+
+// Largest values (up to TopValues) and prefixes by size, largest first
+func (s *Store) TopSpace() ([]SpaceConsumer, []PrefixSpace, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.topDirty {
+		// Rescan since the list lost values to removals
+		s.top = nil
+		err := s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+			if node.Location != 0 {
+				s.insertTop(SpaceConsumer{node.Key, node.Location, valueSize(node)})
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		s.topDirty = false
+	}
+
+	values := append([]SpaceConsumer(nil), s.top...)
+	prefixes := make([]PrefixSpace, 0, len(s.prefixes))
+	for _, p := range s.prefixes {
+		prefixes = append(prefixes, *p)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return prefixes[i].Bytes > prefixes[j].Bytes
+	})
+	return values, prefixes, nil
+}
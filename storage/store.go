@@ -1,13 +1,19 @@
 package storage
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/placement"
 	"github.com/DCsunset/openwhisk-grpc/utils"
 )
 
@@ -15,69 +21,273 @@ type Node struct {
 	Location uint64 // The location of the key
 	Dep      uint64
 	Children []uint64
-	Key      string
-	Value    string
+	// Incremented whenever Children change
+	ChildrenVersion uint64 `json:",omitempty"`
+	Key             string
+	// Roots and buckets have no key (Key is empty)
+	Keyless     bool   `json:",omitempty"`
+	ContentType string `json:",omitempty"`
+	// Value is kept as the buffer received from the request
+	// (nil if it is spilled to a blob)
+	Value []byte
+	// Hash of the spilled value (see Store.Load)
+	Blob string `json:",omitempty"`
+	// Size of the spilled value
+	BlobSize int64 `json:",omitempty"`
+	// Creation time in unix nanoseconds
+	CreatedAt int64
+	Metadata  map[string]string
+	// When the value expires in unix nanoseconds (0 for never)
+	ExpiresAt int64 `json:",omitempty"`
+	// Creation time of the oldest node collapsed into this one (0 if none)
+	CompressedSince int64 `json:",omitempty"`
+	// Counter nodes hold a decimal delta
+	Type db.NodeType `json:",omitempty"`
+	// See Checksum (0 for nodes written before checksums)
+	Checksum uint32 `json:",omitempty"`
+}
+
+// Whether reads of the key stop at the node
+func (n *Node) Defines(key string) bool {
+	return !n.Keyless && n.Key == key
+}
+
+// Expired nodes are skipped by reads but kept while they have children
+func (n *Node) Expired(now int64) bool {
+	return n.ExpiresAt != 0 && n.ExpiresAt <= now
 }
 
 type Store struct {
-	Nodes []Node // all nodes
-	// Map hash locations to memory locations
-	MemLocation map[uint64]int
-	lock        sync.RWMutex
-	Size        int // Size of valid nodes
+	// Where nodes are kept (in memory if nil when initialized).
+	// Nodes must only be modified through store methods.
+	Backend Backend
+	// Map keys to locations of live nodes (oldest first)
+	KeyIndex map[string][]uint64
+	lock     sync.RWMutex
+	// Number of live nodes stored locally (excluding the root)
+	Size int
+	// Number of roots created by CreateRoot stored locally
+	Roots int
+	// Depth of nodes from their root (immutable once written)
+	depths map[uint64]int64
+	// Max children of a node (0 means unlimited)
+	MaxChildren int
+	// Where large values are spilled (disabled if nil)
+	Blobs *BlobStore
+	// Sorted locations of live nodes (index of hash ranges)
+	locations []uint64
+
+	// Length of key prefixes in the space histogram (set before Init)
+	PrefixLength int
+	valueBytes   int64
+	prefixes     map[string]*PrefixSpace
+	// Largest values, largest first (rebuilt by TopSpace if dirty)
+	top      []SpaceConsumer
+	topDirty bool
+
+	// Nodes found not matching their checksum (accessed atomically)
+	ChecksumMismatches int64
+
+	// Deadlines of merges in flight by parent location (see AddChildClaim)
+	merges map[uint64]int64
 }
 
+// Metadata marking intermediate nodes inserted when a node is full
+const BucketKey = "bucket"
+
 func (s *Store) Init() {
-	if len(s.Nodes) == 0 {
-		// Create a root and map first
-		s.MemLocation = make(map[uint64]int)
-		root := Node{
+	if s.KeyIndex != nil {
+		return
+	}
+	if s.Backend == nil {
+		s.Backend = NewMemoryBackend()
+	}
+	s.rebuild()
+
+	// Create the global root
+	if root, _ := s.Backend.GetNode(0); root == nil {
+		s.Backend.PutNode(&Node{
 			Dep:      math.MaxUint64,
 			Location: 0,
-			Key:      "",
-		}
-		s.Nodes = append(s.Nodes, root)
-		s.MemLocation[0] = 0
+			Keyless:  true,
+		})
 	}
 }
 
-func (s *Store) newNode(location uint64, dep uint64, key string, value string) {
+// Rebuild the accounting and indexes from the nodes kept by the backend
+func (s *Store) Rebuild() {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	s.rebuild()
+}
+
+// Must be called with the lock held
+func (s *Store) rebuild() {
+	s.Size = 0
+	s.Roots = 0
+	s.KeyIndex = make(map[string][]uint64)
+	s.depths = make(map[uint64]int64)
+	s.locations = nil
+	s.valueBytes = 0
+	s.prefixes = make(map[string]*PrefixSpace)
+	s.top = nil
+	s.topDirty = false
+	var legacy []Node
+	s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+		// Nodes written by older versions mark roots and buckets by an empty key
+		if len(node.Key) == 0 && !node.Keyless {
+			node.Keyless = true
+			legacy = append(legacy, *node)
+		}
+		if node.Location != 0 {
+			s.account(nil, node)
+			s.Size += 1
+			if node.Dep == math.MaxUint64 {
+				s.Roots += 1
+			}
+			s.indexKey(node)
+			s.locations = append(s.locations, node.Location)
+		}
+		return true
+	})
+	for i := range legacy {
+		s.Backend.PutNode(&legacy[i])
+	}
+	sort.Slice(s.locations, func(i, j int) bool {
+		return s.locations[i] < s.locations[j]
+	})
+}
+
+// Must be called with the lock held
+func (s *Store) putNode(node Node) error {
+	location, dep := node.Location, node.Dep
+
+	// Computed over the value before it is spilled
+	if node.Checksum == 0 && location != 0 {
+		node.Checksum = Checksum(node.Key, node.Value, node.Dep, node.Type, node.Metadata)
+	}
+	if s.Blobs != nil && len(node.Value) > s.Blobs.Threshold {
+		hash, err := s.Blobs.Put(node.Value)
+		if err != nil {
+			return err
+		}
+		node.Blob = hash
+		node.BlobSize = int64(len(node.Value))
+		node.Value = nil
+	}
+
+	// The global root is not counted or indexed
+	if location == 0 {
+		return s.Backend.PutNode(&node)
+	}
+
+	existing, err := s.Backend.GetNode(location)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		// The backend can update it in place
+		old := *existing
+		existing = &old
+	}
+	if err := s.Backend.PutNode(&node); err != nil {
+		return err
+	}
+
+	s.account(existing, &node)
+
+	// Replace existing node so it is not counted twice
+	if existing != nil {
+		s.unindexKey(existing)
+		if existing.Dep == math.MaxUint64 {
+			s.Roots -= 1
+		}
+		s.indexKey(&node)
+		if dep == math.MaxUint64 {
+			s.Roots += 1
+		}
+		return nil
+	}
+
 	s.Size += 1
-	node := Node{
-		Location: location,
-		Dep:      dep,
-		Key:      key,
-		Children: nil,
-		Value:    value,
+	if dep == math.MaxUint64 {
+		s.Roots += 1
+	}
+	s.indexKey(&node)
+	s.insertLocation(location)
+	return nil
+}
+
+// Keyless nodes are not indexed.
+// Must be called with the lock held.
+func (s *Store) indexKey(node *Node) {
+	if node.Keyless {
+		return
+	}
+	s.KeyIndex[node.Key] = append(s.KeyIndex[node.Key], node.Location)
+}
+
+// Must be called with the lock held
+func (s *Store) unindexKey(node *Node) {
+	if node.Keyless {
+		return
+	}
+	key, location := node.Key, node.Location
+	locations := s.KeyIndex[key]
+	for i, loc := range locations {
+		if loc == location {
+			locations = append(locations[:i], locations[i+1:]...)
+			break
+		}
+	}
+	if len(locations) == 0 {
+		delete(s.KeyIndex, key)
+	} else {
+		s.KeyIndex[key] = locations
 	}
+}
 
-	s.Nodes = append(s.Nodes, node)
-	memLoc := len(s.Nodes) - 1
+// Locations of live nodes with the key, newest last
+func (s *Store) LocationsForKey(key string) []uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 
-	s.MemLocation[location] = memLoc
+	locations := s.KeyIndex[key]
+	result := make([]uint64, len(locations))
+	copy(result, locations)
+	return result
 }
 
-func (s *Store) Get(key string, loc uint64) (string, error) {
-	// FIXME: Similuate disk
-	time.Sleep(time.Millisecond * 10)
+func (s *Store) Get(key string, loc uint64) ([]byte, error) {
+	node, err := s.Resolve(key, loc)
+	if err != nil {
+		return nil, err
+	}
+	return node.Value, nil
+}
 
+// Find the nearest node defining the key from the location
+func (s *Store) Resolve(key string, loc uint64) (*Node, error) {
 	var node *Node
 	node = s.GetNode(loc)
 
 	// Find till root
+	now := time.Now().UnixNano()
 	for {
-		if node.Key == key {
-			return node.Value, nil
+		if node == nil {
+			return nil, &dberrors.LocationNotFoundError{Location: loc}
+		}
+		if node.Defines(key) && !node.Expired(now) {
+			return s.Load(node)
 		}
 		if node.Dep == math.MaxUint64 {
 			break
 		}
-		node = s.GetNode(node.Dep)
+		loc = node.Dep
+		node = s.GetNode(loc)
 	}
-	return "", fmt.Errorf("Key %s not found", key)
+	return nil, &dberrors.KeyNotFoundError{Key: key}
 }
 
 type Data struct {
@@ -86,64 +296,751 @@ type Data struct {
 	Dep   int64
 }
 
-func (self *Store) AddChild(location uint64, child uint64) *Node {
-	node := self.GetNode(location)
-	node.Children = append(node.Children, child)
-	return node
+// Add child to the node and return a snapshot of it (nil if not found)
+// and whether the child was appended (false if it was already there)
+func (s *Store) AddChild(location uint64, child uint64) (*Node, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.addChild(location, child)
+}
+
+// Add child like AddChild and claim the merge of the node
+// if it has at least threshold children and no merge is in flight.
+// The claim is released when the children are replaced (the merge is applied)
+// or after the timeout (the merge failed).
+// Returns whether the merge was claimed.
+func (s *Store) AddChildClaim(location uint64, child uint64, threshold int, timeout time.Duration) (*Node, bool, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node, added, err := s.addChild(location, child)
+	if err != nil || node == nil || len(node.Children) < threshold {
+		return node, added, false, err
+	}
+	now := time.Now().UnixNano()
+	if deadline, ok := s.merges[location]; ok && now < deadline {
+		return node, added, false, nil
+	}
+	if s.merges == nil {
+		s.merges = make(map[uint64]int64)
+	}
+	s.merges[location] = now + timeout.Nanoseconds()
+	return node, added, true, nil
+}
+
+// Must be called with the lock held
+func (s *Store) addChild(location uint64, child uint64) (*Node, bool, error) {
+	node, err := s.Backend.GetNode(location)
+	if err != nil || node == nil {
+		return nil, false, err
+	}
+	snapshot := *node
+	snapshot.Children = append([]uint64(nil), node.Children...)
+	// Adding the same child again (e.g. a retry) is not a conflict
+	for _, c := range node.Children {
+		if c == child {
+			return &snapshot, false, nil
+		}
+	}
+	if s.MaxChildren > 0 && len(node.Children) >= s.MaxChildren {
+		return nil, false, &dberrors.QuotaExceededError{
+			Resource: fmt.Sprintf("children of %x", location),
+			Limit:    int64(s.MaxChildren),
+		}
+	}
+	snapshot.Children = append(snapshot.Children, child)
+	snapshot.ChildrenVersion += 1
+	if err := s.appendChild(&snapshot); err != nil {
+		return nil, false, err
+	}
+	return &snapshot, true, nil
 }
 
-func (s *Store) Set(key string, value string, dep uint64) uint64 {
-	// FIXME: Similuate disk
-	time.Sleep(time.Millisecond * 10)
+// Store the node whose last child was just added
+func (s *Store) appendChild(node *Node) error {
+	if appender, ok := s.Backend.(ChildAppender); ok {
+		return appender.AppendChild(node)
+	}
+	return s.Backend.PutNode(node)
+}
+
+// Node that new children of the location should be added to.
+// The last child of a full node is a bucket node (with an empty key
+// and the same key hash) taking further children, so reads through it
+// are unchanged. Buckets are created when a node has one slot left.
+func (s *Store) Bucket(location uint64) (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for {
+		node, err := s.Backend.GetNode(location)
+		if err != nil {
+			return 0, err
+		}
+		if node == nil {
+			return 0, &dberrors.LocationNotFoundError{Location: location}
+		}
+		n := len(node.Children)
+		if s.MaxChildren < 2 || n < s.MaxChildren-1 {
+			return location, nil
+		}
+		if n > 0 {
+			last, err := s.Backend.GetNode(node.Children[n-1])
+			if err != nil {
+				return 0, err
+			}
+			if last != nil && last.Metadata[BucketKey] == "true" {
+				location = last.Location
+				continue
+			}
+		}
+		if n >= s.MaxChildren {
+			// Full without a bucket (AddChild rejects new children)
+			return location, nil
+		}
+
+		loc := newLocation(utils.KeyHash(location))
+		for s.GetNode(loc) != nil {
+			loc = newLocation(utils.KeyHash(location))
+		}
+		bucket := Node{
+			Location:  loc,
+			Dep:       location,
+			Keyless:   true,
+			CreatedAt: time.Now().UnixNano(),
+			Metadata:  map[string]string{BucketKey: "true"},
+		}
+		if err := s.putNode(bucket); err != nil {
+			return 0, err
+		}
+		updated := *node
+		updated.Children = append(append([]uint64(nil), node.Children...), bucket.Location)
+		updated.ChildrenVersion += 1
+		if err := s.appendChild(&updated); err != nil {
+			return 0, err
+		}
+		return loc, nil
+	}
+}
+
+// Remove the child from the node (no-op if it is not a child)
+func (s *Store) RemoveChild(location uint64, child uint64) (*Node, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node, err := s.Backend.GetNode(location)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, &dberrors.LocationNotFoundError{Location: location}
+	}
+	updated := *node
+	updated.Children = nil
+	for _, c := range node.Children {
+		if c != child {
+			updated.Children = append(updated.Children, c)
+		}
+	}
+	if len(updated.Children) == len(node.Children) {
+		return node, nil
+	}
+	updated.ChildrenVersion += 1
+	return &updated, s.Backend.PutNode(&updated)
+}
 
-	// Use random number + key hash
-	loc := uint64(rand.Uint32()) + (uint64(utils.Hash2Uint(utils.Hash([]byte(key)))) << 32)
-	s.newNode(loc, dep, key, value)
+// Drop values of nodes expired at the time, keeping the nodes
+// as stubs so that chains through them stay walkable.
+// Returns the number of expired nodes.
+func (s *Store) Tombstone(now int64) (int, error) {
+	var expired []uint64
+	err := s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+		if node.Expired(now) {
+			expired = append(expired, node.Location)
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
 
+	for _, location := range expired {
+		node, err := s.Backend.GetNode(location)
+		if err != nil {
+			return 0, err
+		}
+		if node == nil || (node.Value == nil && len(node.Blob) == 0) {
+			continue
+		}
+		old, stub := *node, *node
+		stub.Value = nil
+		stub.Blob = ""
+		stub.BlobSize = 0
+		stub.Checksum = Checksum(stub.Key, nil, stub.Dep, stub.Type, stub.Metadata)
+		if err := s.Backend.PutNode(&stub); err != nil {
+			return 0, err
+		}
+		s.account(&old, &stub)
+	}
+	return len(expired), nil
+}
+
+// Expired local nodes without children, which can be removed
+func (s *Store) ExpiredLeaves(now int64) []*Node {
+	var leaves []*Node
+	s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+		if node.Expired(now) && len(node.Children) == 0 {
+			leaves = append(leaves, node)
+		}
+		return true
+	})
+	return leaves
+}
+
+// Flush writes of the backend to disk.
+// Returns false if the backend does not write to disk.
+func (s *Store) Sync() (bool, error) {
+	syncer, ok := s.Backend.(Syncer)
+	if !ok {
+		return false, nil
+	}
+	return true, syncer.Sync()
+}
+
+// Expire a node at the time (unless it expires earlier)
+func (s *Store) Expire(location uint64, at int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node, err := s.Backend.GetNode(location)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return &dberrors.LocationNotFoundError{Location: location}
+	}
+	if node.ExpiresAt != 0 && node.ExpiresAt <= at {
+		return nil
+	}
+	updated := *node
+	updated.ExpiresAt = at
+	return s.Backend.PutNode(&updated)
+}
+
+// Swap children of a node under one lock and return the old ones
+func (s *Store) ReplaceChildren(location uint64, children []uint64) ([]uint64, error) {
+	old, _, err := s.replaceChildren(location, children, false, 0)
+	return old, err
+}
+
+// Swap children of a node only if they are still at the version.
+// Returns the old children and the new version.
+func (s *Store) ReplaceChildrenIf(location uint64, children []uint64, version uint64) ([]uint64, uint64, error) {
+	return s.replaceChildren(location, children, true, version)
+}
+
+func (s *Store) replaceChildren(location uint64, children []uint64, check bool, version uint64) ([]uint64, uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node, err := s.Backend.GetNode(location)
+	if err != nil {
+		return nil, 0, err
+	}
+	if node == nil {
+		return nil, 0, &dberrors.LocationNotFoundError{Location: location}
+	}
+	if check && node.ChildrenVersion != version {
+		return nil, 0, &dberrors.ChildrenChangedError{
+			Location: location,
+			Expected: version,
+			Actual:   node.ChildrenVersion,
+		}
+	}
+	old := node.Children
+	updated := *node
+	updated.Children = children
+	updated.ChildrenVersion += 1
+	if err := s.Backend.PutNode(&updated); err != nil {
+		return nil, 0, err
+	}
+	// The merge in flight (if any) is applied
+	delete(s.merges, location)
+	return old, updated.ChildrenVersion, nil
+}
+
+// Create a node (expiresAt is 0 for never)
+func (s *Store) Set(key string, value []byte, dep uint64, metadata map[string]string, expiresAt int64, contentType string, nodeType db.NodeType) (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Don't overwrite another node on collisions
+	// (checked under the lock so concurrent sets never pick the same location)
+	hash := keyHash(key)
+	loc := newLocation(hash)
+	for {
+		existing, err := s.Backend.GetNode(loc)
+		if err != nil {
+			return 0, err
+		}
+		if existing == nil {
+			break
+		}
+		loc = newLocation(hash)
+	}
+	err := s.putNode(Node{
+		Location:    loc,
+		Dep:         dep,
+		Key:         key,
+		Value:       value,
+		CreatedAt:   time.Now().UnixNano(),
+		Metadata:    metadata,
+		ExpiresAt:   expiresAt,
+		ContentType: contentType,
+		Type:        nodeType,
+	})
+
+	return loc, err
+}
+
+// Create a node at a location chosen by the caller (see CreateNode)
+func (s *Store) SetAt(location uint64, key string, value []byte, dep uint64, metadata map[string]string, expiresAt int64, contentType string, nodeType db.NodeType) error {
+	if err := CheckLocation(location, key); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if existing, err := s.Backend.GetNode(location); err != nil || existing != nil {
+		if err == nil {
+			err = fmt.Errorf("Location %x is taken", location)
+		}
+		return err
+	}
+	return s.putNode(Node{
+		Location:    location,
+		Dep:         dep,
+		Key:         key,
+		Value:       value,
+		CreatedAt:   time.Now().UnixNano(),
+		Metadata:    metadata,
+		ExpiresAt:   expiresAt,
+		ContentType: contentType,
+		Type:        nodeType,
+	})
+}
+
+func keyHash(key string) uint32 {
+	return placement.Hash(key)
+}
+
+// Nodes with a key must be at a location with the hash of the key,
+// with the placement rules or without them (created before the rules).
+// Nodes without key (roots and buckets) can be anywhere.
+func CheckLocation(location uint64, key string) error {
+	if len(key) == 0 {
+		return nil
+	}
+	hash := utils.KeyHash(location)
+	if hash == keyHash(key) || hash == utils.Hash2Uint(utils.Hash([]byte(key))) {
+		return nil
+	}
+	return &dberrors.InvalidLocationError{Location: location, Key: key}
+}
+
+// Use random number + key hash.
+// 0 (the global root), db.DepAuto and math.MaxUint64 (no dep) are never used.
+func newLocation(keyHash uint32) uint64 {
+	for {
+		loc := uint64(rand.Uint32()) + (uint64(keyHash) << 32)
+		if loc != 0 && loc != db.DepAuto && loc != math.MaxUint64 {
+			return loc
+		}
+	}
+}
+
+// Random location with the key hash (e.g. for a copy of a node at another location)
+func NewLocation(keyHash uint32) uint64 {
+	return newLocation(keyHash)
+}
+
+// Location of the key determined by the seed and salt
+// (e.g. for copies created again when an operation is resumed)
+func DeriveLocation(key string, seed string, salt uint64) uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(seed))
+	binary.Write(h, binary.LittleEndian, salt)
+	loc := uint64(h.Sum32()) + (uint64(keyHash(key)) << 32)
+	if loc == 0 || loc >= db.DepAuto {
+		loc ^= 2
+	}
 	return loc
 }
 
-func CreateNode(key, value string, dep uint64) *db.Node {
-	// Use random number + key hash
-	loc := uint64(rand.Uint32()) + (uint64(utils.Hash2Uint(utils.Hash([]byte(key)))) << 32)
+func CreateNode(key string, value []byte, dep uint64) *db.Node {
 	return &db.Node{
-		Location: loc,
-		Dep:      dep,
-		Key:      key,
-		Value:    value,
-		Children: nil,
+		Location:  newLocation(keyHash(key)),
+		Dep:       dep,
+		Key:       key,
+		Value:     value,
+		Children:  nil,
+		CreatedAt: time.Now().UnixNano(),
 	}
 }
 
-func (s *Store) GetNode(loc uint64) *Node {
-	memLoc, ok := s.MemLocation[loc]
+// Create a root without key.
+// The location is random so that roots distribute across servers like keys.
+func CreateRoot() *db.Node {
+	return &db.Node{
+		Location:  newLocation(rand.Uint32()),
+		Dep:       math.MaxUint64,
+		Keyless:   true,
+		CreatedAt: time.Now().UnixNano(),
+	}
+}
+
+// Convert to db.Node without copying the value
+func (n *Node) ToProto() *db.Node {
+	return &db.Node{
+		Location:  n.Location,
+		Dep:       n.Dep,
+		Key:       n.Key,
+		Value:     n.Value,
+		Children:  n.Children,
+		CreatedAt: n.CreatedAt,
+		Metadata:  n.Metadata,
+		ExpiresAt: n.ExpiresAt,
+		Keyless:   n.Keyless,
+		Type:      n.Type,
+		Checksum:  n.Checksum,
+
+		ContentType: n.ContentType,
+
+		ChildrenVersion: n.ChildrenVersion,
+	}
+}
+
+// Copy of the node with its spilled value read back
+func (s *Store) Load(node *Node) (*Node, error) {
+	if node == nil || len(node.Blob) == 0 {
+		return node, nil
+	}
+	value, err := s.Blobs.Get(node.Blob)
+	if err != nil {
+		return nil, err
+	}
+	loaded := *node
+	loaded.Value = value
+	loaded.Blob = ""
+	loaded.BlobSize = 0
+	return &loaded, nil
+}
+
+// Remove blobs no longer referenced by local nodes.
+// Returns the number of blobs removed and the bytes freed.
+func (s *Store) CompactBlobs() (int64, int64, error) {
+	if s.Blobs == nil {
+		return 0, 0, nil
+	}
+	// No node can be written meanwhile
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	referenced := make(map[string]bool)
+	err := s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+		if len(node.Blob) > 0 {
+			referenced[node.Blob] = true
+		}
+		return true
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return s.Blobs.Collect(referenced)
+}
+
+// Remove records of the backend log superseded by later ones.
+// Returns the number of records removed.
+func (s *Store) CompactLog() (int64, error) {
+	compactor, ok := s.Backend.(LogCompactor)
 	if !ok {
+		return 0, nil
+	}
+	return compactor.CompactLog()
+}
+
+// Replace the value of a stored node, leaving the rest of the node as it is
+// (emergency correction: nodes are otherwise immutable).
+// The blob of the old value is removed unless another node references it.
+// Returns the updated node and whether the blob was removed.
+func (s *Store) Redact(location uint64, value []byte) (*Node, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node, err := s.Backend.GetNode(location)
+	if err != nil {
+		return nil, false, err
+	}
+	if node == nil || location == 0 {
+		return nil, false, &dberrors.LocationNotFoundError{Location: location}
+	}
+	blob := node.Blob
+	updated := *node
+	updated.Value = value
+	updated.Blob = ""
+	updated.BlobSize = 0
+	updated.Checksum = 0
+	if err := s.putNode(updated); err != nil {
+		return nil, false, err
+	}
+	stored, err := s.Backend.GetNode(location)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(blob) == 0 || stored.Blob == blob {
+		return stored, false, nil
+	}
+
+	referenced := false
+	err = s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+		referenced = node.Blob == blob
+		return !referenced
+	})
+	if err != nil || referenced {
+		return stored, false, err
+	}
+	return stored, true, s.Blobs.Remove(blob)
+}
+
+// Returns nil if the node is not stored or cannot be read
+func (s *Store) GetNode(loc uint64) *Node {
+	node, err := s.Backend.GetNode(loc)
+	if err != nil {
 		return nil
 	}
-	return &s.Nodes[memLoc]
+	return node
 }
 
-func (s *Store) AddNode(node *db.Node) {
-	s.newNode(node.Location, node.Dep, node.Key, node.Value)
+// Add a node created elsewhere (e.g. by a merge function).
+// Returns false if the same node is already stored (e.g. a retry).
+func (s *Store) AddNode(node *db.Node) (bool, error) {
+	if err := CheckLocation(node.Location, node.Key); err != nil {
+		return false, err
+	}
+	return s.ImportNode(node)
+}
+
+// Add a node without checking its location (e.g. transferred by a split).
+// Older servers send roots and buckets with an empty key and no flag.
+// A different node at the location is never replaced (NodeExistsError),
+// and a node not matching its checksum is rejected (ChecksumMismatchError).
+func (s *Store) ImportNode(node *db.Node) (bool, error) {
+	if err := s.VerifyChecksum(node); err != nil {
+		return false, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, err := s.Backend.GetNode(node.Location)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		if existing, err = s.Load(existing); err != nil {
+			return false, err
+		}
+		if sameNode(existing, node) {
+			return false, nil
+		}
+		return false, &dberrors.NodeExistsError{
+			Location: node.Location,
+			Existing: existing.ToProto(),
+			Incoming: node,
+		}
+	}
+	return true, s.putNode(Node{
+		Location:  node.Location,
+		Dep:       node.Dep,
+		Key:       node.Key,
+		Keyless:   node.Keyless || len(node.Key) == 0,
+		Value:     node.Value,
+		Children:  node.Children,
+		CreatedAt: node.CreatedAt,
+		Metadata:  node.Metadata,
+		ExpiresAt: node.ExpiresAt,
+		Type:      node.Type,
+		Checksum:  node.Checksum,
+
+		ContentType:     node.ContentType,
+		ChildrenVersion: node.ChildrenVersion,
+	})
+}
+
+// Put a node read from the log of a primary, replacing the stored one (see LogReader)
+func (s *Store) Replicate(node *db.Node) error {
+	if err := s.VerifyChecksum(node); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.putNode(Node{
+		Location:  node.Location,
+		Dep:       node.Dep,
+		Key:       node.Key,
+		Keyless:   node.Keyless || len(node.Key) == 0,
+		Value:     node.Value,
+		Children:  node.Children,
+		CreatedAt: node.CreatedAt,
+		Metadata:  node.Metadata,
+		ExpiresAt: node.ExpiresAt,
+		Type:      node.Type,
+		Checksum:  node.Checksum,
+
+		ContentType:     node.ContentType,
+		ChildrenVersion: node.ChildrenVersion,
+	})
+}
+
+// Whether the stored node has the content of the node (ignoring ChildrenVersion)
+func sameNode(stored *Node, node *db.Node) bool {
+	if stored.Dep != node.Dep || stored.Key != node.Key || stored.Keyless != (node.Keyless || len(node.Key) == 0) ||
+		stored.CreatedAt != node.CreatedAt || stored.ExpiresAt != node.ExpiresAt || stored.ContentType != node.ContentType ||
+		stored.Type != node.Type ||
+		!bytes.Equal(stored.Value, node.Value) || len(stored.Children) != len(node.Children) || len(stored.Metadata) != len(node.Metadata) {
+		return false
+	}
+	for i, child := range stored.Children {
+		if node.Children[i] != child {
+			return false
+		}
+	}
+	for k, v := range stored.Metadata {
+		if value, ok := node.Metadata[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *Store) RemoveNode(location uint64) {
-	for i, node := range s.Nodes {
-		if node.Location == location {
-			s.Nodes[i] = Node{
-				Key: "",
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removeNode(location)
+}
+
+// Remove nodes under one lock and return the number removed
+func (s *Store) RemoveNodes(locations []uint64) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	removed := 0
+	for _, location := range locations {
+		if s.removeNode(location) {
+			removed += 1
+		}
+	}
+	return removed
+}
+
+// Must be called with the lock held
+func (s *Store) removeNode(location uint64) bool {
+	// Never remove the root
+	if location == 0 {
+		return false
+	}
+	node, err := s.Backend.GetNode(location)
+	if err != nil || node == nil {
+		return false
+	}
+	if err := s.Backend.DeleteNode(location); err != nil {
+		return false
+	}
+	s.account(node, nil)
+	s.unindexKey(node)
+	s.deleteLocation(location)
+	if node.Dep == math.MaxUint64 {
+		s.Roots -= 1
+	}
+	delete(s.depths, location)
+	delete(s.merges, location)
+	s.Size -= 1
+	return true
+}
+
+func (s *Store) CachedDepth(location uint64) (int64, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	depth, ok := s.depths[location]
+	return depth, ok
+}
+
+func (s *Store) CacheDepth(location uint64, depth int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if node, _ := s.Backend.GetNode(location); node != nil {
+		s.depths[location] = depth
+	}
+}
+
+// Recount live nodes from the backend and check them against Size
+func (s *Store) Verify() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	count, keyed := 0, 0
+	var bytes int64
+	s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
+		if node.Location != 0 {
+			count += 1
+			bytes += valueSize(node)
+			if !node.Keyless {
+				keyed += 1
 			}
-			s.Size -= 1
-			return
 		}
+		return true
+	})
+	if count != s.Size {
+		return fmt.Errorf("Size is %d but %d nodes are live", s.Size, count)
 	}
+	if bytes != s.valueBytes {
+		return fmt.Errorf("Value bytes are %d but values of live nodes take %d", s.valueBytes, bytes)
+	}
+
+	indexed := 0
+	for key, locations := range s.KeyIndex {
+		for _, loc := range locations {
+			node, _ := s.Backend.GetNode(loc)
+			if node == nil || !node.Defines(key) {
+				return fmt.Errorf("Key %s indexes invalid location %x", key, loc)
+			}
+		}
+		indexed += len(locations)
+	}
+	if indexed != keyed {
+		return fmt.Errorf("Key index has %d locations but %d nodes with a key are live", indexed, keyed)
+	}
+	if len(s.locations) != s.Size {
+		return fmt.Errorf("Range index has %d locations but %d nodes are live", len(s.locations), s.Size)
+	}
+	return nil
 }
 
 func (s *Store) Print() {
 	fmt.Println("Nodes:")
-	for _, node := range s.Nodes {
+	s.Backend.IterateRange(0, math.MaxUint32, func(node *Node) bool {
 		if len(node.Key) > 0 {
 			fmt.Printf("%s (Dep: %x, Chilren: %s)\n", node.Key, node.Dep, utils.ToString(node.Children))
 		}
-	}
+		return true
+	})
 }
@@ -0,0 +1,695 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/DCsunset/openwhisk-grpc/db"
+	"github.com/DCsunset/openwhisk-grpc/dberrors"
+	"github.com/DCsunset/openwhisk-grpc/utils"
+)
+
+func newTestStore(t testing.TB) *Store {
+	t.Helper()
+	s := &Store{}
+	s.Init()
+	return s
+}
+
+func mustSet(t testing.TB, s *Store, key string, value string, dep uint64) uint64 {
+	t.Helper()
+	loc, err := s.Set(key, []byte(value), dep, nil, 0, "", db.NodeType_REGULAR)
+	if err != nil {
+		t.Fatalf("Set(%q) failed: %v", key, err)
+	}
+	return loc
+}
+
+func mustVerify(t testing.TB, s *Store) {
+	t.Helper()
+	if err := s.Verify(); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		value     []byte
+		dep       uint64
+		expiresAt int64
+	}{
+		{"root", "a", []byte("1"), math.MaxUint64, 0},
+		{"global root", "a", []byte("1"), 0, 0},
+		{"empty value", "b", nil, math.MaxUint64, 0},
+		{"expiring", "c", []byte("3"), math.MaxUint64, math.MaxInt64},
+		{"large value", "d", make([]byte, 1<<20), math.MaxUint64, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			loc, err := s.Set(tt.key, tt.value, tt.dep, map[string]string{"m": "1"}, tt.expiresAt, "text/plain", db.NodeType_REGULAR)
+			if err != nil {
+				t.Fatalf("Set failed: %v", err)
+			}
+			if err := CheckLocation(loc, tt.key); err != nil {
+				t.Errorf("Location %x does not match the key: %v", loc, err)
+			}
+			node := s.GetNode(loc)
+			if node == nil {
+				t.Fatalf("Node at %x not found", loc)
+			}
+			if node.Dep != tt.dep || node.Key != tt.key || node.ExpiresAt != tt.expiresAt || node.ContentType != "text/plain" {
+				t.Errorf("Stored node %+v does not match the request", node)
+			}
+			if node.Checksum != Checksum(tt.key, tt.value, tt.dep, db.NodeType_REGULAR, map[string]string{"m": "1"}) {
+				t.Errorf("Checksum is %08x", node.Checksum)
+			}
+			if s.Size != 1 {
+				t.Errorf("Size is %d, expected 1", s.Size)
+			}
+			mustVerify(t, s)
+		})
+	}
+}
+
+// Concurrent sets of the same key must never share a location
+func TestSetConcurrent(t *testing.T) {
+	s := newTestStore(t)
+	const writers, sets = 16, 10
+
+	var wg sync.WaitGroup
+	locations := make(chan uint64, writers*sets)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < sets; j++ {
+				loc, err := s.Set("k", []byte("v"), math.MaxUint64, nil, 0, "", db.NodeType_REGULAR)
+				if err != nil {
+					t.Errorf("Set failed: %v", err)
+					return
+				}
+				locations <- loc
+			}
+		}()
+	}
+	wg.Wait()
+	close(locations)
+
+	seen := make(map[uint64]bool)
+	for loc := range locations {
+		if seen[loc] {
+			t.Errorf("Location %x returned twice", loc)
+		}
+		seen[loc] = true
+	}
+	if s.Size != writers*sets {
+		t.Errorf("Size is %d, expected %d", s.Size, writers*sets)
+	}
+	if n := len(s.LocationsForKey("k")); n != writers*sets {
+		t.Errorf("%d locations indexed, expected %d", n, writers*sets)
+	}
+	mustVerify(t, s)
+}
+
+func TestGet(t *testing.T) {
+	s := newTestStore(t)
+	root := mustSet(t, s, "a", "1", math.MaxUint64)
+	b := mustSet(t, s, "b", "2", root)
+	a := mustSet(t, s, "a", "3", b)
+	expired, err := s.Set("b", []byte("4"), a, nil, 1, "", db.NodeType_REGULAR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		location uint64
+		value    string
+		err      interface{}
+	}{
+		{"at node", "a", root, "1", nil},
+		{"from child", "a", b, "1", nil},
+		{"nearest", "a", a, "3", nil},
+		{"other key", "b", a, "2", nil},
+		{"expired skipped", "b", expired, "2", nil},
+		{"missing key", "c", a, "", &dberrors.KeyNotFoundError{}},
+		{"missing location", "a", 12345, "", &dberrors.LocationNotFoundError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := s.Get(tt.key, tt.location)
+			switch target := tt.err.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("Get failed: %v", err)
+				}
+				if string(value) != tt.value {
+					t.Errorf("Value is %q, expected %q", value, tt.value)
+				}
+			case *dberrors.KeyNotFoundError:
+				if !errors.As(err, &target) {
+					t.Errorf("Error is %v, expected key not found", err)
+				}
+			case *dberrors.LocationNotFoundError:
+				if !errors.As(err, &target) {
+					t.Errorf("Error is %v, expected location not found", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAddChild(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxChildren int
+		existing    int
+		again       bool
+		missing     bool
+		added       bool
+		quota       bool
+	}{
+		{name: "first child", added: true},
+		{name: "more children", existing: 3, added: true},
+		{name: "same child again", existing: 1, again: true},
+		{name: "missing node", missing: true},
+		{name: "under the limit", maxChildren: 2, existing: 1, added: true},
+		{name: "at the limit", maxChildren: 2, existing: 2, quota: true},
+		{name: "retry at the limit", maxChildren: 2, existing: 2, again: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			s.MaxChildren = tt.maxChildren
+			parent := mustSet(t, s, "p", "", math.MaxUint64)
+			var last uint64
+			for i := 0; i < tt.existing; i++ {
+				last = uint64(100 + i)
+				if _, _, err := s.AddChild(parent, last); err != nil {
+					t.Fatal(err)
+				}
+			}
+			location, child := parent, uint64(99)
+			if tt.missing {
+				location = 12345
+			}
+			if tt.again {
+				child = last
+			}
+
+			node, added, err := s.AddChild(location, child)
+			if tt.quota {
+				var quota *dberrors.QuotaExceededError
+				if !errors.As(err, &quota) {
+					t.Fatalf("Error is %v, expected quota exceeded", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddChild failed: %v", err)
+			}
+			if tt.missing {
+				if node != nil || added {
+					t.Errorf("Missing node returned %+v, %v", node, added)
+				}
+				return
+			}
+			if added != tt.added {
+				t.Errorf("Added is %v, expected %v", added, tt.added)
+			}
+			expected := tt.existing
+			if tt.added {
+				expected += 1
+			}
+			if len(node.Children) != expected || len(s.GetNode(parent).Children) != expected {
+				t.Errorf("%d children, expected %d", len(node.Children), expected)
+			}
+			if tt.added && s.GetNode(parent).ChildrenVersion != uint64(expected) {
+				t.Errorf("ChildrenVersion is %d, expected %d", s.GetNode(parent).ChildrenVersion, expected)
+			}
+		})
+	}
+}
+
+// Nodes returned by GetNode stay valid while later writes grow the store,
+// and changes made through store methods are visible to later reads
+func TestGetNodeStable(t *testing.T) {
+	s := newTestStore(t)
+	parent := mustSet(t, s, "p", "parent", math.MaxUint64)
+	held := s.GetNode(parent)
+
+	// Writers growing the store while children are added to the parent
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				if _, err := s.Set(fmt.Sprintf("w%d/%d", w, i), []byte("v"), math.MaxUint64, nil, 0, "", db.NodeType_REGULAR); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(w)
+	}
+	var children []uint64
+	for i := 0; i < 100; i++ {
+		child := uint64(1000 + i)
+		if _, _, err := s.AddChild(parent, child); err != nil {
+			t.Fatal(err)
+		}
+		children = append(children, child)
+		if node := s.GetNode(parent); len(node.Children) != len(children) {
+			t.Fatalf("Parent has %d children after adding %d", len(node.Children), len(children))
+		}
+	}
+	wg.Wait()
+
+	if held.Location != parent || held.Key != "p" || string(held.Value) != "parent" || len(held.Children) != 0 {
+		t.Errorf("Node held before the writes changed to %+v", held)
+	}
+	node := s.GetNode(parent)
+	if len(node.Children) != len(children) || node.ChildrenVersion != uint64(len(children)) {
+		t.Fatalf("Parent has children %v (version %d), expected %d", node.Children, node.ChildrenVersion, len(children))
+	}
+	for i, child := range children {
+		if node.Children[i] != child {
+			t.Errorf("Child %d is %x, expected %x", i, node.Children[i], child)
+		}
+	}
+	if err := s.Expire(parent, 42); err != nil {
+		t.Fatal(err)
+	}
+	if s.GetNode(parent).ExpiresAt != 42 {
+		t.Errorf("Expiry of the parent is not visible")
+	}
+	mustVerify(t, s)
+}
+
+func TestRemoveNode(t *testing.T) {
+	tests := []struct {
+		name     string
+		location func(s *Store) uint64
+		removed  bool
+	}{
+		{"node", func(s *Store) uint64 { return s.LocationsForKey("a")[0] }, true},
+		{"global root", func(s *Store) uint64 { return 0 }, false},
+		{"missing", func(s *Store) uint64 { return 12345 }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			mustSet(t, s, "a", "1", math.MaxUint64)
+			mustSet(t, s, "b", "2", math.MaxUint64)
+			location := tt.location(s)
+
+			s.RemoveNode(location)
+			expected := 2
+			if tt.removed {
+				expected = 1
+				if s.GetNode(location) != nil {
+					t.Errorf("Node %x is still stored", location)
+				}
+				if len(s.LocationsForKey("a")) != 0 {
+					t.Errorf("Key is still indexed")
+				}
+			}
+			if s.Size != expected {
+				t.Errorf("Size is %d, expected %d", s.Size, expected)
+			}
+			if s.GetNode(0) == nil {
+				t.Errorf("Global root was removed")
+			}
+			mustVerify(t, s)
+		})
+	}
+}
+
+func TestAddNode(t *testing.T) {
+	stored := func() *db.Node {
+		return Seal(&db.Node{Location: newLocation(keyHash("a")), Dep: math.MaxUint64, Key: "a", Value: []byte("1"), CreatedAt: 1})
+	}
+	tests := []struct {
+		name   string
+		node   func(existing *db.Node) *db.Node
+		added  bool
+		target error
+	}{
+		{"new node", func(*db.Node) *db.Node { return stored() }, true, nil},
+		{"retry", func(existing *db.Node) *db.Node { return existing }, false, nil},
+		{"without checksum", func(*db.Node) *db.Node {
+			return &db.Node{Location: newLocation(keyHash("a")), Dep: math.MaxUint64, Key: "a", Value: []byte("2")}
+		}, true, nil},
+		{"different node", func(existing *db.Node) *db.Node {
+			return Seal(&db.Node{Location: existing.Location, Dep: math.MaxUint64, Key: "a", Value: []byte("2")})
+		}, false, &dberrors.NodeExistsError{}},
+		{"wrong location", func(*db.Node) *db.Node {
+			return Seal(&db.Node{Location: newLocation(keyHash("a") + 1), Dep: math.MaxUint64, Key: "a"})
+		}, false, &dberrors.InvalidLocationError{}},
+		{"corrupted", func(*db.Node) *db.Node {
+			node := stored()
+			node.Value = []byte("2")
+			return node
+		}, false, &dberrors.ChecksumMismatchError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			existing := stored()
+			if added, err := s.AddNode(existing); err != nil || !added {
+				t.Fatalf("AddNode returned %v, %v", added, err)
+			}
+
+			node := tt.node(existing)
+			added, err := s.AddNode(node)
+			if added != tt.added {
+				t.Errorf("Added is %v, expected %v", added, tt.added)
+			}
+			if tt.target == nil && err != nil {
+				t.Fatalf("AddNode failed: %v", err)
+			}
+			if tt.target != nil && fmt.Sprintf("%T", err) != fmt.Sprintf("%T", tt.target) {
+				t.Errorf("Error is %v (%T), expected %T", err, err, tt.target)
+			}
+			expected := 1
+			if tt.added {
+				expected = 2
+			}
+			if s.Size != expected {
+				t.Errorf("Size is %d, expected %d", s.Size, expected)
+			}
+			mustVerify(t, s)
+		})
+	}
+}
+
+// Replicas of a range differing only in the content of a node have different digests
+func TestRangeDigestContent(t *testing.T) {
+	node := func(value string) *db.Node {
+		return &db.Node{Location: uint64(keyHash("a"))<<32 | 1, Dep: math.MaxUint64, Key: "a", Value: []byte(value)}
+	}
+	digest := func(node *db.Node) uint64 {
+		s := newTestStore(t)
+		if _, err := s.AddNode(node); err != nil {
+			t.Fatal(err)
+		}
+		count, digest, err := s.RangeDigest(0, math.MaxUint32)
+		if err != nil || count != 1 {
+			t.Fatalf("RangeDigest returned %d nodes, %v", count, err)
+		}
+		return digest
+	}
+	// Stored before checksums and given one by the store
+	intact, replica, corrupted := digest(Seal(node("1"))), digest(node("1")), digest(node("2"))
+	if intact != replica {
+		t.Errorf("Digests of the same node differ: %x != %x", intact, replica)
+	}
+	if intact == corrupted {
+		t.Errorf("Digest does not cover the content of the nodes")
+	}
+}
+
+// Size must not drift when nodes are removed, added again or replaced
+func TestSizeAcrossReAdds(t *testing.T) {
+	s := newTestStore(t)
+	a := mustSet(t, s, "a", "1", math.MaxUint64)
+	b := mustSet(t, s, "a", "2", a)
+	node := s.GetNode(b).ToProto()
+
+	steps := []struct {
+		name string
+		run  func() error
+		size int
+	}{
+		{"remove", func() error { s.RemoveNode(b); return nil }, 1},
+		{"remove again", func() error { s.RemoveNode(b); return nil }, 1},
+		{"add back", func() error { _, err := s.AddNode(node); return err }, 2},
+		{"add retry", func() error { _, err := s.AddNode(node); return err }, 2},
+		{"replicate over", func() error { return s.Replicate(node) }, 2},
+		{"remove both", func() error { s.RemoveNodes([]uint64{a, b, b}); return nil }, 0},
+		{"replicate new", func() error { return s.Replicate(node) }, 1},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s failed: %v", step.name, err)
+		}
+		if s.Size != step.size {
+			t.Errorf("Size is %d after %s, expected %d", s.Size, step.name, step.size)
+		}
+		mustVerify(t, s)
+	}
+}
+
+// Node of the model of a random DAG
+type modelNode struct {
+	key   string
+	value string
+	dep   uint64
+}
+
+type model map[uint64]*modelNode
+
+// Value of the key read from the location following deps in the model
+func (m model) resolve(key string, location uint64) (string, bool) {
+	for location != math.MaxUint64 {
+		node := m[location]
+		if node.key == key {
+			return node.value, true
+		}
+		location = node.dep
+	}
+	return "", false
+}
+
+// Build random DAGs and check reads and the accounting against a model
+func TestRandomDAG(t *testing.T) {
+	keys := []string{"a", "b", "c", "d"}
+	for seed := int64(1); seed <= 5; seed++ {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			r := rand.New(rand.NewSource(seed))
+			s := newTestStore(t)
+			m := make(model)
+			var locations []uint64
+
+			for i := 0; i < 40; i++ {
+				dep := uint64(math.MaxUint64)
+				if len(locations) > 0 && r.Intn(5) > 0 {
+					dep = locations[r.Intn(len(locations))]
+				}
+				key, value := keys[r.Intn(len(keys))], fmt.Sprint(i)
+				loc := mustSet(t, s, key, value, dep)
+				if dep != math.MaxUint64 {
+					if _, _, err := s.AddChild(dep, loc); err != nil {
+						t.Fatal(err)
+					}
+				}
+				m[loc] = &modelNode{key: key, value: value, dep: dep}
+				locations = append(locations, loc)
+			}
+			if s.Size != len(m) {
+				t.Fatalf("Size is %d, expected %d", s.Size, len(m))
+			}
+			mustVerify(t, s)
+
+			for i := 0; i < 20; i++ {
+				loc, key := locations[r.Intn(len(locations))], keys[r.Intn(len(keys))]
+				expected, ok := m.resolve(key, loc)
+				value, err := s.Get(key, loc)
+				if !ok {
+					var notFound *dberrors.KeyNotFoundError
+					if !errors.As(err, &notFound) {
+						t.Errorf("Get(%s, %x) returned %q, %v; expected key not found", key, loc, value, err)
+					}
+					continue
+				}
+				if err != nil || string(value) != expected {
+					t.Errorf("Get(%s, %x) returned %q, %v; expected %q", key, loc, value, err, expected)
+				}
+			}
+
+			// Children of each node are exactly the nodes depending on it
+			for loc := range m {
+				children := make(map[uint64]bool)
+				for _, child := range s.GetNode(loc).Children {
+					children[child] = true
+				}
+				for other, node := range m {
+					if (node.dep == loc) != children[other] {
+						t.Errorf("Node %x depends on %x: %v, is its child: %v", other, loc, node.dep == loc, children[other])
+					}
+				}
+			}
+
+			// Remove leaves in random order
+			for len(m) > 0 {
+				var leaves []uint64
+				for _, loc := range locations {
+					if m[loc] != nil && len(s.GetNode(loc).Children) == 0 {
+						leaves = append(leaves, loc)
+					}
+				}
+				leaf := leaves[r.Intn(len(leaves))]
+				if dep := m[leaf].dep; dep != math.MaxUint64 {
+					if _, err := s.RemoveChild(dep, leaf); err != nil {
+						t.Fatal(err)
+					}
+				}
+				s.RemoveNode(leaf)
+				delete(m, leaf)
+				if s.Size != len(m) {
+					t.Fatalf("Size is %d, expected %d", s.Size, len(m))
+				}
+				mustVerify(t, s)
+			}
+			if len(s.KeyIndex) != 0 {
+				t.Errorf("Key index is not empty: %v", s.KeyIndex)
+			}
+		})
+	}
+}
+
+func FuzzNewLocation(f *testing.F) {
+	for _, hash := range []uint32{0, 1, math.MaxUint32, keyHash("a")} {
+		f.Add(hash)
+	}
+	f.Fuzz(func(t *testing.T, hash uint32) {
+		loc := newLocation(hash)
+		if utils.KeyHash(loc) != hash {
+			t.Errorf("Location %x has hash %x, expected %x", loc, utils.KeyHash(loc), hash)
+		}
+		if loc == 0 || loc == db.DepAuto || loc == math.MaxUint64 {
+			t.Errorf("Reserved location %x", loc)
+		}
+	})
+}
+
+func FuzzCheckLocation(f *testing.F) {
+	f.Add("a", uint32(0), uint32(1))
+	f.Add("", uint32(5), uint32(0))
+	f.Add("user:1/profile", uint32(math.MaxUint32), uint32(math.MaxUint32))
+	f.Fuzz(func(t *testing.T, key string, random uint32, other uint32) {
+		loc := uint64(random) + uint64(keyHash(key))<<32
+		if err := CheckLocation(loc, key); err != nil {
+			t.Errorf("Location %x of %q rejected: %v", loc, key, err)
+		}
+		legacy := uint64(random) + uint64(utils.Hash2Uint(utils.Hash([]byte(key))))<<32
+		if err := CheckLocation(legacy, key); err != nil {
+			t.Errorf("Legacy location %x of %q rejected: %v", legacy, key, err)
+		}
+
+		wrong := uint64(random) + uint64(other)<<32
+		accepted := len(key) == 0 || other == keyHash(key) || other == utils.Hash2Uint(utils.Hash([]byte(key)))
+		err := CheckLocation(wrong, key)
+		if accepted != (err == nil) {
+			t.Errorf("CheckLocation(%x, %q) returned %v", wrong, key, err)
+		}
+		var invalid *dberrors.InvalidLocationError
+		if err != nil && !errors.As(err, &invalid) {
+			t.Errorf("Error is %T, expected an invalid location", err)
+		}
+	})
+}
+
+func FuzzChecksum(f *testing.F) {
+	f.Add("a", []byte("1"), uint64(math.MaxUint64), "m", "v")
+	f.Add("", []byte(nil), uint64(0), "", "")
+	f.Fuzz(func(t *testing.T, key string, value []byte, dep uint64, mk string, mv string) {
+		metadata := map[string]string{mk: mv}
+		sum := Checksum(key, value, dep, db.NodeType_REGULAR, metadata)
+		if sum == 0 {
+			t.Fatalf("Checksum is 0 (reserved for nodes without one)")
+		}
+		if again := Checksum(key, append([]byte(nil), value...), dep, db.NodeType_REGULAR, map[string]string{mk: mv}); again != sum {
+			t.Errorf("Checksum is not deterministic: %08x != %08x", sum, again)
+		}
+		// A single flipped bit is always detected by CRC32C
+		if flipped := Checksum(key, value, dep^1, db.NodeType_REGULAR, metadata); flipped == sum {
+			t.Errorf("Checksum does not cover the dep")
+		}
+		node := Seal(&db.Node{Key: key, Value: value, Dep: dep, Metadata: metadata})
+		if err := (&Store{}).VerifyChecksum(node); err != nil {
+			t.Errorf("Sealed node fails verification: %v", err)
+		}
+	})
+}
+
+// Runs of one key collapse into their last node, and runs mixing keys are refused
+func TestCompressRun(t *testing.T) {
+	s := newTestStore(t)
+	root := mustSet(t, s, "r", "0", math.MaxUint64)
+	var run []uint64
+	dep := root
+	for i, key := range []string{"a", "a", "b", "a"} {
+		dep = mustSet(t, s, key, fmt.Sprint(i), dep)
+		run = append(run, dep)
+	}
+	for i, location := range run {
+		parent := root
+		if i > 0 {
+			parent = run[i-1]
+		}
+		if _, _, err := s.AddChild(parent, location); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.CompressRun(run); err == nil {
+		t.Fatal("Run with another key compressed")
+	}
+	if err := s.CompressRun(run[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if node := s.GetNode(run[1]); node == nil || node.Dep != root || node.CompressedSince == 0 {
+		t.Errorf("Last node of the run is %v", node)
+	}
+	if s.GetNode(run[0]) != nil {
+		t.Errorf("First node of the run was kept")
+	}
+	for key, expected := range map[string]string{"a": "3", "b": "2", "r": "0"} {
+		if value, err := s.Get(key, run[3]); err != nil || string(value) != expected {
+			t.Errorf("Get(%s) returned %q, %v", key, value, err)
+		}
+	}
+}
+
+// Locations carry the key hash in their upper bits, checked by CheckLocation,
+// and never take the values reserved for the global root, DepAuto and no dep
+func TestLocationFormat(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("k%d", i)
+		hash := keyHash(key)
+		for _, location := range []uint64{
+			CreateNode(key, nil, math.MaxUint64).Location,
+			NewLocation(hash),
+			DeriveLocation(key, "seed", uint64(i)),
+		} {
+			if utils.KeyHash(location) != hash {
+				t.Fatalf("Location %x of %s has hash %x, expected %x", location, key, utils.KeyHash(location), hash)
+			}
+			if err := CheckLocation(location, key); err != nil {
+				t.Fatal(err)
+			}
+			if err := CheckLocation(location, key+"-other"); err == nil && keyHash(key+"-other") != hash {
+				t.Fatalf("Location %x of %s accepted for another key", location, key)
+			}
+		}
+		if DeriveLocation(key, "seed", uint64(i)) != DeriveLocation(key, "seed", uint64(i)) {
+			t.Fatalf("Derived location of %s changed", key)
+		}
+	}
+	for _, hash := range []uint32{0, math.MaxUint32} {
+		for i := 0; i < 1000; i++ {
+			if location := NewLocation(hash); location == 0 || location == db.DepAuto || location == math.MaxUint64 {
+				t.Fatalf("Reserved location %x created", location)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Max spans sent in one request
+const otlpBatchSize = 512
+
+// Exports spans in batches to an OTLP/HTTP collector (JSON encoding)
+type OTLPExporter struct {
+	// Base URL of the collector (e.g. http://localhost:4318)
+	Endpoint string
+	Service  string
+
+	lock  sync.Mutex
+	spans []*Span
+}
+
+func NewOTLPExporter(endpoint, service string) *OTLPExporter {
+	e := &OTLPExporter{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Service:  service,
+	}
+	go func() {
+		for range time.Tick(time.Second) {
+			e.Flush()
+		}
+	}()
+	return e
+}
+
+func (e *OTLPExporter) Export(span *Span) {
+	e.lock.Lock()
+	e.spans = append(e.spans, span)
+	full := len(e.spans) >= otlpBatchSize
+	e.lock.Unlock()
+	if full {
+		go e.Flush()
+	}
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+func attributes(m map[string]string) []otlpAttribute {
+	var result []otlpAttribute
+	for key, value := range m {
+		result = append(result, otlpAttribute{key, otlpValue{value}})
+	}
+	return result
+}
+
+func toOTLP(span *Span) otlpSpan {
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	result := otlpSpan{
+		TraceId:           hex.EncodeToString(span.Context.TraceID[:]),
+		SpanId:            hex.EncodeToString(span.Context.SpanID[:]),
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Attributes:        attributes(span.Attributes),
+	}
+	if span.Parent != [8]byte{} {
+		result.ParentSpanId = hex.EncodeToString(span.Parent[:])
+	}
+	if len(span.Error) > 0 {
+		// STATUS_CODE_ERROR
+		result.Status = otlpStatus{Code: 2, Message: span.Error}
+	}
+	return result
+}
+
+// Send pending spans to the collector
+func (e *OTLPExporter) Flush() {
+	e.lock.Lock()
+	spans := e.spans
+	e.spans = nil
+	e.lock.Unlock()
+
+	for len(spans) > 0 {
+		n := len(spans)
+		if n > otlpBatchSize {
+			n = otlpBatchSize
+		}
+		if err := e.send(spans[:n]); err != nil {
+			log.Printf("Fail to export %d spans: %v", n, err)
+		}
+		spans = spans[n:]
+	}
+}
+
+func (e *OTLPExporter) send(spans []*Span) error {
+	var converted []otlpSpan
+	for _, span := range spans {
+		converted = append(converted, toOTLP(span))
+	}
+	body := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": attributes(map[string]string{"service.name": e.Service}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "openwhisk-grpc"},
+						"spans": converted,
+					},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(e.Endpoint+"/v1/traces", "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return &http.ProtocolError{ErrorString: resp.Status}
+	}
+	return nil
+}
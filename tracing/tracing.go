@@ -0,0 +1,220 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// W3C trace context header (also used as grpc metadata)
+const TraceparentKey = "traceparent"
+
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+}
+
+func (c SpanContext) Valid() bool {
+	return c.TraceID != [16]byte{} && c.SpanID != [8]byte{}
+}
+
+// Format as a W3C traceparent (always sampled)
+func (c SpanContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(c.TraceID[:]), hex.EncodeToString(c.SpanID[:]))
+}
+
+func ParseTraceparent(value string) (SpanContext, error) {
+	var c SpanContext
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return c, fmt.Errorf("Invalid traceparent %s", value)
+	}
+	if _, err := hex.Decode(c.TraceID[:], []byte(parts[1])); err != nil {
+		return c, err
+	}
+	if _, err := hex.Decode(c.SpanID[:], []byte(parts[2])); err != nil {
+		return c, err
+	}
+	if !c.Valid() {
+		return c, fmt.Errorf("Invalid traceparent %s", value)
+	}
+	return c, nil
+}
+
+// Values of the OTLP span kind
+type SpanKind int
+
+const (
+	Internal SpanKind = 1
+	Server   SpanKind = 2
+	Client   SpanKind = 3
+)
+
+type Span struct {
+	Name    string
+	Context SpanContext
+	// Zero for root spans
+	Parent     [8]byte
+	Kind       SpanKind
+	Start      time.Time
+	End        time.Time
+	Attributes map[string]string
+	Error      string
+
+	lock sync.Mutex
+}
+
+// Methods of Span are no-ops on nil (when tracing is disabled)
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Attributes[key] = value
+}
+
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Error = err.Error()
+}
+
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	s.End = time.Now()
+	s.lock.Unlock()
+	if e := getExporter(); e != nil {
+		e.Export(s)
+	}
+}
+
+// Receives finished spans
+type Exporter interface {
+	Export(span *Span)
+}
+
+var exporter struct {
+	lock sync.RWMutex
+	e    Exporter
+}
+
+// Enable tracing with the exporter (nil disables it)
+func SetExporter(e Exporter) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	exporter.e = e
+}
+
+func getExporter() Exporter {
+	exporter.lock.RLock()
+	defer exporter.lock.RUnlock()
+	return exporter.e
+}
+
+type spanKey struct{}
+
+// Current span of the context (nil if none)
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Traceparent of the current span (empty if none)
+func Traceparent(ctx context.Context) string {
+	if span := FromContext(ctx); span != nil {
+		return span.Context.Traceparent()
+	}
+	return ""
+}
+
+// Trace id of the current span, or of the caller if tracing is disabled (empty if none)
+func TraceID(ctx context.Context) string {
+	if span := FromContext(ctx); span != nil {
+		return hex.EncodeToString(span.Context.TraceID[:])
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TraceparentKey); len(values) > 0 {
+			if parent, err := ParseTraceparent(values[0]); err == nil {
+				return hex.EncodeToString(parent.TraceID[:])
+			}
+		}
+	}
+	return ""
+}
+
+// Start a child span of the current span (or a root span).
+// Returns ctx and a nil span if tracing is disabled.
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	var parent SpanContext
+	if span := FromContext(ctx); span != nil {
+		parent = span.Context
+	}
+	return start(ctx, name, parent, Internal)
+}
+
+func start(ctx context.Context, name string, parent SpanContext, kind SpanKind) (context.Context, *Span) {
+	if getExporter() == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		Name:       name,
+		Kind:       kind,
+		Start:      time.Now(),
+		Attributes: make(map[string]string),
+	}
+	if parent.Valid() {
+		span.Context.TraceID = parent.TraceID
+		span.Parent = parent.SpanID
+	} else {
+		rand.Read(span.Context.TraceID[:])
+	}
+	rand.Read(span.Context.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Start a span per request, continuing the trace of the caller
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var parent SpanContext
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(TraceparentKey); len(values) > 0 {
+			parent, _ = ParseTraceparent(values[0])
+		}
+	}
+	ctx, span := start(ctx, info.FullMethod, parent, Server)
+	resp, err := handler(ctx, req)
+	span.SetError(err)
+	span.Finish()
+	return resp, err
+}
+
+// Start a span per call and pass its context to the server
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var parent SpanContext
+	if span := FromContext(ctx); span != nil {
+		parent = span.Context
+	}
+	ctx, span := start(ctx, method, parent, Client)
+	if span == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	span.SetAttribute("net.peer.name", cc.Target())
+	ctx = metadata.AppendToOutgoingContext(ctx, TraceparentKey, span.Context.Traceparent())
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	span.SetError(err)
+	span.Finish()
+	return err
+}
@@ -8,9 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 func MakeRange(min, max int64) []int64 {
@@ -57,26 +57,90 @@ func KeyHash(location uint64) uint32 {
 const APIHOST = "aqua02:31001"
 const USER = "23bc46b1-71f6-4ed5-8c54-816aa4f8c502"
 const PASS = "123zO3xZCLrMN6v2BKK1dXYFpXlPkccOFqm12CdAsMgRU4VrNZ9lyGVCGuMDGIwP"
+const NAMESPACE = "guest"
 
-func CallAction(action string, params []byte) []byte {
+type ActionResult struct {
+	Body []byte
+	// Activation ID assigned by OpenWhisk
+	ActivationId string
+	Duration     time.Duration
+}
+
+// Invoke actions (can be replaced for testing)
+type Invoker interface {
+	CallAction(action string, params []byte) (*ActionResult, error)
+}
+
+// Check actions without invoking them (can be replaced for testing)
+type ActionChecker interface {
+	// Returns an error if OpenWhisk cannot tell
+	ActionExists(action string) (bool, error)
+}
+
+type OpenWhiskInvoker struct{}
+
+func (OpenWhiskInvoker) CallAction(action string, params []byte) (*ActionResult, error) {
+	return CallAction(action, params)
+}
+
+func (OpenWhiskInvoker) ActionExists(action string) (bool, error) {
+	return ActionExists(action)
+}
+
+// Get the metadata of the action (without its code)
+func ActionExists(action string) (bool, error) {
+	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	url := fmt.Sprintf("https://%s/api/v1/namespaces/%s/actions/%s?code=false", APIHOST, NAMESPACE, action)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.SetBasicAuth(USER, PASS)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("Fail to get action: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("Fail to get action: OpenWhisk answered %s", resp.Status)
+	}
+}
+
+func CallAction(action string, params []byte) (*ActionResult, error) {
 	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	url := fmt.Sprintf("https://%s/api/v1/namespaces/guest/actions/%s?blocking=true&result=true", APIHOST, action)
+	url := fmt.Sprintf("https://%s/api/v1/namespaces/%s/actions/%s?blocking=true&result=true", APIHOST, NAMESPACE, action)
 
 	client := &http.Client{}
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer(params))
+	if err != nil {
+		return nil, err
+	}
 	req.SetBasicAuth(USER, PASS)
 	req.Header.Add("Content-Type", "application/json")
 
+	start := time.Now()
 	resp, err := client.Do(req)
-
 	if err != nil {
-		log.Fatalf("Fail to invoke action: %v", err)
+		return nil, fmt.Errorf("Fail to invoke action: %v", err)
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	return bodyBytes
+	return &ActionResult{
+		Body:         bodyBytes,
+		ActivationId: resp.Header.Get("X-Openwhisk-Activation-Id"),
+		Duration:     time.Since(start),
+	}, nil
 }